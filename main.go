@@ -4,10 +4,15 @@ import (
 	"github.com/fibercrypto/fibercryptowallet/src/params"
 	"os"
 
+	_ "github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin"
+	_ "github.com/fibercrypto/fibercryptowallet/src/coin/ethereum"
+	_ "github.com/fibercrypto/fibercryptowallet/src/coin/external"
 	_ "github.com/fibercrypto/fibercryptowallet/src/coin/skycoin"
 	_ "github.com/fibercrypto/fibercryptowallet/src/models"
 	_ "github.com/fibercrypto/fibercryptowallet/src/models/addressBook"
+	_ "github.com/fibercrypto/fibercryptowallet/src/models/explorer"
 	_ "github.com/fibercrypto/fibercryptowallet/src/models/history"
+	_ "github.com/fibercrypto/fibercryptowallet/src/models/inspector"
 	_ "github.com/fibercrypto/fibercryptowallet/src/models/pending"
 	"github.com/therecipe/qt/core"
 	"github.com/therecipe/qt/gui"
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"encoding/json"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	local "github.com/fibercrypto/fibercryptowallet/src/main"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+const (
+	SectionName            = "bitcoin"
+	SettingPathToNode      = "node"
+	SettingPathToWallets   = "wallets"
+	SettingNodeAddress     = "address"
+	SettingNodeCredentials = "credentials"
+	SettingNodeNetwork     = "network"
+	SettingWalletsDirPath  = "directory"
+)
+
+var (
+	sectionManager *local.SectionManager
+	log            = logging.MustGetLogger("Bitcoin Config")
+)
+
+func getMultiPlatformUserDirectory() string {
+	usr, err := user.Current()
+	if err != nil {
+		log.WithError(err).Error()
+		return ""
+	}
+	return filepath.Join(usr.HomeDir, ".fibercryptowallet", "bitcoin", "wallets")
+}
+
+// RegisterConfig registers default settings of Bitcoin-family nodes and wallets
+func RegisterConfig() error {
+	cm := local.GetConfigManager()
+	node := map[string]string{
+		SettingNodeAddress:     "http://127.0.0.1:8332",
+		SettingNodeCredentials: "",
+		SettingNodeNetwork:     "MainNet",
+	}
+	nodeBytes, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+	nodeOpt := local.NewOption(SettingPathToNode, []string{}, false, string(nodeBytes))
+
+	wallets := map[string]string{SettingWalletsDirPath: getMultiPlatformUserDirectory()}
+	walletsBytes, err := json.Marshal(wallets)
+	if err != nil {
+		return err
+	}
+	walletsOpt := local.NewOption(SettingPathToWallets, []string{}, false, string(walletsBytes))
+
+	sectionManager = cm.RegisterSection(SectionName, []*local.Option{nodeOpt, walletsOpt})
+	return nil
+}
+
+// GetOption reads value of setting at path
+func GetOption(path string) (string, error) {
+	stringList := strings.Split(path, "/")
+	return sectionManager.GetValue(stringList[len(stringList)-1], stringList[:len(stringList)-1])
+}
+
+// GetSettings decodes key value pairs stored at setting path
+func GetSettings(path string) (map[string]string, error) {
+	value, err := GetOption(path)
+	if err != nil {
+		return nil, err
+	}
+	settings := make(map[string]string)
+	if err := json.Unmarshal([]byte(value), &settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
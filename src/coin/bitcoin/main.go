@@ -0,0 +1,59 @@
+package bitcoin
+
+import (
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/config"
+	btc "github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/models"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/nodeclient"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logBitcoin = logging.MustGetLogger("Bitcoin Altcoin")
+
+var networks = map[string]params.BitcoinParams{
+	params.BitcoinMainNetParams.NetType: params.BitcoinMainNetParams,
+	params.BitcoinTestNetParams.NetType: params.BitcoinTestNetParams,
+	params.BitcoinRegTestParams.NetType: params.BitcoinRegTestParams,
+}
+
+func init() {
+	UpdateAltcoin()
+}
+
+// UpdateAltcoin refreshes Bitcoin node settings and registers plugin for configured network
+func UpdateAltcoin() {
+	if err := config.RegisterConfig(); err != nil {
+		logBitcoin.Warn("Couldn't register Bitcoin configuration")
+		return
+	}
+	node, err := config.GetSettings(config.SettingPathToNode)
+	if err != nil {
+		logBitcoin.WithError(err).Warn("Couldn't get node settings")
+		return
+	}
+	wallets, err := config.GetSettings(config.SettingPathToWallets)
+	if err != nil {
+		logBitcoin.WithError(err).Warn("Couldn't get wallet settings")
+		return
+	}
+	p, isKnown := networks[node[config.SettingNodeNetwork]]
+	if !isKnown {
+		logBitcoin.WithField("network", node[config.SettingNodeNetwork]).Warn("Unknown Bitcoin network")
+		return
+	}
+	// RPC credentials are kept in a file of their own instead of the plaintext configuration
+	var creds nodeclient.Credentials
+	if path := node[config.SettingNodeCredentials]; path != "" {
+		if creds, err = nodeclient.LoadCredentials(path); err != nil {
+			logBitcoin.WithError(err).WithField("path", path).Error("Couldn't load node credentials")
+			return
+		}
+	}
+	factory := btc.NewBitcoinConnectionFactory(node[config.SettingNodeAddress], creds.Username, creds.Password)
+	if err := core.GetMultiPool().CreateSection(btc.PoolSectionName(p), factory); err != nil {
+		logBitcoin.Warn("Couldn't create section for Bitcoin")
+	}
+	util.RegisterAltcoin(btc.NewBitcoinPlugin(p, wallets[config.SettingWalletsDirPath]))
+}
@@ -0,0 +1,149 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/wire"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logAccount = logging.MustGetLogger("Bitcoin account")
+
+// BitcoinAccount tracks coins locked by a set of addresses
+type BitcoinAccount struct { // Implements CryptoAccount interface
+	params      params.BitcoinParams
+	poolSection string
+	addresses   func() ([]*BitcoinAddress, error)
+}
+
+func newBitcoinAccount(p params.BitcoinParams, poolSection string, addresses func() ([]*BitcoinAddress, error)) *BitcoinAccount {
+	return &BitcoinAccount{
+		params:      p,
+		poolSection: poolSection,
+		addresses:   addresses,
+	}
+}
+
+// scanUnspent looks up confirmed unspent outputs of account addresses
+func (acc *BitcoinAccount) scanUnspent() ([]*BitcoinTransactionOutput, error) {
+	addrs, err := acc.addresses()
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	descriptors := make([]string, len(addrs))
+	for i, addr := range addrs {
+		descriptors[i] = addr.descriptor()
+	}
+	c, err := NewBitcoinRPCClient(acc.poolSection)
+	if err != nil {
+		logAccount.WithError(err).Error("Couldn't get RPC client")
+		return nil, err
+	}
+	defer ReturnBitcoinRPCClient(c)
+	result, err := c.ScanTxOutSet(descriptors)
+	if err != nil {
+		logAccount.WithError(err).Error("Couldn't scan UTXO set")
+		return nil, err
+	}
+	if !result.Success {
+		return nil, errors.ErrNotFound
+	}
+	outputs := make([]*BitcoinTransactionOutput, 0, len(result.Unspents))
+	for _, ux := range result.Unspents {
+		hash, err := wire.NewHashFromStr(ux.TxID)
+		if err != nil {
+			return nil, errors.ErrParseTxID
+		}
+		value, err := core.ParseAmount(acc.params.Ticker, ux.Amount.String(), acc.params.Accuracy)
+		if err != nil {
+			return nil, err
+		}
+		pkScript, err := hex.DecodeString(ux.ScriptPubKey)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, newBitcoinTransactionOutput(acc.params, wire.OutPoint{Hash: hash, Index: ux.Vout}, value.Coins, pkScript))
+	}
+	return outputs, nil
+}
+
+// GetBalance retrieves confirmed coins of asset represented by ticker locked by account addresses
+func (acc *BitcoinAccount) GetBalance(ticker string) (uint64, error) {
+	if ticker != acc.params.Ticker {
+		return 0, errors.ErrInvalidAltcoinTicker
+	}
+	outputs, err := acc.scanUnspent()
+	if err != nil {
+		return 0, err
+	}
+	balance := core.NewAmount(ticker, 0)
+	for _, out := range outputs {
+		if balance, err = balance.Add(core.NewAmount(ticker, out.value)); err != nil {
+			return 0, err
+		}
+	}
+	return balance.Coins, nil
+}
+
+// ListAssets to enumerate the tickers of all assets supported by this account
+func (acc *BitcoinAccount) ListAssets() []string {
+	return []string{acc.params.Ticker}
+}
+
+// ScanUnspentOutputs to determine the outputs that can participate in a transaction
+func (acc *BitcoinAccount) ScanUnspentOutputs() (core.TransactionOutputIterator, error) {
+	outputs, err := acc.scanUnspent()
+	if err != nil {
+		return nil, err
+	}
+	coreOutputs := make([]core.TransactionOutput, len(outputs))
+	for i, out := range outputs {
+		coreOutputs[i] = out
+	}
+	return NewBitcoinTransactionOutputIterator(coreOutputs), nil
+}
+
+// ListTransactions to show account history
+//
+// bitcoind does not index transactions by address, hence history is not available
+func (acc *BitcoinAccount) ListTransactions() core.TransactionIterator {
+	logAccount.Warn("Transaction history is not supported by bitcoind JSON-RPC API")
+	return NewBitcoinTransactionIterator(nil)
+}
+
+// ListPendingTransactions lists transactions in node mempool paying to account addresses
+func (acc *BitcoinAccount) ListPendingTransactions() (core.TransactionIterator, error) {
+	addrs, err := acc.addresses()
+	if err != nil {
+		return nil, err
+	}
+	scripts := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		scripts[string(addr.ScriptPubKey())] = struct{}{}
+	}
+	pending, err := getMempoolTransactions(acc.params, acc.poolSection)
+	if err != nil {
+		return nil, err
+	}
+	txns := make([]core.Transaction, 0)
+	for _, txn := range pending {
+		for _, out := range txn.msgTx.TxOut {
+			if _, isOwned := scripts[string(out.PkScript)]; isOwned {
+				txns = append(txns, txn)
+				break
+			}
+		}
+	}
+	return NewBitcoinTransactionIterator(txns), nil
+}
+
+// Type assertions
+var (
+	_ core.CryptoAccount = &BitcoinAccount{}
+)
@@ -0,0 +1,154 @@
+package bitcoin
+
+import (
+	"errors"
+	"strings"
+)
+
+// BIP173 bech32 encoding of native segwit version 0 addresses
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var (
+	errBech32InvalidChar     = errors.New("Invalid bech32 character")
+	errBech32InvalidChecksum = errors.New("Invalid bech32 checksum")
+	errBech32MixedCase       = errors.New("Mixed case bech32 string")
+	errBech32InvalidLength   = errors.New("Invalid bech32 string length")
+	errBech32InvalidPadding  = errors.New("Invalid bech32 padding")
+)
+
+var bech32Generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+func bech32Polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= bech32Generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	result := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		result = append(result, hrp[i]>>5)
+	}
+	result = append(result, 0)
+	for i := 0; i < len(hrp); i++ {
+		result = append(result, hrp[i]&31)
+	}
+	return result
+}
+
+func bech32Checksum(hrp string, data []byte) []byte {
+	values := append(bech32HrpExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	mod := bech32Polymod(values) ^ 1
+	checksum := make([]byte, 6)
+	for i := range checksum {
+		checksum[i] = byte((mod >> uint(5*(5-i))) & 31)
+	}
+	return checksum
+}
+
+func bech32Encode(hrp string, data []byte) string {
+	combined := append(append([]byte{}, data...), bech32Checksum(hrp, data)...)
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, v := range combined {
+		sb.WriteByte(bech32Charset[v])
+	}
+	return sb.String()
+}
+
+func bech32Decode(s string) (string, []byte, error) {
+	if len(s) < 8 || len(s) > 90 {
+		return "", nil, errBech32InvalidLength
+	}
+	lower := strings.ToLower(s)
+	if lower != s && strings.ToUpper(s) != s {
+		return "", nil, errBech32MixedCase
+	}
+	pos := strings.LastIndexByte(lower, '1')
+	if pos < 1 || pos+7 > len(lower) {
+		return "", nil, errBech32InvalidLength
+	}
+	hrp := lower[:pos]
+	data := make([]byte, 0, len(lower)-pos-1)
+	for i := pos + 1; i < len(lower); i++ {
+		idx := strings.IndexByte(bech32Charset, lower[i])
+		if idx < 0 {
+			return "", nil, errBech32InvalidChar
+		}
+		data = append(data, byte(idx))
+	}
+	if bech32Polymod(append(bech32HrpExpand(hrp), data...)) != 1 {
+		return "", nil, errBech32InvalidChecksum
+	}
+	return hrp, data[:len(data)-6], nil
+}
+
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	acc := uint32(0)
+	bits := uint(0)
+	maxv := uint32(1)<<toBits - 1
+	result := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, v := range data {
+		if uint32(v)>>fromBits != 0 {
+			return nil, errBech32InvalidChar
+		}
+		acc = acc<<fromBits | uint32(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte((acc>>bits)&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			result = append(result, byte((acc<<(toBits-bits))&maxv))
+		}
+	} else if bits >= fromBits || (acc<<(toBits-bits))&maxv != 0 {
+		return nil, errBech32InvalidPadding
+	}
+	return result, nil
+}
+
+// encodeSegwitAddress encodes witness program as a bech32 address
+func encodeSegwitAddress(hrp string, version byte, program []byte) (string, error) {
+	data, err := convertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	return bech32Encode(hrp, append([]byte{version}, data...)), nil
+}
+
+// decodeSegwitAddress decodes version 0 witness program from a bech32 address
+func decodeSegwitAddress(hrp, addr string) (byte, []byte, error) {
+	gotHrp, data, err := bech32Decode(addr)
+	if err != nil {
+		return 0, nil, err
+	}
+	if gotHrp != hrp || len(data) < 1 {
+		return 0, nil, errBech32InvalidLength
+	}
+	version := data[0]
+	if version != 0 {
+		// Witness v1+ addresses (e.g. taproot) use bech32m, not supported yet
+		return 0, nil, errBech32InvalidChecksum
+	}
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(program) != 20 && len(program) != 32 {
+		return 0, nil, errBech32InvalidLength
+	}
+	return version, program, nil
+}
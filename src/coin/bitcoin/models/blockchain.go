@@ -0,0 +1,281 @@
+package bitcoin
+
+import (
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/wire"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logBlockchain = logging.MustGetLogger("Bitcoin blockchain")
+
+// BitcoinBlock header data of a block in the chain
+type BitcoinBlock struct { // Implements Block interface
+	Header *BlockHeader
+}
+
+// GetHash returns block hash in internal byte order
+func (bb *BitcoinBlock) GetHash() ([]byte, error) {
+	if bb.Header == nil {
+		return nil, errors.ErrBlockNotSet
+	}
+	hash, err := wire.NewHashFromStr(bb.Header.Hash)
+	if err != nil {
+		return nil, err
+	}
+	return hash[:], nil
+}
+
+// GetPrevHash returns hash of parent block, nil for genesis block
+func (bb *BitcoinBlock) GetPrevHash() ([]byte, error) {
+	if bb.Header == nil {
+		return nil, errors.ErrBlockNotSet
+	}
+	if bb.Header.PreviousBlockHash == "" {
+		return nil, nil
+	}
+	hash, err := wire.NewHashFromStr(bb.Header.PreviousBlockHash)
+	if err != nil {
+		return nil, err
+	}
+	return hash[:], nil
+}
+
+// GetVersion returns block version
+func (bb *BitcoinBlock) GetVersion() (uint32, error) {
+	if bb.Header == nil {
+		return 0, errors.ErrBlockNotSet
+	}
+	return bb.Header.Version, nil
+}
+
+// GetTime returns block timestamp
+func (bb *BitcoinBlock) GetTime() (core.Timestamp, error) {
+	if bb.Header == nil {
+		return 0, errors.ErrBlockNotSet
+	}
+	return core.Timestamp(bb.Header.Time), nil
+}
+
+// GetHeight returns block sequence number
+func (bb *BitcoinBlock) GetHeight() (uint64, error) {
+	if bb.Header == nil {
+		return 0, errors.ErrBlockNotSet
+	}
+	return bb.Header.Height, nil
+}
+
+// GetFee is not available from block headers
+func (bb *BitcoinBlock) GetFee(ticker string) (uint64, error) {
+	if bb.Header == nil {
+		return 0, errors.ErrBlockNotSet
+	}
+	return 0, errors.ErrNotImplemented
+}
+
+// IsGenesisBlock determines whether this is the first block in the chain
+func (bb *BitcoinBlock) IsGenesisBlock() (bool, error) {
+	if bb.Header == nil {
+		return false, errors.ErrBlockNotSet
+	}
+	return bb.Header.Height == 0, nil
+}
+
+// subsidySupply computes coins issued by blocks up to height, including it
+func subsidySupply(p params.BitcoinParams, height uint64) uint64 {
+	var supply uint64
+	subsidy := p.InitialSubsidy
+	blocks := height + 1
+	for subsidy > 0 && blocks > 0 {
+		n := p.SubsidyHalvingInterval
+		if blocks < n {
+			n = blocks
+		}
+		supply += n * subsidy
+		blocks -= n
+		subsidy >>= 1
+	}
+	return supply
+}
+
+// totalSupply computes coins issued once block rewards are exhausted
+func totalSupply(p params.BitcoinParams) uint64 {
+	var supply uint64
+	for subsidy := p.InitialSubsidy; subsidy > 0; subsidy >>= 1 {
+		supply += p.SubsidyHalvingInterval * subsidy
+	}
+	return supply
+}
+
+// GetTransactions is not available from block headers
+func (bb *BitcoinBlock) GetTransactions() (core.TransactionIterator, error) {
+	return nil, errors.ErrNotImplemented
+}
+
+// BitcoinBlockchain queries chain status and creates transactions through a bitcoind compatible node
+type BitcoinBlockchain struct { // Implements BlockchainStatus and BlockchainTransactionAPI interfaces
+	params      params.BitcoinParams
+	poolSection string
+}
+
+// NewBitcoinBlockchain instantiates blockchain API for network params
+func NewBitcoinBlockchain(p params.BitcoinParams) *BitcoinBlockchain {
+	return &BitcoinBlockchain{params: p, poolSection: PoolSectionName(p)}
+}
+
+// GetCoinValue retrieves value of a blockchain metric
+func (bc *BitcoinBlockchain) GetCoinValue(coinvalue core.CoinValueMetric, ticker string) (uint64, error) {
+	logBlockchain.Info("Getting coin value")
+	if ticker != bc.params.Ticker {
+		return 0, errors.ErrInvalidAltcoinTicker
+	}
+	switch coinvalue {
+	case core.CoinCurrentSupply:
+		c, err := NewBitcoinRPCClient(bc.poolSection)
+		if err != nil {
+			return 0, err
+		}
+		defer ReturnBitcoinRPCClient(c)
+		info, err := c.GetBlockchainInfo()
+		if err != nil {
+			logBlockchain.WithError(err).Warn("Couldn't get blockchain info")
+			return 0, err
+		}
+		return subsidySupply(bc.params, info.Blocks), nil
+	case core.CoinTotalSupply:
+		return totalSupply(bc.params), nil
+	}
+	return 0, errors.ErrInvalidOptions
+}
+
+// GetLastBlock retrieves block at the tip of the chain
+func (bc *BitcoinBlockchain) GetLastBlock() (core.Block, error) {
+	logBlockchain.Info("Getting last block")
+	c, err := NewBitcoinRPCClient(bc.poolSection)
+	if err != nil {
+		return nil, err
+	}
+	defer ReturnBitcoinRPCClient(c)
+	hash, err := c.GetBestBlockHash()
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't get best block hash")
+		return nil, err
+	}
+	header, err := c.GetBlockHeader(hash)
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't get block header")
+		return nil, err
+	}
+	return &BitcoinBlock{Header: header}, nil
+}
+
+// GetNumberOfBlocks determine number of blocks in the chain, including genesis block
+func (bc *BitcoinBlockchain) GetNumberOfBlocks() (uint64, error) {
+	logBlockchain.Info("Getting number of blocks")
+	c, err := NewBitcoinRPCClient(bc.poolSection)
+	if err != nil {
+		return 0, err
+	}
+	defer ReturnBitcoinRPCClient(c)
+	info, err := c.GetBlockchainInfo()
+	if err != nil {
+		return 0, err
+	}
+	return info.Blocks + 1, nil
+}
+
+// GetBlockByHeight is not supported yet
+func (bc *BitcoinBlockchain) GetBlockByHeight(height uint64) (core.Block, error) {
+	return nil, errors.ErrNotImplemented
+}
+
+// GetBlockByHash is not supported yet
+func (bc *BitcoinBlockchain) GetBlockByHash(hash string) (core.Block, error) {
+	return nil, errors.ErrNotImplemented
+}
+
+// GetBlockRange is not supported yet
+func (bc *BitcoinBlockchain) GetBlockRange(start, end uint64) (core.BlockIterator, error) {
+	return nil, errors.ErrNotImplemented
+}
+
+// GetTransaction is not supported yet
+func (bc *BitcoinBlockchain) GetTransaction(txid string) (core.Transaction, error) {
+	return nil, errors.ErrNotImplemented
+}
+
+// GetAddressBalance is not supported yet
+func (bc *BitcoinBlockchain) GetAddressBalance(addr, ticker string) (uint64, error) {
+	return 0, errors.ErrNotImplemented
+}
+
+// GetAddressTransactions is not supported yet
+func (bc *BitcoinBlockchain) GetAddressTransactions(addr string) (core.TransactionIterator, error) {
+	return nil, errors.ErrNotImplemented
+}
+
+// SendFromAddress instantiates a transaction to send funds from specific source addresses
+// to multiple destination addresses. Change goes back to first source address unless specified.
+func (bc *BitcoinBlockchain) SendFromAddress(from []core.WalletAddress, to []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	logBlockchain.Info("Sending coins from addresses via blockchain API")
+	if len(from) == 0 {
+		return nil, errors.ErrInvalidAddressString
+	}
+	addrs := make([]*BitcoinAddress, len(from))
+	for i, wa := range from {
+		addr, err := NewBitcoinAddress(wa.GetAddress().String(), bc.params)
+		if err != nil {
+			return nil, err
+		}
+		addrs[i] = addr
+	}
+	if change == nil {
+		change = addrs[0]
+	}
+	changeAddr, err := NewBitcoinAddress(change.String(), bc.params)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := newBitcoinAccount(bc.params, bc.poolSection, func() ([]*BitcoinAddress, error) {
+		return addrs, nil
+	}).scanUnspent()
+	if err != nil {
+		return nil, err
+	}
+	return buildTransaction(bc.params, candidates, to, changeAddr, options, false)
+}
+
+// Spend instantiates a transaction that spends specific outputs to send to multiple destination addresses.
+// Change goes back to the address of the first output unless specified.
+func (bc *BitcoinBlockchain) Spend(unspent []core.WalletOutput, new []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	logBlockchain.Info("Spending coins from outputs via blockchain API")
+	uxouts := make([]core.TransactionOutput, len(unspent))
+	for i, wu := range unspent {
+		uxouts[i] = wu.GetOutput()
+	}
+	candidates, err := outputsFromGeneric(bc.params, uxouts)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, errors.ErrInsufficientFunds
+	}
+	var changeAddr *BitcoinAddress
+	if change == nil {
+		changeAddr, err = newAddressFromScript(candidates[0].pkScript, bc.params)
+	} else {
+		changeAddr, err = NewBitcoinAddress(change.String(), bc.params)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buildTransaction(bc.params, candidates, new, changeAddr, options, true)
+}
+
+// Type assertions
+var (
+	_ core.Block                    = &BitcoinBlock{}
+	_ core.BlockchainStatus         = &BitcoinBlockchain{}
+	_ core.BlockchainTransactionAPI = &BitcoinBlockchain{}
+)
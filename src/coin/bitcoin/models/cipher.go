@@ -0,0 +1,287 @@
+package bitcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/SkycoinProject/skycoin/src/cipher/base58"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+)
+
+// AddressType enumerates supported output script templates
+type AddressType uint8
+
+const (
+	// AddressP2PKH legacy pay to public key hash
+	AddressP2PKH AddressType = iota
+	// AddressP2SH legacy pay to script hash
+	AddressP2SH
+	// AddressP2WPKH native segwit pay to witness public key hash
+	AddressP2WPKH
+	// AddressP2WSH native segwit pay to witness script hash
+	AddressP2WSH
+)
+
+var (
+	// ErrInvalidAddress address string does not match any supported format for network
+	ErrInvalidAddress = errors.New("Invalid Bitcoin address")
+	// ErrNonStandardScript output script does not match a standard address template
+	ErrNonStandardScript = errors.New("Non standard output script")
+)
+
+// BitcoinAddress identifies the output script of a Bitcoin-family coin
+type BitcoinAddress struct { // Implements Address and CryptoAccount interfaces
+	addrType    AddressType
+	hash        []byte
+	isBip32     bool
+	params      params.BitcoinParams
+	poolSection string
+}
+
+// NewBitcoinAddress parses base58 or bech32 address for network params
+func NewBitcoinAddress(addrStr string, p params.BitcoinParams) (*BitcoinAddress, error) {
+	addr := &BitcoinAddress{params: p, poolSection: PoolSectionName(p)}
+	if _, program, err := decodeSegwitAddress(p.Bech32HRP, addrStr); err == nil {
+		addr.hash = program
+		if len(program) == 20 {
+			addr.addrType = AddressP2WPKH
+		} else {
+			addr.addrType = AddressP2WSH
+		}
+		return addr, nil
+	}
+	b, err := base58.Decode(addrStr)
+	if err != nil || len(b) != 25 {
+		return nil, ErrInvalidAddress
+	}
+	if !bytes.Equal(base58Checksum(b[:21]), b[21:]) {
+		return nil, ErrInvalidAddress
+	}
+	switch b[0] {
+	case p.PubKeyHashAddrID:
+		addr.addrType = AddressP2PKH
+	case p.ScriptHashAddrID:
+		addr.addrType = AddressP2SH
+	default:
+		return nil, ErrInvalidAddress
+	}
+	addr.hash = b[1:21]
+	return addr, nil
+}
+
+// newAddressFromPubKey derives P2PKH or P2WPKH address for compressed public key
+func newAddressFromPubKey(pk cipher.PubKey, addrType AddressType, p params.BitcoinParams) *BitcoinAddress {
+	return &BitcoinAddress{
+		addrType:    addrType,
+		hash:        hash160(pk[:]),
+		isBip32:     true,
+		params:      p,
+		poolSection: PoolSectionName(p),
+	}
+}
+
+// newAddressFromScript recognizes address encoded in standard output script
+func newAddressFromScript(script []byte, p params.BitcoinParams) (*BitcoinAddress, error) {
+	addr := &BitcoinAddress{params: p, poolSection: PoolSectionName(p)}
+	switch {
+	case isPayToPubKeyHash(script):
+		addr.addrType, addr.hash = AddressP2PKH, script[3:23]
+	case isPayToScriptHash(script):
+		addr.addrType, addr.hash = AddressP2SH, script[2:22]
+	case isPayToWitnessPubKeyHash(script):
+		addr.addrType, addr.hash = AddressP2WPKH, script[2:]
+	case isPayToWitnessScriptHash(script):
+		addr.addrType, addr.hash = AddressP2WSH, script[2:]
+	default:
+		return nil, ErrNonStandardScript
+	}
+	addr.hash = append([]byte(nil), addr.hash...)
+	return addr, nil
+}
+
+func base58Checksum(b []byte) []byte {
+	first := sha256.Sum256(b)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
+
+// Type returns the output script template of this address
+func (addr *BitcoinAddress) Type() AddressType {
+	return addr.addrType
+}
+
+// ScriptPubKey builds output script paying to this address
+func (addr *BitcoinAddress) ScriptPubKey() []byte {
+	switch addr.addrType {
+	case AddressP2PKH:
+		return payToPubKeyHashScript(addr.hash)
+	case AddressP2SH:
+		return payToScriptHashScript(addr.hash)
+	}
+	return payToWitnessScript(addr.hash)
+}
+
+// descriptor returns output descriptor used to scan the UTXO set
+func (addr *BitcoinAddress) descriptor() string {
+	return "raw(" + hex.EncodeToString(addr.ScriptPubKey()) + ")"
+}
+
+// IsBip32 flag shall be set if address generation complies to BIP 32
+func (addr *BitcoinAddress) IsBip32() bool {
+	return addr.isBip32
+}
+
+// String return human-readable representation of this address
+func (addr *BitcoinAddress) String() string {
+	switch addr.addrType {
+	case AddressP2WPKH, AddressP2WSH:
+		s, err := encodeSegwitAddress(addr.params.Bech32HRP, 0, addr.hash)
+		if err != nil {
+			logWallet.WithError(err).Error("Couldn't encode segwit address")
+			return ""
+		}
+		return s
+	}
+	version := addr.params.PubKeyHashAddrID
+	if addr.addrType == AddressP2SH {
+		version = addr.params.ScriptHashAddrID
+	}
+	b := append([]byte{version}, addr.hash...)
+	return base58.Encode(append(b, base58Checksum(b)...))
+}
+
+// GetCryptoAccount provides access to address transaction history
+func (addr *BitcoinAddress) GetCryptoAccount() core.CryptoAccount {
+	return newBitcoinAccount(addr.params, addr.poolSection, func() ([]*BitcoinAddress, error) {
+		return []*BitcoinAddress{addr}, nil
+	})
+}
+
+// Bytes binary representation for address
+func (addr *BitcoinAddress) Bytes() []byte {
+	return append([]byte(nil), addr.hash...)
+}
+
+// Checksum computes address consistency token
+func (addr *BitcoinAddress) Checksum() core.Checksum {
+	return base58Checksum(append([]byte{byte(addr.addrType)}, addr.hash...))
+}
+
+// Verify checks that the address appears valid for the public key
+func (addr *BitcoinAddress) Verify(pk core.PubKey) error {
+	if addr.addrType != AddressP2PKH && addr.addrType != AddressP2WPKH {
+		return ErrInvalidAddress
+	}
+	if !bytes.Equal(hash160(pk.Bytes()), addr.hash) {
+		return ErrInvalidAddress
+	}
+	return nil
+}
+
+// Null returns true if the address is null
+func (addr *BitcoinAddress) Null() bool {
+	for _, b := range addr.hash {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// BitcoinAddressIterator iterates over a sequence of addresses
+type BitcoinAddressIterator struct {
+	current   int
+	addresses []core.Address
+}
+
+// Value of address at iterator pointer position
+func (it *BitcoinAddressIterator) Value() core.Address {
+	return it.addresses[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *BitcoinAddressIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *BitcoinAddressIterator) HasNext() bool {
+	return (it.current + 1) < len(it.addresses)
+}
+
+// NewBitcoinAddressIterator instantiates iterator over addresses
+func NewBitcoinAddressIterator(addresses []core.Address) *BitcoinAddressIterator {
+	return &BitcoinAddressIterator{addresses: addresses, current: -1}
+}
+
+// BitcoinSecKey secp256k1 private key wrapper
+type BitcoinSecKey struct {
+	seckey cipher.SecKey
+}
+
+// Verify checks that the private key appears valid
+func (sk *BitcoinSecKey) Verify() error {
+	return sk.seckey.Verify()
+}
+
+// Null returns true if the private key is null
+func (sk *BitcoinSecKey) Null() bool {
+	return sk.seckey.Null()
+}
+
+// Bytes binary representation for private key
+func (sk *BitcoinSecKey) Bytes() []byte {
+	return sk.seckey[:]
+}
+
+// BitcoinPubKey compressed secp256k1 public key wrapper
+type BitcoinPubKey struct {
+	pubkey cipher.PubKey
+}
+
+// Verify checks that the public key appears valid
+func (pk *BitcoinPubKey) Verify() error {
+	return pk.pubkey.Verify()
+}
+
+// Null returns true if the public key is null
+func (pk *BitcoinPubKey) Null() bool {
+	return pk.pubkey.Null()
+}
+
+// Bytes binary representation for public key
+func (pk *BitcoinPubKey) Bytes() []byte {
+	return pk.pubkey[:]
+}
+
+func btcSecKeyFromBytes(b []byte) (*BitcoinSecKey, error) {
+	sk, err := cipher.NewSecKey(b)
+	if err != nil {
+		return nil, err
+	}
+	return &BitcoinSecKey{seckey: sk}, nil
+}
+
+func btcPubKeyFromBytes(b []byte) (*BitcoinPubKey, error) {
+	pk, err := cipher.NewPubKey(b)
+	if err != nil {
+		return nil, err
+	}
+	return &BitcoinPubKey{pubkey: pk}, nil
+}
+
+// Type assertions
+var (
+	_ core.Address         = &BitcoinAddress{}
+	_ core.AddressIterator = &BitcoinAddressIterator{}
+	_ core.PubKey          = &BitcoinPubKey{}
+	_ core.SecKey          = &BitcoinSecKey{}
+)
@@ -0,0 +1,64 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBitcoinAddress(t *testing.T) {
+	tests := []struct {
+		addr     string
+		p        params.BitcoinParams
+		addrType AddressType
+		script   string
+	}{
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", params.BitcoinMainNetParams, AddressP2WPKH, "0014751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", params.BitcoinTestNetParams, AddressP2WSH, "00201863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", params.BitcoinMainNetParams, AddressP2PKH, "76a914d986ed01b7a22225a70edbf2ba7cfb63a15cb3aa88ac"},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", params.BitcoinMainNetParams, AddressP2SH, "a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			addr, err := NewBitcoinAddress(tt.addr, tt.p)
+			require.NoError(t, err)
+			require.Equal(t, tt.addrType, addr.Type())
+			require.Equal(t, tt.script, hex.EncodeToString(addr.ScriptPubKey()))
+
+			script, err := hex.DecodeString(tt.script)
+			require.NoError(t, err)
+			fromScript, err := newAddressFromScript(script, tt.p)
+			require.NoError(t, err)
+			require.Equal(t, addr.String(), fromScript.String())
+		})
+	}
+}
+
+func TestNewBitcoinAddressInvalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5",
+		"1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabB",
+		"tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx",
+		"mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn",
+	}
+	for _, addr := range invalid {
+		_, err := NewBitcoinAddress(addr, params.BitcoinMainNetParams)
+		require.Error(t, err, addr)
+	}
+}
+
+func TestBech32RoundTrip(t *testing.T) {
+	program := []byte{0x75, 0x1e, 0x76, 0xe8, 0x19, 0x91, 0x96, 0xd4, 0x54, 0x94, 0x1c, 0x45, 0xd1, 0xb3, 0xa3, 0x23, 0xf1, 0x43, 0x3b, 0xd6}
+	addr, err := encodeSegwitAddress("bc", 0, program)
+	require.NoError(t, err)
+	require.Equal(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", addr)
+	version, decoded, err := decodeSegwitAddress("bc", addr)
+	require.NoError(t, err)
+	require.Equal(t, byte(0), version)
+	require.Equal(t, program, decoded)
+	_, _, err = decodeSegwitAddress("tb", addr)
+	require.Error(t, err)
+}
@@ -0,0 +1,452 @@
+package bitcoin
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/wire"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logCoin = logging.MustGetLogger("Bitcoin coin")
+
+// prevOutput caches value and script of the output spent by a transaction input
+type prevOutput struct {
+	value    uint64
+	pkScript []byte
+	known    bool
+}
+
+// BitcoinTransaction wraps a Bitcoin-family transaction
+type BitcoinTransaction struct { // Implements Transaction interface
+	params      params.BitcoinParams
+	poolSection string
+	msgTx       *wire.MsgTx
+	prevOuts    []prevOutput
+	status      core.TransactionStatus
+	timestamp   core.Timestamp
+}
+
+func newBitcoinTransaction(p params.BitcoinParams, msgTx *wire.MsgTx, prevOuts []prevOutput, status core.TransactionStatus) *BitcoinTransaction {
+	if prevOuts == nil {
+		prevOuts = make([]prevOutput, len(msgTx.TxIn))
+	}
+	return &BitcoinTransaction{
+		params:      p,
+		poolSection: PoolSectionName(p),
+		msgTx:       msgTx,
+		prevOuts:    prevOuts,
+		status:      status,
+	}
+}
+
+// NewBitcoinTransactionFromHex decodes raw transaction, values of spent outputs are resolved on demand
+func NewBitcoinTransactionFromHex(rawTxn string, p params.BitcoinParams, status core.TransactionStatus) (*BitcoinTransaction, error) {
+	b, err := hex.DecodeString(rawTxn)
+	if err != nil {
+		return nil, err
+	}
+	msgTx, err := wire.DeserializeBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	return newBitcoinTransaction(p, msgTx, nil, status), nil
+}
+
+// MsgTx returns the underlying wire transaction
+func (txn *BitcoinTransaction) MsgTx() *wire.MsgTx {
+	return txn.msgTx
+}
+
+// EncodeHex serializes transaction in hex format accepted by nodes
+func (txn *BitcoinTransaction) EncodeHex() string {
+	return hex.EncodeToString(txn.msgTx.Bytes(true))
+}
+
+// VirtualSize computes transaction size considered to compute fees
+func (txn *BitcoinTransaction) VirtualSize() int {
+	return txn.msgTx.VirtualSize()
+}
+
+// SupportedAssets enumerates tickers of crypto assets supported by this transaction
+func (txn *BitcoinTransaction) SupportedAssets() []string {
+	return []string{txn.params.Ticker}
+}
+
+// GetTimestamp at the moment of creation
+func (txn *BitcoinTransaction) GetTimestamp() core.Timestamp {
+	return txn.timestamp
+}
+
+// GetStatus to retrieve transaction status
+func (txn *BitcoinTransaction) GetStatus() core.TransactionStatus {
+	return txn.status
+}
+
+// GetInputs to list transaction inputs for spent transactions
+func (txn *BitcoinTransaction) GetInputs() []core.TransactionInput {
+	inputs := make([]core.TransactionInput, len(txn.msgTx.TxIn))
+	for i := range txn.msgTx.TxIn {
+		inputs[i] = &BitcoinTransactionInput{txn: txn, index: i}
+	}
+	return inputs
+}
+
+// GetOutputs to list transaction outputs for coins distributed to participants
+func (txn *BitcoinTransaction) GetOutputs() []core.TransactionOutput {
+	txid := txn.msgTx.TxHash()
+	outputs := make([]core.TransactionOutput, len(txn.msgTx.TxOut))
+	for i, out := range txn.msgTx.TxOut {
+		outputs[i] = newBitcoinTransactionOutput(txn.params, wire.OutPoint{Hash: txid, Index: uint32(i)}, out.Value, out.PkScript)
+	}
+	return outputs
+}
+
+// GetId o retrieve transaction ID
+func (txn *BitcoinTransaction) GetId() string {
+	return txn.msgTx.TxHash().String()
+}
+
+// resolvePrevOutput looks up the output spent by input at index, querying the node if unknown
+func (txn *BitcoinTransaction) resolvePrevOutput(idx int) (prevOutput, error) {
+	if idx < 0 || idx >= len(txn.prevOuts) {
+		return prevOutput{}, ErrInputIndexOutOfRange
+	}
+	if txn.prevOuts[idx].known {
+		return txn.prevOuts[idx], nil
+	}
+	outPoint := txn.msgTx.TxIn[idx].PreviousOutPoint
+	c, err := NewBitcoinRPCClient(txn.poolSection)
+	if err != nil {
+		return prevOutput{}, err
+	}
+	defer ReturnBitcoinRPCClient(c)
+	rawTxn, err := c.GetRawTransaction(outPoint.Hash.String())
+	if err != nil {
+		logCoin.WithError(err).Warn("Couldn't get previous transaction")
+		return prevOutput{}, err
+	}
+	prevTxn, err := NewBitcoinTransactionFromHex(rawTxn, txn.params, core.TXN_STATUS_CONFIRMED)
+	if err != nil {
+		return prevOutput{}, err
+	}
+	if int(outPoint.Index) >= len(prevTxn.msgTx.TxOut) {
+		return prevOutput{}, errors.ErrInvalidTxn
+	}
+	out := prevTxn.msgTx.TxOut[outPoint.Index]
+	txn.prevOuts[idx] = prevOutput{value: out.Value, pkScript: out.PkScript, known: true}
+	return txn.prevOuts[idx], nil
+}
+
+// ComputeFee calculates transaction fee expressed in coins of asset represented by ticker
+func (txn *BitcoinTransaction) ComputeFee(ticker string) (uint64, error) {
+	if ticker != txn.params.Ticker {
+		return 0, errors.ErrInvalidAltcoinTicker
+	}
+	inputs := core.NewAmount(ticker, 0)
+	for i := range txn.msgTx.TxIn {
+		prevOut, err := txn.resolvePrevOutput(i)
+		if err != nil {
+			return 0, err
+		}
+		if inputs, err = inputs.Add(core.NewAmount(ticker, prevOut.value)); err != nil {
+			return 0, err
+		}
+	}
+	outputs := core.NewAmount(ticker, 0)
+	for _, out := range txn.msgTx.TxOut {
+		var err error
+		if outputs, err = outputs.Add(core.NewAmount(ticker, out.Value)); err != nil {
+			return 0, err
+		}
+	}
+	fee, err := inputs.Sub(outputs)
+	if err != nil {
+		return 0, errors.ErrInvalidTxn
+	}
+	return fee.Coins, nil
+}
+
+func isInputSigned(in *wire.TxIn) bool {
+	return len(in.SignatureScript) > 0 || len(in.Witness) > 0
+}
+
+// VerifyUnsigned checks for valid unsigned transaction
+func (txn *BitcoinTransaction) VerifyUnsigned() error {
+	if len(txn.msgTx.TxIn) == 0 || len(txn.msgTx.TxOut) == 0 {
+		return errors.ErrInvalidTxn
+	}
+	for _, in := range txn.msgTx.TxIn {
+		if isInputSigned(in) {
+			return errors.ErrInvalidTxn
+		}
+	}
+	for _, out := range txn.msgTx.TxOut {
+		if out.Value < txn.params.DustLimit {
+			return errors.ErrInvalidTxn
+		}
+	}
+	_, err := txn.ComputeFee(txn.params.Ticker)
+	return err
+}
+
+// VerifySigned checks that every input carries a valid signature
+func (txn *BitcoinTransaction) VerifySigned() error {
+	if len(txn.msgTx.TxIn) == 0 || len(txn.msgTx.TxOut) == 0 {
+		return errors.ErrInvalidTxn
+	}
+	for i := range txn.msgTx.TxIn {
+		if err := txn.verifyInput(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (txn *BitcoinTransaction) verifyInput(idx int) error {
+	prevOut, err := txn.resolvePrevOutput(idx)
+	if err != nil {
+		return err
+	}
+	in := txn.msgTx.TxIn[idx]
+	var items [][]byte
+	var hash cipher.SHA256
+	switch {
+	case isPayToPubKeyHash(prevOut.pkScript):
+		var ok bool
+		if items, ok = parsePushes(in.SignatureScript); !ok || len(items) != 2 || len(in.Witness) != 0 {
+			return ErrInvalidSignature
+		}
+		if !bytes.Equal(hash160(items[1]), prevOut.pkScript[3:23]) {
+			return ErrInvalidSignature
+		}
+		hash, err = calcSignatureHash(prevOut.pkScript, txn.msgTx, idx)
+	case isPayToWitnessPubKeyHash(prevOut.pkScript):
+		items = in.Witness
+		if len(items) != 2 || len(in.SignatureScript) != 0 {
+			return ErrInvalidSignature
+		}
+		if !bytes.Equal(hash160(items[1]), prevOut.pkScript[2:]) {
+			return ErrInvalidSignature
+		}
+		hash, err = calcWitnessSignatureHash(payToPubKeyHashScript(prevOut.pkScript[2:]), txn.msgTx, idx, prevOut.value)
+	default:
+		return errors.ErrNotImplemented
+	}
+	if err != nil {
+		return err
+	}
+	pubkey, err := cipher.NewPubKey(items[1])
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	return verifyDERSignature(pubkey, items[0], hash)
+}
+
+// IsFullySigned deermine whether all transaction elements have been signed
+func (txn *BitcoinTransaction) IsFullySigned() (bool, error) {
+	for _, in := range txn.msgTx.TxIn {
+		if !isInputSigned(in) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// BitcoinTransactionInput spends an output of a previous transaction
+type BitcoinTransactionInput struct { // Implements TransactionInput interface
+	txn   *BitcoinTransaction
+	index int
+}
+
+// GetId provides transaction input ID
+func (in *BitcoinTransactionInput) GetId() string {
+	return outPointID(in.txn.msgTx.TxIn[in.index].PreviousOutPoint)
+}
+
+// GetSpentOutput looks up the output spent by this input
+func (in *BitcoinTransactionInput) GetSpentOutput() (core.TransactionOutput, error) {
+	prevOut, err := in.txn.resolvePrevOutput(in.index)
+	if err != nil {
+		return nil, err
+	}
+	out := newBitcoinTransactionOutput(in.txn.params, in.txn.msgTx.TxIn[in.index].PreviousOutPoint, prevOut.value, prevOut.pkScript)
+	out.spent = true
+	return out, nil
+}
+
+// GetCoins looks up coins for asset represented by ticker that have been spent by this input
+func (in *BitcoinTransactionInput) GetCoins(ticker string) (uint64, error) {
+	if ticker != in.txn.params.Ticker {
+		return 0, errors.ErrInvalidAltcoinTicker
+	}
+	prevOut, err := in.txn.resolvePrevOutput(in.index)
+	if err != nil {
+		return 0, err
+	}
+	return prevOut.value, nil
+}
+
+// SupportedAssets enumerates tickers of crypto assets supported by this input
+func (in *BitcoinTransactionInput) SupportedAssets() []string {
+	return in.txn.SupportedAssets()
+}
+
+// BitcoinTransactionOutput locks coins with a public key script
+type BitcoinTransactionOutput struct { // Implements TransactionOutput interface
+	params      params.BitcoinParams
+	poolSection string
+	outPoint    wire.OutPoint
+	value       uint64
+	pkScript    []byte
+	spent       bool
+}
+
+func newBitcoinTransactionOutput(p params.BitcoinParams, outPoint wire.OutPoint, value uint64, pkScript []byte) *BitcoinTransactionOutput {
+	return &BitcoinTransactionOutput{
+		params:      p,
+		poolSection: PoolSectionName(p),
+		outPoint:    outPoint,
+		value:       value,
+		pkScript:    pkScript,
+	}
+}
+
+func outPointID(outPoint wire.OutPoint) string {
+	return outPoint.Hash.String() + ":" + strconv.FormatUint(uint64(outPoint.Index), 10)
+}
+
+// parseOutPointID reads outpoint from an output ID formatted as txid:vout
+func parseOutPointID(id string) (wire.OutPoint, error) {
+	idx := strings.LastIndexByte(id, ':')
+	if idx < 0 {
+		return wire.OutPoint{}, errors.ErrInvalidID
+	}
+	hash, err := wire.NewHashFromStr(id[:idx])
+	if err != nil {
+		return wire.OutPoint{}, errors.ErrInvalidID
+	}
+	vout, err := strconv.ParseUint(id[idx+1:], 10, 32)
+	if err != nil {
+		return wire.OutPoint{}, errors.ErrInvalidID
+	}
+	return wire.OutPoint{Hash: hash, Index: uint32(vout)}, nil
+}
+
+// GetId provides transaction output ID
+func (out *BitcoinTransactionOutput) GetId() string {
+	return outPointID(out.outPoint)
+}
+
+// IsSpent determines whether there exists a transaction with an input spending this output
+func (out *BitcoinTransactionOutput) IsSpent() bool {
+	if out.spent {
+		return true
+	}
+	c, err := NewBitcoinRPCClient(out.poolSection)
+	if err != nil {
+		logCoin.WithError(err).Warn("Couldn't get RPC client")
+		return false
+	}
+	defer ReturnBitcoinRPCClient(c)
+	info, err := c.GetTxOut(out.outPoint.Hash.String(), out.outPoint.Index, true)
+	if err != nil {
+		logCoin.WithError(err).Warn("Couldn't look up output")
+		return false
+	}
+	out.spent = info == nil
+	return out.spent
+}
+
+// GetAddress returns the address of the party receiving funds
+func (out *BitcoinTransactionOutput) GetAddress() (core.Address, error) {
+	return newAddressFromScript(out.pkScript, out.params)
+}
+
+// GetCoins looks up coins for asset represented by ticker that have been transferred in this output
+func (out *BitcoinTransactionOutput) GetCoins(ticker string) (uint64, error) {
+	if ticker != out.params.Ticker {
+		return 0, errors.ErrInvalidAltcoinTicker
+	}
+	return out.value, nil
+}
+
+// SupportedAssets enumerates tickers of crypto assets supported by this output
+func (out *BitcoinTransactionOutput) SupportedAssets() []string {
+	return []string{out.params.Ticker}
+}
+
+// BitcoinTransactionIterator iterates over a sequence of transactions
+type BitcoinTransactionIterator struct {
+	current int
+	txns    []core.Transaction
+}
+
+// Value of transaction at iterator pointer position
+func (it *BitcoinTransactionIterator) Value() core.Transaction {
+	return it.txns[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *BitcoinTransactionIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *BitcoinTransactionIterator) HasNext() bool {
+	return (it.current + 1) < len(it.txns)
+}
+
+// NewBitcoinTransactionIterator instantiates iterator over transactions
+func NewBitcoinTransactionIterator(txns []core.Transaction) *BitcoinTransactionIterator {
+	return &BitcoinTransactionIterator{txns: txns, current: -1}
+}
+
+// BitcoinTransactionOutputIterator iterates over a sequence of transaction outputs
+type BitcoinTransactionOutputIterator struct {
+	current int
+	outputs []core.TransactionOutput
+}
+
+// Value of transaction output at iterator pointer position
+func (it *BitcoinTransactionOutputIterator) Value() core.TransactionOutput {
+	return it.outputs[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *BitcoinTransactionOutputIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *BitcoinTransactionOutputIterator) HasNext() bool {
+	return (it.current + 1) < len(it.outputs)
+}
+
+// NewBitcoinTransactionOutputIterator instantiates iterator over outputs
+func NewBitcoinTransactionOutputIterator(outputs []core.TransactionOutput) *BitcoinTransactionOutputIterator {
+	return &BitcoinTransactionOutputIterator{outputs: outputs, current: -1}
+}
+
+// Type assertions
+var (
+	_ core.Transaction               = &BitcoinTransaction{}
+	_ core.TransactionInput          = &BitcoinTransactionInput{}
+	_ core.TransactionOutput         = &BitcoinTransactionOutput{}
+	_ core.TransactionIterator       = &BitcoinTransactionIterator{}
+	_ core.TransactionOutputIterator = &BitcoinTransactionOutputIterator{}
+)
@@ -0,0 +1,122 @@
+package bitcoin
+
+import (
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+// BitcoinPlugin provide support for a Bitcoin-family coin on a given network
+type BitcoinPlugin struct {
+	Params    params.BitcoinParams
+	WalletDir string
+}
+
+// ListSupportedAltcoins to enumerate supported assets and related metadata
+func (p *BitcoinPlugin) ListSupportedAltcoins() []core.AltcoinMetadata {
+	return []core.AltcoinMetadata{
+		core.AltcoinMetadata{
+			Name:          p.Params.Name,
+			Ticker:        p.Params.Ticker,
+			Family:        params.BitcoinFamily,
+			HasBip44:      true,
+			Bip44CoinType: int32(p.Params.Bip44CoinType),
+			Accuracy:      p.Params.Accuracy,
+		},
+	}
+}
+
+// ListSupportedFamilies classifies similar cryptocurrencies into a family
+func (p *BitcoinPlugin) ListSupportedFamilies() []string {
+	return []string{params.BitcoinFamily}
+}
+
+// RegisterTo boilerplate to register this plugin against an altcoin manager and enable it
+func (p *BitcoinPlugin) RegisterTo(manager core.AltcoinManager) {
+	for _, info := range p.ListSupportedAltcoins() {
+		manager.RegisterAltcoin(info, p)
+	}
+}
+
+// GetName provides concise human-readable caption o identify this plugin
+func (p *BitcoinPlugin) GetName() string {
+	return p.Params.Name
+}
+
+// GetDescription describes plugin and its features
+func (p *BitcoinPlugin) GetDescription() string {
+	return params.BitcoinDescription
+}
+
+// LoadWalletEnvs loads wallet environments to lookup and create wallets
+func (p *BitcoinPlugin) LoadWalletEnvs() []core.WalletEnv {
+	return []core.WalletEnv{NewWalletDirectory(p.WalletDir, p.Params)}
+}
+
+// LoadPEX instantiates proxy object to interact with nodes nodes of the P2P network
+func (p *BitcoinPlugin) LoadPEX(netType string) (core.PEX, error) {
+	if netType != p.Params.NetType {
+		return nil, errors.ErrInvalidNetworkType
+	}
+	return NewBitcoinPEX(p.Params), nil
+}
+
+// LoadTransactionAPI blockchain transaction API entry point
+func (p *BitcoinPlugin) LoadTransactionAPI(netType string) (core.BlockchainTransactionAPI, error) {
+	if netType != p.Params.NetType {
+		return nil, errors.ErrInvalidNetworkType
+	}
+	return NewBitcoinBlockchain(p.Params), nil
+}
+
+// LoadSignService sign service entry point
+func (p *BitcoinPlugin) LoadSignService() (core.BlockchainSignService, error) {
+	return &BitcoinSignService{}, nil
+}
+
+// AddressFromString retrieves address corresponding to readable representation
+func (p *BitcoinPlugin) AddressFromString(addrStr string) (core.Address, error) {
+	addr, err := NewBitcoinAddress(addrStr, p.Params)
+	if err != nil {
+		return nil, err
+	}
+	return addr, nil
+}
+
+// PubKeyFromBytes retrieves public key corresponding to binary representation
+func (p *BitcoinPlugin) PubKeyFromBytes(b []byte) (core.PubKey, error) {
+	return btcPubKeyFromBytes(b)
+}
+
+// SecKeyFromBytes retrieves secret key corresponding to binary representation
+func (p *BitcoinPlugin) SecKeyFromBytes(b []byte) (core.SecKey, error) {
+	return btcSecKeyFromBytes(b)
+}
+
+// ListTxnOptions enumerates options accepted when creating transactions
+func (p *BitcoinPlugin) ListTxnOptions(ticker string) []core.TxnOptionSpec {
+	if ticker != p.Params.Ticker {
+		return nil
+	}
+	return []core.TxnOptionSpec{
+		core.TxnOptionSpec{
+			Key:     TxnOptFeeRate,
+			Caption: "Fee rate (per vbyte)",
+			Default: FeeRateAuto,
+		},
+	}
+}
+
+// NewBitcoinPlugin instantiate plugin entry point for network params storing wallets in walletDir
+func NewBitcoinPlugin(p params.BitcoinParams, walletDir string) core.AltcoinPlugin {
+	return &BitcoinPlugin{
+		Params:    p,
+		WalletDir: walletDir,
+	}
+}
+
+// Type assertions
+var (
+	_ core.AltcoinPlugin      = &BitcoinPlugin{}
+	_ core.TxnOptionsProvider = &BitcoinPlugin{}
+)
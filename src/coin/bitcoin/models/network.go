@@ -0,0 +1,214 @@
+package bitcoin
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logNetwork = logging.MustGetLogger("Bitcoin network")
+
+// BitcoinPEX talks to the P2P network through a bitcoind compatible node
+type BitcoinPEX struct { // Implements PEX interface
+	params      params.BitcoinParams
+	poolSection string
+}
+
+// NewBitcoinPEX instantiates PEX for network params
+func NewBitcoinPEX(p params.BitcoinParams) *BitcoinPEX {
+	logNetwork.Info("Creating new Bitcoin PEX")
+	return &BitcoinPEX{params: p, poolSection: PoolSectionName(p)}
+}
+
+func getMempoolTransactions(p params.BitcoinParams, poolSection string) ([]*BitcoinTransaction, error) {
+	c, err := NewBitcoinRPCClient(poolSection)
+	if err != nil {
+		return nil, err
+	}
+	defer ReturnBitcoinRPCClient(c)
+	txids, err := c.GetRawMempool()
+	if err != nil {
+		logNetwork.WithError(err).Warn("Couldn't get mempool")
+		return nil, err
+	}
+	txns := make([]*BitcoinTransaction, 0, len(txids))
+	for _, txid := range txids {
+		rawTxn, err := c.GetRawTransaction(txid)
+		if err != nil {
+			// Transaction might have been confirmed or evicted meanwhile
+			logNetwork.WithError(err).WithField("txid", txid).Warn("Couldn't get mempool transaction")
+			continue
+		}
+		txn, err := NewBitcoinTransactionFromHex(rawTxn, p, core.TXN_STATUS_PENDING)
+		if err != nil {
+			return nil, err
+		}
+		txns = append(txns, txn)
+	}
+	return txns, nil
+}
+
+// GetTxnPool return transactions pending for confirmation by network peers
+func (bpex *BitcoinPEX) GetTxnPool() (core.TransactionIterator, error) {
+	logNetwork.Info("Getting transaction pool")
+	pending, err := getMempoolTransactions(bpex.params, bpex.poolSection)
+	if err != nil {
+		return nil, err
+	}
+	txns := make([]core.Transaction, len(pending))
+	for i, txn := range pending {
+		txns[i] = txn
+	}
+	return NewBitcoinTransactionIterator(txns), nil
+}
+
+// GetConnections enumerate connections to peer nodes
+func (bpex *BitcoinPEX) GetConnections() (core.PexNodeSet, error) {
+	logNetwork.Info("Getting peers")
+	c, err := NewBitcoinRPCClient(bpex.poolSection)
+	if err != nil {
+		return nil, err
+	}
+	defer ReturnBitcoinRPCClient(c)
+	peers, err := c.GetPeerInfo()
+	if err != nil {
+		logNetwork.WithError(err).Warn("Couldn't get peer info")
+		return nil, err
+	}
+	nodes := make([]core.PexNode, 0, len(peers))
+	for _, peer := range peers {
+		nodes = append(nodes, peerInfoToPexNode(peer))
+	}
+	return &BitcoinPexNodeSet{nodes: nodes}, nil
+}
+
+// BroadcastTxn injects a transaction for confirmation by network peers
+func (bpex *BitcoinPEX) BroadcastTxn(txn core.Transaction) error {
+	logNetwork.Info("Broadcasting transaction")
+	btcTxn, isBtcTxn := txn.(*BitcoinTransaction)
+	if !isBtcTxn {
+		return errors.ErrInvalidTxn
+	}
+	c, err := NewBitcoinRPCClient(bpex.poolSection)
+	if err != nil {
+		return err
+	}
+	defer ReturnBitcoinRPCClient(c)
+	if _, err = c.SendRawTransaction(btcTxn.EncodeHex()); err != nil {
+		logNetwork.WithError(err).Warn("Couldn't send raw transaction")
+		return err
+	}
+	btcTxn.status = core.TXN_STATUS_PENDING
+	return nil
+}
+
+func peerInfoToPexNode(peer PeerInfo) *BitcoinPexNode {
+	node := &BitcoinPexNode{
+		Ip:          peer.Addr,
+		LastSeenIn:  peer.LastRecv,
+		LastSeenOut: peer.LastSend,
+		Outbound:    !peer.Inbound,
+	}
+	if host, port, err := net.SplitHostPort(peer.Addr); err == nil {
+		node.Ip = host
+		if p, err := strconv.ParseUint(port, 10, 16); err == nil {
+			node.Port = uint16(p)
+		}
+	}
+	if peer.SyncedBlocks > 0 {
+		node.Block = uint64(peer.SyncedBlocks)
+	}
+	return node
+}
+
+// BitcoinPexNode describes a peer connected to the node
+type BitcoinPexNode struct { // Implements PexNode interface
+	Ip          string
+	Port        uint16
+	Outbound    bool
+	Block       uint64
+	LastSeenIn  int64
+	LastSeenOut int64
+}
+
+// GetIp returns node IP network address
+func (node *BitcoinPexNode) GetIp() string {
+	return node.Ip
+}
+
+// GetPort retrieves IP port used to connect to peer node
+func (node *BitcoinPexNode) GetPort() uint16 {
+	return node.Port
+}
+
+// GetBlockHeight provides sequence number of the block a the tip of peer's chain
+func (node *BitcoinPexNode) GetBlockHeight() uint64 {
+	return node.Block
+}
+
+// IsTrusted determines if peer node was chosen by the node itself
+func (node *BitcoinPexNode) IsTrusted() bool {
+	return node.Outbound
+}
+
+// GetLastSeenIn timestamp of last message received from peer
+func (node *BitcoinPexNode) GetLastSeenIn() int64 {
+	return node.LastSeenIn
+}
+
+// GetLastSeenOut timestamp of last message sent to peer
+func (node *BitcoinPexNode) GetLastSeenOut() int64 {
+	return node.LastSeenOut
+}
+
+// BitcoinPexNodeSet set of peers connected to the node
+type BitcoinPexNodeSet struct { // Implements PexNodeSet interface
+	nodes []core.PexNode
+}
+
+// ListPeers offers an iterator over this set of nodes
+func (set *BitcoinPexNodeSet) ListPeers() core.PexNodeIterator {
+	return NewBitcoinPexNodeIterator(set.nodes)
+}
+
+// BitcoinPexNodeIterator iterates over peers
+type BitcoinPexNodeIterator struct {
+	current int
+	nodes   []core.PexNode
+}
+
+// Value of PEX node data instance at iterator pointer position
+func (it *BitcoinPexNodeIterator) Value() core.PexNode {
+	return it.nodes[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *BitcoinPexNodeIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *BitcoinPexNodeIterator) HasNext() bool {
+	return (it.current + 1) < len(it.nodes)
+}
+
+// NewBitcoinPexNodeIterator instantiates iterator over peers
+func NewBitcoinPexNodeIterator(nodes []core.PexNode) *BitcoinPexNodeIterator {
+	return &BitcoinPexNodeIterator{nodes: nodes, current: -1}
+}
+
+// Type assertions
+var (
+	_ core.PEX             = &BitcoinPEX{}
+	_ core.PexNode         = &BitcoinPexNode{}
+	_ core.PexNodeSet      = &BitcoinPexNodeSet{}
+	_ core.PexNodeIterator = &BitcoinPexNodeIterator{}
+)
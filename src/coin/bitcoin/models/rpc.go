@@ -0,0 +1,330 @@
+package bitcoin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+// BlockchainInfo subset of getblockchaininfo response
+type BlockchainInfo struct {
+	Chain         string `json:"chain"`
+	Blocks        uint64 `json:"blocks"`
+	Headers       uint64 `json:"headers"`
+	BestBlockHash string `json:"bestblockhash"`
+	MedianTime    int64  `json:"mediantime"`
+	Pruned        bool   `json:"pruned"`
+}
+
+// BlockHeader subset of getblockheader verbose response
+type BlockHeader struct {
+	Hash              string `json:"hash"`
+	Height            uint64 `json:"height"`
+	Version           uint32 `json:"version"`
+	Time              int64  `json:"time"`
+	PreviousBlockHash string `json:"previousblockhash"`
+}
+
+// PeerInfo subset of getpeerinfo response items
+type PeerInfo struct {
+	Addr          string `json:"addr"`
+	Inbound       bool   `json:"inbound"`
+	LastSend      int64  `json:"lastsend"`
+	LastRecv      int64  `json:"lastrecv"`
+	SyncedBlocks  int64  `json:"synced_blocks"`
+	StartingBlock int64  `json:"startingheight"`
+}
+
+// FeeEstimate estimatesmartfee response
+type FeeEstimate struct {
+	// FeeRate expressed in coins per kilo virtual byte
+	FeeRate json.Number `json:"feerate"`
+	Errors  []string    `json:"errors"`
+	Blocks  int64       `json:"blocks"`
+}
+
+// UnspentOutput item of scantxoutset response
+type UnspentOutput struct {
+	TxID         string      `json:"txid"`
+	Vout         uint32      `json:"vout"`
+	ScriptPubKey string      `json:"scriptPubKey"`
+	Desc         string      `json:"desc"`
+	Amount       json.Number `json:"amount"`
+	Height       uint64      `json:"height"`
+}
+
+// ScanResult scantxoutset response
+type ScanResult struct {
+	Success     bool            `json:"success"`
+	Height      uint64          `json:"height"`
+	Unspents    []UnspentOutput `json:"unspents"`
+	TotalAmount json.Number     `json:"total_amount"`
+}
+
+// TxOutInfo gettxout response
+type TxOutInfo struct {
+	BestBlock     string      `json:"bestblock"`
+	Confirmations uint64      `json:"confirmations"`
+	Value         json.Number `json:"value"`
+	ScriptPubKey  struct {
+		Hex string `json:"hex"`
+	} `json:"scriptPubKey"`
+}
+
+// BitcoinRPC is the subset of bitcoind JSON-RPC API used by the plugin
+type BitcoinRPC interface {
+	GetBlockchainInfo() (*BlockchainInfo, error)
+	GetBestBlockHash() (string, error)
+	GetBlockHeader(hash string) (*BlockHeader, error)
+	GetRawMempool() ([]string, error)
+	GetRawTransaction(txid string) (string, error)
+	GetTxOut(txid string, vout uint32, includeMempool bool) (*TxOutInfo, error)
+	GetPeerInfo() ([]PeerInfo, error)
+	SendRawTransaction(hexTxn string) (string, error)
+	EstimateSmartFee(confTarget int) (*FeeEstimate, error)
+	ScanTxOutSet(descriptors []string) (*ScanResult, error)
+}
+
+// RPCError is returned by nodes when a JSON-RPC call fails
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+	ID     uint64          `json:"id"`
+}
+
+// RPCClient talks to a bitcoind compatible node over HTTP JSON-RPC
+type RPCClient struct {
+	url      string
+	user     string
+	password string
+	client   *http.Client
+	lastID   uint64
+}
+
+// NewRPCClient instantiates a JSON-RPC client, credentials are optional
+func NewRPCClient(url, user, password string) *RPCClient {
+	return &RPCClient{
+		url:      url,
+		user:     user,
+		password: password,
+		client:   &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Call invokes JSON-RPC method and decodes its result, if not nil
+func (c *RPCClient) Call(method string, result interface{}, args ...interface{}) error {
+	if args == nil {
+		args = []interface{}{}
+	}
+	reqBody, err := json.Marshal(rpcRequest{
+		JSONRPC: "1.0",
+		ID:      atomic.AddUint64(&c.lastID, 1),
+		Method:  method,
+		Params:  args,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.user != "" || c.password != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("RPC call %s rejected with HTTP status %d", method, resp.StatusCode)
+	}
+	var rpcResp rpcResponse
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&rpcResp); err != nil {
+		return fmt.Errorf("RPC call %s failed with HTTP status %d: %v", method, resp.StatusCode, err)
+	}
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	dec = json.NewDecoder(bytes.NewReader(rpcResp.Result))
+	dec.UseNumber()
+	return dec.Decode(result)
+}
+
+// GetBlockchainInfo returns the state of the node's best chain
+func (c *RPCClient) GetBlockchainInfo() (*BlockchainInfo, error) {
+	var info BlockchainInfo
+	if err := c.Call("getblockchaininfo", &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetBestBlockHash returns the hash of the block at the tip of the chain
+func (c *RPCClient) GetBestBlockHash() (string, error) {
+	var hash string
+	err := c.Call("getbestblockhash", &hash)
+	return hash, err
+}
+
+// GetBlockHeader returns header data of block identified by hash
+func (c *RPCClient) GetBlockHeader(hash string) (*BlockHeader, error) {
+	var header BlockHeader
+	if err := c.Call("getblockheader", &header, hash, true); err != nil {
+		return nil, err
+	}
+	return &header, nil
+}
+
+// GetRawMempool lists IDs of transactions pending for confirmation
+func (c *RPCClient) GetRawMempool() ([]string, error) {
+	var txids []string
+	err := c.Call("getrawmempool", &txids, false)
+	return txids, err
+}
+
+// GetRawTransaction returns hex-encoded transaction
+func (c *RPCClient) GetRawTransaction(txid string) (string, error) {
+	var rawTxn string
+	err := c.Call("getrawtransaction", &rawTxn, txid, false)
+	return rawTxn, err
+}
+
+// GetTxOut returns details of an unspent output, nil if spent or unknown
+func (c *RPCClient) GetTxOut(txid string, vout uint32, includeMempool bool) (*TxOutInfo, error) {
+	var info *TxOutInfo
+	err := c.Call("gettxout", &info, txid, vout, includeMempool)
+	return info, err
+}
+
+// GetPeerInfo lists peers connected to the node
+func (c *RPCClient) GetPeerInfo() ([]PeerInfo, error) {
+	var peers []PeerInfo
+	err := c.Call("getpeerinfo", &peers)
+	return peers, err
+}
+
+// SendRawTransaction submits hex-encoded transaction and returns its ID
+func (c *RPCClient) SendRawTransaction(hexTxn string) (string, error) {
+	var txid string
+	err := c.Call("sendrawtransaction", &txid, hexTxn)
+	return txid, err
+}
+
+// EstimateSmartFee estimates fee rate needed for confirmation within confTarget blocks
+func (c *RPCClient) EstimateSmartFee(confTarget int) (*FeeEstimate, error) {
+	var estimate FeeEstimate
+	if err := c.Call("estimatesmartfee", &estimate, confTarget); err != nil {
+		return nil, err
+	}
+	return &estimate, nil
+}
+
+// ScanTxOutSet looks up confirmed unspent outputs matching output descriptors
+func (c *RPCClient) ScanTxOutSet(descriptors []string) (*ScanResult, error) {
+	var result ScanResult
+	if err := c.Call("scantxoutset", &result, "start", descriptors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BitcoinConnectionFactory creates RPC clients for connection pool
+type BitcoinConnectionFactory struct {
+	url      string
+	user     string
+	password string
+}
+
+// Create instantiates a new RPC client
+func (cf *BitcoinConnectionFactory) Create() (interface{}, error) {
+	return NewRPCClient(cf.url, cf.user, cf.password), nil
+}
+
+// NewBitcoinConnectionFactory instantiates factory of clients for node at url
+func NewBitcoinConnectionFactory(url, user, password string) *BitcoinConnectionFactory {
+	return &BitcoinConnectionFactory{
+		url:      url,
+		user:     user,
+		password: password,
+	}
+}
+
+// PoolSectionName connection pool section used by plugin for network params
+func PoolSectionName(p params.BitcoinParams) string {
+	return "bitcoin." + p.Ticker + "." + p.NetType
+}
+
+type bitcoinRPCClient struct {
+	BitcoinRPC
+	pool core.MultiPoolSection
+}
+
+// NewBitcoinRPCClient takes a client out of connection pool section
+func NewBitcoinRPCClient(section string) (BitcoinRPC, error) {
+	pool, err := core.GetMultiPool().GetSection(section)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := pool.Get()
+	for err == errors.ErrObjectPoolUndeflow {
+		obj, err = pool.Get()
+	}
+	if err != nil {
+		return nil, err
+	}
+	rpc, ok := obj.(BitcoinRPC)
+	if !ok {
+		logNetwork.Errorf("There is no proper client in %s pool", section)
+		return nil, errors.ErrInvalidPoolObjectType
+	}
+	return &bitcoinRPCClient{
+		BitcoinRPC: rpc,
+		pool:       pool,
+	}, nil
+}
+
+// ReturnBitcoinRPCClient puts client back in connection pool
+func ReturnBitcoinRPCClient(obj BitcoinRPC) {
+	poolObj, ok := obj.(*bitcoinRPCClient)
+	if !ok {
+		return
+	}
+	poolObj.pool.Put(poolObj.BitcoinRPC)
+}
+
+// Type assertions
+var (
+	_ BitcoinRPC               = &RPCClient{}
+	_ core.PooledObjectFactory = &BitcoinConnectionFactory{}
+)
@@ -0,0 +1,97 @@
+package bitcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/SkycoinProject/skycoin/src/cipher/ripemd160"
+)
+
+// Script opcodes used by standard output templates
+const (
+	opDup         = 0x76
+	opHash160     = 0xa9
+	opEqual       = 0x87
+	opEqualVerify = 0x88
+	opCheckSig    = 0xac
+	op0           = 0x00
+	opPushData1   = 0x4c
+)
+
+// hash160 computes RIPEMD160(SHA256(b))
+func hash160(b []byte) []byte {
+	sha := sha256.Sum256(b)
+	h := ripemd160.New()
+	_, _ = h.Write(sha[:])
+	return h.Sum(nil)
+}
+
+func payToPubKeyHashScript(pkHash []byte) []byte {
+	script := []byte{opDup, opHash160, byte(len(pkHash))}
+	script = append(script, pkHash...)
+	return append(script, opEqualVerify, opCheckSig)
+}
+
+func payToScriptHashScript(scriptHash []byte) []byte {
+	script := []byte{opHash160, byte(len(scriptHash))}
+	script = append(script, scriptHash...)
+	return append(script, opEqual)
+}
+
+func payToWitnessScript(program []byte) []byte {
+	return append([]byte{op0, byte(len(program))}, program...)
+}
+
+func isPayToPubKeyHash(script []byte) bool {
+	return len(script) == 25 && script[0] == opDup && script[1] == opHash160 &&
+		script[2] == 20 && script[23] == opEqualVerify && script[24] == opCheckSig
+}
+
+func isPayToScriptHash(script []byte) bool {
+	return len(script) == 23 && script[0] == opHash160 && script[1] == 20 && script[22] == opEqual
+}
+
+func isPayToWitnessPubKeyHash(script []byte) bool {
+	return len(script) == 22 && script[0] == op0 && script[1] == 20
+}
+
+func isPayToWitnessScriptHash(script []byte) bool {
+	return len(script) == 34 && script[0] == op0 && script[1] == 32
+}
+
+// pushData builds a script pushing data onto the stack
+func pushData(data []byte) []byte {
+	if len(data) < opPushData1 {
+		return append([]byte{byte(len(data))}, data...)
+	}
+	return append([]byte{opPushData1, byte(len(data))}, data...)
+}
+
+// parsePushes splits a push-only script into data items
+func parsePushes(script []byte) ([][]byte, bool) {
+	items := make([][]byte, 0, 2)
+	r := bytes.NewReader(script)
+	for r.Len() > 0 {
+		op, _ := r.ReadByte()
+		var n int
+		switch {
+		case op < opPushData1:
+			n = int(op)
+		case op == opPushData1:
+			l, err := r.ReadByte()
+			if err != nil {
+				return nil, false
+			}
+			n = int(l)
+		default:
+			return nil, false
+		}
+		if r.Len() < n {
+			return nil, false
+		}
+		item := make([]byte, n)
+		_, _ = r.Read(item)
+		items = append(items, item)
+	}
+	return items, true
+}
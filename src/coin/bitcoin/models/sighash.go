@@ -0,0 +1,152 @@
+package bitcoin
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/wire"
+)
+
+// sigHashAll signs all inputs and outputs
+const sigHashAll = 0x01
+
+var (
+	// ErrInvalidSignature signature encoding is malformed or does not match public key
+	ErrInvalidSignature = errors.New("Invalid transaction signature")
+	// ErrInputIndexOutOfRange input index does not exist in transaction
+	ErrInputIndexOutOfRange = errors.New("Input index out of range")
+)
+
+// calcSignatureHash computes legacy SIGHASH_ALL digest of input spending subScript
+func calcSignatureHash(subScript []byte, tx *wire.MsgTx, idx int) (cipher.SHA256, error) {
+	if idx < 0 || idx >= len(tx.TxIn) {
+		return cipher.SHA256{}, ErrInputIndexOutOfRange
+	}
+	txCopy := tx.Copy()
+	for i, in := range txCopy.TxIn {
+		in.Witness = nil
+		if i == idx {
+			in.SignatureScript = subScript
+		} else {
+			in.SignatureScript = nil
+		}
+	}
+	var buf bytes.Buffer
+	_ = txCopy.Serialize(&buf, false)
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(sigHashAll))
+	return cipher.SHA256(wire.DoubleHashH(buf.Bytes())), nil
+}
+
+// calcWitnessSignatureHash computes BIP143 SIGHASH_ALL digest of segwit version 0 input
+func calcWitnessSignatureHash(scriptCode []byte, tx *wire.MsgTx, idx int, amount uint64) (cipher.SHA256, error) {
+	if idx < 0 || idx >= len(tx.TxIn) {
+		return cipher.SHA256{}, ErrInputIndexOutOfRange
+	}
+	var prevOuts, sequences, outputs bytes.Buffer
+	for _, in := range tx.TxIn {
+		prevOuts.Write(in.PreviousOutPoint.Hash[:])
+		_ = binary.Write(&prevOuts, binary.LittleEndian, in.PreviousOutPoint.Index)
+		_ = binary.Write(&sequences, binary.LittleEndian, in.Sequence)
+	}
+	for _, out := range tx.TxOut {
+		_ = binary.Write(&outputs, binary.LittleEndian, out.Value)
+		_ = wire.WriteVarBytes(&outputs, out.PkScript)
+	}
+	hashPrevOuts := wire.DoubleHashH(prevOuts.Bytes())
+	hashSequence := wire.DoubleHashH(sequences.Bytes())
+	hashOutputs := wire.DoubleHashH(outputs.Bytes())
+
+	in := tx.TxIn[idx]
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.LittleEndian, tx.Version)
+	buf.Write(hashPrevOuts[:])
+	buf.Write(hashSequence[:])
+	buf.Write(in.PreviousOutPoint.Hash[:])
+	_ = binary.Write(&buf, binary.LittleEndian, in.PreviousOutPoint.Index)
+	_ = wire.WriteVarBytes(&buf, scriptCode)
+	_ = binary.Write(&buf, binary.LittleEndian, amount)
+	_ = binary.Write(&buf, binary.LittleEndian, in.Sequence)
+	buf.Write(hashOutputs[:])
+	_ = binary.Write(&buf, binary.LittleEndian, tx.LockTime)
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(sigHashAll))
+	return cipher.SHA256(wire.DoubleHashH(buf.Bytes())), nil
+}
+
+// signatureToDER encodes compact secp256k1 signature in DER format followed by hash type
+func signatureToDER(sig cipher.Sig) []byte {
+	r := canonicalInt(sig[:32])
+	s := canonicalInt(sig[32:64])
+	der := []byte{0x30, byte(4 + len(r) + len(s)), 0x02, byte(len(r))}
+	der = append(der, r...)
+	der = append(der, 0x02, byte(len(s)))
+	der = append(der, s...)
+	return append(der, sigHashAll)
+}
+
+func canonicalInt(b []byte) []byte {
+	for len(b) > 1 && b[0] == 0 && b[1]&0x80 == 0 {
+		b = b[1:]
+	}
+	if b[0]&0x80 != 0 {
+		return append([]byte{0}, b...)
+	}
+	return append([]byte(nil), b...)
+}
+
+// parseDERSignature decodes DER signature followed by SIGHASH_ALL into r and s
+func parseDERSignature(der []byte) ([]byte, []byte, error) {
+	if len(der) < 9 || der[len(der)-1] != sigHashAll {
+		return nil, nil, ErrInvalidSignature
+	}
+	der = der[:len(der)-1]
+	if der[0] != 0x30 || int(der[1]) != len(der)-2 {
+		return nil, nil, ErrInvalidSignature
+	}
+	readInt := func(b []byte) ([]byte, []byte, error) {
+		if len(b) < 2 || b[0] != 0x02 || int(b[1])+2 > len(b) || b[1] == 0 {
+			return nil, nil, ErrInvalidSignature
+		}
+		n := int(b[1])
+		v := b[2 : 2+n]
+		for len(v) > 0 && v[0] == 0 {
+			v = v[1:]
+		}
+		if len(v) > 32 {
+			return nil, nil, ErrInvalidSignature
+		}
+		padded := make([]byte, 32)
+		copy(padded[32-len(v):], v)
+		return padded, b[2+n:], nil
+	}
+	r, rest, err := readInt(der[2:])
+	if err != nil {
+		return nil, nil, err
+	}
+	s, rest, err := readInt(rest)
+	if err != nil || len(rest) != 0 {
+		return nil, nil, ErrInvalidSignature
+	}
+	return r, s, nil
+}
+
+// verifyDERSignature checks DER signature of hash against public key
+func verifyDERSignature(pubkey cipher.PubKey, der []byte, hash cipher.SHA256) error {
+	r, s, err := parseDERSignature(der)
+	if err != nil {
+		return err
+	}
+	var sig cipher.Sig
+	copy(sig[:32], r)
+	copy(sig[32:64], s)
+	// Compact signatures also carry recovery ID, so try all possible values
+	for recid := byte(0); recid < 4; recid++ {
+		sig[64] = recid
+		recovered, err := cipher.PubKeyFromSig(sig, hash)
+		if err == nil && recovered == pubkey {
+			return cipher.VerifyPubKeySignedHash(pubkey, sig, hash)
+		}
+	}
+	return ErrInvalidSignature
+}
@@ -0,0 +1,141 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/wire"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func decodeMsgTx(t *testing.T, s string) *wire.MsgTx {
+	tx, err := wire.DeserializeBytes(decodeHex(t, s))
+	require.NoError(t, err)
+	return tx
+}
+
+func TestCalcSignatureHash(t *testing.T) {
+	// First transaction between users, block 170, spending P2PK output of block 9 coinbase
+	tx := decodeMsgTx(t, "0100000001c997a5e56e104102fa209c6a852dd90660a20b2d9c352423edce25857fcd3704000000004847304402204e45e16932b8af514961a1d3a1a25fdf3f4f7732e9d624c6c61548ab5fb8cd410220181522ec8eca07de4860a4acdd12909d831cc56cbbac4622082221a8768d1d0901ffffffff0200ca9a3b00000000434104ae1a62fe09c5f51b13905f07f06b99a2f7159b2225f374cd378d71302fa28414e7aab37397f554a7df5f142c21c1b7303b8a0626f1baded5c72a704f7e6cd84cac00286bee0000000043410411db93e1dcdb8a016b49840f8c53bc1eb68a382e97b1482ecad7b148a6909a5cb2e0eaddfb84ccf9744464f82e160bfa9b8b64f9d4c03f999b8643f656b412a3ac00000000")
+	require.Equal(t, "f4184fc596403b9d638783cf57adfe4c75c605f6356fbc91338530e9831e9e16", tx.TxHash().String())
+	pkScript := decodeHex(t, "410411db93e1dcdb8a016b49840f8c53bc1eb68a382e97b1482ecad7b148a6909a5cb2e0eaddfb84ccf9744464f82e160bfa9b8b64f9d4c03f999b8643f656b412a3ac")
+	items, ok := parsePushes(tx.TxIn[0].SignatureScript)
+	require.True(t, ok)
+	require.Len(t, items, 1)
+
+	hash, err := calcSignatureHash(pkScript, tx, 0)
+	require.NoError(t, err)
+	require.Equal(t, "7a05c6145f10101e9d6325494245adf1297d80f8f38d4d576d57cdba220bcb19", hash.Hex())
+	// Compressed form of uncompressed public key in output script
+	pubkey := cipher.MustNewPubKey(decodeHex(t, "0311db93e1dcdb8a016b49840f8c53bc1eb68a382e97b1482ecad7b148a6909a5c"))
+	require.NoError(t, verifyDERSignature(pubkey, items[0], hash))
+
+	_, err = calcSignatureHash(pkScript, tx, 1)
+	require.Equal(t, ErrInputIndexOutOfRange, err)
+}
+
+func TestCalcWitnessSignatureHash(t *testing.T) {
+	// Test vectors from BIP143
+	tests := []struct {
+		name    string
+		tx      string
+		idx     int
+		amount  uint64
+		pkHash  string
+		sigHash string
+		pubkey  string
+		sig     string
+		secKey  string
+	}{
+		{
+			name:    "native P2WPKH",
+			tx:      "0100000002fff7f7881a8099afa6940d42d1e7f6362bec38171ea3edf433541db4e4ad969f0000000000eeffffffef51e1b804cc89d182d279655c3aa89e815b1b309fe287d9b2b55d57b90ec68a0100000000ffffffff02202cb206000000001976a9148280b37df378db99f66f85c95a783a76ac7a6d5988ac9093510d000000001976a9143bde42dbee7e4dbe6a21b2d50ce2f0167faa815988ac11000000",
+			idx:     1,
+			amount:  600000000,
+			pkHash:  "1d0f172a0ecb48aee1be1f2687d2963ae33f71a1",
+			sigHash: "c37af31116d1b27caf68aae9e3ac82f1477929014d5b917657d0eb49478cb670",
+			pubkey:  "025476c2e83188368da1ff3e292e7acafcdb3566bb0ad253f62fc70f07aeee6357",
+			sig:     "304402203609e17b84f6a7d30c80bfa610b5b4542f32a8a0d5447a12fb1366d7f01cc44a0220573a954c4518331561406f90300e8f3358f51928d43c212a8caed02de67eebee01",
+			secKey:  "619c335025c7f4012e556c2a58b2506e30b8511b53ade95ea316fd8c3286feb9",
+		},
+		{
+			name:    "P2SH-P2WPKH",
+			tx:      "0100000001db6b1b20aa0fd7b23880be2ecbd4a98130974cf4748fb66092ac4d3ceb1a54770100000000feffffff02b8b4eb0b000000001976a914a457b684d7f0d539a46a45bbc043f35b59d0d96388ac0008af2f000000001976a914fd270b1ee6abcaea97fea7ad0402e8bd8ad6d77c88ac92040000",
+			idx:     0,
+			amount:  1000000000,
+			pkHash:  "79091972186c449eb1ded22b78e40d009bdf0089",
+			sigHash: "64f3b0f4dd2bb3aa1ce8566d220cc74dda9df97d8490cc81d89d735c92e59fb6",
+			pubkey:  "03ad1d8e89212f0b92c74d23bb710c00662ad1470198ac48c43f7d6f93a2a26873",
+			sig:     "3044022047ac8e878352d3ebbde1c94ce3a10d057c24175747116f8288e5d794d12d482f0220217f36a485cae903c713331d877c1f64677e3622ad4010726870540656fe9dcb01",
+			secKey:  "eb696a065ef48a2192da5b28b694f87544b30fae8327c4510137a922f32c6dcf",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := decodeMsgTx(t, tt.tx)
+			scriptCode := payToPubKeyHashScript(decodeHex(t, tt.pkHash))
+			hash, err := calcWitnessSignatureHash(scriptCode, tx, tt.idx, tt.amount)
+			require.NoError(t, err)
+			require.Equal(t, tt.sigHash, hash.Hex())
+
+			pubkey := cipher.MustNewPubKey(decodeHex(t, tt.pubkey))
+			require.Equal(t, tt.pkHash, hex.EncodeToString(hash160(pubkey[:])))
+			require.NoError(t, verifyDERSignature(pubkey, decodeHex(t, tt.sig), hash))
+
+			// Signature made by wallet key must verify as well
+			sk := cipher.MustNewSecKey(decodeHex(t, tt.secKey))
+			require.Equal(t, pubkey, cipher.MustPubKeyFromSecKey(sk))
+			sig := cipher.MustSignHash(hash, sk)
+			require.NoError(t, verifyDERSignature(pubkey, signatureToDER(sig), hash))
+
+			_, err = calcWitnessSignatureHash(scriptCode, tx, len(tx.TxIn), tt.amount)
+			require.Equal(t, ErrInputIndexOutOfRange, err)
+		})
+	}
+}
+
+func TestSignInputRoundTrip(t *testing.T) {
+	sk := cipher.MustNewSecKey(decodeHex(t, "619c335025c7f4012e556c2a58b2506e30b8511b53ade95ea316fd8c3286feb9"))
+	pk := cipher.MustPubKeyFromSecKey(sk)
+	pkHash := hash160(pk[:])
+	prevOuts := []prevOutput{
+		{value: 100000, pkScript: payToPubKeyHashScript(pkHash), known: true},
+		{value: 200000, pkScript: payToWitnessScript(pkHash), known: true},
+	}
+	msgTx := wire.NewMsgTx(2)
+	for i := range prevOuts {
+		msgTx.TxIn = append(msgTx.TxIn, &wire.TxIn{
+			PreviousOutPoint: wire.OutPoint{Hash: wire.DoubleHashH([]byte{byte(i)}), Index: uint32(i)},
+			Sequence:         0xffffffff,
+		})
+	}
+	msgTx.TxOut = append(msgTx.TxOut, &wire.TxOut{Value: 290000, PkScript: payToWitnessScript(pkHash)})
+	txn := newBitcoinTransaction(params.BitcoinRegTestParams, msgTx, prevOuts, core.TXN_STATUS_CREATED)
+
+	for i, prevOut := range prevOuts {
+		require.NoError(t, signInput(txn, i, prevOut, sk))
+	}
+	require.NotEmpty(t, msgTx.TxIn[0].SignatureScript)
+	require.Empty(t, msgTx.TxIn[0].Witness)
+	require.Empty(t, msgTx.TxIn[1].SignatureScript)
+	require.Len(t, msgTx.TxIn[1].Witness, 2)
+	require.NoError(t, txn.VerifySigned())
+
+	// Signatures commit to outputs and to the amount of witness inputs
+	msgTx.TxOut[0].Value--
+	require.Equal(t, ErrInvalidSignature, txn.verifyInput(0))
+	require.Equal(t, ErrInvalidSignature, txn.verifyInput(1))
+	msgTx.TxOut[0].Value++
+	txn.prevOuts[1].value++
+	require.NoError(t, txn.verifyInput(0))
+	require.Equal(t, ErrInvalidSignature, txn.verifyInput(1))
+}
@@ -0,0 +1,19 @@
+package bitcoin
+
+import (
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+)
+
+// BitcoinSignService implements BlockchainSignService for multi-wallet transaction signing
+type BitcoinSignService struct{}
+
+// Sign creates a new transaction by (fully or partially) signing a given transaction
+func (bss *BitcoinSignService) Sign(txn core.Transaction, signSpec []core.InputSignDescriptor, pwd core.PasswordReader) (core.Transaction, error) {
+	return util.GenericMultiWalletSign(txn, signSpec, pwd)
+}
+
+// Type assertions
+var (
+	_ core.BlockchainSignService = &BitcoinSignService{}
+)
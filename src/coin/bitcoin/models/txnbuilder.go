@@ -0,0 +1,267 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/wire"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+const (
+	// TxnOptFeeRate option key for fee rate expressed in base units per virtual byte
+	TxnOptFeeRate = "FeeRate"
+	// FeeRateAuto asks the node to estimate fee rate
+	FeeRateAuto = "auto"
+	// DefaultConfTarget number of blocks targeted for confirmation when estimating fees
+	DefaultConfTarget = 6
+
+	txnVersion = 2
+	// Weight of version, lock time and one byte input and output counters
+	txnBaseWeight = (4 + 4 + 1 + 1) * wire.WitnessScaleFactor
+	// Weight of segwit marker and flag
+	txnWitnessFlagWeight = 2
+	// Weight of a P2PKH input with a 72 bytes signature and a compressed public key
+	p2pkhInputWeight = (32 + 4 + 1 + 107 + 4) * wire.WitnessScaleFactor
+	// Weight of a P2WPKH input with a 72 bytes signature and a compressed public key
+	p2wpkhInputWeight = (32+4+1+4)*wire.WitnessScaleFactor + 1 + 73 + 34
+)
+
+// resolveFeeRate reads fee rate from transaction options, estimating it with the node if requested
+func resolveFeeRate(p params.BitcoinParams, poolSection string, options core.KeyValueStore) (uint64, error) {
+	var value interface{}
+	if options != nil {
+		value = options.GetValue(TxnOptFeeRate)
+	}
+	switch v := value.(type) {
+	case nil:
+		return estimateFeeRate(p, poolSection), nil
+	case uint64:
+		return v, nil
+	case int:
+		if v < 0 {
+			return 0, errors.ErrInvalidOptions
+		}
+		return uint64(v), nil
+	case string:
+		if v == "" || v == FeeRateAuto {
+			return estimateFeeRate(p, poolSection), nil
+		}
+		rate, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return 0, errors.ErrInvalidOptions
+		}
+		return rate, nil
+	}
+	return 0, errors.ErrInvalidOptions
+}
+
+// estimateFeeRate asks node for fee rate, falling back to network default
+func estimateFeeRate(p params.BitcoinParams, poolSection string) uint64 {
+	c, err := NewBitcoinRPCClient(poolSection)
+	if err != nil {
+		logWallet.WithError(err).Warn("Couldn't get RPC client to estimate fees")
+		return p.DefaultFeeRate
+	}
+	defer ReturnBitcoinRPCClient(c)
+	estimate, err := c.EstimateSmartFee(DefaultConfTarget)
+	if err != nil || estimate.FeeRate == "" {
+		logWallet.WithError(err).Warn("Fee estimation not available, using default fee rate")
+		return p.DefaultFeeRate
+	}
+	// Node returns coins per kilo virtual byte
+	perKvB, err := core.ParseAmountTruncated(p.Ticker, estimate.FeeRate.String(), p.Accuracy)
+	if err != nil {
+		logWallet.WithError(err).Warn("Invalid fee estimation, using default fee rate")
+		return p.DefaultFeeRate
+	}
+	rate := (perKvB.Coins + 999) / 1000
+	if rate == 0 {
+		rate = 1
+	}
+	return rate
+}
+
+func inputWeight(pkScript []byte) (int, error) {
+	switch {
+	case isPayToPubKeyHash(pkScript):
+		return p2pkhInputWeight, nil
+	case isPayToWitnessPubKeyHash(pkScript):
+		return p2wpkhInputWeight, nil
+	}
+	return 0, errors.ErrNotImplemented
+}
+
+func outputWeight(pkScript []byte) int {
+	return (8 + 1 + len(pkScript)) * wire.WitnessScaleFactor
+}
+
+// destinationOutputs converts generic outputs into outputs paying to addresses of network params
+func destinationOutputs(p params.BitcoinParams, to []core.TransactionOutput) ([]*wire.TxOut, core.Amount, error) {
+	outs := make([]*wire.TxOut, 0, len(to))
+	total := core.NewAmount(p.Ticker, 0)
+	for _, out := range to {
+		genericAddr, err := out.GetAddress()
+		if err != nil {
+			return nil, total, err
+		}
+		addr, err := NewBitcoinAddress(genericAddr.String(), p)
+		if err != nil {
+			return nil, total, err
+		}
+		coins, err := out.GetCoins(p.Ticker)
+		if err != nil {
+			return nil, total, err
+		}
+		if coins < p.DustLimit {
+			return nil, total, errors.ErrInvalidValue
+		}
+		if total, err = total.Add(core.NewAmount(p.Ticker, coins)); err != nil {
+			return nil, total, err
+		}
+		outs = append(outs, &wire.TxOut{Value: coins, PkScript: addr.ScriptPubKey()})
+	}
+	if len(outs) == 0 {
+		return nil, total, errors.ErrInvalidValue
+	}
+	return outs, total, nil
+}
+
+// buildTransaction selects outputs to spend and pays fees at the requested rate.
+// If spendAll is set every candidate output is spent, otherwise largest outputs are selected first.
+func buildTransaction(p params.BitcoinParams, candidates []*BitcoinTransactionOutput, to []core.TransactionOutput,
+	change *BitcoinAddress, options core.KeyValueStore, spendAll bool) (*BitcoinTransaction, error) {
+	if change == nil {
+		return nil, errors.ErrInvalidAddressString
+	}
+	feeRate, err := resolveFeeRate(p, PoolSectionName(p), options)
+	if err != nil {
+		return nil, err
+	}
+	outs, target, err := destinationOutputs(p, to)
+	if err != nil {
+		return nil, err
+	}
+	utxos := append([]*BitcoinTransactionOutput(nil), candidates...)
+	if !spendAll {
+		sort.SliceStable(utxos, func(i, j int) bool {
+			return utxos[i].value > utxos[j].value
+		})
+	}
+
+	weight := txnBaseWeight
+	for _, out := range outs {
+		weight += outputWeight(out.PkScript)
+	}
+	changeScript := change.ScriptPubKey()
+	hasWitness := false
+	total := core.NewAmount(p.Ticker, 0)
+	selected := make([]*BitcoinTransactionOutput, 0)
+	feeFor := func(w int) uint64 {
+		if hasWitness {
+			w += txnWitnessFlagWeight
+		}
+		return uint64((w+wire.WitnessScaleFactor-1)/wire.WitnessScaleFactor) * feeRate
+	}
+	var changeValue uint64
+	done := false
+	for _, utxo := range utxos {
+		inWeight, err := inputWeight(utxo.pkScript)
+		if err != nil {
+			logWallet.WithField("output", utxo.GetId()).Warn("Skipping output locked by unsupported script")
+			continue
+		}
+		hasWitness = hasWitness || isPayToWitnessPubKeyHash(utxo.pkScript)
+		weight += inWeight
+		selected = append(selected, utxo)
+		if total, err = total.Add(core.NewAmount(p.Ticker, utxo.value)); err != nil {
+			return nil, err
+		}
+		if spendAll && len(selected) < len(utxos) {
+			continue
+		}
+		feeWithChange := feeFor(weight + outputWeight(changeScript))
+		if total.Coins >= target.Coins+feeWithChange+p.DustLimit {
+			changeValue = total.Coins - target.Coins - feeWithChange
+			done = true
+			break
+		}
+		if total.Coins >= target.Coins+feeFor(weight) {
+			// Remainder too small for a change output goes to miners
+			done = true
+			break
+		}
+	}
+	if !done {
+		return nil, errors.ErrInsufficientFunds
+	}
+
+	msgTx := wire.NewMsgTx(txnVersion)
+	prevOuts := make([]prevOutput, len(selected))
+	for i, utxo := range selected {
+		msgTx.TxIn = append(msgTx.TxIn, &wire.TxIn{
+			PreviousOutPoint: utxo.outPoint,
+			Sequence:         wire.MaxTxInSequenceNum,
+		})
+		prevOuts[i] = prevOutput{value: utxo.value, pkScript: utxo.pkScript, known: true}
+	}
+	msgTx.TxOut = outs
+	if changeValue > 0 {
+		msgTx.TxOut = append(msgTx.TxOut, &wire.TxOut{Value: changeValue, PkScript: changeScript})
+	}
+	txn := newBitcoinTransaction(p, msgTx, prevOuts, core.TXN_STATUS_CREATED)
+	txn.timestamp = core.Timestamp(time.Now().Unix())
+	logWallet.Info(fmt.Sprintf("Created transaction spending %d outputs at %d per vbyte", len(selected), feeRate))
+	return txn, nil
+}
+
+// outputsFromGeneric converts outputs to spend into Bitcoin outputs of network params,
+// looking up unspent outputs known only by ID
+func outputsFromGeneric(p params.BitcoinParams, unspent []core.TransactionOutput) ([]*BitcoinTransactionOutput, error) {
+	outputs := make([]*BitcoinTransactionOutput, 0, len(unspent))
+	for _, out := range unspent {
+		if btcOut, isBtcOut := out.(*BitcoinTransactionOutput); isBtcOut {
+			outputs = append(outputs, btcOut)
+			continue
+		}
+		btcOut, err := lookupUnspentOutput(p, out.GetId())
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, btcOut)
+	}
+	return outputs, nil
+}
+
+func lookupUnspentOutput(p params.BitcoinParams, id string) (*BitcoinTransactionOutput, error) {
+	outPoint, err := parseOutPointID(id)
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewBitcoinRPCClient(PoolSectionName(p))
+	if err != nil {
+		return nil, err
+	}
+	defer ReturnBitcoinRPCClient(c)
+	info, err := c.GetTxOut(outPoint.Hash.String(), outPoint.Index, false)
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, errors.ErrNotFound
+	}
+	value, err := core.ParseAmount(p.Ticker, info.Value.String(), p.Accuracy)
+	if err != nil {
+		return nil, err
+	}
+	pkScript, err := hex.DecodeString(info.ScriptPubKey.Hex)
+	if err != nil {
+		return nil, err
+	}
+	return newBitcoinTransactionOutput(p, outPoint, value.Coins, pkScript), nil
+}
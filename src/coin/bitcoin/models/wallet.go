@@ -0,0 +1,858 @@
+package bitcoin
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/SkycoinProject/skycoin/src/cipher/bip32"
+	"github.com/SkycoinProject/skycoin/src/cipher/bip39"
+	"github.com/SkycoinProject/skycoin/src/cipher/encrypt"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logWallet = logging.MustGetLogger("Bitcoin wallet")
+
+const (
+	// WalletTypeBip84 derives native segwit P2WPKH addresses along m/84'/coin'/0' paths
+	WalletTypeBip84 = "bip84"
+	// WalletTypeBip44 derives legacy P2PKH addresses along m/44'/coin'/0' paths
+	WalletTypeBip44 = "bip44"
+	// SignerIDHDWallet identifies the signing strategy of HD wallets
+	SignerIDHDWallet = "btc.hd"
+	// WalletTimestampFormat wallet file name timestamp format
+	WalletTimestampFormat = "2006_01_02"
+
+	walletExt     = ".btcwlt"
+	walletVersion = "1"
+)
+
+// walletCryptor protects wallet seeds at rest
+var walletCryptor = encrypt.DefaultScryptChacha20poly1305
+
+// walletFile is the on-disk representation of HD wallets
+type walletFile struct {
+	Version   string `json:"version"`
+	Label     string `json:"label"`
+	Type      string `json:"type"`
+	Coin      string `json:"coin"`
+	Encrypted bool   `json:"encrypted"`
+	// Seed BIP39 mnemonic, base64 encoded ciphertext if wallet is encrypted
+	Seed string `json:"seed"`
+	// AccountKey extended public key of account 0
+	AccountKey string `json:"account_key"`
+	External   uint32 `json:"external"`
+	Change     uint32 `json:"change"`
+}
+
+func loadWalletFile(path string) (*walletFile, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var wf walletFile
+	if err := json.Unmarshal(b, &wf); err != nil {
+		return nil, err
+	}
+	return &wf, nil
+}
+
+func saveWalletFile(path string, wf *walletFile) error {
+	b, err := json.MarshalIndent(wf, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+func walletPurpose(wltType string) (uint32, error) {
+	switch wltType {
+	case WalletTypeBip84:
+		return 84, nil
+	case WalletTypeBip44:
+		return 44, nil
+	}
+	return 0, errors.ErrInvalidOptions
+}
+
+// accountPrivateKey derives account 0 extended private key of wallet type for network params
+func accountPrivateKey(mnemonic, wltType string, p params.BitcoinParams) (*bip32.PrivateKey, error) {
+	purpose, err := walletPurpose(wltType)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := bip39.NewSeed(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	return bip32.NewPrivateKeyFromPath(seed, fmt.Sprintf("m/%d'/%d'/0'", purpose, p.Bip44CoinType))
+}
+
+func walletAddressType(wltType string) AddressType {
+	if wltType == WalletTypeBip44 {
+		return AddressP2PKH
+	}
+	return AddressP2WPKH
+}
+
+func chainIndex(addrType core.AddressType) uint32 {
+	if addrType == core.ChangeAddress {
+		return 1
+	}
+	return 0
+}
+
+// HDWallet derives Bitcoin-family keys from a BIP39 seed stored in a wallet file
+type HDWallet struct { // Implements Wallet and TxnSigner interfaces
+	Id        string
+	WalletDir string
+	params    params.BitcoinParams
+	mutex     *sync.Mutex
+}
+
+func newHDWallet(id, dir string, p params.BitcoinParams) *HDWallet {
+	return &HDWallet{
+		Id:        id,
+		WalletDir: dir,
+		params:    p,
+		mutex:     new(sync.Mutex),
+	}
+}
+
+func (wlt *HDWallet) path() string {
+	return filepath.Join(wlt.WalletDir, wlt.Id)
+}
+
+func (wlt *HDWallet) load() (*walletFile, error) {
+	wf, err := loadWalletFile(wlt.path())
+	if err != nil {
+		logWallet.WithError(err).WithField("filename", wlt.path()).Error("Couldn't load wallet file")
+		return nil, err
+	}
+	return wf, nil
+}
+
+// GetId returns wallet local identifier
+func (wlt *HDWallet) GetId() string {
+	return wlt.Id
+}
+
+// GetLabel provides a human-readable name for this wallet
+func (wlt *HDWallet) GetLabel() string {
+	wf, err := wlt.load()
+	if err != nil {
+		return ""
+	}
+	return wf.Label
+}
+
+// SetLabel establishes a label for this wallet
+func (wlt *HDWallet) SetLabel(wltName string) {
+	wlt.mutex.Lock()
+	defer wlt.mutex.Unlock()
+	wf, err := wlt.load()
+	if err != nil {
+		return
+	}
+	wf.Label = wltName
+	if err := saveWalletFile(wlt.path(), wf); err != nil {
+		logWallet.WithError(err).Error("Couldn't save wallet label")
+	}
+}
+
+// deriveAddresses derives count addresses in chain starting at startIndex using account public key
+func deriveAddresses(wf *walletFile, chain, startIndex, count uint32, p params.BitcoinParams) ([]*BitcoinAddress, error) {
+	accKey, err := bip32.DeserializeEncodedPublicKey(wf.AccountKey)
+	if err != nil {
+		return nil, err
+	}
+	chainKey, err := accKey.NewPublicChildKey(chain)
+	if err != nil {
+		return nil, err
+	}
+	addrType := walletAddressType(wf.Type)
+	addrs := make([]*BitcoinAddress, 0, count)
+	for i := startIndex; i < startIndex+count; i++ {
+		childKey, err := chainKey.NewPublicChildKey(i)
+		if err != nil {
+			return nil, err
+		}
+		pk, err := cipher.NewPubKey(childKey.Key)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, newAddressFromPubKey(pk, addrType, p))
+	}
+	return addrs, nil
+}
+
+// GenAddresses discover new addresses based on BIP32 derivation sequences
+func (wlt *HDWallet) GenAddresses(addrType core.AddressType, startIndex, count uint32, pwd core.PasswordReader) core.AddressIterator {
+	logWallet.Info("Generating addresses for HD wallet")
+	wlt.mutex.Lock()
+	defer wlt.mutex.Unlock()
+	wf, err := wlt.load()
+	if err != nil {
+		return nil
+	}
+	chain := chainIndex(addrType)
+	addrs, err := deriveAddresses(wf, chain, startIndex, count, wlt.params)
+	if err != nil {
+		logWallet.WithError(err).Error("Couldn't derive addresses")
+		return nil
+	}
+	known := &wf.External
+	if chain == 1 {
+		known = &wf.Change
+	}
+	if startIndex+count > *known {
+		*known = startIndex + count
+		if err := saveWalletFile(wlt.path(), wf); err != nil {
+			logWallet.WithError(err).Error("Couldn't save generated addresses")
+			return nil
+		}
+	}
+	coreAddrs := make([]core.Address, len(addrs))
+	for i, addr := range addrs {
+		coreAddrs[i] = addr
+	}
+	return NewBitcoinAddressIterator(coreAddrs)
+}
+
+// loadedAddresses lists external and change addresses generated so far
+func (wlt *HDWallet) loadedAddresses() ([]*BitcoinAddress, error) {
+	wf, err := wlt.load()
+	if err != nil {
+		return nil, err
+	}
+	external, err := deriveAddresses(wf, 0, 0, wf.External, wlt.params)
+	if err != nil {
+		return nil, err
+	}
+	change, err := deriveAddresses(wf, 1, 0, wf.Change, wlt.params)
+	if err != nil {
+		return nil, err
+	}
+	return append(external, change...), nil
+}
+
+// GetLoadedAddresses iterates over wallet addresses generated so far
+func (wlt *HDWallet) GetLoadedAddresses() (core.AddressIterator, error) {
+	addrs, err := wlt.loadedAddresses()
+	if err != nil {
+		return nil, err
+	}
+	coreAddrs := make([]core.Address, len(addrs))
+	for i, addr := range addrs {
+		coreAddrs[i] = addr
+	}
+	return NewBitcoinAddressIterator(coreAddrs), nil
+}
+
+// GetCryptoAccount instantiate object to determine wallet balance and transaction history
+func (wlt *HDWallet) GetCryptoAccount() core.CryptoAccount {
+	return newBitcoinAccount(wlt.params, PoolSectionName(wlt.params), wlt.loadedAddresses)
+}
+
+// nextChangeAddress derives the first change address not generated yet
+func (wlt *HDWallet) nextChangeAddress() (*BitcoinAddress, error) {
+	wf, err := wlt.load()
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := deriveAddresses(wf, 1, wf.Change, 1, wlt.params)
+	if err != nil {
+		return nil, err
+	}
+	return addrs[0], nil
+}
+
+// commitChangeAddress records change address as generated once it is used in a transaction
+func (wlt *HDWallet) commitChangeAddress(change *BitcoinAddress) {
+	wlt.mutex.Lock()
+	defer wlt.mutex.Unlock()
+	wf, err := wlt.load()
+	if err != nil {
+		return
+	}
+	addrs, err := deriveAddresses(wf, 1, wf.Change, 1, wlt.params)
+	if err != nil || addrs[0].String() != change.String() {
+		return
+	}
+	wf.Change++
+	if err := saveWalletFile(wlt.path(), wf); err != nil {
+		logWallet.WithError(err).Error("Couldn't save change address")
+	}
+}
+
+func (wlt *HDWallet) parseChange(change core.Address) (*BitcoinAddress, bool, error) {
+	if change == nil {
+		addr, err := wlt.nextChangeAddress()
+		return addr, true, err
+	}
+	addr, err := NewBitcoinAddress(change.String(), wlt.params)
+	return addr, false, err
+}
+
+// Transfer instantiates unsigned transaction to send funds from any wallet address to single destination
+func (wlt *HDWallet) Transfer(to core.TransactionOutput, options core.KeyValueStore) (core.Transaction, error) {
+	logWallet.Info("Creating transaction to transfer funds")
+	candidates, err := newBitcoinAccount(wlt.params, PoolSectionName(wlt.params), wlt.loadedAddresses).scanUnspent()
+	if err != nil {
+		return nil, err
+	}
+	return wlt.build(candidates, []core.TransactionOutput{to}, nil, options, false)
+}
+
+// SendFromAddress instantiates unsigned transaction to send funds from specific source addresses
+// to multiple destination addresses
+func (wlt *HDWallet) SendFromAddress(from []core.Address, to []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	logWallet.Info("Creating transaction to send funds from addresses")
+	addrs := make([]*BitcoinAddress, len(from))
+	for i, addr := range from {
+		btcAddr, err := NewBitcoinAddress(addr.String(), wlt.params)
+		if err != nil {
+			return nil, err
+		}
+		addrs[i] = btcAddr
+	}
+	candidates, err := newBitcoinAccount(wlt.params, PoolSectionName(wlt.params), func() ([]*BitcoinAddress, error) {
+		return addrs, nil
+	}).scanUnspent()
+	if err != nil {
+		return nil, err
+	}
+	return wlt.build(candidates, to, change, options, false)
+}
+
+// Spend instantiate unsigned transaction spending specific outputs to send to multiple destination addresses
+func (wlt *HDWallet) Spend(unspent, new []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	logWallet.Info("Creating transaction to spend outputs")
+	candidates, err := outputsFromGeneric(wlt.params, unspent)
+	if err != nil {
+		return nil, err
+	}
+	return wlt.build(candidates, new, change, options, true)
+}
+
+func (wlt *HDWallet) build(candidates []*BitcoinTransactionOutput, to []core.TransactionOutput, change core.Address,
+	options core.KeyValueStore, spendAll bool) (core.Transaction, error) {
+	changeAddr, isNewChange, err := wlt.parseChange(change)
+	if err != nil {
+		return nil, err
+	}
+	txn, err := buildTransaction(wlt.params, candidates, to, changeAddr, options, spendAll)
+	if err != nil {
+		return nil, err
+	}
+	if isNewChange && len(txn.msgTx.TxOut) > len(to) {
+		wlt.commitChangeAddress(changeAddr)
+	}
+	return txn, nil
+}
+
+// Sign creates a new transaction by (fully or partially) choosing a strategy to sign given transaction
+func (wlt *HDWallet) Sign(txn core.Transaction, signer core.TxnSigner, pwd core.PasswordReader, index []string) (core.Transaction, error) {
+	logWallet.Info("Signing transaction with HD wallet")
+	if signer == nil {
+		signer = wlt
+	}
+	return signer.SignTransaction(txn, pwd, index)
+}
+
+// mnemonic reads wallet seed, asking for password if wallet is encrypted
+func (wlt *HDWallet) mnemonic(wf *walletFile, pwd core.PasswordReader, method string) (string, error) {
+	if !wf.Encrypted {
+		return wf.Seed, nil
+	}
+	if pwd == nil {
+		return "", errors.ErrWalletCantSign
+	}
+	pwdCtx := util.NewKeyValueMap()
+	pwdCtx.SetValue(core.StrTypeName, core.TypeNameWallet)
+	pwdCtx.SetValue(core.StrMethodName, method)
+	pwdCtx.SetValue(core.StrWalletName, wlt.Id)
+	pwdCtx.SetValue(core.StrWalletLabel, wf.Label)
+	password, err := pwd("Enter password", pwdCtx)
+	if err != nil {
+		return "", err
+	}
+	return decryptSeed(wf.Seed, password)
+}
+
+func encryptSeed(mnemonic, password string) (string, error) {
+	b, err := walletCryptor.Encrypt([]byte(mnemonic), []byte(password))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func decryptSeed(seed, password string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(seed)
+	if err != nil {
+		return "", err
+	}
+	plain, err := walletCryptor.Decrypt(b, []byte(password))
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// ReadyForTxn determines whether transaction can be signed with this signer instance
+func (wlt *HDWallet) ReadyForTxn(w core.Wallet, txn core.Transaction) (bool, error) {
+	if w == nil || w.GetId() != wlt.Id {
+		return false, nil
+	}
+	btcTxn, isBtcTxn := txn.(*BitcoinTransaction)
+	return isBtcTxn && btcTxn.params.Ticker == wlt.params.Ticker, nil
+}
+
+// SignTransaction signs inputs spending outputs locked by wallet addresses
+//
+// @param txn Transacion object
+// @param pwdReader password prompt to decode target wallet should it be needed
+// @param strIdxs may be `nil` for signing every owned input; if set should contain IDs of inputs that need to be signed
+func (wlt *HDWallet) SignTransaction(txn core.Transaction, pwdReader core.PasswordReader, strIdxs []string) (core.Transaction, error) {
+	btcTxn, isBtcTxn := txn.(*BitcoinTransaction)
+	if !isBtcTxn {
+		return nil, errors.ErrInvalidTxn
+	}
+	wf, err := wlt.load()
+	if err != nil {
+		return nil, err
+	}
+	var indices []int
+	if strIdxs != nil {
+		if indices, err = inputIndices(btcTxn, strIdxs); err != nil {
+			return nil, err
+		}
+	} else {
+		for i := range btcTxn.msgTx.TxIn {
+			indices = append(indices, i)
+		}
+	}
+
+	// Index owned scripts by derivation path
+	type keyPath struct{ chain, index uint32 }
+	owned := make(map[string]keyPath)
+	for chain, count := range []uint32{wf.External, wf.Change} {
+		addrs, err := deriveAddresses(wf, uint32(chain), 0, count, wlt.params)
+		if err != nil {
+			return nil, err
+		}
+		for i, addr := range addrs {
+			owned[string(addr.ScriptPubKey())] = keyPath{uint32(chain), uint32(i)}
+		}
+	}
+
+	signed := newBitcoinTransaction(wlt.params, btcTxn.msgTx.Copy(), append([]prevOutput(nil), btcTxn.prevOuts...), btcTxn.status)
+	signed.timestamp = btcTxn.timestamp
+	var accKey *bip32.PrivateKey
+	for _, idx := range indices {
+		prevOut, err := signed.resolvePrevOutput(idx)
+		if err != nil {
+			return nil, err
+		}
+		path, isOwned := owned[string(prevOut.pkScript)]
+		if !isOwned {
+			if strIdxs != nil {
+				logWallet.WithField("input", idx).Error("Input is not owned by wallet")
+				return nil, errors.ErrWalletCantSign
+			}
+			continue
+		}
+		if accKey == nil {
+			mnemonic, err := wlt.mnemonic(wf, pwdReader, "SignTransaction")
+			if err != nil {
+				return nil, err
+			}
+			if accKey, err = accountPrivateKey(mnemonic, wf.Type, wlt.params); err != nil {
+				return nil, err
+			}
+		}
+		chainKey, err := accKey.NewPrivateChildKey(path.chain)
+		if err != nil {
+			return nil, err
+		}
+		childKey, err := chainKey.NewPrivateChildKey(path.index)
+		if err != nil {
+			return nil, err
+		}
+		sk, err := cipher.NewSecKey(childKey.Key)
+		if err != nil {
+			return nil, err
+		}
+		if err := signInput(signed, idx, prevOut, sk); err != nil {
+			logWallet.WithError(err).Error("Couldn't sign transaction input")
+			return nil, errors.ErrTxnSignFailure
+		}
+	}
+	return signed, nil
+}
+
+// inputIndices maps input IDs to their position in transaction
+func inputIndices(txn *BitcoinTransaction, ids []string) ([]int, error) {
+	indices := make([]int, 0, len(ids))
+	for _, id := range ids {
+		found := false
+		for i, in := range txn.msgTx.TxIn {
+			if outPointID(in.PreviousOutPoint) == id {
+				indices = append(indices, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.ErrInvalidID
+		}
+	}
+	return indices, nil
+}
+
+// signInput sets scriptSig or witness spending a P2PKH or P2WPKH output
+func signInput(txn *BitcoinTransaction, idx int, prevOut prevOutput, sk cipher.SecKey) error {
+	pk, err := cipher.PubKeyFromSecKey(sk)
+	if err != nil {
+		return err
+	}
+	in := txn.msgTx.TxIn[idx]
+	switch {
+	case isPayToPubKeyHash(prevOut.pkScript):
+		hash, err := calcSignatureHash(prevOut.pkScript, txn.msgTx, idx)
+		if err != nil {
+			return err
+		}
+		sig, err := cipher.SignHash(hash, sk)
+		if err != nil {
+			return err
+		}
+		in.SignatureScript = append(pushData(signatureToDER(sig)), pushData(pk[:])...)
+		in.Witness = nil
+	case isPayToWitnessPubKeyHash(prevOut.pkScript):
+		hash, err := calcWitnessSignatureHash(payToPubKeyHashScript(prevOut.pkScript[2:]), txn.msgTx, idx, prevOut.value)
+		if err != nil {
+			return err
+		}
+		sig, err := cipher.SignHash(hash, sk)
+		if err != nil {
+			return err
+		}
+		in.SignatureScript = nil
+		in.Witness = [][]byte{signatureToDER(sig), append([]byte(nil), pk[:]...)}
+	default:
+		return errors.ErrNotImplemented
+	}
+	return nil
+}
+
+// GetSignerUID returns ID of HD wallets signing strategy
+func (wlt *HDWallet) GetSignerUID() (core.UID, error) {
+	return SignerIDHDWallet, nil
+}
+
+// GetSignerDescription describes signing strategy
+func (wlt *HDWallet) GetSignerDescription() (string, error) {
+	return wlt.params.Name + " HD wallet " + wlt.Id, nil
+}
+
+// BitcoinWalletIterator iterates over a sequence of wallets
+type BitcoinWalletIterator struct {
+	current int
+	wallets []core.Wallet
+}
+
+// Value of wallet at iterator pointer position
+func (it *BitcoinWalletIterator) Value() core.Wallet {
+	return it.wallets[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *BitcoinWalletIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *BitcoinWalletIterator) HasNext() bool {
+	return (it.current + 1) < len(it.wallets)
+}
+
+// NewBitcoinWalletIterator instantiates iterator over wallets
+func NewBitcoinWalletIterator(wallets []core.Wallet) *BitcoinWalletIterator {
+	return &BitcoinWalletIterator{wallets: wallets, current: -1}
+}
+
+// WalletDirectory stores HD wallets of a Bitcoin-family network in a local folder
+type WalletDirectory struct { // Implements WalletEnv, WalletSet and WalletStorage interfaces
+	WalletDir string
+	params    params.BitcoinParams
+}
+
+// NewWalletDirectory instantiates wallet environment for network params backed by folder at dirPath
+func NewWalletDirectory(dirPath string, p params.BitcoinParams) *WalletDirectory {
+	return &WalletDirectory{WalletDir: dirPath, params: p}
+}
+
+// GetStorage provides access to wallet data store
+func (wltDir *WalletDirectory) GetStorage() core.WalletStorage {
+	return wltDir
+}
+
+// GetWalletSet loads wallets in this environment
+func (wltDir *WalletDirectory) GetWalletSet() core.WalletSet {
+	return wltDir
+}
+
+// LookupWallet finds wallet whose first external address is firstAddr
+func (wltDir *WalletDirectory) LookupWallet(firstAddr string) (core.Wallet, error) {
+	wls := wltDir.ListWallets()
+	for wls.Next() {
+		w := wls.Value()
+		addrs := w.GenAddresses(core.AccountAddress, 0, 1, nil)
+		if addrs != nil && addrs.Next() && addrs.Value().String() == firstAddr {
+			return w, nil
+		}
+	}
+	return nil, errors.ErrWltFromAddrNotFound
+}
+
+// ListWallets returns an iterator over wallets of network coin found in directory
+func (wltDir *WalletDirectory) ListWallets() core.WalletIterator {
+	logWallet.Info("Listing Bitcoin wallets")
+	wallets := make([]core.Wallet, 0)
+	entries, err := ioutil.ReadDir(wltDir.WalletDir)
+	if err != nil {
+		logWallet.WithError(err).WithField("dirname", wltDir.WalletDir).Error("Couldn't read wallet directory")
+		return NewBitcoinWalletIterator(wallets)
+	}
+	for _, e := range entries {
+		if !e.Mode().IsRegular() || !strings.HasSuffix(e.Name(), walletExt) {
+			continue
+		}
+		if w := wltDir.GetWallet(e.Name()); w != nil {
+			wallets = append(wallets, w)
+		}
+	}
+	return NewBitcoinWalletIterator(wallets)
+}
+
+// GetWallet to lookup wallet by ID
+func (wltDir *WalletDirectory) GetWallet(id string) core.Wallet {
+	path := filepath.Join(wltDir.WalletDir, id)
+	wf, err := loadWalletFile(path)
+	if err != nil {
+		logWallet.WithError(err).WithField("filename", path).Debug("Couldn't load wallet")
+		return nil
+	}
+	if wf.Coin != wltDir.params.Ticker {
+		return nil
+	}
+	return newHDWallet(id, wltDir.WalletDir, wltDir.params)
+}
+
+// CreateWallet instantiates a new HD wallet given BIP39 mnemonic
+func (wltDir *WalletDirectory) CreateWallet(label string, seed string, wltType string, isEncrypted bool, pwd core.PasswordReader, scanAddressesN int) (core.Wallet, error) {
+	logWallet.Info("Creating Bitcoin HD wallet")
+	if err := bip39.ValidateMnemonic(seed); err != nil {
+		return nil, errors.ErrInvalidWalletEntropy
+	}
+	accKey, err := accountPrivateKey(seed, wltType, wltDir.params)
+	if err != nil {
+		return nil, err
+	}
+	wf := &walletFile{
+		Version:    walletVersion,
+		Label:      label,
+		Type:       wltType,
+		Coin:       wltDir.params.Ticker,
+		Seed:       seed,
+		AccountKey: accKey.PublicKey().String(),
+		External:   1,
+	}
+	if isEncrypted {
+		pwdCtx := util.NewKeyValueMap()
+		pwdCtx.SetValue(core.StrTypeName, core.TypeNameWalletSet)
+		pwdCtx.SetValue(core.StrMethodName, "CreateWallet")
+		pwdCtx.SetValue(core.StrWalletLabel, label)
+		password, err := pwd("Enter password", pwdCtx)
+		if err != nil {
+			return nil, err
+		}
+		if wf.Seed, err = encryptSeed(seed, password); err != nil {
+			return nil, err
+		}
+		wf.Encrypted = true
+	}
+	if scanAddressesN > 0 {
+		wf.External = scanAhead(wf, uint32(scanAddressesN), wltDir.params)
+	}
+	if err := os.MkdirAll(wltDir.WalletDir, 0700); err != nil {
+		return nil, err
+	}
+	id := wltDir.newUniqueWalletFilename()
+	if err := saveWalletFile(filepath.Join(wltDir.WalletDir, id), wf); err != nil {
+		logWallet.WithError(err).WithField("dir", wltDir.WalletDir).Error("Couldn't save wallet")
+		return nil, err
+	}
+	return newHDWallet(id, wltDir.WalletDir, wltDir.params), nil
+}
+
+// scanAhead determines how many external addresses lock unspent outputs, at least one
+func scanAhead(wf *walletFile, n uint32, p params.BitcoinParams) uint32 {
+	addrs, err := deriveAddresses(wf, 0, 0, n, p)
+	if err != nil {
+		return 1
+	}
+	outputs, err := newBitcoinAccount(p, PoolSectionName(p), func() ([]*BitcoinAddress, error) {
+		return addrs, nil
+	}).scanUnspent()
+	if err != nil {
+		logWallet.WithError(err).Warn("Couldn't scan addresses ahead")
+		return 1
+	}
+	count := uint32(1)
+	for i, addr := range addrs {
+		script := string(addr.ScriptPubKey())
+		for _, out := range outputs {
+			if string(out.pkScript) == script && uint32(i)+1 > count {
+				count = uint32(i) + 1
+			}
+		}
+	}
+	return count
+}
+
+func (wltDir *WalletDirectory) newUniqueWalletFilename() string {
+	for {
+		timestamp := time.Now().Format(WalletTimestampFormat)
+		padding := hex.EncodeToString(cipher.RandByte(2))
+		name := fmt.Sprintf("%s_%s%s", timestamp, padding, walletExt)
+		if _, err := os.Stat(filepath.Join(wltDir.WalletDir, name)); os.IsNotExist(err) {
+			return name
+		}
+	}
+}
+
+// RemoveWallet deletes HD wallet file
+func (wltDir *WalletDirectory) RemoveWallet(id string) error {
+	logWallet.Info("Removing Bitcoin HD wallet")
+	if wltDir.GetWallet(id) == nil {
+		return errors.ErrNotFound
+	}
+	path := filepath.Join(wltDir.WalletDir, id)
+	if err := os.Remove(path); err != nil {
+		logWallet.WithError(err).WithField("filename", path).Error("Couldn't remove wallet")
+		return err
+	}
+	return nil
+}
+
+// DefaultWalletType default wallet type
+func (wltDir *WalletDirectory) DefaultWalletType() string {
+	return WalletTypeBip84
+}
+
+// SupportedWalletTypes list supported wallet type names
+func (wltDir *WalletDirectory) SupportedWalletTypes() []string {
+	return []string{WalletTypeBip84, WalletTypeBip44}
+}
+
+func (wltDir *WalletDirectory) readPassword(wltName, method string, wf *walletFile, password core.PasswordReader) (string, error) {
+	pwdCtx := util.NewKeyValueMap()
+	pwdCtx.SetValue(core.StrTypeName, core.TypeNameWalletStorage)
+	pwdCtx.SetValue(core.StrMethodName, method)
+	pwdCtx.SetValue(core.StrWalletName, wltName)
+	pwdCtx.SetValue(core.StrWalletLabel, wf.Label)
+	return password("Enter password", pwdCtx)
+}
+
+// Encrypt protects wallet seed using password
+func (wltDir *WalletDirectory) Encrypt(walletName string, password core.PasswordReader) {
+	logWallet.Info("Encrypt Bitcoin wallet")
+	path := filepath.Join(wltDir.WalletDir, walletName)
+	wf, err := loadWalletFile(path)
+	if err != nil {
+		logWallet.WithError(err).WithField("filename", path).Error("Couldn't load wallet inside Encrypt")
+		return
+	}
+	if wf.Encrypted {
+		return
+	}
+	pwd, err := wltDir.readPassword(walletName, "Encrypt", wf, password)
+	if err != nil {
+		logWallet.WithError(err).Error("Something was wrong entering the password")
+		return
+	}
+	if wf.Seed, err = encryptSeed(wf.Seed, pwd); err != nil {
+		logWallet.WithError(err).Error("Couldn't encrypt wallet seed")
+		return
+	}
+	wf.Encrypted = true
+	if err := saveWalletFile(path, wf); err != nil {
+		logWallet.WithError(err).WithField("filename", path).Error("Couldn't save wallet inside Encrypt")
+	}
+}
+
+// Decrypt removes password protection of wallet seed
+func (wltDir *WalletDirectory) Decrypt(walletName string, password core.PasswordReader) {
+	logWallet.Info("Decrypt Bitcoin wallet")
+	path := filepath.Join(wltDir.WalletDir, walletName)
+	wf, err := loadWalletFile(path)
+	if err != nil {
+		logWallet.WithError(err).WithField("filename", path).Error("Couldn't load wallet inside Decrypt")
+		return
+	}
+	if !wf.Encrypted {
+		return
+	}
+	pwd, err := wltDir.readPassword(walletName, "Decrypt", wf, password)
+	if err != nil {
+		logWallet.WithError(err).Error("Something was wrong entering the password")
+		return
+	}
+	if wf.Seed, err = decryptSeed(wf.Seed, pwd); err != nil {
+		logWallet.WithError(err).Error("Couldn't decrypt wallet seed")
+		return
+	}
+	wf.Encrypted = false
+	if err := saveWalletFile(path, wf); err != nil {
+		logWallet.WithError(err).WithField("filename", path).Error("Couldn't save wallet inside Decrypt")
+	}
+}
+
+// IsEncrypted queries whether wallet seed is encrypted or not
+func (wltDir *WalletDirectory) IsEncrypted(walletName string) (bool, error) {
+	wf, err := loadWalletFile(filepath.Join(wltDir.WalletDir, walletName))
+	if err != nil {
+		return false, err
+	}
+	return wf.Encrypted, nil
+}
+
+// Type assertions
+var (
+	_ core.Wallet         = &HDWallet{}
+	_ core.TxnSigner      = &HDWallet{}
+	_ core.WalletIterator = &BitcoinWalletIterator{}
+	_ core.WalletEnv      = &WalletDirectory{}
+	_ core.WalletSet      = &WalletDirectory{}
+	_ core.WalletStorage  = &WalletDirectory{}
+	_ core.WalletRemover  = &WalletDirectory{}
+)
@@ -0,0 +1,208 @@
+package bitcoin
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/cipher/encrypt"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/rpcstub"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func init() {
+	// Keep password derivation fast in tests
+	walletCryptor = encrypt.ScryptChacha20poly1305{N: 1 << 10, R: 8, P: 1, KeyLen: 32}
+}
+
+func testPassword(string, core.KeyValueStore) (string, error) {
+	return "secret", nil
+}
+
+func tempWalletDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "btcwallets")
+	require.NoError(t, err)
+	return dir
+}
+
+// startStubNode serves stub node at a connection pool section dedicated to network params
+func startStubNode(t *testing.T, p params.BitcoinParams) (*rpcstub.Server, func()) {
+	stub := rpcstub.NewServer(p)
+	srv := httptest.NewServer(stub)
+	err := core.GetMultiPool().CreateSection(PoolSectionName(p), NewBitcoinConnectionFactory(srv.URL, "", ""))
+	require.NoError(t, err)
+	return stub, srv.Close
+}
+
+func firstAddress(t *testing.T, wlt core.Wallet, addrType core.AddressType) string {
+	addrs := wlt.GenAddresses(addrType, 0, 1, nil)
+	require.NotNil(t, addrs)
+	require.True(t, addrs.Next())
+	return addrs.Value().String()
+}
+
+func TestHDWalletDerivation(t *testing.T) {
+	dir := tempWalletDir(t)
+	defer os.RemoveAll(dir)
+	wltDir := NewWalletDirectory(dir, params.BitcoinMainNetParams)
+
+	wlt, err := wltDir.CreateWallet("segwit", testMnemonic, WalletTypeBip84, false, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu", firstAddress(t, wlt, core.AccountAddress))
+	require.Equal(t, "bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el", firstAddress(t, wlt, core.ChangeAddress))
+
+	wlt, err = wltDir.CreateWallet("legacy", testMnemonic, WalletTypeBip44, true, testPassword, 0)
+	require.NoError(t, err)
+	require.Equal(t, "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA", firstAddress(t, wlt, core.AccountAddress))
+	isEncrypted, err := wltDir.IsEncrypted(wlt.GetId())
+	require.NoError(t, err)
+	require.True(t, isEncrypted)
+
+	found, err := wltDir.LookupWallet("1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA")
+	require.NoError(t, err)
+	require.Equal(t, "legacy", found.GetLabel())
+
+	_, err = wltDir.CreateWallet("bad", "abandon abandon", WalletTypeBip84, false, nil, 0)
+	require.Error(t, err)
+
+	require.NoError(t, wltDir.RemoveWallet(wlt.GetId()))
+	require.Nil(t, wltDir.GetWallet(wlt.GetId()))
+	require.Equal(t, errors.ErrNotFound, wltDir.RemoveWallet(wlt.GetId()))
+}
+
+func TestWalletStorageEncryption(t *testing.T) {
+	dir := tempWalletDir(t)
+	defer os.RemoveAll(dir)
+	wltDir := NewWalletDirectory(dir, params.BitcoinMainNetParams)
+	wlt, err := wltDir.CreateWallet("wallet", testMnemonic, WalletTypeBip84, false, nil, 0)
+	require.NoError(t, err)
+
+	wltDir.Encrypt(wlt.GetId(), testPassword)
+	isEncrypted, err := wltDir.IsEncrypted(wlt.GetId())
+	require.NoError(t, err)
+	require.True(t, isEncrypted)
+	wf, err := loadWalletFile(wlt.(*HDWallet).path())
+	require.NoError(t, err)
+	require.NotEqual(t, testMnemonic, wf.Seed)
+
+	wltDir.Decrypt(wlt.GetId(), testPassword)
+	wf, err = loadWalletFile(wlt.(*HDWallet).path())
+	require.NoError(t, err)
+	require.False(t, wf.Encrypted)
+	require.Equal(t, testMnemonic, wf.Seed)
+}
+
+func TestHDWalletTransferWithStubNode(t *testing.T) {
+	p := params.BitcoinRegTestParams
+	stub, stop := startStubNode(t, p)
+	defer stop()
+	dir := tempWalletDir(t)
+	defer os.RemoveAll(dir)
+	wltDir := NewWalletDirectory(dir, p)
+
+	for _, wltType := range []string{WalletTypeBip84, WalletTypeBip44} {
+		t.Run(wltType, func(t *testing.T) {
+			wlt, err := wltDir.CreateWallet(wltType, testMnemonic, wltType, true, testPassword, 0)
+			require.NoError(t, err)
+			addr, err := NewBitcoinAddress(firstAddress(t, wlt, core.AccountAddress), p)
+			require.NoError(t, err)
+			stub.Fund(addr.ScriptPubKey(), 100000000)
+			stub.Fund(addr.ScriptPubKey(), 20000000)
+
+			balance, err := wlt.GetCryptoAccount().GetBalance(p.Ticker)
+			require.NoError(t, err)
+			require.Equal(t, uint64(120000000), balance)
+
+			dest := util.NewGenericAddress("bcrt1qw508d6qejxtdg4y5r3zarvary0c5xw7kygt080")
+			to := util.NewGenericOutput(&dest, "")
+			to.SetCoins(p.Ticker, 30000000)
+			opts := util.NewKeyValueMap()
+			opts.SetValue(TxnOptFeeRate, "2")
+			txn, err := wlt.Transfer(&to, opts)
+			require.NoError(t, err)
+			require.NoError(t, txn.VerifyUnsigned())
+			require.Len(t, txn.GetInputs(), 1)
+			require.Len(t, txn.GetOutputs(), 2)
+			fee, err := txn.ComputeFee(p.Ticker)
+			require.NoError(t, err)
+
+			signed, err := wlt.Sign(txn, nil, testPassword, nil)
+			require.NoError(t, err)
+			isSigned, err := signed.IsFullySigned()
+			require.NoError(t, err)
+			require.True(t, isSigned)
+			require.NoError(t, signed.VerifySigned())
+			// Fee rate is honoured with the actual signed size
+			vsize := uint64(signed.(*BitcoinTransaction).VirtualSize())
+			require.True(t, fee >= 2*vsize && fee <= 2*vsize+4, "fee %d vsize %d", fee, vsize)
+			// Original transaction is left untouched
+			isSigned, err = txn.IsFullySigned()
+			require.NoError(t, err)
+			require.False(t, isSigned)
+
+			pex := NewBitcoinPEX(p)
+			require.NoError(t, pex.BroadcastTxn(signed))
+			require.Equal(t, []string{signed.GetId()}, stub.Mempool())
+			pending, err := wlt.GetCryptoAccount().ListPendingTransactions()
+			require.NoError(t, err)
+			require.True(t, pending.Next())
+			require.Equal(t, signed.GetId(), pending.Value().GetId())
+
+			stub.Mine()
+			balance, err = wlt.GetCryptoAccount().GetBalance(p.Ticker)
+			require.NoError(t, err)
+			require.Equal(t, uint64(120000000-30000000)-fee, balance)
+		})
+	}
+}
+
+func TestHDWalletSignWrongPassword(t *testing.T) {
+	p := params.BitcoinRegTestParams
+	stub, stop := startStubNode(t, p)
+	defer stop()
+	dir := tempWalletDir(t)
+	defer os.RemoveAll(dir)
+	wlt, err := NewWalletDirectory(dir, p).CreateWallet("wallet", testMnemonic, WalletTypeBip84, true, testPassword, 0)
+	require.NoError(t, err)
+	addr, err := NewBitcoinAddress(firstAddress(t, wlt, core.AccountAddress), p)
+	require.NoError(t, err)
+	stub.Fund(addr.ScriptPubKey(), 100000000)
+
+	to := util.NewGenericOutput(addr, "")
+	to.SetCoins(p.Ticker, 10000)
+	txn, err := wlt.Transfer(&to, nil)
+	require.NoError(t, err)
+	_, err = wlt.Sign(txn, nil, func(string, core.KeyValueStore) (string, error) {
+		return "wrong", nil
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestBuildTransactionInsufficientFunds(t *testing.T) {
+	p := params.BitcoinRegTestParams
+	stub, stop := startStubNode(t, p)
+	defer stop()
+	dir := tempWalletDir(t)
+	defer os.RemoveAll(dir)
+	wlt, err := NewWalletDirectory(dir, p).CreateWallet("wallet", testMnemonic, WalletTypeBip84, false, nil, 0)
+	require.NoError(t, err)
+	addr, err := NewBitcoinAddress(firstAddress(t, wlt, core.AccountAddress), p)
+	require.NoError(t, err)
+	stub.Fund(addr.ScriptPubKey(), 10000)
+
+	to := util.NewGenericOutput(addr, "")
+	to.SetCoins(p.Ticker, 10000)
+	_, err = wlt.Transfer(&to, nil)
+	require.Error(t, err)
+
+	to.SetCoins(p.Ticker, 100)
+	_, err = wlt.Transfer(&to, nil)
+	require.Error(t, err)
+}
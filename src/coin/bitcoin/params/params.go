@@ -0,0 +1,112 @@
+package params
+
+// BitcoinParams describe a Bitcoin-family coin and the network it runs on
+type BitcoinParams struct {
+	// Name human readable name of the coin
+	Name string
+	// Ticker coin identifier
+	Ticker string
+	// NetType network name accepted by plugin loaders (e.g. MainNet)
+	NetType string
+	// Bip44CoinType coin_type segment of BIP44 / BIP84 derivation paths
+	Bip44CoinType uint32
+	// PubKeyHashAddrID version byte of base58 P2PKH addresses
+	PubKeyHashAddrID byte
+	// ScriptHashAddrID version byte of base58 P2SH addresses
+	ScriptHashAddrID byte
+	// Bech32HRP human readable part of native segwit addresses
+	Bech32HRP string
+	// Accuracy decimal places of a coin expressed in base units (satoshis)
+	Accuracy int32
+	// InitialSubsidy block reward, in base units, before the first halving
+	InitialSubsidy uint64
+	// SubsidyHalvingInterval number of blocks between subsidy halvings
+	SubsidyHalvingInterval uint64
+	// DefaultFeeRate fee rate in base units per virtual byte used when estimation is not available
+	DefaultFeeRate uint64
+	// DustLimit outputs below this value are not relayed and are added to fees instead
+	DustLimit uint64
+}
+
+// Constparams
+const (
+	// BitcoinFamily identifies Bitcoin-like UTXO coins
+	BitcoinFamily = "Bitcoin"
+	// BitcoinTicker Bitcoin coin identifier
+	BitcoinTicker = "BTC"
+	// BitcoinName human readable name associated to Bitcoin
+	BitcoinName = "Bitcoin"
+	// BitcoinTestTicker Bitcoin test networks coin identifier
+	BitcoinTestTicker = "TBTC"
+	// BitcoinTestName human readable name associated to Bitcoin test networks
+	BitcoinTestName = "Bitcoin Testnet"
+	// LitecoinTicker Litecoin coin identifier
+	LitecoinTicker = "LTC"
+	// LitecoinName human readable name associated to Litecoin
+	LitecoinName = "Litecoin"
+	// BitcoinDescription verbose explanation of Bitcoin family coins
+	BitcoinDescription = "Bitcoin and similar UTXO coins spoken to via bitcoind compatible JSON-RPC nodes"
+)
+
+var (
+	// BitcoinMainNetParams Bitcoin main network
+	BitcoinMainNetParams = BitcoinParams{
+		Name:                   BitcoinName,
+		Ticker:                 BitcoinTicker,
+		NetType:                "MainNet",
+		Bip44CoinType:          0,
+		PubKeyHashAddrID:       0x00,
+		ScriptHashAddrID:       0x05,
+		Bech32HRP:              "bc",
+		Accuracy:               8,
+		InitialSubsidy:         50 * 1e8,
+		SubsidyHalvingInterval: 210000,
+		DefaultFeeRate:         10,
+		DustLimit:              546,
+	}
+	// BitcoinTestNetParams Bitcoin public test network
+	BitcoinTestNetParams = BitcoinParams{
+		Name:                   BitcoinTestName,
+		Ticker:                 BitcoinTestTicker,
+		NetType:                "TestNet",
+		Bip44CoinType:          1,
+		PubKeyHashAddrID:       0x6f,
+		ScriptHashAddrID:       0xc4,
+		Bech32HRP:              "tb",
+		Accuracy:               8,
+		InitialSubsidy:         50 * 1e8,
+		SubsidyHalvingInterval: 210000,
+		DefaultFeeRate:         1,
+		DustLimit:              546,
+	}
+	// BitcoinRegTestParams Bitcoin local regression test network
+	BitcoinRegTestParams = BitcoinParams{
+		Name:                   BitcoinTestName,
+		Ticker:                 BitcoinTestTicker,
+		NetType:                "RegTest",
+		Bip44CoinType:          1,
+		PubKeyHashAddrID:       0x6f,
+		ScriptHashAddrID:       0xc4,
+		Bech32HRP:              "bcrt",
+		Accuracy:               8,
+		InitialSubsidy:         50 * 1e8,
+		SubsidyHalvingInterval: 150,
+		DefaultFeeRate:         1,
+		DustLimit:              546,
+	}
+	// LitecoinMainNetParams Litecoin main network
+	LitecoinMainNetParams = BitcoinParams{
+		Name:                   LitecoinName,
+		Ticker:                 LitecoinTicker,
+		NetType:                "MainNet",
+		Bip44CoinType:          2,
+		PubKeyHashAddrID:       0x30,
+		ScriptHashAddrID:       0x32,
+		Bech32HRP:              "ltc",
+		Accuracy:               8,
+		InitialSubsidy:         50 * 1e8,
+		SubsidyHalvingInterval: 840000,
+		DefaultFeeRate:         10,
+		DustLimit:              5460,
+	}
+)
@@ -0,0 +1,406 @@
+// Package rpcstub implements an in-memory bitcoind compatible JSON-RPC server
+// so that Bitcoin-family plugin features can be tested without a regtest node
+package rpcstub
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/bitcoin/wire"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+)
+
+// JSON-RPC error codes returned by bitcoind
+const (
+	ErrCodeMethodNotFound = -32601
+	ErrCodeInvalidParams  = -8
+	ErrCodeNotFound       = -5
+	ErrCodeDeserialize    = -22
+	ErrCodeVerifyRejected = -26
+	ErrCodeAlreadyInChain = -27
+)
+
+type utxo struct {
+	value    uint64
+	pkScript []byte
+	height   uint64
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Server simulates a bitcoind node holding a chain of empty blocks,
+// a UTXO set indexed by output script and a mempool
+type Server struct {
+	// User and Password enable HTTP basic authentication if set
+	User     string
+	Password string
+	// FeeRate returned by estimatesmartfee in coins per kilo virtual byte, empty if not available
+	FeeRate string
+	// Peers returned by getpeerinfo
+	Peers []map[string]interface{}
+
+	params  params.BitcoinParams
+	mutex   sync.Mutex
+	blocks  []wire.Hash
+	times   []int64
+	utxos   map[wire.OutPoint]utxo
+	txns    map[wire.Hash][]byte
+	mempool []wire.Hash
+	spent   map[wire.OutPoint]wire.Hash
+	nonce   uint32
+}
+
+// NewServer instantiates stub node for network params with a genesis block
+func NewServer(p params.BitcoinParams) *Server {
+	s := &Server{
+		params: p,
+		utxos:  make(map[wire.OutPoint]utxo),
+		txns:   make(map[wire.Hash][]byte),
+		spent:  make(map[wire.OutPoint]wire.Hash),
+	}
+	s.addBlock()
+	return s
+}
+
+func (s *Server) addBlock() {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(len(s.blocks)))
+	s.blocks = append(s.blocks, wire.DoubleHashH(append([]byte("block"), b[:]...)))
+	s.times = append(s.times, time.Now().Unix())
+}
+
+func (s *Server) height() uint64 {
+	return uint64(len(s.blocks) - 1)
+}
+
+// Fund confirms a new transaction paying value to output script in a new block and returns its ID
+func (s *Server) Fund(pkScript []byte, value uint64) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.nonce++
+	tx := wire.NewMsgTx(2)
+	var prev wire.Hash
+	binary.LittleEndian.PutUint32(prev[:], s.nonce)
+	tx.TxIn = append(tx.TxIn, &wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: prev, Index: 0xffffffff},
+		SignatureScript:  []byte{0x01, byte(s.nonce)},
+		Sequence:         wire.MaxTxInSequenceNum,
+	})
+	tx.TxOut = append(tx.TxOut, &wire.TxOut{Value: value, PkScript: pkScript})
+	s.addBlock()
+	txid := tx.TxHash()
+	s.txns[txid] = tx.Bytes(true)
+	s.utxos[wire.OutPoint{Hash: txid, Index: 0}] = utxo{value: value, pkScript: pkScript, height: s.height()}
+	return txid.String()
+}
+
+// Mine confirms all mempool transactions in a new block
+func (s *Server) Mine() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.addBlock()
+	for _, txid := range s.mempool {
+		tx, _ := wire.DeserializeBytes(s.txns[txid])
+		for _, in := range tx.TxIn {
+			delete(s.utxos, in.PreviousOutPoint)
+			delete(s.spent, in.PreviousOutPoint)
+		}
+		for i, out := range tx.TxOut {
+			s.utxos[wire.OutPoint{Hash: txid, Index: uint32(i)}] = utxo{value: out.Value, pkScript: out.PkScript, height: s.height()}
+		}
+	}
+	s.mempool = nil
+}
+
+// Mempool lists IDs of transactions accepted but not confirmed yet
+func (s *Server) Mempool() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	txids := make([]string, len(s.mempool))
+	for i, txid := range s.mempool {
+		txids[i] = txid.String()
+	}
+	return txids
+}
+
+// RawTransaction returns a transaction known to the node, nil if not found
+func (s *Server) RawTransaction(txid string) *wire.MsgTx {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	hash, err := wire.NewHashFromStr(txid)
+	if err != nil {
+		return nil
+	}
+	b, isKnown := s.txns[hash]
+	if !isKnown {
+		return nil
+	}
+	tx, _ := wire.DeserializeBytes(b)
+	return tx
+}
+
+func (s *Server) formatCoins(value uint64) json.Number {
+	return json.Number(core.NewAmount(s.params.Ticker, value).Format(s.params.Accuracy))
+}
+
+// ServeHTTP dispatches JSON-RPC requests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.User != "" || s.Password != "" {
+		user, password, hasAuth := r.BasicAuth()
+		if !hasAuth || user != s.User || password != s.Password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.mutex.Lock()
+	result, rpcErr := s.dispatch(req.Method, req.Params)
+	s.mutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if rpcErr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"result": result,
+		"error":  rpcErr,
+		"id":     req.ID,
+	})
+}
+
+func invalidParams() *rpcError {
+	return &rpcError{Code: ErrCodeInvalidParams, Message: "Invalid parameters"}
+}
+
+func (s *Server) dispatch(method string, args []json.RawMessage) (interface{}, *rpcError) {
+	switch method {
+	case "getblockchaininfo":
+		return map[string]interface{}{
+			"chain":         strings.ToLower(s.params.NetType),
+			"blocks":        s.height(),
+			"headers":       s.height(),
+			"bestblockhash": s.blocks[s.height()].String(),
+			"mediantime":    s.times[s.height()],
+			"pruned":        false,
+		}, nil
+	case "getbestblockhash":
+		return s.blocks[s.height()].String(), nil
+	case "getblockheader":
+		var hashStr string
+		if len(args) < 1 || json.Unmarshal(args[0], &hashStr) != nil {
+			return nil, invalidParams()
+		}
+		for h, hash := range s.blocks {
+			if hash.String() != hashStr {
+				continue
+			}
+			header := map[string]interface{}{
+				"hash":    hashStr,
+				"height":  h,
+				"version": 0x20000000,
+				"time":    s.times[h],
+			}
+			if h > 0 {
+				header["previousblockhash"] = s.blocks[h-1].String()
+			}
+			return header, nil
+		}
+		return nil, &rpcError{Code: ErrCodeNotFound, Message: "Block not found"}
+	case "getrawmempool":
+		txids := make([]string, len(s.mempool))
+		for i, txid := range s.mempool {
+			txids[i] = txid.String()
+		}
+		return txids, nil
+	case "getrawtransaction":
+		var txid string
+		if len(args) < 1 || json.Unmarshal(args[0], &txid) != nil {
+			return nil, invalidParams()
+		}
+		hash, err := wire.NewHashFromStr(txid)
+		if err != nil {
+			return nil, invalidParams()
+		}
+		b, isKnown := s.txns[hash]
+		if !isKnown {
+			return nil, &rpcError{Code: ErrCodeNotFound, Message: "No such mempool or blockchain transaction"}
+		}
+		return hex.EncodeToString(b), nil
+	case "gettxout":
+		return s.getTxOut(args)
+	case "getpeerinfo":
+		if s.Peers == nil {
+			return []interface{}{}, nil
+		}
+		return s.Peers, nil
+	case "sendrawtransaction":
+		return s.sendRawTransaction(args)
+	case "estimatesmartfee":
+		if s.FeeRate == "" {
+			return map[string]interface{}{"errors": []string{"Insufficient data or no feerate found"}, "blocks": 0}, nil
+		}
+		return map[string]interface{}{"feerate": json.Number(s.FeeRate), "blocks": 2}, nil
+	case "scantxoutset":
+		return s.scanTxOutSet(args)
+	}
+	return nil, &rpcError{Code: ErrCodeMethodNotFound, Message: "Method not found"}
+}
+
+func (s *Server) getTxOut(args []json.RawMessage) (interface{}, *rpcError) {
+	var txid string
+	var vout uint32
+	includeMempool := true
+	if len(args) < 2 || json.Unmarshal(args[0], &txid) != nil || json.Unmarshal(args[1], &vout) != nil {
+		return nil, invalidParams()
+	}
+	if len(args) > 2 && json.Unmarshal(args[2], &includeMempool) != nil {
+		return nil, invalidParams()
+	}
+	hash, err := wire.NewHashFromStr(txid)
+	if err != nil {
+		return nil, invalidParams()
+	}
+	outPoint := wire.OutPoint{Hash: hash, Index: vout}
+	ux, isUnspent := s.utxos[outPoint]
+	if includeMempool {
+		if _, isSpent := s.spent[outPoint]; isSpent {
+			return nil, nil
+		}
+		if !isUnspent {
+			for _, memTxid := range s.mempool {
+				if memTxid != hash {
+					continue
+				}
+				tx, _ := wire.DeserializeBytes(s.txns[memTxid])
+				if int(vout) < len(tx.TxOut) {
+					ux, isUnspent = utxo{value: tx.TxOut[vout].Value, pkScript: tx.TxOut[vout].PkScript, height: s.height() + 1}, true
+				}
+			}
+		}
+	}
+	if !isUnspent {
+		return nil, nil
+	}
+	return map[string]interface{}{
+		"bestblock":     s.blocks[s.height()].String(),
+		"confirmations": s.height() + 1 - ux.height,
+		"value":         s.formatCoins(ux.value),
+		"scriptPubKey":  map[string]interface{}{"hex": hex.EncodeToString(ux.pkScript)},
+	}, nil
+}
+
+func (s *Server) sendRawTransaction(args []json.RawMessage) (interface{}, *rpcError) {
+	var rawTxn string
+	if len(args) < 1 || json.Unmarshal(args[0], &rawTxn) != nil {
+		return nil, invalidParams()
+	}
+	b, err := hex.DecodeString(rawTxn)
+	if err != nil {
+		return nil, &rpcError{Code: ErrCodeDeserialize, Message: "TX decode failed"}
+	}
+	tx, err := wire.DeserializeBytes(b)
+	if err != nil {
+		return nil, &rpcError{Code: ErrCodeDeserialize, Message: "TX decode failed"}
+	}
+	txid := tx.TxHash()
+	if _, isKnown := s.txns[txid]; isKnown {
+		return nil, &rpcError{Code: ErrCodeAlreadyInChain, Message: "Transaction already in block chain"}
+	}
+	var inputs, outputs uint64
+	for _, in := range tx.TxIn {
+		ux, isUnspent := s.utxos[in.PreviousOutPoint]
+		if _, isSpent := s.spent[in.PreviousOutPoint]; !isUnspent || isSpent {
+			return nil, &rpcError{Code: ErrCodeVerifyRejected, Message: "bad-txns-inputs-missingorspent"}
+		}
+		if len(in.SignatureScript) == 0 && len(in.Witness) == 0 {
+			return nil, &rpcError{Code: ErrCodeVerifyRejected, Message: "mandatory-script-verify-flag-failed"}
+		}
+		inputs += ux.value
+	}
+	for _, out := range tx.TxOut {
+		if out.Value < s.params.DustLimit {
+			return nil, &rpcError{Code: ErrCodeVerifyRejected, Message: "dust"}
+		}
+		outputs += out.Value
+	}
+	if outputs > inputs {
+		return nil, &rpcError{Code: ErrCodeVerifyRejected, Message: "bad-txns-in-belowout"}
+	}
+	for _, in := range tx.TxIn {
+		s.spent[in.PreviousOutPoint] = txid
+	}
+	s.txns[txid] = b
+	s.mempool = append(s.mempool, txid)
+	return txid.String(), nil
+}
+
+func (s *Server) scanTxOutSet(args []json.RawMessage) (interface{}, *rpcError) {
+	var action string
+	var descriptors []string
+	if len(args) < 2 || json.Unmarshal(args[0], &action) != nil || json.Unmarshal(args[1], &descriptors) != nil || action != "start" {
+		return nil, invalidParams()
+	}
+	scripts := make(map[string]string, len(descriptors))
+	for _, desc := range descriptors {
+		if !strings.HasPrefix(desc, "raw(") || !strings.HasSuffix(desc, ")") {
+			return nil, &rpcError{Code: ErrCodeInvalidParams, Message: "Unsupported descriptor " + desc}
+		}
+		script, err := hex.DecodeString(desc[4 : len(desc)-1])
+		if err != nil {
+			return nil, invalidParams()
+		}
+		scripts[string(script)] = desc
+	}
+	unspents := make([]map[string]interface{}, 0)
+	var total uint64
+	for outPoint, ux := range s.utxos {
+		desc, isMatch := scripts[string(ux.pkScript)]
+		if !isMatch {
+			continue
+		}
+		total += ux.value
+		unspents = append(unspents, map[string]interface{}{
+			"txid":         outPoint.Hash.String(),
+			"vout":         outPoint.Index,
+			"scriptPubKey": hex.EncodeToString(ux.pkScript),
+			"desc":         desc,
+			"amount":       s.formatCoins(ux.value),
+			"height":       ux.height,
+		})
+	}
+	// Deterministic order simplifies assertions
+	sortUnspents(unspents)
+	return map[string]interface{}{
+		"success":      true,
+		"height":       s.height(),
+		"unspents":     unspents,
+		"total_amount": s.formatCoins(total),
+	}, nil
+}
+
+func sortUnspents(unspents []map[string]interface{}) {
+	key := func(i int) string {
+		return unspents[i]["txid"].(string) + ":" + strconv.FormatUint(uint64(unspents[i]["vout"].(uint32)), 10)
+	}
+	sort.Slice(unspents, func(i, j int) bool {
+		return key(i) < key(j)
+	})
+}
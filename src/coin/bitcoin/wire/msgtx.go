@@ -0,0 +1,396 @@
+package wire
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+const (
+	// HashSize number of bytes in transaction and block hashes
+	HashSize = 32
+	// WitnessScaleFactor weight of non-witness bytes relative to witness data
+	WitnessScaleFactor = 4
+	// MaxTxInSequenceNum sequence number disabling relative lock time and RBF
+	MaxTxInSequenceNum uint32 = 0xffffffff
+
+	witnessMarker = 0x00
+	witnessFlag   = 0x01
+	// maxVarSliceLen guards against allocating huge buffers on malformed input
+	maxVarSliceLen = 1 << 24
+)
+
+var (
+	// ErrTrailingBytes raw transaction has extra data after lock time
+	ErrTrailingBytes = errors.New("Unexpected bytes after end of transaction")
+	// ErrInvalidHashLength hash string does not encode 32 bytes
+	ErrInvalidHashLength = errors.New("Invalid hash length")
+	// ErrVarSliceTooLong serialized slice exceeds allowed size
+	ErrVarSliceTooLong = errors.New("Serialized slice too long")
+)
+
+// Hash is a double SHA256 digest stored in internal byte order
+type Hash [HashSize]byte
+
+// String returns hash hex in the reversed byte order displayed by nodes and explorers
+func (h Hash) String() string {
+	var rev Hash
+	for i := 0; i < HashSize; i++ {
+		rev[i] = h[HashSize-1-i]
+	}
+	return hex.EncodeToString(rev[:])
+}
+
+// NewHashFromStr parses hash hex in the reversed byte order displayed by nodes and explorers
+func NewHashFromStr(s string) (Hash, error) {
+	var h Hash
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return h, err
+	}
+	if len(b) != HashSize {
+		return h, ErrInvalidHashLength
+	}
+	for i := 0; i < HashSize; i++ {
+		h[i] = b[HashSize-1-i]
+	}
+	return h, nil
+}
+
+// DoubleHashH computes SHA256(SHA256(b))
+func DoubleHashH(b []byte) Hash {
+	first := sha256.Sum256(b)
+	return Hash(sha256.Sum256(first[:]))
+}
+
+// OutPoint references a previous transaction output
+type OutPoint struct {
+	Hash  Hash
+	Index uint32
+}
+
+// TxIn spends a previous transaction output
+type TxIn struct {
+	PreviousOutPoint OutPoint
+	SignatureScript  []byte
+	Witness          [][]byte
+	Sequence         uint32
+}
+
+// TxOut locks value with a public key script
+type TxOut struct {
+	Value    uint64
+	PkScript []byte
+}
+
+// MsgTx is a Bitcoin transaction as transmitted over the wire
+type MsgTx struct {
+	Version  int32
+	TxIn     []*TxIn
+	TxOut    []*TxOut
+	LockTime uint32
+}
+
+// NewMsgTx instantiates an empty transaction
+func NewMsgTx(version int32) *MsgTx {
+	return &MsgTx{Version: version}
+}
+
+// HasWitness determines whether any input carries witness data
+func (tx *MsgTx) HasWitness() bool {
+	for _, in := range tx.TxIn {
+		if len(in.Witness) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Copy creates a deep copy of the transaction
+func (tx *MsgTx) Copy() *MsgTx {
+	newTx := &MsgTx{
+		Version:  tx.Version,
+		TxIn:     make([]*TxIn, len(tx.TxIn)),
+		TxOut:    make([]*TxOut, len(tx.TxOut)),
+		LockTime: tx.LockTime,
+	}
+	for i, in := range tx.TxIn {
+		newIn := &TxIn{
+			PreviousOutPoint: in.PreviousOutPoint,
+			SignatureScript:  copyBytes(in.SignatureScript),
+			Sequence:         in.Sequence,
+		}
+		if in.Witness != nil {
+			newIn.Witness = make([][]byte, len(in.Witness))
+			for j, item := range in.Witness {
+				newIn.Witness[j] = copyBytes(item)
+			}
+		}
+		newTx.TxIn[i] = newIn
+	}
+	for i, out := range tx.TxOut {
+		newTx.TxOut[i] = &TxOut{Value: out.Value, PkScript: copyBytes(out.PkScript)}
+	}
+	return newTx
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append(make([]byte, 0, len(b)), b...)
+}
+
+// Serialize encodes transaction, including witness data if requested and available
+func (tx *MsgTx) Serialize(w io.Writer, witness bool) error {
+	witness = witness && tx.HasWitness()
+	if err := binary.Write(w, binary.LittleEndian, tx.Version); err != nil {
+		return err
+	}
+	if witness {
+		if _, err := w.Write([]byte{witnessMarker, witnessFlag}); err != nil {
+			return err
+		}
+	}
+	if err := WriteVarInt(w, uint64(len(tx.TxIn))); err != nil {
+		return err
+	}
+	for _, in := range tx.TxIn {
+		if _, err := w.Write(in.PreviousOutPoint.Hash[:]); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, in.PreviousOutPoint.Index); err != nil {
+			return err
+		}
+		if err := WriteVarBytes(w, in.SignatureScript); err != nil {
+			return err
+		}
+		if err := binary.Write(w, binary.LittleEndian, in.Sequence); err != nil {
+			return err
+		}
+	}
+	if err := WriteVarInt(w, uint64(len(tx.TxOut))); err != nil {
+		return err
+	}
+	for _, out := range tx.TxOut {
+		if err := binary.Write(w, binary.LittleEndian, out.Value); err != nil {
+			return err
+		}
+		if err := WriteVarBytes(w, out.PkScript); err != nil {
+			return err
+		}
+	}
+	if witness {
+		for _, in := range tx.TxIn {
+			if err := WriteVarInt(w, uint64(len(in.Witness))); err != nil {
+				return err
+			}
+			for _, item := range in.Witness {
+				if err := WriteVarBytes(w, item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return binary.Write(w, binary.LittleEndian, tx.LockTime)
+}
+
+// Bytes encodes transaction, including witness data if requested and available
+func (tx *MsgTx) Bytes(witness bool) []byte {
+	var buf bytes.Buffer
+	// Writing to bytes.Buffer never fails
+	_ = tx.Serialize(&buf, witness)
+	return buf.Bytes()
+}
+
+// TxHash computes transaction ID, which never commits to witness data
+func (tx *MsgTx) TxHash() Hash {
+	return DoubleHashH(tx.Bytes(false))
+}
+
+// WitnessHash computes transaction hash including witness data
+func (tx *MsgTx) WitnessHash() Hash {
+	return DoubleHashH(tx.Bytes(true))
+}
+
+// Weight computes BIP141 transaction weight
+func (tx *MsgTx) Weight() int {
+	base := len(tx.Bytes(false))
+	total := len(tx.Bytes(true))
+	return base*(WitnessScaleFactor-1) + total
+}
+
+// VirtualSize computes BIP141 virtual size used to determine fees
+func (tx *MsgTx) VirtualSize() int {
+	return (tx.Weight() + WitnessScaleFactor - 1) / WitnessScaleFactor
+}
+
+// Deserialize decodes a transaction in either legacy or segwit encoding
+func Deserialize(r io.Reader) (*MsgTx, error) {
+	tx := new(MsgTx)
+	if err := binary.Read(r, binary.LittleEndian, &tx.Version); err != nil {
+		return nil, err
+	}
+	count, err := ReadVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+	witness := false
+	if count == witnessMarker {
+		var flag [1]byte
+		if _, err = io.ReadFull(r, flag[:]); err != nil {
+			return nil, err
+		}
+		if flag[0] != witnessFlag {
+			return nil, errors.New("Invalid witness flag")
+		}
+		witness = true
+		if count, err = ReadVarInt(r); err != nil {
+			return nil, err
+		}
+	}
+	if count > maxVarSliceLen {
+		return nil, ErrVarSliceTooLong
+	}
+	tx.TxIn = make([]*TxIn, count)
+	for i := range tx.TxIn {
+		in := new(TxIn)
+		if _, err = io.ReadFull(r, in.PreviousOutPoint.Hash[:]); err != nil {
+			return nil, err
+		}
+		if err = binary.Read(r, binary.LittleEndian, &in.PreviousOutPoint.Index); err != nil {
+			return nil, err
+		}
+		if in.SignatureScript, err = ReadVarBytes(r); err != nil {
+			return nil, err
+		}
+		if err = binary.Read(r, binary.LittleEndian, &in.Sequence); err != nil {
+			return nil, err
+		}
+		tx.TxIn[i] = in
+	}
+	if count, err = ReadVarInt(r); err != nil {
+		return nil, err
+	}
+	if count > maxVarSliceLen {
+		return nil, ErrVarSliceTooLong
+	}
+	tx.TxOut = make([]*TxOut, count)
+	for i := range tx.TxOut {
+		out := new(TxOut)
+		if err = binary.Read(r, binary.LittleEndian, &out.Value); err != nil {
+			return nil, err
+		}
+		if out.PkScript, err = ReadVarBytes(r); err != nil {
+			return nil, err
+		}
+		tx.TxOut[i] = out
+	}
+	if witness {
+		for _, in := range tx.TxIn {
+			if count, err = ReadVarInt(r); err != nil {
+				return nil, err
+			}
+			if count > maxVarSliceLen {
+				return nil, ErrVarSliceTooLong
+			}
+			in.Witness = make([][]byte, count)
+			for j := range in.Witness {
+				if in.Witness[j], err = ReadVarBytes(r); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if err = binary.Read(r, binary.LittleEndian, &tx.LockTime); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// DeserializeBytes decodes a transaction rejecting trailing data
+func DeserializeBytes(b []byte) (*MsgTx, error) {
+	r := bytes.NewReader(b)
+	tx, err := Deserialize(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() > 0 {
+		return nil, ErrTrailingBytes
+	}
+	return tx, nil
+}
+
+// WriteVarInt encodes a Bitcoin compact size integer
+func WriteVarInt(w io.Writer, n uint64) error {
+	var buf []byte
+	switch {
+	case n < 0xfd:
+		buf = []byte{byte(n)}
+	case n <= 0xffff:
+		buf = make([]byte, 3)
+		buf[0] = 0xfd
+		binary.LittleEndian.PutUint16(buf[1:], uint16(n))
+	case n <= 0xffffffff:
+		buf = make([]byte, 5)
+		buf[0] = 0xfe
+		binary.LittleEndian.PutUint32(buf[1:], uint32(n))
+	default:
+		buf = make([]byte, 9)
+		buf[0] = 0xff
+		binary.LittleEndian.PutUint64(buf[1:], n)
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// ReadVarInt decodes a Bitcoin compact size integer
+func ReadVarInt(r io.Reader) (uint64, error) {
+	var prefix [1]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return 0, err
+	}
+	switch prefix[0] {
+	case 0xfd:
+		var v uint16
+		err := binary.Read(r, binary.LittleEndian, &v)
+		return uint64(v), err
+	case 0xfe:
+		var v uint32
+		err := binary.Read(r, binary.LittleEndian, &v)
+		return uint64(v), err
+	case 0xff:
+		var v uint64
+		err := binary.Read(r, binary.LittleEndian, &v)
+		return v, err
+	}
+	return uint64(prefix[0]), nil
+}
+
+// WriteVarBytes encodes a byte slice prefixed by its length
+func WriteVarBytes(w io.Writer, b []byte) error {
+	if err := WriteVarInt(w, uint64(len(b))); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// ReadVarBytes decodes a byte slice prefixed by its length
+func ReadVarBytes(r io.Reader) ([]byte, error) {
+	n, err := ReadVarInt(r)
+	if err != nil {
+		return nil, err
+	}
+	if n > maxVarSliceLen {
+		return nil, ErrVarSliceTooLong
+	}
+	b := make([]byte, n)
+	if _, err = io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,82 @@
+package wire
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestTx(witness bool) *MsgTx {
+	tx := NewMsgTx(2)
+	prev, _ := NewHashFromStr("4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b")
+	in := &TxIn{
+		PreviousOutPoint: OutPoint{Hash: prev, Index: 1},
+		SignatureScript:  []byte{0x51},
+		Sequence:         MaxTxInSequenceNum,
+	}
+	if witness {
+		in.SignatureScript = []byte{}
+		in.Witness = [][]byte{bytes.Repeat([]byte{0x30}, 71), bytes.Repeat([]byte{0x02}, 33)}
+	}
+	tx.TxIn = append(tx.TxIn, in)
+	tx.TxOut = append(tx.TxOut,
+		&TxOut{Value: 100000, PkScript: append([]byte{0x00, 0x14}, bytes.Repeat([]byte{0xab}, 20)...)},
+		&TxOut{Value: 0xfffffffff, PkScript: bytes.Repeat([]byte{0x6a}, 300)},
+	)
+	return tx
+}
+
+func TestHashString(t *testing.T) {
+	str := "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+	h, err := NewHashFromStr(str)
+	require.NoError(t, err)
+	require.Equal(t, byte(0x3b), h[0])
+	require.Equal(t, str, h.String())
+	_, err = NewHashFromStr("abcd")
+	require.Equal(t, ErrInvalidHashLength, err)
+}
+
+func TestMsgTxRoundTrip(t *testing.T) {
+	for _, witness := range []bool{false, true} {
+		tx := makeTestTx(witness)
+		raw := tx.Bytes(true)
+		decoded, err := DeserializeBytes(raw)
+		require.NoError(t, err)
+		require.Equal(t, tx, decoded)
+		require.Equal(t, tx.TxHash(), decoded.TxHash())
+		require.Equal(t, witness, decoded.HasWitness())
+		if witness {
+			require.NotEqual(t, tx.TxHash(), tx.WitnessHash())
+			require.True(t, len(tx.Bytes(true)) > len(tx.Bytes(false)))
+		} else {
+			require.Equal(t, tx.TxHash(), tx.WitnessHash())
+			require.Equal(t, len(raw)*WitnessScaleFactor, tx.Weight())
+		}
+		require.Equal(t, (tx.Weight()+3)/4, tx.VirtualSize())
+
+		_, err = DeserializeBytes(append(raw, 0x00))
+		require.Equal(t, ErrTrailingBytes, err)
+		_, err = DeserializeBytes(raw[:len(raw)-1])
+		require.Error(t, err)
+	}
+}
+
+func TestMsgTxCopy(t *testing.T) {
+	tx := makeTestTx(true)
+	cp := tx.Copy()
+	require.Equal(t, tx, cp)
+	cp.TxIn[0].Witness[0][0] = 0xff
+	cp.TxOut[0].Value = 1
+	require.NotEqual(t, tx, cp)
+}
+
+func TestVarInt(t *testing.T) {
+	for _, n := range []uint64{0, 0xfc, 0xfd, 0xffff, 0x10000, 0xffffffff, 0x100000000} {
+		var buf bytes.Buffer
+		require.NoError(t, WriteVarInt(&buf, n))
+		v, err := ReadVarInt(&buf)
+		require.NoError(t, err)
+		require.Equal(t, n, v)
+	}
+}
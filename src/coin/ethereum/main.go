@@ -0,0 +1,49 @@
+package ethereum
+
+import (
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/config"
+	eth "github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/models"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logEthereum = logging.MustGetLogger("Ethereum Altcoin")
+
+var networks = map[string]params.EthereumParams{
+	params.EthereumMainNetParams.NetType: params.EthereumMainNetParams,
+	params.EthereumDevNetParams.NetType:  params.EthereumDevNetParams,
+}
+
+func init() {
+	UpdateAltcoin()
+}
+
+// UpdateAltcoin refreshes Ethereum node settings and registers plugin for configured network
+func UpdateAltcoin() {
+	if err := config.RegisterConfig(); err != nil {
+		logEthereum.Warn("Couldn't register Ethereum configuration")
+		return
+	}
+	node, err := config.GetSettings(config.SettingPathToNode)
+	if err != nil {
+		logEthereum.WithError(err).Warn("Couldn't get node settings")
+		return
+	}
+	wallets, err := config.GetSettings(config.SettingPathToWallets)
+	if err != nil {
+		logEthereum.WithError(err).Warn("Couldn't get wallet settings")
+		return
+	}
+	p, isKnown := networks[node[config.SettingNodeNetwork]]
+	if !isKnown {
+		logEthereum.WithField("network", node[config.SettingNodeNetwork]).Warn("Unknown Ethereum network")
+		return
+	}
+	factory := eth.NewEthereumConnectionFactory(node[config.SettingNodeAddress])
+	if err := core.GetMultiPool().CreateSection(eth.PoolSectionName(p), factory); err != nil {
+		logEthereum.Warn("Couldn't create section for Ethereum")
+	}
+	util.RegisterAltcoin(eth.NewEthereumPlugin(p, wallets[config.SettingWalletsDirPath]))
+}
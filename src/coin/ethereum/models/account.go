@@ -0,0 +1,159 @@
+package ethereum
+
+import (
+	"math/big"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/types"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logAccount = logging.MustGetLogger("Ethereum account")
+
+// assetBalance returns wei or token units of asset represented by ticker held by account at block tag
+func assetBalance(c EthereumRPC, p params.EthereumParams, addr types.Address, ticker, tag string) (*big.Int, error) {
+	if ticker == p.Ticker {
+		return c.GetBalance(addr, tag)
+	}
+	token, isToken := p.LookupToken(ticker)
+	if !isToken {
+		return nil, errors.ErrInvalidAltcoinTicker
+	}
+	contract, err := types.ParseAddress(token.Contract)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.CallContract(CallMsg{To: &contract, Data: types.ERC20BalanceOfData(addr)}, tag)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(result), nil
+}
+
+// EthereumAccount tracks ether and token balances of a set of addresses
+type EthereumAccount struct { // Implements CryptoAccount interface
+	params      params.EthereumParams
+	poolSection string
+	addresses   func() ([]*EthereumAddress, error)
+}
+
+func newEthereumAccount(p params.EthereumParams, poolSection string, addresses func() ([]*EthereumAddress, error)) *EthereumAccount {
+	return &EthereumAccount{
+		params:      p,
+		poolSection: poolSection,
+		addresses:   addresses,
+	}
+}
+
+// balances looks up confirmed balances of asset represented by ticker held by account addresses
+func (acc *EthereumAccount) balances(ticker string) ([]*EthereumAddress, []uint64, error) {
+	addrs, err := acc.addresses()
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := NewEthereumRPCClient(acc.poolSection)
+	if err != nil {
+		logAccount.WithError(err).Error("Couldn't get RPC client")
+		return nil, nil, err
+	}
+	defer ReturnEthereumRPCClient(c)
+	coins := make([]uint64, len(addrs))
+	for i, addr := range addrs {
+		balance, err := assetBalance(c, acc.params, addr.addr, ticker, BlockLatest)
+		if err != nil {
+			logAccount.WithError(err).WithField("ticker", ticker).Error("Couldn't get balance")
+			return nil, nil, err
+		}
+		// Dust below asset accuracy can not be spent, hence not shown
+		amount, err := toAmount(acc.params, ticker, balance, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		coins[i] = amount.Coins
+	}
+	return addrs, coins, nil
+}
+
+// GetBalance retrieves confirmed coins of asset represented by ticker held by account addresses
+func (acc *EthereumAccount) GetBalance(ticker string) (uint64, error) {
+	if _, isKnown := acc.params.UnitScale(ticker); !isKnown {
+		return 0, errors.ErrInvalidAltcoinTicker
+	}
+	_, coins, err := acc.balances(ticker)
+	if err != nil {
+		return 0, err
+	}
+	balance := core.NewAmount(ticker, 0)
+	for _, c := range coins {
+		if balance, err = balance.Add(core.NewAmount(ticker, c)); err != nil {
+			return 0, err
+		}
+	}
+	return balance.Coins, nil
+}
+
+// ListAssets to enumerate ether and tokens supported by this account
+func (acc *EthereumAccount) ListAssets() []string {
+	return acc.params.Tickers()
+}
+
+// ScanUnspentOutputs returns an output per non-empty balance of each address and asset
+func (acc *EthereumAccount) ScanUnspentOutputs() (core.TransactionOutputIterator, error) {
+	outputs := make([]core.TransactionOutput, 0)
+	for _, ticker := range acc.params.Tickers() {
+		addrs, coins, err := acc.balances(ticker)
+		if err != nil {
+			return nil, err
+		}
+		for i, addr := range addrs {
+			if coins[i] == 0 {
+				continue
+			}
+			outputs = append(outputs, newEthereumTransactionOutput(acc.params, balanceOutputID(addr.addr, ticker), addr.addr, ticker, coins[i]))
+		}
+	}
+	return NewEthereumTransactionOutputIterator(outputs), nil
+}
+
+// ListTransactions to show account history
+//
+// Ethereum nodes do not index transactions by account, hence history is not available
+func (acc *EthereumAccount) ListTransactions() core.TransactionIterator {
+	logAccount.Warn("Transaction history is not supported by Ethereum JSON-RPC API")
+	return NewEthereumTransactionIterator(nil)
+}
+
+// ListPendingTransactions lists pending transactions sent by or paying to account addresses
+func (acc *EthereumAccount) ListPendingTransactions() (core.TransactionIterator, error) {
+	addrs, err := acc.addresses()
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[types.Address]struct{}, len(addrs))
+	for _, addr := range addrs {
+		owned[addr.addr] = struct{}{}
+	}
+	pending, err := getPendingTransactions(acc.params, acc.poolSection)
+	if err != nil {
+		return nil, err
+	}
+	txns := make([]core.Transaction, 0)
+	for _, txn := range pending {
+		_, isSender := owned[txn.from]
+		isRecipient := false
+		if t, isTransfer := decodeTransfer(acc.params, txn.tx); isTransfer {
+			_, isRecipient = owned[t.recipient]
+		}
+		if isSender || isRecipient {
+			txns = append(txns, txn)
+		}
+	}
+	return NewEthereumTransactionIterator(txns), nil
+}
+
+// Type assertions
+var (
+	_ core.CryptoAccount = &EthereumAccount{}
+)
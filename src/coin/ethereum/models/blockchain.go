@@ -0,0 +1,218 @@
+package ethereum
+
+import (
+	"math/big"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/types"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logBlockchain = logging.MustGetLogger("Ethereum blockchain")
+
+// EthereumBlock header data of a block in the chain
+type EthereumBlock struct { // Implements Block interface
+	Header *BlockHeader
+}
+
+// GetHash returns block hash
+func (eb *EthereumBlock) GetHash() ([]byte, error) {
+	if eb.Header == nil {
+		return nil, errors.ErrBlockNotSet
+	}
+	return types.DecodeHex(eb.Header.Hash)
+}
+
+// GetPrevHash returns hash of parent block
+func (eb *EthereumBlock) GetPrevHash() ([]byte, error) {
+	if eb.Header == nil {
+		return nil, errors.ErrBlockNotSet
+	}
+	return types.DecodeHex(eb.Header.ParentHash)
+}
+
+// GetVersion returns zero since Ethereum blocks are not versioned
+func (eb *EthereumBlock) GetVersion() (uint32, error) {
+	if eb.Header == nil {
+		return 0, errors.ErrBlockNotSet
+	}
+	return 0, nil
+}
+
+// GetTime returns block timestamp
+func (eb *EthereumBlock) GetTime() (core.Timestamp, error) {
+	if eb.Header == nil {
+		return 0, errors.ErrBlockNotSet
+	}
+	t, err := types.DecodeUint64(eb.Header.Timestamp)
+	return core.Timestamp(t), err
+}
+
+// GetHeight returns block number
+func (eb *EthereumBlock) GetHeight() (uint64, error) {
+	if eb.Header == nil {
+		return 0, errors.ErrBlockNotSet
+	}
+	return types.DecodeUint64(eb.Header.Number)
+}
+
+// GetFee is not available from block headers
+func (eb *EthereumBlock) GetFee(ticker string) (uint64, error) {
+	if eb.Header == nil {
+		return 0, errors.ErrBlockNotSet
+	}
+	return 0, errors.ErrNotImplemented
+}
+
+// IsGenesisBlock determines whether this is the first block in the chain
+func (eb *EthereumBlock) IsGenesisBlock() (bool, error) {
+	height, err := eb.GetHeight()
+	return height == 0, err
+}
+
+// GetTransactions is not available from block headers
+func (eb *EthereumBlock) GetTransactions() (core.TransactionIterator, error) {
+	return nil, errors.ErrNotImplemented
+}
+
+// EthereumBlockchain queries chain status and creates transactions through an Ethereum JSON-RPC node
+type EthereumBlockchain struct { // Implements BlockchainStatus and BlockchainTransactionAPI interfaces
+	params      params.EthereumParams
+	poolSection string
+}
+
+// NewEthereumBlockchain instantiates blockchain API for network params
+func NewEthereumBlockchain(p params.EthereumParams) *EthereumBlockchain {
+	return &EthereumBlockchain{params: p, poolSection: PoolSectionName(p)}
+}
+
+// GetCoinValue retrieves supply of tokens, ether supply is not available via JSON-RPC
+func (bc *EthereumBlockchain) GetCoinValue(coinvalue core.CoinValueMetric, ticker string) (uint64, error) {
+	logBlockchain.Info("Getting coin value")
+	if ticker == bc.params.Ticker {
+		return 0, errors.ErrNotImplemented
+	}
+	token, isToken := bc.params.LookupToken(ticker)
+	if !isToken {
+		return 0, errors.ErrInvalidAltcoinTicker
+	}
+	if coinvalue != core.CoinCurrentSupply && coinvalue != core.CoinTotalSupply {
+		return 0, errors.ErrInvalidOptions
+	}
+	contract, err := types.ParseAddress(token.Contract)
+	if err != nil {
+		return 0, err
+	}
+	c, err := NewEthereumRPCClient(bc.poolSection)
+	if err != nil {
+		return 0, err
+	}
+	defer ReturnEthereumRPCClient(c)
+	result, err := c.CallContract(CallMsg{To: &contract, Data: types.ERC20TotalSupplyData()}, BlockLatest)
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't get token supply")
+		return 0, err
+	}
+	supply, err := toAmount(bc.params, ticker, new(big.Int).SetBytes(result), false)
+	return supply.Coins, err
+}
+
+// GetLastBlock retrieves block at the tip of the chain
+func (bc *EthereumBlockchain) GetLastBlock() (core.Block, error) {
+	logBlockchain.Info("Getting last block")
+	c, err := NewEthereumRPCClient(bc.poolSection)
+	if err != nil {
+		return nil, err
+	}
+	defer ReturnEthereumRPCClient(c)
+	header, err := c.GetBlockByNumber(BlockLatest)
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't get latest block")
+		return nil, err
+	}
+	return &EthereumBlock{Header: header}, nil
+}
+
+// GetNumberOfBlocks determine number of blocks in the chain, including genesis block
+func (bc *EthereumBlockchain) GetNumberOfBlocks() (uint64, error) {
+	logBlockchain.Info("Getting number of blocks")
+	c, err := NewEthereumRPCClient(bc.poolSection)
+	if err != nil {
+		return 0, err
+	}
+	defer ReturnEthereumRPCClient(c)
+	number, err := c.BlockNumber()
+	if err != nil {
+		return 0, err
+	}
+	return number + 1, nil
+}
+
+// GetBlockByHeight is not supported yet
+func (bc *EthereumBlockchain) GetBlockByHeight(height uint64) (core.Block, error) {
+	return nil, errors.ErrNotImplemented
+}
+
+// GetBlockByHash is not supported yet
+func (bc *EthereumBlockchain) GetBlockByHash(hash string) (core.Block, error) {
+	return nil, errors.ErrNotImplemented
+}
+
+// GetBlockRange is not supported yet
+func (bc *EthereumBlockchain) GetBlockRange(start, end uint64) (core.BlockIterator, error) {
+	return nil, errors.ErrNotImplemented
+}
+
+// GetTransaction is not supported yet
+func (bc *EthereumBlockchain) GetTransaction(txid string) (core.Transaction, error) {
+	return nil, errors.ErrNotImplemented
+}
+
+// GetAddressBalance is not supported yet
+func (bc *EthereumBlockchain) GetAddressBalance(addr, ticker string) (uint64, error) {
+	return 0, errors.ErrNotImplemented
+}
+
+// GetAddressTransactions is not supported yet
+func (bc *EthereumBlockchain) GetAddressTransactions(addr string) (core.TransactionIterator, error) {
+	return nil, errors.ErrNotImplemented
+}
+
+// SendFromAddress instantiates a transaction to send funds from a single account to a single recipient
+func (bc *EthereumBlockchain) SendFromAddress(from []core.WalletAddress, to []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	logBlockchain.Info("Sending coins from address via blockchain API")
+	if len(from) != 1 || len(to) != 1 {
+		return nil, ErrSingleTransfer
+	}
+	sender, err := NewEthereumAddress(from[0].GetAddress().String(), bc.params)
+	if err != nil {
+		return nil, err
+	}
+	return buildTransaction(bc.params, sender.addr, to[0], options)
+}
+
+// Spend instantiates a transaction debiting the account owning balance outputs
+func (bc *EthereumBlockchain) Spend(unspent []core.WalletOutput, new []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	logBlockchain.Info("Spending coins from outputs via blockchain API")
+	if len(new) != 1 {
+		return nil, ErrSingleTransfer
+	}
+	uxouts := make([]core.TransactionOutput, len(unspent))
+	for i, wu := range unspent {
+		uxouts[i] = wu.GetOutput()
+	}
+	from, err := singleSender(bc.params, uxouts)
+	if err != nil {
+		return nil, err
+	}
+	return buildTransaction(bc.params, from, new[0], options)
+}
+
+// Type assertions
+var (
+	_ core.Block                    = &EthereumBlock{}
+	_ core.BlockchainStatus         = &EthereumBlockchain{}
+	_ core.BlockchainTransactionAPI = &EthereumBlockchain{}
+)
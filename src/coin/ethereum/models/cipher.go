@@ -0,0 +1,183 @@
+package ethereum
+
+import (
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/types"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+)
+
+// EthereumAddress identifies an Ethereum account
+type EthereumAddress struct { // Implements Address interface
+	addr        types.Address
+	isBip32     bool
+	params      params.EthereumParams
+	poolSection string
+}
+
+// NewEthereumAddress parses hex address for network params
+func NewEthereumAddress(addrStr string, p params.EthereumParams) (*EthereumAddress, error) {
+	addr, err := types.ParseAddress(addrStr)
+	if err != nil {
+		return nil, err
+	}
+	return newEthereumAddress(addr, p), nil
+}
+
+func newEthereumAddress(addr types.Address, p params.EthereumParams) *EthereumAddress {
+	return &EthereumAddress{addr: addr, params: p, poolSection: PoolSectionName(p)}
+}
+
+// newAddressFromPubKey derives account address of compressed public key
+func newAddressFromPubKey(pk cipher.PubKey, p params.EthereumParams) (*EthereumAddress, error) {
+	addr, err := types.PubKeyToAddress(pk[:])
+	if err != nil {
+		return nil, err
+	}
+	ethAddr := newEthereumAddress(addr, p)
+	ethAddr.isBip32 = true
+	return ethAddr, nil
+}
+
+// Account returns the underlying account address
+func (addr *EthereumAddress) Account() types.Address {
+	return addr.addr
+}
+
+// IsBip32 flag shall be set if address generation complies to BIP 32
+func (addr *EthereumAddress) IsBip32() bool {
+	return addr.isBip32
+}
+
+// String return EIP-55 checksum representation of this address
+func (addr *EthereumAddress) String() string {
+	return addr.addr.Hex()
+}
+
+// GetCryptoAccount provides access to address balances
+func (addr *EthereumAddress) GetCryptoAccount() core.CryptoAccount {
+	return newEthereumAccount(addr.params, addr.poolSection, func() ([]*EthereumAddress, error) {
+		return []*EthereumAddress{addr}, nil
+	})
+}
+
+// Bytes binary representation for address
+func (addr *EthereumAddress) Bytes() []byte {
+	return append([]byte(nil), addr.addr[:]...)
+}
+
+// Checksum computes address consistency token
+func (addr *EthereumAddress) Checksum() core.Checksum {
+	return types.Keccak256(addr.addr[:])[:4]
+}
+
+// Verify checks that the address appears valid for the public key
+func (addr *EthereumAddress) Verify(pk core.PubKey) error {
+	derived, err := types.PubKeyToAddress(pk.Bytes())
+	if err != nil {
+		return err
+	}
+	if derived != addr.addr {
+		return types.ErrInvalidAddress
+	}
+	return nil
+}
+
+// Null returns true if the address is null
+func (addr *EthereumAddress) Null() bool {
+	return addr.addr == types.Address{}
+}
+
+// EthereumAddressIterator iterates over a sequence of addresses
+type EthereumAddressIterator struct {
+	current   int
+	addresses []core.Address
+}
+
+// Value of address at iterator pointer position
+func (it *EthereumAddressIterator) Value() core.Address {
+	return it.addresses[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *EthereumAddressIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *EthereumAddressIterator) HasNext() bool {
+	return (it.current + 1) < len(it.addresses)
+}
+
+// NewEthereumAddressIterator instantiates iterator over addresses
+func NewEthereumAddressIterator(addresses []core.Address) *EthereumAddressIterator {
+	return &EthereumAddressIterator{addresses: addresses, current: -1}
+}
+
+// EthereumSecKey secp256k1 private key wrapper
+type EthereumSecKey struct {
+	seckey cipher.SecKey
+}
+
+// Verify checks that the private key appears valid
+func (sk *EthereumSecKey) Verify() error {
+	return sk.seckey.Verify()
+}
+
+// Null returns true if the private key is null
+func (sk *EthereumSecKey) Null() bool {
+	return sk.seckey.Null()
+}
+
+// Bytes binary representation for private key
+func (sk *EthereumSecKey) Bytes() []byte {
+	return sk.seckey[:]
+}
+
+// EthereumPubKey compressed secp256k1 public key wrapper
+type EthereumPubKey struct {
+	pubkey cipher.PubKey
+}
+
+// Verify checks that the public key appears valid
+func (pk *EthereumPubKey) Verify() error {
+	return pk.pubkey.Verify()
+}
+
+// Null returns true if the public key is null
+func (pk *EthereumPubKey) Null() bool {
+	return pk.pubkey.Null()
+}
+
+// Bytes binary representation for public key
+func (pk *EthereumPubKey) Bytes() []byte {
+	return pk.pubkey[:]
+}
+
+func ethSecKeyFromBytes(b []byte) (*EthereumSecKey, error) {
+	sk, err := cipher.NewSecKey(b)
+	if err != nil {
+		return nil, err
+	}
+	return &EthereumSecKey{seckey: sk}, nil
+}
+
+func ethPubKeyFromBytes(b []byte) (*EthereumPubKey, error) {
+	pk, err := cipher.NewPubKey(b)
+	if err != nil {
+		return nil, err
+	}
+	return &EthereumPubKey{pubkey: pk}, nil
+}
+
+// Type assertions
+var (
+	_ core.Address         = &EthereumAddress{}
+	_ core.AddressIterator = &EthereumAddressIterator{}
+	_ core.PubKey          = &EthereumPubKey{}
+	_ core.SecKey          = &EthereumSecKey{}
+)
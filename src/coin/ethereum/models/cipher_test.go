@@ -0,0 +1,77 @@
+package ethereum
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/types"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenContractsChecksum(t *testing.T) {
+	for _, p := range []params.EthereumParams{params.EthereumMainNetParams, params.EthereumDevNetParams} {
+		for _, token := range p.Tokens {
+			contract, err := types.ParseAddress(token.Contract)
+			require.NoError(t, err, token.Ticker)
+			require.Equal(t, token.Contract, contract.Hex())
+			require.True(t, token.Accuracy <= token.Decimals)
+		}
+	}
+}
+
+func TestEthereumAddress(t *testing.T) {
+	p := params.EthereumDevNetParams
+	pk, _ := cipher.GenerateKeyPair()
+	addr, err := newAddressFromPubKey(pk, p)
+	require.NoError(t, err)
+	require.True(t, addr.IsBip32())
+	require.NoError(t, addr.Verify(&EthereumPubKey{pubkey: pk}))
+	other, _ := cipher.GenerateKeyPair()
+	require.Error(t, addr.Verify(&EthereumPubKey{pubkey: other}))
+
+	parsed, err := NewEthereumAddress(addr.String(), p)
+	require.NoError(t, err)
+	require.Equal(t, addr.Bytes(), parsed.Bytes())
+	require.False(t, parsed.Null())
+
+	_, err = NewEthereumAddress("0x00", p)
+	require.Error(t, err)
+}
+
+func TestUnitConversion(t *testing.T) {
+	p := params.EthereumMainNetParams
+	wei, err := fromAmount(p, core.NewAmount("ETH", 1500000000))
+	require.NoError(t, err)
+	require.Equal(t, "1500000000000000000", wei.String())
+	amount, err := toAmount(p, "ETH", wei, false)
+	require.NoError(t, err)
+	require.Equal(t, core.NewAmount("ETH", 1500000000), amount)
+
+	// Dust below accuracy is only discarded on demand
+	dusty := new(big.Int).Add(wei, big.NewInt(999))
+	_, err = toAmount(p, "ETH", dusty, false)
+	require.Equal(t, errors.ErrAmountPrecision, err)
+	amount, err = toAmount(p, "ETH", dusty, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1500000000), amount.Coins)
+	_, err = toAmount(p, "ETH", new(big.Int).Lsh(wei, 64), false)
+	require.Equal(t, errors.ErrAmountOverflow, err)
+
+	units, err := fromAmount(p, core.NewAmount("USDT", 2500000))
+	require.NoError(t, err)
+	require.Equal(t, "2500000", units.String())
+
+	// Fees are rounded up to base units
+	fee, err := feeBaseUnits(p, 21000, big.NewInt(1500000001))
+	require.NoError(t, err)
+	require.Equal(t, uint64(31501), fee)
+
+	_, err = fromAmount(p, core.NewAmount("XYZ", 1))
+	require.Error(t, err)
+	_, err = toAmount(p, "XYZ", wei, true)
+	require.Error(t, err)
+}
@@ -0,0 +1,457 @@
+package ethereum
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/types"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+	"github.com/shopspring/decimal"
+)
+
+var logCoin = logging.MustGetLogger("Ethereum coin")
+
+// toAmount converts wei or token units into an amount of asset represented by ticker.
+// Units below asset accuracy are rejected, unless truncate is set to discard dust.
+func toAmount(p params.EthereumParams, ticker string, n *big.Int, truncate bool) (core.Amount, error) {
+	decimals, isKnown := p.AssetDecimals(ticker)
+	if !isKnown {
+		return core.Amount{}, errors.ErrInvalidAltcoinTicker
+	}
+	accuracy, _ := p.AssetAccuracy(ticker)
+	value := decimal.NewFromBigInt(n, -decimals).String()
+	if truncate {
+		return core.ParseAmountTruncated(ticker, value, accuracy)
+	}
+	return core.ParseAmount(ticker, value, accuracy)
+}
+
+// fromAmount converts amount into wei or token units
+func fromAmount(p params.EthereumParams, amount core.Amount) (*big.Int, error) {
+	scale, isKnown := p.UnitScale(amount.Ticker)
+	if !isKnown {
+		return nil, errors.ErrInvalidAltcoinTicker
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(amount.Coins), scale), nil
+}
+
+// feeBaseUnits computes ether paid for gas at gasPrice, rounded up to base units
+func feeBaseUnits(p params.EthereumParams, gas uint64, gasPrice *big.Int) (uint64, error) {
+	scale, _ := p.UnitScale(p.Ticker)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(gas), gasPrice)
+	fee.Add(fee, new(big.Int).Sub(scale, big.NewInt(1)))
+	fee.Quo(fee, scale)
+	if !fee.IsUint64() {
+		return 0, errors.ErrAmountOverflow
+	}
+	return fee.Uint64(), nil
+}
+
+// transfer describes value moved by a transaction
+type transfer struct {
+	ticker    string
+	recipient types.Address
+	// amount in wei or token units
+	amount *big.Int
+}
+
+// decodeTransfer recognizes ether transfers and token transfers to known contracts
+func decodeTransfer(p params.EthereumParams, tx *types.Transaction) (transfer, bool) {
+	if tx.To == nil {
+		return transfer{}, false
+	}
+	if token, isToken := p.LookupContract(tx.To.Hex()); isToken {
+		if recipient, amount, isTransfer := types.ParseERC20TransferData(tx.Data); isTransfer {
+			return transfer{ticker: token.Ticker, recipient: recipient, amount: amount}, true
+		}
+		return transfer{}, false
+	}
+	if len(tx.Data) > 0 {
+		return transfer{}, false
+	}
+	value := tx.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	return transfer{ticker: p.Ticker, recipient: *tx.To, amount: value}, true
+}
+
+// EthereumTransaction wraps an account based transaction sending ether or tokens to a single recipient
+type EthereumTransaction struct { // Implements Transaction interface
+	params      params.EthereumParams
+	poolSection string
+	tx          *types.Transaction
+	from        types.Address
+	status      core.TransactionStatus
+	timestamp   core.Timestamp
+}
+
+func newEthereumTransaction(p params.EthereumParams, tx *types.Transaction, from types.Address, status core.TransactionStatus) (*EthereumTransaction, error) {
+	// Fields out of range, such as negative amounts, can not be serialized
+	if _, err := tx.Bytes(); err != nil {
+		return nil, err
+	}
+	return &EthereumTransaction{
+		params:      p,
+		poolSection: PoolSectionName(p),
+		tx:          tx,
+		from:        from,
+		status:      status,
+	}, nil
+}
+
+// NewEthereumTransactionFromRaw decodes signed transaction and recovers its sender
+func NewEthereumTransactionFromRaw(rawTxn []byte, p params.EthereumParams, status core.TransactionStatus) (*EthereumTransaction, error) {
+	tx, err := types.DecodeTransaction(rawTxn)
+	if err != nil {
+		return nil, err
+	}
+	from, err := tx.Sender(p.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	return newEthereumTransaction(p, tx, from, status)
+}
+
+// newEthereumTransactionFromRPC converts transaction returned by node
+func newEthereumTransactionFromRPC(p params.EthereumParams, rpcTxn *RPCTransaction, status core.TransactionStatus) (*EthereumTransaction, error) {
+	from, err := types.ParseAddress(rpcTxn.From)
+	if err != nil {
+		return nil, err
+	}
+	tx := &types.Transaction{}
+	if rpcTxn.To != nil {
+		to, err := types.ParseAddress(*rpcTxn.To)
+		if err != nil {
+			return nil, err
+		}
+		tx.To = &to
+	}
+	if tx.Nonce, err = types.DecodeUint64(rpcTxn.Nonce); err != nil {
+		return nil, err
+	}
+	if tx.Gas, err = types.DecodeUint64(rpcTxn.Gas); err != nil {
+		return nil, err
+	}
+	if tx.GasPrice, err = types.DecodeBig(rpcTxn.GasPrice); err != nil {
+		return nil, err
+	}
+	if tx.Value, err = types.DecodeBig(rpcTxn.Value); err != nil {
+		return nil, err
+	}
+	if tx.Data, err = types.DecodeHex(rpcTxn.Input); err != nil {
+		return nil, err
+	}
+	if tx.V, err = types.DecodeBig(rpcTxn.V); err != nil {
+		return nil, err
+	}
+	if tx.R, err = types.DecodeBig(rpcTxn.R); err != nil {
+		return nil, err
+	}
+	if tx.S, err = types.DecodeBig(rpcTxn.S); err != nil {
+		return nil, err
+	}
+	return newEthereumTransaction(p, tx, from, status)
+}
+
+// Tx returns the underlying transaction
+func (txn *EthereumTransaction) Tx() *types.Transaction {
+	return txn.tx
+}
+
+// From returns the address of sender account
+func (txn *EthereumTransaction) From() types.Address {
+	return txn.from
+}
+
+// RawBytes serializes transaction in format accepted by nodes
+func (txn *EthereumTransaction) RawBytes() ([]byte, error) {
+	return txn.tx.Bytes()
+}
+
+// SupportedAssets enumerates ether, paying for gas, and the token transferred if any
+func (txn *EthereumTransaction) SupportedAssets() []string {
+	if t, isTransfer := decodeTransfer(txn.params, txn.tx); isTransfer && t.ticker != txn.params.Ticker {
+		return []string{txn.params.Ticker, t.ticker}
+	}
+	return []string{txn.params.Ticker}
+}
+
+// GetTimestamp at the moment of creation
+func (txn *EthereumTransaction) GetTimestamp() core.Timestamp {
+	return txn.timestamp
+}
+
+// GetStatus to retrieve transaction status
+func (txn *EthereumTransaction) GetStatus() core.TransactionStatus {
+	return txn.status
+}
+
+// GetInputs returns a single input debiting sender account
+func (txn *EthereumTransaction) GetInputs() []core.TransactionInput {
+	return []core.TransactionInput{&EthereumTransactionInput{txn: txn}}
+}
+
+// GetOutputs returns a single output crediting recipient account
+func (txn *EthereumTransaction) GetOutputs() []core.TransactionOutput {
+	t, isTransfer := decodeTransfer(txn.params, txn.tx)
+	if !isTransfer {
+		return nil
+	}
+	amount, err := toAmount(txn.params, t.ticker, t.amount, false)
+	if err != nil {
+		logCoin.WithError(err).Warn("Couldn't convert transferred amount")
+		return nil
+	}
+	out := newEthereumTransactionOutput(txn.params, txn.GetId()+":0", t.recipient, t.ticker, amount.Coins)
+	return []core.TransactionOutput{out}
+}
+
+// GetId o retrieve transaction ID
+func (txn *EthereumTransaction) GetId() string {
+	hash, err := txn.tx.Hash()
+	if err != nil {
+		logCoin.WithError(err).Warn("Couldn't serialize transaction")
+		return ""
+	}
+	return hash.Hex()
+}
+
+// ComputeFee calculates ether paid for gas limit, no fee is paid in tokens
+func (txn *EthereumTransaction) ComputeFee(ticker string) (uint64, error) {
+	if ticker != txn.params.Ticker {
+		if _, isToken := txn.params.LookupToken(ticker); isToken {
+			return 0, nil
+		}
+		return 0, errors.ErrInvalidAltcoinTicker
+	}
+	if txn.tx.GasPrice == nil {
+		return 0, errors.ErrInvalidTxn
+	}
+	return feeBaseUnits(txn.params, txn.tx.Gas, txn.tx.GasPrice)
+}
+
+// VerifyUnsigned checks for valid unsigned transaction
+func (txn *EthereumTransaction) VerifyUnsigned() error {
+	if txn.tx.IsSigned() {
+		return errors.ErrInvalidTxn
+	}
+	if txn.tx.GasPrice == nil || txn.tx.Gas < txn.params.TransferGas {
+		return errors.ErrInvalidTxn
+	}
+	if _, isTransfer := decodeTransfer(txn.params, txn.tx); !isTransfer {
+		return errors.ErrInvalidTxn
+	}
+	return nil
+}
+
+// VerifySigned checks that transaction was signed by sender for network chain
+func (txn *EthereumTransaction) VerifySigned() error {
+	sender, err := txn.tx.Sender(txn.params.ChainID)
+	if err != nil {
+		return err
+	}
+	if sender != txn.from {
+		return types.ErrInvalidSignature
+	}
+	return nil
+}
+
+// IsFullySigned deermine whether all transaction elements have been signed
+func (txn *EthereumTransaction) IsFullySigned() (bool, error) {
+	return txn.tx.IsSigned(), nil
+}
+
+// EthereumTransactionInput debits sender account
+type EthereumTransactionInput struct { // Implements TransactionInput interface
+	txn *EthereumTransaction
+}
+
+// GetId identifies input by sender address and nonce
+func (in *EthereumTransactionInput) GetId() string {
+	return in.txn.from.Hex() + ":" + strconv.FormatUint(in.txn.tx.Nonce, 10)
+}
+
+// GetSpentOutput returns balance debited from sender account
+func (in *EthereumTransactionInput) GetSpentOutput() (core.TransactionOutput, error) {
+	t, isTransfer := decodeTransfer(in.txn.params, in.txn.tx)
+	if !isTransfer {
+		return nil, errors.ErrInvalidTxn
+	}
+	coins, err := in.GetCoins(t.ticker)
+	if err != nil {
+		return nil, err
+	}
+	out := newEthereumTransactionOutput(in.txn.params, balanceOutputID(in.txn.from, t.ticker), in.txn.from, t.ticker, coins)
+	out.spent = true
+	return out, nil
+}
+
+// GetCoins looks up coins debited from sender account, ether includes fees
+func (in *EthereumTransactionInput) GetCoins(ticker string) (uint64, error) {
+	t, isTransfer := decodeTransfer(in.txn.params, in.txn.tx)
+	if !isTransfer {
+		return 0, errors.ErrInvalidTxn
+	}
+	fee, err := in.txn.ComputeFee(ticker)
+	if err != nil {
+		return 0, err
+	}
+	if ticker != t.ticker {
+		return fee, nil
+	}
+	amount, err := toAmount(in.txn.params, ticker, t.amount, false)
+	if err != nil {
+		return 0, err
+	}
+	total, err := amount.Add(core.NewAmount(ticker, fee))
+	return total.Coins, err
+}
+
+// SupportedAssets enumerates tickers of crypto assets supported by this input
+func (in *EthereumTransactionInput) SupportedAssets() []string {
+	return in.txn.SupportedAssets()
+}
+
+// balanceOutputID identifies balance of asset held by account
+func balanceOutputID(addr types.Address, ticker string) string {
+	return addr.Hex() + ":" + ticker
+}
+
+// parseBalanceOutputID reads account address from balance output ID
+func parseBalanceOutputID(id string) (types.Address, string, error) {
+	idx := strings.LastIndexByte(id, ':')
+	if idx < 0 {
+		return types.Address{}, "", errors.ErrInvalidID
+	}
+	addr, err := types.ParseAddress(id[:idx])
+	if err != nil {
+		return types.Address{}, "", errors.ErrInvalidID
+	}
+	return addr, id[idx+1:], nil
+}
+
+// EthereumTransactionOutput credits an account with a single asset.
+// Account balances are exposed as outputs too, so that they can be selected for spending.
+type EthereumTransactionOutput struct { // Implements TransactionOutput interface
+	params  params.EthereumParams
+	id      string
+	address types.Address
+	ticker  string
+	coins   uint64
+	spent   bool
+}
+
+func newEthereumTransactionOutput(p params.EthereumParams, id string, addr types.Address, ticker string, coins uint64) *EthereumTransactionOutput {
+	return &EthereumTransactionOutput{
+		params:  p,
+		id:      id,
+		address: addr,
+		ticker:  ticker,
+		coins:   coins,
+	}
+}
+
+// GetId provides transaction output ID
+func (out *EthereumTransactionOutput) GetId() string {
+	return out.id
+}
+
+// IsSpent determines whether output has been debited, balances of accounts are never spent as a whole
+func (out *EthereumTransactionOutput) IsSpent() bool {
+	return out.spent
+}
+
+// GetAddress returns the address of the account receiving funds
+func (out *EthereumTransactionOutput) GetAddress() (core.Address, error) {
+	return newEthereumAddress(out.address, out.params), nil
+}
+
+// GetCoins looks up coins for asset represented by ticker that have been transferred in this output
+func (out *EthereumTransactionOutput) GetCoins(ticker string) (uint64, error) {
+	if ticker == out.ticker {
+		return out.coins, nil
+	}
+	if _, isKnown := out.params.UnitScale(ticker); isKnown {
+		return 0, nil
+	}
+	return 0, errors.ErrInvalidAltcoinTicker
+}
+
+// SupportedAssets enumerates tickers of crypto assets supported by this output
+func (out *EthereumTransactionOutput) SupportedAssets() []string {
+	return []string{out.ticker}
+}
+
+// EthereumTransactionIterator iterates over a sequence of transactions
+type EthereumTransactionIterator struct {
+	current int
+	txns    []core.Transaction
+}
+
+// Value of transaction at iterator pointer position
+func (it *EthereumTransactionIterator) Value() core.Transaction {
+	return it.txns[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *EthereumTransactionIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *EthereumTransactionIterator) HasNext() bool {
+	return (it.current + 1) < len(it.txns)
+}
+
+// NewEthereumTransactionIterator instantiates iterator over transactions
+func NewEthereumTransactionIterator(txns []core.Transaction) *EthereumTransactionIterator {
+	return &EthereumTransactionIterator{txns: txns, current: -1}
+}
+
+// EthereumTransactionOutputIterator iterates over a sequence of transaction outputs
+type EthereumTransactionOutputIterator struct {
+	current int
+	outputs []core.TransactionOutput
+}
+
+// Value of transaction output at iterator pointer position
+func (it *EthereumTransactionOutputIterator) Value() core.TransactionOutput {
+	return it.outputs[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *EthereumTransactionOutputIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *EthereumTransactionOutputIterator) HasNext() bool {
+	return (it.current + 1) < len(it.outputs)
+}
+
+// NewEthereumTransactionOutputIterator instantiates iterator over outputs
+func NewEthereumTransactionOutputIterator(outputs []core.TransactionOutput) *EthereumTransactionOutputIterator {
+	return &EthereumTransactionOutputIterator{outputs: outputs, current: -1}
+}
+
+// Type assertions
+var (
+	_ core.Transaction               = &EthereumTransaction{}
+	_ core.TransactionInput          = &EthereumTransactionInput{}
+	_ core.TransactionOutput         = &EthereumTransactionOutput{}
+	_ core.TransactionIterator       = &EthereumTransactionIterator{}
+	_ core.TransactionOutputIterator = &EthereumTransactionOutputIterator{}
+)
@@ -0,0 +1,143 @@
+package ethereum
+
+import (
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+// EthereumPlugin provide support for ether and ERC-20 tokens on a given network
+type EthereumPlugin struct {
+	Params    params.EthereumParams
+	WalletDir string
+}
+
+// ListSupportedAltcoins to enumerate ether and supported tokens
+func (p *EthereumPlugin) ListSupportedAltcoins() []core.AltcoinMetadata {
+	altcoins := []core.AltcoinMetadata{
+		core.AltcoinMetadata{
+			Name:          p.Params.Name,
+			Ticker:        p.Params.Ticker,
+			Family:        params.EthereumFamily,
+			HasBip44:      true,
+			Bip44CoinType: int32(p.Params.Bip44CoinType),
+			Accuracy:      p.Params.Accuracy,
+		},
+	}
+	for _, token := range p.Params.Tokens {
+		altcoins = append(altcoins, core.AltcoinMetadata{
+			Name:          token.Name,
+			Ticker:        token.Ticker,
+			Family:        params.EthereumFamily,
+			HasBip44:      true,
+			Bip44CoinType: int32(p.Params.Bip44CoinType),
+			Accuracy:      token.Accuracy,
+		})
+	}
+	return altcoins
+}
+
+// ListSupportedFamilies classifies similar cryptocurrencies into a family
+func (p *EthereumPlugin) ListSupportedFamilies() []string {
+	return []string{params.EthereumFamily}
+}
+
+// RegisterTo boilerplate to register this plugin against an altcoin manager and enable it
+func (p *EthereumPlugin) RegisterTo(manager core.AltcoinManager) {
+	for _, info := range p.ListSupportedAltcoins() {
+		manager.RegisterAltcoin(info, p)
+	}
+}
+
+// GetName provides concise human-readable caption o identify this plugin
+func (p *EthereumPlugin) GetName() string {
+	return p.Params.Name
+}
+
+// GetDescription describes plugin and its features
+func (p *EthereumPlugin) GetDescription() string {
+	return params.EthereumDescription
+}
+
+// LoadWalletEnvs loads wallet environments to lookup and create wallets
+func (p *EthereumPlugin) LoadWalletEnvs() []core.WalletEnv {
+	return []core.WalletEnv{NewWalletDirectory(p.WalletDir, p.Params)}
+}
+
+// LoadPEX instantiates proxy object to interact with nodes nodes of the P2P network
+func (p *EthereumPlugin) LoadPEX(netType string) (core.PEX, error) {
+	if netType != p.Params.NetType {
+		return nil, errors.ErrInvalidNetworkType
+	}
+	return NewEthereumPEX(p.Params), nil
+}
+
+// LoadTransactionAPI blockchain transaction API entry point
+func (p *EthereumPlugin) LoadTransactionAPI(netType string) (core.BlockchainTransactionAPI, error) {
+	if netType != p.Params.NetType {
+		return nil, errors.ErrInvalidNetworkType
+	}
+	return NewEthereumBlockchain(p.Params), nil
+}
+
+// LoadSignService sign service entry point
+func (p *EthereumPlugin) LoadSignService() (core.BlockchainSignService, error) {
+	return &EthereumSignService{}, nil
+}
+
+// AddressFromString retrieves address corresponding to readable representation
+func (p *EthereumPlugin) AddressFromString(addrStr string) (core.Address, error) {
+	addr, err := NewEthereumAddress(addrStr, p.Params)
+	if err != nil {
+		return nil, err
+	}
+	return addr, nil
+}
+
+// PubKeyFromBytes retrieves public key corresponding to binary representation
+func (p *EthereumPlugin) PubKeyFromBytes(b []byte) (core.PubKey, error) {
+	return ethPubKeyFromBytes(b)
+}
+
+// SecKeyFromBytes retrieves secret key corresponding to binary representation
+func (p *EthereumPlugin) SecKeyFromBytes(b []byte) (core.SecKey, error) {
+	return ethSecKeyFromBytes(b)
+}
+
+// ListTxnOptions enumerates options accepted when creating transactions of ether or tokens
+func (p *EthereumPlugin) ListTxnOptions(ticker string) []core.TxnOptionSpec {
+	if _, isKnown := p.Params.UnitScale(ticker); !isKnown {
+		return nil
+	}
+	return []core.TxnOptionSpec{
+		core.TxnOptionSpec{
+			Key:     TxnOptGasPrice,
+			Caption: "Gas price (gwei)",
+			Default: OptionAuto,
+		},
+		core.TxnOptionSpec{
+			Key:     TxnOptGasLimit,
+			Caption: "Gas limit",
+			Default: OptionAuto,
+		},
+		core.TxnOptionSpec{
+			Key:     TxnOptNonce,
+			Caption: "Nonce",
+			Default: OptionAuto,
+		},
+	}
+}
+
+// NewEthereumPlugin instantiate plugin entry point for network params storing wallets in walletDir
+func NewEthereumPlugin(p params.EthereumParams, walletDir string) core.AltcoinPlugin {
+	return &EthereumPlugin{
+		Params:    p,
+		WalletDir: walletDir,
+	}
+}
+
+// Type assertions
+var (
+	_ core.AltcoinPlugin      = &EthereumPlugin{}
+	_ core.TxnOptionsProvider = &EthereumPlugin{}
+)
@@ -0,0 +1,212 @@
+package ethereum
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logNetwork = logging.MustGetLogger("Ethereum network")
+
+// EthereumPEX talks to the P2P network through an Ethereum JSON-RPC node
+type EthereumPEX struct { // Implements PEX interface
+	params      params.EthereumParams
+	poolSection string
+}
+
+// NewEthereumPEX instantiates PEX for network params
+func NewEthereumPEX(p params.EthereumParams) *EthereumPEX {
+	logNetwork.Info("Creating new Ethereum PEX")
+	return &EthereumPEX{params: p, poolSection: PoolSectionName(p)}
+}
+
+func getPendingTransactions(p params.EthereumParams, poolSection string) ([]*EthereumTransaction, error) {
+	c, err := NewEthereumRPCClient(poolSection)
+	if err != nil {
+		return nil, err
+	}
+	defer ReturnEthereumRPCClient(c)
+	rpcTxns, err := c.GetPendingTransactions()
+	if err != nil {
+		logNetwork.WithError(err).Warn("Couldn't get pending transactions")
+		return nil, err
+	}
+	txns := make([]*EthereumTransaction, 0, len(rpcTxns))
+	for i := range rpcTxns {
+		txn, err := newEthereumTransactionFromRPC(p, &rpcTxns[i], core.TXN_STATUS_PENDING)
+		if err != nil {
+			return nil, err
+		}
+		txns = append(txns, txn)
+	}
+	return txns, nil
+}
+
+// GetTxnPool return transactions pending for confirmation by network peers
+func (epex *EthereumPEX) GetTxnPool() (core.TransactionIterator, error) {
+	logNetwork.Info("Getting transaction pool")
+	pending, err := getPendingTransactions(epex.params, epex.poolSection)
+	if err != nil {
+		return nil, err
+	}
+	txns := make([]core.Transaction, len(pending))
+	for i, txn := range pending {
+		txns[i] = txn
+	}
+	return NewEthereumTransactionIterator(txns), nil
+}
+
+// GetConnections enumerate connections to peer nodes
+//
+// Peers are only listed by nodes exposing admin API, otherwise the set is empty
+func (epex *EthereumPEX) GetConnections() (core.PexNodeSet, error) {
+	logNetwork.Info("Getting peers")
+	c, err := NewEthereumRPCClient(epex.poolSection)
+	if err != nil {
+		return nil, err
+	}
+	defer ReturnEthereumRPCClient(c)
+	peers, err := c.AdminPeers()
+	if err != nil {
+		if _, isRPCErr := err.(*RPCError); isRPCErr {
+			logNetwork.WithError(err).Warn("Peers not available, admin API disabled")
+			return &EthereumPexNodeSet{}, nil
+		}
+		return nil, err
+	}
+	nodes := make([]core.PexNode, 0, len(peers))
+	for _, peer := range peers {
+		nodes = append(nodes, peerInfoToPexNode(peer))
+	}
+	return &EthereumPexNodeSet{nodes: nodes}, nil
+}
+
+// BroadcastTxn injects a transaction for confirmation by network peers
+func (epex *EthereumPEX) BroadcastTxn(txn core.Transaction) error {
+	logNetwork.Info("Broadcasting transaction")
+	ethTxn, isEthTxn := txn.(*EthereumTransaction)
+	if !isEthTxn {
+		return errors.ErrInvalidTxn
+	}
+	c, err := NewEthereumRPCClient(epex.poolSection)
+	if err != nil {
+		return err
+	}
+	defer ReturnEthereumRPCClient(c)
+	rawTxn, err := ethTxn.RawBytes()
+	if err != nil {
+		return err
+	}
+	if _, err = c.SendRawTransaction(rawTxn); err != nil {
+		logNetwork.WithError(err).Warn("Couldn't send raw transaction")
+		return err
+	}
+	ethTxn.status = core.TXN_STATUS_PENDING
+	return nil
+}
+
+func peerInfoToPexNode(peer PeerInfo) *EthereumPexNode {
+	node := &EthereumPexNode{
+		Ip:       peer.Network.RemoteAddress,
+		Outbound: !peer.Network.Inbound,
+	}
+	if host, port, err := net.SplitHostPort(peer.Network.RemoteAddress); err == nil {
+		node.Ip = host
+		if p, err := strconv.ParseUint(port, 10, 16); err == nil {
+			node.Port = uint16(p)
+		}
+	}
+	return node
+}
+
+// EthereumPexNode describes a peer connected to the node
+type EthereumPexNode struct { // Implements PexNode interface
+	Ip          string
+	Port        uint16
+	Outbound    bool
+	Block       uint64
+	LastSeenIn  int64
+	LastSeenOut int64
+}
+
+// GetIp returns node IP network address
+func (node *EthereumPexNode) GetIp() string {
+	return node.Ip
+}
+
+// GetPort retrieves IP port used to connect to peer node
+func (node *EthereumPexNode) GetPort() uint16 {
+	return node.Port
+}
+
+// GetBlockHeight provides sequence number of the block a the tip of peer's chain
+func (node *EthereumPexNode) GetBlockHeight() uint64 {
+	return node.Block
+}
+
+// IsTrusted determines if peer node was chosen by the node itself
+func (node *EthereumPexNode) IsTrusted() bool {
+	return node.Outbound
+}
+
+// GetLastSeenIn timestamp of last message received from peer
+func (node *EthereumPexNode) GetLastSeenIn() int64 {
+	return node.LastSeenIn
+}
+
+// GetLastSeenOut timestamp of last message sent to peer
+func (node *EthereumPexNode) GetLastSeenOut() int64 {
+	return node.LastSeenOut
+}
+
+// EthereumPexNodeSet set of peers connected to the node
+type EthereumPexNodeSet struct { // Implements PexNodeSet interface
+	nodes []core.PexNode
+}
+
+// ListPeers offers an iterator over this set of nodes
+func (set *EthereumPexNodeSet) ListPeers() core.PexNodeIterator {
+	return NewEthereumPexNodeIterator(set.nodes)
+}
+
+// EthereumPexNodeIterator iterates over peers
+type EthereumPexNodeIterator struct {
+	current int
+	nodes   []core.PexNode
+}
+
+// Value of PEX node data instance at iterator pointer position
+func (it *EthereumPexNodeIterator) Value() core.PexNode {
+	return it.nodes[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *EthereumPexNodeIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *EthereumPexNodeIterator) HasNext() bool {
+	return (it.current + 1) < len(it.nodes)
+}
+
+// NewEthereumPexNodeIterator instantiates iterator over peers
+func NewEthereumPexNodeIterator(nodes []core.PexNode) *EthereumPexNodeIterator {
+	return &EthereumPexNodeIterator{nodes: nodes, current: -1}
+}
+
+// Type assertions
+var (
+	_ core.PEX             = &EthereumPEX{}
+	_ core.PexNode         = &EthereumPexNode{}
+	_ core.PexNodeSet      = &EthereumPexNodeSet{}
+	_ core.PexNodeIterator = &EthereumPexNodeIterator{}
+)
@@ -0,0 +1,354 @@
+package ethereum
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/types"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+// Block tags accepted by state queries
+const (
+	BlockLatest  = "latest"
+	BlockPending = "pending"
+)
+
+// BlockHeader subset of eth_getBlockByNumber response
+type BlockHeader struct {
+	Number     string `json:"number"`
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parentHash"`
+	Timestamp  string `json:"timestamp"`
+}
+
+// RPCTransaction eth_getTransactionByHash response and items of full blocks
+type RPCTransaction struct {
+	Hash        string  `json:"hash"`
+	BlockNumber *string `json:"blockNumber"`
+	From        string  `json:"from"`
+	To          *string `json:"to"`
+	Nonce       string  `json:"nonce"`
+	Gas         string  `json:"gas"`
+	GasPrice    string  `json:"gasPrice"`
+	Value       string  `json:"value"`
+	Input       string  `json:"input"`
+	V           string  `json:"v"`
+	R           string  `json:"r"`
+	S           string  `json:"s"`
+}
+
+// Receipt subset of eth_getTransactionReceipt response
+type Receipt struct {
+	TransactionHash string `json:"transactionHash"`
+	BlockNumber     string `json:"blockNumber"`
+	GasUsed         string `json:"gasUsed"`
+	Status          string `json:"status"`
+}
+
+// PeerInfo subset of admin_peers response items
+type PeerInfo struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Network struct {
+		LocalAddress  string `json:"localAddress"`
+		RemoteAddress string `json:"remoteAddress"`
+		Inbound       bool   `json:"inbound"`
+		Trusted       bool   `json:"trusted"`
+	} `json:"network"`
+}
+
+// CallMsg parameters of eth_call and eth_estimateGas
+type CallMsg struct {
+	From  *types.Address
+	To    *types.Address
+	Value *big.Int
+	Data  []byte
+}
+
+func (msg CallMsg) toArg() map[string]interface{} {
+	arg := make(map[string]interface{})
+	if msg.From != nil {
+		arg["from"] = msg.From.Hex()
+	}
+	if msg.To != nil {
+		arg["to"] = msg.To.Hex()
+	}
+	if msg.Value != nil {
+		arg["value"] = types.EncodeBig(msg.Value)
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = types.EncodeHex(msg.Data)
+	}
+	return arg
+}
+
+// EthereumRPC is the subset of Ethereum JSON-RPC API used by the plugin
+type EthereumRPC interface {
+	ChainID() (uint64, error)
+	BlockNumber() (uint64, error)
+	GetBlockByNumber(tag string) (*BlockHeader, error)
+	GetPendingTransactions() ([]RPCTransaction, error)
+	GetBalance(addr types.Address, tag string) (*big.Int, error)
+	GetTransactionCount(addr types.Address, tag string) (uint64, error)
+	GasPrice() (*big.Int, error)
+	EstimateGas(msg CallMsg) (uint64, error)
+	CallContract(msg CallMsg, tag string) ([]byte, error)
+	SendRawTransaction(rawTxn []byte) (string, error)
+	GetTransactionByHash(hash string) (*RPCTransaction, error)
+	GetTransactionReceipt(hash string) (*Receipt, error)
+	AdminPeers() ([]PeerInfo, error)
+}
+
+// RPCError is returned by nodes when a JSON-RPC call fails
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+	ID     uint64          `json:"id"`
+}
+
+// RPCClient talks to an Ethereum node over HTTP JSON-RPC
+type RPCClient struct {
+	url    string
+	client *http.Client
+	lastID uint64
+}
+
+// NewRPCClient instantiates a JSON-RPC client for node at url
+func NewRPCClient(url string) *RPCClient {
+	return &RPCClient{
+		url:    url,
+		client: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Call invokes JSON-RPC method and decodes its result, if not nil
+func (c *RPCClient) Call(method string, result interface{}, args ...interface{}) error {
+	if args == nil {
+		args = []interface{}{}
+	}
+	reqBody, err := json.Marshal(rpcRequest{
+		JSONRPC: "2.0",
+		ID:      atomic.AddUint64(&c.lastID, 1),
+		Method:  method,
+		Params:  args,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Post(c.url, "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("RPC call %s failed with HTTP status %d: %v", method, resp.StatusCode, err)
+	}
+	if rpcResp.Error != nil {
+		return rpcResp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(rpcResp.Result, result)
+}
+
+func (c *RPCClient) callQuantity(method string, args ...interface{}) (*big.Int, error) {
+	var hexNum string
+	if err := c.Call(method, &hexNum, args...); err != nil {
+		return nil, err
+	}
+	return types.DecodeBig(hexNum)
+}
+
+func (c *RPCClient) callUint64(method string, args ...interface{}) (uint64, error) {
+	n, err := c.callQuantity(method, args...)
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsUint64() {
+		return 0, types.ErrInvalidHex
+	}
+	return n.Uint64(), nil
+}
+
+// ChainID returns EIP-155 identifier of the chain followed by node
+func (c *RPCClient) ChainID() (uint64, error) {
+	return c.callUint64("eth_chainId")
+}
+
+// BlockNumber returns the number of the block at the tip of the chain
+func (c *RPCClient) BlockNumber() (uint64, error) {
+	return c.callUint64("eth_blockNumber")
+}
+
+// GetBlockByNumber returns header data of block identified by number or tag
+func (c *RPCClient) GetBlockByNumber(tag string) (*BlockHeader, error) {
+	var header *BlockHeader
+	if err := c.Call("eth_getBlockByNumber", &header, tag, false); err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errors.ErrNotFound
+	}
+	return header, nil
+}
+
+// GetPendingTransactions lists transactions the node would include in next block
+func (c *RPCClient) GetPendingTransactions() ([]RPCTransaction, error) {
+	var block *struct {
+		Transactions []RPCTransaction `json:"transactions"`
+	}
+	if err := c.Call("eth_getBlockByNumber", &block, BlockPending, true); err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, nil
+	}
+	return block.Transactions, nil
+}
+
+// GetBalance returns wei held by account at block tag
+func (c *RPCClient) GetBalance(addr types.Address, tag string) (*big.Int, error) {
+	return c.callQuantity("eth_getBalance", addr.Hex(), tag)
+}
+
+// GetTransactionCount returns number of transactions sent by account at block tag, i.e. its next nonce
+func (c *RPCClient) GetTransactionCount(addr types.Address, tag string) (uint64, error) {
+	return c.callUint64("eth_getTransactionCount", addr.Hex(), tag)
+}
+
+// GasPrice returns gas price in wei suggested by node
+func (c *RPCClient) GasPrice() (*big.Int, error) {
+	return c.callQuantity("eth_gasPrice")
+}
+
+// EstimateGas estimates gas needed to execute message
+func (c *RPCClient) EstimateGas(msg CallMsg) (uint64, error) {
+	return c.callUint64("eth_estimateGas", msg.toArg())
+}
+
+// CallContract executes message without creating a transaction and returns its output
+func (c *RPCClient) CallContract(msg CallMsg, tag string) ([]byte, error) {
+	var hexData string
+	if err := c.Call("eth_call", &hexData, msg.toArg(), tag); err != nil {
+		return nil, err
+	}
+	return types.DecodeHex(hexData)
+}
+
+// SendRawTransaction submits signed transaction and returns its hash
+func (c *RPCClient) SendRawTransaction(rawTxn []byte) (string, error) {
+	var hash string
+	err := c.Call("eth_sendRawTransaction", &hash, types.EncodeHex(rawTxn))
+	return hash, err
+}
+
+// GetTransactionByHash looks up transaction, nil if unknown
+func (c *RPCClient) GetTransactionByHash(hash string) (*RPCTransaction, error) {
+	var txn *RPCTransaction
+	err := c.Call("eth_getTransactionByHash", &txn, hash)
+	return txn, err
+}
+
+// GetTransactionReceipt looks up receipt of mined transaction, nil if not mined
+func (c *RPCClient) GetTransactionReceipt(hash string) (*Receipt, error) {
+	var receipt *Receipt
+	err := c.Call("eth_getTransactionReceipt", &receipt, hash)
+	return receipt, err
+}
+
+// AdminPeers lists peers connected to the node, requires admin API to be enabled
+func (c *RPCClient) AdminPeers() ([]PeerInfo, error) {
+	var peers []PeerInfo
+	err := c.Call("admin_peers", &peers)
+	return peers, err
+}
+
+// EthereumConnectionFactory creates RPC clients for connection pool
+type EthereumConnectionFactory struct {
+	url string
+}
+
+// Create instantiates a new RPC client
+func (cf *EthereumConnectionFactory) Create() (interface{}, error) {
+	return NewRPCClient(cf.url), nil
+}
+
+// NewEthereumConnectionFactory instantiates factory of clients for node at url
+func NewEthereumConnectionFactory(url string) *EthereumConnectionFactory {
+	return &EthereumConnectionFactory{url: url}
+}
+
+// PoolSectionName connection pool section used by plugin for network params
+func PoolSectionName(p params.EthereumParams) string {
+	return "ethereum." + p.Ticker + "." + p.NetType
+}
+
+type ethereumRPCClient struct {
+	EthereumRPC
+	pool core.MultiPoolSection
+}
+
+// NewEthereumRPCClient takes a client out of connection pool section
+func NewEthereumRPCClient(section string) (EthereumRPC, error) {
+	pool, err := core.GetMultiPool().GetSection(section)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := pool.Get()
+	for err == errors.ErrObjectPoolUndeflow {
+		obj, err = pool.Get()
+	}
+	if err != nil {
+		return nil, err
+	}
+	rpc, ok := obj.(EthereumRPC)
+	if !ok {
+		logNetwork.Errorf("There is no proper client in %s pool", section)
+		return nil, errors.ErrInvalidPoolObjectType
+	}
+	return &ethereumRPCClient{
+		EthereumRPC: rpc,
+		pool:        pool,
+	}, nil
+}
+
+// ReturnEthereumRPCClient puts client back in connection pool
+func ReturnEthereumRPCClient(obj EthereumRPC) {
+	poolObj, ok := obj.(*ethereumRPCClient)
+	if !ok {
+		return
+	}
+	poolObj.pool.Put(poolObj.EthereumRPC)
+}
+
+// Type assertions
+var (
+	_ EthereumRPC              = &RPCClient{}
+	_ core.PooledObjectFactory = &EthereumConnectionFactory{}
+)
@@ -0,0 +1,19 @@
+package ethereum
+
+import (
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+)
+
+// EthereumSignService implements BlockchainSignService for multi-wallet transaction signing
+type EthereumSignService struct{}
+
+// Sign creates a new transaction by (fully or partially) signing a given transaction
+func (ess *EthereumSignService) Sign(txn core.Transaction, signSpec []core.InputSignDescriptor, pwd core.PasswordReader) (core.Transaction, error) {
+	return util.GenericMultiWalletSign(txn, signSpec, pwd)
+}
+
+// Type assertions
+var (
+	_ core.BlockchainSignService = &EthereumSignService{}
+)
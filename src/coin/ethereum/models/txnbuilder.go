@@ -0,0 +1,203 @@
+package ethereum
+
+import (
+	goerrors "errors"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/types"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+const (
+	// TxnOptGasPrice option key for gas price expressed in ether base units (gwei)
+	TxnOptGasPrice = "GasPrice"
+	// TxnOptGasLimit option key for maximum gas consumed by transaction
+	TxnOptGasLimit = "GasLimit"
+	// TxnOptNonce option key for sequence number of transaction sent by account
+	TxnOptNonce = "Nonce"
+	// OptionAuto asks the node for a suitable option value
+	OptionAuto = "auto"
+)
+
+// ErrSingleTransfer is returned when asked to send from several accounts or to several recipients
+var ErrSingleTransfer = goerrors.New("Ethereum transactions send a single asset from one account to one recipient")
+
+// resolveUintOption reads numeric transaction option, isAuto is set if the node should choose its value
+func resolveUintOption(options core.KeyValueStore, key string) (value uint64, isAuto bool, err error) {
+	var v interface{}
+	if options != nil {
+		v = options.GetValue(key)
+	}
+	switch v := v.(type) {
+	case nil:
+		return 0, true, nil
+	case uint64:
+		return v, false, nil
+	case int:
+		if v < 0 {
+			return 0, false, errors.ErrInvalidOptions
+		}
+		return uint64(v), false, nil
+	case string:
+		if v == "" || v == OptionAuto {
+			return 0, true, nil
+		}
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return 0, false, errors.ErrInvalidOptions
+		}
+		return n, false, nil
+	}
+	return 0, false, errors.ErrInvalidOptions
+}
+
+// resolveGasPrice returns gas price in wei, asking the node if requested and falling back to network default
+func resolveGasPrice(c EthereumRPC, p params.EthereumParams, options core.KeyValueStore) (*big.Int, error) {
+	price, isAuto, err := resolveUintOption(options, TxnOptGasPrice)
+	if err != nil {
+		return nil, err
+	}
+	if !isAuto {
+		return fromAmount(p, core.NewAmount(p.Ticker, price))
+	}
+	gasPrice, err := c.GasPrice()
+	if err != nil || gasPrice.Sign() == 0 {
+		logWallet.WithError(err).Warn("Gas price estimation not available, using default gas price")
+		return new(big.Int).SetUint64(p.DefaultGasPrice), nil
+	}
+	return gasPrice, nil
+}
+
+// transferTo reads the single asset paid to output and encodes it as transaction recipient, value and call data
+func transferTo(p params.EthereumParams, tx *types.Transaction, to core.TransactionOutput) (transfer, error) {
+	genericAddr, err := to.GetAddress()
+	if err != nil {
+		return transfer{}, err
+	}
+	recipient, err := NewEthereumAddress(genericAddr.String(), p)
+	if err != nil {
+		return transfer{}, err
+	}
+	t := transfer{recipient: recipient.addr}
+	for _, ticker := range p.Tickers() {
+		coins, err := to.GetCoins(ticker)
+		if err != nil || coins == 0 {
+			continue
+		}
+		if t.amount != nil {
+			return transfer{}, ErrSingleTransfer
+		}
+		t.ticker = ticker
+		if t.amount, err = fromAmount(p, core.NewAmount(ticker, coins)); err != nil {
+			return transfer{}, err
+		}
+	}
+	if t.amount == nil {
+		return transfer{}, errors.ErrInvalidValue
+	}
+	if t.ticker == p.Ticker {
+		tx.To, tx.Value = &t.recipient, t.amount
+		return t, nil
+	}
+	token, _ := p.LookupToken(t.ticker)
+	contract, err := types.ParseAddress(token.Contract)
+	if err != nil {
+		return transfer{}, err
+	}
+	tx.To, tx.Value = &contract, new(big.Int)
+	tx.Data = types.ERC20TransferData(t.recipient, t.amount)
+	return t, nil
+}
+
+// buildTransaction creates unsigned transaction paying output from account at address from
+func buildTransaction(p params.EthereumParams, from types.Address, to core.TransactionOutput, options core.KeyValueStore) (*EthereumTransaction, error) {
+	tx := &types.Transaction{}
+	t, err := transferTo(p, tx, to)
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewEthereumRPCClient(PoolSectionName(p))
+	if err != nil {
+		return nil, err
+	}
+	defer ReturnEthereumRPCClient(c)
+
+	nonce, isAuto, err := resolveUintOption(options, TxnOptNonce)
+	if err != nil {
+		return nil, err
+	}
+	if isAuto {
+		if nonce, err = c.GetTransactionCount(from, BlockPending); err != nil {
+			logWallet.WithError(err).Error("Couldn't get account nonce")
+			return nil, err
+		}
+	}
+	tx.Nonce = nonce
+	if tx.GasPrice, err = resolveGasPrice(c, p, options); err != nil {
+		return nil, err
+	}
+	gas, isAuto, err := resolveUintOption(options, TxnOptGasLimit)
+	if err != nil {
+		return nil, err
+	}
+	if isAuto {
+		if gas, err = c.EstimateGas(CallMsg{From: &from, To: tx.To, Value: tx.Value, Data: tx.Data}); err != nil {
+			logWallet.WithError(err).Warn("Gas estimation not available, using default gas limit")
+			gas = p.TransferGas
+			if t.ticker != p.Ticker {
+				gas = p.TokenTransferGas
+			}
+		}
+	}
+	tx.Gas = gas
+
+	// Check balances so that unaffordable transactions are not signed
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas), tx.GasPrice)
+	cost.Add(cost, tx.Value)
+	balance, err := c.GetBalance(from, BlockLatest)
+	if err != nil {
+		return nil, err
+	}
+	if balance.Cmp(cost) < 0 {
+		return nil, errors.ErrInsufficientFunds
+	}
+	if t.ticker != p.Ticker {
+		tokens, err := assetBalance(c, p, from, t.ticker, BlockLatest)
+		if err != nil {
+			return nil, err
+		}
+		if tokens.Cmp(t.amount) < 0 {
+			return nil, errors.ErrInsufficientFunds
+		}
+	}
+	txn, err := newEthereumTransaction(p, tx, from, core.TXN_STATUS_CREATED)
+	if err != nil {
+		return nil, err
+	}
+	txn.timestamp = core.Timestamp(time.Now().Unix())
+	logWallet.Infof("Created %s transaction with nonce %d and gas limit %d", t.ticker, tx.Nonce, tx.Gas)
+	return txn, nil
+}
+
+// singleSender determines account spending outputs, all of them shall belong to the same account
+func singleSender(p params.EthereumParams, unspent []core.TransactionOutput) (types.Address, error) {
+	var from types.Address
+	if len(unspent) == 0 {
+		return from, errors.ErrInsufficientFunds
+	}
+	for i, out := range unspent {
+		addr, _, err := parseBalanceOutputID(out.GetId())
+		if err != nil {
+			return from, err
+		}
+		if i > 0 && addr != from {
+			return from, ErrSingleTransfer
+		}
+		from = addr
+	}
+	return from, nil
+}
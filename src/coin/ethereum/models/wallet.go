@@ -0,0 +1,718 @@
+package ethereum
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/SkycoinProject/skycoin/src/cipher/bip32"
+	"github.com/SkycoinProject/skycoin/src/cipher/bip39"
+	"github.com/SkycoinProject/skycoin/src/cipher/encrypt"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/types"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logWallet = logging.MustGetLogger("Ethereum wallet")
+
+const (
+	// WalletTypeBip44 derives accounts along m/44'/coin'/0'/0/index paths
+	WalletTypeBip44 = "bip44"
+	// SignerIDHDWallet identifies the signing strategy of HD wallets
+	SignerIDHDWallet = "eth.hd"
+	// WalletTimestampFormat wallet file name timestamp format
+	WalletTimestampFormat = "2006_01_02"
+
+	walletExt     = ".ethwlt"
+	walletVersion = "1"
+)
+
+// walletCryptor protects wallet seeds at rest
+var walletCryptor = encrypt.DefaultScryptChacha20poly1305
+
+// walletFile is the on-disk representation of HD wallets
+type walletFile struct {
+	Version   string `json:"version"`
+	Label     string `json:"label"`
+	Type      string `json:"type"`
+	Coin      string `json:"coin"`
+	ChainID   uint64 `json:"chain_id"`
+	Encrypted bool   `json:"encrypted"`
+	// Seed BIP39 mnemonic, base64 encoded ciphertext if wallet is encrypted
+	Seed string `json:"seed"`
+	// AccountKey extended public key of account 0
+	AccountKey string `json:"account_key"`
+	Addresses  uint32 `json:"addresses"`
+}
+
+func loadWalletFile(path string) (*walletFile, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var wf walletFile
+	if err := json.Unmarshal(b, &wf); err != nil {
+		return nil, err
+	}
+	return &wf, nil
+}
+
+func saveWalletFile(path string, wf *walletFile) error {
+	b, err := json.MarshalIndent(wf, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// accountPrivateKey derives account 0 extended private key for network params
+func accountPrivateKey(mnemonic string, p params.EthereumParams) (*bip32.PrivateKey, error) {
+	seed, err := bip39.NewSeed(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	return bip32.NewPrivateKeyFromPath(seed, fmt.Sprintf("m/44'/%d'/0'", p.Bip44CoinType))
+}
+
+// HDWallet derives Ethereum accounts from a BIP39 seed stored in a wallet file.
+// The same accounts hold ether and every token supported by network params.
+type HDWallet struct { // Implements Wallet and TxnSigner interfaces
+	Id        string
+	WalletDir string
+	params    params.EthereumParams
+	mutex     *sync.Mutex
+}
+
+func newHDWallet(id, dir string, p params.EthereumParams) *HDWallet {
+	return &HDWallet{
+		Id:        id,
+		WalletDir: dir,
+		params:    p,
+		mutex:     new(sync.Mutex),
+	}
+}
+
+func (wlt *HDWallet) path() string {
+	return filepath.Join(wlt.WalletDir, wlt.Id)
+}
+
+func (wlt *HDWallet) load() (*walletFile, error) {
+	wf, err := loadWalletFile(wlt.path())
+	if err != nil {
+		logWallet.WithError(err).WithField("filename", wlt.path()).Error("Couldn't load wallet file")
+		return nil, err
+	}
+	return wf, nil
+}
+
+// GetId returns wallet local identifier
+func (wlt *HDWallet) GetId() string {
+	return wlt.Id
+}
+
+// GetLabel provides a human-readable name for this wallet
+func (wlt *HDWallet) GetLabel() string {
+	wf, err := wlt.load()
+	if err != nil {
+		return ""
+	}
+	return wf.Label
+}
+
+// SetLabel establishes a label for this wallet
+func (wlt *HDWallet) SetLabel(wltName string) {
+	wlt.mutex.Lock()
+	defer wlt.mutex.Unlock()
+	wf, err := wlt.load()
+	if err != nil {
+		return
+	}
+	wf.Label = wltName
+	if err := saveWalletFile(wlt.path(), wf); err != nil {
+		logWallet.WithError(err).Error("Couldn't save wallet label")
+	}
+}
+
+// deriveAddresses derives count external addresses starting at startIndex using account public key
+func deriveAddresses(wf *walletFile, startIndex, count uint32, p params.EthereumParams) ([]*EthereumAddress, error) {
+	accKey, err := bip32.DeserializeEncodedPublicKey(wf.AccountKey)
+	if err != nil {
+		return nil, err
+	}
+	chainKey, err := accKey.NewPublicChildKey(0)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]*EthereumAddress, 0, count)
+	for i := startIndex; i < startIndex+count; i++ {
+		childKey, err := chainKey.NewPublicChildKey(i)
+		if err != nil {
+			return nil, err
+		}
+		pk, err := cipher.NewPubKey(childKey.Key)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := newAddressFromPubKey(pk, p)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// GenAddresses discover new addresses based on BIP32 derivation sequences.
+// Account based coins do not need change addresses, hence every address type is derived from external chain.
+func (wlt *HDWallet) GenAddresses(addrType core.AddressType, startIndex, count uint32, pwd core.PasswordReader) core.AddressIterator {
+	logWallet.Info("Generating addresses for HD wallet")
+	wlt.mutex.Lock()
+	defer wlt.mutex.Unlock()
+	wf, err := wlt.load()
+	if err != nil {
+		return nil
+	}
+	addrs, err := deriveAddresses(wf, startIndex, count, wlt.params)
+	if err != nil {
+		logWallet.WithError(err).Error("Couldn't derive addresses")
+		return nil
+	}
+	if startIndex+count > wf.Addresses {
+		wf.Addresses = startIndex + count
+		if err := saveWalletFile(wlt.path(), wf); err != nil {
+			logWallet.WithError(err).Error("Couldn't save generated addresses")
+			return nil
+		}
+	}
+	coreAddrs := make([]core.Address, len(addrs))
+	for i, addr := range addrs {
+		coreAddrs[i] = addr
+	}
+	return NewEthereumAddressIterator(coreAddrs)
+}
+
+// loadedAddresses lists addresses generated so far
+func (wlt *HDWallet) loadedAddresses() ([]*EthereumAddress, error) {
+	wf, err := wlt.load()
+	if err != nil {
+		return nil, err
+	}
+	return deriveAddresses(wf, 0, wf.Addresses, wlt.params)
+}
+
+// GetLoadedAddresses iterates over wallet addresses generated so far
+func (wlt *HDWallet) GetLoadedAddresses() (core.AddressIterator, error) {
+	addrs, err := wlt.loadedAddresses()
+	if err != nil {
+		return nil, err
+	}
+	coreAddrs := make([]core.Address, len(addrs))
+	for i, addr := range addrs {
+		coreAddrs[i] = addr
+	}
+	return NewEthereumAddressIterator(coreAddrs), nil
+}
+
+// GetCryptoAccount instantiate object to determine wallet balances
+func (wlt *HDWallet) GetCryptoAccount() core.CryptoAccount {
+	return newEthereumAccount(wlt.params, PoolSectionName(wlt.params), wlt.loadedAddresses)
+}
+
+// Transfer instantiates unsigned transaction sending funds from the first wallet account able to afford it
+func (wlt *HDWallet) Transfer(to core.TransactionOutput, options core.KeyValueStore) (core.Transaction, error) {
+	logWallet.Info("Creating transaction to transfer funds")
+	addrs, err := wlt.loadedAddresses()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		txn, err := buildTransaction(wlt.params, addr.addr, to, options)
+		if err == errors.ErrInsufficientFunds {
+			continue
+		}
+		return txn, err
+	}
+	return nil, errors.ErrInsufficientFunds
+}
+
+// SendFromAddress instantiates unsigned transaction to send funds from a single account to a single recipient.
+// Change address is ignored since accounts keep the remaining balance.
+func (wlt *HDWallet) SendFromAddress(from []core.Address, to []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	logWallet.Info("Creating transaction to send funds from address")
+	if len(from) != 1 || len(to) != 1 {
+		return nil, ErrSingleTransfer
+	}
+	sender, err := NewEthereumAddress(from[0].String(), wlt.params)
+	if err != nil {
+		return nil, err
+	}
+	return buildTransaction(wlt.params, sender.addr, to[0], options)
+}
+
+// Spend instantiate unsigned transaction debiting the account owning balance outputs
+func (wlt *HDWallet) Spend(unspent, new []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	logWallet.Info("Creating transaction to spend outputs")
+	if len(new) != 1 {
+		return nil, ErrSingleTransfer
+	}
+	from, err := singleSender(wlt.params, unspent)
+	if err != nil {
+		return nil, err
+	}
+	return buildTransaction(wlt.params, from, new[0], options)
+}
+
+// Sign creates a new transaction by (fully or partially) choosing a strategy to sign given transaction
+func (wlt *HDWallet) Sign(txn core.Transaction, signer core.TxnSigner, pwd core.PasswordReader, index []string) (core.Transaction, error) {
+	logWallet.Info("Signing transaction with HD wallet")
+	if signer == nil {
+		signer = wlt
+	}
+	return signer.SignTransaction(txn, pwd, index)
+}
+
+// mnemonic reads wallet seed, asking for password if wallet is encrypted
+func (wlt *HDWallet) mnemonic(wf *walletFile, pwd core.PasswordReader, method string) (string, error) {
+	if !wf.Encrypted {
+		return wf.Seed, nil
+	}
+	if pwd == nil {
+		return "", errors.ErrWalletCantSign
+	}
+	pwdCtx := util.NewKeyValueMap()
+	pwdCtx.SetValue(core.StrTypeName, core.TypeNameWallet)
+	pwdCtx.SetValue(core.StrMethodName, method)
+	pwdCtx.SetValue(core.StrWalletName, wlt.Id)
+	pwdCtx.SetValue(core.StrWalletLabel, wf.Label)
+	password, err := pwd("Enter password", pwdCtx)
+	if err != nil {
+		return "", err
+	}
+	return decryptSeed(wf.Seed, password)
+}
+
+func encryptSeed(mnemonic, password string) (string, error) {
+	b, err := walletCryptor.Encrypt([]byte(mnemonic), []byte(password))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func decryptSeed(seed, password string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(seed)
+	if err != nil {
+		return "", err
+	}
+	plain, err := walletCryptor.Decrypt(b, []byte(password))
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// ReadyForTxn determines whether transaction can be signed with this signer instance
+func (wlt *HDWallet) ReadyForTxn(w core.Wallet, txn core.Transaction) (bool, error) {
+	if w == nil || w.GetId() != wlt.Id {
+		return false, nil
+	}
+	ethTxn, isEthTxn := txn.(*EthereumTransaction)
+	return isEthTxn && ethTxn.params.ChainID == wlt.params.ChainID, nil
+}
+
+// SignTransaction signs transaction sent by a wallet account
+//
+// @param txn Transacion object
+// @param pwdReader password prompt to decode target wallet should it be needed
+// @param strIdxs may be `nil` for signing the single input; if set should contain its ID
+func (wlt *HDWallet) SignTransaction(txn core.Transaction, pwdReader core.PasswordReader, strIdxs []string) (core.Transaction, error) {
+	ethTxn, isEthTxn := txn.(*EthereumTransaction)
+	if !isEthTxn {
+		return nil, errors.ErrInvalidTxn
+	}
+	inputID := ethTxn.GetInputs()[0].GetId()
+	for _, id := range strIdxs {
+		if id != inputID {
+			return nil, errors.ErrInvalidID
+		}
+	}
+	wf, err := wlt.load()
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := deriveAddresses(wf, 0, wf.Addresses, wlt.params)
+	if err != nil {
+		return nil, err
+	}
+	index := -1
+	for i, addr := range addrs {
+		if addr.addr == ethTxn.from {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		logWallet.WithField("from", ethTxn.from.Hex()).Error("Sender account is not owned by wallet")
+		return nil, errors.ErrWalletCantSign
+	}
+	mnemonic, err := wlt.mnemonic(wf, pwdReader, "SignTransaction")
+	if err != nil {
+		return nil, err
+	}
+	accKey, err := accountPrivateKey(mnemonic, wlt.params)
+	if err != nil {
+		return nil, err
+	}
+	chainKey, err := accKey.NewPrivateChildKey(0)
+	if err != nil {
+		return nil, err
+	}
+	childKey, err := chainKey.NewPrivateChildKey(uint32(index))
+	if err != nil {
+		return nil, err
+	}
+	sk, err := cipher.NewSecKey(childKey.Key)
+	if err != nil {
+		return nil, err
+	}
+	tx := ethTxn.tx.Copy()
+	signingHash, err := tx.SigningHash(wlt.params.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := cipher.SignHash(signingHash, sk)
+	if err != nil {
+		logWallet.WithError(err).Error("Couldn't sign transaction")
+		return nil, errors.ErrTxnSignFailure
+	}
+	tx.SetSignature(sig, wlt.params.ChainID)
+	signed, err := newEthereumTransaction(wlt.params, tx, ethTxn.from, ethTxn.status)
+	if err != nil {
+		return nil, err
+	}
+	signed.timestamp = ethTxn.timestamp
+	return signed, nil
+}
+
+// GetSignerUID returns ID of HD wallets signing strategy
+func (wlt *HDWallet) GetSignerUID() (core.UID, error) {
+	return SignerIDHDWallet, nil
+}
+
+// GetSignerDescription describes signing strategy
+func (wlt *HDWallet) GetSignerDescription() (string, error) {
+	return wlt.params.Name + " HD wallet " + wlt.Id, nil
+}
+
+// EthereumWalletIterator iterates over a sequence of wallets
+type EthereumWalletIterator struct {
+	current int
+	wallets []core.Wallet
+}
+
+// Value of wallet at iterator pointer position
+func (it *EthereumWalletIterator) Value() core.Wallet {
+	return it.wallets[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *EthereumWalletIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *EthereumWalletIterator) HasNext() bool {
+	return (it.current + 1) < len(it.wallets)
+}
+
+// NewEthereumWalletIterator instantiates iterator over wallets
+func NewEthereumWalletIterator(wallets []core.Wallet) *EthereumWalletIterator {
+	return &EthereumWalletIterator{wallets: wallets, current: -1}
+}
+
+// WalletDirectory stores HD wallets of an Ethereum network in a local folder
+type WalletDirectory struct { // Implements WalletEnv, WalletSet and WalletStorage interfaces
+	WalletDir string
+	params    params.EthereumParams
+}
+
+// NewWalletDirectory instantiates wallet environment for network params backed by folder at dirPath
+func NewWalletDirectory(dirPath string, p params.EthereumParams) *WalletDirectory {
+	return &WalletDirectory{WalletDir: dirPath, params: p}
+}
+
+// GetStorage provides access to wallet data store
+func (wltDir *WalletDirectory) GetStorage() core.WalletStorage {
+	return wltDir
+}
+
+// GetWalletSet loads wallets in this environment
+func (wltDir *WalletDirectory) GetWalletSet() core.WalletSet {
+	return wltDir
+}
+
+// LookupWallet finds wallet whose first address is firstAddr
+func (wltDir *WalletDirectory) LookupWallet(firstAddr string) (core.Wallet, error) {
+	target, err := types.ParseAddress(firstAddr)
+	if err != nil {
+		return nil, err
+	}
+	wls := wltDir.ListWallets()
+	for wls.Next() {
+		w := wls.Value()
+		addrs := w.GenAddresses(core.AccountAddress, 0, 1, nil)
+		if addrs != nil && addrs.Next() && addrs.Value().String() == target.Hex() {
+			return w, nil
+		}
+	}
+	return nil, errors.ErrWltFromAddrNotFound
+}
+
+// ListWallets returns an iterator over wallets of network chain found in directory
+func (wltDir *WalletDirectory) ListWallets() core.WalletIterator {
+	logWallet.Info("Listing Ethereum wallets")
+	wallets := make([]core.Wallet, 0)
+	entries, err := ioutil.ReadDir(wltDir.WalletDir)
+	if err != nil {
+		logWallet.WithError(err).WithField("dirname", wltDir.WalletDir).Error("Couldn't read wallet directory")
+		return NewEthereumWalletIterator(wallets)
+	}
+	for _, e := range entries {
+		if !e.Mode().IsRegular() || !strings.HasSuffix(e.Name(), walletExt) {
+			continue
+		}
+		if w := wltDir.GetWallet(e.Name()); w != nil {
+			wallets = append(wallets, w)
+		}
+	}
+	return NewEthereumWalletIterator(wallets)
+}
+
+// GetWallet to lookup wallet by ID
+func (wltDir *WalletDirectory) GetWallet(id string) core.Wallet {
+	path := filepath.Join(wltDir.WalletDir, id)
+	wf, err := loadWalletFile(path)
+	if err != nil {
+		logWallet.WithError(err).WithField("filename", path).Debug("Couldn't load wallet")
+		return nil
+	}
+	if wf.Coin != wltDir.params.Ticker || wf.ChainID != wltDir.params.ChainID {
+		return nil
+	}
+	return newHDWallet(id, wltDir.WalletDir, wltDir.params)
+}
+
+// CreateWallet instantiates a new HD wallet given BIP39 mnemonic
+func (wltDir *WalletDirectory) CreateWallet(label string, seed string, wltType string, isEncrypted bool, pwd core.PasswordReader, scanAddressesN int) (core.Wallet, error) {
+	logWallet.Info("Creating Ethereum HD wallet")
+	if wltType != WalletTypeBip44 {
+		return nil, errors.ErrInvalidOptions
+	}
+	if err := bip39.ValidateMnemonic(seed); err != nil {
+		return nil, errors.ErrInvalidWalletEntropy
+	}
+	accKey, err := accountPrivateKey(seed, wltDir.params)
+	if err != nil {
+		return nil, err
+	}
+	wf := &walletFile{
+		Version:    walletVersion,
+		Label:      label,
+		Type:       wltType,
+		Coin:       wltDir.params.Ticker,
+		ChainID:    wltDir.params.ChainID,
+		Seed:       seed,
+		AccountKey: accKey.PublicKey().String(),
+		Addresses:  1,
+	}
+	if isEncrypted {
+		pwdCtx := util.NewKeyValueMap()
+		pwdCtx.SetValue(core.StrTypeName, core.TypeNameWalletSet)
+		pwdCtx.SetValue(core.StrMethodName, "CreateWallet")
+		pwdCtx.SetValue(core.StrWalletLabel, label)
+		password, err := pwd("Enter password", pwdCtx)
+		if err != nil {
+			return nil, err
+		}
+		if wf.Seed, err = encryptSeed(seed, password); err != nil {
+			return nil, err
+		}
+		wf.Encrypted = true
+	}
+	if scanAddressesN > 0 {
+		wf.Addresses = scanAhead(wf, uint32(scanAddressesN), wltDir.params)
+	}
+	if err := os.MkdirAll(wltDir.WalletDir, 0700); err != nil {
+		return nil, err
+	}
+	id := wltDir.newUniqueWalletFilename()
+	if err := saveWalletFile(filepath.Join(wltDir.WalletDir, id), wf); err != nil {
+		logWallet.WithError(err).WithField("dir", wltDir.WalletDir).Error("Couldn't save wallet")
+		return nil, err
+	}
+	return newHDWallet(id, wltDir.WalletDir, wltDir.params), nil
+}
+
+// scanAhead determines how many addresses have been used to send or hold ether, at least one
+func scanAhead(wf *walletFile, n uint32, p params.EthereumParams) uint32 {
+	addrs, err := deriveAddresses(wf, 0, n, p)
+	if err != nil {
+		return 1
+	}
+	c, err := NewEthereumRPCClient(PoolSectionName(p))
+	if err != nil {
+		logWallet.WithError(err).Warn("Couldn't scan addresses ahead")
+		return 1
+	}
+	defer ReturnEthereumRPCClient(c)
+	count := uint32(1)
+	for i, addr := range addrs {
+		nonce, err := c.GetTransactionCount(addr.addr, BlockLatest)
+		if err != nil {
+			logWallet.WithError(err).Warn("Couldn't scan addresses ahead")
+			return count
+		}
+		balance, err := c.GetBalance(addr.addr, BlockLatest)
+		if err != nil {
+			logWallet.WithError(err).Warn("Couldn't scan addresses ahead")
+			return count
+		}
+		if nonce > 0 || balance.Sign() > 0 {
+			count = uint32(i) + 1
+		}
+	}
+	return count
+}
+
+func (wltDir *WalletDirectory) newUniqueWalletFilename() string {
+	for {
+		timestamp := time.Now().Format(WalletTimestampFormat)
+		padding := hex.EncodeToString(cipher.RandByte(2))
+		name := fmt.Sprintf("%s_%s%s", timestamp, padding, walletExt)
+		if _, err := os.Stat(filepath.Join(wltDir.WalletDir, name)); os.IsNotExist(err) {
+			return name
+		}
+	}
+}
+
+// RemoveWallet deletes HD wallet file
+func (wltDir *WalletDirectory) RemoveWallet(id string) error {
+	logWallet.Info("Removing Ethereum HD wallet")
+	if wltDir.GetWallet(id) == nil {
+		return errors.ErrNotFound
+	}
+	path := filepath.Join(wltDir.WalletDir, id)
+	if err := os.Remove(path); err != nil {
+		logWallet.WithError(err).WithField("filename", path).Error("Couldn't remove wallet")
+		return err
+	}
+	return nil
+}
+
+// DefaultWalletType default wallet type
+func (wltDir *WalletDirectory) DefaultWalletType() string {
+	return WalletTypeBip44
+}
+
+// SupportedWalletTypes list supported wallet type names
+func (wltDir *WalletDirectory) SupportedWalletTypes() []string {
+	return []string{WalletTypeBip44}
+}
+
+func (wltDir *WalletDirectory) readPassword(wltName, method string, wf *walletFile, password core.PasswordReader) (string, error) {
+	pwdCtx := util.NewKeyValueMap()
+	pwdCtx.SetValue(core.StrTypeName, core.TypeNameWalletStorage)
+	pwdCtx.SetValue(core.StrMethodName, method)
+	pwdCtx.SetValue(core.StrWalletName, wltName)
+	pwdCtx.SetValue(core.StrWalletLabel, wf.Label)
+	return password("Enter password", pwdCtx)
+}
+
+// Encrypt protects wallet seed using password
+func (wltDir *WalletDirectory) Encrypt(walletName string, password core.PasswordReader) {
+	logWallet.Info("Encrypt Ethereum wallet")
+	path := filepath.Join(wltDir.WalletDir, walletName)
+	wf, err := loadWalletFile(path)
+	if err != nil {
+		logWallet.WithError(err).WithField("filename", path).Error("Couldn't load wallet inside Encrypt")
+		return
+	}
+	if wf.Encrypted {
+		return
+	}
+	pwd, err := wltDir.readPassword(walletName, "Encrypt", wf, password)
+	if err != nil {
+		logWallet.WithError(err).Error("Something was wrong entering the password")
+		return
+	}
+	if wf.Seed, err = encryptSeed(wf.Seed, pwd); err != nil {
+		logWallet.WithError(err).Error("Couldn't encrypt wallet seed")
+		return
+	}
+	wf.Encrypted = true
+	if err := saveWalletFile(path, wf); err != nil {
+		logWallet.WithError(err).WithField("filename", path).Error("Couldn't save wallet inside Encrypt")
+	}
+}
+
+// Decrypt removes password protection of wallet seed
+func (wltDir *WalletDirectory) Decrypt(walletName string, password core.PasswordReader) {
+	logWallet.Info("Decrypt Ethereum wallet")
+	path := filepath.Join(wltDir.WalletDir, walletName)
+	wf, err := loadWalletFile(path)
+	if err != nil {
+		logWallet.WithError(err).WithField("filename", path).Error("Couldn't load wallet inside Decrypt")
+		return
+	}
+	if !wf.Encrypted {
+		return
+	}
+	pwd, err := wltDir.readPassword(walletName, "Decrypt", wf, password)
+	if err != nil {
+		logWallet.WithError(err).Error("Something was wrong entering the password")
+		return
+	}
+	if wf.Seed, err = decryptSeed(wf.Seed, pwd); err != nil {
+		logWallet.WithError(err).Error("Couldn't decrypt wallet seed")
+		return
+	}
+	wf.Encrypted = false
+	if err := saveWalletFile(path, wf); err != nil {
+		logWallet.WithError(err).WithField("filename", path).Error("Couldn't save wallet inside Decrypt")
+	}
+}
+
+// IsEncrypted queries whether wallet seed is encrypted or not
+func (wltDir *WalletDirectory) IsEncrypted(walletName string) (bool, error) {
+	wf, err := loadWalletFile(filepath.Join(wltDir.WalletDir, walletName))
+	if err != nil {
+		return false, err
+	}
+	return wf.Encrypted, nil
+}
+
+// Type assertions
+var (
+	_ core.Wallet         = &HDWallet{}
+	_ core.TxnSigner      = &HDWallet{}
+	_ core.WalletIterator = &EthereumWalletIterator{}
+	_ core.WalletEnv      = &WalletDirectory{}
+	_ core.WalletSet      = &WalletDirectory{}
+	_ core.WalletStorage  = &WalletDirectory{}
+	_ core.WalletRemover  = &WalletDirectory{}
+)
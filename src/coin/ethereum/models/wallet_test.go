@@ -0,0 +1,242 @@
+package ethereum
+
+import (
+	"io/ioutil"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/cipher/encrypt"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/rpcstub"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/types"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func init() {
+	// Keep password derivation fast in tests
+	walletCryptor = encrypt.ScryptChacha20poly1305{N: 1 << 10, R: 8, P: 1, KeyLen: 32}
+}
+
+func testPassword(string, core.KeyValueStore) (string, error) {
+	return "secret", nil
+}
+
+func tempWalletDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "ethwallets")
+	require.NoError(t, err)
+	return dir
+}
+
+// startStubNode serves stub node at the connection pool section of network params
+func startStubNode(t *testing.T, p params.EthereumParams) (*rpcstub.Server, func()) {
+	stub := rpcstub.NewServer(p)
+	srv := httptest.NewServer(stub)
+	err := core.GetMultiPool().CreateSection(PoolSectionName(p), NewEthereumConnectionFactory(srv.URL))
+	require.NoError(t, err)
+	return stub, srv.Close
+}
+
+func firstAddress(t *testing.T, wlt core.Wallet) string {
+	addrs := wlt.GenAddresses(core.AccountAddress, 0, 1, nil)
+	require.NotNil(t, addrs)
+	require.True(t, addrs.Next())
+	return addrs.Value().String()
+}
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func TestHDWalletDerivation(t *testing.T) {
+	dir := tempWalletDir(t)
+	defer os.RemoveAll(dir)
+	wltDir := NewWalletDirectory(dir, params.EthereumMainNetParams)
+
+	wlt, err := wltDir.CreateWallet("wallet", testMnemonic, WalletTypeBip44, true, testPassword, 0)
+	require.NoError(t, err)
+	require.Equal(t, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", firstAddress(t, wlt))
+	isEncrypted, err := wltDir.IsEncrypted(wlt.GetId())
+	require.NoError(t, err)
+	require.True(t, isEncrypted)
+
+	found, err := wltDir.LookupWallet("0x9858effd232b4033e47d90003d41ec34ecaeda94")
+	require.NoError(t, err)
+	require.Equal(t, wlt.GetId(), found.GetId())
+
+	// Wallets of other chains are not listed
+	require.False(t, NewWalletDirectory(dir, params.EthereumDevNetParams).ListWallets().Next())
+
+	_, err = wltDir.CreateWallet("bad", testMnemonic, "bip84", false, nil, 0)
+	require.Error(t, err)
+
+	require.NoError(t, wltDir.RemoveWallet(wlt.GetId()))
+	require.Nil(t, wltDir.GetWallet(wlt.GetId()))
+	require.Equal(t, errors.ErrNotFound, wltDir.RemoveWallet(wlt.GetId()))
+}
+
+func TestHDWalletEtherAndTokenTransfers(t *testing.T) {
+	p := params.EthereumDevNetParams
+	stub, stop := startStubNode(t, p)
+	defer stop()
+	dir := tempWalletDir(t)
+	defer os.RemoveAll(dir)
+	wlt, err := NewWalletDirectory(dir, p).CreateWallet("wallet", testMnemonic, WalletTypeBip44, true, testPassword, 0)
+	require.NoError(t, err)
+	from, err := types.ParseAddress(firstAddress(t, wlt))
+	require.NoError(t, err)
+	contract, err := types.ParseAddress(p.Tokens[0].Contract)
+	require.NoError(t, err)
+	stub.Fund(from, ether(2))
+	stub.MintToken(contract, from, ether(100))
+
+	acc := wlt.GetCryptoAccount()
+	require.Equal(t, []string{"ETH", "TST"}, acc.ListAssets())
+	balance, err := acc.GetBalance("ETH")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2000000000), balance)
+	balance, err = acc.GetBalance("TST")
+	require.NoError(t, err)
+	require.Equal(t, uint64(100000000000), balance)
+	outputs, err := acc.ScanUnspentOutputs()
+	require.NoError(t, err)
+	require.True(t, outputs.Next())
+	require.Equal(t, from.Hex()+":ETH", outputs.Value().GetId())
+	require.True(t, outputs.Next())
+	require.Equal(t, from.Hex()+":TST", outputs.Value().GetId())
+	require.False(t, outputs.Next())
+
+	dest := util.NewGenericAddress("0x3535353535353535353535353535353535353535")
+	pex := NewEthereumPEX(p)
+
+	// Ether transfer at gas price chosen by the node
+	to := util.NewGenericOutput(&dest, "")
+	to.SetCoins("ETH", 500000000)
+	txn, err := wlt.Transfer(&to, nil)
+	require.NoError(t, err)
+	require.NoError(t, txn.VerifyUnsigned())
+	require.Equal(t, []string{"ETH"}, txn.SupportedAssets())
+	fee, err := txn.ComputeFee("ETH")
+	require.NoError(t, err)
+	require.Equal(t, uint64(21000), fee)
+	spent, err := txn.GetInputs()[0].GetCoins("ETH")
+	require.NoError(t, err)
+	require.Equal(t, uint64(500000000)+fee, spent)
+	signed, err := wlt.Sign(txn, nil, testPassword, nil)
+	require.NoError(t, err)
+	require.NoError(t, signed.VerifySigned())
+	isSigned, err := txn.IsFullySigned()
+	require.NoError(t, err)
+	require.False(t, isSigned)
+	require.NoError(t, pex.BroadcastTxn(signed))
+	require.Equal(t, []string{signed.GetId()}, stub.Pending())
+
+	// Token transfer queued with next nonce and explicit gas price
+	tokenTo := util.NewGenericOutput(&dest, "")
+	tokenTo.SetCoins("TST", 40000000000)
+	opts := util.NewKeyValueMap()
+	opts.SetValue(TxnOptGasPrice, "2")
+	tokenTxn, err := wlt.Transfer(&tokenTo, opts)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ETH", "TST"}, tokenTxn.SupportedAssets())
+	require.Equal(t, uint64(1), tokenTxn.(*EthereumTransaction).Tx().Nonce)
+	tokenFee, err := tokenTxn.ComputeFee("ETH")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2*52000), tokenFee)
+	outs := tokenTxn.GetOutputs()
+	require.Len(t, outs, 1)
+	recipient, err := outs[0].GetAddress()
+	require.NoError(t, err)
+	require.Equal(t, "0x3535353535353535353535353535353535353535", recipient.String())
+	coins, err := outs[0].GetCoins("TST")
+	require.NoError(t, err)
+	require.Equal(t, uint64(40000000000), coins)
+	signedToken, err := wlt.Sign(tokenTxn, nil, testPassword, []string{tokenTxn.GetInputs()[0].GetId()})
+	require.NoError(t, err)
+	require.NoError(t, pex.BroadcastTxn(signedToken))
+
+	pending, err := acc.ListPendingTransactions()
+	require.NoError(t, err)
+	require.True(t, pending.Next())
+	require.Equal(t, signed.GetId(), pending.Value().GetId())
+	require.True(t, pending.Next())
+	require.Equal(t, signedToken.GetId(), pending.Value().GetId())
+
+	stub.Mine()
+	balance, err = acc.GetBalance("ETH")
+	require.NoError(t, err)
+	require.Equal(t, uint64(2000000000-500000000)-fee-tokenFee, balance)
+	balance, err = acc.GetBalance("TST")
+	require.NoError(t, err)
+	require.Equal(t, uint64(60000000000), balance)
+	require.Equal(t, ether(40).String(), stub.TokenBalance(contract, *signed.(*EthereumTransaction).Tx().To).String())
+
+	supply, err := NewEthereumBlockchain(p).GetCoinValue(core.CoinCurrentSupply, "TST")
+	require.NoError(t, err)
+	require.Equal(t, uint64(100000000000), supply)
+	blocks, err := NewEthereumBlockchain(p).GetNumberOfBlocks()
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), blocks)
+}
+
+func TestHDWalletTransferErrors(t *testing.T) {
+	p := params.EthereumDevNetParams
+	stub, stop := startStubNode(t, p)
+	defer stop()
+	dir := tempWalletDir(t)
+	defer os.RemoveAll(dir)
+	wlt, err := NewWalletDirectory(dir, p).CreateWallet("wallet", testMnemonic, WalletTypeBip44, true, testPassword, 0)
+	require.NoError(t, err)
+	from, err := types.ParseAddress(firstAddress(t, wlt))
+	require.NoError(t, err)
+	stub.Fund(from, big.NewInt(21000*1000000000))
+
+	dest := util.NewGenericAddress("0x3535353535353535353535353535353535353535")
+	to := util.NewGenericOutput(&dest, "")
+	to.SetCoins("ETH", 1)
+	_, err = wlt.Transfer(&to, nil)
+	require.Equal(t, errors.ErrInsufficientFunds, err)
+
+	to.SetCoins("TST", 1)
+	_, err = wlt.Transfer(&to, nil)
+	require.Equal(t, ErrSingleTransfer, err)
+
+	tokenTo := util.NewGenericOutput(&dest, "")
+	tokenTo.SetCoins("TST", 1)
+	_, err = wlt.Transfer(&tokenTo, nil)
+	require.Equal(t, errors.ErrInsufficientFunds, err)
+
+	ethTo := util.NewGenericOutput(&dest, "")
+	ethTo.SetCoins("ETH", 0)
+	_, err = wlt.Transfer(&ethTo, nil)
+	require.Equal(t, errors.ErrInvalidValue, err)
+
+	stub.Fund(from, ether(1))
+	ethTo.SetCoins("ETH", 1000)
+	txn, err := wlt.Transfer(&ethTo, nil)
+	require.NoError(t, err)
+	_, err = wlt.Sign(txn, nil, func(string, core.KeyValueStore) (string, error) {
+		return "wrong", nil
+	}, nil)
+	require.Error(t, err)
+	_, err = wlt.Sign(txn, nil, testPassword, []string{"0x00:1"})
+	require.Equal(t, errors.ErrInvalidID, err)
+
+	// Nodes reject replayed nonces
+	signed, err := wlt.Sign(txn, nil, testPassword, nil)
+	require.NoError(t, err)
+	pex := NewEthereumPEX(p)
+	require.NoError(t, pex.BroadcastTxn(signed))
+	stub.Mine()
+	require.Error(t, pex.BroadcastTxn(signed))
+
+	peers, err := pex.GetConnections()
+	require.NoError(t, err)
+	require.False(t, peers.ListPeers().Next())
+}
@@ -0,0 +1,191 @@
+package params
+
+import "math/big"
+
+// TokenParams describe an ERC-20 token held in Ethereum accounts
+type TokenParams struct {
+	// Name human readable name of the token
+	Name string
+	// Ticker token identifier
+	Ticker string
+	// Contract EIP-55 address of token contract
+	Contract string
+	// Decimals of token amounts as declared by contract
+	Decimals int32
+	// Accuracy decimal places of token amounts expressed in base units, at most Decimals
+	Accuracy int32
+}
+
+// EthereumParams describe an Ethereum-like account based coin and the network it runs on
+type EthereumParams struct {
+	// Name human readable name of the coin
+	Name string
+	// Ticker coin identifier
+	Ticker string
+	// NetType network name accepted by plugin loaders (e.g. MainNet)
+	NetType string
+	// ChainID EIP-155 chain identifier signed in transactions
+	ChainID uint64
+	// Bip44CoinType coin_type segment of BIP44 derivation paths
+	Bip44CoinType uint32
+	// Accuracy decimal places of ether expressed in base units (gwei), at most EtherDecimals
+	Accuracy int32
+	// DefaultGasPrice gas price in wei used when estimation is not available
+	DefaultGasPrice uint64
+	// TransferGas gas limit of plain value transfers
+	TransferGas uint64
+	// TokenTransferGas gas limit of token transfers used when estimation is not available
+	TokenTransferGas uint64
+	// Tokens ERC-20 tokens supported on network
+	Tokens []TokenParams
+}
+
+// Constparams
+const (
+	// EthereumFamily identifies Ethereum-like account based coins and their tokens
+	EthereumFamily = "Ethereum"
+	// EtherTicker Ethereum coin identifier
+	EtherTicker = "ETH"
+	// EtherName human readable name associated to Ethereum
+	EtherName = "Ethereum"
+	// EtherDecimals decimal places of an ether expressed in wei
+	EtherDecimals = 18
+	// EthereumDescription verbose explanation of Ethereum family coins
+	EthereumDescription = "Ethereum and ERC-20 tokens spoken to via Ethereum JSON-RPC nodes"
+)
+
+// LookupToken finds token identified by ticker
+func (p EthereumParams) LookupToken(ticker string) (TokenParams, bool) {
+	for _, token := range p.Tokens {
+		if token.Ticker == ticker {
+			return token, true
+		}
+	}
+	return TokenParams{}, false
+}
+
+// LookupContract finds token issued by contract at EIP-55 or lower case address
+func (p EthereumParams) LookupContract(contract string) (TokenParams, bool) {
+	for _, token := range p.Tokens {
+		if equalFoldHex(token.Contract, contract) {
+			return token, true
+		}
+	}
+	return TokenParams{}, false
+}
+
+// Tickers lists ether and token identifiers
+func (p EthereumParams) Tickers() []string {
+	tickers := []string{p.Ticker}
+	for _, token := range p.Tokens {
+		tickers = append(tickers, token.Ticker)
+	}
+	return tickers
+}
+
+// UnitScale number of indivisible units (e.g. wei) in a base unit of asset identified by ticker
+func (p EthereumParams) UnitScale(ticker string) (*big.Int, bool) {
+	if ticker == p.Ticker {
+		return pow10(EtherDecimals - p.Accuracy), true
+	}
+	if token, isToken := p.LookupToken(ticker); isToken {
+		return pow10(token.Decimals - token.Accuracy), true
+	}
+	return nil, false
+}
+
+// AssetAccuracy decimal places of base units of asset identified by ticker
+func (p EthereumParams) AssetAccuracy(ticker string) (int32, bool) {
+	if ticker == p.Ticker {
+		return p.Accuracy, true
+	}
+	token, isToken := p.LookupToken(ticker)
+	return token.Accuracy, isToken
+}
+
+// AssetDecimals decimal places of asset identified by ticker expressed in indivisible units (e.g. wei)
+func (p EthereumParams) AssetDecimals(ticker string) (int32, bool) {
+	if ticker == p.Ticker {
+		return EtherDecimals, true
+	}
+	token, isToken := p.LookupToken(ticker)
+	return token.Decimals, isToken
+}
+
+func pow10(n int32) *big.Int {
+	if n <= 0 {
+		return big.NewInt(1)
+	}
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func equalFoldHex(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		x, y := a[i], b[i]
+		if 'A' <= x && x <= 'F' {
+			x += 'a' - 'A'
+		}
+		if 'A' <= y && y <= 'F' {
+			y += 'a' - 'A'
+		}
+		if x != y {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	// EthereumMainNetParams Ethereum main network
+	EthereumMainNetParams = EthereumParams{
+		Name:             EtherName,
+		Ticker:           EtherTicker,
+		NetType:          "MainNet",
+		ChainID:          1,
+		Bip44CoinType:    60,
+		Accuracy:         9,
+		DefaultGasPrice:  20000000000,
+		TransferGas:      21000,
+		TokenTransferGas: 100000,
+		Tokens: []TokenParams{
+			TokenParams{
+				Name:     "Tether USD",
+				Ticker:   "USDT",
+				Contract: "0xdAC17F958D2ee523a2206206994597C13D831ec7",
+				Decimals: 6,
+				Accuracy: 6,
+			},
+			TokenParams{
+				Name:     "Dai Stablecoin",
+				Ticker:   "DAI",
+				Contract: "0x6B175474E89094C44Da98b954EedeAC495271d0F",
+				Decimals: 18,
+				Accuracy: 9,
+			},
+		},
+	}
+	// EthereumDevNetParams local development network, e.g. geth --dev or the plugin stub node
+	EthereumDevNetParams = EthereumParams{
+		Name:             EtherName + " DevNet",
+		Ticker:           EtherTicker,
+		NetType:          "DevNet",
+		ChainID:          1337,
+		Bip44CoinType:    60,
+		Accuracy:         9,
+		DefaultGasPrice:  1000000000,
+		TransferGas:      21000,
+		TokenTransferGas: 100000,
+		Tokens: []TokenParams{
+			TokenParams{
+				Name:     "Test Token",
+				Ticker:   "TST",
+				Contract: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+				Decimals: 18,
+				Accuracy: 9,
+			},
+		},
+	}
+)
@@ -0,0 +1,530 @@
+// Package rpcstub implements an in-memory Ethereum JSON-RPC server holding ether
+// and ERC-20 token balances so that Ethereum plugin features can be tested without a dev node
+package rpcstub
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/ethereum/types"
+)
+
+// JSON-RPC error codes returned by geth
+const (
+	ErrCodeMethodNotFound = -32601
+	ErrCodeInvalidParams  = -32602
+	ErrCodeServer         = -32000
+)
+
+// tokenTransferGas gas estimated for token transfers
+const tokenTransferGas = 52000
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type block struct {
+	hash   types.Hash
+	time   int64
+	txns   []types.Hash
+	number uint64
+}
+
+type storedTxn struct {
+	tx   *types.Transaction
+	from types.Address
+	// block number, nil while pending
+	block *uint64
+	// status of execution once mined
+	success bool
+}
+
+// Server simulates an Ethereum node charging the whole gas limit of transactions.
+// Token contracts of network params are emulated natively.
+type Server struct {
+	// GasPrice returned by eth_gasPrice in wei
+	GasPrice *big.Int
+	// Peers returned by admin_peers, method is not available if nil
+	Peers []map[string]interface{}
+
+	params   params.EthereumParams
+	mutex    sync.Mutex
+	blocks   []block
+	balances map[types.Address]*big.Int
+	nonces   map[types.Address]uint64
+	tokens   map[types.Address]map[types.Address]*big.Int
+	txns     map[types.Hash]*storedTxn
+	pending  []types.Hash
+}
+
+// NewServer instantiates stub node for network params with a genesis block
+func NewServer(p params.EthereumParams) *Server {
+	s := &Server{
+		GasPrice: new(big.Int).SetUint64(p.DefaultGasPrice),
+		params:   p,
+		balances: make(map[types.Address]*big.Int),
+		nonces:   make(map[types.Address]uint64),
+		tokens:   make(map[types.Address]map[types.Address]*big.Int),
+		txns:     make(map[types.Hash]*storedTxn),
+	}
+	for _, token := range p.Tokens {
+		contract, _ := types.ParseAddress(token.Contract)
+		s.tokens[contract] = make(map[types.Address]*big.Int)
+	}
+	s.addBlock(nil)
+	return s
+}
+
+func (s *Server) addBlock(txns []types.Hash) {
+	var b [8]byte
+	number := uint64(len(s.blocks))
+	binary.BigEndian.PutUint64(b[:], number)
+	var hash types.Hash
+	copy(hash[:], types.Keccak256([]byte("block"), b[:]))
+	s.blocks = append(s.blocks, block{hash: hash, time: time.Now().Unix(), txns: txns, number: number})
+}
+
+func (s *Server) height() uint64 {
+	return uint64(len(s.blocks) - 1)
+}
+
+func (s *Server) balance(addr types.Address) *big.Int {
+	if b, hasBalance := s.balances[addr]; hasBalance {
+		return b
+	}
+	return new(big.Int)
+}
+
+func (s *Server) tokenBalance(contract, holder types.Address) *big.Int {
+	if b, hasBalance := s.tokens[contract][holder]; hasBalance {
+		return b
+	}
+	return new(big.Int)
+}
+
+// Fund credits account with wei in a new block
+func (s *Server) Fund(addr types.Address, wei *big.Int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.balances[addr] = new(big.Int).Add(s.balance(addr), wei)
+	s.addBlock(nil)
+}
+
+// MintToken credits account with units of token issued by contract in a new block
+func (s *Server) MintToken(contract, addr types.Address, units *big.Int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, isToken := s.tokens[contract]; !isToken {
+		s.tokens[contract] = make(map[types.Address]*big.Int)
+	}
+	s.tokens[contract][addr] = new(big.Int).Add(s.tokenBalance(contract, addr), units)
+	s.addBlock(nil)
+}
+
+// Balance returns wei held by account
+func (s *Server) Balance(addr types.Address) *big.Int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return new(big.Int).Set(s.balance(addr))
+}
+
+// TokenBalance returns units of token issued by contract held by account
+func (s *Server) TokenBalance(contract, addr types.Address) *big.Int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return new(big.Int).Set(s.tokenBalance(contract, addr))
+}
+
+// Pending lists hashes of transactions accepted but not mined yet
+func (s *Server) Pending() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	hashes := make([]string, len(s.pending))
+	for i, hash := range s.pending {
+		hashes[i] = hash.Hex()
+	}
+	return hashes
+}
+
+// Mine executes pending transactions in a new block
+func (s *Server) Mine() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	number := s.height() + 1
+	for _, hash := range s.pending {
+		st := s.txns[hash]
+		tx := st.tx
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas), tx.GasPrice)
+		s.balances[st.from] = new(big.Int).Sub(s.balance(st.from), fee)
+		s.nonces[st.from]++
+		st.block = &number
+		st.success = s.execute(st.from, tx)
+	}
+	s.addBlock(s.pending)
+	s.pending = nil
+}
+
+// execute applies value transfer and token transfer calls, returns false if reverted
+func (s *Server) execute(from types.Address, tx *types.Transaction) bool {
+	if s.balance(from).Cmp(tx.Value) < 0 {
+		return false
+	}
+	s.balances[from] = new(big.Int).Sub(s.balance(from), tx.Value)
+	s.balances[*tx.To] = new(big.Int).Add(s.balance(*tx.To), tx.Value)
+	holders, isToken := s.tokens[*tx.To]
+	if !isToken || len(tx.Data) == 0 {
+		return true
+	}
+	recipient, amount, isTransfer := types.ParseERC20TransferData(tx.Data)
+	if !isTransfer || s.tokenBalance(*tx.To, from).Cmp(amount) < 0 {
+		return false
+	}
+	holders[from] = new(big.Int).Sub(s.tokenBalance(*tx.To, from), amount)
+	holders[recipient] = new(big.Int).Add(s.tokenBalance(*tx.To, recipient), amount)
+	return true
+}
+
+// ServeHTTP dispatches JSON-RPC requests
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.mutex.Lock()
+	result, rpcErr := s.dispatch(req.Method, req.Params)
+	s.mutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	resp := map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      req.ID,
+	}
+	if rpcErr != nil {
+		resp["error"] = rpcErr
+	} else {
+		resp["result"] = result
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func invalidParams() *rpcError {
+	return &rpcError{Code: ErrCodeInvalidParams, Message: "invalid argument"}
+}
+
+func parseAddressArg(arg json.RawMessage) (types.Address, *rpcError) {
+	var addrStr string
+	if json.Unmarshal(arg, &addrStr) != nil {
+		return types.Address{}, invalidParams()
+	}
+	addr, err := types.ParseAddress(addrStr)
+	if err != nil {
+		return types.Address{}, invalidParams()
+	}
+	return addr, nil
+}
+
+type callArg struct {
+	From  *string `json:"from"`
+	To    *string `json:"to"`
+	Value *string `json:"value"`
+	Data  *string `json:"data"`
+}
+
+func (s *Server) dispatch(method string, args []json.RawMessage) (interface{}, *rpcError) {
+	switch method {
+	case "eth_chainId":
+		return types.EncodeUint64(s.params.ChainID), nil
+	case "eth_blockNumber":
+		return types.EncodeUint64(s.height()), nil
+	case "eth_gasPrice":
+		return types.EncodeBig(s.GasPrice), nil
+	case "eth_getBalance":
+		if len(args) < 1 {
+			return nil, invalidParams()
+		}
+		addr, rpcErr := parseAddressArg(args[0])
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		return types.EncodeBig(s.balance(addr)), nil
+	case "eth_getTransactionCount":
+		return s.getTransactionCount(args)
+	case "eth_estimateGas":
+		return s.estimateGas(args)
+	case "eth_call":
+		return s.call(args)
+	case "eth_sendRawTransaction":
+		return s.sendRawTransaction(args)
+	case "eth_getTransactionByHash":
+		return s.getTransactionByHash(args)
+	case "eth_getTransactionReceipt":
+		return s.getTransactionReceipt(args)
+	case "eth_getBlockByNumber":
+		return s.getBlockByNumber(args)
+	case "net_peerCount":
+		return types.EncodeUint64(uint64(len(s.Peers))), nil
+	case "admin_peers":
+		if s.Peers == nil {
+			break
+		}
+		return s.Peers, nil
+	}
+	return nil, &rpcError{Code: ErrCodeMethodNotFound, Message: "the method " + method + " does not exist/is not available"}
+}
+
+// pendingNonce returns next nonce of account accounting for pending transactions
+func (s *Server) pendingNonce(addr types.Address) uint64 {
+	nonce := s.nonces[addr]
+	for _, hash := range s.pending {
+		if s.txns[hash].from == addr {
+			nonce++
+		}
+	}
+	return nonce
+}
+
+func (s *Server) getTransactionCount(args []json.RawMessage) (interface{}, *rpcError) {
+	var tag string
+	if len(args) < 2 || json.Unmarshal(args[1], &tag) != nil {
+		return nil, invalidParams()
+	}
+	addr, rpcErr := parseAddressArg(args[0])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if tag == "pending" {
+		return types.EncodeUint64(s.pendingNonce(addr)), nil
+	}
+	return types.EncodeUint64(s.nonces[addr]), nil
+}
+
+func (s *Server) parseCall(arg json.RawMessage) (*types.Address, []byte, *rpcError) {
+	var call callArg
+	if json.Unmarshal(arg, &call) != nil || call.To == nil {
+		return nil, nil, invalidParams()
+	}
+	to, err := types.ParseAddress(*call.To)
+	if err != nil {
+		return nil, nil, invalidParams()
+	}
+	var data []byte
+	if call.Data != nil {
+		if data, err = types.DecodeHex(*call.Data); err != nil {
+			return nil, nil, invalidParams()
+		}
+	}
+	return &to, data, nil
+}
+
+func (s *Server) estimateGas(args []json.RawMessage) (interface{}, *rpcError) {
+	if len(args) < 1 {
+		return nil, invalidParams()
+	}
+	to, data, rpcErr := s.parseCall(args[0])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if len(data) == 0 {
+		return types.EncodeUint64(s.params.TransferGas), nil
+	}
+	if _, isToken := s.tokens[*to]; isToken {
+		if _, _, isTransfer := types.ParseERC20TransferData(data); isTransfer {
+			return types.EncodeUint64(tokenTransferGas), nil
+		}
+	}
+	return nil, &rpcError{Code: ErrCodeServer, Message: "execution reverted"}
+}
+
+func (s *Server) call(args []json.RawMessage) (interface{}, *rpcError) {
+	if len(args) < 1 {
+		return nil, invalidParams()
+	}
+	to, data, rpcErr := s.parseCall(args[0])
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	holders, isToken := s.tokens[*to]
+	if !isToken {
+		return "0x", nil
+	}
+	if holder, isBalanceOf := types.ParseERC20BalanceOfData(data); isBalanceOf {
+		return types.EncodeHex(types.EncodeUint256(s.tokenBalance(*to, holder))), nil
+	}
+	if types.IsERC20TotalSupplyData(data) {
+		supply := new(big.Int)
+		for _, units := range holders {
+			supply.Add(supply, units)
+		}
+		return types.EncodeHex(types.EncodeUint256(supply)), nil
+	}
+	return nil, &rpcError{Code: ErrCodeServer, Message: "execution reverted"}
+}
+
+func (s *Server) sendRawTransaction(args []json.RawMessage) (interface{}, *rpcError) {
+	var rawHex string
+	if len(args) < 1 || json.Unmarshal(args[0], &rawHex) != nil {
+		return nil, invalidParams()
+	}
+	raw, err := types.DecodeHex(rawHex)
+	if err != nil {
+		return nil, invalidParams()
+	}
+	tx, err := types.DecodeTransaction(raw)
+	if err != nil {
+		return nil, &rpcError{Code: ErrCodeServer, Message: "rlp: " + err.Error()}
+	}
+	from, err := tx.Sender(s.params.ChainID)
+	if err != nil {
+		return nil, &rpcError{Code: ErrCodeServer, Message: "invalid sender"}
+	}
+	if tx.To == nil {
+		return nil, &rpcError{Code: ErrCodeServer, Message: "contract creation not supported"}
+	}
+	hash, err := tx.Hash()
+	if err != nil {
+		return nil, &rpcError{Code: ErrCodeServer, Message: "rlp: " + err.Error()}
+	}
+	if _, isKnown := s.txns[hash]; isKnown {
+		return nil, &rpcError{Code: ErrCodeServer, Message: "already known"}
+	}
+	nonce := s.pendingNonce(from)
+	if tx.Nonce < nonce {
+		return nil, &rpcError{Code: ErrCodeServer, Message: "nonce too low"}
+	}
+	if tx.Nonce > nonce {
+		return nil, &rpcError{Code: ErrCodeServer, Message: "nonce too high"}
+	}
+	if tx.Gas < s.params.TransferGas {
+		return nil, &rpcError{Code: ErrCodeServer, Message: "intrinsic gas too low"}
+	}
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas), tx.GasPrice)
+	cost.Add(cost, tx.Value)
+	if s.balance(from).Cmp(cost) < 0 {
+		return nil, &rpcError{Code: ErrCodeServer, Message: "insufficient funds for gas * price + value"}
+	}
+	s.txns[hash] = &storedTxn{tx: tx, from: from}
+	s.pending = append(s.pending, hash)
+	return hash.Hex(), nil
+}
+
+func (s *Server) formatTxn(hash types.Hash, st *storedTxn) map[string]interface{} {
+	tx := st.tx
+	var blockNumber interface{}
+	if st.block != nil {
+		blockNumber = types.EncodeUint64(*st.block)
+	}
+	var to interface{}
+	if tx.To != nil {
+		to = tx.To.Hex()
+	}
+	return map[string]interface{}{
+		"hash":        hash.Hex(),
+		"blockNumber": blockNumber,
+		"from":        st.from.Hex(),
+		"to":          to,
+		"nonce":       types.EncodeUint64(tx.Nonce),
+		"gas":         types.EncodeUint64(tx.Gas),
+		"gasPrice":    types.EncodeBig(tx.GasPrice),
+		"value":       types.EncodeBig(tx.Value),
+		"input":       types.EncodeHex(tx.Data),
+		"v":           types.EncodeBig(tx.V),
+		"r":           types.EncodeBig(tx.R),
+		"s":           types.EncodeBig(tx.S),
+	}
+}
+
+func (s *Server) lookupTxn(args []json.RawMessage) (types.Hash, *storedTxn, *rpcError) {
+	var hashStr string
+	if len(args) < 1 || json.Unmarshal(args[0], &hashStr) != nil {
+		return types.Hash{}, nil, invalidParams()
+	}
+	hash, err := types.ParseHash(hashStr)
+	if err != nil {
+		return types.Hash{}, nil, invalidParams()
+	}
+	return hash, s.txns[hash], nil
+}
+
+func (s *Server) getTransactionByHash(args []json.RawMessage) (interface{}, *rpcError) {
+	hash, st, rpcErr := s.lookupTxn(args)
+	if rpcErr != nil || st == nil {
+		return nil, rpcErr
+	}
+	return s.formatTxn(hash, st), nil
+}
+
+func (s *Server) getTransactionReceipt(args []json.RawMessage) (interface{}, *rpcError) {
+	hash, st, rpcErr := s.lookupTxn(args)
+	if rpcErr != nil || st == nil || st.block == nil {
+		return nil, rpcErr
+	}
+	status := "0x0"
+	if st.success {
+		status = "0x1"
+	}
+	return map[string]interface{}{
+		"transactionHash": hash.Hex(),
+		"blockNumber":     types.EncodeUint64(*st.block),
+		"gasUsed":         types.EncodeUint64(st.tx.Gas),
+		"status":          status,
+	}, nil
+}
+
+func (s *Server) getBlockByNumber(args []json.RawMessage) (interface{}, *rpcError) {
+	var tag string
+	full := false
+	if len(args) < 1 || json.Unmarshal(args[0], &tag) != nil {
+		return nil, invalidParams()
+	}
+	if len(args) > 1 && json.Unmarshal(args[1], &full) != nil {
+		return nil, invalidParams()
+	}
+	var b block
+	switch tag {
+	case "latest":
+		b = s.blocks[s.height()]
+	case "pending":
+		b = block{number: s.height() + 1, time: time.Now().Unix(), txns: s.pending}
+	case "earliest":
+		b = s.blocks[0]
+	default:
+		number, err := types.DecodeUint64(tag)
+		if err != nil {
+			return nil, invalidParams()
+		}
+		if number > s.height() {
+			return nil, nil
+		}
+		b = s.blocks[number]
+	}
+	txns := make([]interface{}, len(b.txns))
+	for i, hash := range b.txns {
+		if full {
+			txns[i] = s.formatTxn(hash, s.txns[hash])
+		} else {
+			txns[i] = hash.Hex()
+		}
+	}
+	result := map[string]interface{}{
+		"number":       types.EncodeUint64(b.number),
+		"hash":         b.hash.Hex(),
+		"timestamp":    types.EncodeUint64(uint64(b.time)),
+		"transactions": txns,
+	}
+	if b.number > 0 {
+		result["parentHash"] = s.blocks[b.number-1].hash.Hex()
+	} else {
+		result["parentHash"] = types.Hash{}.Hex()
+	}
+	return result, nil
+}
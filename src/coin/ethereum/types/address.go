@@ -0,0 +1,162 @@
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/SkycoinProject/skycoin/src/cipher/secp256k1-go"
+)
+
+const (
+	// AddressLength number of bytes in account addresses
+	AddressLength = 20
+	// HashLength number of bytes in transaction and block hashes
+	HashLength = 32
+)
+
+var (
+	// ErrInvalidAddress address string is not 20 bytes hex-encoded or fails EIP-55 checksum
+	ErrInvalidAddress = errors.New("Invalid Ethereum address")
+	// ErrInvalidHex string is not a 0x prefixed hex quantity or data
+	ErrInvalidHex = errors.New("Invalid hex string")
+)
+
+// Address identifies an externally owned account or a contract
+type Address [AddressLength]byte
+
+// ParseAddress decodes 0x prefixed hex address, verifying EIP-55 checksum of mixed-case strings
+func ParseAddress(s string) (Address, error) {
+	var addr Address
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return addr, ErrInvalidAddress
+	}
+	b, err := hex.DecodeString(s[2:])
+	if err != nil || len(b) != AddressLength {
+		return addr, ErrInvalidAddress
+	}
+	copy(addr[:], b)
+	body := s[2:]
+	if strings.ToLower(body) != body && strings.ToUpper(body) != body && addr.Hex() != "0x"+body {
+		return addr, ErrInvalidAddress
+	}
+	return addr, nil
+}
+
+// PubKeyToAddress derives account address of compressed or uncompressed secp256k1 public key
+func PubKeyToAddress(pubkey []byte) (Address, error) {
+	var addr Address
+	if len(pubkey) == 33 {
+		if secp256k1.VerifyPubkey(pubkey) != 1 {
+			return addr, ErrInvalidAddress
+		}
+		pubkey = secp256k1.UncompressPubkey(pubkey)
+	}
+	if len(pubkey) != 65 {
+		return addr, ErrInvalidAddress
+	}
+	copy(addr[:], Keccak256(pubkey[1:])[12:])
+	return addr, nil
+}
+
+// Hex returns EIP-55 mixed-case checksum encoding
+func (addr Address) Hex() string {
+	lower := hex.EncodeToString(addr[:])
+	hash := Keccak256([]byte(lower))
+	checksummed := []byte(lower)
+	for i, c := range checksummed {
+		if c < 'a' {
+			continue
+		}
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if nibble&0x0f >= 8 {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(checksummed)
+}
+
+// String implements fmt.Stringer
+func (addr Address) String() string {
+	return addr.Hex()
+}
+
+// Hash identifies transactions and blocks
+type Hash [HashLength]byte
+
+// ParseHash decodes 0x prefixed hex hash
+func ParseHash(s string) (Hash, error) {
+	var h Hash
+	b, err := DecodeHex(s)
+	if err != nil || len(b) != HashLength {
+		return h, ErrInvalidHex
+	}
+	copy(h[:], b)
+	return h, nil
+}
+
+// Hex returns 0x prefixed hex encoding
+func (h Hash) Hex() string {
+	return EncodeHex(h[:])
+}
+
+// String implements fmt.Stringer
+func (h Hash) String() string {
+	return h.Hex()
+}
+
+// EncodeHex encodes data as 0x prefixed hex string
+func EncodeHex(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+// DecodeHex decodes 0x prefixed hex data
+func DecodeHex(s string) ([]byte, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return nil, ErrInvalidHex
+	}
+	b, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil, ErrInvalidHex
+	}
+	return b, nil
+}
+
+// EncodeBig encodes quantity as 0x prefixed hex number without leading zeros
+func EncodeBig(n *big.Int) string {
+	if n == nil || n.Sign() == 0 {
+		return "0x0"
+	}
+	return "0x" + n.Text(16)
+}
+
+// EncodeUint64 encodes quantity as 0x prefixed hex number without leading zeros
+func EncodeUint64(n uint64) string {
+	return "0x" + strconv.FormatUint(n, 16)
+}
+
+// DecodeBig decodes 0x prefixed hex quantity
+func DecodeBig(s string) (*big.Int, error) {
+	if len(s) < 3 || (!strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X")) {
+		return nil, ErrInvalidHex
+	}
+	n, ok := new(big.Int).SetString(s[2:], 16)
+	if !ok || n.Sign() < 0 {
+		return nil, ErrInvalidHex
+	}
+	return n, nil
+}
+
+// DecodeUint64 decodes 0x prefixed hex quantity of at most 64 bits
+func DecodeUint64(s string) (uint64, error) {
+	n, err := DecodeBig(s)
+	if err != nil || !n.IsUint64() {
+		return 0, ErrInvalidHex
+	}
+	return n.Uint64(), nil
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"bytes"
+	"math/big"
+)
+
+// ERC-20 method selectors, i.e. first four bytes of Keccak-256 of method signatures
+var (
+	erc20Transfer    = []byte{0xa9, 0x05, 0x9c, 0xbb}
+	erc20BalanceOf   = []byte{0x70, 0xa0, 0x82, 0x31}
+	erc20TotalSupply = []byte{0x18, 0x16, 0x0d, 0xdd}
+)
+
+func abiWord(b []byte) []byte {
+	word := make([]byte, 32)
+	copy(word[32-len(b):], b)
+	return word
+}
+
+// ERC20TransferData encodes call data of transfer(address,uint256)
+func ERC20TransferData(to Address, amount *big.Int) []byte {
+	data := append([]byte(nil), erc20Transfer...)
+	data = append(data, abiWord(to[:])...)
+	return append(data, abiWord(amount.Bytes())...)
+}
+
+// ParseERC20TransferData decodes recipient and amount of transfer(address,uint256) call data
+func ParseERC20TransferData(data []byte) (Address, *big.Int, bool) {
+	var to Address
+	if len(data) != 4+64 || !bytes.Equal(data[:4], erc20Transfer) {
+		return to, nil, false
+	}
+	if !bytes.Equal(data[4:16], make([]byte, 12)) {
+		return to, nil, false
+	}
+	copy(to[:], data[16:36])
+	return to, new(big.Int).SetBytes(data[36:68]), true
+}
+
+// ERC20BalanceOfData encodes call data of balanceOf(address)
+func ERC20BalanceOfData(holder Address) []byte {
+	return append(append([]byte(nil), erc20BalanceOf...), abiWord(holder[:])...)
+}
+
+// ParseERC20BalanceOfData decodes holder of balanceOf(address) call data
+func ParseERC20BalanceOfData(data []byte) (Address, bool) {
+	var holder Address
+	if len(data) != 4+32 || !bytes.Equal(data[:4], erc20BalanceOf) {
+		return holder, false
+	}
+	copy(holder[:], data[16:36])
+	return holder, true
+}
+
+// ERC20TotalSupplyData encodes call data of totalSupply()
+func ERC20TotalSupplyData() []byte {
+	return append([]byte(nil), erc20TotalSupply...)
+}
+
+// IsERC20TotalSupplyData determines whether call data invokes totalSupply()
+func IsERC20TotalSupplyData(data []byte) bool {
+	return bytes.Equal(data, erc20TotalSupply)
+}
+
+// EncodeUint256 ABI encodes unsigned integer as a single word
+func EncodeUint256(n *big.Int) []byte {
+	return abiWord(n.Bytes())
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Legacy Keccak as used by Ethereum, i.e. before NIST SHA-3 padding changes
+const keccak256Rate = 136
+
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+var keccakRotations = [24]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+
+var keccakPiLanes = [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+
+func keccakF1600(st *[25]uint64) {
+	var bc [5]uint64
+	for round := 0; round < 24; round++ {
+		// Theta
+		for i := 0; i < 5; i++ {
+			bc[i] = st[i] ^ st[i+5] ^ st[i+10] ^ st[i+15] ^ st[i+20]
+		}
+		for i := 0; i < 5; i++ {
+			t := bc[(i+4)%5] ^ bits.RotateLeft64(bc[(i+1)%5], 1)
+			for j := 0; j < 25; j += 5 {
+				st[j+i] ^= t
+			}
+		}
+		// Rho and pi
+		t := st[1]
+		for i := 0; i < 24; i++ {
+			j := keccakPiLanes[i]
+			bc[0] = st[j]
+			st[j] = bits.RotateLeft64(t, keccakRotations[i])
+			t = bc[0]
+		}
+		// Chi
+		for j := 0; j < 25; j += 5 {
+			for i := 0; i < 5; i++ {
+				bc[i] = st[j+i]
+			}
+			for i := 0; i < 5; i++ {
+				st[j+i] ^= (^bc[(i+1)%5]) & bc[(i+2)%5]
+			}
+		}
+		// Iota
+		st[0] ^= keccakRoundConstants[round]
+	}
+}
+
+// Keccak256 computes legacy Keccak-256 digest of data chunks
+func Keccak256(data ...[]byte) []byte {
+	var msg []byte
+	for _, chunk := range data {
+		msg = append(msg, chunk...)
+	}
+	// Pad with Keccak domain bits up to a multiple of rate
+	padded := make([]byte, (len(msg)/keccak256Rate+1)*keccak256Rate)
+	copy(padded, msg)
+	padded[len(msg)] ^= 0x01
+	padded[len(padded)-1] ^= 0x80
+
+	var st [25]uint64
+	for off := 0; off < len(padded); off += keccak256Rate {
+		for i := 0; i < keccak256Rate/8; i++ {
+			st[i] ^= binary.LittleEndian.Uint64(padded[off+8*i:])
+		}
+		keccakF1600(&st)
+	}
+	out := make([]byte, 32)
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(out[8*i:], st[i])
+	}
+	return out
+}
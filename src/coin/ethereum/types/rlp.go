@@ -0,0 +1,142 @@
+package types
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	// ErrRLPDecode input is not a valid canonical RLP encoding
+	ErrRLPDecode = errors.New("Invalid RLP encoding")
+	// ErrRLPEncode item is neither a byte string, an unsigned integer nor a list thereof
+	ErrRLPEncode = errors.New("Unsupported RLP item")
+)
+
+// EncodeRLP serializes byte strings, unsigned integers and lists thereof
+func EncodeRLP(item interface{}) ([]byte, error) {
+	switch v := item.(type) {
+	case []byte:
+		if len(v) == 1 && v[0] < 0x80 {
+			return []byte{v[0]}, nil
+		}
+		return append(rlpHeader(0x80, len(v)), v...), nil
+	case string:
+		return EncodeRLP([]byte(v))
+	case uint64:
+		return EncodeRLP(new(big.Int).SetUint64(v))
+	case *big.Int:
+		if v == nil {
+			return EncodeRLP([]byte{})
+		}
+		if v.Sign() < 0 {
+			return nil, ErrRLPEncode
+		}
+		return EncodeRLP(v.Bytes())
+	case []interface{}:
+		var payload []byte
+		for _, elem := range v {
+			b, err := EncodeRLP(elem)
+			if err != nil {
+				return nil, err
+			}
+			payload = append(payload, b...)
+		}
+		return append(rlpHeader(0xc0, len(payload)), payload...), nil
+	}
+	return nil, ErrRLPEncode
+}
+
+func rlpHeader(offset byte, size int) []byte {
+	if size < 56 {
+		return []byte{offset + byte(size)}
+	}
+	sizeBytes := new(big.Int).SetUint64(uint64(size)).Bytes()
+	return append([]byte{offset + 55 + byte(len(sizeBytes))}, sizeBytes...)
+}
+
+// DecodeRLP parses a single item, yielding []byte for strings and []interface{} for lists
+func DecodeRLP(b []byte) (interface{}, error) {
+	item, rest, err := decodeRLPItem(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, ErrRLPDecode
+	}
+	return item, nil
+}
+
+func decodeRLPItem(b []byte) (interface{}, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, ErrRLPDecode
+	}
+	prefix := b[0]
+	switch {
+	case prefix < 0x80:
+		return []byte{prefix}, b[1:], nil
+	case prefix < 0xc0:
+		payload, rest, err := rlpPayload(b, 0x80)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(payload) == 1 && payload[0] < 0x80 {
+			return nil, nil, ErrRLPDecode
+		}
+		return payload, rest, nil
+	}
+	payload, rest, err := rlpPayload(b, 0xc0)
+	if err != nil {
+		return nil, nil, err
+	}
+	list := make([]interface{}, 0)
+	for len(payload) > 0 {
+		var elem interface{}
+		if elem, payload, err = decodeRLPItem(payload); err != nil {
+			return nil, nil, err
+		}
+		list = append(list, elem)
+	}
+	return list, rest, nil
+}
+
+func rlpPayload(b []byte, offset byte) ([]byte, []byte, error) {
+	prefix := b[0] - offset
+	start, size := 1, 0
+	if prefix < 56 {
+		size = int(prefix)
+	} else {
+		sizeLen := int(prefix - 55)
+		if len(b) < 1+sizeLen || b[1] == 0 || sizeLen > 4 {
+			return nil, nil, ErrRLPDecode
+		}
+		for _, c := range b[1 : 1+sizeLen] {
+			size = size<<8 | int(c)
+		}
+		if size < 56 {
+			return nil, nil, ErrRLPDecode
+		}
+		start += sizeLen
+	}
+	if len(b) < start+size {
+		return nil, nil, ErrRLPDecode
+	}
+	return b[start : start+size], b[start+size:], nil
+}
+
+// rlpBigInt interprets decoded item as a canonical unsigned integer
+func rlpBigInt(item interface{}) (*big.Int, error) {
+	b, isBytes := item.([]byte)
+	if !isBytes || (len(b) > 0 && b[0] == 0) || len(b) > 32 {
+		return nil, ErrRLPDecode
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// rlpUint64 interprets decoded item as a canonical unsigned integer of at most 64 bits
+func rlpUint64(item interface{}) (uint64, error) {
+	n, err := rlpBigInt(item)
+	if err != nil || !n.IsUint64() {
+		return 0, ErrRLPDecode
+	}
+	return n.Uint64(), nil
+}
@@ -0,0 +1,173 @@
+package types
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+)
+
+var (
+	// ErrInvalidSignature transaction signature is missing, malformed or signed for another chain
+	ErrInvalidSignature = errors.New("Invalid transaction signature")
+	// ErrInvalidTransaction transaction encoding does not match legacy transaction format
+	ErrInvalidTransaction = errors.New("Invalid transaction encoding")
+)
+
+// Transaction legacy Ethereum transaction, replay protected by EIP-155 once signed
+type Transaction struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	Gas      uint64
+	// To is nil for contract creation
+	To    *Address
+	Value *big.Int
+	Data  []byte
+	V     *big.Int
+	R     *big.Int
+	S     *big.Int
+}
+
+func (tx *Transaction) fields() []interface{} {
+	var to []byte
+	if tx.To != nil {
+		to = tx.To[:]
+	}
+	return []interface{}{tx.Nonce, tx.GasPrice, tx.Gas, to, tx.Value, tx.Data}
+}
+
+// IsSigned determines whether transaction carries a signature
+func (tx *Transaction) IsSigned() bool {
+	return tx.V != nil && tx.R != nil && tx.S != nil && tx.R.Sign() != 0 && tx.S.Sign() != 0
+}
+
+// SigningHash computes the EIP-155 digest signed by sender
+func (tx *Transaction) SigningHash(chainID uint64) (cipher.SHA256, error) {
+	var h cipher.SHA256
+	b, err := EncodeRLP(append(tx.fields(), chainID, uint64(0), uint64(0)))
+	if err != nil {
+		return h, err
+	}
+	copy(h[:], Keccak256(b))
+	return h, nil
+}
+
+// Bytes serializes transaction in RLP format accepted by eth_sendRawTransaction
+func (tx *Transaction) Bytes() ([]byte, error) {
+	return EncodeRLP(append(tx.fields(), tx.V, tx.R, tx.S))
+}
+
+// Hash computes transaction ID
+func (tx *Transaction) Hash() (Hash, error) {
+	var h Hash
+	b, err := tx.Bytes()
+	if err != nil {
+		return h, err
+	}
+	copy(h[:], Keccak256(b))
+	return h, nil
+}
+
+// Copy makes a deep copy of transaction
+func (tx *Transaction) Copy() *Transaction {
+	cpy := *tx
+	if tx.To != nil {
+		to := *tx.To
+		cpy.To = &to
+	}
+	copyBig := func(n *big.Int) *big.Int {
+		if n == nil {
+			return nil
+		}
+		return new(big.Int).Set(n)
+	}
+	cpy.GasPrice, cpy.Value = copyBig(tx.GasPrice), copyBig(tx.Value)
+	cpy.V, cpy.R, cpy.S = copyBig(tx.V), copyBig(tx.R), copyBig(tx.S)
+	cpy.Data = append([]byte(nil), tx.Data...)
+	return &cpy
+}
+
+// SetSignature stores compact recoverable signature with EIP-155 recovery ID
+func (tx *Transaction) SetSignature(sig cipher.Sig, chainID uint64) {
+	tx.R = new(big.Int).SetBytes(sig[:32])
+	tx.S = new(big.Int).SetBytes(sig[32:64])
+	tx.V = new(big.Int).SetUint64(uint64(sig[64]) + 35 + 2*chainID)
+}
+
+// Sender recovers address of account that signed transaction for chain
+func (tx *Transaction) Sender(chainID uint64) (Address, error) {
+	if !tx.IsSigned() || tx.R.BitLen() > 256 || tx.S.BitLen() > 256 {
+		return Address{}, ErrInvalidSignature
+	}
+	offset := new(big.Int).SetUint64(35 + 2*chainID)
+	recID := new(big.Int).Sub(tx.V, offset)
+	if recID.Sign() < 0 || recID.Cmp(big.NewInt(1)) > 0 {
+		return Address{}, ErrInvalidSignature
+	}
+	var sig cipher.Sig
+	tx.R.FillBytes(sig[:32])
+	tx.S.FillBytes(sig[32:64])
+	sig[64] = byte(recID.Uint64())
+	signingHash, err := tx.SigningHash(chainID)
+	if err != nil {
+		return Address{}, err
+	}
+	pk, err := cipher.PubKeyFromSig(sig, signingHash)
+	if err != nil {
+		return Address{}, ErrInvalidSignature
+	}
+	if cipher.VerifyPubKeySignedHash(pk, sig, signingHash) != nil {
+		return Address{}, ErrInvalidSignature
+	}
+	return PubKeyToAddress(pk[:])
+}
+
+// DecodeTransaction parses RLP encoded legacy transaction
+func DecodeTransaction(b []byte) (*Transaction, error) {
+	item, err := DecodeRLP(b)
+	if err != nil {
+		return nil, err
+	}
+	fields, isList := item.([]interface{})
+	if !isList || len(fields) != 9 {
+		return nil, ErrInvalidTransaction
+	}
+	for _, field := range fields {
+		if _, isBytes := field.([]byte); !isBytes {
+			return nil, ErrInvalidTransaction
+		}
+	}
+	tx := &Transaction{}
+	if tx.Nonce, err = rlpUint64(fields[0]); err != nil {
+		return nil, err
+	}
+	if tx.GasPrice, err = rlpBigInt(fields[1]); err != nil {
+		return nil, err
+	}
+	if tx.Gas, err = rlpUint64(fields[2]); err != nil {
+		return nil, err
+	}
+	switch to := fields[3].([]byte); len(to) {
+	case 0:
+	case AddressLength:
+		var addr Address
+		copy(addr[:], to)
+		tx.To = &addr
+	default:
+		return nil, ErrInvalidTransaction
+	}
+	if tx.Value, err = rlpBigInt(fields[4]); err != nil {
+		return nil, err
+	}
+	tx.Data = fields[5].([]byte)
+	if tx.V, err = rlpBigInt(fields[6]); err != nil {
+		return nil, err
+	}
+	if tx.R, err = rlpBigInt(fields[7]); err != nil {
+		return nil, err
+	}
+	if tx.S, err = rlpBigInt(fields[8]); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
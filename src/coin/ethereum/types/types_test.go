@@ -0,0 +1,138 @@
+package types
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeccak256(t *testing.T) {
+	tests := []struct {
+		input string
+		hash  string
+	}{
+		{"", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"abc", "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+		{"transfer(address,uint256)", "a9059cbb2ab09eb219583f4a59a5d0623ade346d962bcd4e46b11da047c9049b"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.hash, hex.EncodeToString(Keccak256([]byte(tt.input))))
+	}
+	// Input spanning several blocks
+	long := make([]byte, 300)
+	require.Equal(t, hex.EncodeToString(Keccak256(long)), hex.EncodeToString(Keccak256(long[:100], long[100:])))
+}
+
+func TestRLPRoundTrip(t *testing.T) {
+	item := []interface{}{[]byte("dog"), []interface{}{[]byte{}, []byte{0x7f}}, make([]byte, 60)}
+	b, err := EncodeRLP(item)
+	require.NoError(t, err)
+	decoded, err := DecodeRLP(b)
+	require.NoError(t, err)
+	require.Equal(t, item, decoded)
+
+	for _, tt := range []struct {
+		item    interface{}
+		encoded []byte
+	}{
+		{"dog", []byte{0x83, 'd', 'o', 'g'}},
+		{uint64(0), []byte{0x80}},
+		{uint64(1024), []byte{0x82, 0x04, 0x00}},
+	} {
+		b, err := EncodeRLP(tt.item)
+		require.NoError(t, err)
+		require.Equal(t, tt.encoded, b)
+	}
+	_, err = EncodeRLP(big.NewInt(-1))
+	require.Equal(t, ErrRLPEncode, err)
+	_, err = EncodeRLP([]interface{}{[]byte("dog"), 1.5})
+	require.Equal(t, ErrRLPEncode, err)
+
+	_, err = DecodeRLP([]byte{0x81, 0x05})
+	require.Equal(t, ErrRLPDecode, err)
+	_, err = DecodeRLP([]byte{0x83, 'd', 'o'})
+	require.Equal(t, ErrRLPDecode, err)
+}
+
+func TestParseAddress(t *testing.T) {
+	addr, err := ParseAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	require.NoError(t, err)
+	require.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", addr.Hex())
+	_, err = ParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	require.NoError(t, err)
+	_, err = ParseAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD")
+	require.Equal(t, ErrInvalidAddress, err)
+	_, err = ParseAddress("5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	require.Equal(t, ErrInvalidAddress, err)
+}
+
+func TestTransactionSender(t *testing.T) {
+	// EIP-155 example transaction
+	raw, err := hex.DecodeString("f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83")
+	require.NoError(t, err)
+	tx, err := DecodeTransaction(raw)
+	require.NoError(t, err)
+	require.Equal(t, uint64(9), tx.Nonce)
+	require.Equal(t, uint64(21000), tx.Gas)
+	signingHash, err := tx.SigningHash(1)
+	require.NoError(t, err)
+	require.Equal(t, "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53", hex.EncodeToString(signingHash[:]))
+	b, err := tx.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, raw, b)
+	sender, err := tx.Sender(1)
+	require.NoError(t, err)
+	require.Equal(t, "0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F", sender.Hex())
+	_, err = tx.Sender(3)
+	require.Error(t, err)
+}
+
+func TestTransactionSignRoundTrip(t *testing.T) {
+	pk, sk := cipher.GenerateKeyPair()
+	from, err := PubKeyToAddress(pk[:])
+	require.NoError(t, err)
+	to, err := ParseAddress("0x3535353535353535353535353535353535353535")
+	require.NoError(t, err)
+	tx := &Transaction{
+		Nonce:    1,
+		GasPrice: big.NewInt(1000000000),
+		Gas:      65000,
+		To:       &to,
+		Value:    big.NewInt(0),
+		Data:     ERC20TransferData(from, big.NewInt(42)),
+	}
+	require.False(t, tx.IsSigned())
+	signingHash, err := tx.SigningHash(1337)
+	require.NoError(t, err)
+	sig, err := cipher.SignHash(signingHash, sk)
+	require.NoError(t, err)
+	signed := tx.Copy()
+	signed.SetSignature(sig, 1337)
+	require.False(t, tx.IsSigned())
+
+	b, err := signed.Bytes()
+	require.NoError(t, err)
+	decoded, err := DecodeTransaction(b)
+	require.NoError(t, err)
+	signedHash, err := signed.Hash()
+	require.NoError(t, err)
+	decodedHash, err := decoded.Hash()
+	require.NoError(t, err)
+	require.Equal(t, signedHash, decodedHash)
+
+	// Negative amounts can not be serialized
+	signed.Value = big.NewInt(-1)
+	_, err = signed.Bytes()
+	require.Equal(t, ErrRLPEncode, err)
+	sender, err := decoded.Sender(1337)
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+
+	recipient, amount, isTransfer := ParseERC20TransferData(decoded.Data)
+	require.True(t, isTransfer)
+	require.Equal(t, from, recipient)
+	require.Equal(t, int64(42), amount.Int64())
+}
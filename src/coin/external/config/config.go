@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	local "github.com/fibercrypto/fibercryptowallet/src/main"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+const (
+	SectionName           = "external"
+	SettingPathToPlugins  = "plugins"
+	SettingPluginsDirPath = "directory"
+	SettingPluginsTimeout = "timeout"
+	DefaultPluginsTimeout = "30s"
+)
+
+var (
+	sectionManager *local.SectionManager
+	log            = logging.MustGetLogger("External plugins Config")
+)
+
+func getMultiPlatformUserDirectory() string {
+	usr, err := user.Current()
+	if err != nil {
+		log.WithError(err).Error()
+		return ""
+	}
+	return filepath.Join(usr.HomeDir, ".fibercryptowallet", "plugins")
+}
+
+// RegisterConfig registers default settings of out-of-process plugins
+func RegisterConfig() error {
+	cm := local.GetConfigManager()
+	plugins := map[string]string{
+		SettingPluginsDirPath: getMultiPlatformUserDirectory(),
+		SettingPluginsTimeout: DefaultPluginsTimeout,
+	}
+	pluginsBytes, err := json.Marshal(plugins)
+	if err != nil {
+		return err
+	}
+	pluginsOpt := local.NewOption(SettingPathToPlugins, []string{}, false, string(pluginsBytes))
+
+	sectionManager = cm.RegisterSection(SectionName, []*local.Option{pluginsOpt})
+	return nil
+}
+
+// GetOption reads value of setting at path
+func GetOption(path string) (string, error) {
+	stringList := strings.Split(path, "/")
+	return sectionManager.GetValue(stringList[len(stringList)-1], stringList[:len(stringList)-1])
+}
+
+// GetSettings decodes key value pairs stored at setting path
+func GetSettings(path string) (map[string]string, error) {
+	value, err := GetOption(path)
+	if err != nil {
+		return nil, err
+	}
+	settings := make(map[string]string)
+	if err := json.Unmarshal([]byte(value), &settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
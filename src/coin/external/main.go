@@ -0,0 +1,37 @@
+package external
+
+import (
+	"time"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/external/config"
+	external "github.com/fibercrypto/fibercryptowallet/src/coin/external/models"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logExternal = logging.MustGetLogger("External plugins")
+
+func init() {
+	UpdateAltcoins()
+}
+
+// UpdateAltcoins discovers plugins in configured directory and registers them
+func UpdateAltcoins() {
+	if err := config.RegisterConfig(); err != nil {
+		logExternal.Warn("Couldn't register external plugins configuration")
+		return
+	}
+	plugins, err := config.GetSettings(config.SettingPathToPlugins)
+	if err != nil {
+		logExternal.WithError(err).Warn("Couldn't get plugins settings")
+		return
+	}
+	timeout, err := time.ParseDuration(plugins[config.SettingPluginsTimeout])
+	if err != nil {
+		logExternal.WithError(err).Warn("Invalid plugin call timeout")
+		timeout = external.DefaultCallTimeout
+	}
+	for _, plugin := range external.LoadPlugins(plugins[config.SettingPluginsDirPath], timeout) {
+		util.RegisterAltcoin(plugin)
+	}
+}
@@ -0,0 +1,72 @@
+package external
+
+import (
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logAccount = logging.MustGetLogger("External account")
+
+// ExternalAccount crypto account of a wallet or an address managed by a plugin process
+type ExternalAccount struct { // Implements CryptoAccount interface
+	plugin *ExternalPlugin
+	args   AccountArgs
+}
+
+// GetBalance retrieves coins of asset represented by ticker that may be spent by this account
+func (acc *ExternalAccount) GetBalance(ticker string) (uint64, error) {
+	args := acc.args
+	args.Ticker = ticker
+	var balance uint64
+	if err := acc.plugin.call("Balance", args, &balance); err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
+
+// ListAssets to enumerate the tickers of all assets supported by this account
+func (acc *ExternalAccount) ListAssets() []string {
+	var assets []string
+	if err := acc.plugin.call("ListAssets", acc.args, &assets); err != nil {
+		logAccount.WithError(err).Error("Couldn't list account assets")
+		return nil
+	}
+	return assets
+}
+
+// ScanUnspentOutputs to determine the outputs that can participate in a transaction
+func (acc *ExternalAccount) ScanUnspentOutputs() (core.TransactionOutputIterator, error) {
+	var data []OutputData
+	if err := acc.plugin.call("UnspentOutputs", acc.args, &data); err != nil {
+		return nil, err
+	}
+	outs := make([]core.TransactionOutput, len(data))
+	for i, d := range data {
+		outs[i] = newExternalTransactionOutput(d, acc.plugin)
+	}
+	return NewExternalTransactionOutputIterator(outs), nil
+}
+
+// ListTransactions to show account history
+func (acc *ExternalAccount) ListTransactions() core.TransactionIterator {
+	var data []TxnData
+	if err := acc.plugin.call("Transactions", acc.args, &data); err != nil {
+		logAccount.WithError(err).Error("Couldn't list account transactions")
+		return nil
+	}
+	return newExternalTransactionIterator(data, acc.plugin)
+}
+
+// ListPendingTransactions to obtain details of transactions pending for confirmation
+func (acc *ExternalAccount) ListPendingTransactions() (core.TransactionIterator, error) {
+	var data []TxnData
+	if err := acc.plugin.call("PendingTransactions", acc.args, &data); err != nil {
+		return nil, err
+	}
+	return newExternalTransactionIterator(data, acc.plugin), nil
+}
+
+// Type assertions
+var (
+	_ core.CryptoAccount = &ExternalAccount{}
+)
@@ -0,0 +1,137 @@
+package external
+
+import (
+	"errors"
+
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+)
+
+func newAddressData(addr core.Address) *AddressData {
+	return &AddressData{
+		Address:  addr.String(),
+		Bytes:    addr.Bytes(),
+		Checksum: addr.Checksum(),
+		IsBip32:  addr.IsBip32(),
+		IsNull:   addr.Null(),
+	}
+}
+
+// newKeyData takes snapshot of public or private key, both sharing the same method set
+func newKeyData(key core.PubKey) KeyData {
+	data := KeyData{Bytes: key.Bytes(), IsNull: key.Null()}
+	if err := key.Verify(); err != nil {
+		data.VerifyErr = err.Error()
+	}
+	return data
+}
+
+// ExternalAddress snapshot of an address decoded by a plugin process
+type ExternalAddress struct {
+	data   AddressData
+	plugin *ExternalPlugin
+}
+
+// IsBip32 flag shall be set if address generation complies to BIP 32
+func (addr *ExternalAddress) IsBip32() bool {
+	return addr.data.IsBip32
+}
+
+// String return human-readable representation of this address
+func (addr *ExternalAddress) String() string {
+	return addr.data.Address
+}
+
+// GetCryptoAccount provides access to address transaction history
+func (addr *ExternalAddress) GetCryptoAccount() core.CryptoAccount {
+	return &ExternalAccount{plugin: addr.plugin, args: AccountArgs{Address: addr.data.Address}}
+}
+
+// Bytes binary representation for address
+func (addr *ExternalAddress) Bytes() []byte {
+	return addr.data.Bytes
+}
+
+// Checksum computes address consistency token
+func (addr *ExternalAddress) Checksum() core.Checksum {
+	return addr.data.Checksum
+}
+
+// Verify checks that the address appears valid for the public key
+func (addr *ExternalAddress) Verify(pk core.PubKey) error {
+	args := VerifyAddressArgs{Address: addr.data.Address, PubKey: pk.Bytes()}
+	return addr.plugin.call("VerifyAddress", args, &Empty{})
+}
+
+// Null returns true if the address is null
+func (addr *ExternalAddress) Null() bool {
+	return addr.data.IsNull
+}
+
+// ExternalKey snapshot of a public or private key decoded by a plugin process
+type ExternalKey struct {
+	data KeyData
+}
+
+// Verify checks that the key appears valid
+func (key *ExternalKey) Verify() error {
+	if key.data.VerifyErr != "" {
+		return errors.New(key.data.VerifyErr)
+	}
+	return nil
+}
+
+// Null returns true if the key is null
+func (key *ExternalKey) Null() bool {
+	return key.data.IsNull
+}
+
+// Bytes binary representation for key
+func (key *ExternalKey) Bytes() []byte {
+	return key.data.Bytes
+}
+
+// ExternalAddressIterator iterates over a sequence of addresses
+type ExternalAddressIterator struct {
+	current   int
+	addresses []core.Address
+}
+
+// Value of address at iterator pointer position
+func (it *ExternalAddressIterator) Value() core.Address {
+	return it.addresses[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *ExternalAddressIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *ExternalAddressIterator) HasNext() bool {
+	return (it.current + 1) < len(it.addresses)
+}
+
+// NewExternalAddressIterator instantiates iterator over addresses
+func NewExternalAddressIterator(addresses []core.Address) *ExternalAddressIterator {
+	return &ExternalAddressIterator{addresses: addresses, current: -1}
+}
+
+func newExternalAddressIterator(data []AddressData, plugin *ExternalPlugin) *ExternalAddressIterator {
+	addrs := make([]core.Address, len(data))
+	for i, d := range data {
+		addrs[i] = &ExternalAddress{data: d, plugin: plugin}
+	}
+	return NewExternalAddressIterator(addrs)
+}
+
+// Type assertions
+var (
+	_ core.Address         = &ExternalAddress{}
+	_ core.PubKey          = &ExternalKey{}
+	_ core.SecKey          = &ExternalKey{}
+	_ core.AddressIterator = &ExternalAddressIterator{}
+)
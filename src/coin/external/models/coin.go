@@ -0,0 +1,310 @@
+package external
+
+import (
+	"errors"
+
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+func newCoins(assets []string, getCoins func(string) (uint64, error)) map[string]uint64 {
+	coins := make(map[string]uint64, len(assets))
+	for _, ticker := range assets {
+		if value, err := getCoins(ticker); err == nil {
+			coins[ticker] = value
+		}
+	}
+	return coins
+}
+
+func newOutputData(out core.TransactionOutput) OutputData {
+	data := OutputData{
+		Id:      out.GetId(),
+		IsSpent: out.IsSpent(),
+		Assets:  out.SupportedAssets(),
+	}
+	data.Coins = newCoins(data.Assets, out.GetCoins)
+	if addr, err := out.GetAddress(); err == nil && addr != nil {
+		data.Address = newAddressData(addr)
+	}
+	return data
+}
+
+func newInputData(in core.TransactionInput) InputData {
+	data := InputData{
+		Id:     in.GetId(),
+		Assets: in.SupportedAssets(),
+	}
+	data.Coins = newCoins(data.Assets, in.GetCoins)
+	if out, err := in.GetSpentOutput(); err == nil && out != nil {
+		spent := newOutputData(out)
+		data.SpentOutput = &spent
+	}
+	return data
+}
+
+func newTxnData(txn core.Transaction) TxnData {
+	data := TxnData{
+		Id:        txn.GetId(),
+		Status:    txn.GetStatus(),
+		Timestamp: txn.GetTimestamp(),
+		Assets:    txn.SupportedAssets(),
+	}
+	data.Fees = newCoins(data.Assets, txn.ComputeFee)
+	for _, in := range txn.GetInputs() {
+		data.Inputs = append(data.Inputs, newInputData(in))
+	}
+	for _, out := range txn.GetOutputs() {
+		data.Outputs = append(data.Outputs, newOutputData(out))
+	}
+	if err := txn.VerifyUnsigned(); err != nil {
+		data.VerifyUnsignedErr = err.Error()
+	}
+	if err := txn.VerifySigned(); err != nil {
+		data.VerifySignedErr = err.Error()
+	}
+	data.IsFullySigned, _ = txn.IsFullySigned()
+	return data
+}
+
+func lookupCoins(coins map[string]uint64, ticker string) (uint64, error) {
+	if value, isSupported := coins[ticker]; isSupported {
+		return value, nil
+	}
+	return 0, fce.ErrInvalidAltcoinTicker
+}
+
+// ExternalTransaction snapshot of a transaction handled by a plugin process
+type ExternalTransaction struct {
+	data   TxnData
+	plugin *ExternalPlugin
+}
+
+// SupportedAssets enumerates assets involved in transaction
+func (txn *ExternalTransaction) SupportedAssets() []string {
+	return txn.data.Assets
+}
+
+// GetTimestamp at the moment of creation
+func (txn *ExternalTransaction) GetTimestamp() core.Timestamp {
+	return txn.data.Timestamp
+}
+
+// GetStatus to retrieve transaction status
+func (txn *ExternalTransaction) GetStatus() core.TransactionStatus {
+	return txn.data.Status
+}
+
+// GetInputs to list transaction inputs
+func (txn *ExternalTransaction) GetInputs() []core.TransactionInput {
+	inputs := make([]core.TransactionInput, len(txn.data.Inputs))
+	for i, in := range txn.data.Inputs {
+		inputs[i] = &ExternalTransactionInput{data: in, plugin: txn.plugin}
+	}
+	return inputs
+}
+
+// GetOutputs to list transaction outputs
+func (txn *ExternalTransaction) GetOutputs() []core.TransactionOutput {
+	outputs := make([]core.TransactionOutput, len(txn.data.Outputs))
+	for i, out := range txn.data.Outputs {
+		outputs[i] = newExternalTransactionOutput(out, txn.plugin)
+	}
+	return outputs
+}
+
+// GetId to retrieve transaction ID
+func (txn *ExternalTransaction) GetId() string {
+	return txn.data.Id
+}
+
+// ComputeFee returns transaction fee expressed in coins of asset represented by ticker
+func (txn *ExternalTransaction) ComputeFee(ticker string) (uint64, error) {
+	return lookupCoins(txn.data.Fees, ticker)
+}
+
+// VerifyUnsigned checks for valid unsigned transaction
+func (txn *ExternalTransaction) VerifyUnsigned() error {
+	if txn.data.VerifyUnsignedErr != "" {
+		return errors.New(txn.data.VerifyUnsignedErr)
+	}
+	return nil
+}
+
+// VerifySigned checks for valid signed transaction
+func (txn *ExternalTransaction) VerifySigned() error {
+	if txn.data.VerifySignedErr != "" {
+		return errors.New(txn.data.VerifySignedErr)
+	}
+	return nil
+}
+
+// IsFullySigned determines whether all transaction elements have been signed
+func (txn *ExternalTransaction) IsFullySigned() (bool, error) {
+	return txn.data.IsFullySigned, nil
+}
+
+// handle returns the reference to transaction kept by plugin process
+func (txn *ExternalTransaction) handle(plugin *ExternalPlugin) (string, error) {
+	if txn.plugin != plugin || txn.data.Handle == "" {
+		return "", fce.ErrInvalidTxn
+	}
+	return txn.data.Handle, nil
+}
+
+// ExternalTransactionInput snapshot of a transaction input
+type ExternalTransactionInput struct {
+	data   InputData
+	plugin *ExternalPlugin
+}
+
+// GetId provides transaction input ID
+func (in *ExternalTransactionInput) GetId() string {
+	return in.data.Id
+}
+
+// GetSpentOutput returns the output spent by this input
+func (in *ExternalTransactionInput) GetSpentOutput() (core.TransactionOutput, error) {
+	if in.data.SpentOutput == nil {
+		return nil, fce.ErrNotFound
+	}
+	return newExternalTransactionOutput(*in.data.SpentOutput, in.plugin), nil
+}
+
+// GetCoins looks up coins of asset represented by ticker spent by this input
+func (in *ExternalTransactionInput) GetCoins(ticker string) (uint64, error) {
+	return lookupCoins(in.data.Coins, ticker)
+}
+
+// SupportedAssets enumerates assets spent by this input
+func (in *ExternalTransactionInput) SupportedAssets() []string {
+	return in.data.Assets
+}
+
+// ExternalTransactionOutput snapshot of a transaction output
+type ExternalTransactionOutput struct {
+	data OutputData
+	addr core.Address
+}
+
+func newExternalTransactionOutput(data OutputData, plugin *ExternalPlugin) *ExternalTransactionOutput {
+	out := &ExternalTransactionOutput{data: data}
+	if data.Address != nil {
+		out.addr = &ExternalAddress{data: *data.Address, plugin: plugin}
+	}
+	return out
+}
+
+// GetId provides transaction output ID
+func (out *ExternalTransactionOutput) GetId() string {
+	return out.data.Id
+}
+
+// IsSpent determines whether output was spent when snapshot was taken
+func (out *ExternalTransactionOutput) IsSpent() bool {
+	return out.data.IsSpent
+}
+
+// GetAddress returns the address of the party receiving funds
+func (out *ExternalTransactionOutput) GetAddress() (core.Address, error) {
+	if out.addr == nil {
+		return nil, fce.ErrNilValue
+	}
+	return out.addr, nil
+}
+
+// GetCoins looks up coins of asset represented by ticker transferred in this output
+func (out *ExternalTransactionOutput) GetCoins(ticker string) (uint64, error) {
+	return lookupCoins(out.data.Coins, ticker)
+}
+
+// SupportedAssets enumerates assets transferred in this output
+func (out *ExternalTransactionOutput) SupportedAssets() []string {
+	return out.data.Assets
+}
+
+// newOutputDataFrom prepares destination outputs to be sent to plugin process
+func newOutputDataFrom(outs []core.TransactionOutput) []OutputData {
+	data := make([]OutputData, len(outs))
+	for i, out := range outs {
+		data[i] = newOutputData(out)
+	}
+	return data
+}
+
+// ExternalTransactionIterator iterates over a sequence of transactions
+type ExternalTransactionIterator struct {
+	current int
+	txns    []core.Transaction
+}
+
+// Value of transaction at iterator pointer position
+func (it *ExternalTransactionIterator) Value() core.Transaction {
+	return it.txns[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *ExternalTransactionIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *ExternalTransactionIterator) HasNext() bool {
+	return (it.current + 1) < len(it.txns)
+}
+
+// NewExternalTransactionIterator instantiates iterator over transactions
+func NewExternalTransactionIterator(txns []core.Transaction) *ExternalTransactionIterator {
+	return &ExternalTransactionIterator{txns: txns, current: -1}
+}
+
+func newExternalTransactionIterator(data []TxnData, plugin *ExternalPlugin) *ExternalTransactionIterator {
+	txns := make([]core.Transaction, len(data))
+	for i, d := range data {
+		txns[i] = &ExternalTransaction{data: d, plugin: plugin}
+	}
+	return NewExternalTransactionIterator(txns)
+}
+
+// ExternalTransactionOutputIterator iterates over a sequence of transaction outputs
+type ExternalTransactionOutputIterator struct {
+	current int
+	outputs []core.TransactionOutput
+}
+
+// Value of output at iterator pointer position
+func (it *ExternalTransactionOutputIterator) Value() core.TransactionOutput {
+	return it.outputs[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *ExternalTransactionOutputIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *ExternalTransactionOutputIterator) HasNext() bool {
+	return (it.current + 1) < len(it.outputs)
+}
+
+// NewExternalTransactionOutputIterator instantiates iterator over outputs
+func NewExternalTransactionOutputIterator(outputs []core.TransactionOutput) *ExternalTransactionOutputIterator {
+	return &ExternalTransactionOutputIterator{outputs: outputs, current: -1}
+}
+
+// Type assertions
+var (
+	_ core.Transaction               = &ExternalTransaction{}
+	_ core.TransactionInput          = &ExternalTransactionInput{}
+	_ core.TransactionOutput         = &ExternalTransactionOutput{}
+	_ core.TransactionIterator       = &ExternalTransactionIterator{}
+	_ core.TransactionOutputIterator = &ExternalTransactionOutputIterator{}
+)
@@ -0,0 +1,158 @@
+package external
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/mocks"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// pipeTransport serves plugin in-process over an in-memory connection
+type pipeTransport struct {
+	plugin core.AltcoinPlugin
+	dials  int
+	last   net.Conn
+}
+
+func (t *pipeTransport) Dial() (io.ReadWriteCloser, error) {
+	host, remote := net.Pipe()
+	t.dials++
+	t.last = remote
+	go ServeConn(t.plugin, remote) // nolint errcheck
+	return host, nil
+}
+
+func (t *pipeTransport) String() string {
+	return "pipe"
+}
+
+func mockPlugin() (*mocks.AltcoinPlugin, *mocks.WalletSet) {
+	wlt := new(mocks.Wallet)
+	wlt.On("GetId").Return("w1")
+	wlt.On("GetLabel").Return("Main")
+	wltIt := new(mocks.WalletIterator)
+	wltIt.On("Next").Return(true).Once()
+	wltIt.On("Next").Return(false)
+	wltIt.On("Value").Return(wlt)
+
+	wltSet := new(mocks.WalletSet)
+	wltSet.On("ListWallets").Return(wltIt)
+	wltSet.On("GetWallet", "w1").Return(wlt)
+	wltSet.On("GetWallet", "w2").Return(nil)
+	wltEnv := new(mocks.WalletEnv)
+	wltEnv.On("LookupWallet", "2bad").Return(nil, fce.ErrWltFromAddrNotFound)
+	wltEnv.On("GetWalletSet").Return(wltSet)
+
+	plugin := new(mocks.AltcoinPlugin)
+	plugin.On("GetName").Return("Mock")
+	plugin.On("GetDescription").Return("Mock plugin")
+	plugin.On("ListSupportedFamilies").Return([]string{"MCK"})
+	plugin.On("ListSupportedAltcoins").Return([]core.AltcoinMetadata{{Name: "Mockcoin", Ticker: "MCK", Family: "MCK"}})
+	plugin.On("LoadWalletEnvs").Return([]core.WalletEnv{wltEnv})
+	return plugin, wltSet
+}
+
+func TestExternalPluginDescribe(t *testing.T) {
+	plugin, _ := mockPlugin()
+	p, err := NewExternalPlugin(NewConnection(&pipeTransport{plugin: plugin}, time.Second))
+	require.NoError(t, err)
+
+	require.Equal(t, "Mock", p.GetName())
+	require.Equal(t, "Mock plugin", p.GetDescription())
+	require.Equal(t, []string{"MCK"}, p.ListSupportedFamilies())
+	require.Equal(t, "MCK", p.ListSupportedAltcoins()[0].Ticker)
+	require.Len(t, p.LoadWalletEnvs(), 1)
+}
+
+func TestExternalWalletEnv(t *testing.T) {
+	plugin, _ := mockPlugin()
+	p, err := NewExternalPlugin(NewConnection(&pipeTransport{plugin: plugin}, time.Second))
+	require.NoError(t, err)
+	wltSet := p.LoadWalletEnvs()[0].GetWalletSet()
+
+	it := wltSet.ListWallets()
+	require.True(t, it.Next())
+	require.Equal(t, "w1", it.Value().GetId())
+	require.Equal(t, "Main", it.Value().GetLabel())
+	require.False(t, it.Next())
+
+	require.NotNil(t, wltSet.GetWallet("w1"))
+	require.Nil(t, wltSet.GetWallet("w2"))
+
+	_, err = p.LoadWalletEnvs()[0].(*ExternalWalletEnv).LookupWallet("2bad")
+	require.Equal(t, fce.ErrWltFromAddrNotFound, err)
+}
+
+func TestExternalPEXBroadcastForeignTxn(t *testing.T) {
+	plugin, _ := mockPlugin()
+	p, err := NewExternalPlugin(NewConnection(&pipeTransport{plugin: plugin}, time.Second))
+	require.NoError(t, err)
+	other, err := NewExternalPlugin(NewConnection(&pipeTransport{plugin: plugin}, time.Second))
+	require.NoError(t, err)
+	pex, err := p.LoadPEX("MainNet")
+	require.NoError(t, err)
+
+	require.Equal(t, fce.ErrInvalidTxn, pex.BroadcastTxn(new(mocks.Transaction)))
+	foreign := &ExternalTransaction{data: TxnData{Handle: "1"}, plugin: other}
+	require.Equal(t, fce.ErrInvalidTxn, pex.BroadcastTxn(foreign))
+	unknown := &ExternalTransaction{data: TxnData{Handle: "1"}, plugin: p}
+	require.Equal(t, ErrUnknownHandle, pex.BroadcastTxn(unknown))
+}
+
+func TestConnectionRestart(t *testing.T) {
+	plugin, _ := mockPlugin()
+	transport := &pipeTransport{plugin: plugin}
+	conn := NewConnection(transport, time.Second)
+	p, err := NewExternalPlugin(conn)
+	require.NoError(t, err)
+	require.NoError(t, p.Ping())
+	require.Equal(t, 1, transport.dials)
+
+	// Plugin process exits
+	transport.last.Close()
+	require.Equal(t, ErrPluginUnavailable, p.Ping())
+	// Restarts are throttled
+	require.Equal(t, ErrPluginUnavailable, p.Ping())
+	require.Equal(t, 1, transport.dials)
+
+	require.NoError(t, p.Restart())
+	require.NoError(t, p.Ping())
+	require.Equal(t, 2, transport.dials)
+}
+
+func TestConnectionTimeout(t *testing.T) {
+	plugin, wltSet := mockPlugin()
+	wltSet.On("GetWallet", "slow").Return(nil).After(time.Second)
+	p, err := NewExternalPlugin(NewConnection(&pipeTransport{plugin: plugin}, 10*time.Millisecond))
+	require.NoError(t, err)
+
+	var info WalletInfo
+	require.Equal(t, ErrPluginTimeout, p.call("GetWallet", WalletArgs{WalletID: "slow"}, &info))
+	// Slow calls do not break connection
+	require.NoError(t, p.Ping())
+}
+
+func TestConnectionHung(t *testing.T) {
+	plugin, wltSet := mockPlugin()
+	wltSet.On("GetWallet", "slow").Return(nil).After(time.Second)
+	transport := &pipeTransport{plugin: plugin}
+	conn := NewConnection(transport, 10*time.Millisecond)
+	conn.restartDelay = 0
+	p, err := NewExternalPlugin(conn)
+	require.NoError(t, err)
+
+	var info WalletInfo
+	for i := 1; i < DefaultMaxTimeouts; i++ {
+		require.Equal(t, ErrPluginTimeout, p.call("GetWallet", WalletArgs{WalletID: "slow"}, &info))
+	}
+	require.Equal(t, 1, transport.dials)
+	// Plugin process is restarted once it stops answering
+	require.Equal(t, ErrPluginTimeout, p.call("GetWallet", WalletArgs{WalletID: "slow"}, &info))
+	require.NoError(t, p.Ping())
+	require.Equal(t, 2, transport.dials)
+}
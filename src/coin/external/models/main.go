@@ -0,0 +1,135 @@
+package external
+
+import (
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+)
+
+// ExternalPlugin proxy of an altcoin plugin running in a separate process
+type ExternalPlugin struct {
+	conn *Connection
+	info PluginInfo
+}
+
+// NewExternalPlugin asks plugin process reached by connection to describe itself
+func NewExternalPlugin(conn *Connection) (*ExternalPlugin, error) {
+	p := &ExternalPlugin{conn: conn}
+	if err := p.call("Describe", Empty{}, &p.info); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *ExternalPlugin) call(method string, args interface{}, reply interface{}) error {
+	return p.conn.Call(method, args, reply)
+}
+
+// Restart restarts plugin process, or reconnects to it, independently of other plugins
+func (p *ExternalPlugin) Restart() error {
+	return p.conn.Restart()
+}
+
+// Ping checks that plugin process is alive
+func (p *ExternalPlugin) Ping() error {
+	return p.call("Ping", Empty{}, &Empty{})
+}
+
+// ListSupportedAltcoins to enumerate supported assets and related metadata
+func (p *ExternalPlugin) ListSupportedAltcoins() []core.AltcoinMetadata {
+	return p.info.Altcoins
+}
+
+// ListSupportedFamilies classifies similar cryptocurrencies into a family
+func (p *ExternalPlugin) ListSupportedFamilies() []string {
+	return p.info.Families
+}
+
+// RegisterTo boilerplate to register this plugin against an altcoin manager and enable it
+func (p *ExternalPlugin) RegisterTo(manager core.AltcoinManager) {
+	for _, info := range p.ListSupportedAltcoins() {
+		manager.RegisterAltcoin(info, p)
+	}
+}
+
+// GetName provides concise human-readable caption to identify this plugin
+func (p *ExternalPlugin) GetName() string {
+	return p.info.Name
+}
+
+// GetDescription describes plugin and its features
+func (p *ExternalPlugin) GetDescription() string {
+	return p.info.Description
+}
+
+// LoadWalletEnvs loads wallet environments of plugin process
+func (p *ExternalPlugin) LoadWalletEnvs() []core.WalletEnv {
+	envs := make([]core.WalletEnv, p.info.WalletEnvs)
+	for i := range envs {
+		envs[i] = &ExternalWalletEnv{plugin: p, env: i}
+	}
+	return envs
+}
+
+// LoadPEX instantiates proxy object to interact with nodes of the P2P network
+func (p *ExternalPlugin) LoadPEX(netType string) (core.PEX, error) {
+	return &ExternalPEX{plugin: p, netType: netType}, nil
+}
+
+// LoadTransactionAPI is not available for plugins running in a separate process
+func (p *ExternalPlugin) LoadTransactionAPI(netType string) (core.BlockchainTransactionAPI, error) {
+	return nil, fce.ErrNotImplemented
+}
+
+// LoadSignService sign service entry point
+func (p *ExternalPlugin) LoadSignService() (core.BlockchainSignService, error) {
+	return &ExternalSignService{}, nil
+}
+
+// AddressFromString retrieves address corresponding to readable representation
+func (p *ExternalPlugin) AddressFromString(addrStr string) (core.Address, error) {
+	var data AddressData
+	if err := p.call("AddressFromString", addrStr, &data); err != nil {
+		return nil, err
+	}
+	return &ExternalAddress{data: data, plugin: p}, nil
+}
+
+// PubKeyFromBytes retrieves public key corresponding to binary representation
+func (p *ExternalPlugin) PubKeyFromBytes(b []byte) (core.PubKey, error) {
+	var data KeyData
+	if err := p.call("PubKeyFromBytes", b, &data); err != nil {
+		return nil, err
+	}
+	return &ExternalKey{data: data}, nil
+}
+
+// SecKeyFromBytes retrieves secret key corresponding to binary representation
+func (p *ExternalPlugin) SecKeyFromBytes(b []byte) (core.SecKey, error) {
+	var data KeyData
+	if err := p.call("SecKeyFromBytes", b, &data); err != nil {
+		return nil, err
+	}
+	return &ExternalKey{data: data}, nil
+}
+
+// ListTxnOptions enumerates options accepted by plugin process when creating transactions
+func (p *ExternalPlugin) ListTxnOptions(ticker string) []core.TxnOptionSpec {
+	return p.info.TxnOptions[ticker]
+}
+
+// ExternalSignService implements BlockchainSignService for multi-wallet transaction signing
+type ExternalSignService struct{}
+
+// Sign creates a new transaction by (fully or partially) signing a given transaction
+func (ess *ExternalSignService) Sign(txn core.Transaction, signSpec []core.InputSignDescriptor, pwd core.PasswordReader) (core.Transaction, error) {
+	return util.GenericMultiWalletSign(txn, signSpec, pwd)
+}
+
+// Type assertions
+var (
+	_ core.AltcoinPlugin         = &ExternalPlugin{}
+	_ core.TxnOptionsProvider    = &ExternalPlugin{}
+	_ core.PluginRestarter       = &ExternalPlugin{}
+	_ core.BlockchainSignService = &ExternalSignService{}
+)
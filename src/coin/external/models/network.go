@@ -0,0 +1,130 @@
+package external
+
+import (
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+// ExternalPEX peer-to-peer API of a network reached by a plugin process
+type ExternalPEX struct { // Implements PEX interface
+	plugin  *ExternalPlugin
+	netType string
+}
+
+// GetTxnPool return transactions pending for confirmation by network peers
+func (pex *ExternalPEX) GetTxnPool() (core.TransactionIterator, error) {
+	var data []TxnData
+	if err := pex.plugin.call("TxnPool", NetArgs{NetType: pex.netType}, &data); err != nil {
+		return nil, err
+	}
+	return newExternalTransactionIterator(data, pex.plugin), nil
+}
+
+// GetConnections enumerate connections to peer nodes
+func (pex *ExternalPEX) GetConnections() (core.PexNodeSet, error) {
+	var data []PexNodeData
+	if err := pex.plugin.call("Connections", NetArgs{NetType: pex.netType}, &data); err != nil {
+		return nil, err
+	}
+	nodes := make([]core.PexNode, len(data))
+	for i, d := range data {
+		nodes[i] = &ExternalPexNode{data: d}
+	}
+	return &ExternalPexNodeSet{nodes: nodes}, nil
+}
+
+// BroadcastTxn injects a transaction created by the same plugin process for confirmation by network peers
+func (pex *ExternalPEX) BroadcastTxn(txn core.Transaction) error {
+	extTxn, isExternal := txn.(*ExternalTransaction)
+	if !isExternal {
+		return fce.ErrInvalidTxn
+	}
+	handle, err := extTxn.handle(pex.plugin)
+	if err != nil {
+		return err
+	}
+	return pex.plugin.call("Broadcast", BroadcastArgs{NetType: pex.netType, Txn: handle}, &Empty{})
+}
+
+// ExternalPexNode snapshot of a peer node
+type ExternalPexNode struct { // Implements PexNode interface
+	data PexNodeData
+}
+
+// GetIp returns node IP network address
+func (node *ExternalPexNode) GetIp() string {
+	return node.data.Ip
+}
+
+// GetPort retrieves IP port used to connect to peer node
+func (node *ExternalPexNode) GetPort() uint16 {
+	return node.data.Port
+}
+
+// GetBlockHeight provides sequence number of the block a the tip of peer's chain
+func (node *ExternalPexNode) GetBlockHeight() uint64 {
+	return node.data.BlockHeight
+}
+
+// IsTrusted determines if peer node is a network seed node
+func (node *ExternalPexNode) IsTrusted() bool {
+	return node.data.IsTrusted
+}
+
+// GetLastSeenIn timestamp of last message received from peer
+func (node *ExternalPexNode) GetLastSeenIn() int64 {
+	return node.data.LastSeenIn
+}
+
+// GetLastSeenOut timestamp of last message sent to peer
+func (node *ExternalPexNode) GetLastSeenOut() int64 {
+	return node.data.LastSeenOut
+}
+
+// ExternalPexNodeSet set of peers connected to network nodes
+type ExternalPexNodeSet struct { // Implements PexNodeSet interface
+	nodes []core.PexNode
+}
+
+// ListPeers offers an iterator over this set of nodes
+func (set *ExternalPexNodeSet) ListPeers() core.PexNodeIterator {
+	return NewExternalPexNodeIterator(set.nodes)
+}
+
+// ExternalPexNodeIterator iterates over peers
+type ExternalPexNodeIterator struct {
+	current int
+	nodes   []core.PexNode
+}
+
+// Value of PEX node data instance at iterator pointer position
+func (it *ExternalPexNodeIterator) Value() core.PexNode {
+	return it.nodes[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *ExternalPexNodeIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *ExternalPexNodeIterator) HasNext() bool {
+	return (it.current + 1) < len(it.nodes)
+}
+
+// NewExternalPexNodeIterator instantiates iterator over peers
+func NewExternalPexNodeIterator(nodes []core.PexNode) *ExternalPexNodeIterator {
+	return &ExternalPexNodeIterator{nodes: nodes, current: -1}
+}
+
+// Type assertions
+var (
+	_ core.PEX             = &ExternalPEX{}
+	_ core.PexNode         = &ExternalPexNode{}
+	_ core.PexNodeSet      = &ExternalPexNodeSet{}
+	_ core.PexNodeIterator = &ExternalPexNodeIterator{}
+)
@@ -0,0 +1,257 @@
+package external
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logProcess = logging.MustGetLogger("External plugin process")
+
+const (
+	// DefaultCallTimeout time to wait for plugin process to answer a call
+	DefaultCallTimeout = 30 * time.Second
+	// DefaultRestartDelay minimum time between attempts to restart a plugin process
+	DefaultRestartDelay = 5 * time.Second
+	// DefaultMaxTimeouts consecutive timed out calls after which plugin process is considered hung and restarted
+	DefaultMaxTimeouts = 3
+)
+
+// Transport establishes connections to a plugin process
+type Transport interface {
+	// Dial connects to plugin process, starting it if needed
+	Dial() (io.ReadWriteCloser, error)
+	// String identifies plugin process in logs
+	String() string
+}
+
+// processConn talks to a child process over its standard input and output
+type processConn struct {
+	io.Reader
+	io.WriteCloser
+	cmd *exec.Cmd
+}
+
+// Close terminates child process
+func (conn *processConn) Close() error {
+	conn.WriteCloser.Close()
+	if conn.cmd.Process != nil {
+		_ = conn.cmd.Process.Kill()
+	}
+	return conn.cmd.Wait()
+}
+
+// ProcessTransport starts plugin executable and talks to it over stdio
+type ProcessTransport struct {
+	Path string
+	Args []string
+}
+
+// Dial starts a new plugin process
+func (t *ProcessTransport) Dial() (io.ReadWriteCloser, error) {
+	cmd := exec.Command(t.Path, t.Args...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &processConn{Reader: stdout, WriteCloser: stdin, cmd: cmd}, nil
+}
+
+// String identifies plugin process in logs
+func (t *ProcessTransport) String() string {
+	return t.Path
+}
+
+// SocketTransport connects to a plugin process listening on a unix socket
+type SocketTransport struct {
+	Path string
+}
+
+// Dial connects to plugin socket
+func (t *SocketTransport) Dial() (io.ReadWriteCloser, error) {
+	return net.Dial("unix", t.Path)
+}
+
+// String identifies plugin process in logs
+func (t *SocketTransport) String() string {
+	return "unix:" + t.Path
+}
+
+// Connection forwards calls to a plugin process.
+// Connection is re-established, and plugin process restarted, on first call after it is lost
+// or after too many consecutive calls timed out.
+type Connection struct {
+	transport    Transport
+	timeout      time.Duration
+	restartDelay time.Duration
+	maxTimeouts  int
+
+	mutex    sync.Mutex
+	client   *rpc.Client
+	lastDial time.Time
+	timeouts int
+}
+
+// NewConnection instantiates connection to plugin process reached by transport
+func NewConnection(transport Transport, timeout time.Duration) *Connection {
+	return &Connection{
+		transport:    transport,
+		timeout:      timeout,
+		restartDelay: DefaultRestartDelay,
+		maxTimeouts:  DefaultMaxTimeouts,
+	}
+}
+
+func (c *Connection) getClient() (*rpc.Client, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.client != nil {
+		return c.client, nil
+	}
+	if !c.lastDial.IsZero() && time.Since(c.lastDial) < c.restartDelay {
+		return nil, ErrPluginUnavailable
+	}
+	c.lastDial = time.Now()
+	logProcess.WithField("plugin", c.transport.String()).Info("Connecting to plugin process")
+	conn, err := c.transport.Dial()
+	if err != nil {
+		logProcess.WithError(err).WithField("plugin", c.transport.String()).Error("Couldn't connect to plugin process")
+		return nil, ErrPluginUnavailable
+	}
+	c.client = rpc.NewClientWithCodec(jsonrpc.NewClientCodec(conn))
+	return c.client, nil
+}
+
+// reset drops client unless it was already replaced
+func (c *Connection) reset(client *rpc.Client) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.client == client {
+		c.client.Close()
+		c.client = nil
+		c.timeouts = 0
+	}
+}
+
+// answered clears count of consecutive timed out calls
+func (c *Connection) answered() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.timeouts = 0
+}
+
+// timedOut counts consecutive timed out calls and tells whether plugin process is considered hung
+func (c *Connection) timedOut(client *rpc.Client) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.client != client {
+		return false
+	}
+	c.timeouts++
+	return c.timeouts >= c.maxTimeouts
+}
+
+// Call invokes method of plugin service and waits for the reply until timeout expires
+func (c *Connection) Call(method string, args interface{}, reply interface{}) error {
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+	call := client.Go(ServiceName+"."+method, args, reply, make(chan *rpc.Call, 1))
+	select {
+	case <-call.Done:
+		if _, isServerError := call.Error.(rpc.ServerError); call.Error != nil && !isServerError {
+			logProcess.WithError(call.Error).WithField("plugin", c.transport.String()).Warn("Lost connection to plugin process")
+			c.reset(client)
+			return ErrPluginUnavailable
+		}
+		c.answered()
+		return remoteError(call.Error)
+	case <-time.After(c.timeout):
+		logProcess.WithField("plugin", c.transport.String()).WithField("method", method).Warn("Plugin call timed out")
+		if c.timedOut(client) {
+			// Hung plugin process is killed, if started by host, and started again on next call
+			logProcess.WithField("plugin", c.transport.String()).Error("Plugin process does not answer, dropping connection")
+			c.reset(client)
+		}
+		return ErrPluginTimeout
+	}
+}
+
+// Restart drops connection to plugin process, killing it if it was started by host, and connects again
+func (c *Connection) Restart() error {
+	c.mutex.Lock()
+	client := c.client
+	c.lastDial = time.Time{}
+	c.mutex.Unlock()
+	if client != nil {
+		c.reset(client)
+	}
+	_, err := c.getClient()
+	return err
+}
+
+// Close drops connection to plugin process
+func (c *Connection) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.client == nil {
+		return nil
+	}
+	err := c.client.Close()
+	c.client = nil
+	return err
+}
+
+// Discover lists transports to plugins found in directory.
+// Executable files are started as child processes, unix sockets are connected to.
+func Discover(dir string) []Transport {
+	transports := make([]Transport, 0)
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		logProcess.WithError(err).WithField("dirname", dir).Debug("Couldn't read plugins directory")
+		return transports
+	}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		switch mode := e.Mode(); {
+		case mode&os.ModeSocket != 0:
+			transports = append(transports, &SocketTransport{Path: path})
+		case mode.IsRegular() && mode&0111 != 0:
+			transports = append(transports, &ProcessTransport{Path: path})
+		}
+	}
+	return transports
+}
+
+// LoadPlugins connects to plugins found in directory.
+// Plugins failing to describe themselves are skipped.
+func LoadPlugins(dir string, timeout time.Duration) []*ExternalPlugin {
+	plugins := make([]*ExternalPlugin, 0)
+	for _, transport := range Discover(dir) {
+		plugin, err := NewExternalPlugin(NewConnection(transport, timeout))
+		if err != nil {
+			logProcess.WithError(err).WithField("plugin", transport.String()).Error("Couldn't load plugin")
+			continue
+		}
+		plugins = append(plugins, plugin)
+	}
+	return plugins
+}
@@ -0,0 +1,255 @@
+package external
+
+import (
+	"errors"
+
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+const (
+	// ServiceName identifies plugin RPC service on both sides of the process boundary
+	ServiceName = "Plugin"
+	// EnvPluginSocket environment variable telling plugin processes to listen on a unix socket instead of stdio
+	EnvPluginSocket = "FCW_PLUGIN_SOCKET"
+)
+
+var (
+	// ErrPluginTimeout plugin process did not answer in time
+	ErrPluginTimeout = errors.New("Plugin call timed out")
+	// ErrPluginUnavailable plugin process exited or connection to it was lost
+	ErrPluginUnavailable = errors.New("Plugin process unavailable")
+	// ErrUnknownHandle object referenced by host is not known by plugin process
+	ErrUnknownHandle = errors.New("Unknown plugin object handle")
+)
+
+// wellKnownErrors are restored on host side so that callers may compare against them
+var wellKnownErrors = []error{
+	fce.ErrInsufficientFunds,
+	fce.ErrInvalidAddressString,
+	fce.ErrInvalidID,
+	fce.ErrInvalidNetworkType,
+	fce.ErrInvalidOptions,
+	fce.ErrInvalidTxn,
+	fce.ErrInvalidWalletEntropy,
+	fce.ErrNotFound,
+	fce.ErrNotImplemented,
+	fce.ErrTxnSignFailure,
+	fce.ErrUnsupportedSigner,
+	fce.ErrWalletCantSign,
+	fce.ErrWltFromAddrNotFound,
+	ErrUnknownHandle,
+}
+
+// remoteError maps error messages received from plugin processes back to well known errors
+func remoteError(err error) error {
+	if err == nil {
+		return nil
+	}
+	for _, known := range wellKnownErrors {
+		if err.Error() == known.Error() {
+			return known
+		}
+	}
+	return err
+}
+
+// Empty argument or reply of calls not carrying data
+type Empty struct{}
+
+// PluginInfo describes the plugin served by a process
+type PluginInfo struct {
+	Name        string
+	Description string
+	Families    []string
+	Altcoins    []core.AltcoinMetadata
+	// TxnOptions options supported by plugin indexed by asset ticker
+	TxnOptions map[string][]core.TxnOptionSpec
+	// WalletEnvs number of wallet environments loaded by plugin
+	WalletEnvs int
+}
+
+// AddressData snapshot of an address
+type AddressData struct {
+	Address  string
+	Bytes    []byte
+	Checksum []byte
+	IsBip32  bool
+	IsNull   bool
+}
+
+// KeyData snapshot of a public or private key
+type KeyData struct {
+	Bytes     []byte
+	IsNull    bool
+	VerifyErr string
+}
+
+// OutputData snapshot of a transaction output
+type OutputData struct {
+	Id      string
+	Address *AddressData
+	IsSpent bool
+	Assets  []string
+	Coins   map[string]uint64
+}
+
+// InputData snapshot of a transaction input
+type InputData struct {
+	Id          string
+	Assets      []string
+	Coins       map[string]uint64
+	SpentOutput *OutputData
+}
+
+// TxnData snapshot of a transaction.
+// Handle is set for transactions that can be signed or broadcast later on.
+type TxnData struct {
+	Handle            string
+	Id                string
+	Status            core.TransactionStatus
+	Timestamp         core.Timestamp
+	Assets            []string
+	Fees              map[string]uint64
+	Inputs            []InputData
+	Outputs           []OutputData
+	VerifyUnsignedErr string
+	VerifySignedErr   string
+	IsFullySigned     bool
+}
+
+// PexNodeData snapshot of a peer node
+type PexNodeData struct {
+	Ip          string
+	Port        uint16
+	BlockHeight uint64
+	IsTrusted   bool
+	LastSeenIn  int64
+	LastSeenOut int64
+}
+
+// WalletInfo identifies a wallet
+type WalletInfo struct {
+	Id    string
+	Label string
+	// IsSigner is set for wallets able to sign transactions
+	IsSigner bool
+}
+
+// WalletTypes wallet types supported by a wallet set
+type WalletTypes struct {
+	Default   string
+	Supported []string
+}
+
+// SignerInfo describes signing strategy of a wallet
+type SignerInfo struct {
+	UID         core.UID
+	Description string
+}
+
+// EnvArgs refer to a wallet environment of the plugin
+type EnvArgs struct {
+	Env int
+}
+
+// WalletArgs refer to a wallet in a wallet environment
+type WalletArgs struct {
+	Env      int
+	WalletID string
+}
+
+// LookupWalletArgs look up wallet by first address
+type LookupWalletArgs struct {
+	Env       int
+	FirstAddr string
+}
+
+// CreateWalletArgs create wallet in a wallet environment
+type CreateWalletArgs struct {
+	Env       int
+	Label     string
+	Seed      string
+	Type      string
+	Encrypted bool
+	Password  string
+	ScanN     int
+}
+
+// StorageArgs encrypt or decrypt a wallet
+type StorageArgs struct {
+	Env      int
+	WalletID string
+	Password string
+}
+
+// LabelArgs change wallet label
+type LabelArgs struct {
+	Env      int
+	WalletID string
+	Label    string
+}
+
+// GenAddressesArgs derive wallet addresses
+type GenAddressesArgs struct {
+	Env        int
+	WalletID   string
+	AddrType   core.AddressType
+	StartIndex uint32
+	Count      uint32
+	Password   string
+}
+
+// AccountArgs refer to the crypto account of a wallet or else of an address
+type AccountArgs struct {
+	Env      int
+	WalletID string
+	Address  string
+	Ticker   string
+}
+
+// TransferArgs send funds from any wallet address
+type TransferArgs struct {
+	Env      int
+	WalletID string
+	To       OutputData
+	Options  map[string]string
+}
+
+// SendArgs send funds from specific addresses, or else outputs, of a wallet
+type SendArgs struct {
+	Env      int
+	WalletID string
+	From     []string
+	Unspent  []string
+	To       []OutputData
+	Change   string
+	Options  map[string]string
+}
+
+// SignArgs sign transaction with a wallet
+type SignArgs struct {
+	Env      int
+	WalletID string
+	Txn      string
+	SignerID core.UID
+	Password string
+	Index    []string
+}
+
+// NetArgs refer to a network of the plugin
+type NetArgs struct {
+	NetType string
+}
+
+// BroadcastArgs broadcast transaction to a network
+type BroadcastArgs struct {
+	NetType string
+	Txn     string
+}
+
+// VerifyAddressArgs check address against public key
+type VerifyAddressArgs struct {
+	Address string
+	PubKey  []byte
+}
@@ -0,0 +1,679 @@
+package external
+
+import (
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logServer = logging.MustGetLogger("External plugin server")
+
+// maxTxnHandles number of transactions kept by plugin process for later signing or broadcasting
+const maxTxnHandles = 1024
+
+// PluginService exposes an altcoin plugin to the host process
+type PluginService struct {
+	plugin core.AltcoinPlugin
+	envs   []core.WalletEnv
+
+	mutex      sync.Mutex
+	txns       map[string]core.Transaction
+	txnHandles []string
+	nextHandle uint64
+}
+
+// NewPluginService instantiates RPC service exposing plugin
+func NewPluginService(plugin core.AltcoinPlugin) *PluginService {
+	return &PluginService{
+		plugin: plugin,
+		envs:   plugin.LoadWalletEnvs(),
+		txns:   make(map[string]core.Transaction),
+	}
+}
+
+func newRPCServer(plugin core.AltcoinPlugin) (*rpc.Server, error) {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName(ServiceName, NewPluginService(plugin)); err != nil {
+		return nil, err
+	}
+	return srv, nil
+}
+
+// ServeConn answers host requests received over conn until it is closed
+func ServeConn(plugin core.AltcoinPlugin, conn io.ReadWriteCloser) error {
+	srv, err := newRPCServer(plugin)
+	if err != nil {
+		return err
+	}
+	srv.ServeCodec(jsonrpc.NewServerCodec(conn))
+	return nil
+}
+
+// stdioConn joins standard input and output of plugin process
+type stdioConn struct {
+	io.Reader
+	io.Writer
+}
+
+// Close closes standard streams of plugin process
+func (conn *stdioConn) Close() error {
+	os.Stdin.Close()
+	return os.Stdout.Close()
+}
+
+// Serve should be invoked in main function of plugin executables.
+// It answers host requests over a unix socket if EnvPluginSocket is set, otherwise over stdio.
+func Serve(plugin core.AltcoinPlugin) error {
+	if path := os.Getenv(EnvPluginSocket); path != "" {
+		return ServeUnix(plugin, path)
+	}
+	// Standard output is reserved to RPC messages
+	logging.SetOutputTo(os.Stderr)
+	return ServeConn(plugin, &stdioConn{Reader: os.Stdin, Writer: os.Stdout})
+}
+
+// ServeUnix answers host requests received by connections to unix socket at path
+func ServeUnix(plugin core.AltcoinPlugin, path string) error {
+	srv, err := newRPCServer(plugin)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go srv.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+func (s *PluginService) env(index int) (core.WalletEnv, error) {
+	if index < 0 || index >= len(s.envs) {
+		return nil, ErrUnknownHandle
+	}
+	return s.envs[index], nil
+}
+
+func (s *PluginService) wallet(env int, id string) (core.Wallet, error) {
+	wltEnv, err := s.env(env)
+	if err != nil {
+		return nil, err
+	}
+	if wlt := wltEnv.GetWalletSet().GetWallet(id); wlt != nil {
+		return wlt, nil
+	}
+	return nil, fce.ErrNotFound
+}
+
+// account resolves crypto account of wallet, or else of address if no wallet ID is set
+func (s *PluginService) account(args AccountArgs) (core.CryptoAccount, error) {
+	if args.WalletID == "" {
+		addr, err := s.plugin.AddressFromString(args.Address)
+		if err != nil {
+			return nil, err
+		}
+		return addr.GetCryptoAccount(), nil
+	}
+	wlt, err := s.wallet(args.Env, args.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return wlt.GetCryptoAccount(), nil
+}
+
+// keepTxn binds transaction to a handle, forgetting the oldest ones once limit is reached
+func (s *PluginService) keepTxn(txn core.Transaction) TxnData {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.nextHandle++
+	handle := strconv.FormatUint(s.nextHandle, 10)
+	s.txns[handle] = txn
+	s.txnHandles = append(s.txnHandles, handle)
+	if len(s.txnHandles) > maxTxnHandles {
+		delete(s.txns, s.txnHandles[0])
+		s.txnHandles = s.txnHandles[1:]
+	}
+	data := newTxnData(txn)
+	data.Handle = handle
+	return data
+}
+
+func (s *PluginService) txn(handle string) (core.Transaction, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if txn, isKept := s.txns[handle]; isKept {
+		return txn, nil
+	}
+	return nil, ErrUnknownHandle
+}
+
+func (s *PluginService) outputs(data []OutputData) ([]core.TransactionOutput, error) {
+	outs := make([]core.TransactionOutput, len(data))
+	for i, d := range data {
+		var addr core.Address
+		if d.Address != nil {
+			var err error
+			if addr, err = s.plugin.AddressFromString(d.Address.Address); err != nil {
+				return nil, err
+			}
+		}
+		outs[i] = &ExternalTransactionOutput{data: d, addr: addr}
+	}
+	return outs, nil
+}
+
+func (s *PluginService) addresses(strAddrs []string) ([]core.Address, error) {
+	addrs := make([]core.Address, len(strAddrs))
+	for i, strAddr := range strAddrs {
+		addr, err := s.plugin.AddressFromString(strAddr)
+		if err != nil {
+			return nil, err
+		}
+		addrs[i] = addr
+	}
+	return addrs, nil
+}
+
+func (s *PluginService) change(strAddr string) (core.Address, error) {
+	if strAddr == "" {
+		return nil, nil
+	}
+	return s.plugin.AddressFromString(strAddr)
+}
+
+func newOptions(values map[string]string) core.KeyValueStore {
+	options := util.NewKeyValueMap()
+	for k, v := range values {
+		options.SetValue(k, v)
+	}
+	return options
+}
+
+func newWalletInfo(wlt core.Wallet) WalletInfo {
+	_, isSigner := wlt.(core.TxnSigner)
+	return WalletInfo{Id: wlt.GetId(), Label: wlt.GetLabel(), IsSigner: isSigner}
+}
+
+func newTxnDataList(it core.TransactionIterator) []TxnData {
+	txns := make([]TxnData, 0)
+	for it != nil && it.Next() {
+		txns = append(txns, newTxnData(it.Value()))
+	}
+	return txns
+}
+
+func newAddressDataList(it core.AddressIterator) []AddressData {
+	addrs := make([]AddressData, 0)
+	for it != nil && it.Next() {
+		addrs = append(addrs, *newAddressData(it.Value()))
+	}
+	return addrs
+}
+
+// Ping checks that plugin process is alive
+func (s *PluginService) Ping(args Empty, reply *Empty) error {
+	return nil
+}
+
+// Describe returns plugin metadata
+func (s *PluginService) Describe(args Empty, reply *PluginInfo) error {
+	reply.Name = s.plugin.GetName()
+	reply.Description = s.plugin.GetDescription()
+	reply.Families = s.plugin.ListSupportedFamilies()
+	reply.Altcoins = s.plugin.ListSupportedAltcoins()
+	reply.WalletEnvs = len(s.envs)
+	reply.TxnOptions = make(map[string][]core.TxnOptionSpec)
+	if provider, isProvider := s.plugin.(core.TxnOptionsProvider); isProvider {
+		for _, info := range reply.Altcoins {
+			reply.TxnOptions[info.Ticker] = provider.ListTxnOptions(info.Ticker)
+		}
+	}
+	return nil
+}
+
+// ListWallets enumerates wallets of wallet environment
+func (s *PluginService) ListWallets(args EnvArgs, reply *[]WalletInfo) error {
+	wltEnv, err := s.env(args.Env)
+	if err != nil {
+		return err
+	}
+	wallets := make([]WalletInfo, 0)
+	it := wltEnv.GetWalletSet().ListWallets()
+	for it != nil && it.Next() {
+		wallets = append(wallets, newWalletInfo(it.Value()))
+	}
+	*reply = wallets
+	return nil
+}
+
+// GetWallet looks up wallet by ID
+func (s *PluginService) GetWallet(args WalletArgs, reply *WalletInfo) error {
+	wlt, err := s.wallet(args.Env, args.WalletID)
+	if err != nil {
+		return err
+	}
+	*reply = newWalletInfo(wlt)
+	return nil
+}
+
+// LookupWallet looks up wallet by first address
+func (s *PluginService) LookupWallet(args LookupWalletArgs, reply *WalletInfo) error {
+	wltEnv, err := s.env(args.Env)
+	if err != nil {
+		return err
+	}
+	wlt, err := wltEnv.LookupWallet(args.FirstAddr)
+	if err != nil {
+		return err
+	}
+	*reply = newWalletInfo(wlt)
+	return nil
+}
+
+// CreateWallet instantiates a new wallet given account seed
+func (s *PluginService) CreateWallet(args CreateWalletArgs, reply *WalletInfo) error {
+	wltEnv, err := s.env(args.Env)
+	if err != nil {
+		return err
+	}
+	wlt, err := wltEnv.GetWalletSet().CreateWallet(args.Label, args.Seed, args.Type, args.Encrypted, util.ConstantPassword(args.Password), args.ScanN)
+	if err != nil {
+		return err
+	}
+	*reply = newWalletInfo(wlt)
+	return nil
+}
+
+// WalletTypes lists wallet types supported by wallet environment
+func (s *PluginService) WalletTypes(args EnvArgs, reply *WalletTypes) error {
+	wltEnv, err := s.env(args.Env)
+	if err != nil {
+		return err
+	}
+	wltSet := wltEnv.GetWalletSet()
+	reply.Default = wltSet.DefaultWalletType()
+	reply.Supported = wltSet.SupportedWalletTypes()
+	return nil
+}
+
+// Encrypt protects wallet data using password
+func (s *PluginService) Encrypt(args StorageArgs, reply *Empty) error {
+	wltEnv, err := s.env(args.Env)
+	if err != nil {
+		return err
+	}
+	wltEnv.GetStorage().Encrypt(args.WalletID, util.ConstantPassword(args.Password))
+	return nil
+}
+
+// Decrypt removes password protection of wallet data
+func (s *PluginService) Decrypt(args StorageArgs, reply *Empty) error {
+	wltEnv, err := s.env(args.Env)
+	if err != nil {
+		return err
+	}
+	wltEnv.GetStorage().Decrypt(args.WalletID, util.ConstantPassword(args.Password))
+	return nil
+}
+
+// IsEncrypted queries whether wallet data is encrypted or not
+func (s *PluginService) IsEncrypted(args WalletArgs, reply *bool) error {
+	wltEnv, err := s.env(args.Env)
+	if err != nil {
+		return err
+	}
+	*reply, err = wltEnv.GetStorage().IsEncrypted(args.WalletID)
+	return err
+}
+
+// SetLabel establishes a label for wallet
+func (s *PluginService) SetLabel(args LabelArgs, reply *Empty) error {
+	wlt, err := s.wallet(args.Env, args.WalletID)
+	if err != nil {
+		return err
+	}
+	wlt.SetLabel(args.Label)
+	return nil
+}
+
+// GenAddresses derives wallet addresses
+func (s *PluginService) GenAddresses(args GenAddressesArgs, reply *[]AddressData) error {
+	wlt, err := s.wallet(args.Env, args.WalletID)
+	if err != nil {
+		return err
+	}
+	it := wlt.GenAddresses(args.AddrType, args.StartIndex, args.Count, util.ConstantPassword(args.Password))
+	*reply = newAddressDataList(it)
+	return nil
+}
+
+// LoadedAddresses lists wallet addresses known to have history
+func (s *PluginService) LoadedAddresses(args WalletArgs, reply *[]AddressData) error {
+	wlt, err := s.wallet(args.Env, args.WalletID)
+	if err != nil {
+		return err
+	}
+	it, err := wlt.GetLoadedAddresses()
+	if err != nil {
+		return err
+	}
+	*reply = newAddressDataList(it)
+	return nil
+}
+
+// Balance retrieves account balance of asset
+func (s *PluginService) Balance(args AccountArgs, reply *uint64) error {
+	account, err := s.account(args)
+	if err != nil {
+		return err
+	}
+	*reply, err = account.GetBalance(args.Ticker)
+	return err
+}
+
+// ListAssets enumerates assets supported by account
+func (s *PluginService) ListAssets(args AccountArgs, reply *[]string) error {
+	account, err := s.account(args)
+	if err != nil {
+		return err
+	}
+	*reply = account.ListAssets()
+	return nil
+}
+
+// UnspentOutputs lists outputs that can be spent by account
+func (s *PluginService) UnspentOutputs(args AccountArgs, reply *[]OutputData) error {
+	account, err := s.account(args)
+	if err != nil {
+		return err
+	}
+	it, err := account.ScanUnspentOutputs()
+	if err != nil {
+		return err
+	}
+	outs := make([]OutputData, 0)
+	for it != nil && it.Next() {
+		outs = append(outs, newOutputData(it.Value()))
+	}
+	*reply = outs
+	return nil
+}
+
+// Transactions lists account history
+func (s *PluginService) Transactions(args AccountArgs, reply *[]TxnData) error {
+	account, err := s.account(args)
+	if err != nil {
+		return err
+	}
+	*reply = newTxnDataList(account.ListTransactions())
+	return nil
+}
+
+// PendingTransactions lists account transactions pending for confirmation
+func (s *PluginService) PendingTransactions(args AccountArgs, reply *[]TxnData) error {
+	account, err := s.account(args)
+	if err != nil {
+		return err
+	}
+	it, err := account.ListPendingTransactions()
+	if err != nil {
+		return err
+	}
+	*reply = newTxnDataList(it)
+	return nil
+}
+
+// Transfer creates transaction sending funds from any wallet address
+func (s *PluginService) Transfer(args TransferArgs, reply *TxnData) error {
+	wlt, err := s.wallet(args.Env, args.WalletID)
+	if err != nil {
+		return err
+	}
+	to, err := s.outputs([]OutputData{args.To})
+	if err != nil {
+		return err
+	}
+	txn, err := wlt.Transfer(to[0], newOptions(args.Options))
+	if err != nil {
+		return err
+	}
+	*reply = s.keepTxn(txn)
+	return nil
+}
+
+// SendFromAddress creates transaction sending funds from specific wallet addresses
+func (s *PluginService) SendFromAddress(args SendArgs, reply *TxnData) error {
+	wlt, err := s.wallet(args.Env, args.WalletID)
+	if err != nil {
+		return err
+	}
+	from, err := s.addresses(args.From)
+	if err != nil {
+		return err
+	}
+	to, err := s.outputs(args.To)
+	if err != nil {
+		return err
+	}
+	change, err := s.change(args.Change)
+	if err != nil {
+		return err
+	}
+	txn, err := wlt.SendFromAddress(from, to, change, newOptions(args.Options))
+	if err != nil {
+		return err
+	}
+	*reply = s.keepTxn(txn)
+	return nil
+}
+
+// Spend creates transaction spending specific wallet outputs
+func (s *PluginService) Spend(args SendArgs, reply *TxnData) error {
+	wlt, err := s.wallet(args.Env, args.WalletID)
+	if err != nil {
+		return err
+	}
+	it, err := wlt.GetCryptoAccount().ScanUnspentOutputs()
+	if err != nil {
+		return err
+	}
+	spendable := make(map[string]core.TransactionOutput)
+	for it != nil && it.Next() {
+		spendable[it.Value().GetId()] = it.Value()
+	}
+	unspent := make([]core.TransactionOutput, len(args.Unspent))
+	for i, id := range args.Unspent {
+		out, isSpendable := spendable[id]
+		if !isSpendable {
+			return fce.ErrInvalidID
+		}
+		unspent[i] = out
+	}
+	to, err := s.outputs(args.To)
+	if err != nil {
+		return err
+	}
+	change, err := s.change(args.Change)
+	if err != nil {
+		return err
+	}
+	txn, err := wlt.Spend(unspent, to, change, newOptions(args.Options))
+	if err != nil {
+		return err
+	}
+	*reply = s.keepTxn(txn)
+	return nil
+}
+
+// Sign signs transaction with wallet, using the signing strategy identified by signer ID if set
+func (s *PluginService) Sign(args SignArgs, reply *TxnData) error {
+	wlt, err := s.wallet(args.Env, args.WalletID)
+	if err != nil {
+		return err
+	}
+	txn, err := s.txn(args.Txn)
+	if err != nil {
+		return err
+	}
+	var signer core.TxnSigner
+	if args.SignerID != "" {
+		if signer = util.LookupSignerByUID(wlt, args.SignerID); signer == nil {
+			return fce.ErrUnsupportedSigner
+		}
+	}
+	signed, err := wlt.Sign(txn, signer, util.ConstantPassword(args.Password), args.Index)
+	if err != nil {
+		return err
+	}
+	*reply = s.keepTxn(signed)
+	return nil
+}
+
+// ReadyForTxn determines whether wallet signing strategy can sign transaction
+func (s *PluginService) ReadyForTxn(args SignArgs, reply *bool) error {
+	wlt, err := s.wallet(args.Env, args.WalletID)
+	if err != nil {
+		return err
+	}
+	signer, isSigner := wlt.(core.TxnSigner)
+	if !isSigner {
+		return fce.ErrWalletCantSign
+	}
+	txn, err := s.txn(args.Txn)
+	if err != nil {
+		return err
+	}
+	*reply, err = signer.ReadyForTxn(wlt, txn)
+	return err
+}
+
+// SignerInfo describes wallet signing strategy
+func (s *PluginService) SignerInfo(args WalletArgs, reply *SignerInfo) error {
+	wlt, err := s.wallet(args.Env, args.WalletID)
+	if err != nil {
+		return err
+	}
+	signer, isSigner := wlt.(core.TxnSigner)
+	if !isSigner {
+		return fce.ErrWalletCantSign
+	}
+	if reply.UID, err = signer.GetSignerUID(); err != nil {
+		return err
+	}
+	reply.Description, err = signer.GetSignerDescription()
+	return err
+}
+
+// TxnPool lists transactions pending for confirmation by network peers
+func (s *PluginService) TxnPool(args NetArgs, reply *[]TxnData) error {
+	pex, err := s.plugin.LoadPEX(args.NetType)
+	if err != nil {
+		return err
+	}
+	it, err := pex.GetTxnPool()
+	if err != nil {
+		return err
+	}
+	*reply = newTxnDataList(it)
+	return nil
+}
+
+// Connections lists peers of network nodes
+func (s *PluginService) Connections(args NetArgs, reply *[]PexNodeData) error {
+	pex, err := s.plugin.LoadPEX(args.NetType)
+	if err != nil {
+		return err
+	}
+	nodeSet, err := pex.GetConnections()
+	if err != nil {
+		return err
+	}
+	nodes := make([]PexNodeData, 0)
+	it := nodeSet.ListPeers()
+	for it != nil && it.Next() {
+		node := it.Value()
+		nodes = append(nodes, PexNodeData{
+			Ip:          node.GetIp(),
+			Port:        node.GetPort(),
+			BlockHeight: node.GetBlockHeight(),
+			IsTrusted:   node.IsTrusted(),
+			LastSeenIn:  node.GetLastSeenIn(),
+			LastSeenOut: node.GetLastSeenOut(),
+		})
+	}
+	*reply = nodes
+	return nil
+}
+
+// Broadcast injects transaction for confirmation by network peers
+func (s *PluginService) Broadcast(args BroadcastArgs, reply *Empty) error {
+	txn, err := s.txn(args.Txn)
+	if err != nil {
+		return err
+	}
+	pex, err := s.plugin.LoadPEX(args.NetType)
+	if err != nil {
+		return err
+	}
+	return pex.BroadcastTxn(txn)
+}
+
+// AddressFromString decodes readable address representation
+func (s *PluginService) AddressFromString(args string, reply *AddressData) error {
+	addr, err := s.plugin.AddressFromString(args)
+	if err != nil {
+		return err
+	}
+	*reply = *newAddressData(addr)
+	return nil
+}
+
+// VerifyAddress checks that address matches public key
+func (s *PluginService) VerifyAddress(args VerifyAddressArgs, reply *Empty) error {
+	addr, err := s.plugin.AddressFromString(args.Address)
+	if err != nil {
+		return err
+	}
+	pk, err := s.plugin.PubKeyFromBytes(args.PubKey)
+	if err != nil {
+		return err
+	}
+	return addr.Verify(pk)
+}
+
+// PubKeyFromBytes decodes public key
+func (s *PluginService) PubKeyFromBytes(args []byte, reply *KeyData) error {
+	pk, err := s.plugin.PubKeyFromBytes(args)
+	if err != nil {
+		return err
+	}
+	*reply = newKeyData(pk)
+	return nil
+}
+
+// SecKeyFromBytes decodes private key
+func (s *PluginService) SecKeyFromBytes(args []byte, reply *KeyData) error {
+	sk, err := s.plugin.SecKeyFromBytes(args)
+	if err != nil {
+		return err
+	}
+	*reply = newKeyData(sk)
+	return nil
+}
@@ -0,0 +1,451 @@
+package external
+
+import (
+	"fmt"
+
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logWallet = logging.MustGetLogger("External wallet")
+
+// ExternalWalletEnv wallet environment loaded by a plugin process
+type ExternalWalletEnv struct { // Implements WalletEnv, WalletSet and WalletStorage interfaces
+	plugin *ExternalPlugin
+	env    int
+}
+
+// GetStorage provides access to wallet data store
+func (wltEnv *ExternalWalletEnv) GetStorage() core.WalletStorage {
+	return wltEnv
+}
+
+// GetWalletSet loads wallets in this environment
+func (wltEnv *ExternalWalletEnv) GetWalletSet() core.WalletSet {
+	return wltEnv
+}
+
+func (wltEnv *ExternalWalletEnv) newWallet(info WalletInfo) *ExternalWallet {
+	return &ExternalWallet{
+		plugin: wltEnv.plugin,
+		env:    wltEnv.env,
+		info:   info,
+	}
+}
+
+// LookupWallet finds wallet whose first address is firstAddr
+func (wltEnv *ExternalWalletEnv) LookupWallet(firstAddr string) (core.Wallet, error) {
+	var info WalletInfo
+	args := LookupWalletArgs{Env: wltEnv.env, FirstAddr: firstAddr}
+	if err := wltEnv.plugin.call("LookupWallet", args, &info); err != nil {
+		return nil, err
+	}
+	return wltEnv.newWallet(info), nil
+}
+
+// ListWallets returns an iterator over wallets in the set
+func (wltEnv *ExternalWalletEnv) ListWallets() core.WalletIterator {
+	var infos []WalletInfo
+	if err := wltEnv.plugin.call("ListWallets", EnvArgs{Env: wltEnv.env}, &infos); err != nil {
+		logWallet.WithError(err).Error("Couldn't list wallets")
+		return nil
+	}
+	wallets := make([]core.Wallet, len(infos))
+	for i, info := range infos {
+		wallets[i] = wltEnv.newWallet(info)
+	}
+	return NewExternalWalletIterator(wallets)
+}
+
+// GetWallet to lookup wallet by ID
+func (wltEnv *ExternalWalletEnv) GetWallet(id string) core.Wallet {
+	var info WalletInfo
+	if err := wltEnv.plugin.call("GetWallet", WalletArgs{Env: wltEnv.env, WalletID: id}, &info); err != nil {
+		logWallet.WithError(err).WithField("id", id).Debug("Couldn't get wallet")
+		return nil
+	}
+	return wltEnv.newWallet(info)
+}
+
+// CreateWallet instantiates a new wallet given account seed
+func (wltEnv *ExternalWalletEnv) CreateWallet(label string, seed string, wltType string, isEncrypted bool, pwd core.PasswordReader, scanAddressesN int) (core.Wallet, error) {
+	args := CreateWalletArgs{
+		Env:       wltEnv.env,
+		Label:     label,
+		Seed:      seed,
+		Type:      wltType,
+		Encrypted: isEncrypted,
+		ScanN:     scanAddressesN,
+	}
+	if isEncrypted {
+		pwdCtx := util.NewKeyValueMap()
+		pwdCtx.SetValue(core.StrTypeName, core.TypeNameWalletSet)
+		pwdCtx.SetValue(core.StrMethodName, "CreateWallet")
+		pwdCtx.SetValue(core.StrWalletLabel, label)
+		password, err := pwd("Enter password", pwdCtx)
+		if err != nil {
+			return nil, err
+		}
+		args.Password = password
+	}
+	var info WalletInfo
+	if err := wltEnv.plugin.call("CreateWallet", args, &info); err != nil {
+		return nil, err
+	}
+	return wltEnv.newWallet(info), nil
+}
+
+func (wltEnv *ExternalWalletEnv) walletTypes() WalletTypes {
+	var types WalletTypes
+	if err := wltEnv.plugin.call("WalletTypes", EnvArgs{Env: wltEnv.env}, &types); err != nil {
+		logWallet.WithError(err).Error("Couldn't get wallet types")
+	}
+	return types
+}
+
+// DefaultWalletType default wallet type
+func (wltEnv *ExternalWalletEnv) DefaultWalletType() string {
+	return wltEnv.walletTypes().Default
+}
+
+// SupportedWalletTypes list supported wallet type names
+func (wltEnv *ExternalWalletEnv) SupportedWalletTypes() []string {
+	return wltEnv.walletTypes().Supported
+}
+
+func (wltEnv *ExternalWalletEnv) readPassword(walletName, method string, pwd core.PasswordReader) (string, error) {
+	pwdCtx := util.NewKeyValueMap()
+	pwdCtx.SetValue(core.StrTypeName, core.TypeNameWalletStorage)
+	pwdCtx.SetValue(core.StrMethodName, method)
+	pwdCtx.SetValue(core.StrWalletName, walletName)
+	return pwd("Enter password", pwdCtx)
+}
+
+// Encrypt protects wallet data using password
+func (wltEnv *ExternalWalletEnv) Encrypt(walletName string, pwd core.PasswordReader) {
+	password, err := wltEnv.readPassword(walletName, "Encrypt", pwd)
+	if err != nil {
+		logWallet.WithError(err).Error("Something was wrong entering the password")
+		return
+	}
+	args := StorageArgs{Env: wltEnv.env, WalletID: walletName, Password: password}
+	if err := wltEnv.plugin.call("Encrypt", args, &Empty{}); err != nil {
+		logWallet.WithError(err).Error("Couldn't encrypt wallet")
+	}
+}
+
+// Decrypt removes password protection of wallet data
+func (wltEnv *ExternalWalletEnv) Decrypt(walletName string, pwd core.PasswordReader) {
+	password, err := wltEnv.readPassword(walletName, "Decrypt", pwd)
+	if err != nil {
+		logWallet.WithError(err).Error("Something was wrong entering the password")
+		return
+	}
+	args := StorageArgs{Env: wltEnv.env, WalletID: walletName, Password: password}
+	if err := wltEnv.plugin.call("Decrypt", args, &Empty{}); err != nil {
+		logWallet.WithError(err).Error("Couldn't decrypt wallet")
+	}
+}
+
+// IsEncrypted queries whether wallet data is encrypted or not
+func (wltEnv *ExternalWalletEnv) IsEncrypted(walletName string) (bool, error) {
+	var isEncrypted bool
+	if err := wltEnv.plugin.call("IsEncrypted", WalletArgs{Env: wltEnv.env, WalletID: walletName}, &isEncrypted); err != nil {
+		return false, err
+	}
+	return isEncrypted, nil
+}
+
+// ExternalWallet wallet managed by a plugin process
+type ExternalWallet struct { // Implements Wallet and TxnSigner interfaces
+	plugin *ExternalPlugin
+	env    int
+	info   WalletInfo
+}
+
+func (wlt *ExternalWallet) args() WalletArgs {
+	return WalletArgs{Env: wlt.env, WalletID: wlt.info.Id}
+}
+
+// readPassword asks for password only if wallet is encrypted
+func (wlt *ExternalWallet) readPassword(method string, pwd core.PasswordReader) (string, error) {
+	if pwd == nil {
+		return "", nil
+	}
+	var isEncrypted bool
+	if err := wlt.plugin.call("IsEncrypted", wlt.args(), &isEncrypted); err != nil || !isEncrypted {
+		return "", err
+	}
+	pwdCtx := util.NewKeyValueMap()
+	pwdCtx.SetValue(core.StrTypeName, core.TypeNameWallet)
+	pwdCtx.SetValue(core.StrMethodName, method)
+	pwdCtx.SetValue(core.StrWalletName, wlt.info.Id)
+	pwdCtx.SetValue(core.StrWalletLabel, wlt.info.Label)
+	return pwd("Enter password", pwdCtx)
+}
+
+// GetId returns wallet local identifier
+func (wlt *ExternalWallet) GetId() string {
+	return wlt.info.Id
+}
+
+// GetLabel provides a human-readable name for this wallet
+func (wlt *ExternalWallet) GetLabel() string {
+	return wlt.info.Label
+}
+
+// SetLabel establishes a label for this wallet
+func (wlt *ExternalWallet) SetLabel(wltName string) {
+	args := LabelArgs{Env: wlt.env, WalletID: wlt.info.Id, Label: wltName}
+	if err := wlt.plugin.call("SetLabel", args, &Empty{}); err != nil {
+		logWallet.WithError(err).Error("Couldn't set wallet label")
+		return
+	}
+	wlt.info.Label = wltName
+}
+
+// options extracts values of transaction options supported by plugin
+func (wlt *ExternalWallet) options(options core.KeyValueStore) map[string]string {
+	values := make(map[string]string)
+	if options == nil {
+		return values
+	}
+	for _, specs := range wlt.plugin.info.TxnOptions {
+		for _, spec := range specs {
+			if value := options.GetValue(spec.Key); value != nil {
+				values[spec.Key] = fmt.Sprint(value)
+			}
+		}
+	}
+	return values
+}
+
+func (wlt *ExternalWallet) newTransaction(method string, args interface{}) (core.Transaction, error) {
+	var data TxnData
+	if err := wlt.plugin.call(method, args, &data); err != nil {
+		return nil, err
+	}
+	return &ExternalTransaction{data: data, plugin: wlt.plugin}, nil
+}
+
+// Transfer instantiates unsigned transaction to send funds from any wallet address to single destination
+func (wlt *ExternalWallet) Transfer(to core.TransactionOutput, options core.KeyValueStore) (core.Transaction, error) {
+	args := TransferArgs{
+		Env:      wlt.env,
+		WalletID: wlt.info.Id,
+		To:       newOutputData(to),
+		Options:  wlt.options(options),
+	}
+	return wlt.newTransaction("Transfer", args)
+}
+
+func addressStrings(addrs []core.Address) []string {
+	strAddrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		strAddrs[i] = addr.String()
+	}
+	return strAddrs
+}
+
+func changeString(change core.Address) string {
+	if change == nil {
+		return ""
+	}
+	return change.String()
+}
+
+// SendFromAddress instantiates unsigned transaction to send funds from specific source addresses
+func (wlt *ExternalWallet) SendFromAddress(from []core.Address, to []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	args := SendArgs{
+		Env:      wlt.env,
+		WalletID: wlt.info.Id,
+		From:     addressStrings(from),
+		To:       newOutputDataFrom(to),
+		Change:   changeString(change),
+		Options:  wlt.options(options),
+	}
+	return wlt.newTransaction("SendFromAddress", args)
+}
+
+// Spend instantiate unsigned transaction spending specific wallet outputs
+func (wlt *ExternalWallet) Spend(unspent, new []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	ids := make([]string, len(unspent))
+	for i, out := range unspent {
+		ids[i] = out.GetId()
+	}
+	args := SendArgs{
+		Env:      wlt.env,
+		WalletID: wlt.info.Id,
+		Unspent:  ids,
+		To:       newOutputDataFrom(new),
+		Change:   changeString(change),
+		Options:  wlt.options(options),
+	}
+	return wlt.newTransaction("Spend", args)
+}
+
+// GenAddresses discover new addresses based on default hierarchically deterministic derivation sequences
+func (wlt *ExternalWallet) GenAddresses(addrType core.AddressType, startIndex, count uint32, pwd core.PasswordReader) core.AddressIterator {
+	password, err := wlt.readPassword("GenAddresses", pwd)
+	if err != nil {
+		logWallet.WithError(err).Error("Something was wrong entering the password")
+		return nil
+	}
+	args := GenAddressesArgs{
+		Env:        wlt.env,
+		WalletID:   wlt.info.Id,
+		AddrType:   addrType,
+		StartIndex: startIndex,
+		Count:      count,
+		Password:   password,
+	}
+	var data []AddressData
+	if err := wlt.plugin.call("GenAddresses", args, &data); err != nil {
+		logWallet.WithError(err).Error("Couldn't generate addresses")
+		return nil
+	}
+	return newExternalAddressIterator(data, wlt.plugin)
+}
+
+// GetCryptoAccount instantiate object to determine wallet balance and transaction history
+func (wlt *ExternalWallet) GetCryptoAccount() core.CryptoAccount {
+	return &ExternalAccount{plugin: wlt.plugin, args: AccountArgs{Env: wlt.env, WalletID: wlt.info.Id}}
+}
+
+// GetLoadedAddresses iterates over wallet addresses discovered and known to have previous history and coins
+func (wlt *ExternalWallet) GetLoadedAddresses() (core.AddressIterator, error) {
+	var data []AddressData
+	if err := wlt.plugin.call("LoadedAddresses", wlt.args(), &data); err != nil {
+		return nil, err
+	}
+	return newExternalAddressIterator(data, wlt.plugin), nil
+}
+
+// Sign creates a new transaction by (fully or partially) choosing a strategy to sign given transaction.
+// Signers other than the wallet itself are resolved by ID inside plugin process.
+func (wlt *ExternalWallet) Sign(txn core.Transaction, signer core.TxnSigner, pwd core.PasswordReader, index []string) (core.Transaction, error) {
+	var signerID core.UID
+	if signer != nil && signer != core.TxnSigner(wlt) {
+		var err error
+		if signerID, err = signer.GetSignerUID(); err != nil {
+			return nil, err
+		}
+	}
+	return wlt.sign(txn, signerID, pwd, index)
+}
+
+func (wlt *ExternalWallet) sign(txn core.Transaction, signerID core.UID, pwd core.PasswordReader, index []string) (core.Transaction, error) {
+	extTxn, isExternal := txn.(*ExternalTransaction)
+	if !isExternal {
+		return nil, fce.ErrInvalidTxn
+	}
+	handle, err := extTxn.handle(wlt.plugin)
+	if err != nil {
+		return nil, err
+	}
+	password, err := wlt.readPassword("SignTransaction", pwd)
+	if err != nil {
+		return nil, err
+	}
+	args := SignArgs{
+		Env:      wlt.env,
+		WalletID: wlt.info.Id,
+		Txn:      handle,
+		SignerID: signerID,
+		Password: password,
+		Index:    index,
+	}
+	return wlt.newTransaction("Sign", args)
+}
+
+// ReadyForTxn determines whether this wallet can sign given transaction
+func (wlt *ExternalWallet) ReadyForTxn(w core.Wallet, txn core.Transaction) (bool, error) {
+	if !wlt.info.IsSigner || w == nil || w.GetId() != wlt.info.Id {
+		return false, nil
+	}
+	extTxn, isExternal := txn.(*ExternalTransaction)
+	if !isExternal {
+		return false, nil
+	}
+	handle, err := extTxn.handle(wlt.plugin)
+	if err != nil {
+		return false, nil
+	}
+	var isReady bool
+	args := SignArgs{Env: wlt.env, WalletID: wlt.info.Id, Txn: handle}
+	if err := wlt.plugin.call("ReadyForTxn", args, &isReady); err != nil {
+		return false, err
+	}
+	return isReady, nil
+}
+
+// SignTransaction signs transaction using wallet signing strategy
+func (wlt *ExternalWallet) SignTransaction(txn core.Transaction, pwd core.PasswordReader, index []string) (core.Transaction, error) {
+	if !wlt.info.IsSigner {
+		return nil, fce.ErrWalletCantSign
+	}
+	return wlt.sign(txn, "", pwd, index)
+}
+
+func (wlt *ExternalWallet) signerInfo() (SignerInfo, error) {
+	var info SignerInfo
+	if !wlt.info.IsSigner {
+		return info, fce.ErrWalletCantSign
+	}
+	err := wlt.plugin.call("SignerInfo", wlt.args(), &info)
+	return info, err
+}
+
+// GetSignerUID returns ID of wallet signing strategy
+func (wlt *ExternalWallet) GetSignerUID() (core.UID, error) {
+	info, err := wlt.signerInfo()
+	return info.UID, err
+}
+
+// GetSignerDescription describes wallet signing strategy
+func (wlt *ExternalWallet) GetSignerDescription() (string, error) {
+	info, err := wlt.signerInfo()
+	return info.Description, err
+}
+
+// ExternalWalletIterator iterates over a sequence of wallets
+type ExternalWalletIterator struct {
+	current int
+	wallets []core.Wallet
+}
+
+// Value of wallet at iterator pointer position
+func (it *ExternalWalletIterator) Value() core.Wallet {
+	return it.wallets[it.current]
+}
+
+// Next discards current value and moves iteration pointer up to next item
+func (it *ExternalWalletIterator) Next() bool {
+	if it.HasNext() {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// HasNext may be used to query whether more items are to be expected in the sequence
+func (it *ExternalWalletIterator) HasNext() bool {
+	return (it.current + 1) < len(it.wallets)
+}
+
+// NewExternalWalletIterator instantiates iterator over wallets
+func NewExternalWalletIterator(wallets []core.Wallet) *ExternalWalletIterator {
+	return &ExternalWalletIterator{wallets: wallets, current: -1}
+}
+
+// Type assertions
+var (
+	_ core.WalletEnv      = &ExternalWalletEnv{}
+	_ core.WalletSet      = &ExternalWalletEnv{}
+	_ core.WalletStorage  = &ExternalWalletEnv{}
+	_ core.Wallet         = &ExternalWallet{}
+	_ core.TxnSigner      = &ExternalWallet{}
+	_ core.WalletIterator = &ExternalWalletIterator{}
+)
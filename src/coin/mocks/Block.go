@@ -119,6 +119,29 @@ func (_m *Block) GetTime() (core.Timestamp, error) {
 	return r0, r1
 }
 
+// GetTransactions provides a mock function with given fields:
+func (_m *Block) GetTransactions() (core.TransactionIterator, error) {
+	ret := _m.Called()
+
+	var r0 core.TransactionIterator
+	if rf, ok := ret.Get(0).(func() core.TransactionIterator); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(core.TransactionIterator)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVersion provides a mock function with given fields:
 func (_m *Block) GetVersion() (uint32, error) {
 	ret := _m.Called()
@@ -10,6 +10,119 @@ type BlockchainStatus struct {
 	mock.Mock
 }
 
+// GetAddressBalance provides a mock function with given fields: addr, ticker
+func (_m *BlockchainStatus) GetAddressBalance(addr string, ticker string) (uint64, error) {
+	ret := _m.Called(addr, ticker)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(string, string) uint64); ok {
+		r0 = rf(addr, ticker)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(addr, ticker)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAddressTransactions provides a mock function with given fields: addr
+func (_m *BlockchainStatus) GetAddressTransactions(addr string) (core.TransactionIterator, error) {
+	ret := _m.Called(addr)
+
+	var r0 core.TransactionIterator
+	if rf, ok := ret.Get(0).(func(string) core.TransactionIterator); ok {
+		r0 = rf(addr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(core.TransactionIterator)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(addr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockByHash provides a mock function with given fields: hash
+func (_m *BlockchainStatus) GetBlockByHash(hash string) (core.Block, error) {
+	ret := _m.Called(hash)
+
+	var r0 core.Block
+	if rf, ok := ret.Get(0).(func(string) core.Block); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(core.Block)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockByHeight provides a mock function with given fields: height
+func (_m *BlockchainStatus) GetBlockByHeight(height uint64) (core.Block, error) {
+	ret := _m.Called(height)
+
+	var r0 core.Block
+	if rf, ok := ret.Get(0).(func(uint64) core.Block); ok {
+		r0 = rf(height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(core.Block)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockRange provides a mock function with given fields: start, end
+func (_m *BlockchainStatus) GetBlockRange(start uint64, end uint64) (core.BlockIterator, error) {
+	ret := _m.Called(start, end)
+
+	var r0 core.BlockIterator
+	if rf, ok := ret.Get(0).(func(uint64, uint64) core.BlockIterator); ok {
+		r0 = rf(start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(core.BlockIterator)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCoinValue provides a mock function with given fields: coinvalue, ticker
 func (_m *BlockchainStatus) GetCoinValue(coinvalue core.CoinValueMetric, ticker string) (uint64, error) {
 	ret := _m.Called(coinvalue, ticker)
@@ -74,3 +187,26 @@ func (_m *BlockchainStatus) GetNumberOfBlocks() (uint64, error) {
 
 	return r0, r1
 }
+
+// GetTransaction provides a mock function with given fields: txid
+func (_m *BlockchainStatus) GetTransaction(txid string) (core.Transaction, error) {
+	ret := _m.Called(txid)
+
+	var r0 core.Transaction
+	if rf, ok := ret.Get(0).(func(string) core.Transaction); ok {
+		r0 = rf(txid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(core.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(txid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -46,7 +46,7 @@ func (_m *TransactionInput) GetId() string {
 }
 
 // GetSpentOutput provides a mock function with given fields:
-func (_m *TransactionInput) GetSpentOutput() core.TransactionOutput {
+func (_m *TransactionInput) GetSpentOutput() (core.TransactionOutput, error) {
 	ret := _m.Called()
 
 	var r0 core.TransactionOutput
@@ -58,7 +58,14 @@ func (_m *TransactionInput) GetSpentOutput() core.TransactionOutput {
 		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // SupportedAssets provides a mock function with given fields:
@@ -0,0 +1,485 @@
+package apicache
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logCache = logging.MustGetLogger("Skycoin API cache")
+
+// DefaultTipCheckInterval minimum time between queries of the chain tip height
+const DefaultTipCheckInterval = 5 * time.Second
+
+// Cache keeps Skycoin node responses shared by SkycoinAPI clients of a node.
+// Immutable data (confirmed transactions, spent outputs, blocks) is kept indefinitely.
+// Mutable data (balances, unspent outputs, pending transactions, ...) is dropped
+// whenever chain tip height changes or transactions are injected.
+type Cache struct {
+	tipCheckInterval time.Duration
+
+	mutex        sync.Mutex
+	tip          *readable.BlockchainProgress
+	lastTipCheck time.Time
+	txns         map[string]readable.TransactionWithStatus
+	txnsVerbose  map[string]readable.TransactionWithStatusVerbose
+	spentOutputs map[string]readable.SpentOutput
+	blocks       map[uint64]readable.Block
+	blockSeqs    map[string]uint64
+	mutable      map[string]interface{}
+	generation   uint64
+}
+
+// NewCache instantiates empty cache checking chain tip height at most once per tipCheckInterval
+func NewCache(tipCheckInterval time.Duration) *Cache {
+	return &Cache{
+		tipCheckInterval: tipCheckInterval,
+		txns:             make(map[string]readable.TransactionWithStatus),
+		txnsVerbose:      make(map[string]readable.TransactionWithStatusVerbose),
+		spentOutputs:     make(map[string]readable.SpentOutput),
+		blocks:           make(map[uint64]readable.Block),
+		blockSeqs:        make(map[string]uint64),
+		mutable:          make(map[string]interface{}),
+	}
+}
+
+// Wrap decorates client so that its responses are cached
+func (c *Cache) Wrap(skyApi skytypes.SkycoinAPI) skytypes.SkycoinAPI {
+	return &cachingAPI{api: skyApi, cache: c}
+}
+
+// storeBlock keeps block indexed by sequence number and hash, mutex must be held
+func (c *Cache) storeBlock(b readable.Block) {
+	c.blocks[b.Head.BkSeq] = b
+	c.blockSeqs[b.Head.Hash] = b.Head.BkSeq
+}
+
+// Invalidate drops mutable data
+func (c *Cache) Invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.invalidate()
+}
+
+func (c *Cache) invalidate() {
+	c.mutable = make(map[string]interface{})
+	c.generation++
+}
+
+// updateTip records chain tip, dropping mutable data if its height changed
+func (c *Cache) updateTip(progress *readable.BlockchainProgress) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.tip != nil && c.tip.Current != progress.Current {
+		logCache.WithField("height", progress.Current).Debug("Chain tip changed")
+		c.invalidate()
+	}
+	c.tip = progress
+	c.lastTipCheck = time.Now()
+}
+
+func (c *Cache) freshTip() *readable.BlockchainProgress {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.tip == nil || time.Since(c.lastTipCheck) >= c.tipCheckInterval {
+		return nil
+	}
+	return c.tip
+}
+
+// confirmations computes how deep in the chain a block is
+func confirmations(tip, blockSeq uint64) uint64 {
+	if tip < blockSeq {
+		return 1
+	}
+	return tip - blockSeq + 1
+}
+
+func (c *Cache) storeTxn(txn readable.TransactionWithStatus) {
+	if txn.Status.Confirmed {
+		c.txns[txn.Transaction.Hash] = txn
+	}
+}
+
+func (c *Cache) storeTxnVerbose(txn readable.TransactionWithStatusVerbose) {
+	if txn.Status.Confirmed {
+		c.txnsVerbose[txn.Transaction.Hash] = txn
+	}
+}
+
+// cachingAPI decorates SkycoinAPI client caching its responses
+type cachingAPI struct {
+	api   skytypes.SkycoinAPI
+	cache *Cache
+}
+
+// tip queries chain tip unless it was checked recently
+func (ca *cachingAPI) tip() (*readable.BlockchainProgress, error) {
+	if progress := ca.cache.freshTip(); progress != nil {
+		return progress, nil
+	}
+	progress, err := ca.api.BlockchainProgress()
+	if err != nil {
+		return nil, err
+	}
+	ca.cache.updateTip(progress)
+	return progress, nil
+}
+
+// mutable looks up mutable data, fetching it if not cached for current chain tip
+func (ca *cachingAPI) mutable(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	if _, err := ca.tip(); err != nil {
+		return fetch()
+	}
+	c := ca.cache
+	c.mutex.Lock()
+	value, isCached := c.mutable[key]
+	generation := c.generation
+	c.mutex.Unlock()
+	if isCached {
+		return value, nil
+	}
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	// Skip data fetched while chain tip was changing
+	if generation == c.generation {
+		c.mutable[key] = value
+	}
+	c.mutex.Unlock()
+	return value, nil
+}
+
+func addressesKey(method string, addrs []string) string {
+	return method + ":" + strings.Join(addrs, ",")
+}
+
+// Transaction Get transaction info by id
+func (ca *cachingAPI) Transaction(txid string) (*readable.TransactionWithStatus, error) {
+	c := ca.cache
+	c.mutex.Lock()
+	txn, isCached := c.txns[txid]
+	c.mutex.Unlock()
+	if !isCached {
+		res, err := ca.api.Transaction(txid)
+		if err != nil || res == nil {
+			return res, err
+		}
+		c.mutex.Lock()
+		c.storeTxn(*res)
+		c.mutex.Unlock()
+		return res, nil
+	}
+	if tip, err := ca.tip(); err == nil {
+		txn.Status.Height = confirmations(tip.Current, txn.Status.BlockSeq)
+	}
+	return &txn, nil
+}
+
+// Transactions Get transactions for addresses
+func (ca *cachingAPI) Transactions(addrs []string) ([]readable.TransactionWithStatus, error) {
+	res, err := ca.mutable(addressesKey("Transactions", addrs), func() (interface{}, error) {
+		txns, err := ca.api.Transactions(addrs)
+		if err != nil {
+			return nil, err
+		}
+		ca.cache.mutex.Lock()
+		for _, txn := range txns {
+			ca.cache.storeTxn(txn)
+		}
+		ca.cache.mutex.Unlock()
+		return txns, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]readable.TransactionWithStatus), nil
+}
+
+// TransactionVerbose Get transaction info by id. Include spent input data
+func (ca *cachingAPI) TransactionVerbose(txid string) (*readable.TransactionWithStatusVerbose, error) {
+	c := ca.cache
+	c.mutex.Lock()
+	txn, isCached := c.txnsVerbose[txid]
+	c.mutex.Unlock()
+	if !isCached {
+		res, err := ca.api.TransactionVerbose(txid)
+		if err != nil || res == nil {
+			return res, err
+		}
+		c.mutex.Lock()
+		c.storeTxnVerbose(*res)
+		c.mutex.Unlock()
+		return res, nil
+	}
+	if tip, err := ca.tip(); err == nil {
+		txn.Status.Height = confirmations(tip.Current, txn.Status.BlockSeq)
+	}
+	return &txn, nil
+}
+
+// TransactionsVerbose Get transactions for addresses. Include spent input data
+func (ca *cachingAPI) TransactionsVerbose(addrs []string) ([]readable.TransactionWithStatusVerbose, error) {
+	res, err := ca.mutable(addressesKey("TransactionsVerbose", addrs), func() (interface{}, error) {
+		txns, err := ca.api.TransactionsVerbose(addrs)
+		if err != nil {
+			return nil, err
+		}
+		ca.cache.mutex.Lock()
+		for _, txn := range txns {
+			ca.cache.storeTxnVerbose(txn)
+		}
+		ca.cache.mutex.Unlock()
+		return txns, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]readable.TransactionWithStatusVerbose), nil
+}
+
+// UxOut Get uxout
+func (ca *cachingAPI) UxOut(uxID string) (*readable.SpentOutput, error) {
+	c := ca.cache
+	c.mutex.Lock()
+	out, isCached := c.spentOutputs[uxID]
+	c.mutex.Unlock()
+	if isCached {
+		return &out, nil
+	}
+	res, err := ca.api.UxOut(uxID)
+	if err != nil || res == nil {
+		return res, err
+	}
+	// Unspent outputs will be spent eventually
+	if res.SpentBlockSeq != 0 {
+		c.mutex.Lock()
+		c.spentOutputs[uxID] = *res
+		c.mutex.Unlock()
+	}
+	return res, nil
+}
+
+// PendingTransactionsVerbose Get unconfirmed transactions
+func (ca *cachingAPI) PendingTransactionsVerbose() ([]readable.UnconfirmedTransactionVerbose, error) {
+	res, err := ca.mutable("PendingTransactionsVerbose", func() (interface{}, error) {
+		return ca.api.PendingTransactionsVerbose()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.([]readable.UnconfirmedTransactionVerbose), nil
+}
+
+// CoinSupply Determine coin supply
+func (ca *cachingAPI) CoinSupply() (*api.CoinSupply, error) {
+	res, err := ca.mutable("CoinSupply", func() (interface{}, error) {
+		return ca.api.CoinSupply()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*api.CoinSupply), nil
+}
+
+// LastBlocks Get last N blocks
+func (ca *cachingAPI) LastBlocks(n uint64) (*readable.Blocks, error) {
+	c := ca.cache
+	if tip, err := ca.tip(); err == nil && n <= tip.Current+1 {
+		blocks := make([]readable.Block, 0, n)
+		c.mutex.Lock()
+		for seq := tip.Current + 1 - n; seq <= tip.Current; seq++ {
+			b, isCached := c.blocks[seq]
+			if !isCached {
+				break
+			}
+			blocks = append(blocks, b)
+		}
+		c.mutex.Unlock()
+		if uint64(len(blocks)) == n {
+			return &readable.Blocks{Blocks: blocks}, nil
+		}
+	}
+	res, err := ca.api.LastBlocks(n)
+	if err != nil || res == nil {
+		return res, err
+	}
+	c.mutex.Lock()
+	for _, b := range res.Blocks {
+		c.storeBlock(b)
+	}
+	c.mutex.Unlock()
+	return res, nil
+}
+
+// BlockchainProgress Get blockchain progress
+func (ca *cachingAPI) BlockchainProgress() (*readable.BlockchainProgress, error) {
+	return ca.tip()
+}
+
+// BlockBySeq Get block by sequence number
+func (ca *cachingAPI) BlockBySeq(seq uint64) (*readable.Block, error) {
+	c := ca.cache
+	c.mutex.Lock()
+	b, isCached := c.blocks[seq]
+	c.mutex.Unlock()
+	if isCached {
+		return &b, nil
+	}
+	res, err := ca.api.BlockBySeq(seq)
+	if err != nil || res == nil {
+		return res, err
+	}
+	c.mutex.Lock()
+	c.storeBlock(*res)
+	c.mutex.Unlock()
+	return res, nil
+}
+
+// BlockByHash Get block by header hash
+func (ca *cachingAPI) BlockByHash(hash string) (*readable.Block, error) {
+	c := ca.cache
+	c.mutex.Lock()
+	seq, isCached := c.blockSeqs[hash]
+	b := c.blocks[seq]
+	c.mutex.Unlock()
+	if isCached {
+		return &b, nil
+	}
+	res, err := ca.api.BlockByHash(hash)
+	if err != nil || res == nil {
+		return res, err
+	}
+	c.mutex.Lock()
+	c.storeBlock(*res)
+	c.mutex.Unlock()
+	return res, nil
+}
+
+// Health Get node health, version and coin identity
+func (ca *cachingAPI) Health() (*api.HealthResponse, error) {
+	return ca.api.Health()
+}
+
+// Balance Get balance of addresses
+func (ca *cachingAPI) Balance(addrs []string) (*api.BalanceResponse, error) {
+	res, err := ca.mutable(addressesKey("Balance", addrs), func() (interface{}, error) {
+		return ca.api.Balance(addrs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*api.BalanceResponse), nil
+}
+
+// OutputsForAddresses Get historical unspent outputs for an address
+func (ca *cachingAPI) OutputsForAddresses(addrs []string) (*readable.UnspentOutputsSummary, error) {
+	res, err := ca.mutable(addressesKey("OutputsForAddresses", addrs), func() (interface{}, error) {
+		return ca.api.OutputsForAddresses(addrs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*readable.UnspentOutputsSummary), nil
+}
+
+// Wallet Get wallet
+func (ca *cachingAPI) Wallet(id string) (*api.WalletResponse, error) {
+	return ca.api.Wallet(id)
+}
+
+// UpdateWallet Change wallet label
+func (ca *cachingAPI) UpdateWallet(id, label string) error {
+	return ca.api.UpdateWallet(id, label)
+}
+
+// NewWalletAddress Generate new address in wallet
+func (ca *cachingAPI) NewWalletAddress(id string, n int, password string) ([]string, error) {
+	return ca.api.NewWalletAddress(id, n, password)
+}
+
+// Wallets Get wallets
+func (ca *cachingAPI) Wallets() ([]api.WalletResponse, error) {
+	return ca.api.Wallets()
+}
+
+// CreateWallet Create wallet
+func (ca *cachingAPI) CreateWallet(o api.CreateWalletOptions) (*api.WalletResponse, error) {
+	return ca.api.CreateWallet(o)
+}
+
+// EncryptWallet Encrypt wallet
+func (ca *cachingAPI) EncryptWallet(id, password string) (*api.WalletResponse, error) {
+	return ca.api.EncryptWallet(id, password)
+}
+
+// DecryptWallet Decrypt wallet
+func (ca *cachingAPI) DecryptWallet(id, password string) (*api.WalletResponse, error) {
+	return ca.api.DecryptWallet(id, password)
+}
+
+// WalletBalance Get wallet balance
+func (ca *cachingAPI) WalletBalance(id string) (*api.BalanceResponse, error) {
+	return ca.api.WalletBalance(id)
+}
+
+// WalletUnconfirmedTransactionsVerbose Get unconfirmed transactions of a wallet
+func (ca *cachingAPI) WalletUnconfirmedTransactionsVerbose(id string) (*api.UnconfirmedTxnsVerboseResponse, error) {
+	return ca.api.WalletUnconfirmedTransactionsVerbose(id)
+}
+
+// NetworkConnections Get a list of all connections
+func (ca *cachingAPI) NetworkConnections(filters *api.NetworkConnectionsFilter) (*api.Connections, error) {
+	return ca.api.NetworkConnections(filters)
+}
+
+// InjectTransaction Inject transaction
+func (ca *cachingAPI) InjectTransaction(txn *coin.Transaction) (string, error) {
+	txid, err := ca.api.InjectTransaction(txn)
+	if err == nil {
+		ca.cache.Invalidate()
+	}
+	return txid, err
+}
+
+// InjectEncodedTransaction Inject raw transaction
+func (ca *cachingAPI) InjectEncodedTransaction(rawTxn string) (string, error) {
+	txid, err := ca.api.InjectEncodedTransaction(rawTxn)
+	if err == nil {
+		ca.cache.Invalidate()
+	}
+	return txid, err
+}
+
+// VerifyTransaction Decode and verify raw transaction without injecting it
+func (ca *cachingAPI) VerifyTransaction(req api.VerifyTransactionRequest) (*api.VerifyTransactionResponse, error) {
+	return ca.api.VerifyTransaction(req)
+}
+
+// WalletSignTransaction Sign transaction
+func (ca *cachingAPI) WalletSignTransaction(req api.WalletSignTransactionRequest) (*api.CreateTransactionResponse, error) {
+	return ca.api.WalletSignTransaction(req)
+}
+
+// WalletCreateTransaction Create transaction from wallet addresses
+func (ca *cachingAPI) WalletCreateTransaction(req api.WalletCreateTransactionRequest) (*api.CreateTransactionResponse, error) {
+	return ca.api.WalletCreateTransaction(req)
+}
+
+// CreateTransaction Create transaction from unspent outputs or addresses
+func (ca *cachingAPI) CreateTransaction(req api.CreateTransactionRequest) (*api.CreateTransactionResponse, error) {
+	return ca.api.CreateTransaction(req)
+}
+
+// Type assertions
+var (
+	_ skytypes.SkycoinAPI = &cachingAPI{}
+)
@@ -0,0 +1,150 @@
+package apicache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skymocks"
+	"github.com/stretchr/testify/require"
+)
+
+// mockNode mocks node whose chain tip is at height
+func mockNode(height *uint64) *skymocks.SkycoinAPI {
+	skyMock := new(skymocks.SkycoinAPI)
+	skyMock.On("BlockchainProgress").Return(func() *readable.BlockchainProgress {
+		return &readable.BlockchainProgress{Current: *height, Highest: *height}
+	}, nil)
+	return skyMock
+}
+
+func TestCacheConfirmedTransaction(t *testing.T) {
+	height := uint64(10)
+	skyMock := mockNode(&height)
+	confirmed := &readable.TransactionWithStatus{
+		Status:      readable.TransactionStatus{Confirmed: true, Height: 3, BlockSeq: 8},
+		Transaction: readable.Transaction{Hash: "confirmed"},
+	}
+	pending := &readable.TransactionWithStatus{
+		Status:      readable.TransactionStatus{Unconfirmed: true},
+		Transaction: readable.Transaction{Hash: "pending"},
+	}
+	skyMock.On("Transaction", "confirmed").Return(confirmed, nil)
+	skyMock.On("Transaction", "pending").Return(pending, nil)
+	skyApi := NewCache(0).Wrap(skyMock)
+
+	for i := 0; i < 2; i++ {
+		txn, err := skyApi.Transaction("confirmed")
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), txn.Status.Height)
+		_, err = skyApi.Transaction("pending")
+		require.NoError(t, err)
+	}
+	skyMock.AssertNumberOfCalls(t, "Transaction", 3)
+
+	// Confirmations are updated as chain grows
+	height = 12
+	txn, err := skyApi.Transaction("confirmed")
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), txn.Status.Height)
+	skyMock.AssertNumberOfCalls(t, "Transaction", 3)
+}
+
+func TestCacheSpentOutput(t *testing.T) {
+	height := uint64(10)
+	skyMock := mockNode(&height)
+	skyMock.On("UxOut", "spent").Return(&readable.SpentOutput{Uxid: "spent", SpentBlockSeq: 5}, nil)
+	skyMock.On("UxOut", "unspent").Return(&readable.SpentOutput{Uxid: "unspent"}, nil)
+	skyApi := NewCache(0).Wrap(skyMock)
+
+	for i := 0; i < 2; i++ {
+		out, err := skyApi.UxOut("spent")
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), out.SpentBlockSeq)
+		_, err = skyApi.UxOut("unspent")
+		require.NoError(t, err)
+	}
+	skyMock.AssertNumberOfCalls(t, "UxOut", 3)
+}
+
+func TestCacheMutableData(t *testing.T) {
+	height := uint64(10)
+	skyMock := mockNode(&height)
+	addrs := []string{"addr1", "addr2"}
+	skyMock.On("Balance", addrs).Return(&api.BalanceResponse{}, nil)
+	skyMock.On("PendingTransactionsVerbose").Return([]readable.UnconfirmedTransactionVerbose{}, nil)
+	skyMock.On("InjectEncodedTransaction", "rawtxn").Return("txid", nil)
+	skyApi := NewCache(0).Wrap(skyMock)
+
+	for i := 0; i < 2; i++ {
+		_, err := skyApi.Balance(addrs)
+		require.NoError(t, err)
+		_, err = skyApi.PendingTransactionsVerbose()
+		require.NoError(t, err)
+	}
+	skyMock.AssertNumberOfCalls(t, "Balance", 1)
+	skyMock.AssertNumberOfCalls(t, "PendingTransactionsVerbose", 1)
+
+	// Chain tip changes
+	height = 11
+	_, err := skyApi.Balance(addrs)
+	require.NoError(t, err)
+	skyMock.AssertNumberOfCalls(t, "Balance", 2)
+
+	// Injected transactions change pending pool
+	_, err = skyApi.InjectEncodedTransaction("rawtxn")
+	require.NoError(t, err)
+	_, err = skyApi.PendingTransactionsVerbose()
+	require.NoError(t, err)
+	skyMock.AssertNumberOfCalls(t, "PendingTransactionsVerbose", 2)
+}
+
+func TestCacheTipCheckInterval(t *testing.T) {
+	height := uint64(10)
+	skyMock := mockNode(&height)
+	skyApi := NewCache(time.Hour).Wrap(skyMock)
+
+	for i := 0; i < 3; i++ {
+		progress, err := skyApi.BlockchainProgress()
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), progress.Current)
+	}
+	skyMock.AssertNumberOfCalls(t, "BlockchainProgress", 1)
+}
+
+func TestCacheLastBlocks(t *testing.T) {
+	height := uint64(10)
+	skyMock := mockNode(&height)
+	block := func(seq uint64) readable.Block {
+		return readable.Block{Head: readable.BlockHeader{BkSeq: seq}}
+	}
+	skyMock.On("LastBlocks", uint64(2)).Return(func(n uint64) *readable.Blocks {
+		return &readable.Blocks{Blocks: []readable.Block{block(height - 1), block(height)}}
+	}, nil)
+	skyApi := NewCache(0).Wrap(skyMock)
+
+	for i := 0; i < 2; i++ {
+		blocks, err := skyApi.LastBlocks(2)
+		require.NoError(t, err)
+		require.Equal(t, []readable.Block{block(9), block(10)}, blocks.Blocks)
+	}
+	skyMock.AssertNumberOfCalls(t, "LastBlocks", 1)
+
+	height = 11
+	blocks, err := skyApi.LastBlocks(2)
+	require.NoError(t, err)
+	require.Equal(t, []readable.Block{block(10), block(11)}, blocks.Blocks)
+	skyMock.AssertNumberOfCalls(t, "LastBlocks", 2)
+
+	// Blocks served by LastBlocks are looked up by sequence without reaching node
+	b, err := skyApi.BlockBySeq(10)
+	require.NoError(t, err)
+	require.Equal(t, block(10), *b)
+	skyMock.On("BlockBySeq", uint64(0)).Return(&readable.Block{}, nil).Once()
+	for i := 0; i < 2; i++ {
+		_, err = skyApi.BlockBySeq(0)
+		require.NoError(t, err)
+	}
+	skyMock.AssertNumberOfCalls(t, "BlockBySeq", 1)
+}
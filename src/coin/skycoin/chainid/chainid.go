@@ -0,0 +1,128 @@
+package chainid
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+const (
+	// PropertyGenesisBlockHash genesis block hash differs
+	PropertyGenesisBlockHash = "genesis block hash"
+	// PropertyCoinName coin name reported by node differs
+	PropertyCoinName = "coin name"
+	// PropertyDistribution distribution addresses differ
+	PropertyDistribution = "distribution"
+)
+
+// MismatchError node serves a chain other than the one described by coin params
+type MismatchError struct {
+	// Property of chain identity that differs
+	Property string
+	// Expected value according to coin params
+	Expected string
+	// Actual value reported by node
+	Actual string
+}
+
+// Error explains which property differs
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("%v: %s is %s, expected %s", fce.ErrChainMismatch, e.Property, e.Actual, e.Expected)
+}
+
+// IsMismatch tells whether err means node is on a different chain
+func IsMismatch(err error) bool {
+	_, isMismatch := err.(*MismatchError)
+	return isMismatch
+}
+
+// normalizeName makes names like `Fiber Test` and `fibertest` comparable
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), ""))
+}
+
+// Verify checks that node is on the chain described by coin params.
+// Genesis block hash, coin name and distribution addresses are compared
+// whenever params define them. Nodes not reporting their coin name are
+// identified by the other properties only.
+func Verify(skyApi skytypes.SkycoinAPI, p params.SkyFiberParams) error {
+	if p.GenesisBlockHash != "" {
+		genesis, err := skyApi.BlockBySeq(0)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(genesis.Head.Hash, p.GenesisBlockHash) {
+			return &MismatchError{Property: PropertyGenesisBlockHash, Expected: p.GenesisBlockHash, Actual: genesis.Head.Hash}
+		}
+	}
+
+	health, err := skyApi.Health()
+	if err != nil {
+		return err
+	}
+	if health.CoinName != "" {
+		name := normalizeName(p.Name)
+		if name != normalizeName(health.CoinName) && name != normalizeName(health.Fiber.DisplayName) {
+			return &MismatchError{Property: PropertyCoinName, Expected: p.Name, Actual: health.CoinName}
+		}
+	}
+
+	if len(p.Distribution.Addresses) != 0 {
+		supply, err := skyApi.CoinSupply()
+		if err != nil {
+			return err
+		}
+		actual := append(append([]string{}, supply.UnlockedAddresses...), supply.LockedAddresses...)
+		if missing, unexpected := diff(p.Distribution.Addresses, actual); missing != "" || unexpected != "" {
+			found := fmt.Sprintf("%d addresses", len(actual))
+			if missing != "" {
+				found += " lacking " + missing
+			}
+			if unexpected != "" {
+				found += " with " + unexpected
+			}
+			return &MismatchError{
+				Property: PropertyDistribution,
+				Expected: fmt.Sprintf("%d addresses", len(p.Distribution.Addresses)),
+				Actual:   found,
+			}
+		}
+	}
+	return nil
+}
+
+// diff returns first expected address missing in actual and first unexpected one, if any
+func diff(expected, actual []string) (missing, unexpected string) {
+	inExpected := make(map[string]bool, len(expected))
+	for _, addr := range expected {
+		inExpected[addr] = true
+	}
+	inActual := make(map[string]bool, len(actual))
+	for _, addr := range actual {
+		inActual[addr] = true
+	}
+	sorted := func(addrs []string) []string {
+		s := append([]string{}, addrs...)
+		sort.Strings(s)
+		return s
+	}
+	for _, addr := range sorted(expected) {
+		if !inActual[addr] {
+			missing = addr
+			break
+		}
+	}
+	for _, addr := range sorted(actual) {
+		if !inExpected[addr] {
+			unexpected = addr
+			break
+		}
+	}
+	return missing, unexpected
+}
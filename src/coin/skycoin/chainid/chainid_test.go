@@ -0,0 +1,70 @@
+package chainid
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/sandbox"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skymocks"
+	"github.com/stretchr/testify/require"
+)
+
+// sandboxParams params of the chain served by sandbox node
+func sandboxParams(t *testing.T, node *sandbox.Node) params.SkyFiberParams {
+	genesis, err := node.BlockBySeq(0)
+	require.NoError(t, err)
+	p := params.SkycoinMainNetParams
+	p.Name = "Sandbox"
+	p.GenesisBlockHash = genesis.Head.Hash
+	p.Distribution.Addresses = []string{node.FaucetAddress()}
+	return p
+}
+
+func TestVerify(t *testing.T) {
+	node := sandbox.NewNode()
+	require.NoError(t, Verify(node, sandboxParams(t, node)))
+
+	err := Verify(node, params.SkycoinMainNetParams)
+	require.True(t, IsMismatch(err))
+	require.Equal(t, PropertyGenesisBlockHash, err.(*MismatchError).Property)
+
+	p := sandboxParams(t, node)
+	p.Name = "Skycoin"
+	err = Verify(node, p)
+	require.True(t, IsMismatch(err))
+	require.Equal(t, &MismatchError{Property: PropertyCoinName, Expected: "Skycoin", Actual: sandbox.CoinName}, err)
+
+	p = sandboxParams(t, node)
+	p.Distribution.Addresses = params.SkycoinMainNetParams.Distribution.Addresses
+	err = Verify(node, p)
+	require.True(t, IsMismatch(err))
+	require.Equal(t, PropertyDistribution, err.(*MismatchError).Property)
+	require.Contains(t, err.Error(), node.FaucetAddress())
+}
+
+func TestVerifyUnnamedCoin(t *testing.T) {
+	p := params.SkycoinMainNetParams
+	skyMock := new(skymocks.SkycoinAPI)
+	genesis := &readable.Block{Head: readable.BlockHeader{Hash: p.GenesisBlockHash}}
+	skyMock.On("BlockBySeq", uint64(0)).Return(genesis, nil)
+	skyMock.On("Health").Return(&api.HealthResponse{}, nil)
+	skyMock.On("CoinSupply").Return(&api.CoinSupply{
+		UnlockedAddresses: p.Distribution.Addresses[:25],
+		LockedAddresses:   p.Distribution.Addresses[25:],
+	}, nil)
+
+	require.NoError(t, Verify(skyMock, p))
+}
+
+func TestVerifyUnavailable(t *testing.T) {
+	errDown := errors.New("connection refused")
+	skyMock := new(skymocks.SkycoinAPI)
+	skyMock.On("BlockBySeq", uint64(0)).Return(nil, errDown)
+
+	err := Verify(skyMock, params.SkycoinMainNetParams)
+	require.Equal(t, errDown, err)
+	require.False(t, IsMismatch(err))
+}
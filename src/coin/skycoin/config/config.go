@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/params"
 	local "github.com/fibercrypto/fibercryptowallet/src/main"
 	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
 )
@@ -19,7 +20,25 @@ const (
 	SettingPathToLog          = "log"
 	SettingPathToNode         = "node"
 	SettingNodeAddress        = "address"
+	SettingNodeRecordFile     = "record"
+	SettingNodeMaxRetries     = "maxRetries"
+	SettingNodeRateLimit      = "rateLimit"
+	SettingNodeFailures       = "failureThreshold"
+	SettingNodeOpenTimeout    = "openTimeout"
+	SettingNodeCredentials    = "credentials"
+	SettingNodeCAFile         = "caFile"
+	SettingNodeFingerprint    = "fingerprint"
+	SettingNodeTimeout        = "timeout"
+	SettingNodeProxy          = "proxy"
+	SettingNodeVerify         = "verify"
+	SettingNodeMirrors        = "mirrors"
+	SettingNodeQuorum         = "quorum"
+	SettingNodeSendGating     = "sendGating"
 	SettingPathToWalletSource = "walletSource"
+	SettingPathToFiberCoins   = "fiberCoins"
+	SettingFiberCoinsFile     = "file"
+	SettingPathToProxy        = "proxy"
+	SettingProxyAddress       = "address"
 )
 
 var (
@@ -80,7 +99,21 @@ func RegisterConfig() error {
 
 	logOutputFileOpt := local.NewOption(SettingPathToLog, []string{}, false, string(outputFileBytes))
 
-	sectionManager = cm.RegisterSection(SectionName, []*local.Option{nodeOpt, wltOpt, logLevelOpt, logOutputOpt, logOutputFileOpt})
+	fiberCoins := map[string]string{SettingFiberCoinsFile: ""}
+	fiberCoinsBytes, err := json.Marshal(fiberCoins)
+	if err != nil {
+		return err
+	}
+	fiberCoinsOpt := local.NewOption(SettingPathToFiberCoins, []string{}, false, string(fiberCoinsBytes))
+
+	proxy := map[string]string{SettingProxyAddress: ""}
+	proxyBytes, err := json.Marshal(proxy)
+	if err != nil {
+		return err
+	}
+	proxyOpt := local.NewOption(SettingPathToProxy, []string{}, false, string(proxyBytes))
+
+	sectionManager = cm.RegisterSection(SectionName, []*local.Option{nodeOpt, wltOpt, logLevelOpt, logOutputOpt, logOutputFileOpt, fiberCoinsOpt, proxyOpt})
 	return nil
 }
 
@@ -122,7 +155,7 @@ func getFromCache(cacheKeyValue string) uint64 {
 	}
 	val, err := strconv.ParseUint(strVal, 10, 64)
 	if err != nil {
-		log.WithError(err).Warn("Couldn't parse %s to int", strVal)
+		log.WithError(err).Warnf("Couldn't parse %s to int", strVal)
 		return 0
 	}
 	return val
@@ -170,3 +203,37 @@ type walletSource struct {
 	Tp     string `json:"SourceType"`
 	Source string `json:"Source"`
 }
+
+// GetFiberCoins loads SkyFiber coins defined in configuration file, if any
+func GetFiberCoins() ([]params.SkyFiberParams, error) {
+	fiberCoinsStr, err := GetOption(SettingPathToFiberCoins)
+	if err != nil {
+		return nil, err
+	}
+	fiberCoins := make(map[string]string)
+	if err := json.Unmarshal([]byte(fiberCoinsStr), &fiberCoins); err != nil {
+		return nil, err
+	}
+	path := fiberCoins[SettingFiberCoinsFile]
+	if path == "" {
+		return nil, nil
+	}
+	usr, err := user.Current()
+	if err != nil {
+		return nil, err
+	}
+	return params.LoadFiberCoins(path, usr.HomeDir)
+}
+
+// GetProxy returns SOCKS5 proxy URL through which nodes are reached, if any
+func GetProxy() (string, error) {
+	proxyStr, err := GetOption(SettingPathToProxy)
+	if err != nil {
+		return "", err
+	}
+	proxy := make(map[string]string)
+	if err := json.Unmarshal([]byte(proxyStr), &proxy); err != nil {
+		return "", err
+	}
+	return proxy[SettingProxyAddress], nil
+}
@@ -2,10 +2,17 @@ package skycoin //nolint goimports
 
 import (
 	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
 
 	skylog "github.com/SkycoinProject/skycoin/src/util/logging"
 	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/config"
 	sky "github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/models"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/nodeclient"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/replay"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/resilience"
 	"github.com/fibercrypto/fibercryptowallet/src/core"
 	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
 
@@ -17,11 +24,141 @@ import (
 var logSkycoin = logging.MustGetLogger("Skycoin Altcoin")
 
 func init() {
-	UpdateAltcoin()
+	if err := UpdateAltcoin(); err != nil {
+		logSkycoin.WithError(err).Error("Couldn't apply Skycoin node settings")
+	}
+}
+
+// nodePolicy overrides default node resilience policy with node settings
+func nodePolicy(node map[string]string) resilience.Policy {
+	policy := resilience.DefaultPolicy()
+	if v, isSet := node[config.SettingNodeMaxRetries]; isSet {
+		if retries, err := strconv.ParseUint(v, 10, 64); err == nil {
+			policy.MaxRetries = retries
+		} else {
+			logSkycoin.WithError(err).Warn("Invalid node max retries")
+		}
+	}
+	if v, isSet := node[config.SettingNodeRateLimit]; isSet {
+		if rate, err := strconv.ParseFloat(v, 64); err == nil {
+			policy.RateLimit = rate
+		} else {
+			logSkycoin.WithError(err).Warn("Invalid node rate limit")
+		}
+	}
+	if v, isSet := node[config.SettingNodeFailures]; isSet {
+		if failures, err := strconv.Atoi(v); err == nil {
+			policy.FailureThreshold = failures
+		} else {
+			logSkycoin.WithError(err).Warn("Invalid node failure threshold")
+		}
+	}
+	if v, isSet := node[config.SettingNodeOpenTimeout]; isSet {
+		if timeout, err := time.ParseDuration(v); err == nil {
+			policy.OpenTimeout = timeout
+		} else {
+			logSkycoin.WithError(err).Warn("Invalid node open circuit timeout")
+		}
+	}
+	return policy
 }
 
-// Refresh Skycoin Altcoin node settings
-func UpdateAltcoin() {
+// nodeOptions reads node authentication, TLS, timeout and proxy settings.
+// Credentials are loaded from the file referenced by node settings,
+// failing to do so is reported rather than reaching the node without them.
+func nodeOptions(node map[string]string) (nodeclient.Options, error) {
+	opts := nodeclient.Options{
+		CAFile:      node[config.SettingNodeCAFile],
+		Fingerprint: node[config.SettingNodeFingerprint],
+		Proxy:       node[config.SettingNodeProxy],
+	}
+	if path := node[config.SettingNodeCredentials]; path != "" {
+		creds, err := nodeclient.LoadCredentials(path)
+		if err != nil {
+			logSkycoin.WithError(err).WithField("path", path).Error("Couldn't load node credentials")
+			return opts, err
+		}
+		opts.Credentials = creds
+	}
+	if v := node[config.SettingNodeTimeout]; v != "" {
+		if timeout, err := time.ParseDuration(v); err == nil {
+			opts.Timeout = timeout
+		} else {
+			logSkycoin.WithError(err).Warn("Invalid node timeout")
+		}
+	}
+	return opts, nil
+}
+
+// nodeMirrors reads comma separated mirror node URLs and quorum size
+func nodeMirrors(node map[string]string) ([]string, int) {
+	var urls []string
+	for _, url := range strings.Split(node[config.SettingNodeMirrors], ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	size := 0
+	if v := node[config.SettingNodeQuorum]; v != "" {
+		var err error
+		if size, err = strconv.Atoi(v); err != nil {
+			logSkycoin.WithError(err).Warn("Invalid node quorum")
+		}
+	}
+	return urls, size
+}
+
+// nodeSendGating reads whether sending through a node not ready is refused, the default, or only warned about
+func nodeSendGating(node map[string]string) sky.SendGating {
+	v := node[config.SettingNodeSendGating]
+	if v == "" {
+		return sky.SendGatingBlock
+	}
+	gating, err := sky.ParseSendGating(v)
+	if err != nil {
+		logSkycoin.WithError(err).WithField("sendGating", v).Warn("Invalid node send gating")
+		return sky.SendGatingBlock
+	}
+	return gating
+}
+
+// nodeFactory creates connection factory to coin nodes at address applying
+// resilience, authentication, TLS, mirrors, verification and send gating settings
+func nodeFactory(address string, node map[string]string, coin params.SkyFiberParams) (*sky.SkycoinConnectionFactory, error) {
+	opts, err := nodeOptions(node)
+	if err != nil {
+		return nil, err
+	}
+	factory := sky.NewSkycoinConnectionFactory(address)
+	factory.SetPolicy(nodePolicy(node))
+	factory.SetOptions(opts)
+	if mirrors, size := nodeMirrors(node); len(mirrors) > 0 {
+		factory.SetMirrors(mirrors, size)
+	}
+	factory.VerifyChain(coin)
+	if v := node[config.SettingNodeVerify]; v != "" {
+		if enabled, err := strconv.ParseBool(v); err == nil {
+			factory.VerifyResponses(enabled)
+		} else {
+			logSkycoin.WithError(err).Warn("Invalid node verify setting")
+		}
+	}
+	if recordFile := node[config.SettingNodeRecordFile]; recordFile != "" {
+		rec, err := replay.CreateRecorder(recordFile)
+		if err != nil {
+			logSkycoin.WithError(err).WithField("coin", coin.Name).Warn("Couldn't record node session")
+		} else {
+			logSkycoin.WithField("path", recordFile).WithField("coin", coin.Name).Info("Recording node session")
+			factory.RecordTo(rec)
+		}
+	}
+	sky.GetNodeStatus(coin.PoolSection).SetGating(nodeSendGating(node))
+	return factory, nil
+}
+
+// Refresh Skycoin Altcoin node settings.
+// Coins whose node can not be reached as configured are left out and the first such error is returned.
+func UpdateAltcoin() error {
 	err := config.RegisterConfig()
 	if err != nil {
 		logSkycoin.Warn("Couldn't register Skycoin configuration")
@@ -59,6 +196,14 @@ func UpdateAltcoin() {
 		}
 	}
 
+	// Proxy applies to every node unless overridden in node settings
+	proxy, err := config.GetProxy()
+	if err != nil {
+		logSkycoin.WithError(err).Warn("Couldn't get proxy settings")
+	} else if err = nodeclient.SetGlobalProxy(proxy); err != nil {
+		logSkycoin.WithError(err).WithField("proxy", proxy).Error("Invalid proxy")
+	}
+
 	nodeSettingStr, err := config.GetOption(config.SettingPathToNode)
 	if err != nil {
 		logSkycoin.Warn("Couldn't get node settings")
@@ -69,9 +214,36 @@ func UpdateAltcoin() {
 		logSkycoin.WithError(err).Warn("Couldn't unmarshal from options")
 	}
 
-	err = core.GetMultiPool().CreateSection(sky.PoolSection, sky.NewSkycoinConnectionFactory(node["address"]))
+	var nodeErr error
+	factory, err := nodeFactory(node[config.SettingNodeAddress], node, sky.SkycoinMainNetParams)
 	if err != nil {
-		logSkycoin.Warn("Couldn't create section for Skycoin")
+		nodeErr = err
+	} else {
+		err = core.GetMultiPool().CreateSection(sky.PoolSection, factory)
+		if err != nil {
+			logSkycoin.Warn("Couldn't create section for Skycoin")
+		}
+		util.RegisterAltcoin(sky.NewSkyFiberPlugin(sky.SkycoinMainNetParams))
+	}
+
+	fiberCoins, err := config.GetFiberCoins()
+	if err != nil {
+		logSkycoin.WithError(err).Warn("Couldn't load SkyFiber coins")
+	}
+	for _, fiberCoin := range fiberCoins {
+		fiberFactory, err := nodeFactory(fiberCoin.NodeAddress, fiberCoin.NodeSettings, fiberCoin)
+		if err != nil {
+			if nodeErr == nil {
+				nodeErr = err
+			}
+			continue
+		}
+		err = core.GetMultiPool().CreateSection(fiberCoin.PoolSection, fiberFactory)
+		if err != nil {
+			logSkycoin.WithError(err).Warnf("Couldn't create section for %s", fiberCoin.Name)
+			continue
+		}
+		util.RegisterAltcoin(sky.NewSkyFiberPlugin(fiberCoin))
 	}
-	util.RegisterAltcoin(sky.NewSkyFiberPlugin(sky.SkycoinMainNetParams))
+	return nodeErr
 }
@@ -1,8 +1,13 @@
 package skycoin //nolint goimports
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/config"
+	sky "github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/models"
 	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/params"
 
 	util "github.com/fibercrypto/fibercryptowallet/src/util"
@@ -14,3 +19,43 @@ func TestRegisterSkycoinPlugin(t *testing.T) {
 	require.Equal(t, "Coin Hours", util.AltcoinCaption(params.CoinHoursTicker))
 	require.Equal(t, "Calculated Hours", util.AltcoinCaption(params.CalculatedHoursTicker))
 }
+
+func TestFiberCoinNodeFactory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fibernode")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	recordFile := filepath.Join(dir, "fiber.rec")
+
+	fiberCoin := params.SkycoinMainNetParams
+	fiberCoin.Name = "Fiber Test"
+	fiberCoin.PoolSection = params.SkycoinPoolSection + ".ftc"
+	fiberCoin.NodeSettings = map[string]string{
+		config.SettingNodeSendGating: "off",
+		config.SettingNodeRecordFile: recordFile,
+	}
+	factory, err := nodeFactory("http://127.0.0.1:1", fiberCoin.NodeSettings, fiberCoin)
+	require.NoError(t, err)
+	require.NotNil(t, factory)
+	_, err = os.Stat(recordFile)
+	require.NoError(t, err)
+	require.NoError(t, sky.GetNodeStatus(fiberCoin.PoolSection).CheckSend())
+}
+
+func TestNodeOptionsCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nodecreds")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "credentials.json")
+
+	node := map[string]string{config.SettingNodeCredentials: path}
+	_, err = nodeOptions(node)
+	require.Error(t, err)
+	_, err = nodeFactory("http://127.0.0.1:1", node, params.SkycoinMainNetParams)
+	require.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"username":"user","password":"secret"}`), 0600))
+	opts, err := nodeOptions(node)
+	require.NoError(t, err)
+	require.Equal(t, "user", opts.Credentials.Username)
+	require.Equal(t, "secret", opts.Credentials.Password)
+}
@@ -17,8 +17,9 @@ import (
 var log = logging.MustGetLogger("Skycoin Account")
 
 func (addr *SkycoinAddress) GetBalance(ticker string) (uint64, error) {
+	fiberCoin := LookupFiberCoin(addr.poolSection)
 
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(addr.poolSection))
 	if err != nil {
 		log.WithError(err).Error("Couldn't get API client")
 		return 0, err
@@ -31,19 +32,20 @@ func (addr *SkycoinAddress) GetBalance(ticker string) (uint64, error) {
 		return 0, err
 	}
 
-	if ticker == Sky {
+	if ticker == fiberCoin.Ticker {
 		return bl.Confirmed.Coins, nil
-	} else if ticker == CoinHour {
+	} else if ticker == fiberCoin.CoinHoursTicker {
 		return bl.Confirmed.Hours, nil
 	} else {
 		return 0, errorTickerInvalid{ticker}
 	}
 }
 func (addr *SkycoinAddress) ListAssets() []string {
-	return []string{Sky, CoinHour}
+	fiberCoin := LookupFiberCoin(addr.poolSection)
+	return []string{fiberCoin.Ticker, fiberCoin.CoinHoursTicker}
 }
 func (addr *SkycoinAddress) ScanUnspentOutputs() (core.TransactionOutputIterator, error) {
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(addr.poolSection))
 	if err != nil {
 		log.WithError(err).Error("Couldn't get API client")
 		return nil, err
@@ -69,6 +71,7 @@ func (addr *SkycoinAddress) ScanUnspentOutputs() (core.TransactionOutputIterator
 			},
 			spent:           true,
 			calculatedHours: out.CalculatedHours,
+			poolSection:     addr.poolSection,
 		})
 	}
 
@@ -76,7 +79,7 @@ func (addr *SkycoinAddress) ScanUnspentOutputs() (core.TransactionOutputIterator
 }
 func (addr *SkycoinAddress) ListTransactions() core.TransactionIterator {
 
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(addr.poolSection))
 	if err != nil {
 		log.WithError(err).Error("Couldn't get API client")
 		return nil
@@ -90,16 +93,7 @@ func (addr *SkycoinAddress) ListTransactions() core.TransactionIterator {
 	}
 
 	for _, tx := range txn {
-		st := core.TXN_STATUS_PENDING
-		if tx.Status.Confirmed {
-			st = core.TXN_STATUS_CONFIRMED
-		}
-
-		transactions = append(transactions, &SkycoinTransaction{
-			skyTxn: tx.Transaction,
-			status: st,
-		})
-
+		transactions = append(transactions, newSkycoinTransaction(tx, addr.poolSection))
 	}
 
 	return NewSkycoinTransactionIterator(transactions)
@@ -110,6 +104,7 @@ func (addr *SkycoinAddress) ListPendingTransactions() (core.TransactionIterator,
 }
 
 func (wlt *RemoteWallet) GetBalance(ticker string) (uint64, error) {
+	fiberCoin := LookupFiberCoin(wlt.poolSection)
 	c, err := NewSkycoinApiClient(wlt.poolSection)
 	if err != nil {
 		log.WithError(err).Error("Couldn't get API client")
@@ -123,9 +118,9 @@ func (wlt *RemoteWallet) GetBalance(ticker string) (uint64, error) {
 		return 0, err
 	}
 
-	if ticker == Sky {
+	if ticker == fiberCoin.Ticker {
 		return bl.Confirmed.Coins, nil
-	} else if ticker == CoinHour {
+	} else if ticker == fiberCoin.CoinHoursTicker {
 		return bl.Confirmed.Hours, nil
 	} else {
 		return 0, errorTickerInvalid{ticker}
@@ -134,7 +129,8 @@ func (wlt *RemoteWallet) GetBalance(ticker string) (uint64, error) {
 }
 
 func (wlt *RemoteWallet) ListAssets() []string {
-	return []string{Sky, CoinHour}
+	fiberCoin := LookupFiberCoin(wlt.poolSection)
+	return []string{fiberCoin.Ticker, fiberCoin.CoinHoursTicker}
 }
 
 func (wlt *RemoteWallet) ScanUnspentOutputs() (core.TransactionOutputIterator, error) {
@@ -177,7 +173,7 @@ func (wlt *RemoteWallet) ListTransactions() core.TransactionIterator {
 }
 
 func (wlt *RemoteWallet) ListPendingTransactions() (core.TransactionIterator, error) {
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(wlt.poolSection))
 	if err != nil {
 		log.WithError(err).Error("Couldn't get API client")
 		return nil, err
@@ -191,12 +187,13 @@ func (wlt *RemoteWallet) ListPendingTransactions() (core.TransactionIterator, er
 	}
 	txns := make([]core.Transaction, 0)
 	for _, ut := range response.Transactions {
-		txns = append(txns, &SkycoinPendingTransaction{Transaction: &ut})
+		txns = append(txns, &SkycoinPendingTransaction{Transaction: &ut, poolSection: wlt.poolSection})
 	}
 	return NewSkycoinTransactionIterator(txns), nil
 }
 
 func (wlt *LocalWallet) updateBalances() error {
+	fiberCoin := LookupFiberCoin(wlt.poolSection)
 	walletName := filepath.Join(wlt.WalletDir, wlt.Id)
 	log.WithField("walletName", walletName).Info("Calling wallet.Load(walletName)")
 	walletLoaded, err := wallet.Load(walletName)
@@ -210,7 +207,7 @@ func (wlt *LocalWallet) updateBalances() error {
 		addrs = append(addrs, addr.String())
 	}
 
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(wlt.poolSection))
 	if err != nil {
 		log.WithError(err).Error("Couldn't get API client")
 		return err
@@ -229,28 +226,18 @@ func (wlt *LocalWallet) updateBalances() error {
 		return err
 	}
 
-	flSky, err := strconv.ParseFloat(bl.Confirmed.Coins, 64)
+	sky, err := util.ParseAmount(bl.Confirmed.Coins, fiberCoin.Ticker)
 	if err != nil {
-		log.WithError(err).WithField("bl.Confirmed.Coins", bl.Confirmed.Coins).Error("strconv.ParseFloat(bl.Confirmed.Coins, 64) failed")
+		log.WithError(err).WithField("bl.Confirmed.Coins", bl.Confirmed.Coins).Error("util.ParseAmount(bl.Confirmed.Coins, Sky) failed")
 		return err
 	}
-	accuracy, err2 := util.AltcoinQuotient(Sky)
-	if err2 != nil {
-		log.WithError(err2).WithField("Sky", Sky).Error("util.AltcoinQuotient(Sky) failed")
-		return err2
-	}
-	wlt.balance.SetCoins(Sky, uint64(flSky*float64(accuracy)))
-	coinHours, err := strconv.ParseFloat(bl.Confirmed.Hours, 64)
+	wlt.balance.SetCoins(fiberCoin.Ticker, sky.Coins)
+	coinHours, err := util.ParseAmount(bl.Confirmed.Hours, fiberCoin.CoinHoursTicker)
 	if err != nil {
-		log.WithError(err).WithField("bl.Confirmed.Hours", bl.Confirmed.Hours).Error("strconv.ParseFloat(bl.Confirmed.Hours, 64) failed")
+		log.WithError(err).WithField("bl.Confirmed.Hours", bl.Confirmed.Hours).Error("util.ParseAmount(bl.Confirmed.Hours, CoinHour) failed")
 		return err
 	}
-	accuracy, err2 = util.AltcoinQuotient(CoinHour)
-	if err2 != nil {
-		log.WithError(err2).WithField("CoinHour", CoinHour).Error("util.AltcoinQuotient(CoinHour) failed")
-		return err2
-	}
-	wlt.balance.SetCoins(CoinHour, uint64(coinHours*float64(accuracy)))
+	wlt.balance.SetCoins(fiberCoin.CoinHoursTicker, coinHours.Coins)
 	return nil
 }
 
@@ -270,7 +257,8 @@ func (wlt *LocalWallet) GetBalance(ticker string) (uint64, error) {
 }
 
 func (wlt *LocalWallet) ListAssets() []string {
-	return []string{Sky, CoinHour}
+	fiberCoin := LookupFiberCoin(wlt.poolSection)
+	return []string{fiberCoin.Ticker, fiberCoin.CoinHoursTicker}
 }
 
 func (wlt *LocalWallet) ScanUnspentOutputs() (core.TransactionOutputIterator, error) {
@@ -326,7 +314,7 @@ func (wlt *LocalWallet) ListPendingTransactions() (core.TransactionIterator, err
 	}
 	txns := make([]core.Transaction, 0)
 	for _, ut := range response.Transactions {
-		txns = append(txns, &SkycoinPendingTransaction{Transaction: &ut})
+		txns = append(txns, &SkycoinPendingTransaction{Transaction: &ut, poolSection: wlt.poolSection})
 	}
 	return NewSkycoinTransactionIterator(txns), nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
 
+	"github.com/SkycoinProject/skycoin/src/cipher"
 	"github.com/SkycoinProject/skycoin/src/readable"
 
 	"github.com/fibercrypto/fibercryptowallet/src/core"
@@ -15,7 +16,8 @@ import (
 var logBlockchain = logging.MustGetLogger("Skycoin Blockchain")
 
 type SkycoinBlock struct { //implements core.Block interface
-	Block *readable.Block
+	Block       *readable.Block
+	poolSection string
 }
 
 func (sb *SkycoinBlock) GetHash() ([]byte, error) {
@@ -54,15 +56,16 @@ func (sb *SkycoinBlock) GetHeight() (uint64, error) {
 	if sb.Block == nil {
 		return 0, errors.ErrBlockNotSet
 	}
-	return 0, nil //TODO ???
+	return sb.Block.Head.BkSeq, nil
 }
 
 func (sb *SkycoinBlock) GetFee(ticker string) (uint64, error) {
+	fiberCoin := LookupFiberCoin(sb.poolSection)
 	logBlockchain.Info("Getting fee")
 	if sb.Block == nil {
 		return 0, errors.ErrBlockNotSet
 	}
-	if ticker == CoinHour {
+	if ticker == fiberCoin.CoinHoursTicker {
 		return sb.Block.Head.Fee, nil
 	}
 	return 0, nil
@@ -73,7 +76,67 @@ func (sb *SkycoinBlock) IsGenesisBlock() (bool, error) {
 	if sb.Block == nil {
 		return false, errors.ErrBlockNotSet
 	}
-	return true, nil
+	return sb.Block.Head.BkSeq == 0, nil
+}
+
+// GetTransactions lists transactions included in this block, including spent input data
+func (sb *SkycoinBlock) GetTransactions() (core.TransactionIterator, error) {
+	logBlockchain.Info("Getting block transactions")
+	if sb.Block == nil {
+		return nil, errors.ErrBlockNotSet
+	}
+	c, err := NewSkycoinApiClient(sectionOrDefault(sb.poolSection))
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't load client")
+		return nil, err
+	}
+	defer ReturnSkycoinClient(c)
+	txns := make([]core.Transaction, 0, len(sb.Block.Body.Transactions))
+	for _, txn := range sb.Block.Body.Transactions {
+		txnV, err := c.TransactionVerbose(txn.Hash)
+		if err != nil {
+			logBlockchain.WithError(err).WithField("txid", txn.Hash).Warn("Couldn't get block transaction")
+			return nil, err
+		}
+		txns = append(txns, newSkycoinTransaction(*txnV, sb.poolSection))
+	}
+	return NewSkycoinTransactionIterator(txns), nil
+}
+
+// SkycoinBlockIterator fetches blocks in a range as iteration moves forward
+type SkycoinBlockIterator struct { // Implements BlockIterator interface
+	next        uint64
+	end         uint64
+	current     *SkycoinBlock
+	poolSection string
+}
+
+func (it *SkycoinBlockIterator) Value() core.Block {
+	return it.current
+}
+
+func (it *SkycoinBlockIterator) Next() bool {
+	if !it.HasNext() {
+		return false
+	}
+	c, err := NewSkycoinApiClient(sectionOrDefault(it.poolSection))
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't load client")
+		return false
+	}
+	defer ReturnSkycoinClient(c)
+	b, err := c.BlockBySeq(it.next)
+	if err != nil {
+		logBlockchain.WithError(err).WithField("seq", it.next).Warn("Couldn't get block")
+		return false
+	}
+	it.current = &SkycoinBlock{Block: b, poolSection: it.poolSection}
+	it.next++
+	return true
+}
+
+func (it *SkycoinBlockIterator) HasNext() bool {
+	return it.next <= it.end
 }
 
 type SkycoinBlockchainInfo struct {
@@ -90,12 +153,19 @@ type SkycoinBlockchain struct { //Implements BlockchainStatus interface
 	lastTimeSupplyRequested uint64
 	CacheTime               uint64
 	cachedStatus            *SkycoinBlockchainInfo
+	poolSection             string
 }
 
 func NewSkycoinBlockchain(invalidCacheTime uint64) *SkycoinBlockchain {
-	return &SkycoinBlockchain{CacheTime: invalidCacheTime}
+	return newSkycoinBlockchain(invalidCacheTime, PoolSection)
+}
+
+// newSkycoinBlockchain instantiates blockchain status API of SkyFiber coin served by nodes of connection pool section
+func newSkycoinBlockchain(invalidCacheTime uint64, poolSection string) *SkycoinBlockchain {
+	return &SkycoinBlockchain{CacheTime: invalidCacheTime, poolSection: poolSection}
 }
 func (ss *SkycoinBlockchain) GetCoinValue(coinvalue core.CoinValueMetric, ticker string) (uint64, error) {
+	fiberCoin := LookupFiberCoin(ss.poolSection)
 	logBlockchain.Info("Getting Coin value")
 	elapsed := uint64(time.Now().UTC().UnixNano()) - ss.lastTimeSupplyRequested
 	if elapsed > ss.CacheTime || ss.cachedStatus == nil {
@@ -108,12 +178,12 @@ func (ss *SkycoinBlockchain) GetCoinValue(coinvalue core.CoinValueMetric, ticker
 	}
 
 	switch ticker {
-	case Sky:
+	case fiberCoin.Ticker:
 		if coinvalue == core.CoinCurrentSupply {
 			return ss.cachedStatus.CurrentSkySupply, nil
 		}
 		return ss.cachedStatus.TotalSkySupply, nil
-	case CoinHour:
+	case fiberCoin.CoinHoursTicker:
 		if coinvalue == core.CoinCurrentSupply {
 			return ss.cachedStatus.CurrentCoinHourSupply, nil
 		}
@@ -156,9 +226,10 @@ func (ss *SkycoinBlockchain) SetCacheTime(time uint64) {
 }
 
 func (ss *SkycoinBlockchain) requestSupplyInfo() error {
+	fiberCoin := LookupFiberCoin(ss.poolSection)
 	logBlockchain.Info("Requesting supply info")
 
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(ss.poolSection))
 	if err != nil {
 		logBlockchain.WithError(err).Warn("Couldn't load client")
 		return err
@@ -172,25 +243,25 @@ func (ss *SkycoinBlockchain) requestSupplyInfo() error {
 		return err
 	}
 
-	ss.cachedStatus.CurrentCoinHourSupply, err = util.GetCoinValue(coinSupply.CurrentCoinHourSupply, CoinHour)
+	ss.cachedStatus.CurrentCoinHourSupply, err = util.GetCoinValue(coinSupply.CurrentCoinHourSupply, fiberCoin.CoinHoursTicker)
 	if err != nil {
 		logBlockchain.WithError(err).Warn("Couldn't get current coin hours supply")
 		return err
 	}
 
-	ss.cachedStatus.TotalCoinHourSupply, err = util.GetCoinValue(coinSupply.TotalCoinHourSupply, CoinHour)
+	ss.cachedStatus.TotalCoinHourSupply, err = util.GetCoinValue(coinSupply.TotalCoinHourSupply, fiberCoin.CoinHoursTicker)
 	if err != nil {
 		logBlockchain.WithError(err).Warn("Couldn't get total coin hours supply")
 		return err
 	}
 
-	ss.cachedStatus.CurrentSkySupply, err = util.GetCoinValue(coinSupply.CurrentSupply, Sky)
+	ss.cachedStatus.CurrentSkySupply, err = util.GetCoinValue(coinSupply.CurrentSupply, fiberCoin.Ticker)
 	if err != nil {
 		logBlockchain.WithError(err).Warn("Couldn't get current Skycoin's supply")
 		return err
 	}
 
-	ss.cachedStatus.TotalSkySupply, err = util.GetCoinValue(coinSupply.TotalSupply, Sky)
+	ss.cachedStatus.TotalSkySupply, err = util.GetCoinValue(coinSupply.TotalSupply, fiberCoin.Ticker)
 	if err != nil {
 		logBlockchain.WithError(err).Warn("Couldn't get total Skycoin's supply")
 		return err
@@ -208,7 +279,7 @@ func (ss *SkycoinBlockchain) requestSupplyInfo() error {
 
 func (ss *SkycoinBlockchain) requestStatusInfo() error {
 	logBlockchain.Info("Requesting status information")
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(ss.poolSection))
 	if err != nil {
 		logBlockchain.WithError(err).Warn("Couldn't load client")
 		return err
@@ -223,7 +294,7 @@ func (ss *SkycoinBlockchain) requestStatusInfo() error {
 		return err
 	}
 	lastBlock := blocks.Blocks[len(blocks.Blocks)-1]
-	ss.cachedStatus.LastBlockInfo = &SkycoinBlock{Block: &lastBlock}
+	ss.cachedStatus.LastBlockInfo = &SkycoinBlock{Block: &lastBlock, poolSection: ss.poolSection}
 
 	progress, err := c.BlockchainProgress()
 	if err != nil {
@@ -238,6 +309,132 @@ func (ss *SkycoinBlockchain) requestStatusInfo() error {
 	return nil
 }
 
+// GetBlockByHeight retrieves block by sequence number
+func (ss *SkycoinBlockchain) GetBlockByHeight(height uint64) (core.Block, error) {
+	logBlockchain.Info("Getting block by height")
+	c, err := NewSkycoinApiClient(sectionOrDefault(ss.poolSection))
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't load client")
+		return nil, err
+	}
+	defer ReturnSkycoinClient(c)
+	b, err := c.BlockBySeq(height)
+	if err != nil {
+		logBlockchain.WithError(err).WithField("seq", height).Warn("Couldn't get block")
+		return nil, err
+	}
+	return &SkycoinBlock{Block: b, poolSection: ss.poolSection}, nil
+}
+
+// GetBlockByHash retrieves block by header hash
+func (ss *SkycoinBlockchain) GetBlockByHash(hash string) (core.Block, error) {
+	logBlockchain.Info("Getting block by hash")
+	c, err := NewSkycoinApiClient(sectionOrDefault(ss.poolSection))
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't load client")
+		return nil, err
+	}
+	defer ReturnSkycoinClient(c)
+	b, err := c.BlockByHash(hash)
+	if err != nil {
+		logBlockchain.WithError(err).WithField("hash", hash).Warn("Couldn't get block")
+		return nil, err
+	}
+	return &SkycoinBlock{Block: b, poolSection: ss.poolSection}, nil
+}
+
+// GetBlockRange iterates over blocks with sequence numbers from start up to end, both included.
+// Range is cut at the tip of the chain.
+func (ss *SkycoinBlockchain) GetBlockRange(start, end uint64) (core.BlockIterator, error) {
+	logBlockchain.Info("Getting block range")
+	if start > end {
+		return nil, errors.ErrInvalidIndex
+	}
+	c, err := NewSkycoinApiClient(sectionOrDefault(ss.poolSection))
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't load client")
+		return nil, err
+	}
+	defer ReturnSkycoinClient(c)
+	progress, err := c.BlockchainProgress()
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't get blockchain progress")
+		return nil, err
+	}
+	if end > progress.Current {
+		end = progress.Current
+	}
+	return &SkycoinBlockIterator{next: start, end: end, poolSection: ss.poolSection}, nil
+}
+
+// GetTransaction looks up any transaction by ID, including spent input data
+func (ss *SkycoinBlockchain) GetTransaction(txid string) (core.Transaction, error) {
+	logBlockchain.Info("Getting transaction")
+	c, err := NewSkycoinApiClient(sectionOrDefault(ss.poolSection))
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't load client")
+		return nil, err
+	}
+	defer ReturnSkycoinClient(c)
+	txn, err := c.TransactionVerbose(txid)
+	if err != nil {
+		logBlockchain.WithError(err).WithField("txid", txid).Warn("Couldn't get transaction")
+		return nil, err
+	}
+	return newSkycoinTransaction(*txn, ss.poolSection), nil
+}
+
+// GetAddressBalance retrieves confirmed balance of any address
+func (ss *SkycoinBlockchain) GetAddressBalance(addr, ticker string) (uint64, error) {
+	fiberCoin := LookupFiberCoin(ss.poolSection)
+	logBlockchain.Info("Getting address balance")
+	if _, err := cipher.DecodeBase58Address(addr); err != nil {
+		return 0, errors.ErrInvalidAddressString
+	}
+	c, err := NewSkycoinApiClient(sectionOrDefault(ss.poolSection))
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't load client")
+		return 0, err
+	}
+	defer ReturnSkycoinClient(c)
+	bal, err := c.Balance([]string{addr})
+	if err != nil {
+		logBlockchain.WithError(err).WithField("addr", addr).Warn("Couldn't get balance")
+		return 0, err
+	}
+	switch ticker {
+	case fiberCoin.Ticker:
+		return bal.Confirmed.Coins, nil
+	case fiberCoin.CoinHoursTicker:
+		return bal.Confirmed.Hours, nil
+	}
+	return 0, errorTickerInvalid{}
+}
+
+// GetAddressTransactions lists transactions of any address, including spent input data
+func (ss *SkycoinBlockchain) GetAddressTransactions(addr string) (core.TransactionIterator, error) {
+	logBlockchain.Info("Getting address transactions")
+	if _, err := cipher.DecodeBase58Address(addr); err != nil {
+		return nil, errors.ErrInvalidAddressString
+	}
+	c, err := NewSkycoinApiClient(sectionOrDefault(ss.poolSection))
+	if err != nil {
+		logBlockchain.WithError(err).Warn("Couldn't load client")
+		return nil, err
+	}
+	defer ReturnSkycoinClient(c)
+	txns, err := c.TransactionsVerbose([]string{addr})
+	if err != nil {
+		logBlockchain.WithError(err).WithField("addr", addr).Warn("Couldn't get transactions")
+		return nil, err
+	}
+	transactions := make([]core.Transaction, 0, len(txns))
+	for _, txn := range txns {
+		transactions = append(transactions, newSkycoinTransaction(txn, ss.poolSection))
+	}
+	return NewSkycoinTransactionIterator(transactions), nil
+}
+
 // SendFromAddress instantiates a transaction to send funds from specific source addresses
 // to multiple destination addresses
 func (ss *SkycoinBlockchain) SendFromAddress(from []core.WalletAddress, to []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
@@ -246,8 +443,8 @@ func (ss *SkycoinBlockchain) SendFromAddress(from []core.WalletAddress, to []cor
 	for i, wa := range from {
 		addresses[i] = wa.GetAddress()
 	}
-	createTxnFunc := skyAPICreateTxn
-	return createTransaction(addresses, to, nil, change, options, createTxnFunc)
+	createTxnFunc := skyAPICreateTxn(ss.poolSection)
+	return createTransaction(ss.poolSection, addresses, to, nil, change, options, createTxnFunc)
 }
 
 // Spend instantiates a transaction that spends specific outputs to send to multiple destination addresses
@@ -257,6 +454,14 @@ func (ss *SkycoinBlockchain) Spend(unspent []core.WalletOutput, new []core.Trans
 	for i, wu := range unspent {
 		uxouts[i] = wu.GetOutput()
 	}
-	createTxnFunc := skyAPICreateTxn
-	return createTransaction(nil, new, uxouts, change, options, createTxnFunc)
+	createTxnFunc := skyAPICreateTxn(ss.poolSection)
+	return createTransaction(ss.poolSection, nil, new, uxouts, change, options, createTxnFunc)
 }
+
+// Type assertions
+var (
+	_ core.Block                    = &SkycoinBlock{}
+	_ core.BlockIterator            = &SkycoinBlockIterator{}
+	_ core.BlockchainStatus         = &SkycoinBlockchain{}
+	_ core.BlockchainTransactionAPI = &SkycoinBlockchain{}
+)
@@ -8,7 +8,10 @@ import (
 
 	"github.com/SkycoinProject/skycoin/src/api"
 	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/testutil"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/sandbox"
 	"github.com/fibercrypto/fibercryptowallet/src/core"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
 )
 
 func TestSkycoinBlockStructure(t *testing.T) {
@@ -55,7 +58,6 @@ func TestSkycoinBlockStructure(t *testing.T) {
 	require.Equal(t, core.Timestamp(time), btime)
 	bheigth, err5 := skyBlock.GetHeight()
 	require.NoError(t, err5)
-	//TODO: When the behavior of the GetHeight function is decided, the test must be modified accordingly
 	require.Equal(t, uint64(0), bheigth)
 	bfee, err6 := skyBlock.GetFee(CoinHour)
 	require.NoError(t, err6)
@@ -170,3 +172,65 @@ func TestSkycoinBlockchainSetCacheTime(t *testing.T) {
 	bchn.SetCacheTime(time)
 	require.Equal(t, time, bchn.CacheTime)
 }
+
+func TestSkycoinBlockchainExplorer(t *testing.T) {
+	poolSection := "skycoin-explorer-sandbox"
+	require.NoError(t, core.GetMultiPool().CreateSection(poolSection, NewSkycoinConnectionFactory(sandbox.URLScheme+"explorer-test")))
+	node := sandbox.GetNode("explorer-test")
+	addr := testutil.MakeAddress().String()
+	txid, err := node.Mint(addr, 10e6, 100)
+	require.NoError(t, err)
+	explorer := newSkycoinBlockchain(0, poolSection)
+
+	block, err := explorer.GetBlockByHeight(1)
+	require.NoError(t, err)
+	height, err := block.GetHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), height)
+	genesis, err := block.IsGenesisBlock()
+	require.NoError(t, err)
+	require.False(t, genesis)
+	txns, err := block.GetTransactions()
+	require.NoError(t, err)
+	require.True(t, txns.Next())
+	require.Equal(t, txid, txns.Value().GetId())
+	require.Equal(t, core.TXN_STATUS_CONFIRMED, txns.Value().GetStatus())
+	require.False(t, txns.Next())
+
+	hash, err := block.GetHash()
+	require.NoError(t, err)
+	sameBlock, err := explorer.GetBlockByHash(string(hash))
+	require.NoError(t, err)
+	require.Equal(t, block, sameBlock)
+
+	// Range is cut at chain tip
+	blocks, err := explorer.GetBlockRange(0, 100)
+	require.NoError(t, err)
+	heights := make([]uint64, 0)
+	for blocks.Next() {
+		height, err := blocks.Value().GetHeight()
+		require.NoError(t, err)
+		heights = append(heights, height)
+	}
+	require.Equal(t, []uint64{0, 1}, heights)
+	_, err = explorer.GetBlockRange(2, 1)
+	require.Equal(t, fce.ErrInvalidIndex, err)
+
+	txn, err := explorer.GetTransaction(txid)
+	require.NoError(t, err)
+	require.Len(t, txn.GetOutputs(), 2)
+
+	coins, err := explorer.GetAddressBalance(addr, Sky)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10e6), coins)
+	hours, err := explorer.GetAddressBalance(addr, CoinHour)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), hours)
+	_, err = explorer.GetAddressBalance("invalid", Sky)
+	require.Equal(t, fce.ErrInvalidAddressString, err)
+	history, err := explorer.GetAddressTransactions(addr)
+	require.NoError(t, err)
+	require.True(t, history.Next())
+	require.Equal(t, txid, history.Value().GetId())
+	require.False(t, history.Next())
+}
@@ -31,6 +31,11 @@ func NewSkycoinAddressIterator(addresses []core.Address) *SkycoinAddressIterator
 }
 
 func NewSkycoinAddress(addrStr string) (SkycoinAddress, error) {
+	return newSkycoinAddress(addrStr, PoolSection)
+}
+
+// newSkycoinAddress parses address of SkyFiber coin served by nodes of connection pool section
+func newSkycoinAddress(addrStr, poolSection string) (SkycoinAddress, error) {
 	var skyAddr cipher.Address
 	var err error
 	if skyAddr, err = cipher.DecodeBase58Address(addrStr); err != nil {
@@ -40,7 +45,7 @@ func NewSkycoinAddress(addrStr string) (SkycoinAddress, error) {
 	return SkycoinAddress{
 		isBip32:     false,
 		address:     skyAddr,
-		poolSection: PoolSection,
+		poolSection: sectionOrDefault(poolSection),
 	}, nil
 }
 
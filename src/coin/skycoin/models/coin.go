@@ -11,6 +11,7 @@ import (
 	"github.com/SkycoinProject/skycoin/src/readable"
 	"github.com/SkycoinProject/skycoin/src/visor"
 	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/verify"
 	"github.com/fibercrypto/fibercryptowallet/src/core"
 	"github.com/fibercrypto/fibercryptowallet/src/errors"
 	"github.com/fibercrypto/fibercryptowallet/src/util"
@@ -26,11 +27,13 @@ var logCoin = logging.MustGetLogger("Skycoin coin")
 */
 type SkycoinPendingTransaction struct {
 	Transaction *readable.UnconfirmedTransactionVerbose
+	poolSection string
 }
 
 func (txn *SkycoinPendingTransaction) SupportedAssets() []string {
+	fiberCoin := LookupFiberCoin(txn.poolSection)
 	logCoin.Info("Getting supported assets")
-	return []string{Sky, CoinHour}
+	return []string{fiberCoin.Ticker, fiberCoin.CoinHoursTicker}
 }
 
 func (txn *SkycoinPendingTransaction) GetTimestamp() core.Timestamp {
@@ -47,7 +50,7 @@ func (txn *SkycoinPendingTransaction) GetInputs() []core.TransactionInput {
 	logCoin.Info("Getting inputs from Skycoin pending transaction")
 	inputs := make([]core.TransactionInput, 0)
 	for _, input := range txn.Transaction.Transaction.In {
-		inputs = append(inputs, &SkycoinTransactionInput{skyIn: input})
+		inputs = append(inputs, &SkycoinTransactionInput{skyIn: input, poolSection: txn.poolSection})
 	}
 	return inputs
 }
@@ -56,7 +59,7 @@ func (txn *SkycoinPendingTransaction) GetOutputs() []core.TransactionOutput {
 	logCoin.Info("Getting outputs from Skycoin pending transaction")
 	outputs := make([]core.TransactionOutput, 0)
 	for _, output := range txn.Transaction.Transaction.Out {
-		outputs = append(outputs, &SkycoinTransactionOutput{skyOut: output, spent: false})
+		outputs = append(outputs, &SkycoinTransactionOutput{skyOut: output, spent: false, poolSection: txn.poolSection})
 	}
 	return outputs
 }
@@ -67,12 +70,13 @@ func (txn *SkycoinPendingTransaction) GetId() string {
 }
 
 func (txn *SkycoinPendingTransaction) ComputeFee(ticker string) (uint64, error) {
+	fiberCoin := LookupFiberCoin(txn.poolSection)
 	logCoin.Info("Computing fee for " + ticker + " ticket")
-	if ticker == CoinHour {
+	if ticker == fiberCoin.CoinHoursTicker {
 		return txn.Transaction.Transaction.Fee, nil
-	} else if ticker == Sky {
+	} else if ticker == fiberCoin.Ticker {
 		return uint64(0), nil
-	} else if ticker == CalculatedHour {
+	} else if ticker == fiberCoin.CalculatedHoursTicker {
 		return uint64(0), errors.ErrNotImplemented
 	}
 	logCoin.Warningf("Invalid ticker %v\n", ticker)
@@ -290,15 +294,17 @@ func NewUninjectedTransaction(txn *coin.Transaction, fee uint64) (*SkycoinUninje
 }
 
 type SkycoinUninjectedTransaction struct {
-	txn     *coin.Transaction
-	inputs  []core.TransactionInput
-	outputs []core.TransactionOutput
-	fee     uint64
+	txn         *coin.Transaction
+	inputs      []core.TransactionInput
+	outputs     []core.TransactionOutput
+	fee         uint64
+	poolSection string
 }
 
 func (skyTxn *SkycoinUninjectedTransaction) SupportedAssets() []string {
+	fiberCoin := LookupFiberCoin(skyTxn.poolSection)
 	logCoin.Info("Getting supported assets from un injected transactions")
-	return []string{Sky, CoinHour}
+	return []string{fiberCoin.Ticker, fiberCoin.CoinHoursTicker}
 }
 
 func (skyTxn *SkycoinUninjectedTransaction) GetTimestamp() core.Timestamp {
@@ -314,7 +320,7 @@ func (skyTxn *SkycoinUninjectedTransaction) GetStatus() core.TransactionStatus {
 func (skyTxn *SkycoinUninjectedTransaction) GetInputs() []core.TransactionInput {
 	logCoin.Info("Getting inputs from un injected transactions")
 	if len(skyTxn.inputs) == 0 {
-		inputs, err := getSkycoinTransactionInputsFromInputsHashes(skyTxn.txn.In)
+		inputs, err := getSkycoinTransactionInputsFromInputsHashes(skyTxn.poolSection, skyTxn.txn.In)
 		if err != nil {
 			// TODO: This method should also returns error
 			return nil
@@ -334,8 +340,9 @@ func (skyTxn *SkycoinUninjectedTransaction) GetOutputs() []core.TransactionOutpu
 				return nil
 			}
 			outputs = append(outputs, &SkycoinTransactionOutput{
-				skyOut: *rOut,
-				spent:  false,
+				skyOut:      *rOut,
+				spent:       false,
+				poolSection: skyTxn.poolSection,
 			})
 		}
 		skyTxn.outputs = outputs
@@ -344,12 +351,13 @@ func (skyTxn *SkycoinUninjectedTransaction) GetOutputs() []core.TransactionOutpu
 }
 
 func (skyTxn *SkycoinUninjectedTransaction) ComputeFee(ticker string) (uint64, error) {
+	fiberCoin := LookupFiberCoin(skyTxn.poolSection)
 	logCoin.Info("Computing fee for un injected transaction with" + ticker + " ticker")
-	if ticker == CoinHour {
+	if ticker == fiberCoin.CoinHoursTicker {
 		return skyTxn.fee, nil
-	} else if ticker == Sky {
+	} else if ticker == fiberCoin.Ticker {
 		return uint64(0), nil
-	} else if ticker == CalculatedHour {
+	} else if ticker == fiberCoin.CalculatedHoursTicker {
 		return uint64(0), errors.ErrNotImplemented
 	}
 	logCoin.Warningf("Invalid ticker %v\n", ticker)
@@ -386,14 +394,29 @@ SkycoinTransaction
 type SkycoinTransaction struct {
 	skyTxn readable.TransactionVerbose
 
-	status  core.TransactionStatus
-	inputs  []core.TransactionInput
-	outputs []core.TransactionOutput
+	status      core.TransactionStatus
+	inputs      []core.TransactionInput
+	outputs     []core.TransactionOutput
+	poolSection string
+}
+
+// newSkycoinTransaction wraps transaction reported by node
+func newSkycoinTransaction(txn readable.TransactionWithStatusVerbose, poolSection string) *SkycoinTransaction {
+	status := core.TXN_STATUS_PENDING
+	if txn.Status.Confirmed {
+		status = core.TXN_STATUS_CONFIRMED
+	}
+	return &SkycoinTransaction{
+		skyTxn:      txn.Transaction,
+		status:      status,
+		poolSection: poolSection,
+	}
 }
 
 func (txn *SkycoinTransaction) SupportedAssets() []string {
+	fiberCoin := LookupFiberCoin(txn.poolSection)
 	logCoin.Info("Getting supported assets from transactions")
-	return []string{Sky, CoinHour}
+	return []string{fiberCoin.Ticker, fiberCoin.CoinHoursTicker}
 }
 
 func (txn *SkycoinTransaction) GetTimestamp() core.Timestamp {
@@ -408,7 +431,7 @@ func (txn *SkycoinTransaction) GetStatus() core.TransactionStatus {
 		return txn.status
 	}
 
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(txn.poolSection))
 	if err != nil {
 		return 0
 	}
@@ -429,7 +452,7 @@ func (txn *SkycoinTransaction) GetInputs() []core.TransactionInput {
 	logCoin.Info("Getting inputs from transaction")
 
 	if len(txn.inputs) == 0 {
-		ins, err := getSkycoinTransactionInputsFromTxnHash(txn.skyTxn.Hash)
+		ins, err := getSkycoinTransactionInputsFromTxnHash(txn.poolSection, txn.skyTxn.Hash)
 		if err != nil {
 			return nil
 		}
@@ -445,8 +468,9 @@ func (txn *SkycoinTransaction) GetOutputs() []core.TransactionOutput {
 		txn.outputs = make([]core.TransactionOutput, 0)
 		for _, out := range txn.skyTxn.Out {
 			txn.outputs = append(txn.outputs, &SkycoinTransactionOutput{
-				skyOut: out,
-				spent:  false,
+				skyOut:      out,
+				spent:       false,
+				poolSection: txn.poolSection,
 			})
 		}
 	}
@@ -458,13 +482,20 @@ func (txn *SkycoinTransaction) GetId() string {
 	return txn.skyTxn.Hash
 }
 
+// Inconsistencies found so far in data reported by node about this transaction.
+// Always empty unless responses are verified, see SkycoinConnectionFactory.VerifyResponses.
+func (txn *SkycoinTransaction) Inconsistencies() []*verify.Inconsistency {
+	return verify.Lookup(txn.skyTxn.Hash)
+}
+
 func (txn *SkycoinTransaction) ComputeFee(ticker string) (uint64, error) {
+	fiberCoin := LookupFiberCoin(txn.poolSection)
 	logCoin.Info("Compute fee for transaction with " + ticker + "ticker")
-	if ticker == CoinHour {
+	if ticker == fiberCoin.CoinHoursTicker {
 		return txn.skyTxn.Fee, nil
-	} else if ticker == Sky {
+	} else if ticker == fiberCoin.Ticker {
 		return uint64(0), nil
-	} else if ticker == CalculatedHour {
+	} else if ticker == fiberCoin.CalculatedHoursTicker {
 		return uint64(0), errors.ErrNotImplemented
 	}
 	logCoin.Warningf("Invalid ticker %v\n", ticker)
@@ -496,8 +527,8 @@ func (txn *SkycoinTransaction) IsFullySigned() (bool, error) {
 	return checkFullySigned(txn)
 }
 
-func getSkycoinTransactionInputsFromTxnHash(hash string) ([]core.TransactionInput, error) {
-	c, err := NewSkycoinApiClient(PoolSection)
+func getSkycoinTransactionInputsFromTxnHash(poolSection, hash string) ([]core.TransactionInput, error) {
+	c, err := NewSkycoinApiClient(sectionOrDefault(poolSection))
 	if err != nil {
 		return nil, err
 	}
@@ -511,15 +542,16 @@ func getSkycoinTransactionInputsFromTxnHash(hash string) ([]core.TransactionInpu
 		inputs = append(inputs, &SkycoinTransactionInput{
 			skyIn:       in,
 			spentOutput: nil,
+			poolSection: poolSection,
 		})
 	}
 
 	return inputs, nil
 }
 
-func getSkycoinTransactionInputsFromInputsHashes(inputsHashes []cipher.SHA256) ([]core.TransactionInput, error) {
+func getSkycoinTransactionInputsFromInputsHashes(poolSection string, inputsHashes []cipher.SHA256) ([]core.TransactionInput, error) {
 	inputs := make([]core.TransactionInput, 0)
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(poolSection))
 	if err != nil {
 		return nil, err
 	}
@@ -530,26 +562,10 @@ func getSkycoinTransactionInputsFromInputsHashes(inputsHashes []cipher.SHA256) (
 		if err != nil {
 			return nil, err
 		}
-		addr, err := cipher.DecodeBase58Address(ux.OwnerAddress)
+		cUx, err := uxOutFromSpentOutput(ux)
 		if err != nil {
 			return nil, err
 		}
-		srcTxn, err := cipher.SHA256FromHex(ux.SrcTx)
-		if err != nil {
-			return nil, err
-		}
-		cUx := coin.UxOut{
-			Head: coin.UxHead{
-				BkSeq: ux.SrcBkSeq,
-				Time:  ux.Time,
-			},
-			Body: coin.UxBody{
-				Address:        addr,
-				Coins:          ux.Coins,
-				Hours:          ux.Hours,
-				SrcTransaction: srcTxn,
-			},
-		}
 
 		visorInput, err := visor.NewTransactionInput(cUx, uint64(time.Now().UTC().Unix()))
 		if err != nil {
@@ -562,6 +578,7 @@ func getSkycoinTransactionInputsFromInputsHashes(inputsHashes []cipher.SHA256) (
 		inputs = append(inputs, &SkycoinTransactionInput{
 			skyIn:       readInput,
 			spentOutput: nil,
+			poolSection: poolSection,
 		})
 
 	}
@@ -574,6 +591,7 @@ SkycoinTransacionInput wraps verbose readable transaction input data
 type SkycoinTransactionInput struct {
 	skyIn       readable.TransactionInput
 	spentOutput *SkycoinTransactionOutput
+	poolSection string
 }
 
 func (in *SkycoinTransactionInput) GetId() string {
@@ -582,10 +600,11 @@ func (in *SkycoinTransactionInput) GetId() string {
 }
 
 func (in *SkycoinTransactionInput) GetSpentOutput() (core.TransactionOutput, error) {
+	fiberCoin := LookupFiberCoin(in.poolSection)
 	logCoin.Info("Getting spent outputs for transaction inputs")
 	if in.spentOutput == nil {
 
-		c, err := NewSkycoinApiClient(PoolSection)
+		c, err := NewSkycoinApiClient(sectionOrDefault(in.poolSection))
 		if err != nil {
 			return nil, err
 		}
@@ -594,11 +613,10 @@ func (in *SkycoinTransactionInput) GetSpentOutput() (core.TransactionOutput, err
 		if err != nil {
 			return nil, err
 		}
-		skyAccuracy, err := util.AltcoinQuotient(Sky)
+		coins, err := util.FormatAmount(core.NewAmount(fiberCoin.Ticker, out.Coins))
 		if err != nil {
 			return nil, err
 		}
-		coins := util.FormatCoins(out.Coins, skyAccuracy)
 		skyOut := &SkycoinTransactionOutput{
 			skyOut: readable.TransactionOutput{
 				Address: out.OwnerAddress,
@@ -606,7 +624,8 @@ func (in *SkycoinTransactionInput) GetSpentOutput() (core.TransactionOutput, err
 				Hours:   out.Hours,
 				Hash:    out.Uxid,
 			},
-			spent: true}
+			spent:       true,
+			poolSection: in.poolSection}
 		in.spentOutput = skyOut
 
 	}
@@ -615,27 +634,36 @@ func (in *SkycoinTransactionInput) GetSpentOutput() (core.TransactionOutput, err
 
 // SupportedAssets enumerates tickers of crypto assets supported by this output
 func (in *SkycoinTransactionInput) SupportedAssets() []string {
-	return []string{Sky, CoinHour, CalculatedHour}
+	fiberCoin := LookupFiberCoin(in.poolSection)
+	return []string{fiberCoin.Ticker, fiberCoin.CoinHoursTicker, fiberCoin.CalculatedHoursTicker}
+}
+
+// AccruedAsset refers coin hours to calculated hours accumulated until spent
+func (in *SkycoinTransactionInput) AccruedAsset(ticker string) (string, bool) {
+	return accruedAsset(in.poolSection, ticker)
 }
 
 // GetCoins return input balance in one of supported coins , or error
 func (in *SkycoinTransactionInput) GetCoins(ticker string) (uint64, error) {
+	fiberCoin := LookupFiberCoin(in.poolSection)
 	logCoin.Info("Getting coins for transaction inputs using " + ticker + "ticker")
 
 	accuracy, err2 := util.AltcoinQuotient(ticker)
 	if err2 != nil {
 		return uint64(0), err2
 	}
-	if ticker == Sky {
-		skyf, err := strconv.ParseFloat(in.skyIn.Coins, 64)
+	if ticker == fiberCoin.Ticker {
+		sky, err := util.ParseAmount(in.skyIn.Coins, fiberCoin.Ticker)
 		if err != nil {
 			return 0, err
 		}
-		return uint64(skyf * float64(accuracy)), nil
-	} else if ticker == CoinHour {
-		return in.skyIn.Hours * accuracy, nil
-	} else if ticker == CalculatedHour {
-		return in.skyIn.CalculatedHours * accuracy, nil
+		return sky.Coins, nil
+	} else if ticker == fiberCoin.CoinHoursTicker {
+		hours, err := core.NewAmount(ticker, in.skyIn.Hours).Mul(accuracy)
+		return hours.Coins, err
+	} else if ticker == fiberCoin.CalculatedHoursTicker {
+		hours, err := core.NewAmount(ticker, in.skyIn.CalculatedHours).Mul(accuracy)
+		return hours.Coins, err
 	}
 	logCoin.Errorf("Invalid ticker %v\n", ticker)
 	return uint64(0), errors.ErrInvalidAltcoinTicker
@@ -676,6 +704,7 @@ type SkycoinTransactionOutput struct {
 	skyOut          readable.TransactionOutput
 	spent           bool
 	calculatedHours uint64
+	poolSection     string
 }
 
 func (out *SkycoinTransactionOutput) GetId() string {
@@ -686,7 +715,7 @@ func (out *SkycoinTransactionOutput) GetId() string {
 
 func (out *SkycoinTransactionOutput) GetAddress() (core.Address, error) {
 	logCoin.Info("Getting address for transaction output")
-	skyAddrs, err := NewSkycoinAddress(out.skyOut.Address)
+	skyAddrs, err := newSkycoinAddress(out.skyOut.Address, out.poolSection)
 	if err != nil {
 		logCoin.Error(err)
 		return nil, err
@@ -696,26 +725,30 @@ func (out *SkycoinTransactionOutput) GetAddress() (core.Address, error) {
 
 // SupportedAssets enumerates tickers of crypto assets supported by this output
 func (in *SkycoinTransactionOutput) SupportedAssets() []string {
-	return []string{Sky, CoinHour, CalculatedHour}
+	fiberCoin := LookupFiberCoin(in.poolSection)
+	return []string{fiberCoin.Ticker, fiberCoin.CoinHoursTicker, fiberCoin.CalculatedHoursTicker}
 }
 
 // GetCoins return input balance in one of supported coins , or error
 func (out *SkycoinTransactionOutput) GetCoins(ticker string) (uint64, error) {
+	fiberCoin := LookupFiberCoin(out.poolSection)
 	logCoin.Info("Getting coins for transaction outputs using " + ticker + " ticker")
 	accuracy, err2 := util.AltcoinQuotient(ticker)
 	if err2 != nil {
 		return uint64(0), err2
 	}
-	if ticker == Sky {
-		skyf, err := strconv.ParseFloat(out.skyOut.Coins, 64)
+	if ticker == fiberCoin.Ticker {
+		sky, err := util.ParseAmount(out.skyOut.Coins, fiberCoin.Ticker)
 		if err != nil {
 			return 0, err
 		}
-		return uint64(skyf * float64(accuracy)), nil
-	} else if ticker == CoinHour {
-		return out.skyOut.Hours * accuracy, nil
-	} else if ticker == CalculatedHour {
-		return out.calculatedHours * accuracy, nil
+		return sky.Coins, nil
+	} else if ticker == fiberCoin.CoinHoursTicker {
+		hours, err := core.NewAmount(ticker, out.skyOut.Hours).Mul(accuracy)
+		return hours.Coins, err
+	} else if ticker == fiberCoin.CalculatedHoursTicker {
+		hours, err := core.NewAmount(ticker, out.calculatedHours).Mul(accuracy)
+		return hours.Coins, err
 	}
 	logCoin.Errorf("Invalid ticker %v\n", ticker)
 	return uint64(0), errors.ErrInvalidAltcoinTicker
@@ -727,7 +760,7 @@ func (out *SkycoinTransactionOutput) IsSpent() bool {
 		return true
 	}
 
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(out.poolSection))
 	if err != nil {
 		return true
 	}
@@ -743,11 +776,12 @@ func (out *SkycoinTransactionOutput) IsSpent() bool {
 	return false
 }
 
-func newCreatedTransactionInputs(rIns []api.CreatedTransactionInput) []core.TransactionInput {
+func newCreatedTransactionInputs(poolSection string, rIns []api.CreatedTransactionInput) []core.TransactionInput {
 	ins := make([]core.TransactionInput, len(rIns))
 	for i, rIn := range rIns {
 		ins[i] = &SkycoinCreatedTransactionInput{
-			skyIn: rIn,
+			skyIn:       rIn,
+			poolSection: poolSection,
 		}
 	}
 	return ins
@@ -759,6 +793,7 @@ SkycoinCreatedTransacionInput wraps created transaction input data
 type SkycoinCreatedTransactionInput struct {
 	skyIn       api.CreatedTransactionInput
 	spentOutput *SkycoinCreatedTransactionOutput
+	poolSection string
 }
 
 // GetId return transaction UXID
@@ -767,9 +802,10 @@ func (in *SkycoinCreatedTransactionInput) GetId() string {
 }
 
 func (in *SkycoinCreatedTransactionInput) GetSpentOutput() (core.TransactionOutput, error) {
+	fiberCoin := LookupFiberCoin(in.poolSection)
 	if in.spentOutput == nil {
 
-		calculatedHours, err := in.GetCoins(CalculatedHour)
+		calculatedHours, err := in.GetCoins(fiberCoin.CalculatedHoursTicker)
 		if err != nil {
 			return nil, err
 		}
@@ -781,7 +817,8 @@ func (in *SkycoinCreatedTransactionInput) GetSpentOutput() (core.TransactionOutp
 				UxID:    in.skyIn.UxID,
 			},
 			calculatedHours: calculatedHours,
-			spent:           false}
+			spent:           false,
+			poolSection:     in.poolSection}
 		in.spentOutput = skyOut
 
 	}
@@ -791,26 +828,32 @@ func (in *SkycoinCreatedTransactionInput) GetSpentOutput() (core.TransactionOutp
 
 // SupportedAssets enumerates tickers of crypto assets supported by this output
 func (in *SkycoinCreatedTransactionInput) SupportedAssets() []string {
-	return []string{Sky, CoinHour, CalculatedHour}
+	fiberCoin := LookupFiberCoin(in.poolSection)
+	return []string{fiberCoin.Ticker, fiberCoin.CoinHoursTicker, fiberCoin.CalculatedHoursTicker}
+}
+
+// AccruedAsset refers coin hours to calculated hours accumulated until spent
+func (in *SkycoinCreatedTransactionInput) AccruedAsset(ticker string) (string, bool) {
+	return accruedAsset(in.poolSection, ticker)
 }
 
 // GetCoins return input balance in one of supported coins , or error
 func (in *SkycoinCreatedTransactionInput) GetCoins(ticker string) (uint64, error) {
-	accuracy, err2 := util.AltcoinQuotient(ticker)
-	if err2 != nil {
-		return uint64(0), err2
+	fiberCoin := LookupFiberCoin(in.poolSection)
+	if _, err := util.AltcoinAccuracy(ticker); err != nil {
+		return uint64(0), err
 	}
 	var result uint64
 	var tmpResult int64
 	var err error
-	if ticker == Sky {
-		var skyf float64
-		skyf, err = strconv.ParseFloat(in.skyIn.Coins, 64)
-		result = uint64(skyf * float64(accuracy))
-	} else if ticker == CoinHour {
+	if ticker == fiberCoin.Ticker {
+		var sky core.Amount
+		sky, err = util.ParseAmount(in.skyIn.Coins, fiberCoin.Ticker)
+		result = sky.Coins
+	} else if ticker == fiberCoin.CoinHoursTicker {
 		tmpResult, err = strconv.ParseInt(in.skyIn.Hours, 10, 64)
 		result = uint64(tmpResult)
-	} else if ticker == CalculatedHour {
+	} else if ticker == fiberCoin.CalculatedHoursTicker {
 		tmpResult, err = strconv.ParseInt(in.skyIn.CalculatedHours, 10, 64)
 		result = uint64(tmpResult)
 	} else {
@@ -823,11 +866,12 @@ func (in *SkycoinCreatedTransactionInput) GetCoins(ticker string) (uint64, error
 	return result, nil
 }
 
-func newCreatedTransactionOutputs(rOuts []api.CreatedTransactionOutput) []core.TransactionOutput {
+func newCreatedTransactionOutputs(poolSection string, rOuts []api.CreatedTransactionOutput) []core.TransactionOutput {
 	ins := make([]core.TransactionOutput, len(rOuts))
 	for i, rOut := range rOuts {
 		ins[i] = &SkycoinCreatedTransactionOutput{
-			skyOut: rOut,
+			skyOut:      rOut,
+			poolSection: poolSection,
 		}
 	}
 	return ins
@@ -840,6 +884,7 @@ type SkycoinCreatedTransactionOutput struct {
 	skyOut          api.CreatedTransactionOutput
 	spent           bool
 	calculatedHours uint64
+	poolSection     string
 }
 
 func (out *SkycoinCreatedTransactionOutput) GetId() string {
@@ -847,7 +892,7 @@ func (out *SkycoinCreatedTransactionOutput) GetId() string {
 }
 
 func (out *SkycoinCreatedTransactionOutput) GetAddress() (core.Address, error) {
-	skyAddrs, err := NewSkycoinAddress(out.skyOut.Address)
+	skyAddrs, err := newSkycoinAddress(out.skyOut.Address, out.poolSection)
 	if err != nil {
 		logCoin.Error(err)
 		return nil, err
@@ -857,26 +902,27 @@ func (out *SkycoinCreatedTransactionOutput) GetAddress() (core.Address, error) {
 
 // SupportedAssets enumerates tickers of crypto assets supported by this output
 func (in *SkycoinCreatedTransactionOutput) SupportedAssets() []string {
-	return []string{Sky, CoinHour, CalculatedHour}
+	fiberCoin := LookupFiberCoin(in.poolSection)
+	return []string{fiberCoin.Ticker, fiberCoin.CoinHoursTicker, fiberCoin.CalculatedHoursTicker}
 }
 
 // GetCoins return input balance in one of supported coins , or error
 func (out *SkycoinCreatedTransactionOutput) GetCoins(ticker string) (uint64, error) {
-	accuracy, err2 := util.AltcoinQuotient(ticker)
-	if err2 != nil {
-		return uint64(0), err2
+	fiberCoin := LookupFiberCoin(out.poolSection)
+	if _, err := util.AltcoinAccuracy(ticker); err != nil {
+		return uint64(0), err
 	}
 	var tmpResult int64
 	var result uint64
 	var err error
-	if ticker == Sky {
-		var skyf float64
-		skyf, err = strconv.ParseFloat(out.skyOut.Coins, 64)
-		result = uint64(skyf * float64(accuracy))
-	} else if ticker == CoinHour {
+	if ticker == fiberCoin.Ticker {
+		var sky core.Amount
+		sky, err = util.ParseAmount(out.skyOut.Coins, fiberCoin.Ticker)
+		result = sky.Coins
+	} else if ticker == fiberCoin.CoinHoursTicker {
 		tmpResult, err = strconv.ParseInt(out.skyOut.Hours, 10, 64)
 		result = uint64(tmpResult)
-	} else if ticker == CalculatedHour {
+	} else if ticker == fiberCoin.CalculatedHoursTicker {
 		result = out.calculatedHours
 		err = nil
 	} else {
@@ -894,7 +940,7 @@ func (out *SkycoinCreatedTransactionOutput) IsSpent() bool {
 		return true
 	}
 
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(out.poolSection))
 	if err != nil {
 		return true
 	}
@@ -912,8 +958,13 @@ func (out *SkycoinCreatedTransactionOutput) IsSpent() bool {
 
 // NewSkycoinCreatedTransaction return readable created transaction wrapper
 func NewSkycoinCreatedTransaction(rTxn api.CreatedTransaction) *SkycoinCreatedTransaction {
+	return newSkycoinCreatedTransaction(rTxn, PoolSection)
+}
+
+func newSkycoinCreatedTransaction(rTxn api.CreatedTransaction, poolSection string) *SkycoinCreatedTransaction {
 	return &SkycoinCreatedTransaction{
-		skyTxn: rTxn,
+		skyTxn:      rTxn,
+		poolSection: poolSection,
 	}
 }
 
@@ -923,13 +974,15 @@ SkycoinCreatedTransaction wraps a readable created transaction to implement core
 type SkycoinCreatedTransaction struct {
 	skyTxn api.CreatedTransaction
 
-	inputs  []core.TransactionInput
-	outputs []core.TransactionOutput
+	inputs      []core.TransactionInput
+	outputs     []core.TransactionOutput
+	poolSection string
 }
 
 // SupportedAssets are SKY, SKYCH, and accumulated SKYCH
 func (txn *SkycoinCreatedTransaction) SupportedAssets() []string {
-	return []string{Sky, CoinHour}
+	fiberCoin := LookupFiberCoin(txn.poolSection)
+	return []string{fiberCoin.Ticker, fiberCoin.CoinHoursTicker}
 }
 
 // GetTimestamp will return zero
@@ -944,7 +997,7 @@ func (txn *SkycoinCreatedTransaction) GetStatus() core.TransactionStatus {
 // GetInputs return inputs spent by this transaction
 func (txn *SkycoinCreatedTransaction) GetInputs() []core.TransactionInput {
 	if len(txn.inputs) == 0 {
-		txn.inputs = newCreatedTransactionInputs(txn.skyTxn.In)
+		txn.inputs = newCreatedTransactionInputs(txn.poolSection, txn.skyTxn.In)
 	}
 	return txn.inputs
 }
@@ -952,7 +1005,7 @@ func (txn *SkycoinCreatedTransaction) GetInputs() []core.TransactionInput {
 // GetOuptuts return outputs owned by transaction receivers
 func (txn *SkycoinCreatedTransaction) GetOutputs() []core.TransactionOutput {
 	if txn.outputs == nil {
-		txn.outputs = newCreatedTransactionOutputs(txn.skyTxn.Out)
+		txn.outputs = newCreatedTransactionOutputs(txn.poolSection, txn.skyTxn.Out)
 	}
 	return txn.outputs
 }
@@ -962,15 +1015,16 @@ func (txn *SkycoinCreatedTransaction) GetId() string {
 }
 
 func (txn *SkycoinCreatedTransaction) ComputeFee(ticker string) (uint64, error) {
-	if ticker == CoinHour {
+	fiberCoin := LookupFiberCoin(txn.poolSection)
+	if ticker == fiberCoin.CoinHoursTicker {
 		fee, err := strconv.ParseInt(txn.skyTxn.Fee, 10, 64)
 		if err != nil {
 			return uint64(0), err
 		}
 		return uint64(fee), nil
-	} else if ticker == Sky {
+	} else if ticker == fiberCoin.Ticker {
 		return uint64(0), nil
-	} else if ticker == CalculatedHour {
+	} else if ticker == fiberCoin.CalculatedHoursTicker {
 		return uint64(0), errors.ErrNotImplemented
 	}
 	logCoin.Warningf("Invalid ticker %v\n", ticker)
@@ -1016,6 +1070,9 @@ var (
 	_ skytypes.ReadableTxn           = &SkycoinTransaction{}
 	_ core.Transaction               = &SkycoinTransaction{}
 	_ core.TransactionInput          = &SkycoinTransactionInput{}
+	_ core.AccruingInput             = &SkycoinTransactionInput{}
+	_ core.TransactionInput          = &SkycoinCreatedTransactionInput{}
+	_ core.AccruingInput             = &SkycoinCreatedTransactionInput{}
 	_ core.TransactionOutput         = &SkycoinTransactionOutput{}
 	_ skytypes.SkycoinTxn            = &SkycoinCreatedTransaction{}
 	_ skytypes.ReadableTxn           = &SkycoinCreatedTransaction{}
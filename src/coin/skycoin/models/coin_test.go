@@ -1446,7 +1446,7 @@ func Test_getSkycoinTransactionInputsFromTxnHash(t *testing.T) {
 			}, nil,
 		).Once()
 		t.Run("InputsFromTxnHash", func(t *testing.T) {
-			txnInputs, err := getSkycoinTransactionInputsFromTxnHash("hash")
+			txnInputs, err := getSkycoinTransactionInputsFromTxnHash(PoolSection, "hash")
 			require.NoError(t, err)
 			rawInputs := make([]readable.TransactionInput, len(txnInputs))
 			for i, in := range txnInputs {
@@ -1460,7 +1460,7 @@ func Test_getSkycoinTransactionInputsFromTxnHash(t *testing.T) {
 		inputs = inputs[:len(inputs)-1]
 	}
 	global_mock.On("TransactionVerbose", "hash").Return(nil, goerrors.New("failure"))
-	_, err := getSkycoinTransactionInputsFromTxnHash("hash")
+	_, err := getSkycoinTransactionInputsFromTxnHash(PoolSection, "hash")
 	require.Error(t, err)
 }
 
@@ -1919,7 +1919,7 @@ func Test_newCreatedTransactionOutputs(t *testing.T) {
 	}
 
 	for len(outputs) > 0 {
-		outs := newCreatedTransactionOutputs(outputs)
+		outs := newCreatedTransactionOutputs(PoolSection, outputs)
 		rawOutputs := make([]api.CreatedTransactionOutput, len(outputs))
 		for i, out := range outs {
 			createdOut, valid := out.(*SkycoinCreatedTransactionOutput)
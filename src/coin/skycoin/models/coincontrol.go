@@ -0,0 +1,305 @@
+package skycoin
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+const (
+	// outputLocksExt suffix of files persisting output locks next to wallet files
+	outputLocksExt = ".locks"
+	// TxnOptReserveOutputs transaction option reserving outputs spent by created transactions until spent or unlocked
+	TxnOptReserveOutputs = "ReserveOutputs"
+	// ReserveOutputsEnabled reserves outputs spent by created transactions
+	ReserveOutputsEnabled = "true"
+	// ReserveOutputsDisabled leaves outputs spent by created transactions available for coin selection
+	ReserveOutputsDisabled = "false"
+)
+
+var (
+	// outputLocksMutex serializes access to output locks files
+	outputLocksMutex sync.Mutex
+	// remoteOutputLocks binds remote wallets, having no local files, to their output locks during the session
+	remoteOutputLocks = make(map[string]map[string]core.OutputLock)
+)
+
+// isReserveOutputs tells whether transaction options request reserving outputs spent by created transactions
+func isReserveOutputs(options core.KeyValueStore) bool {
+	reserve, _ := options.GetValue(TxnOptReserveOutputs).(string)
+	return reserve == ReserveOutputsEnabled
+}
+
+// outputLocksPath file persisting output locks of local wallet
+func (wlt *LocalWallet) outputLocksPath() string {
+	return filepath.Join(wlt.WalletDir, wlt.Id+outputLocksExt)
+}
+
+// loadOutputLocks reads output locks file, no output is locked if it does not exist
+func loadOutputLocks(path string) (map[string]core.OutputLock, error) {
+	locks := make(map[string]core.OutputLock)
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return locks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &locks); err != nil {
+		return nil, err
+	}
+	return locks, nil
+}
+
+func saveOutputLocks(path string, locks map[string]core.OutputLock) error {
+	b, err := json.MarshalIndent(locks, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+// SetOutputLock persists lock state of wallet output.
+// Unlocked outputs are removed from locks file.
+func (wlt *LocalWallet) SetOutputLock(outID string, lock core.OutputLock) error {
+	logWallet.Infof("Setting lock %d for output %s", lock, outID)
+	if outID == "" || lock > core.OutputReserved {
+		return errors.ErrInvalidValue
+	}
+	outputLocksMutex.Lock()
+	defer outputLocksMutex.Unlock()
+	locks, err := loadOutputLocks(wlt.outputLocksPath())
+	if err != nil {
+		logWallet.WithError(err).Warn("Couldn't load output locks")
+		return err
+	}
+	updateOutputLock(locks, outID, lock)
+	return saveOutputLocks(wlt.outputLocksPath(), locks)
+}
+
+// GetOutputLock retrieves lock state of wallet output
+func (wlt *LocalWallet) GetOutputLock(outID string) (core.OutputLock, error) {
+	outputLocksMutex.Lock()
+	defer outputLocksMutex.Unlock()
+	locks, err := loadOutputLocks(wlt.outputLocksPath())
+	if err != nil {
+		return core.OutputUnlocked, err
+	}
+	return locks[outID], nil
+}
+
+// ListOutputLocks maps identifiers of locked outputs to their lock state.
+// Locks of outputs spent since they were locked are pruned from locks file.
+func (wlt *LocalWallet) ListOutputLocks() (map[string]core.OutputLock, error) {
+	outputLocksMutex.Lock()
+	locks, err := loadOutputLocks(wlt.outputLocksPath())
+	outputLocksMutex.Unlock()
+	if err != nil || len(locks) == 0 {
+		return locks, err
+	}
+	unspent := scanUnspentIDs(wlt)
+	if unspent == nil {
+		return locks, nil
+	}
+	outputLocksMutex.Lock()
+	defer outputLocksMutex.Unlock()
+	// Locks are reloaded in case they changed while scanning outputs
+	locks, err = loadOutputLocks(wlt.outputLocksPath())
+	if err != nil {
+		return nil, err
+	}
+	if pruneOutputLocks(locks, unspent) {
+		if err := saveOutputLocks(wlt.outputLocksPath(), locks); err != nil {
+			logWallet.WithError(err).Warn("Couldn't save pruned output locks")
+			return nil, err
+		}
+	}
+	return locks, nil
+}
+
+// outputLocksKey identifies remote wallet across node connection pool sections
+func (wlt *RemoteWallet) outputLocksKey() string {
+	return sectionOrDefault(wlt.poolSection) + "/" + wlt.Id
+}
+
+// SetOutputLock keeps lock state of remote wallet output for the session.
+// Remote wallets files are managed by the node, hence locks are not persisted.
+func (wlt *RemoteWallet) SetOutputLock(outID string, lock core.OutputLock) error {
+	logWallet.Infof("Setting lock %d for output %s of remote wallet", lock, outID)
+	if outID == "" || lock > core.OutputReserved {
+		return errors.ErrInvalidValue
+	}
+	outputLocksMutex.Lock()
+	defer outputLocksMutex.Unlock()
+	locks, isLocked := remoteOutputLocks[wlt.outputLocksKey()]
+	if !isLocked {
+		locks = make(map[string]core.OutputLock)
+		remoteOutputLocks[wlt.outputLocksKey()] = locks
+	}
+	updateOutputLock(locks, outID, lock)
+	return nil
+}
+
+// GetOutputLock retrieves lock state of remote wallet output
+func (wlt *RemoteWallet) GetOutputLock(outID string) (core.OutputLock, error) {
+	outputLocksMutex.Lock()
+	defer outputLocksMutex.Unlock()
+	return remoteOutputLocks[wlt.outputLocksKey()][outID], nil
+}
+
+// ListOutputLocks maps identifiers of locked outputs of remote wallet to their lock state.
+// Locks of outputs spent since they were locked are pruned.
+func (wlt *RemoteWallet) ListOutputLocks() (map[string]core.OutputLock, error) {
+	outputLocksMutex.Lock()
+	isLocked := len(remoteOutputLocks[wlt.outputLocksKey()]) > 0
+	outputLocksMutex.Unlock()
+	var unspent map[string]bool
+	if isLocked {
+		unspent = scanUnspentIDs(wlt)
+	}
+	outputLocksMutex.Lock()
+	defer outputLocksMutex.Unlock()
+	locks := remoteOutputLocks[wlt.outputLocksKey()]
+	pruneOutputLocks(locks, unspent)
+	listed := make(map[string]core.OutputLock, len(locks))
+	for outID, lock := range locks {
+		listed[outID] = lock
+	}
+	return listed, nil
+}
+
+// updateOutputLock sets lock state of output, unlocked outputs are removed from locks
+func updateOutputLock(locks map[string]core.OutputLock, outID string, lock core.OutputLock) {
+	if lock == core.OutputUnlocked {
+		delete(locks, outID)
+	} else {
+		locks[outID] = lock
+	}
+}
+
+// scanUnspentIDs lists identifiers of unspent outputs of wallet.
+// Nil is returned if outputs could not be retrieved.
+func scanUnspentIDs(wlt core.Wallet) map[string]bool {
+	outs, err := scanSkycoinOutputs(wlt)
+	if err != nil {
+		logWallet.WithError(err).Warn("Couldn't scan unspent outputs to prune output locks")
+		return nil
+	}
+	unspent := make(map[string]bool, len(outs))
+	for _, out := range outs {
+		unspent[out.GetId()] = true
+	}
+	return unspent
+}
+
+// pruneOutputLocks removes locks of outputs not found among unspent ones,
+// keeping every lock if unspent outputs are unknown. It tells whether any lock was removed.
+func pruneOutputLocks(locks map[string]core.OutputLock, unspent map[string]bool) bool {
+	if unspent == nil {
+		return false
+	}
+	pruned := false
+	for outID := range locks {
+		if !unspent[outID] {
+			delete(locks, outID)
+			pruned = true
+		}
+	}
+	return pruned
+}
+
+// reserveInputs marks unlocked outputs spent by transactions as reserved, if options request so,
+// keeping them away from automatic coin selection until spent or unlocked
+func reserveInputs(wlt core.Wallet, options core.KeyValueStore, txns ...core.Transaction) error {
+	if !isReserveOutputs(options) {
+		return nil
+	}
+	controller, isController := wlt.(core.CoinController)
+	if !isController {
+		return errors.ErrInvalidOptions
+	}
+	for _, txn := range txns {
+		for _, in := range txn.GetInputs() {
+			lock, err := controller.GetOutputLock(in.GetId())
+			if err != nil {
+				return err
+			}
+			if lock != core.OutputUnlocked {
+				continue
+			}
+			if err := controller.SetOutputLock(in.GetId(), core.OutputReserved); err != nil {
+				logWallet.WithError(err).Warn("Couldn't reserve transaction input")
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lookupOutputLocks lists locked outputs of wallets supporting coin control
+func lookupOutputLocks(wlt core.Wallet) (map[string]core.OutputLock, error) {
+	controller, isController := wlt.(core.CoinController)
+	if !isController {
+		return nil, nil
+	}
+	return controller.ListOutputLocks()
+}
+
+// unlockedOutputs lists unspent outputs of wallet owned by from addresses, every address if from is nil,
+// which are available for automatic coin selection.
+// Nil is returned if no output is locked, so that coins are selected among addresses as usual.
+func unlockedOutputs(wlt core.Wallet, from []core.Address) ([]core.TransactionOutput, error) {
+	locks, err := lookupOutputLocks(wlt)
+	if err != nil || len(locks) == 0 {
+		return nil, err
+	}
+	owners := make(map[string]bool, len(from))
+	for _, addr := range from {
+		owners[addr.String()] = true
+	}
+	outs, err := scanSkycoinOutputs(wlt)
+	if err != nil {
+		return nil, err
+	}
+	unlocked := make([]core.TransactionOutput, 0, len(outs))
+	for _, out := range outs {
+		if locks[out.GetId()] != core.OutputUnlocked {
+			continue
+		}
+		if from == nil || owners[out.skyOut.Address] {
+			unlocked = append(unlocked, out)
+		}
+	}
+	if len(unlocked) == 0 {
+		logWallet.Warn("Every output available for transfer is locked")
+		return nil, errors.ErrInsufficientFunds
+	}
+	return unlocked, nil
+}
+
+// excludeLockedOutputs keeps locked outputs away from coin selection.
+// If the wallet has output locks then unlocked outputs owned by from addresses,
+// or by any wallet address if from is nil, are returned to be spent explicitly.
+// Otherwise from addresses are returned as is.
+func excludeLockedOutputs(wlt core.Wallet, from []core.Address) ([]core.Address, []core.TransactionOutput, error) {
+	unlocked, err := unlockedOutputs(wlt, from)
+	if err != nil {
+		logWallet.WithError(err).Warn("Couldn't lookup unlocked outputs")
+		return nil, nil, err
+	}
+	if unlocked != nil {
+		return nil, unlocked, nil
+	}
+	return from, nil, nil
+}
+
+// Type assertions
+var (
+	_ core.CoinController = &LocalWallet{}
+	_ core.CoinController = &RemoteWallet{}
+)
@@ -0,0 +1,156 @@
+package skycoin
+
+import (
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/testutil"
+	"github.com/SkycoinProject/skycoin/src/wallet"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/stretchr/testify/require"
+)
+
+func requireTxnInputs(t *testing.T, txn core.Transaction, expected ...string) {
+	ids := make([]string, 0, len(txn.GetInputs()))
+	for _, in := range txn.GetInputs() {
+		ids = append(ids, in.GetId())
+	}
+	require.Equal(t, expected, ids)
+}
+
+func TestCoinControl(t *testing.T) {
+	sw := newSandboxWallet(t, "coincontrol", 1)
+	defer sw.close()
+	poolSection, node, wltEnv, wlt := sw.poolSection, sw.node, sw.env, sw.wlt
+	src := sw.addrs[0]
+	for i := 0; i < 3; i++ {
+		_, err := node.Mint(src.String(), 10e6, 100)
+		require.NoError(t, err)
+	}
+	outs, err := scanSkycoinOutputs(wlt)
+	require.NoError(t, err)
+	require.Len(t, outs, 3)
+	frozen, reserved, free := outs[0].GetId(), outs[1].GetId(), outs[2].GetId()
+
+	controller, isController := wlt.(core.CoinController)
+	require.True(t, isController)
+	require.Equal(t, errors.ErrInvalidValue, controller.SetOutputLock(frozen, core.OutputReserved+1))
+	require.NoError(t, controller.SetOutputLock(frozen, core.OutputFrozen))
+	require.NoError(t, controller.SetOutputLock(reserved, core.OutputReserved))
+
+	// Locks are persisted per wallet
+	controller = wltEnv.GetWalletSet().GetWallet(wlt.GetId()).(core.CoinController)
+	locks, err := controller.ListOutputLocks()
+	require.NoError(t, err)
+	require.Equal(t, map[string]core.OutputLock{frozen: core.OutputFrozen, reserved: core.OutputReserved}, locks)
+	lock, err := controller.GetOutputLock(free)
+	require.NoError(t, err)
+	require.Equal(t, core.OutputUnlocked, lock)
+
+	opt := NewTransferOptions()
+	opt.SetValue(TxnOptBurnFactor, "0.5")
+	opt.SetValue(TxnOptCoinHoursSelectionType, CoinHoursSelectionAuto)
+	dest := &SkycoinTransactionOutput{skyOut: readable.TransactionOutput{Address: testutil.MakeAddress().String(), Coins: "5"}}
+
+	// Automatic coin selection only spends unlocked outputs
+	txn, err := wlt.Transfer(dest, opt)
+	require.NoError(t, err)
+	requireTxnInputs(t, txn, free)
+	txn, err = wlt.SendFromAddress([]core.Address{src}, []core.TransactionOutput{dest}, nil, opt)
+	require.NoError(t, err)
+	requireTxnInputs(t, txn, free)
+	dest.skyOut.Coins = "15"
+	_, err = wlt.Transfer(dest, opt)
+	require.Error(t, err)
+
+	require.NoError(t, controller.SetOutputLock(reserved, core.OutputUnlocked))
+	txn, err = wlt.Transfer(dest, opt)
+	require.NoError(t, err)
+	require.Len(t, txn.GetInputs(), 2)
+	require.NotContains(t, []string{txn.GetInputs()[0].GetId(), txn.GetInputs()[1].GetId()}, frozen)
+
+	// Manual selection may still spend locked outputs
+	dest.skyOut.Coins = "5"
+	txn, err = wlt.Spend([]core.TransactionOutput{outs[0]}, []core.TransactionOutput{dest}, src, opt)
+	require.NoError(t, err)
+	requireTxnInputs(t, txn, frozen)
+
+	// Drafts reserve their inputs if requested
+	opt.SetValue(TxnOptReserveOutputs, ReserveOutputsEnabled)
+	txn, err = wlt.Transfer(dest, opt)
+	require.NoError(t, err)
+	requireTxnInputs(t, txn, free)
+	lock, err = controller.GetOutputLock(free)
+	require.NoError(t, err)
+	require.Equal(t, core.OutputReserved, lock)
+	txn, err = wlt.Transfer(dest, opt)
+	require.NoError(t, err)
+	requireTxnInputs(t, txn, reserved)
+	opt.SetValue(TxnOptReserveOutputs, ReserveOutputsDisabled)
+	_, err = wlt.Transfer(dest, opt)
+	require.Equal(t, errors.ErrInsufficientFunds, err)
+
+	// Locks of spent outputs are pruned once broadcast transactions are confirmed
+	signer, err := util.LookupSignServiceForWallet(wlt, core.UID(""))
+	require.NoError(t, err)
+	signed, err := wlt.Sign(txn, signer, util.EmptyPassword, nil)
+	require.NoError(t, err)
+	require.NoError(t, NewSkycoinPEX(poolSection).BroadcastTxn(signed))
+	locks, err = controller.ListOutputLocks()
+	require.NoError(t, err)
+	require.Equal(t, map[string]core.OutputLock{frozen: core.OutputFrozen, free: core.OutputReserved}, locks)
+
+	// Change of broadcast transaction is available for coin selection
+	txn, err = wlt.Transfer(dest, opt)
+	require.NoError(t, err)
+	require.Len(t, txn.GetInputs(), 1)
+	require.NotContains(t, []string{frozen, reserved, free}, txn.GetInputs()[0].GetId())
+}
+
+func TestRemoteWalletCoinControl(t *testing.T) {
+	poolSection, node := newSandboxSection(t, "remote-coincontrol")
+
+	wltSet := &SkycoinRemoteWallet{poolSection: poolSection}
+	wlt, err := wltSet.CreateWallet("Remote coin control", testutil.RandSHA256(t).Hex(), wallet.WalletTypeDeterministic, false, util.EmptyPassword, 0)
+	require.NoError(t, err)
+	addrs, err := wlt.GetLoadedAddresses()
+	require.NoError(t, err)
+	require.True(t, addrs.Next())
+	src := addrs.Value()
+	for i := 0; i < 2; i++ {
+		_, err = node.Mint(src.String(), 10e6, 100)
+		require.NoError(t, err)
+	}
+	outs, err := scanSkycoinOutputs(wlt)
+	require.NoError(t, err)
+	require.Len(t, outs, 2)
+	frozen, free := outs[0].GetId(), outs[1].GetId()
+
+	controller, isController := wlt.(core.CoinController)
+	require.True(t, isController)
+	require.NoError(t, controller.SetOutputLock(frozen, core.OutputFrozen))
+	require.NoError(t, controller.SetOutputLock(testutil.RandSHA256(t).Hex(), core.OutputFrozen))
+	// Locks of outputs not owned by wallet are pruned
+	locks, err := controller.ListOutputLocks()
+	require.NoError(t, err)
+	require.Equal(t, map[string]core.OutputLock{frozen: core.OutputFrozen}, locks)
+
+	opt := NewTransferOptions()
+	opt.SetValue(TxnOptBurnFactor, "0.5")
+	opt.SetValue(TxnOptCoinHoursSelectionType, CoinHoursSelectionAuto)
+	dest := &SkycoinTransactionOutput{skyOut: readable.TransactionOutput{Address: testutil.MakeAddress().String(), Coins: "5"}}
+	txn, err := wlt.Transfer(dest, opt)
+	require.NoError(t, err)
+	requireTxnInputs(t, txn, free)
+	txn, err = wlt.SendFromAddress([]core.Address{src}, []core.TransactionOutput{dest}, nil, opt)
+	require.NoError(t, err)
+	requireTxnInputs(t, txn, free)
+
+	opt.SetValue(TxnOptReserveOutputs, ReserveOutputsEnabled)
+	_, err = wlt.Transfer(dest, opt)
+	require.NoError(t, err)
+	_, err = wlt.Transfer(dest, opt)
+	require.Equal(t, errors.ErrInsufficientFunds, err)
+}
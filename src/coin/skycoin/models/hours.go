@@ -0,0 +1,434 @@
+package skycoin
+
+import (
+	"math/big"
+	"strconv"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	skyparams "github.com/SkycoinProject/skycoin/src/params"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/transaction"
+	"github.com/SkycoinProject/skycoin/src/util/droplet"
+	"github.com/SkycoinProject/skycoin/src/util/fee"
+	"github.com/SkycoinProject/skycoin/src/util/mathutil"
+	"github.com/SkycoinProject/skycoin/src/visor"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+const (
+	// CoinHoursSelectionKeep sends no coin hours to destinations, change keeps them
+	CoinHoursSelectionKeep = "keep"
+	// CoinHoursSelectionFixed sends the same amount of coin hours to every destination
+	CoinHoursSelectionFixed = "fixed"
+	// CoinHoursSelectionProportional shares coin hours left after the fee
+	// between destinations and change proportionally to their coins
+	CoinHoursSelectionProportional = "proportional"
+	// TxnOptHoursPerDestination transaction option setting coin hours sent to every destination in fixed mode
+	TxnOptHoursPerDestination = "HoursPerDestination"
+)
+
+// newHoursSelection translates coin hours selection type into node hours selection
+func newHoursSelection(coinHoursType, burnFactor string) (api.HoursSelection, error) {
+	switch coinHoursType {
+	case CoinHoursSelectionAuto:
+		return api.HoursSelection{
+			Type:        transaction.HoursSelectionTypeAuto,
+			Mode:        transaction.HoursSelectionModeShare,
+			ShareFactor: burnFactor,
+		}, nil
+	case CoinHoursSelectionKeep:
+		return api.HoursSelection{
+			Type:        transaction.HoursSelectionTypeAuto,
+			Mode:        transaction.HoursSelectionModeShare,
+			ShareFactor: "0",
+		}, nil
+	case CoinHoursSelectionManual, CoinHoursSelectionFixed, CoinHoursSelectionProportional:
+		// Coin hours of every destination are computed before sending request
+		return api.HoursSelection{Type: transaction.HoursSelectionTypeManual}, nil
+	}
+	return api.HoursSelection{}, errors.ErrInvalidOptions
+}
+
+// hoursPerDestination validates coin hours sent to every destination if selection type is fixed
+func hoursPerDestination(coinHoursType string, options core.KeyValueStore) (string, error) {
+	if coinHoursType != CoinHoursSelectionFixed {
+		return "", nil
+	}
+	hours, isString := options.GetValue(TxnOptHoursPerDestination).(string)
+	if !isString {
+		return "", errors.ErrInvalidOptions
+	}
+	if _, err := strconv.ParseUint(hours, 10, 64); err != nil {
+		return "", errors.ErrInvalidOptions
+	}
+	return hours, nil
+}
+
+// needsSources tells whether transaction options require looking up outputs of source addresses
+func needsSources(options core.KeyValueStore) bool {
+	return isSendMax(options) || options.GetValue(TxnOptCoinHoursSelectionType) == CoinHoursSelectionProportional
+}
+
+// requestOutputs looks up spendable outputs of request addresses, or unspent outputs listed in request.
+// Calculated hours are projected at time at if it is after head block time, which is returned otherwise.
+func requestOutputs(c skytypes.SkycoinAPI, req *api.CreateTransactionRequest, at uint64) (readable.UnspentOutputs, uint64, error) {
+	var summary *readable.UnspentOutputsSummary
+	var outs readable.UnspentOutputs
+	var err error
+	if len(req.Addresses) != 0 {
+		if summary, err = c.OutputsForAddresses(req.Addresses); err != nil {
+			return nil, 0, err
+		}
+		outs = summary.SpendableOutputs()
+	} else if len(req.UxOuts) != 0 {
+		// Calculated hours are only reported for outputs of addresses
+		owners := make([]string, 0, len(req.UxOuts))
+		requested := make(map[string]bool, len(req.UxOuts))
+		for _, uxID := range req.UxOuts {
+			uxOut, err := c.UxOut(uxID)
+			if err != nil {
+				return nil, 0, err
+			}
+			owners = append(owners, uxOut.OwnerAddress)
+			requested[uxID] = true
+		}
+		if summary, err = c.OutputsForAddresses(owners); err != nil {
+			return nil, 0, err
+		}
+		outs = make(readable.UnspentOutputs, 0, len(req.UxOuts))
+		for _, out := range summary.SpendableOutputs() {
+			if requested[out.Hash] {
+				outs = append(outs, out)
+				delete(requested, out.Hash)
+			}
+		}
+		if len(requested) != 0 {
+			logWallet.Warn("Transaction sources include spent outputs")
+			return nil, 0, errors.ErrTxnInputSpent
+		}
+	} else {
+		logWallet.Warn("Transaction sources are neither addresses nor outputs")
+		return nil, 0, errors.ErrInvalidOptions
+	}
+	if at <= summary.Head.Time {
+		return outs, summary.Head.Time, nil
+	}
+	projected, err := projectOutputs(outs, at)
+	return projected, at, err
+}
+
+// projectOutputs computes coin hours outputs would have accumulated at time at
+func projectOutputs(outs readable.UnspentOutputs, at uint64) (readable.UnspentOutputs, error) {
+	uxa, err := outs.ToUxArray()
+	if err != nil {
+		return nil, err
+	}
+	projected := make(readable.UnspentOutputs, len(outs))
+	copy(projected, outs)
+	for i := range uxa {
+		if projected[i].CalculatedHours, err = uxa[i].CoinHours(at); err != nil {
+			return nil, err
+		}
+	}
+	return projected, nil
+}
+
+// selectInputs chooses outputs spent by request as nodes do when creating transactions at headTime
+func selectInputs(outs readable.UnspentOutputs, req *api.CreateTransactionRequest, headTime uint64) ([]transaction.UxBalance, error) {
+	uxa, err := outs.ToUxArray()
+	if err != nil {
+		return nil, err
+	}
+	if len(uxa) == 0 {
+		return nil, errors.ErrInsufficientFunds
+	}
+	// Selection only depends on coins, coin hours of receivers are set aside
+	selReq := api.CreateTransactionRequest{
+		HoursSelection: api.HoursSelection{
+			Type:        transaction.HoursSelectionTypeAuto,
+			Mode:        transaction.HoursSelectionModeShare,
+			ShareFactor: "1",
+		},
+		ChangeAddress: req.ChangeAddress,
+		To:            make([]api.Receiver, 0, len(req.To)),
+	}
+	if selReq.ChangeAddress == nil {
+		selReq.ChangeAddress = &outs[0].Address
+	}
+	for _, recv := range req.To {
+		selReq.To = append(selReq.To, api.Receiver{Address: recv.Address, Coins: recv.Coins})
+	}
+	p, err := skytypes.NewTxnParams(selReq)
+	if err != nil {
+		return nil, err
+	}
+	_, inputs, err := transaction.Create(p, coin.NewAddressUxOuts(uxa), headTime)
+	return inputs, err
+}
+
+// proportionalHours shares coin hours of inputs left after the minimum fee between receivers
+// proportionally to coins, change takes the coin hours of its coins and those lost to rounding
+func proportionalHours(inputs []transaction.UxBalance, coins []uint64, verifyParams skyparams.VerifyTxn) ([]uint64, error) {
+	var coinsIn, hoursIn uint64
+	var err error
+	for _, in := range inputs {
+		if coinsIn, err = mathutil.AddUint64(coinsIn, in.Coins); err != nil {
+			return nil, err
+		}
+		if hoursIn, err = mathutil.AddUint64(hoursIn, in.Hours); err != nil {
+			return nil, err
+		}
+	}
+	if coinsIn == 0 {
+		return nil, errors.ErrInsufficientFunds
+	}
+	remaining := new(big.Int).SetUint64(hoursIn - fee.RequiredFee(hoursIn, verifyParams.BurnFactor))
+	total := new(big.Int).SetUint64(coinsIn)
+	hours := make([]uint64, 0, len(coins))
+	for _, c := range coins {
+		share := new(big.Int).Mul(remaining, new(big.Int).SetUint64(c))
+		hours = append(hours, share.Div(share, total).Uint64())
+	}
+	return hours, nil
+}
+
+// shareHours computes share of coin hours allocated to destinations by decimal share factor, rounded down
+func shareHours(hours uint64, shareFactor string) (uint64, error) {
+	share, isValid := new(big.Rat).SetString(shareFactor)
+	if !isValid || share.Sign() < 0 || share.Cmp(big.NewRat(1, 1)) > 0 {
+		return 0, errors.ErrInvalidOptions
+	}
+	allocated := new(big.Rat).Mul(share, new(big.Rat).SetInt(new(big.Int).SetUint64(hours)))
+	return new(big.Int).Quo(allocated.Num(), allocated.Denom()).Uint64(), nil
+}
+
+// burnHours distributes coin hours of transaction created by Skycoin library as nodes enforcing verifyParams would do.
+// Skycoin library always burns coin hours as required by Skycoin nodes.
+func burnHours(txn *coin.Transaction, inputs []transaction.UxBalance, req *api.CreateTransactionRequest, verifyParams skyparams.VerifyTxn) error {
+	if verifyParams.BurnFactor == skyparams.UserVerifyTxn.BurnFactor {
+		return nil
+	}
+	var hoursIn uint64
+	var err error
+	for _, in := range inputs {
+		if hoursIn, err = mathutil.AddUint64(hoursIn, in.Hours); err != nil {
+			return err
+		}
+	}
+	remaining := hoursIn - fee.RequiredFee(hoursIn, verifyParams.BurnFactor)
+	hasChange := len(txn.Out) > len(req.To)
+	var allocated uint64
+	if req.HoursSelection.Type == transaction.HoursSelectionTypeManual {
+		for _, out := range txn.Out[:len(req.To)] {
+			if allocated, err = mathutil.AddUint64(allocated, out.Hours); err != nil {
+				return err
+			}
+		}
+		if allocated > remaining {
+			return errors.ErrTxnInsufficientHours
+		}
+	} else {
+		// Destinations take every coin hour if there is no change
+		shareFactor := "1"
+		if hasChange {
+			shareFactor = req.HoursSelection.ShareFactor
+		}
+		if allocated, err = shareHours(remaining, shareFactor); err != nil {
+			return err
+		}
+		coins := make([]uint64, 0, len(req.To))
+		for _, out := range txn.Out[:len(req.To)] {
+			coins = append(coins, out.Coins)
+		}
+		hours, err := transaction.DistributeCoinHoursProportional(coins, allocated)
+		if err != nil {
+			return err
+		}
+		for i := range hours {
+			txn.Out[i].Hours = hours[i]
+		}
+	}
+	if hasChange {
+		txn.Out[len(req.To)].Hours = remaining - allocated
+	}
+	return txn.UpdateHeader()
+}
+
+// fillProportionalHours sets coin hours of request receivers proportionally to their coins
+// and binds request to the outputs it was computed for
+func fillProportionalHours(c skytypes.SkycoinAPI, req *api.CreateTransactionRequest, at uint64, verifyParams skyparams.VerifyTxn) error {
+	outs, headTime, err := requestOutputs(c, req, at)
+	if err != nil {
+		return err
+	}
+	inputs, err := selectInputs(outs, req, headTime)
+	if err != nil {
+		return err
+	}
+	coins := make([]uint64, 0, len(req.To))
+	for _, recv := range req.To {
+		amount, err := droplet.FromString(recv.Coins)
+		if err != nil {
+			return err
+		}
+		coins = append(coins, amount)
+	}
+	hours, err := proportionalHours(inputs, coins, verifyParams)
+	if err != nil {
+		return err
+	}
+	for i := range req.To {
+		req.To[i].Hours = strconv.FormatUint(hours[i], 10)
+	}
+	req.Addresses = nil
+	req.UxOuts = make([]string, 0, len(inputs))
+	for _, in := range inputs {
+		req.UxOuts = append(req.UxOuts, in.Hash.Hex())
+	}
+	return nil
+}
+
+// distributeHours sets coin hours of request receivers proportionally to coins using nodes of connection pool section,
+// coin hours are projected at time at unless it is zero
+func distributeHours(poolSection string, req *api.CreateTransactionRequest, at uint64) error {
+	c, err := NewSkycoinApiClient(sectionOrDefault(poolSection))
+	if err != nil {
+		logWallet.WithError(err).Warn("Couldn't load api client")
+		return err
+	}
+	defer ReturnSkycoinClient(c)
+	if err := fillProportionalHours(c, req, at, verifyTxnParams(poolSection)); err != nil {
+		logWallet.WithError(err).Warn("Couldn't distribute coin hours")
+		return err
+	}
+	return nil
+}
+
+// loadedAddresses lists addresses of wallet
+func loadedAddresses(wlt core.Wallet) ([]core.Address, error) {
+	iterAddr, err := wlt.GetLoadedAddresses()
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]core.Address, 0)
+	for iterAddr.Next() {
+		addrs = append(addrs, iterAddr.Value())
+	}
+	return addrs, nil
+}
+
+// projectAddressOutputs lists spendable outputs of addresses, every wallet address if nil,
+// with coin hours accumulated at time at
+func projectAddressOutputs(wlt core.Wallet, poolSection string, from []core.Address, at uint64) ([]core.TransactionOutput, error) {
+	var err error
+	if from == nil {
+		if from, err = loadedAddresses(wlt); err != nil {
+			return nil, err
+		}
+	}
+	req := api.CreateTransactionRequest{Addresses: make([]string, 0, len(from))}
+	for _, addr := range from {
+		req.Addresses = append(req.Addresses, addr.String())
+	}
+	c, err := NewSkycoinApiClient(sectionOrDefault(poolSection))
+	if err != nil {
+		return nil, err
+	}
+	defer ReturnSkycoinClient(c)
+	outs, _, err := requestOutputs(c, &req, at)
+	if err != nil {
+		return nil, err
+	}
+	projected := make([]core.TransactionOutput, 0, len(outs))
+	for _, out := range outs {
+		projected = append(projected, &SkycoinTransactionOutput{
+			skyOut: readable.TransactionOutput{
+				Address: out.Address,
+				Coins:   out.Coins,
+				Hours:   out.Hours,
+				Hash:    out.Hash,
+			},
+			calculatedHours: out.CalculatedHours,
+			poolSection:     poolSection,
+		})
+	}
+	return projected, nil
+}
+
+// previewTransfer creates transaction locally as nodes would do at time at
+func previewTransfer(wlt core.Wallet, poolSection string, from []core.Address, to []core.TransactionOutput, change core.Address, at uint64, options core.KeyValueStore) (core.Transaction, error) {
+	var err error
+	if from == nil {
+		if from, err = loadedAddresses(wlt); err != nil {
+			return nil, err
+		}
+	}
+	createTxnFunc := func(txnReq *api.CreateTransactionRequest) (core.Transaction, error) {
+		c, err := NewSkycoinApiClient(sectionOrDefault(poolSection))
+		if err != nil {
+			return nil, err
+		}
+		defer ReturnSkycoinClient(c)
+		outs, headTime, err := requestOutputs(c, txnReq, at)
+		if err != nil {
+			return nil, err
+		}
+		if txnReq.ChangeAddress == nil && len(outs) != 0 {
+			txnReq.ChangeAddress = &outs[0].Address
+		}
+		p, err := skytypes.NewTxnParams(*txnReq)
+		if err != nil {
+			return nil, err
+		}
+		uxa, err := outs.ToUxArray()
+		if err != nil {
+			return nil, err
+		}
+		txn, inputs, err := transaction.Create(p, coin.NewAddressUxOuts(uxa), headTime)
+		if err != nil {
+			return nil, err
+		}
+		if err := burnHours(txn, inputs, txnReq, verifyTxnParams(poolSection)); err != nil {
+			return nil, err
+		}
+		cTxn, err := api.NewCreatedTransaction(txn, visor.NewTransactionInputsFromUxBalance(inputs))
+		if err != nil {
+			return nil, err
+		}
+		return newSkycoinCreatedTransaction(*cTxn, poolSection), nil
+	}
+	return createTransactionAt(poolSection, at, from, to, nil, change, options, createTxnFunc)
+}
+
+// ProjectOutputs lists unspent outputs of addresses, every wallet address if nil,
+// with calculated hours accumulated at time at
+func (wlt *LocalWallet) ProjectOutputs(from []core.Address, at core.Timestamp) ([]core.TransactionOutput, error) {
+	return projectAddressOutputs(wlt, wlt.poolSection, from, uint64(at))
+}
+
+// PreviewTransfer instantiates transaction sending funds from addresses, every wallet address if nil,
+// as it would be created at time at, so as to review fee and coin hours received before creating it
+func (wlt *LocalWallet) PreviewTransfer(from []core.Address, to []core.TransactionOutput, change core.Address, at core.Timestamp, options core.KeyValueStore) (core.Transaction, error) {
+	return previewTransfer(wlt, wlt.poolSection, from, to, change, uint64(at), options)
+}
+
+// ProjectOutputs lists unspent outputs of addresses, every wallet address if nil,
+// with calculated hours accumulated at time at
+func (wlt *RemoteWallet) ProjectOutputs(from []core.Address, at core.Timestamp) ([]core.TransactionOutput, error) {
+	return projectAddressOutputs(wlt, wlt.poolSection, from, uint64(at))
+}
+
+// PreviewTransfer instantiates transaction sending funds from addresses, every wallet address if nil,
+// as it would be created at time at, so as to review fee and coin hours received before creating it
+func (wlt *RemoteWallet) PreviewTransfer(from []core.Address, to []core.TransactionOutput, change core.Address, at core.Timestamp, options core.KeyValueStore) (core.Transaction, error) {
+	return previewTransfer(wlt, wlt.poolSection, from, to, change, uint64(at), options)
+}
+
+// Type assertions
+var (
+	_ core.CoinHoursPlanner = &LocalWallet{}
+	_ core.CoinHoursPlanner = &RemoteWallet{}
+)
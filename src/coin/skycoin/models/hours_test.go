@@ -0,0 +1,157 @@
+package skycoin
+
+import (
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	skyparams "github.com/SkycoinProject/skycoin/src/params"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/testutil"
+	"github.com/SkycoinProject/skycoin/src/transaction"
+	"github.com/SkycoinProject/skycoin/src/util/fee"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHoursSelection(t *testing.T) {
+	sel, err := newHoursSelection(CoinHoursSelectionAuto, "0.5")
+	require.NoError(t, err)
+	require.Equal(t, api.HoursSelection{Type: transaction.HoursSelectionTypeAuto, Mode: transaction.HoursSelectionModeShare, ShareFactor: "0.5"}, sel)
+	sel, err = newHoursSelection(CoinHoursSelectionKeep, "0.5")
+	require.NoError(t, err)
+	require.Equal(t, "0", sel.ShareFactor)
+	for _, mode := range []string{CoinHoursSelectionManual, CoinHoursSelectionFixed, CoinHoursSelectionProportional} {
+		sel, err = newHoursSelection(mode, "0.5")
+		require.NoError(t, err)
+		require.Equal(t, api.HoursSelection{Type: transaction.HoursSelectionTypeManual}, sel)
+	}
+	_, err = newHoursSelection("unknown", "0.5")
+	require.Equal(t, errors.ErrInvalidOptions, err)
+
+	opt := NewTransferOptions()
+	hours, err := hoursPerDestination(CoinHoursSelectionAuto, opt)
+	require.NoError(t, err)
+	require.Empty(t, hours)
+	_, err = hoursPerDestination(CoinHoursSelectionFixed, opt)
+	require.Equal(t, errors.ErrInvalidOptions, err)
+	opt.SetValue(TxnOptHoursPerDestination, "-1")
+	_, err = hoursPerDestination(CoinHoursSelectionFixed, opt)
+	require.Equal(t, errors.ErrInvalidOptions, err)
+	opt.SetValue(TxnOptHoursPerDestination, "12")
+	hours, err = hoursPerDestination(CoinHoursSelectionFixed, opt)
+	require.NoError(t, err)
+	require.Equal(t, "12", hours)
+}
+
+func TestProportionalHours(t *testing.T) {
+	inputs := []transaction.UxBalance{
+		{Coins: 10e6, Hours: 150},
+		{Coins: 20e6, Hours: 50},
+	}
+	hours, err := proportionalHours(inputs, []uint64{15e6, 5e6}, skyparams.UserVerifyTxn)
+	require.NoError(t, err)
+	remaining := 200 - fee.RequiredFee(200, skyparams.UserVerifyTxn.BurnFactor)
+	require.Equal(t, []uint64{remaining / 2, remaining / 6}, hours)
+
+	_, err = proportionalHours(nil, []uint64{1e6}, skyparams.UserVerifyTxn)
+	require.Equal(t, errors.ErrInsufficientFunds, err)
+}
+
+func TestProjectOutputs(t *testing.T) {
+	outs := readable.UnspentOutputs{
+		{Hash: testutil.RandSHA256(t).Hex(), Address: testutil.MakeAddress().String(), SourceTransaction: testutil.RandSHA256(t).Hex(),
+			Coins: "2.000000", Hours: 10, CalculatedHours: 10, Time: 1000},
+	}
+	projected, err := projectOutputs(outs, 1000+3600)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), projected[0].CalculatedHours)
+	require.Equal(t, uint64(10), outs[0].CalculatedHours)
+}
+
+func TestCoinHoursPlanner(t *testing.T) {
+	sw := newSandboxWallet(t, "hours", 1)
+	defer sw.close()
+	poolSection, p, node, wlt := sw.poolSection, sw.params, sw.node, sw.wlt
+	src := sw.addrs[0]
+	_, err := node.Mint(src.String(), 30e6, 400)
+	require.NoError(t, err)
+
+	dest := func(coins string) core.TransactionOutput {
+		return &SkycoinTransactionOutput{skyOut: readable.TransactionOutput{Address: testutil.MakeAddress().String(), Coins: coins}}
+	}
+	outputHours := func(txn core.Transaction) []uint64 {
+		hours := make([]uint64, 0, len(txn.GetOutputs()))
+		for _, out := range txn.GetOutputs() {
+			h, err := out.GetCoins(p.CoinHoursTicker)
+			require.NoError(t, err)
+			hours = append(hours, h)
+		}
+		return hours
+	}
+	to := []core.TransactionOutput{dest("10"), dest("5")}
+	opt := NewTransferOptions()
+	opt.SetValue(TxnOptBurnFactor, "0.5")
+	remaining := 400 - fee.RequiredFee(400, skyparams.UserVerifyTxn.BurnFactor)
+
+	// Change keeps every coin hour left after the fee
+	opt.SetValue(TxnOptCoinHoursSelectionType, CoinHoursSelectionKeep)
+	txn, err := wlt.SendFromAddress([]core.Address{src}, to, src, opt)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0, 0, remaining}, outputHours(txn))
+
+	opt.SetValue(TxnOptCoinHoursSelectionType, CoinHoursSelectionFixed)
+	opt.SetValue(TxnOptHoursPerDestination, "7")
+	txn, err = wlt.SendFromAddress([]core.Address{src}, to, src, opt)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{7, 7, remaining - 14}, outputHours(txn))
+
+	opt.SetValue(TxnOptCoinHoursSelectionType, CoinHoursSelectionProportional)
+	txn, err = wlt.SendFromAddress([]core.Address{src}, to, src, opt)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{remaining / 3, remaining / 6, remaining - remaining/3 - remaining/6}, outputHours(txn))
+
+	// Calculated hours accumulate over time
+	planner, isPlanner := wlt.(core.CoinHoursPlanner)
+	require.True(t, isPlanner)
+	current, err := planner.ProjectOutputs(nil, 0)
+	require.NoError(t, err)
+	require.Len(t, current, 1)
+	nowHours, err := current[0].GetCoins(p.CalculatedHoursTicker)
+	require.NoError(t, err)
+	head, err := node.LastBlocks(1)
+	require.NoError(t, err)
+	later := core.Timestamp(head.Blocks[0].Head.Time + 10*3600)
+	projected, err := planner.ProjectOutputs([]core.Address{src}, later)
+	require.NoError(t, err)
+	require.Len(t, projected, 1)
+	laterHours, err := projected[0].GetCoins(p.CalculatedHoursTicker)
+	require.NoError(t, err)
+	require.Equal(t, nowHours+300, laterHours)
+
+	// Fee and coin hours received are previewed at a future date
+	preview, err := planner.PreviewTransfer(nil, to, src, later, opt)
+	require.NoError(t, err)
+	laterRemaining := laterHours - fee.RequiredFee(laterHours, skyparams.UserVerifyTxn.BurnFactor)
+	require.Equal(t, []uint64{laterRemaining / 3, laterRemaining / 6, laterRemaining - laterRemaining/3 - laterRemaining/6}, outputHours(preview))
+	txnFee, err := preview.ComputeFee(p.CoinHoursTicker)
+	require.NoError(t, err)
+	require.Equal(t, laterHours-laterRemaining, txnFee)
+
+	// Previews burn coin hours as required by the wallet coin
+	fiberParams := p
+	fiberParams.VerifyTxn.BurnFactor = 4
+	RegisterFiberCoin(poolSection, fiberParams)
+	defer RegisterFiberCoin(poolSection, p)
+	fiberRemaining := laterHours - fee.RequiredFee(laterHours, 4)
+	preview, err = planner.PreviewTransfer(nil, to, src, later, opt)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{fiberRemaining / 3, fiberRemaining / 6, fiberRemaining - fiberRemaining/3 - fiberRemaining/6}, outputHours(preview))
+	opt.SetValue(TxnOptCoinHoursSelectionType, CoinHoursSelectionAuto)
+	preview, err = planner.PreviewTransfer(nil, to, src, later, opt)
+	require.NoError(t, err)
+	txnFee, err = preview.ComputeFee(p.CoinHoursTicker)
+	require.NoError(t, err)
+	require.Equal(t, laterHours-fiberRemaining, txnFee)
+	require.Equal(t, fiberRemaining-fiberRemaining/2, outputHours(preview)[2])
+}
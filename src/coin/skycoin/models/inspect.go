@@ -0,0 +1,194 @@
+package skycoin
+
+import (
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/util/fee"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+)
+
+// InspectedOutput describes an output created by an inspected transaction
+type InspectedOutput struct {
+	// UxID identifies the output
+	UxID string
+	// Address owning output coins
+	Address string
+	// Coins amount in droplets
+	Coins uint64
+	// Hours amount of coin hours
+	Hours uint64
+	// Wallet ID of local wallet owning address, empty if it belongs to a third party
+	Wallet string
+}
+
+// InspectedInput describes an output spent by an inspected transaction
+type InspectedInput struct {
+	InspectedOutput
+	// Signed is set if input signature is present
+	Signed bool
+	// Spent is set if output has already been spent in the blockchain
+	Spent bool
+}
+
+// TransactionInspection summarizes a raw transaction decoded for review before it is broadcast
+type TransactionInspection struct {
+	// Txn decoded transaction
+	Txn *SkycoinUninjectedTransaction
+	// Inputs spent outputs, hours are calculated at the time of the last block
+	Inputs []InspectedInput
+	// Outputs created outputs
+	Outputs []InspectedOutput
+	// Hours total coin hours sent to outputs
+	Hours uint64
+	// Fee coin hours burned by transaction
+	Fee uint64
+	// FullySigned is set if every input has been signed
+	FullySigned bool
+	// VerifyErr reports why transaction is invalid, nil if it is valid
+	VerifyErr error
+}
+
+// DecodeRawTransaction parses hex-encoded transaction resolving its inputs to compute fee
+func DecodeRawTransaction(poolSection, rawTxn string) (*SkycoinUninjectedTransaction, error) {
+	inspection, err := InspectRawTransaction(poolSection, rawTxn)
+	if err != nil {
+		return nil, err
+	}
+	return inspection.Txn, nil
+}
+
+// InspectRawTransaction decodes hex-encoded transaction, resolves inputs via node
+// and verifies signatures. Addresses are looked up in wallets of wltEnvs.
+func InspectRawTransaction(poolSection, rawTxn string, wltEnvs ...core.WalletEnv) (*TransactionInspection, error) {
+	logCoin.Info("Inspecting raw transaction")
+	txn, err := coin.DeserializeTransactionHex(rawTxn)
+	if err != nil {
+		logCoin.WithError(err).Warn("Couldn't decode raw transaction")
+		return nil, err
+	}
+	c, err := NewSkycoinApiClient(sectionOrDefault(poolSection))
+	if err != nil {
+		return nil, err
+	}
+	defer ReturnSkycoinClient(c)
+	health, err := c.Health()
+	if err != nil {
+		return nil, err
+	}
+	headTime := health.BlockchainMetadata.Head.Time
+	owners := walletAddresses(wltEnvs)
+
+	inspection := &TransactionInspection{
+		Inputs:      make([]InspectedInput, 0, len(txn.In)),
+		Outputs:     make([]InspectedOutput, 0, len(txn.Out)),
+		FullySigned: txn.IsFullySigned(),
+	}
+	uxIn := make(coin.UxArray, 0, len(txn.In))
+	for i, in := range txn.In {
+		ux, err := c.UxOut(in.Hex())
+		if err != nil {
+			logCoin.WithError(err).Warn("Couldn't resolve transaction input")
+			return nil, err
+		}
+		cUx, err := uxOutFromSpentOutput(ux)
+		if err != nil {
+			return nil, err
+		}
+		hours, err := cUx.CoinHours(headTime)
+		if err != nil {
+			return nil, err
+		}
+		uxIn = append(uxIn, cUx)
+		inspection.Inputs = append(inspection.Inputs, InspectedInput{
+			InspectedOutput: InspectedOutput{
+				UxID:    ux.Uxid,
+				Address: ux.OwnerAddress,
+				Coins:   ux.Coins,
+				Hours:   hours,
+				Wallet:  owners[ux.OwnerAddress],
+			},
+			Signed: i < len(txn.Sigs) && !txn.Sigs[i].Null(),
+			Spent:  ux.SpentTxnID != cipher.SHA256{}.Hex(),
+		})
+	}
+	txnHash := txn.Hash()
+	for _, out := range txn.Out {
+		rOut, err := readable.NewTransactionOutput(&out, txnHash)
+		if err != nil {
+			return nil, err
+		}
+		addr := out.Address.String()
+		inspection.Outputs = append(inspection.Outputs, InspectedOutput{
+			UxID:    rOut.Hash,
+			Address: addr,
+			Coins:   out.Coins,
+			Hours:   out.Hours,
+			Wallet:  owners[addr],
+		})
+		inspection.Hours += out.Hours
+	}
+
+	if inspection.FullySigned {
+		inspection.VerifyErr = txn.Verify()
+	} else {
+		inspection.VerifyErr = txn.VerifyUnsigned()
+	}
+	inspection.Fee, err = fee.TransactionFee(&txn, headTime, uxIn)
+	if err != nil && inspection.VerifyErr == nil {
+		inspection.VerifyErr = err
+	}
+	inspection.Txn, err = NewUninjectedTransaction(&txn, inspection.Fee)
+	if err != nil {
+		return nil, err
+	}
+	inspection.Txn.poolSection = poolSection
+	return inspection, nil
+}
+
+// walletAddresses maps loaded addresses of every wallet to wallet ID
+func walletAddresses(wltEnvs []core.WalletEnv) map[string]string {
+	owners := make(map[string]string)
+	for _, env := range wltEnvs {
+		wlts := env.GetWalletSet().ListWallets()
+		if wlts == nil {
+			continue
+		}
+		for wlts.Next() {
+			wlt := wlts.Value()
+			addrs, err := wlt.GetLoadedAddresses()
+			if err != nil {
+				logCoin.WithError(err).Warn("Couldn't get loaded addresses")
+				continue
+			}
+			for addrs.Next() {
+				owners[addrs.Value().String()] = wlt.GetId()
+			}
+		}
+	}
+	return owners
+}
+
+// uxOutFromSpentOutput rebuilds unspent output from node response
+func uxOutFromSpentOutput(ux *readable.SpentOutput) (coin.UxOut, error) {
+	addr, err := cipher.DecodeBase58Address(ux.OwnerAddress)
+	if err != nil {
+		return coin.UxOut{}, err
+	}
+	srcTxn, err := cipher.SHA256FromHex(ux.SrcTx)
+	if err != nil {
+		return coin.UxOut{}, err
+	}
+	return coin.UxOut{
+		Head: coin.UxHead{
+			BkSeq: ux.SrcBkSeq,
+			Time:  ux.Time,
+		},
+		Body: coin.UxBody{
+			Address:        addr,
+			Coins:          ux.Coins,
+			Hours:          ux.Hours,
+			SrcTransaction: srcTxn,
+		},
+	}, nil
+}
@@ -0,0 +1,94 @@
+package skycoin
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/testutil"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeRawTxn(t *testing.T, txn core.Transaction) string {
+	skyTxn, isSkyTxn := txn.(skytypes.SkycoinTxn)
+	require.True(t, isSkyTxn)
+	txnBytes, err := skyTxn.EncodeSkycoinTransaction()
+	require.NoError(t, err)
+	return hex.EncodeToString(txnBytes)
+}
+
+func TestInspectRawTransaction(t *testing.T) {
+	sw := newSandboxWallet(t, "inspect", 1)
+	defer sw.close()
+	poolSection, p, node, wltEnv, wlt := sw.poolSection, sw.params, sw.node, sw.env, sw.wlt
+	src := sw.addrs[0].String()
+	_, err := node.Mint(src, 100e6, 1000)
+	require.NoError(t, err)
+
+	dest := testutil.MakeAddress().String()
+	opt := NewTransferOptions()
+	opt.SetValue("BurnFactor", "0.5")
+	opt.SetValue("CoinHoursSelectionType", "auto")
+	txn, err := wlt.Transfer(&SkycoinTransactionOutput{
+		skyOut: readable.TransactionOutput{
+			Address: dest,
+			Coins:   "10",
+		}}, opt)
+	require.NoError(t, err)
+
+	_, err = InspectRawTransaction(poolSection, "not a transaction", wltEnv)
+	require.Error(t, err)
+
+	// Unsigned transaction
+	inspection, err := InspectRawTransaction(poolSection, encodeRawTxn(t, txn), wltEnv)
+	require.NoError(t, err)
+	require.False(t, inspection.FullySigned)
+	require.NoError(t, inspection.VerifyErr)
+	require.Len(t, inspection.Inputs, 1)
+	in := inspection.Inputs[0]
+	require.Equal(t, src, in.Address)
+	require.Equal(t, uint64(100e6), in.Coins)
+	require.Equal(t, wlt.GetId(), in.Wallet)
+	require.False(t, in.Signed)
+	require.False(t, in.Spent)
+	require.Len(t, inspection.Outputs, 2)
+	require.Equal(t, dest, inspection.Outputs[0].Address)
+	require.Equal(t, uint64(10e6), inspection.Outputs[0].Coins)
+	require.Empty(t, inspection.Outputs[0].Wallet)
+	require.Equal(t, in.Hours, inspection.Hours+inspection.Fee)
+	require.NotZero(t, inspection.Fee)
+	fee, err := inspection.Txn.ComputeFee(CoinHour)
+	require.NoError(t, err)
+	require.Equal(t, inspection.Fee, fee)
+
+	// Signed transaction
+	signer, err := util.LookupSignServiceForWallet(wlt, core.UID(""))
+	require.NoError(t, err)
+	signed, err := wlt.Sign(txn, signer, util.EmptyPassword, nil)
+	require.NoError(t, err)
+	rawTxn := encodeRawTxn(t, signed)
+	inspection, err = InspectRawTransaction(poolSection, rawTxn, wltEnv)
+	require.NoError(t, err)
+	require.True(t, inspection.FullySigned)
+	require.NoError(t, inspection.VerifyErr)
+	require.True(t, inspection.Inputs[0].Signed)
+	require.Equal(t, signed.GetId(), inspection.Txn.GetId())
+
+	// Decoded transactions can be broadcast
+	plugin := NewSkyFiberPlugin(p).(core.RawTxnDecoder)
+	_, err = plugin.DecodeRawTxn("XYZ", rawTxn)
+	require.Equal(t, errors.ErrInvalidAltcoinTicker, err)
+	decoded, err := plugin.DecodeRawTxn(p.Ticker, rawTxn)
+	require.NoError(t, err)
+	require.NoError(t, NewSkycoinPEX(poolSection).BroadcastTxn(decoded))
+
+	// Inputs of confirmed transaction are spent
+	inspection, err = InspectRawTransaction(poolSection, rawTxn)
+	require.NoError(t, err)
+	require.True(t, inspection.Inputs[0].Spent)
+	require.Empty(t, inspection.Inputs[0].Wallet)
+}
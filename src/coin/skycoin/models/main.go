@@ -15,24 +15,29 @@ type SkyFiberPlugin struct {
 
 // ListSupportedAltcoins to enumerate supported assets and related metadata
 func (p *SkyFiberPlugin) ListSupportedAltcoins() []core.AltcoinMetadata {
+	coinHoursName := p.Params.CoinHoursName
+	if coinHoursName == "" {
+		coinHoursName = p.Params.Name + " " + CoinHoursName
+	}
 	return []core.AltcoinMetadata{
 		core.AltcoinMetadata{
-			Name:     SkycoinName,
-			Ticker:   SkycoinTicker,
-			Family:   SkycoinFamily,
-			HasBip44: false,
-			Accuracy: 6,
+			Name:          p.Params.Name,
+			Ticker:        p.Params.Ticker,
+			Family:        SkycoinFamily,
+			HasBip44:      true,
+			Bip44CoinType: int32(p.Params.Bip44CoinType),
+			Accuracy:      int32(p.Params.Accuracy),
 		},
 		core.AltcoinMetadata{
-			Name:     CoinHoursName,
-			Ticker:   CoinHoursTicker,
+			Name:     coinHoursName,
+			Ticker:   p.Params.CoinHoursTicker,
 			Family:   SkycoinFamily,
 			HasBip44: false,
 			Accuracy: 0,
 		},
 		core.AltcoinMetadata{
 			Name:     CalculatedHoursName,
-			Ticker:   CalculatedHoursTicker,
+			Ticker:   p.Params.CalculatedHoursTicker,
 			Family:   SkycoinFamily,
 			HasBip44: false,
 			Accuracy: 0,
@@ -47,6 +52,7 @@ func (p *SkyFiberPlugin) ListSupportedFamilies() []string {
 
 // RegisterTo boilerplate to register this plugin against an altcoin manager and enable it
 func (p *SkyFiberPlugin) RegisterTo(manager core.AltcoinManager) {
+	RegisterFiberCoin(p.Params.PoolSection, p.Params)
 	for _, info := range p.ListSupportedAltcoins() {
 		manager.RegisterAltcoin(info, p)
 	}
@@ -64,6 +70,10 @@ func (p *SkyFiberPlugin) GetDescription() string {
 
 // LoadWalletEnvs loads wallet environments to lookup and create wallets
 func (p *SkyFiberPlugin) LoadWalletEnvs() []core.WalletEnv {
+	if p.Params.PoolSection != PoolSection {
+		// Other SkyFiber coins keep local wallets in their own directory
+		return []core.WalletEnv{newFiberWalletDirectory(p.Params.WalletDir, p.Params.PoolSection)}
+	}
 
 	wltSources, err := config.GetWalletSources()
 	if err != nil {
@@ -91,7 +101,7 @@ func (p *SkyFiberPlugin) LoadPEX(netType string) (core.PEX, error) {
 	if netType != "MainNet" {
 		return nil, errors.ErrInvalidNetworkType
 	}
-	return NewSkycoinPEX(PoolSection), nil
+	return NewSkycoinPEX(p.Params.PoolSection), nil
 }
 
 // LoadTransactionAPI blockchain transaction API entry poiny
@@ -100,7 +110,7 @@ func (p *SkyFiberPlugin) LoadTransactionAPI(netType string) (core.BlockchainTran
 		return nil, errors.ErrInvalidNetworkType
 	}
 	refreshTimeOut := config.GetDataRefreshTimeout()
-	return NewSkycoinBlockchain(refreshTimeOut*(1000000000)), nil
+	return newSkycoinBlockchain(refreshTimeOut*(1000000000), p.Params.PoolSection), nil
 }
 
 // LoadSignService sign service entry point
@@ -110,7 +120,7 @@ func (p *SkyFiberPlugin) LoadSignService() (core.BlockchainSignService, error) {
 
 // AddressFromString retrieves address corresponding to readable representation
 func (p *SkyFiberPlugin) AddressFromString(addrStr string) (core.Address, error) {
-	addr, err := NewSkycoinAddress(addrStr)
+	addr, err := newSkycoinAddress(addrStr, p.Params.PoolSection)
 	if err != nil {
 		return nil, err
 	}
@@ -127,6 +137,52 @@ func (p *SkyFiberPlugin) SecKeyFromBytes(b []byte) (core.SecKey, error) {
 	return skySecKeyFromBytes(b)
 }
 
+// ListTxnOptions enumerates options accepted when creating SkyFiber transactions
+func (p *SkyFiberPlugin) ListTxnOptions(ticker string) []core.TxnOptionSpec {
+	if ticker != p.Params.Ticker && ticker != p.Params.CoinHoursTicker {
+		return nil
+	}
+	return []core.TxnOptionSpec{
+		core.TxnOptionSpec{
+			Key:     TxnOptCoinHoursSelectionType,
+			Caption: "Coin hours selection",
+			Default: CoinHoursSelectionAuto,
+			Choices: []string{CoinHoursSelectionAuto, CoinHoursSelectionManual, CoinHoursSelectionKeep,
+				CoinHoursSelectionFixed, CoinHoursSelectionProportional},
+		},
+		core.TxnOptionSpec{
+			Key:     TxnOptBurnFactor,
+			Caption: "Burn factor",
+			Default: p.Params.ShareFactor,
+		},
+		core.TxnOptionSpec{
+			Key:     TxnOptSendMax,
+			Caption: "Send max",
+			Default: SendMaxDisabled,
+			Choices: []string{SendMaxDisabled, SendMaxEnabled},
+		},
+		core.TxnOptionSpec{
+			Key:     TxnOptHoursPerDestination,
+			Caption: "Coin hours per destination",
+			Default: "0",
+		},
+		core.TxnOptionSpec{
+			Key:     TxnOptReserveOutputs,
+			Caption: "Reserve outputs",
+			Default: ReserveOutputsDisabled,
+			Choices: []string{ReserveOutputsDisabled, ReserveOutputsEnabled},
+		},
+	}
+}
+
+// DecodeRawTxn parses hex-encoded SkyFiber transaction resolving its inputs via node
+func (p *SkyFiberPlugin) DecodeRawTxn(ticker, rawTxn string) (core.Transaction, error) {
+	if ticker != p.Params.Ticker && ticker != p.Params.CoinHoursTicker {
+		return nil, errors.ErrInvalidAltcoinTicker
+	}
+	return DecodeRawTransaction(p.Params.PoolSection, rawTxn)
+}
+
 // NewSkyFiberPlugin instantiate SkyFiber plugin entry point
 func NewSkyFiberPlugin(params params.SkyFiberParams) core.AltcoinPlugin {
 	return &SkyFiberPlugin{
@@ -136,5 +192,7 @@ func NewSkyFiberPlugin(params params.SkyFiberParams) core.AltcoinPlugin {
 
 // Type assertions
 var (
-	_ core.AltcoinPlugin = &SkyFiberPlugin{}
+	_ core.AltcoinPlugin      = &SkyFiberPlugin{}
+	_ core.TxnOptionsProvider = &SkyFiberPlugin{}
+	_ core.RawTxnDecoder      = &SkyFiberPlugin{}
 )
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/SkycoinProject/skycoin/src/readable"
 	"github.com/fibercrypto/fibercryptowallet/src/coin/mocks"
 	"github.com/fibercrypto/fibercryptowallet/src/core"
 	"github.com/fibercrypto/fibercryptowallet/src/params"
@@ -36,11 +37,12 @@ func TestSkyFiberPluginFunctions(t *testing.T) {
 	description := "FiberCrypto wallet connector for Skycoin and SkyFiber altcoins"
 	altcoins := []core.AltcoinMetadata{
 		core.AltcoinMetadata{
-			Name:     SkycoinName,
-			Ticker:   SkycoinTicker,
-			Family:   SkycoinFamily,
-			HasBip44: false,
-			Accuracy: 6,
+			Name:          SkycoinName,
+			Ticker:        SkycoinTicker,
+			Family:        SkycoinFamily,
+			HasBip44:      true,
+			Bip44CoinType: 8000,
+			Accuracy:      6,
 		},
 		core.AltcoinMetadata{
 			Name:     CoinHoursName,
@@ -142,6 +144,29 @@ func TestSkyFiberPluginNetOperations(t *testing.T) {
 	})
 }
 
+func TestSkyFiberPluginListTxnOptions(t *testing.T) {
+	plugin := NewSkyFiberPlugin(SkycoinMainNetParams)
+	provider, isProvider := plugin.(core.TxnOptionsProvider)
+	require.True(t, isProvider)
+
+	for _, ticker := range []string{SkycoinTicker, CoinHoursTicker} {
+		opts := provider.ListTxnOptions(ticker)
+		require.Len(t, opts, 5)
+		require.Equal(t, TxnOptCoinHoursSelectionType, opts[0].Key)
+		require.Equal(t, CoinHoursSelectionAuto, opts[0].Default)
+		require.Equal(t, []string{CoinHoursSelectionAuto, CoinHoursSelectionManual, CoinHoursSelectionKeep,
+			CoinHoursSelectionFixed, CoinHoursSelectionProportional}, opts[0].Choices)
+		require.Equal(t, TxnOptBurnFactor, opts[1].Key)
+		require.Equal(t, DefaultShareFactor, opts[1].Default)
+		require.Equal(t, TxnOptSendMax, opts[2].Key)
+		require.Equal(t, SendMaxDisabled, opts[2].Default)
+		require.Equal(t, TxnOptHoursPerDestination, opts[3].Key)
+		require.Equal(t, TxnOptReserveOutputs, opts[4].Key)
+		require.Equal(t, ReserveOutputsDisabled, opts[4].Default)
+	}
+	require.Nil(t, provider.ListTxnOptions("UNKNOWN"))
+}
+
 func TestSkyFiberPluginAddressFromString(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -268,3 +293,66 @@ func TestSkyFiberPluginSecKeyFromBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestSkyFiberPluginFiberCoin(t *testing.T) {
+	fiberParams := SkycoinMainNetParams
+	fiberParams.Name = "Fiber Test"
+	fiberParams.Ticker = "FTC"
+	fiberParams.CoinHoursName = "Fiber Hours"
+	fiberParams.CoinHoursTicker = "FTH"
+	fiberParams.CalculatedHoursTicker = "FTH#ACC"
+	fiberParams.ShareFactor = "0.25"
+	fiberParams.PoolSection = "skycoin.ftc"
+	fiberParams.WalletDir = "testdata/fibertest"
+
+	plugin := NewSkyFiberPlugin(fiberParams)
+	util.RegisterAltcoin(plugin)
+	require.Equal(t, fiberParams, LookupFiberCoin(fiberParams.PoolSection))
+	require.Equal(t, SkycoinMainNetParams, LookupFiberCoin(PoolSection))
+
+	altcoins := plugin.ListSupportedAltcoins()
+	require.Len(t, altcoins, 3)
+	require.Equal(t, core.AltcoinMetadata{Name: "Fiber Test", Ticker: "FTC", Family: SkycoinFamily, HasBip44: true, Bip44CoinType: 8000, Accuracy: 6}, altcoins[0])
+	require.Equal(t, "Fiber Hours", altcoins[1].Name)
+	require.Equal(t, "FTH", altcoins[1].Ticker)
+	require.Equal(t, "FTH#ACC", altcoins[2].Ticker)
+
+	pex, err := plugin.LoadPEX("MainNet")
+	require.NoError(t, err)
+	require.Equal(t, fiberParams.PoolSection, pex.(*SkycoinPEX).poolSection)
+	api, err := plugin.LoadTransactionAPI("MainNet")
+	require.NoError(t, err)
+	require.Equal(t, fiberParams.PoolSection, api.(*SkycoinBlockchain).poolSection)
+
+	envs := plugin.LoadWalletEnvs()
+	require.Len(t, envs, 1)
+	wltDir := envs[0].(*WalletDirectory)
+	require.Equal(t, fiberParams.WalletDir, wltDir.WalletDir)
+	require.Equal(t, fiberParams.PoolSection, wltDir.GetWalletSet().(*SkycoinLocalWallet).poolSection)
+
+	addr, err := plugin.AddressFromString("R6aHqKWSQfvpdo2fGSrq4F1RYXkBWR9HHJ")
+	require.NoError(t, err)
+	require.Equal(t, []string{"FTC", "FTH"}, addr.(*SkycoinAddress).ListAssets())
+
+	opts := plugin.(core.TxnOptionsProvider).ListTxnOptions("FTC")
+	require.Len(t, opts, 5)
+	require.Equal(t, "0.25", opts[1].Default)
+	require.Nil(t, plugin.(core.TxnOptionsProvider).ListTxnOptions(SkycoinTicker))
+
+	out := &SkycoinTransactionOutput{
+		skyOut:      readable.TransactionOutput{Address: addr.String(), Coins: "1.5", Hours: 7},
+		poolSection: fiberParams.PoolSection,
+	}
+	require.Equal(t, []string{"FTC", "FTH", "FTH#ACC"}, out.SupportedAssets())
+	coins, err := out.GetCoins("FTC")
+	require.NoError(t, err)
+	require.Equal(t, uint64(1500000), coins)
+	hours, err := out.GetCoins("FTH")
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), hours)
+	_, err = out.GetCoins(SkycoinTicker)
+	require.Error(t, err)
+	outAddr, err := out.GetAddress()
+	require.NoError(t, err)
+	require.Equal(t, fiberParams.PoolSection, outAddr.(*SkycoinAddress).poolSection)
+}
@@ -3,10 +3,19 @@ package skycoin
 import (
 	"encoding/hex"
 	"strings"
+	"sync"
 
-	"github.com/SkycoinProject/skycoin/src/api"
 	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/apicache"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/chainid"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/nodeclient"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/quorum"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/replay"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/resilience"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/sandbox"
 	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/verify"
 	"github.com/fibercrypto/fibercryptowallet/src/core"
 	"github.com/fibercrypto/fibercryptowallet/src/errors"
 	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
@@ -15,22 +24,190 @@ import (
 var logNetwork = logging.MustGetLogger("Skycoin network")
 
 const (
-	PoolSection = "skycoin"
+	PoolSection = params.SkycoinPoolSection
 )
 
+// nodeState state shared by every client of a node
+type nodeState struct {
+	url   string
+	guard *resilience.Guard
+	cache *apicache.Cache
+
+	chainMutex    sync.Mutex
+	chainVerified bool
+	chainErr      error
+}
+
+func newNodeState(url string, policy resilience.Policy) *nodeState {
+	return &nodeState{
+		url:   url,
+		guard: resilience.NewGuard(policy),
+		cache: apicache.NewCache(apicache.DefaultTipCheckInterval),
+	}
+}
+
+// verifyChain checks once that node is on the expected chain.
+// Nodes on a different chain are refused until settings change.
+func (ns *nodeState) verifyChain(skyApi skytypes.SkycoinAPI, chain *params.SkyFiberParams) error {
+	if chain == nil {
+		return nil
+	}
+	ns.chainMutex.Lock()
+	defer ns.chainMutex.Unlock()
+	if ns.chainVerified {
+		return ns.chainErr
+	}
+	err := chainid.Verify(skyApi, *chain)
+	if err == nil || chainid.IsMismatch(err) {
+		ns.chainVerified = true
+		ns.chainErr = err
+	}
+	if err != nil {
+		logNetwork.WithError(err).WithField("url", ns.url).Error("Couldn't verify chain of Skycoin node")
+	}
+	return err
+}
+
+func (ns *nodeState) resetChain() {
+	ns.chainMutex.Lock()
+	defer ns.chainMutex.Unlock()
+	ns.chainVerified = false
+	ns.chainErr = nil
+}
+
 type SkycoinConnectionFactory struct {
-	url string
+	url      string
+	opts     nodeclient.Options
+	recorder *replay.Recorder
+	policy   resilience.Policy
+	verify   bool
+	chain    *params.SkyFiberParams
+	// nodes first one is the node at url, followed by mirrors
+	nodes  []*nodeState
+	quorum *quorum.Quorum
 }
 
 func (cf *SkycoinConnectionFactory) Create() (interface{}, error) {
+	// Nodes running in process are not worth caching and serve chains of their own
+	if node, isSandbox := sandbox.LookupURL(cf.url); isSandbox {
+		return cf.record(node), nil
+	}
+	if rp, isReplay, err := replay.LookupURL(cf.url); isReplay {
+		if err != nil {
+			return nil, err
+		}
+		return cf.record(rp), nil
+	}
+	primary, err := cf.connect(cf.nodes[0], cf.opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(cf.nodes) == 1 {
+		return primary, nil
+	}
+	nodes := []quorum.Node{{URL: cf.url, API: primary}}
+	// Credentials and pinned certificate belong to the primary node, trusted CAs apply to mirrors as well
+	mirrorOpts := nodeclient.Options{CAFile: cf.opts.CAFile, Timeout: cf.opts.Timeout, Proxy: cf.opts.Proxy}
+	for _, mirror := range cf.nodes[1:] {
+		skyApi, err := cf.connect(mirror, mirrorOpts)
+		if chainid.IsMismatch(err) {
+			return nil, err
+		}
+		if err != nil {
+			logNetwork.WithError(err).WithField("url", mirror.url).Warn("Leaving out Skycoin node mirror")
+			continue
+		}
+		nodes = append(nodes, quorum.Node{URL: mirror.url, API: skyApi})
+	}
+	return cf.quorum.Wrap(nodes), nil
+}
 
-	return api.NewClient(cf.url), nil
+// connect instantiates client of node, recording calls made to the primary one
+func (cf *SkycoinConnectionFactory) connect(node *nodeState, opts nodeclient.Options) (skytypes.SkycoinAPI, error) {
+	client, err := nodeclient.NewClient(node.url, opts)
+	if err != nil {
+		logNetwork.WithError(err).WithField("url", node.url).Error("Couldn't create Skycoin node client")
+		return nil, err
+	}
+	var skyApi skytypes.SkycoinAPI = client
+	if node == cf.nodes[0] {
+		skyApi = cf.record(skyApi)
+	}
+	skyApi = node.guard.Wrap(skyApi)
+	if cf.verify {
+		// Cached responses were checked when fetched
+		skyApi = verify.Wrap(skyApi)
+	}
+	skyApi = node.cache.Wrap(skyApi)
+	if err := node.verifyChain(skyApi, cf.chain); err != nil {
+		return nil, err
+	}
+	return skyApi, nil
 }
 
-func NewSkycoinConnectionFactory(url string) *SkycoinConnectionFactory {
+func (cf *SkycoinConnectionFactory) record(skyApi skytypes.SkycoinAPI) skytypes.SkycoinAPI {
+	if cf.recorder == nil {
+		return skyApi
+	}
+	return cf.recorder.Wrap(skyApi)
+}
+
+// SetOptions changes authentication, TLS and timeout settings of clients created afterwards
+func (cf *SkycoinConnectionFactory) SetOptions(opts nodeclient.Options) {
+	cf.opts = opts
+}
+
+// SetMirrors cross-checks reads of clients created afterwards against mirror nodes
+// and broadcasts transactions to all of them, see quorum.Quorum.
+// Quorum of zero nodes means a majority of them.
+func (cf *SkycoinConnectionFactory) SetMirrors(urls []string, quorumSize int) {
+	cf.nodes = cf.nodes[:1]
+	for _, url := range urls {
+		cf.nodes = append(cf.nodes, newNodeState(url, cf.policy))
+	}
+	cf.quorum = quorum.New(quorumSize)
+}
+
+// Disagreements returns most recent disagreements between node and its mirrors
+func (cf *SkycoinConnectionFactory) Disagreements() []quorum.Disagreement {
+	if cf.quorum == nil {
+		return nil
+	}
+	return cf.quorum.Disagreements()
+}
+
+// VerifyChain refuses to create clients of nodes not on the chain described by coin params
+func (cf *SkycoinConnectionFactory) VerifyChain(p params.SkyFiberParams) {
+	cf.chain = &p
+	for _, node := range cf.nodes {
+		node.resetChain()
+	}
+}
+
+// VerifyResponses turns on local checks of transactions and outputs reported to clients created afterwards
+func (cf *SkycoinConnectionFactory) VerifyResponses(enabled bool) {
+	cf.verify = enabled
+}
+
+// RecordTo records calls made by clients created afterwards
+func (cf *SkycoinConnectionFactory) RecordTo(rec *replay.Recorder) {
+	cf.recorder = rec
+}
 
+// SetPolicy changes how calls made by clients created afterwards are retried, throttled and cut off
+func (cf *SkycoinConnectionFactory) SetPolicy(policy resilience.Policy) {
+	cf.policy = policy
+	for _, node := range cf.nodes {
+		node.guard = resilience.NewGuard(policy)
+	}
+}
+
+func NewSkycoinConnectionFactory(url string) *SkycoinConnectionFactory {
+	policy := resilience.DefaultPolicy()
 	return &SkycoinConnectionFactory{
-		url: url,
+		url:    url,
+		policy: policy,
+		nodes:  []*nodeState{newNodeState(url, policy)},
 	}
 }
 
@@ -92,36 +269,57 @@ type SkycoinPEX struct {
 }
 
 func (spex *SkycoinPEX) GetConnections() (core.PexNodeSet, error) {
-	//TODO
-	return nil, nil
+	return NewSkycoinRemoteNetwork(spex.poolSection), nil
 }
 
 func (spex *SkycoinPEX) BroadcastTxn(txn core.Transaction) error {
+	_, err := spex.BroadcastTxnReport(txn)
+	return err
+}
+
+// BroadcastTxnReport injects transaction into every node of PEX pool section and reports which ones accepted it.
+// Nodes are labelled by pool section unless mirrors are configured.
+func (spex *SkycoinPEX) BroadcastTxnReport(txn core.Transaction) (*quorum.BroadcastReport, error) {
 	logNetwork.Info("Broadcasting transaction")
 	unTxn, ok := txn.(skytypes.SkycoinTxn)
 	if !ok {
-		return errors.ErrInvalidTxn
+		return nil, errors.ErrInvalidTxn
+	}
+	if err := GetNodeStatus(spex.poolSection).CheckSend(); err != nil {
+		logNetwork.WithError(err).Warn("Node not ready to broadcast transactions")
+		return nil, err
 	}
 	c, err := NewSkycoinApiClient(spex.poolSection)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer ReturnSkycoinClient(c)
 	txnBytes, err := unTxn.EncodeSkycoinTransaction()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	rawTxn := hex.EncodeToString(txnBytes)
+
+	skyApi := c
+	if pooled, isPooled := c.(*SkycoinApiClient); isPooled {
+		skyApi = pooled.SkycoinAPI
 	}
-	_, err = c.InjectEncodedTransaction(hex.EncodeToString(txnBytes))
+	if broadcaster, isQuorum := skyApi.(quorum.Broadcaster); isQuorum {
+		return broadcaster.Broadcast(rawTxn)
+	}
+	report := &quorum.BroadcastReport{Rejected: make(map[string]error)}
+	report.TxID, err = c.InjectEncodedTransaction(rawTxn)
 	if err != nil {
-		return err
+		report.Rejected[spex.poolSection] = err
+		return report, err
 	}
-
-	return nil
+	report.Accepted = []string{spex.poolSection}
+	return report, nil
 }
 
 func (spex *SkycoinPEX) GetTxnPool() (core.TransactionIterator, error) {
 	logNetwork.Info("Getting transaction pool")
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(spex.poolSection))
 	if err != nil {
 		return nil, err
 	}
@@ -133,7 +331,7 @@ func (spex *SkycoinPEX) GetTxnPool() (core.TransactionIterator, error) {
 	skycoinTxns := make([]core.Transaction, 0)
 	for _, txn := range txns {
 		t := txn
-		skycoinTxns = append(skycoinTxns, &SkycoinPendingTransaction{Transaction: &t})
+		skycoinTxns = append(skycoinTxns, &SkycoinPendingTransaction{Transaction: &t, poolSection: spex.poolSection})
 	}
 	return NewSkycoinTransactionIterator(skycoinTxns), nil
 }
@@ -166,20 +364,23 @@ func NewSkycoinPexNodeIterator(network []core.PexNode) *SkycoinPexNodeIterator {
 
 type SkycoinNetworkConnections struct {
 	//Implements NetworkSet interface
-	nodeAddress string
-}
-
-func NewSkycoinRemoteNetwork(nodeAddress string) *SkycoinNetworkConnections {
-	return &SkycoinNetworkConnections{nodeAddress}
+	poolSection string
 }
 
-func (remoteNetwork *SkycoinNetworkConnections) newClient() *api.Client {
-	return api.NewClient(remoteNetwork.nodeAddress)
+// NewSkycoinRemoteNetwork lists peers of node reached through pool section,
+// so that its authentication, TLS and proxy settings apply
+func NewSkycoinRemoteNetwork(poolSection string) *SkycoinNetworkConnections {
+	return &SkycoinNetworkConnections{poolSection}
 }
 
 func (remoteNetwork *SkycoinNetworkConnections) ListPeers() core.PexNodeIterator {
 	logNetwork.Info("Getting list of peers in Skycoin network connections")
-	c := remoteNetwork.newClient()
+	c, err := NewSkycoinApiClient(remoteNetwork.poolSection)
+	if err != nil {
+		logNetwork.WithError(err).Warn("Couldn't create client")
+		return nil
+	}
+	defer ReturnSkycoinClient(c)
 	nets, err := c.NetworkConnections(nil)
 
 	if err != nil {
@@ -1,14 +1,18 @@
 package skycoin
 
 import (
+	"sync"
+
 	skyparams "github.com/SkycoinProject/skycoin/src/params"
 	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/params"
 )
 
 var (
-	SkycoinMainNetParams = params.SkyFiberParams{
-		Distribution: skyparams.MainNetDistribution,
-	}
+	SkycoinMainNetParams = params.SkycoinMainNetParams
+
+	fiberCoinsMutex sync.RWMutex
+	// fiberCoins binds connection pool sections to params of the coin served by their nodes
+	fiberCoins = make(map[string]params.SkyFiberParams)
 )
 
 const (
@@ -23,3 +27,49 @@ const (
 	CalculatedHoursName        = params.CalculatedHoursName
 	CalculatedHoursDescription = params.CalculatedHoursDescription
 )
+
+// RegisterFiberCoin binds connection pool section to params of SkyFiber coin
+func RegisterFiberCoin(poolSection string, p params.SkyFiberParams) {
+	fiberCoinsMutex.Lock()
+	defer fiberCoinsMutex.Unlock()
+	fiberCoins[poolSection] = p
+}
+
+// LookupFiberCoin returns params of SkyFiber coin served by nodes of connection pool section.
+// Skycoin is assumed for sections not bound to any coin.
+func LookupFiberCoin(poolSection string) params.SkyFiberParams {
+	fiberCoinsMutex.RLock()
+	defer fiberCoinsMutex.RUnlock()
+	if p, isBound := fiberCoins[poolSection]; isBound {
+		return p
+	}
+	return SkycoinMainNetParams
+}
+
+// verifyTxnParams returns transaction verification params of SkyFiber coin served by nodes of connection pool section.
+// Skycoin params are assumed for coins not defining them.
+func verifyTxnParams(poolSection string) skyparams.VerifyTxn {
+	verifyParams := LookupFiberCoin(poolSection).VerifyTxn
+	if verifyParams == (skyparams.VerifyTxn{}) {
+		return skyparams.UserVerifyTxn
+	}
+	return verifyParams
+}
+
+// accruedAsset maps coin hours of SkyFiber coin served by nodes of connection pool section
+// to the ticker of calculated hours
+func accruedAsset(poolSection, ticker string) (string, bool) {
+	fiberCoin := LookupFiberCoin(poolSection)
+	if ticker == fiberCoin.CoinHoursTicker {
+		return fiberCoin.CalculatedHoursTicker, true
+	}
+	return "", false
+}
+
+// sectionOrDefault resolves connection pool section of objects not bound to any section
+func sectionOrDefault(poolSection string) string {
+	if poolSection == "" {
+		return PoolSection
+	}
+	return poolSection
+}
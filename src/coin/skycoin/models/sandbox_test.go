@@ -0,0 +1,112 @@
+package skycoin
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/testutil"
+	"github.com/SkycoinProject/skycoin/src/wallet"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/sandbox"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/stretchr/testify/require"
+)
+
+// newSandboxSection connects connection pool section to sandbox node identified by name
+// and registers Skycoin params for it
+func newSandboxSection(t *testing.T, name string) (string, *sandbox.Node) {
+	poolSection := "skycoin-sandbox-" + name
+	err := core.GetMultiPool().CreateSection(poolSection, NewSkycoinConnectionFactory(sandbox.URLScheme+"models-"+name))
+	require.NoError(t, err)
+	p := SkycoinMainNetParams
+	p.PoolSection = poolSection
+	RegisterFiberCoin(poolSection, p)
+	return poolSection, sandbox.GetNode("models-" + name)
+}
+
+// sandboxWallet deterministic local wallet kept in a temporary directory and talking to a sandbox node
+type sandboxWallet struct {
+	poolSection string
+	params      params.SkyFiberParams
+	node        *sandbox.Node
+	env         core.WalletEnv
+	wlt         core.Wallet
+	addrs       []core.Address
+	dir         string
+}
+
+// newSandboxWallet creates wallet with addrs addresses talking to sandbox node identified by name
+func newSandboxWallet(t *testing.T, name string, addrs int) *sandboxWallet {
+	poolSection, node := newSandboxSection(t, name)
+	dir, err := ioutil.TempDir("", "sandbox-wallets")
+	require.NoError(t, err)
+	env := newFiberWalletDirectory(dir, poolSection)
+	// Sandbox nodes outlive tests, fresh seed ensures addresses have no history
+	seed := testutil.RandSHA256(t).Hex()
+	wlt, err := env.GetWalletSet().CreateWallet(name, seed, wallet.WalletTypeDeterministic, false, util.EmptyPassword, 0)
+	if err != nil {
+		os.RemoveAll(dir)
+		require.NoError(t, err)
+	}
+	sw := &sandboxWallet{
+		poolSection: poolSection,
+		params:      LookupFiberCoin(poolSection),
+		node:        node,
+		env:         env,
+		wlt:         wlt,
+		dir:         dir,
+	}
+	it := wlt.GenAddresses(core.AccountAddress, 0, uint32(addrs), nil)
+	for it.Next() {
+		sw.addrs = append(sw.addrs, it.Value())
+	}
+	return sw
+}
+
+// close removes wallet directory
+func (sw *sandboxWallet) close() {
+	os.RemoveAll(sw.dir)
+}
+
+func TestSandboxLocalWalletTransfer(t *testing.T) {
+	sw := newSandboxWallet(t, "test", 1)
+	defer sw.close()
+	node, wlt := sw.node, sw.wlt
+	src := sw.addrs[0].String()
+	_, err := node.Mint(src, 100e6, 1000)
+	require.NoError(t, err)
+
+	balance, err := wlt.GetCryptoAccount().GetBalance(SkycoinTicker)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100e6), balance)
+
+	dest := testutil.MakeAddress().String()
+	opt := NewTransferOptions()
+	opt.SetValue("BurnFactor", "0.5")
+	opt.SetValue("CoinHoursSelectionType", "auto")
+	txn, err := wlt.Transfer(&SkycoinTransactionOutput{
+		skyOut: readable.TransactionOutput{
+			Address: dest,
+			Coins:   "10",
+		}}, opt)
+	require.NoError(t, err)
+	signer, err := util.LookupSignServiceForWallet(wlt, core.UID(""))
+	require.NoError(t, err)
+	signed, err := wlt.Sign(txn, signer, util.EmptyPassword, nil)
+	require.NoError(t, err)
+	require.NoError(t, NewSkycoinPEX(sw.poolSection).BroadcastTxn(signed))
+
+	// Sandbox node mines transactions as soon as they are injected
+	txnR, err := node.Transaction(signed.GetId())
+	require.NoError(t, err)
+	require.True(t, txnR.Status.Confirmed)
+	srcBalance, err := node.Balance([]string{src})
+	require.NoError(t, err)
+	require.Equal(t, uint64(90e6), srcBalance.Confirmed.Coins)
+	destBalance, err := node.Balance([]string{dest})
+	require.NoError(t, err)
+	require.Equal(t, uint64(10e6), destBalance.Confirmed.Coins)
+}
@@ -0,0 +1,136 @@
+package skycoin
+
+import (
+	"strconv"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	skyparams "github.com/SkycoinProject/skycoin/src/params"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/util/droplet"
+	"github.com/SkycoinProject/skycoin/src/util/fee"
+	"github.com/SkycoinProject/skycoin/src/util/mathutil"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+const (
+	// TxnOptSendMax transaction option sending every coin and coin hour of sources to a single destination
+	TxnOptSendMax = "SendMax"
+	// SendMaxEnabled empties transaction sources
+	SendMaxEnabled = "true"
+	// SendMaxDisabled sends amounts set for destinations
+	SendMaxDisabled = "false"
+)
+
+// isSendMax tells whether transaction options request emptying transaction sources
+func isSendMax(options core.KeyValueStore) bool {
+	sendMax, _ := options.GetValue(TxnOptSendMax).(string)
+	return sendMax == SendMaxEnabled
+}
+
+// SendMaxAmounts maximum coins and coin hours sent by a transaction emptying unspent outputs
+type SendMaxAmounts struct {
+	// Coins sent to destination, in droplets
+	Coins uint64
+	// Hours sent to destination once the fee is burned
+	Hours uint64
+	// Fee burned coin hours
+	Fee uint64
+	// Change droplets beyond allowed precision, returned to sources
+	Change uint64
+}
+
+// computeSendMax sums coins and coin hours of outputs left after burning the minimum fee.
+// Coins are rounded down to droplet precision allowed in transaction outputs.
+func computeSendMax(outs readable.UnspentOutputs, verifyParams skyparams.VerifyTxn) (*SendMaxAmounts, error) {
+	if len(outs) > maxTxnInputs(1, verifyParams) {
+		logWallet.Warnf("Send max sources exceed %d outputs", maxTxnInputs(1, verifyParams))
+		return nil, errors.ErrTxnTooLarge
+	}
+	var coins, hours uint64
+	for _, out := range outs {
+		outCoins, err := droplet.FromString(out.Coins)
+		if err != nil {
+			return nil, err
+		}
+		if coins, err = mathutil.AddUint64(coins, outCoins); err != nil {
+			return nil, err
+		}
+		if hours, err = mathutil.AddUint64(hours, out.CalculatedHours); err != nil {
+			return nil, err
+		}
+	}
+	unit := dropletPrecisionUnit(verifyParams)
+	change := coins % unit
+	coins -= change
+	if coins == 0 {
+		return nil, errors.ErrInsufficientFunds
+	}
+	txnFee := fee.RequiredFee(hours, verifyParams.BurnFactor)
+	return &SendMaxAmounts{
+		Coins:  coins,
+		Hours:  hours - txnFee,
+		Fee:    txnFee,
+		Change: change,
+	}, nil
+}
+
+// shareSendMaxHours computes coin hours sent to destination if coin hours are shared automatically.
+// Nodes only share coin hours with change if there is any, otherwise destination takes them all.
+func shareSendMaxHours(amounts *SendMaxAmounts, shareFactor string) (uint64, error) {
+	if amounts.Change == 0 {
+		return amounts.Hours, nil
+	}
+	return shareHours(amounts.Hours, shareFactor)
+}
+
+// fillSendMax sets coins, and coin hours if selected manually, of the single receiver of request
+// so that the transaction spends every source output
+func fillSendMax(c skytypes.SkycoinAPI, req *api.CreateTransactionRequest, at uint64, verifyParams skyparams.VerifyTxn) (*SendMaxAmounts, error) {
+	if len(req.To) != 1 {
+		logWallet.Warn("Send max requires a single destination")
+		return nil, errors.ErrInvalidOptions
+	}
+	outs, _, err := requestOutputs(c, req, at)
+	if err != nil {
+		return nil, err
+	}
+	amounts, err := computeSendMax(outs, verifyParams)
+	if err != nil {
+		return nil, err
+	}
+	if req.To[0].Coins, err = droplet.ToString(amounts.Coins); err != nil {
+		return nil, err
+	}
+	if req.HoursSelection.Type == CoinHoursSelectionManual {
+		req.To[0].Hours = strconv.FormatUint(amounts.Hours, 10)
+	} else if amounts.Hours, err = shareSendMaxHours(amounts, req.HoursSelection.ShareFactor); err != nil {
+		return nil, err
+	}
+	// Exactly the outputs accounted for are spent
+	req.Addresses = nil
+	req.UxOuts = make([]string, 0, len(outs))
+	for _, out := range outs {
+		req.UxOuts = append(req.UxOuts, out.Hash)
+	}
+	return amounts, nil
+}
+
+// sendMax fills request to empty its sources using nodes of connection pool section,
+// coin hours are projected at time at unless it is zero
+func sendMax(poolSection string, req *api.CreateTransactionRequest, at uint64) error {
+	c, err := NewSkycoinApiClient(sectionOrDefault(poolSection))
+	if err != nil {
+		logWallet.WithError(err).Warn("Couldn't load api client")
+		return err
+	}
+	defer ReturnSkycoinClient(c)
+	amounts, err := fillSendMax(c, req, at, verifyTxnParams(poolSection))
+	if err != nil {
+		logWallet.WithError(err).Warn("Couldn't compute send max amounts")
+		return err
+	}
+	logWallet.Infof("Sending max %d droplets and %d coin hours, burning %d", amounts.Coins, amounts.Hours, amounts.Fee)
+	return nil
+}
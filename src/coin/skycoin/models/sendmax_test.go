@@ -0,0 +1,138 @@
+package skycoin
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	skyparams "github.com/SkycoinProject/skycoin/src/params"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/testutil"
+	"github.com/SkycoinProject/skycoin/src/util/fee"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeSendMax(t *testing.T) {
+	outs := readable.UnspentOutputs{
+		{Hash: testutil.RandSHA256(t).Hex(), Coins: "10.000000", CalculatedHours: 100},
+		{Hash: testutil.RandSHA256(t).Hex(), Coins: "5.500000", CalculatedHours: 51},
+	}
+	amounts, err := computeSendMax(outs, skyparams.UserVerifyTxn)
+	require.NoError(t, err)
+	require.Equal(t, uint64(15.5e6), amounts.Coins)
+	require.Equal(t, fee.RequiredFee(151, skyparams.UserVerifyTxn.BurnFactor), amounts.Fee)
+	require.Equal(t, uint64(151), amounts.Hours+amounts.Fee)
+
+	// Droplets beyond allowed precision are left as change
+	outs[1].Coins = "5.500001"
+	amounts, err = computeSendMax(outs, skyparams.UserVerifyTxn)
+	require.NoError(t, err)
+	require.Equal(t, uint64(15.5e6), amounts.Coins)
+	require.Equal(t, uint64(1), amounts.Change)
+
+	// Coin hours are only shared if change is left
+	hours, err := shareSendMaxHours(amounts, "0.5")
+	require.NoError(t, err)
+	require.Equal(t, amounts.Hours/2, hours)
+	_, err = shareSendMaxHours(amounts, "1.5")
+	require.Equal(t, errors.ErrInvalidOptions, err)
+	amounts.Change = 0
+	hours, err = shareSendMaxHours(amounts, "0.5")
+	require.NoError(t, err)
+	require.Equal(t, amounts.Hours, hours)
+
+	_, err = computeSendMax(readable.UnspentOutputs{}, skyparams.UserVerifyTxn)
+	require.Equal(t, errors.ErrInsufficientFunds, err)
+	small := skyparams.UserVerifyTxn
+	small.MaxTransactionSize = 200
+	_, err = computeSendMax(outs, small)
+	require.Equal(t, errors.ErrTxnTooLarge, err)
+}
+
+func TestSendMax(t *testing.T) {
+	sw := newSandboxWallet(t, "sendmax", 2)
+	defer sw.close()
+	poolSection, p, node, wlt, addrs := sw.poolSection, sw.params, sw.node, sw.wlt, sw.addrs
+	addrStrs := []string{addrs[0].String(), addrs[1].String()}
+	_, err := node.Mint(addrStrs[0], 10e6, 100)
+	require.NoError(t, err)
+	_, err = node.Mint(addrStrs[0], 5.5e6, 51)
+	require.NoError(t, err)
+	_, err = node.Mint(addrStrs[1], 1e6, 7)
+	require.NoError(t, err)
+
+	// Receiver amounts are computed from outputs of addresses or selected outputs
+	dest := testutil.MakeAddress().String()
+	req := api.CreateTransactionRequest{
+		Addresses:      addrStrs,
+		HoursSelection: api.HoursSelection{Type: CoinHoursSelectionManual},
+		To:             []api.Receiver{{Address: dest}},
+	}
+	amounts, err := fillSendMax(node, &req, 0, skyparams.UserVerifyTxn)
+	require.NoError(t, err)
+	require.Equal(t, uint64(16.5e6), amounts.Coins)
+	require.Equal(t, "16.500000", req.To[0].Coins)
+	require.Equal(t, strconv.FormatUint(amounts.Hours, 10), req.To[0].Hours)
+	require.Nil(t, req.Addresses)
+	require.Len(t, req.UxOuts, 3)
+	selected := api.CreateTransactionRequest{
+		UxOuts:         req.UxOuts[:2],
+		HoursSelection: api.HoursSelection{Type: CoinHoursSelectionAuto, Mode: "share", ShareFactor: "0.5"},
+		To:             []api.Receiver{{Address: dest}},
+	}
+	_, err = fillSendMax(node, &selected, 0, skyparams.UserVerifyTxn)
+	require.NoError(t, err)
+	require.Equal(t, req.UxOuts[:2], selected.UxOuts)
+	require.Empty(t, selected.To[0].Hours)
+	twice := selected
+	twice.To = append(selected.To, api.Receiver{Address: dest})
+	_, err = fillSendMax(node, &twice, 0, skyparams.UserVerifyTxn)
+	require.Equal(t, errors.ErrInvalidOptions, err)
+
+	opt := NewTransferOptions()
+	opt.SetValue(TxnOptBurnFactor, "0.5")
+	opt.SetValue(TxnOptCoinHoursSelectionType, CoinHoursSelectionManual)
+	opt.SetValue(TxnOptSendMax, SendMaxEnabled)
+	to := &SkycoinTransactionOutput{skyOut: readable.TransactionOutput{Address: dest, Coins: "0"}}
+	txn, err := wlt.SendFromAddress(addrs, []core.TransactionOutput{to}, nil, opt)
+	require.NoError(t, err)
+	require.Len(t, txn.GetInputs(), 3)
+	require.Len(t, txn.GetOutputs(), 1)
+	coins, err := txn.GetOutputs()[0].GetCoins(p.Ticker)
+	require.NoError(t, err)
+	require.Equal(t, amounts.Coins, coins)
+
+	// Fee is burned as required by the wallet coin
+	fiberParams := p
+	fiberParams.VerifyTxn.BurnFactor = 4
+	RegisterFiberCoin(poolSection, fiberParams)
+	txn, err = wlt.SendFromAddress(addrs, []core.TransactionOutput{to}, nil, opt)
+	RegisterFiberCoin(poolSection, p)
+	require.NoError(t, err)
+	hours, err := txn.GetOutputs()[0].GetCoins(p.CoinHoursTicker)
+	require.NoError(t, err)
+	require.Equal(t, 158-fee.RequiredFee(158, 4), hours)
+
+	// Transfer empties the whole wallet
+	opt.SetValue(TxnOptCoinHoursSelectionType, CoinHoursSelectionAuto)
+	txn, err = wlt.Transfer(to, opt)
+	require.NoError(t, err)
+	require.Len(t, txn.GetInputs(), 3)
+	require.Len(t, txn.GetOutputs(), 1)
+
+	signer, err := util.LookupSignServiceForWallet(wlt, core.UID(""))
+	require.NoError(t, err)
+	signed, err := wlt.Sign(txn, signer, util.EmptyPassword, nil)
+	require.NoError(t, err)
+	require.NoError(t, NewSkycoinPEX(poolSection).BroadcastTxn(signed))
+	balances, err := node.Balance(addrStrs)
+	require.NoError(t, err)
+	require.Zero(t, balances.Confirmed.Coins)
+	require.Zero(t, balances.Confirmed.Hours)
+	balances, err = node.Balance([]string{dest})
+	require.NoError(t, err)
+	require.Equal(t, amounts.Coins, balances.Confirmed.Coins)
+}
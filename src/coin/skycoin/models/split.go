@@ -0,0 +1,451 @@
+package skycoin
+
+import (
+	"sort"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	skyparams "github.com/SkycoinProject/skycoin/src/params"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/transaction"
+	"github.com/SkycoinProject/skycoin/src/util/droplet"
+	"github.com/SkycoinProject/skycoin/src/util/mathutil"
+	"github.com/SkycoinProject/skycoin/src/visor"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+// Encoded transactions take 49 bytes for length, type, inner hash and array lengths.
+// Every input adds a hash and a signature, every output an address, coins and hours.
+const (
+	txnHeaderSize = 49
+	txnInputSize  = 32 + 65
+	txnOutputSize = 21 + 8 + 8
+)
+
+var (
+	// PlanPollInterval time between checks for confirmation of transactions spent by later ones in a plan
+	PlanPollInterval = 5 * time.Second
+	// PlanConfirmTimeout time to wait for a transaction to be confirmed before broadcasting those spending its outputs
+	PlanConfirmTimeout = 10 * time.Minute
+)
+
+// maxTxnInputs tells how many inputs fit in a transaction creating outputs
+func maxTxnInputs(outputs int, verifyParams skyparams.VerifyTxn) int {
+	return (int(verifyParams.MaxTransactionSize) - txnHeaderSize - txnOutputSize*outputs) / txnInputSize
+}
+
+// maxTxnOutputs tells how many outputs fit in a transaction spending inputs
+func maxTxnOutputs(inputs int, verifyParams skyparams.VerifyTxn) int {
+	return (int(verifyParams.MaxTransactionSize) - txnHeaderSize - txnInputSize*inputs) / txnOutputSize
+}
+
+// plannedTxn transaction of a transfer plan along with the outputs it spends
+type plannedTxn struct {
+	txn    *coin.Transaction
+	inputs []transaction.UxBalance
+}
+
+// PlanTransfer creates transactions fulfilling transaction creation request in the order they have to be broadcast.
+// Requests exceeding transaction size limits are split and change is chained,
+// i.e. transactions after the first may spend change of previous ones.
+// Only requests spending from addresses are supported.
+func PlanTransfer(poolSection string, req api.CreateTransactionRequest) ([]*SkycoinCreatedTransaction, error) {
+	return planTransfer(poolSection, req, nil, verifyTxnParams(poolSection))
+}
+
+// planTransfer looks up outputs of request addresses, other than locked ones,
+// and splits payment to fit in verifyParams limits
+func planTransfer(poolSection string, req api.CreateTransactionRequest, locks map[string]core.OutputLock, verifyParams skyparams.VerifyTxn) ([]*SkycoinCreatedTransaction, error) {
+	logWallet.Info("Planning transfer")
+	if len(req.Addresses) == 0 || len(req.UxOuts) != 0 {
+		return nil, errors.ErrInvalidOptions
+	}
+	p, err := skytypes.NewTxnParams(req)
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewSkycoinApiClient(sectionOrDefault(poolSection))
+	if err != nil {
+		return nil, err
+	}
+	defer ReturnSkycoinClient(c)
+	summary, err := c.OutputsForAddresses(req.Addresses)
+	if err != nil {
+		return nil, err
+	}
+	spendable := make(readable.UnspentOutputs, 0, len(summary.HeadOutputs))
+	for _, out := range summary.SpendableOutputs() {
+		if locks[out.Hash] == core.OutputUnlocked {
+			spendable = append(spendable, out)
+		}
+	}
+	uxa, err := spendable.ToUxArray()
+	if err != nil {
+		return nil, err
+	}
+	head := coin.BlockHeader{
+		BkSeq: summary.Head.BkSeq,
+		Time:  summary.Head.Time,
+	}
+	plan, err := planTransactions(p, uxa, head, verifyParams)
+	if err != nil {
+		logWallet.WithError(err).Warn("Couldn't plan transfer")
+		return nil, err
+	}
+	txns := make([]*SkycoinCreatedTransaction, 0, len(plan))
+	for _, planned := range plan {
+		cTxn, err := api.NewCreatedTransaction(planned.txn, visor.NewTransactionInputsFromUxBalance(planned.inputs))
+		if err != nil {
+			return nil, err
+		}
+		txns = append(txns, newSkycoinCreatedTransaction(*cTxn, poolSection))
+	}
+	return txns, nil
+}
+
+// planTransactions splits payment into transactions within size limits of verifyParams.
+// Receivers are paid in order, as many per transaction as the largest outputs allowed in it can fund.
+// If not even a single receiver can be paid that way those outputs are merged first.
+func planTransactions(p transaction.Params, uxa coin.UxArray, head coin.BlockHeader, verifyParams skyparams.VerifyTxn) ([]plannedTxn, error) {
+	txn, inputs, err := transaction.Create(p, coin.NewAddressUxOuts(uxa), head.Time)
+	if err != nil {
+		return nil, err
+	}
+	if size, err := txn.Size(); err != nil {
+		return nil, err
+	} else if size <= verifyParams.MaxTransactionSize {
+		return []plannedTxn{{txn: txn, inputs: inputs}}, nil
+	}
+
+	// Chained transactions must send change to a known address
+	change := inputs[0].Address
+	if p.ChangeAddress != nil {
+		change = *p.ChangeAddress
+	}
+	p.ChangeAddress = &change
+	pool, err := transaction.NewUxBalances(uxa, head.Time)
+	if err != nil {
+		return nil, err
+	}
+	uxOuts := make(map[cipher.SHA256]coin.UxOut, len(uxa))
+	for _, ux := range uxa {
+		uxOuts[ux.Hash()] = ux
+	}
+	// Change outputs are created in the block after head, hours are computed at head time
+	nextHead := coin.BlockHeader{BkSeq: head.BkSeq + 1, Time: head.Time}
+
+	plan := make([]plannedTxn, 0)
+	to := p.To
+	for len(to) != 0 {
+		sortBalancesCoinsHighToLow(pool)
+		var planned *plannedTxn
+		paid := len(to)
+		if limit := maxTxnOutputs(1, verifyParams) - 1; paid > limit {
+			paid = limit
+		}
+		for ; paid > 0; paid /= 2 {
+			batch := p
+			batch.To = to[:paid]
+			if planned, err = createPlanned(batch, topBalances(pool, maxTxnInputs(paid+1, verifyParams)), uxOuts, head.Time); err == nil {
+				break
+			}
+		}
+		if planned == nil {
+			if len(pool) <= maxTxnInputs(2, verifyParams) {
+				return nil, err
+			}
+			planned, err = createConsolidation(topBalances(pool, maxTxnInputs(1, verifyParams)), change, uxOuts, head.Time)
+			if err != nil {
+				return nil, err
+			}
+		}
+		to = to[paid:]
+		plan = append(plan, *planned)
+
+		pool = spendBalances(pool, planned.inputs)
+		for i, ux := range coin.CreateUnspents(nextHead, *planned.txn) {
+			if i < paid {
+				continue
+			}
+			uxb, err := transaction.NewUxBalance(head.Time, ux)
+			if err != nil {
+				return nil, err
+			}
+			uxOuts[uxb.Hash] = ux
+			pool = append(pool, uxb)
+		}
+	}
+	return plan, nil
+}
+
+// createPlanned creates transaction spending some of candidate outputs
+func createPlanned(p transaction.Params, candidates []transaction.UxBalance, uxOuts map[cipher.SHA256]coin.UxOut, headTime uint64) (*plannedTxn, error) {
+	uxa := make(coin.UxArray, 0, len(candidates))
+	for _, uxb := range candidates {
+		uxa = append(uxa, uxOuts[uxb.Hash])
+	}
+	txn, inputs, err := transaction.Create(p, coin.NewAddressUxOuts(uxa), headTime)
+	if err != nil {
+		return nil, err
+	}
+	return &plannedTxn{txn: txn, inputs: inputs}, nil
+}
+
+// createConsolidation merges outputs into a single one owned by change address
+func createConsolidation(outputs []transaction.UxBalance, change cipher.Address, uxOuts map[cipher.SHA256]coin.UxOut, headTime uint64) (*plannedTxn, error) {
+	var coins uint64
+	for _, uxb := range outputs {
+		var err error
+		if coins, err = mathutil.AddUint64(coins, uxb.Coins); err != nil {
+			return nil, err
+		}
+	}
+	strCoins, err := droplet.ToString(coins)
+	if err != nil {
+		return nil, err
+	}
+	// Share factor decimal type is vendored by Skycoin, so params are decoded as nodes do
+	strChange := change.String()
+	p, err := skytypes.NewTxnParams(api.CreateTransactionRequest{
+		HoursSelection: api.HoursSelection{
+			Type:        transaction.HoursSelectionTypeAuto,
+			Mode:        transaction.HoursSelectionModeShare,
+			ShareFactor: "1",
+		},
+		To:            []api.Receiver{{Address: strChange, Coins: strCoins}},
+		ChangeAddress: &strChange,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return createPlanned(p, outputs, uxOuts, headTime)
+}
+
+// sortBalancesCoinsHighToLow sorts outputs as transaction.ChooseSpendsMinimizeUxOuts does
+func sortBalancesCoinsHighToLow(uxb []transaction.UxBalance) {
+	sort.Slice(uxb, func(i, j int) bool {
+		if uxb[i].Coins != uxb[j].Coins {
+			return uxb[i].Coins > uxb[j].Coins
+		}
+		if uxb[i].Hours != uxb[j].Hours {
+			return uxb[i].Hours < uxb[j].Hours
+		}
+		return uxb[i].Hash.Hex() < uxb[j].Hash.Hex()
+	})
+}
+
+// topBalances returns up to n first outputs
+func topBalances(uxb []transaction.UxBalance, n int) []transaction.UxBalance {
+	if n > len(uxb) {
+		n = len(uxb)
+	}
+	return uxb[:n]
+}
+
+// spendBalances removes spent outputs from pool
+func spendBalances(pool, spent []transaction.UxBalance) []transaction.UxBalance {
+	isSpent := make(map[cipher.SHA256]bool, len(spent))
+	for _, uxb := range spent {
+		isSpent[uxb.Hash] = true
+	}
+	unspent := make([]transaction.UxBalance, 0, len(pool))
+	for _, uxb := range pool {
+		if !isSpent[uxb.Hash] {
+			unspent = append(unspent, uxb)
+		}
+	}
+	return unspent
+}
+
+// PlanSendFromAddress instantiates unsigned transactions to send funds from specific source addresses,
+// splitting transfers which exceed transaction size limits
+func (wlt LocalWallet) PlanSendFromAddress(from []core.Address, to []core.TransactionOutput, change core.Address, options core.KeyValueStore) ([]core.Transaction, error) {
+	logWallet.Info("Planning transfer from addresses in local wallet")
+	if isSendMax(options) {
+		// Emptied sources are spent by a single transaction
+		txn, err := wlt.SendFromAddress(from, to, change, options)
+		if err != nil {
+			return nil, err
+		}
+		return []core.Transaction{txn}, nil
+	}
+	var plan []core.Transaction
+	createTxnFunc := func(txnReq *api.CreateTransactionRequest) (core.Transaction, error) {
+		locks, err := wlt.ListOutputLocks()
+		if err != nil {
+			return nil, err
+		}
+		txns, err := planTransfer(wlt.poolSection, *txnReq, locks, verifyTxnParams(wlt.poolSection))
+		if err != nil {
+			return nil, err
+		}
+		for _, txn := range txns {
+			plan = append(plan, txn)
+		}
+		return plan[0], nil
+	}
+	if _, err := createTransaction(wlt.poolSection, from, to, nil, change, options, createTxnFunc); err != nil {
+		return nil, err
+	}
+	if err := reserveInputs(&wlt, options, plan...); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// SignPlan signs transactions planned by PlanSendFromAddress in order.
+// Transaction IDs change once signed, so inputs spending outputs of previous transactions are updated before signing.
+func (wlt LocalWallet) SignPlan(txns []core.Transaction, signer core.TxnSigner, pwd core.PasswordReader) ([]core.Transaction, error) {
+	logWallet.Infof("Signing plan of %d transactions", len(txns))
+	return signPlan(txns, wlt.poolSection, func(txn core.Transaction) (core.Transaction, error) {
+		return wlt.Sign(txn, signer, pwd, nil)
+	})
+}
+
+// signedOutput output of a signed plan transaction
+type signedOutput struct {
+	uxID string
+	txID string
+}
+
+// signPlan signs transactions in order, relinking inputs to outputs of transactions signed before
+func signPlan(txns []core.Transaction, poolSection string, sign func(core.Transaction) (core.Transaction, error)) ([]core.Transaction, error) {
+	relinked := make(map[string]signedOutput)
+	signed := make([]core.Transaction, 0, len(txns))
+	for _, txn := range txns {
+		rTxn, isReadable := txn.(skytypes.ReadableTxn)
+		if !isReadable {
+			return nil, errors.ErrInvalidTxn
+		}
+		cTxn, err := rTxn.ToCreatedTransaction()
+		if err != nil {
+			return nil, err
+		}
+		unsigned, err := relinkInputs(*cTxn, relinked)
+		if err != nil {
+			return nil, err
+		}
+		signedTxn, err := sign(newSkycoinCreatedTransaction(*unsigned, poolSection))
+		if err != nil {
+			return nil, err
+		}
+		signedOuts := signedTxn.GetOutputs()
+		for i, out := range cTxn.Out {
+			relinked[out.UxID] = signedOutput{uxID: signedOuts[i].GetId(), txID: signedTxn.GetId()}
+		}
+		signed = append(signed, signedTxn)
+	}
+	return signed, nil
+}
+
+// relinkInputs replaces inputs found in relinked, which maps planned output IDs to signed ones.
+// Signatures are dropped and outputs identified after the new transaction ID.
+func relinkInputs(cTxn api.CreatedTransaction, relinked map[string]signedOutput) (*api.CreatedTransaction, error) {
+	txn, err := cTxn.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	cTxn.In = append([]api.CreatedTransactionInput{}, cTxn.In...)
+	isRelinked := false
+	for i, in := range cTxn.In {
+		out, isFound := relinked[in.UxID]
+		if !isFound {
+			continue
+		}
+		if txn.In[i], err = cipher.SHA256FromHex(out.uxID); err != nil {
+			return nil, err
+		}
+		cTxn.In[i].UxID = out.uxID
+		cTxn.In[i].TxID = out.txID
+		isRelinked = true
+	}
+	if !isRelinked {
+		return &cTxn, nil
+	}
+	txn.Sigs = make([]cipher.Sig, len(txn.In))
+	if err := txn.UpdateHeader(); err != nil {
+		return nil, err
+	}
+	txnHash := txn.Hash()
+	cTxn.Length = txn.Length
+	cTxn.TxID = txnHash.Hex()
+	cTxn.InnerHash = txn.InnerHash.Hex()
+	cTxn.Sigs = make([]string, len(txn.Sigs))
+	for i, sig := range txn.Sigs {
+		cTxn.Sigs[i] = sig.Hex()
+	}
+	cTxn.Out = make([]api.CreatedTransactionOutput, 0, len(txn.Out))
+	for _, out := range txn.Out {
+		cOut, err := api.NewCreatedTransactionOutput(out, txnHash)
+		if err != nil {
+			return nil, err
+		}
+		cTxn.Out = append(cTxn.Out, *cOut)
+	}
+	return &cTxn, nil
+}
+
+// BroadcastPlan broadcasts transactions in order. Before broadcasting a transaction
+// it waits for confirmation of previous ones whose outputs it spends.
+func (spex *SkycoinPEX) BroadcastPlan(txns []core.Transaction) error {
+	logNetwork.Infof("Broadcasting plan of %d transactions", len(txns))
+	creators := make(map[string]string)
+	for _, txn := range txns {
+		for _, in := range txn.GetInputs() {
+			if creator, isChained := creators[in.GetId()]; isChained {
+				if err := spex.waitConfirmed(creator); err != nil {
+					return err
+				}
+			}
+		}
+		if err := spex.BroadcastTxn(txn); err != nil {
+			return err
+		}
+		for _, out := range txn.GetOutputs() {
+			creators[out.GetId()] = txn.GetId()
+		}
+	}
+	return nil
+}
+
+// waitConfirmed polls node until transaction is confirmed or PlanConfirmTimeout expires
+func (spex *SkycoinPEX) waitConfirmed(txid string) error {
+	deadline := time.Now().Add(PlanConfirmTimeout)
+	for {
+		confirmed, err := spex.isConfirmed(txid)
+		if err != nil {
+			return err
+		}
+		if confirmed {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			logNetwork.Warnf("Transaction %s not confirmed in time", txid)
+			return errors.ErrTxnNotConfirmed
+		}
+		time.Sleep(PlanPollInterval)
+	}
+}
+
+func (spex *SkycoinPEX) isConfirmed(txid string) (bool, error) {
+	c, err := NewSkycoinApiClient(sectionOrDefault(spex.poolSection))
+	if err != nil {
+		return false, err
+	}
+	defer ReturnSkycoinClient(c)
+	txn, err := c.Transaction(txid)
+	if err != nil {
+		return false, err
+	}
+	return txn.Status.Confirmed, nil
+}
+
+// Type assertions
+var (
+	_ core.TxnPlanner      = LocalWallet{}
+	_ core.PlanBroadcaster = &SkycoinPEX{}
+)
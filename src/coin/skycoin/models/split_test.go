@@ -0,0 +1,169 @@
+package skycoin
+
+import (
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	skyparams "github.com/SkycoinProject/skycoin/src/params"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/testutil"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/stretchr/testify/require"
+)
+
+func requirePlanFits(t *testing.T, plan []plannedTxn, verifyParams skyparams.VerifyTxn) {
+	for _, planned := range plan {
+		size, err := planned.txn.Size()
+		require.NoError(t, err)
+		require.True(t, size <= verifyParams.MaxTransactionSize)
+		require.NoError(t, planned.txn.VerifyUnsigned())
+	}
+}
+
+func TestPlanTransactions(t *testing.T) {
+	head := coin.BlockHeader{BkSeq: 1, Time: 100}
+	owner := testutil.MakeAddress()
+	uxa := make(coin.UxArray, 0, 12)
+	for i := 0; i < 12; i++ {
+		uxa = append(uxa, coin.UxOut{
+			Head: coin.UxHead{BkSeq: head.BkSeq, Time: head.Time},
+			Body: coin.UxBody{
+				SrcTransaction: testutil.RandSHA256(t),
+				Address:        owner,
+				Coins:          1e6,
+				Hours:          100,
+			},
+		})
+	}
+	dest := testutil.MakeAddress()
+	p, err := skytypes.NewTxnParams(api.CreateTransactionRequest{
+		HoursSelection: api.HoursSelection{Type: "auto", Mode: "share", ShareFactor: "0.5"},
+		To:             []api.Receiver{{Address: dest.String(), Coins: "10"}},
+	})
+	require.NoError(t, err)
+
+	// Transfers within limits are not split
+	plan, err := planTransactions(p, uxa, head, skyparams.UserVerifyTxn)
+	require.NoError(t, err)
+	require.Len(t, plan, 1)
+
+	// Up to 4 inputs fit in payments and 5 in consolidations
+	small := skyparams.UserVerifyTxn
+	small.MaxTransactionSize = 600
+	plan, err = planTransactions(p, uxa, head, small)
+	require.NoError(t, err)
+	requirePlanFits(t, plan, small)
+	require.Len(t, plan, 3)
+	for _, planned := range plan[:2] {
+		require.Len(t, planned.inputs, 5)
+		require.Len(t, planned.txn.Out, 1)
+		require.Equal(t, owner, planned.txn.Out[0].Address)
+	}
+	require.Equal(t, uint64(5e6), plan[0].txn.Out[0].Coins)
+	require.Equal(t, uint64(9e6), plan[1].txn.Out[0].Coins)
+	payment := plan[2]
+	require.True(t, len(payment.inputs) <= maxTxnInputs(2, small))
+	require.Equal(t, plan[1].txn.Hash(), payment.inputs[0].SrcTransaction)
+	require.Equal(t, dest, payment.txn.Out[0].Address)
+	require.Equal(t, uint64(10e6), payment.txn.Out[0].Coins)
+
+	// Receivers are split in batches fitting in transactions
+	to := make([]api.Receiver, 25)
+	for i := range to {
+		to[i] = api.Receiver{Address: testutil.MakeAddress().String(), Coins: "1"}
+	}
+	p, err = skytypes.NewTxnParams(api.CreateTransactionRequest{
+		HoursSelection: api.HoursSelection{Type: "auto", Mode: "share", ShareFactor: "0.5"},
+		To:             to,
+	})
+	require.NoError(t, err)
+	large := append(coin.UxArray{}, uxa[:3]...)
+	for i := range large {
+		large[i].Body.Coins = 100e6
+	}
+	plan, err = planTransactions(p, large, head, small)
+	require.NoError(t, err)
+	requirePlanFits(t, plan, small)
+	require.Len(t, plan, 3)
+	var paid []coin.TransactionOutput
+	for _, planned := range plan {
+		out := planned.txn.Out
+		// Last output is change
+		paid = append(paid, out[:len(out)-1]...)
+	}
+	require.Len(t, paid, len(p.To))
+	for i, out := range paid {
+		require.Equal(t, p.To[i].Address, out.Address)
+	}
+
+	// Insufficient balance is reported before planning
+	p.To = append(p.To, coin.TransactionOutput{Address: dest, Coins: 300e6})
+	_, err = planTransactions(p, large, head, small)
+	require.Error(t, err)
+}
+
+func TestPlanSendFromAddress(t *testing.T) {
+	sw := newSandboxWallet(t, "split", 1)
+	defer sw.close()
+	poolSection, p, node, wlt := sw.poolSection, sw.params, sw.node, sw.wlt
+	src := sw.addrs[0]
+	for i := 0; i < 6; i++ {
+		_, err := node.Mint(src.String(), 1e6, 100)
+		require.NoError(t, err)
+	}
+	dest := testutil.MakeAddress().String()
+
+	planner, isPlanner := wlt.(core.TxnPlanner)
+	require.True(t, isPlanner)
+	opt := NewTransferOptions()
+	opt.SetValue("BurnFactor", "0.5")
+	opt.SetValue("CoinHoursSelectionType", "auto")
+	txns, err := planner.PlanSendFromAddress([]core.Address{src}, []core.TransactionOutput{
+		&SkycoinTransactionOutput{skyOut: readable.TransactionOutput{Address: dest, Coins: "5"}},
+	}, nil, opt)
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+
+	// Up to 2 inputs fit in payments and 3 in consolidations
+	small := skyparams.UserVerifyTxn
+	small.MaxTransactionSize = 400
+	// Plans honour size limits of the wallet coin
+	p.VerifyTxn = small
+	RegisterFiberCoin(poolSection, p)
+	txns, err = planner.PlanSendFromAddress([]core.Address{src}, []core.TransactionOutput{
+		&SkycoinTransactionOutput{skyOut: readable.TransactionOutput{Address: dest, Coins: "5"}},
+	}, nil, opt)
+	require.NoError(t, err)
+	require.Len(t, txns, 3)
+	p.VerifyTxn = skyparams.UserVerifyTxn
+	RegisterFiberCoin(poolSection, p)
+	plan, err := planTransfer(poolSection, api.CreateTransactionRequest{
+		Addresses:      []string{src.String()},
+		HoursSelection: api.HoursSelection{Type: "auto", Mode: "share", ShareFactor: "0.5"},
+		To:             []api.Receiver{{Address: dest, Coins: "5"}},
+	}, nil, small)
+	require.NoError(t, err)
+	require.Len(t, plan, 3)
+
+	txns = make([]core.Transaction, 0, len(plan))
+	for _, txn := range plan {
+		txns = append(txns, txn)
+	}
+	signer, err := util.LookupSignServiceForWallet(wlt, core.UID(""))
+	require.NoError(t, err)
+	signed, err := planner.SignPlan(txns, signer, util.EmptyPassword)
+	require.NoError(t, err)
+	require.Len(t, signed, len(txns))
+	// Chained inputs spend outputs of signed transactions
+	require.Equal(t, signed[0].GetOutputs()[0].GetId(), signed[1].GetInputs()[0].GetId())
+	require.NoError(t, NewSkycoinPEX(poolSection).BroadcastPlan(signed))
+	balances, err := node.Balance([]string{dest})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5e6), balances.Confirmed.Coins)
+	balances, err = node.Balance([]string{src.String()})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1e6), balances.Confirmed.Coins)
+}
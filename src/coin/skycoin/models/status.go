@@ -0,0 +1,238 @@
+package skycoin
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logStatus = logging.MustGetLogger("Skycoin Node Status")
+
+const (
+	// MinNodeVersion oldest node version serving the API this wallet relies upon
+	MinNodeVersion = "0.27.0"
+	// DefaultMaxBlocksBehind blocks a node may lag behind its peers and still be considered synchronized
+	DefaultMaxBlocksBehind = 2
+	// DefaultMaxBlockAge time since last block beyond which chain tip is considered stale
+	DefaultMaxBlockAge = 6 * time.Hour
+	// DefaultStatusCacheTime time node status is reused before asking node again
+	DefaultStatusCacheTime = 10 * time.Second
+)
+
+// SendGating determines what happens to transactions created or broadcast while node is not ready
+type SendGating int
+
+const (
+	// SendGatingOff ignores node status
+	SendGatingOff SendGating = iota
+	// SendGatingWarn logs node problems and goes on
+	SendGatingWarn
+	// SendGatingBlock refuses to create and broadcast transactions
+	SendGatingBlock
+)
+
+// ParseSendGating parses off, warn or block
+func ParseSendGating(s string) (SendGating, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "off":
+		return SendGatingOff, nil
+	case "warn":
+		return SendGatingWarn, nil
+	case "block":
+		return SendGatingBlock, nil
+	}
+	return SendGatingOff, errors.ErrInvalidOptions
+}
+
+// NodeStatus health of node serving a connection pool section
+type NodeStatus struct {
+	CheckedAt time.Time
+	// Err why node could not be reached, nil if reachable
+	Err          error
+	Version      string
+	Compatible   bool
+	CurrentBlock uint64
+	HighestBlock uint64
+	Peers        int
+	LastBlockAge time.Duration
+	// Problems blocking ones first, then warnings
+	Problems []error
+}
+
+// Reachable determines whether node answered status requests
+func (ns *NodeStatus) Reachable() bool {
+	return ns.Err == nil
+}
+
+// Blocking returns first problem preventing transactions from being sent, if any
+func (ns *NodeStatus) Blocking() error {
+	for _, err := range ns.Problems {
+		if isBlocking(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Warnings returns problems worth telling user about which do not prevent sending
+func (ns *NodeStatus) Warnings() []error {
+	warnings := make([]error, 0)
+	for _, err := range ns.Problems {
+		if !isBlocking(err) {
+			warnings = append(warnings, err)
+		}
+	}
+	return warnings
+}
+
+func isBlocking(err error) bool {
+	return err != errors.ErrNodeStale && err != errors.ErrNodeNoPeers
+}
+
+// SkycoinNodeStatus reports version, API compatibility, sync progress, peer count
+// and last block age of node serving a connection pool section
+type SkycoinNodeStatus struct {
+	poolSection     string
+	CacheTime       time.Duration
+	MaxBlocksBehind uint64
+	MaxBlockAge     time.Duration
+
+	mutex  sync.Mutex
+	gating SendGating
+	cached *NodeStatus
+}
+
+var (
+	nodeStatuses      = make(map[string]*SkycoinNodeStatus)
+	nodeStatusesMutex sync.Mutex
+)
+
+// GetNodeStatus returns status service of node serving connection pool section
+func GetNodeStatus(poolSection string) *SkycoinNodeStatus {
+	poolSection = sectionOrDefault(poolSection)
+	nodeStatusesMutex.Lock()
+	defer nodeStatusesMutex.Unlock()
+	sns, exists := nodeStatuses[poolSection]
+	if !exists {
+		sns = newSkycoinNodeStatus(poolSection)
+		nodeStatuses[poolSection] = sns
+	}
+	return sns
+}
+
+func newSkycoinNodeStatus(poolSection string) *SkycoinNodeStatus {
+	return &SkycoinNodeStatus{
+		poolSection:     poolSection,
+		CacheTime:       DefaultStatusCacheTime,
+		MaxBlocksBehind: DefaultMaxBlocksBehind,
+		MaxBlockAge:     DefaultMaxBlockAge,
+	}
+}
+
+// SetGating changes what happens to transactions sent while node is not ready
+func (sns *SkycoinNodeStatus) SetGating(gating SendGating) {
+	sns.mutex.Lock()
+	defer sns.mutex.Unlock()
+	sns.gating = gating
+}
+
+// GetStatus returns node status, asking node if the one known is older than cache time
+func (sns *SkycoinNodeStatus) GetStatus() *NodeStatus {
+	sns.mutex.Lock()
+	cached := sns.cached
+	sns.mutex.Unlock()
+	if cached != nil && time.Since(cached.CheckedAt) < sns.CacheTime {
+		return cached
+	}
+	return sns.Refresh()
+}
+
+// Refresh asks node for its status
+func (sns *SkycoinNodeStatus) Refresh() *NodeStatus {
+	status := sns.request()
+	sns.mutex.Lock()
+	defer sns.mutex.Unlock()
+	sns.cached = status
+	return status
+}
+
+func (sns *SkycoinNodeStatus) request() *NodeStatus {
+	logStatus.Info("Requesting node status")
+	status := &NodeStatus{CheckedAt: time.Now()}
+	unreachable := func(err error) *NodeStatus {
+		logStatus.WithError(err).Warn("Couldn't get node status")
+		status.Err = err
+		status.Problems = []error{err}
+		return status
+	}
+	c, err := NewSkycoinApiClient(sns.poolSection)
+	if err != nil {
+		return unreachable(err)
+	}
+	defer ReturnSkycoinClient(c)
+	health, err := c.Health()
+	if err != nil {
+		return unreachable(err)
+	}
+	progress, err := c.BlockchainProgress()
+	if err != nil {
+		return unreachable(err)
+	}
+
+	status.Version = health.Version.Version
+	status.CurrentBlock = progress.Current
+	status.HighestBlock = progress.Highest
+	status.Peers = health.OpenConnections
+	status.LastBlockAge = health.BlockchainMetadata.TimeSinceLastBlock.Duration
+	status.Compatible = isCompatible(health.Version)
+	if !status.Compatible {
+		status.Problems = append(status.Problems, errors.ErrNodeIncompatible)
+	}
+	if status.HighestBlock > status.CurrentBlock+sns.MaxBlocksBehind {
+		status.Problems = append(status.Problems, errors.ErrNodeNotSynced)
+	}
+	if status.LastBlockAge > sns.MaxBlockAge {
+		status.Problems = append(status.Problems, errors.ErrNodeStale)
+	}
+	if status.Peers == 0 {
+		status.Problems = append(status.Problems, errors.ErrNodeNoPeers)
+	}
+	return status
+}
+
+// isCompatible determines whether node version is at least MinNodeVersion
+func isCompatible(build readable.BuildInfo) bool {
+	version, err := build.Semver()
+	if err != nil {
+		return false
+	}
+	minVersion, err := readable.BuildInfo{Version: MinNodeVersion}.Semver()
+	if err != nil {
+		return false
+	}
+	return version.GTE(*minVersion)
+}
+
+// CheckSend tells whether transactions may be created and broadcast according to node status
+func (sns *SkycoinNodeStatus) CheckSend() error {
+	sns.mutex.Lock()
+	gating := sns.gating
+	sns.mutex.Unlock()
+	if gating == SendGatingOff {
+		return nil
+	}
+	status := sns.GetStatus()
+	for _, warning := range status.Warnings() {
+		logStatus.WithError(warning).Warn("Sending through node with problems")
+	}
+	err := status.Blocking()
+	if err != nil && gating == SendGatingWarn {
+		logStatus.WithError(err).Warn("Sending through node not ready")
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+package skycoin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/sandbox"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skymocks"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// skyMockFactory serves the same mock to every pool client
+type skyMockFactory struct {
+	skyMock *skymocks.SkycoinAPI
+}
+
+func (f *skyMockFactory) Create() (interface{}, error) {
+	return f.skyMock, nil
+}
+
+func mockNodeStatus(t *testing.T, poolSection, version string, current, highest uint64) *SkycoinNodeStatus {
+	skyMock := new(skymocks.SkycoinAPI)
+	health := &api.HealthResponse{Version: readable.BuildInfo{Version: version}, OpenConnections: 8}
+	skyMock.On("Health").Return(health, nil)
+	skyMock.On("BlockchainProgress").Return(&readable.BlockchainProgress{Current: current, Highest: highest}, nil)
+	require.NoError(t, core.GetMultiPool().CreateSection(poolSection, &skyMockFactory{skyMock}))
+	return GetNodeStatus(poolSection)
+}
+
+func TestNodeStatusSandbox(t *testing.T) {
+	poolSection := "skycoin-status-sandbox"
+	require.NoError(t, core.GetMultiPool().CreateSection(poolSection, NewSkycoinConnectionFactory(sandbox.URLScheme+"status-test")))
+	sns := GetNodeStatus(poolSection)
+	sns.SetGating(SendGatingBlock)
+
+	status := sns.GetStatus()
+	require.True(t, status.Reachable())
+	require.Equal(t, sandbox.Version, status.Version)
+	require.True(t, status.Compatible)
+	require.NoError(t, status.Blocking())
+	require.Equal(t, []error{errors.ErrNodeNoPeers}, status.Warnings())
+	require.NoError(t, sns.CheckSend())
+
+	// Status is cached
+	sandbox.GetNode("status-test").AdvanceTime(DefaultMaxBlockAge + time.Hour)
+	require.Equal(t, status, sns.GetStatus())
+	status = sns.Refresh()
+	require.Equal(t, []error{errors.ErrNodeStale, errors.ErrNodeNoPeers}, status.Warnings())
+	require.NoError(t, sns.CheckSend())
+}
+
+func TestNodeStatusGating(t *testing.T) {
+	sns := mockNodeStatus(t, "skycoin-status-syncing", "0.27.1", 5, 10)
+	status := sns.GetStatus()
+	require.Equal(t, uint64(5), status.CurrentBlock)
+	require.Equal(t, 8, status.Peers)
+	require.Equal(t, errors.ErrNodeNotSynced, status.Blocking())
+
+	require.NoError(t, sns.CheckSend())
+	sns.SetGating(SendGatingWarn)
+	require.NoError(t, sns.CheckSend())
+	sns.SetGating(SendGatingBlock)
+	require.Equal(t, errors.ErrNodeNotSynced, sns.CheckSend())
+
+	// Transactions are neither created nor broadcast
+	_, err := newSkycoinBlockchain(0, "skycoin-status-syncing").SendFromAddress(nil, nil, nil, nil)
+	require.Equal(t, errors.ErrNodeNotSynced, err)
+	err = NewSkycoinPEX("skycoin-status-syncing").BroadcastTxn(new(SkycoinUninjectedTransaction))
+	require.Equal(t, errors.ErrNodeNotSynced, err)
+}
+
+func TestNodeStatusProblems(t *testing.T) {
+	status := mockNodeStatus(t, "skycoin-status-old", "0.26.0", 10, 10).GetStatus()
+	require.False(t, status.Compatible)
+	require.Equal(t, errors.ErrNodeIncompatible, status.Blocking())
+
+	status = GetNodeStatus("skycoin-status-unknown").GetStatus()
+	require.False(t, status.Reachable())
+	require.Equal(t, errors.ErrInvalidPoolSection, status.Blocking())
+}
+
+func TestParseSendGating(t *testing.T) {
+	for s, expected := range map[string]SendGating{"off": SendGatingOff, "Warn": SendGatingWarn, " block": SendGatingBlock} {
+		gating, err := ParseSendGating(s)
+		require.NoError(t, err)
+		require.Equal(t, expected, gating)
+	}
+	_, err := ParseSendGating("maybe")
+	require.Equal(t, errors.ErrInvalidOptions, err)
+}
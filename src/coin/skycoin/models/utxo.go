@@ -0,0 +1,254 @@
+package skycoin
+
+import (
+	"sort"
+
+	skyparams "github.com/SkycoinProject/skycoin/src/params"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/util/droplet"
+	"github.com/SkycoinProject/skycoin/src/util/fee"
+	"github.com/SkycoinProject/skycoin/src/util/mathutil"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+// ConsolidateOutputs instantiates unsigned transactions merging unspent outputs
+// holding less than dust coins, every output if dust is zero
+func (wlt *LocalWallet) ConsolidateOutputs(dust uint64, to core.Address, options core.KeyValueStore) ([]core.Transaction, error) {
+	return consolidateOutputs(wlt, wlt.poolSection, dust, to, options)
+}
+
+// SplitOutput instantiates unsigned transaction sending equal amounts of output coins to addresses
+func (wlt *LocalWallet) SplitOutput(outID string, to []core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	return splitOutput(wlt, wlt.poolSection, outID, to, options)
+}
+
+// PreviewOutputs lists unspent outputs wallet would hold once transactions are confirmed
+func (wlt *LocalWallet) PreviewOutputs(txns []core.Transaction) ([]core.TransactionOutput, error) {
+	return previewOutputs(wlt, txns)
+}
+
+// ConsolidateOutputs instantiates unsigned transactions merging unspent outputs
+// holding less than dust coins, every output if dust is zero
+func (wlt *RemoteWallet) ConsolidateOutputs(dust uint64, to core.Address, options core.KeyValueStore) ([]core.Transaction, error) {
+	return consolidateOutputs(wlt, wlt.poolSection, dust, to, options)
+}
+
+// SplitOutput instantiates unsigned transaction sending equal amounts of output coins to addresses
+func (wlt *RemoteWallet) SplitOutput(outID string, to []core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	return splitOutput(wlt, wlt.poolSection, outID, to, options)
+}
+
+// PreviewOutputs lists unspent outputs wallet would hold once transactions are confirmed
+func (wlt *RemoteWallet) PreviewOutputs(txns []core.Transaction) ([]core.TransactionOutput, error) {
+	return previewOutputs(wlt, txns)
+}
+
+// scanSkycoinOutputs lists unspent outputs of wallet
+func scanSkycoinOutputs(wlt core.Wallet) ([]*SkycoinTransactionOutput, error) {
+	outsIter, err := wlt.GetCryptoAccount().ScanUnspentOutputs()
+	if err != nil {
+		return nil, err
+	}
+	outs := make([]*SkycoinTransactionOutput, 0)
+	for outsIter.Next() {
+		out, isSkyOut := outsIter.Value().(*SkycoinTransactionOutput)
+		if !isSkyOut {
+			return nil, errors.ErrInvalidTypeAssertion
+		}
+		outs = append(outs, out)
+	}
+	return outs, nil
+}
+
+// consolidateOutputs merges unlocked dust outputs of wallet, as many per transaction as fit in size limits
+func consolidateOutputs(wlt core.Wallet, poolSection string, dust uint64, to core.Address, options core.KeyValueStore) ([]core.Transaction, error) {
+	logWallet.Info("Consolidating wallet outputs")
+	outs, err := scanSkycoinOutputs(wlt)
+	if err != nil {
+		return nil, err
+	}
+	locks, err := lookupOutputLocks(wlt)
+	if err != nil {
+		return nil, err
+	}
+	merged := make([]*SkycoinTransactionOutput, 0, len(outs))
+	outCoins := make(map[*SkycoinTransactionOutput]uint64, len(outs))
+	for _, out := range outs {
+		if locks[out.GetId()] != core.OutputUnlocked {
+			continue
+		}
+		coins, err := droplet.FromString(out.skyOut.Coins)
+		if err != nil {
+			return nil, err
+		}
+		if dust == 0 || coins < dust {
+			merged = append(merged, out)
+			outCoins[out] = coins
+		}
+	}
+	if len(merged) < 2 {
+		logWallet.Warn("Not enough outputs to consolidate")
+		return nil, errors.ErrInvalidValue
+	}
+	// Smaller outputs are merged first
+	sort.SliceStable(merged, func(i, j int) bool {
+		return outCoins[merged[i]] < outCoins[merged[j]]
+	})
+
+	// Room is left for change output in case coin hours can not be sent in full
+	chunkSize := maxTxnInputs(2, verifyTxnParams(poolSection))
+	txns := make([]core.Transaction, 0, len(merged)/chunkSize+1)
+	for start := 0; start < len(merged); start += chunkSize {
+		end := start + chunkSize
+		if end > len(merged) {
+			end = len(merged)
+		}
+		if end-start < 2 {
+			break
+		}
+		txn, err := spendOutputs(wlt, poolSection, merged[start:end], []core.Address{to}, options)
+		if err != nil {
+			return nil, err
+		}
+		txns = append(txns, txn)
+	}
+	return txns, nil
+}
+
+// splitOutput spends wallet output sending equal amounts of coins to addresses
+func splitOutput(wlt core.Wallet, poolSection string, outID string, to []core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	logWallet.Infof("Splitting output %s in %d outputs", outID, len(to))
+	if len(to) < 2 {
+		return nil, errors.ErrInvalidValue
+	}
+	if len(to)+1 > maxTxnOutputs(1, verifyTxnParams(poolSection)) {
+		return nil, errors.ErrTxnTooLarge
+	}
+	addrs := make(map[string]bool, len(to))
+	for _, addr := range to {
+		if addrs[addr.String()] {
+			return nil, errors.ErrTxnDuplicateOutput
+		}
+		addrs[addr.String()] = true
+	}
+	outs, err := scanSkycoinOutputs(wlt)
+	if err != nil {
+		return nil, err
+	}
+	for _, out := range outs {
+		if out.GetId() == outID {
+			return spendOutputs(wlt, poolSection, []*SkycoinTransactionOutput{out}, to, options)
+		}
+	}
+	logWallet.Warnf("Output %s not found in wallet", outID)
+	return nil, errors.ErrNotFound
+}
+
+// spendOutputs spends outputs sending equal amounts of coins to addresses, rounded down to allowed droplet precision.
+// If coin hours are selected manually those left after the minimum fee are also split equally.
+func spendOutputs(wlt core.Wallet, poolSection string, outs []*SkycoinTransactionOutput, to []core.Address, options core.KeyValueStore) (core.Transaction, error) {
+	var coins, hours uint64
+	unspent := make([]core.TransactionOutput, 0, len(outs))
+	for _, out := range outs {
+		outCoins, err := droplet.FromString(out.skyOut.Coins)
+		if err != nil {
+			return nil, err
+		}
+		if coins, err = mathutil.AddUint64(coins, outCoins); err != nil {
+			return nil, err
+		}
+		if hours, err = mathutil.AddUint64(hours, out.calculatedHours); err != nil {
+			return nil, err
+		}
+		unspent = append(unspent, out)
+	}
+
+	verifyParams := verifyTxnParams(poolSection)
+	unit := dropletPrecisionUnit(verifyParams)
+	n := uint64(len(to))
+	outCoins := coins / n / unit * unit
+	if outCoins == 0 {
+		return nil, errors.ErrTxnZeroCoinOutput
+	}
+	strCoins, err := droplet.ToString(outCoins)
+	if err != nil {
+		return nil, err
+	}
+	var outHours uint64
+	if options.GetValue(TxnOptCoinHoursSelectionType) == CoinHoursSelectionManual {
+		outHours = (hours - fee.RequiredFee(hours, verifyParams.BurnFactor)) / n
+	}
+
+	dests := make([]core.TransactionOutput, 0, len(to))
+	for _, addr := range to {
+		dests = append(dests, &SkycoinTransactionOutput{
+			skyOut: readable.TransactionOutput{
+				Address: addr.String(),
+				Coins:   strCoins,
+				Hours:   outHours,
+			},
+			poolSection: poolSection,
+		})
+	}
+	change, err := outs[0].GetAddress()
+	if err != nil {
+		return nil, err
+	}
+	return wlt.Spend(unspent, dests, change, options)
+}
+
+// dropletPrecisionUnit smallest amount of droplets allowed in transaction outputs
+func dropletPrecisionUnit(verifyParams skyparams.VerifyTxn) uint64 {
+	unit := uint64(1)
+	for i := verifyParams.MaxDropletPrecision; i < droplet.Exponent; i++ {
+		unit *= 10
+	}
+	return unit
+}
+
+// previewOutputs removes outputs spent by transactions from wallet outputs and adds those created for wallet addresses
+func previewOutputs(wlt core.Wallet, txns []core.Transaction) ([]core.TransactionOutput, error) {
+	addrsIter, err := wlt.GetLoadedAddresses()
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[string]bool)
+	for addrsIter.Next() {
+		owned[addrsIter.Value().String()] = true
+	}
+	spent := make(map[string]bool)
+	for _, txn := range txns {
+		for _, in := range txn.GetInputs() {
+			spent[in.GetId()] = true
+		}
+	}
+	outs, err := scanSkycoinOutputs(wlt)
+	if err != nil {
+		return nil, err
+	}
+	preview := make([]core.TransactionOutput, 0, len(outs))
+	for _, out := range outs {
+		if !spent[out.GetId()] {
+			preview = append(preview, out)
+		}
+	}
+	for _, txn := range txns {
+		for _, out := range txn.GetOutputs() {
+			addr, err := out.GetAddress()
+			if err != nil {
+				return nil, err
+			}
+			if owned[addr.String()] && !spent[out.GetId()] {
+				preview = append(preview, out)
+			}
+		}
+	}
+	return preview, nil
+}
+
+// Type assertions
+var (
+	_ core.UTXOManager = &LocalWallet{}
+	_ core.UTXOManager = &RemoteWallet{}
+)
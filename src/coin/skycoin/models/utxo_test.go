@@ -0,0 +1,105 @@
+package skycoin
+
+import (
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/testutil"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/stretchr/testify/require"
+)
+
+func requireOutputCoins(t *testing.T, outs []core.TransactionOutput, ticker string, expected map[uint64]int) {
+	actual := make(map[uint64]int)
+	for _, out := range outs {
+		coins, err := out.GetCoins(ticker)
+		require.NoError(t, err)
+		actual[coins]++
+	}
+	require.Equal(t, expected, actual)
+}
+
+func TestUTXOManager(t *testing.T) {
+	sw := newSandboxWallet(t, "utxo", 3)
+	defer sw.close()
+	poolSection, p, node, wlt, addrs := sw.poolSection, sw.params, sw.node, sw.wlt, sw.addrs
+	for i := 0; i < 5; i++ {
+		_, err := node.Mint(addrs[i%2].String(), 1e6, 100)
+		require.NoError(t, err)
+	}
+	_, err := node.Mint(addrs[0].String(), 100e6, 1000)
+	require.NoError(t, err)
+	outs, err := scanSkycoinOutputs(wlt)
+	require.NoError(t, err)
+	require.Len(t, outs, 6)
+	var bigID string
+	for _, out := range outs {
+		if out.skyOut.Coins == "100.000000" {
+			bigID = out.GetId()
+		}
+	}
+	require.NotEmpty(t, bigID)
+
+	manager, isManager := wlt.(core.UTXOManager)
+	require.True(t, isManager)
+	auto := NewTransferOptions()
+	auto.SetValue(TxnOptCoinHoursSelectionType, CoinHoursSelectionAuto)
+	auto.SetValue(TxnOptBurnFactor, "0.5")
+	manual := NewTransferOptions()
+	manual.SetValue(TxnOptCoinHoursSelectionType, CoinHoursSelectionManual)
+	manual.SetValue(TxnOptBurnFactor, "0.5")
+
+	// Split outputs take equal coins and, if selected manually, equal hours
+	_, err = manager.SplitOutput(bigID, addrs[:1], manual)
+	require.Equal(t, errors.ErrInvalidValue, err)
+	_, err = manager.SplitOutput(bigID, []core.Address{addrs[0], addrs[1], addrs[0]}, manual)
+	require.Equal(t, errors.ErrTxnDuplicateOutput, err)
+	_, err = manager.SplitOutput(testutil.RandSHA256(t).Hex(), addrs, manual)
+	require.Equal(t, errors.ErrNotFound, err)
+	split, err := manager.SplitOutput(bigID, addrs, manual)
+	require.NoError(t, err)
+	splitOuts := split.GetOutputs()
+	require.Len(t, splitOuts, 4)
+	hours, err := splitOuts[0].GetCoins(p.CoinHoursTicker)
+	require.NoError(t, err)
+	for _, out := range splitOuts[1:3] {
+		outHours, err := out.GetCoins(p.CoinHoursTicker)
+		require.NoError(t, err)
+		require.Equal(t, hours, outHours)
+	}
+	preview, err := manager.PreviewOutputs([]core.Transaction{split})
+	require.NoError(t, err)
+	requireOutputCoins(t, preview, p.Ticker, map[uint64]int{1e6: 5, 33333000: 3, 1000: 1})
+
+	// Split amounts are rounded to droplet precision of the wallet coin
+	wholeCoins := p
+	wholeCoins.VerifyTxn.MaxDropletPrecision = 0
+	RegisterFiberCoin(poolSection, wholeCoins)
+	split, err = manager.SplitOutput(bigID, addrs, manual)
+	require.NoError(t, err)
+	RegisterFiberCoin(poolSection, p)
+	preview, err = manager.PreviewOutputs([]core.Transaction{split})
+	require.NoError(t, err)
+	requireOutputCoins(t, preview, p.Ticker, map[uint64]int{1e6: 6, 33e6: 3})
+
+	// Dust outputs are merged in a single output
+	_, err = manager.ConsolidateOutputs(1, addrs[2], auto)
+	require.Equal(t, errors.ErrInvalidValue, err)
+	txns, err := manager.ConsolidateOutputs(2e6, addrs[2], auto)
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+	require.Len(t, txns[0].GetInputs(), 5)
+	preview, err = manager.PreviewOutputs(txns)
+	require.NoError(t, err)
+	requireOutputCoins(t, preview, p.Ticker, map[uint64]int{100e6: 1, 5e6: 1})
+
+	signer, err := util.LookupSignServiceForWallet(wlt, core.UID(""))
+	require.NoError(t, err)
+	signed, err := wlt.Sign(txns[0], signer, util.EmptyPassword, nil)
+	require.NoError(t, err)
+	require.NoError(t, NewSkycoinPEX(poolSection).BroadcastTxn(signed))
+	outs, err = scanSkycoinOutputs(wlt)
+	require.NoError(t, err)
+	require.Len(t, outs, 2)
+}
@@ -0,0 +1,169 @@
+package skycoin
+
+import (
+	"fmt"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	skyparams "github.com/SkycoinProject/skycoin/src/params"
+	"github.com/SkycoinProject/skycoin/src/util/fee"
+	"github.com/SkycoinProject/skycoin/src/visor"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/resilience"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+// TxnValidationError transaction breaks a rule checked before broadcast
+type TxnValidationError struct {
+	// Rule broken, one of errors.ErrTxn* values or errors.ErrInvalidTxn for any other consensus rule
+	Rule error
+	// Index of offending input or output, -1 if rule applies to the whole transaction
+	Index int
+	// Detail explains what has to be fixed
+	Detail string
+}
+
+// Error reports broken rule followed by details
+func (e *TxnValidationError) Error() string {
+	if e.Detail == "" {
+		return e.Rule.Error()
+	}
+	return fmt.Sprintf("%v: %s", e.Rule, e.Detail)
+}
+
+// ValidationRule tells which rule err breaks, nil if err is not a validation error
+func ValidationRule(err error) error {
+	if e, isValidation := err.(*TxnValidationError); isValidation {
+		return e.Rule
+	}
+	return nil
+}
+
+func validationError(rule error, index int, format string, args ...interface{}) error {
+	return &TxnValidationError{
+		Rule:   rule,
+		Index:  index,
+		Detail: fmt.Sprintf(format, args...),
+	}
+}
+
+// ValidateTxn checks transaction against Skycoin rules for user transactions.
+// Rules are checked locally first and then by node verify endpoint.
+func (spex *SkycoinPEX) ValidateTxn(txn core.Transaction) error {
+	logNetwork.Info("Validating transaction")
+	skyTxn, isSkyTxn := txn.(skytypes.SkycoinTxn)
+	if !isSkyTxn {
+		return errors.ErrInvalidTxn
+	}
+	txnBytes, err := skyTxn.EncodeSkycoinTransaction()
+	if err != nil {
+		return err
+	}
+	rawTxn, err := coin.DeserializeTransaction(txnBytes)
+	if err != nil {
+		return err
+	}
+	c, err := NewSkycoinApiClient(sectionOrDefault(spex.poolSection))
+	if err != nil {
+		return err
+	}
+	defer ReturnSkycoinClient(c)
+	return validateTransaction(c, &rawTxn, verifyTxnParams(spex.poolSection))
+}
+
+// validateTransaction applies verifyParams and consensus rules to txn before asking node to verify it
+func validateTransaction(c skytypes.SkycoinAPI, txn *coin.Transaction, verifyParams skyparams.VerifyTxn) error {
+	size, err := txn.Size()
+	if err != nil {
+		return err
+	}
+	if size > verifyParams.MaxTransactionSize {
+		return validationError(errors.ErrTxnTooLarge, -1, "%d bytes, at most %d allowed", size, verifyParams.MaxTransactionSize)
+	}
+
+	outputs := make(map[coin.TransactionOutput]int, len(txn.Out))
+	for i, out := range txn.Out {
+		if out.Coins == 0 {
+			return validationError(errors.ErrTxnZeroCoinOutput, i, "output %d to %s", i, out.Address)
+		}
+		if err := skyparams.DropletPrecisionCheck(verifyParams.MaxDropletPrecision, out.Coins); err != nil {
+			return validationError(errors.ErrTxnInvalidPrecision, i, "output %d to %s, at most %d decimal places allowed", i, out.Address, verifyParams.MaxDropletPrecision)
+		}
+		if j, isDuplicate := outputs[out]; isDuplicate {
+			return validationError(errors.ErrTxnDuplicateOutput, i, "output %d repeats output %d", i, j)
+		}
+		outputs[out] = i
+	}
+
+	for i := range txn.In {
+		if i >= len(txn.Sigs) || txn.Sigs[i].Null() {
+			return validationError(errors.ErrTxnNotFullySigned, i, "input %d is not signed", i)
+		}
+	}
+
+	health, err := c.Health()
+	if err != nil {
+		return err
+	}
+	head := coin.BlockHeader{
+		BkSeq: health.BlockchainMetadata.Head.BkSeq,
+		Time:  health.BlockchainMetadata.Head.Time,
+	}
+	uxIn := make(coin.UxArray, 0, len(txn.In))
+	for i, in := range txn.In {
+		ux, err := c.UxOut(in.Hex())
+		if err != nil {
+			return err
+		}
+		if ux.SpentTxnID != (cipher.SHA256{}).Hex() {
+			return validationError(errors.ErrTxnInputSpent, i, "input %d was spent by transaction %s", i, ux.SpentTxnID)
+		}
+		cUx, err := uxOutFromSpentOutput(ux)
+		if err != nil {
+			return err
+		}
+		uxIn = append(uxIn, cUx)
+	}
+
+	txnFee, err := fee.TransactionFee(txn, head.Time, uxIn)
+	if err == fee.ErrTxnInsufficientCoinHours {
+		return validationError(errors.ErrTxnInsufficientHours, -1, "outputs hold more coin hours than inputs")
+	} else if err != nil {
+		return validationError(errors.ErrInvalidTxn, -1, "%v", err)
+	}
+	if err := fee.VerifyTransactionFee(txn, txnFee, verifyParams.BurnFactor); err != nil {
+		outHours, err := txn.OutputHours()
+		if err != nil {
+			return validationError(errors.ErrInvalidTxn, -1, "%v", err)
+		}
+		required := fee.RequiredFee(txnFee+outHours, verifyParams.BurnFactor)
+		return validationError(errors.ErrTxnInsufficientFee, -1, "%d coin hours burned, at least %d required", txnFee, required)
+	}
+
+	if err := visor.VerifySingleTxnUserConstraints(*txn); err != nil {
+		return validationError(errors.ErrInvalidTxn, -1, "%v", err)
+	}
+	if err := visor.VerifySingleTxnHardConstraints(*txn, head, uxIn, visor.TxnSigned); err != nil {
+		return validationError(errors.ErrInvalidTxn, -1, "%v", err)
+	}
+
+	rawTxn, err := txn.SerializeHex()
+	if err != nil {
+		return err
+	}
+	_, err = c.VerifyTransaction(api.VerifyTransactionRequest{EncodedTransaction: rawTxn})
+	if err != nil {
+		if resilience.Classify(err) == errors.ErrNodeUnavailable {
+			return err
+		}
+		return validationError(errors.ErrTxnRejectedByNode, -1, "%v", err)
+	}
+	return nil
+}
+
+// Type assertions
+var (
+	_ core.TxnValidator = &SkycoinPEX{}
+)
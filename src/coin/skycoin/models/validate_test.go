@@ -0,0 +1,153 @@
+package skycoin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	skyparams "github.com/SkycoinProject/skycoin/src/params"
+	"github.com/SkycoinProject/skycoin/src/testutil"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/mocks"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/sandbox"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// rejectingNode sandbox node refusing to verify any transaction
+type rejectingNode struct {
+	*sandbox.Node
+	status int
+}
+
+func (n rejectingNode) VerifyTransaction(req api.VerifyTransactionRequest) (*api.VerifyTransactionResponse, error) {
+	return nil, api.NewClientError(http.StatusText(n.status), n.status, "rejected")
+}
+
+func createSandboxTxn(t *testing.T, node *sandbox.Node, signed bool) coin.Transaction {
+	wr, err := node.CreateWallet(api.CreateWalletOptions{Type: "deterministic", Seed: testutil.RandSHA256(t).Hex(), Label: "Validate"})
+	require.NoError(t, err)
+	_, err = node.Mint(wr.Entries[0].Address, 100e6, 1000)
+	require.NoError(t, err)
+	txnR, err := node.WalletCreateTransaction(api.WalletCreateTransactionRequest{
+		WalletID: wr.Meta.Filename,
+		Unsigned: true,
+		CreateTransactionRequest: api.CreateTransactionRequest{
+			HoursSelection: api.HoursSelection{Type: "auto", Mode: "share", ShareFactor: "0.5"},
+			To:             []api.Receiver{{Address: testutil.MakeAddress().String(), Coins: "10"}},
+		},
+	})
+	require.NoError(t, err)
+	rawTxn := txnR.EncodedTransaction
+	if signed {
+		signedR, err := node.WalletSignTransaction(api.WalletSignTransactionRequest{
+			WalletID:           wr.Meta.Filename,
+			EncodedTransaction: rawTxn,
+		})
+		require.NoError(t, err)
+		rawTxn = signedR.EncodedTransaction
+	}
+	txn, err := coin.DeserializeTransactionHex(rawTxn)
+	require.NoError(t, err)
+	return txn
+}
+
+func requireValidationRule(t *testing.T, rule error, index int, err error) {
+	require.Error(t, err)
+	require.Equal(t, rule, ValidationRule(err), err.Error())
+	require.Equal(t, index, err.(*TxnValidationError).Index)
+}
+
+func TestValidateTransaction(t *testing.T) {
+	node := sandbox.NewNode()
+	verifyParams := skyparams.UserVerifyTxn
+
+	txn := createSandboxTxn(t, node, true)
+	require.NoError(t, validateTransaction(node, &txn, verifyParams))
+
+	tooLarge := verifyParams
+	tooLarge.MaxTransactionSize = 100
+	requireValidationRule(t, errors.ErrTxnTooLarge, -1, validateTransaction(node, &txn, tooLarge))
+
+	unsigned := createSandboxTxn(t, node, false)
+	requireValidationRule(t, errors.ErrTxnNotFullySigned, 0, validateTransaction(node, &unsigned, verifyParams))
+
+	zeroCoins := txn
+	zeroCoins.Out = append([]coin.TransactionOutput{}, txn.Out...)
+	zeroCoins.Out[1].Coins = 0
+	requireValidationRule(t, errors.ErrTxnZeroCoinOutput, 1, validateTransaction(node, &zeroCoins, verifyParams))
+
+	precision := txn
+	precision.Out = append([]coin.TransactionOutput{}, txn.Out...)
+	precision.Out[0].Coins++
+	requireValidationRule(t, errors.ErrTxnInvalidPrecision, 0, validateTransaction(node, &precision, verifyParams))
+
+	duplicate := txn
+	duplicate.Out = append([]coin.TransactionOutput{}, txn.Out...)
+	duplicate.Out = append(duplicate.Out, txn.Out[0])
+	requireValidationRule(t, errors.ErrTxnDuplicateOutput, 2, validateTransaction(node, &duplicate, verifyParams))
+
+	uxOut, err := node.UxOut(txn.In[0].Hex())
+	require.NoError(t, err)
+	outHours, err := txn.OutputHours()
+	require.NoError(t, err)
+	// Sandbox node does not advance time unless asked, output hours are those minted
+	txnFee := uxOut.Hours - outHours
+	require.NotZero(t, txnFee)
+
+	noFee := txn
+	noFee.Out = append([]coin.TransactionOutput{}, txn.Out...)
+	noFee.Out[1].Hours += txnFee
+	requireValidationRule(t, errors.ErrTxnInsufficientFee, -1, validateTransaction(node, &noFee, verifyParams))
+
+	overspent := txn
+	overspent.Out = append([]coin.TransactionOutput{}, txn.Out...)
+	overspent.Out[1].Hours += txnFee + 1
+	requireValidationRule(t, errors.ErrTxnInsufficientHours, -1, validateTransaction(node, &overspent, verifyParams))
+
+	// Consensus rules are checked after user rules
+	moreCoins := txn
+	moreCoins.Out = append([]coin.TransactionOutput{}, txn.Out...)
+	moreCoins.Out[1].Coins += 1e6
+	requireValidationRule(t, errors.ErrInvalidTxn, -1, validateTransaction(node, &moreCoins, verifyParams))
+
+	// Node rejections are told apart from node failures
+	rejected := validateTransaction(rejectingNode{node, http.StatusUnprocessableEntity}, &txn, verifyParams)
+	requireValidationRule(t, errors.ErrTxnRejectedByNode, -1, rejected)
+	unavailable := validateTransaction(rejectingNode{node, http.StatusServiceUnavailable}, &txn, verifyParams)
+	require.Error(t, unavailable)
+	require.Nil(t, ValidationRule(unavailable))
+
+	_, err = node.InjectTransaction(&txn)
+	require.NoError(t, err)
+	requireValidationRule(t, errors.ErrTxnInputSpent, 0, validateTransaction(node, &txn, verifyParams))
+}
+
+func TestSkycoinPEXValidateTxn(t *testing.T) {
+	poolSection, node := newSandboxSection(t, "validate")
+
+	txn := createSandboxTxn(t, node, true)
+	unTxn, err := NewUninjectedTransaction(&txn, 0)
+	require.NoError(t, err)
+	pex := NewSkycoinPEX(poolSection)
+	require.NoError(t, pex.ValidateTxn(unTxn))
+	require.NoError(t, pex.BroadcastTxn(unTxn))
+	requireValidationRule(t, errors.ErrTxnInputSpent, 0, pex.ValidateTxn(unTxn))
+
+	require.Equal(t, errors.ErrInvalidTxn, pex.ValidateTxn(new(mocks.Transaction)))
+
+	// Fiber coins are validated against their own params
+	fiberSection := "skycoin-sandbox-validate-fiber"
+	err = core.GetMultiPool().CreateSection(fiberSection, NewSkycoinConnectionFactory(sandbox.URLScheme+"models-validate"))
+	require.NoError(t, err)
+	fiberParams := SkycoinMainNetParams
+	fiberParams.PoolSection = fiberSection
+	fiberParams.VerifyTxn.MaxTransactionSize = 100
+	RegisterFiberCoin(fiberSection, fiberParams)
+	txn = createSandboxTxn(t, node, true)
+	unTxn, err = NewUninjectedTransaction(&txn, 0)
+	require.NoError(t, err)
+	require.NoError(t, pex.ValidateTxn(unTxn))
+	requireValidationRule(t, errors.ErrTxnTooLarge, -1, NewSkycoinPEX(fiberSection).ValidateTxn(unTxn))
+}
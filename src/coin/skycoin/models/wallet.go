@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/SkycoinProject/skycoin/src/api"
 	"github.com/SkycoinProject/skycoin/src/cipher"
 	"github.com/SkycoinProject/skycoin/src/cipher/bip39"
+	"github.com/SkycoinProject/skycoin/src/cipher/bip44"
 	"github.com/SkycoinProject/skycoin/src/coin"
 	"github.com/SkycoinProject/skycoin/src/readable"
 	"github.com/SkycoinProject/skycoin/src/visor"
@@ -36,6 +38,12 @@ const (
 
 	SignerIDLocalWallet  = "sky.local"
 	SignerIDRemoteWallet = "sky.remote"
+
+	TxnOptCoinHoursSelectionType = "CoinHoursSelectionType"
+	TxnOptBurnFactor             = "BurnFactor"
+	CoinHoursSelectionAuto       = "auto"
+	CoinHoursSelectionManual     = "manual"
+	DefaultShareFactor           = params.DefaultShareFactor
 )
 
 // SkycoinWalletIterator implements WalletIterator interface
@@ -251,6 +259,11 @@ func (wltSrv *SkycoinRemoteWallet) GetWallet(id string) core.Wallet {
 }
 
 func NewWalletNode(nodeAddress string) *WalletNode {
+	return newFiberWalletNode(nodeAddress, SkycoinMainNetParams)
+}
+
+// newFiberWalletNode instantiates remote wallets environment of SkyFiber coin node
+func newFiberWalletNode(nodeAddress string, fiberCoin params.SkyFiberParams) *WalletNode {
 
 	pool := core.GetMultiPool()
 	sections, err := pool.ListSections()
@@ -261,7 +274,7 @@ func NewWalletNode(nodeAddress string) *WalletNode {
 	var sect string
 	for {
 		find := false
-		sect = fmt.Sprintf("skycoin-%d", cont)
+		sect = fmt.Sprintf("%s-%d", fiberCoin.PoolSection, cont)
 		for _, sec := range sections {
 			if sec == sect {
 				find = true
@@ -278,6 +291,7 @@ func NewWalletNode(nodeAddress string) *WalletNode {
 	if err != nil {
 		return nil
 	}
+	RegisterFiberCoin(sect, fiberCoin)
 	return &WalletNode{
 		NodeAddress: nodeAddress,
 		poolSection: sect,
@@ -379,7 +393,7 @@ func (wlt *RemoteWallet) Sign(txn core.Transaction, signer core.TxnSigner, pwd c
 }
 
 func (wlt *RemoteWallet) signSkycoinTxn(txn core.Transaction, pwd core.PasswordReader, index []int) (core.Transaction, error) {
-	client, err := NewSkycoinApiClient(PoolSection)
+	client, err := NewSkycoinApiClient(sectionOrDefault(wlt.poolSection))
 	var password string = ""
 	if err != nil {
 		logWallet.WithError(err).Warn(err)
@@ -420,7 +434,7 @@ func (wlt *RemoteWallet) signSkycoinTxn(txn core.Transaction, pwd core.PasswordR
 		logWallet.WithError(err).Warn("Error signing transaction")
 		return nil, err
 	}
-	cTxn := NewSkycoinCreatedTransaction(txnResponse.Transaction)
+	cTxn := newSkycoinCreatedTransaction(txnResponse.Transaction, wlt.poolSection)
 	return cTxn, nil
 }
 
@@ -451,10 +465,11 @@ func (wlt *RemoteWallet) GetId() string {
 }
 
 func (wlt *RemoteWallet) Transfer(destination core.TransactionOutput, options core.KeyValueStore) (core.Transaction, error) {
+	fiberCoin := LookupFiberCoin(wlt.poolSection)
 	logWallet.Info("Transfer from remote wallet")
-	amount, err := destination.GetCoins(SkycoinTicker)
+	amount, err := destination.GetCoins(fiberCoin.Ticker)
 	if err != nil {
-		logWallet.WithError(err).Warnf("Couldn't retrieve %s to transfer", params.SkycoinTicker)
+		logWallet.WithError(err).Warnf("Couldn't retrieve %s to transfer", fiberCoin.Ticker)
 		return nil, err
 	}
 	to, err := destination.GetAddress()
@@ -465,12 +480,11 @@ func (wlt *RemoteWallet) Transfer(destination core.TransactionOutput, options co
 
 	var txnOutput SkycoinTransactionOutput
 	txnOutput.skyOut.Address = to.String()
-	quot, err := util.AltcoinQuotient(params.SkycoinTicker)
+	txnOutput.skyOut.Coins, err = util.FormatAmount(core.NewAmount(fiberCoin.Ticker, amount))
 	if err != nil {
-		logWallet.WithError(err).Warnf("Couldn't get quotient for %s", params.SkycoinTicker)
+		logWallet.WithError(err).Warnf("Couldn't format amount of %s", fiberCoin.Ticker)
 		return nil, err
 	}
-	txnOutput.skyOut.Coins = util.FormatCoins(amount, quot)
 	createTxnFunc := func(txnR *api.CreateTransactionRequest) (core.Transaction, error) {
 		logWallet.Info("Creating transaction for remote wallet")
 		var req api.WalletCreateTransactionRequest
@@ -490,16 +504,50 @@ func (wlt *RemoteWallet) Transfer(destination core.TransactionOutput, options co
 			return nil, err
 		}
 
-		return fromTxnResponse(txnResponse), nil
+		return fromTxnResponse(txnResponse, wlt.poolSection), nil
 	}
 
-	return createTransaction(nil, []core.TransactionOutput{&txnOutput}, nil, nil, options, createTxnFunc)
+	var from []core.Address
+	if needsSources(options) {
+		// Outputs of wallet addresses are looked up
+		iterAddr, err := wlt.GetLoadedAddresses()
+		if err != nil {
+			logWallet.WithError(err).Warn("Couldn't get loaded addresses")
+			return nil, err
+		}
+		for iterAddr.Next() {
+			from = append(from, iterAddr.Value())
+		}
+	}
+	from, unlocked, err := excludeLockedOutputs(wlt, from)
+	if err != nil {
+		return nil, err
+	}
+	txn, err := createTransaction(wlt.poolSection, from, []core.TransactionOutput{&txnOutput}, unlocked, nil, options, createTxnFunc)
+	if err != nil {
+		return nil, err
+	}
+	if err := reserveInputs(wlt, options, txn); err != nil {
+		return nil, err
+	}
+	return txn, nil
 }
 
 type createTxn func(*api.CreateTransactionRequest) (core.Transaction, error)
 
-func createTransaction(from []core.Address, to, uxOut []core.TransactionOutput, change core.Address, options core.KeyValueStore, createTxnFunc createTxn) (core.Transaction, error) {
+func createTransaction(poolSection string, from []core.Address, to, uxOut []core.TransactionOutput, change core.Address, options core.KeyValueStore, createTxnFunc createTxn) (core.Transaction, error) {
+	return createTransactionAt(poolSection, 0, from, to, uxOut, change, options, createTxnFunc)
+}
+
+// createTransactionAt requests transaction creation, coin hours computed by the wallet are projected
+// at time at, or at head block time if it is zero
+func createTransactionAt(poolSection string, at uint64, from []core.Address, to, uxOut []core.TransactionOutput, change core.Address, options core.KeyValueStore, createTxnFunc createTxn) (core.Transaction, error) {
 	logWallet.Info("Creating transaction...")
+	if err := GetNodeStatus(poolSection).CheckSend(); err != nil {
+		logWallet.WithError(err).Warn("Node not ready to send transactions")
+		return nil, err
+	}
+	fiberCoin := LookupFiberCoin(poolSection)
 	var req api.CreateTransactionRequest
 	req.IgnoreUnconfirmed = false
 
@@ -519,42 +567,43 @@ func createTransaction(from []core.Address, to, uxOut []core.TransactionOutput,
 		req.UxOuts = uxOuts
 	}
 
-	obj := options.GetValue("CoinHoursSelectionType")
+	obj := options.GetValue(TxnOptCoinHoursSelectionType)
 	coinHoursType, ok := obj.(string)
 	if !ok {
 		logWallet.WithError(nil).Warn("Couldn't get CoinHoursSelectionType")
 		return nil, errors.ErrInvalidOptions
 	}
-	obj = options.GetValue("BurnFactor")
+	obj = options.GetValue(TxnOptBurnFactor)
 
 	burnFactor, ok := obj.(string)
 	if !ok {
 		logWallet.WithError(nil).Warn("Couldn't get BurnFactor")
 		return nil, errors.ErrInvalidOptions
 	}
-	coinHoursSelection := api.HoursSelection{
-		Type: "manual",
-	}
-	if coinHoursType == "auto" {
-		coinHoursSelection.Type = "auto"
-		coinHoursSelection.Mode = "share"
-		coinHoursSelection.ShareFactor = burnFactor
+	coinHoursSelection, err := newHoursSelection(coinHoursType, burnFactor)
+	if err != nil {
+		logWallet.WithError(err).Warnf("Unknown coin hours selection %s", coinHoursType)
+		return nil, err
 	}
 	req.HoursSelection = coinHoursSelection
+	fixedHours, err := hoursPerDestination(coinHoursType, options)
+	if err != nil {
+		logWallet.WithError(err).Warn("Couldn't get HoursPerDestination")
+		return nil, err
+	}
 
 	destination := make([]api.Receiver, 0)
 	for _, out := range to {
-		skyV, err := out.GetCoins(Sky)
+		skyV, err := out.GetCoins(fiberCoin.Ticker)
 		if err != nil {
 			logWallet.WithError(err).Warn("Couldn't get Skycoin's")
 			return nil, err
 		}
-		quotient, err := util.AltcoinQuotient(Sky)
+		strAmount, err := util.FormatAmount(core.NewAmount(fiberCoin.Ticker, skyV))
 		if err != nil {
-			logWallet.WithError(err).Warn("Couldn't get Skycoin's quotient")
+			logWallet.WithError(err).Warn("Couldn't format Skycoin's")
 			return nil, err
 		}
-		strAmount := util.FormatCoins(skyV, quotient)
 		recv := api.Receiver{}
 		outAddr, err := out.GetAddress()
 		if err != nil {
@@ -563,19 +612,19 @@ func createTransaction(from []core.Address, to, uxOut []core.TransactionOutput,
 		}
 		recv.Address = outAddr.String()
 		recv.Coins = strAmount
-		if coinHoursSelection.Type == "manual" {
-			chV, err := out.GetCoins(CoinHour)
+		if coinHoursType == CoinHoursSelectionFixed {
+			recv.Hours = fixedHours
+		} else if coinHoursType == CoinHoursSelectionManual {
+			chV, err := out.GetCoins(fiberCoin.CoinHoursTicker)
 			if err != nil {
 				logWallet.WithError(err).Warn("Couldn't get CoinHours")
 				return nil, err
 			}
-			quotient, err = util.AltcoinQuotient(CoinHour)
+			recv.Hours, err = util.FormatAmount(core.NewAmount(fiberCoin.CoinHoursTicker, chV))
 			if err != nil {
-				logWallet.WithError(err).Warn("Couldn't get CoinHours quotient")
+				logWallet.WithError(err).Warn("Couldn't format CoinHours")
 				return nil, err
 			}
-
-			recv.Hours = util.FormatCoins(chV, quotient)
 		}
 		destination = append(destination, recv)
 	}
@@ -588,6 +637,17 @@ func createTransaction(from []core.Address, to, uxOut []core.TransactionOutput,
 		}
 	}
 
+	if isSendMax(options) {
+		if err := sendMax(poolSection, &req, at); err != nil {
+			return nil, err
+		}
+	}
+	if coinHoursType == CoinHoursSelectionProportional {
+		if err := distributeHours(poolSection, &req, at); err != nil {
+			return nil, err
+		}
+	}
+
 	return createTxnFunc(&req)
 
 }
@@ -613,10 +673,21 @@ func (wlt *RemoteWallet) SendFromAddress(from []core.Address, to []core.Transact
 			return nil, err
 		}
 
-		return fromTxnResponse(txnResponse), nil
+		return fromTxnResponse(txnResponse, wlt.poolSection), nil
 	}
 
-	return createTransaction(from, to, nil, change, options, createTxnFunc)
+	from, unlocked, err := excludeLockedOutputs(wlt, from)
+	if err != nil {
+		return nil, err
+	}
+	txn, err := createTransaction(wlt.poolSection, from, to, unlocked, change, options, createTxnFunc)
+	if err != nil {
+		return nil, err
+	}
+	if err := reserveInputs(wlt, options, txn); err != nil {
+		return nil, err
+	}
+	return txn, nil
 }
 
 func (wlt *RemoteWallet) Spend(unspent, new []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
@@ -639,10 +710,17 @@ func (wlt *RemoteWallet) Spend(unspent, new []core.TransactionOutput, change cor
 			return nil, err
 		}
 
-		return fromTxnResponse(txnResponse), nil
+		return fromTxnResponse(txnResponse, wlt.poolSection), nil
 	}
 
-	return createTransaction(nil, new, unspent, change, options, createTxnFunc)
+	txn, err := createTransaction(wlt.poolSection, nil, new, unspent, change, options, createTxnFunc)
+	if err != nil {
+		return nil, err
+	}
+	if err := reserveInputs(wlt, options, txn); err != nil {
+		return nil, err
+	}
+	return txn, nil
 }
 
 func (wlt *RemoteWallet) GenAddresses(addrType core.AddressType, startIndex, count uint32, pwd core.PasswordReader) core.AddressIterator {
@@ -672,7 +750,7 @@ func (wlt *RemoteWallet) GenAddresses(addrType core.AddressType, startIndex, cou
 	// FIXME: Lazy iterator wrapping wallet entries instead of copying to addresses slice
 	addresses := make([]core.Address, 0)
 	for _, entry := range wltR.Entries[startIndex:int(util.Min(len(wltR.Entries), int(startIndex+count)))] {
-		addresses = append(addresses, walletEntryToAddress(entry))
+		addresses = append(addresses, walletEntryToAddress(entry, wlt.poolSection))
 	}
 	// Checking if all the necessary addresses exists
 	if uint32(len(wltR.Entries)) < (startIndex + count) {
@@ -684,7 +762,7 @@ func (wlt *RemoteWallet) GenAddresses(addrType core.AddressType, startIndex, cou
 			return nil
 		}
 		for _, addr := range newAddrs {
-			skyAddrs, err := NewSkycoinAddress(addr)
+			skyAddrs, err := newSkycoinAddress(addr, wlt.poolSection)
 			if err != nil {
 				logWallet.WithError(err).Warningf("GenAddresses: Unable to parse address %s", skyAddrs.String())
 			} else if wlt.GetSkycoinWalletType() == wallet.WalletTypeBip44 {
@@ -719,7 +797,7 @@ func (wlt *RemoteWallet) GetLoadedAddresses() (core.AddressIterator, error) {
 	}
 	addresses := make([]core.Address, 0)
 	for _, entry := range wltR.Entries {
-		addresses = append(addresses, walletEntryToAddress(entry))
+		addresses = append(addresses, walletEntryToAddress(entry, wlt.poolSection))
 	}
 
 	return NewSkycoinAddressIterator(addresses), nil
@@ -805,9 +883,9 @@ func walletResponseToWallet(wltR api.WalletResponse) *RemoteWallet {
 	}
 }
 
-func walletEntryToAddress(wltE readable.WalletEntry) *SkycoinAddress {
+func walletEntryToAddress(wltE readable.WalletEntry, poolSection string) *SkycoinAddress {
 
-	skyAddrs, err := NewSkycoinAddress(wltE.Address)
+	skyAddrs, err := newSkycoinAddress(wltE.Address, poolSection)
 	if err != nil {
 		logWallet.WithError(err).Error("Invalid address in wallet entry")
 		return nil
@@ -822,10 +900,19 @@ func NewWalletDirectory(dirPath string) *WalletDirectory {
 	}
 }
 
+// newFiberWalletDirectory instantiates directory of wallets of SkyFiber coin served by nodes of connection pool section
+func newFiberWalletDirectory(dirPath, poolSection string) *WalletDirectory {
+	return &WalletDirectory{
+		WalletDir:   dirPath,
+		poolSection: poolSection,
+	}
+}
+
 type WalletDirectory struct {
 	// Implements WallentEnv interface
-	WalletDir  string
-	wltService *SkycoinLocalWallet
+	WalletDir   string
+	wltService  *SkycoinLocalWallet
+	poolSection string
 }
 
 func lookupWallet(env core.WalletEnv, firstAddr string) (core.Wallet, error) {
@@ -851,7 +938,7 @@ func (wltDir *WalletDirectory) LookupWallet(firstAddr string) (core.Wallet, erro
 func (wltDir *WalletDirectory) GetStorage() core.WalletStorage {
 	logWallet.Info("Getting storage from wallet directory")
 	if wltDir.wltService == nil {
-		wltDir.wltService = &SkycoinLocalWallet{wltDir.WalletDir}
+		wltDir.wltService = &SkycoinLocalWallet{walletDir: wltDir.WalletDir, poolSection: wltDir.poolSection}
 	}
 	return wltDir.wltService
 }
@@ -859,14 +946,15 @@ func (wltDir *WalletDirectory) GetStorage() core.WalletStorage {
 func (wltDir *WalletDirectory) GetWalletSet() core.WalletSet {
 	logWallet.Info("Getting wallet set from wallet directory")
 	if wltDir.wltService == nil {
-		wltDir.wltService = &SkycoinLocalWallet{wltDir.WalletDir}
+		wltDir.wltService = &SkycoinLocalWallet{walletDir: wltDir.WalletDir, poolSection: wltDir.poolSection}
 	}
 	return wltDir.wltService
 }
 
 // Implements WalletStorage and WalletSet interfaces
 type SkycoinLocalWallet struct {
-	walletDir string
+	walletDir   string
+	poolSection string
 }
 
 func (wltSrv *SkycoinLocalWallet) ListWallets() core.WalletIterator {
@@ -896,12 +984,13 @@ func (wltSrv *SkycoinLocalWallet) ListWallets() core.WalletIterator {
 				return nil
 			}
 			wallets = append(wallets, &LocalWallet{
-				Id:        name,
-				Label:     w.Label(),
-				Encrypted: w.IsEncrypted(),
-				Type:      w.Type(),
-				CoinType:  string(w.Coin()),
-				WalletDir: wltSrv.walletDir,
+				Id:          name,
+				Label:       w.Label(),
+				Encrypted:   w.IsEncrypted(),
+				Type:        w.Type(),
+				CoinType:    string(w.Coin()),
+				WalletDir:   wltSrv.walletDir,
+				poolSection: wltSrv.poolSection,
 			})
 		}
 		logWallet.Debug("Entry " + strconv.Itoa(i) + " finished")
@@ -921,12 +1010,13 @@ func (wltSrv *SkycoinLocalWallet) GetWallet(id string) core.Wallet {
 		return nil
 	}
 	return &LocalWallet{
-		Id:        id,
-		Label:     w.Label(),
-		Encrypted: w.IsEncrypted(),
-		Type:      w.Type(),
-		CoinType:  string(w.Coin()),
-		WalletDir: wltSrv.walletDir,
+		Id:          id,
+		Label:       w.Label(),
+		Encrypted:   w.IsEncrypted(),
+		Type:        w.Type(),
+		CoinType:    string(w.Coin()),
+		WalletDir:   wltSrv.walletDir,
+		poolSection: wltSrv.poolSection,
 	}
 }
 
@@ -951,11 +1041,15 @@ func (wltSrv *SkycoinLocalWallet) CreateWallet(label string, seed string, wltTyp
 		Type:     wltType,
 		Password: passwordByte,
 	}
+	if wltType == wallet.WalletTypeBip44 {
+		coinType := bip44.CoinType(LookupFiberCoin(wltSrv.poolSection).Bip44CoinType)
+		opts.Bip44Coin = &coinType
+	}
 	wltName := wltSrv.newUnicWalletFilename()
 	var wlt wallet.Wallet
 
 	if scanAddressesN > 0 {
-		wlt, err = wallet.NewWalletScanAhead(wltName, opts, &TransactionFinder{})
+		wlt, err = wallet.NewWalletScanAhead(wltName, opts, &TransactionFinder{poolSection: wltSrv.poolSection})
 		if err != nil {
 			logWallet.WithError(err).WithField("wltName", wltName).Error("Call to wallet.NewWalletScanAhead(wltName, opts, &TransactionFinder{}) inside CreateWallet failed")
 			return nil, err
@@ -974,12 +1068,13 @@ func (wltSrv *SkycoinLocalWallet) CreateWallet(label string, seed string, wltTyp
 	}
 
 	return &LocalWallet{
-		Id:        wltName,
-		Label:     wlt.Label(),
-		Encrypted: wlt.IsEncrypted(),
-		Type:      wlt.Type(),
-		CoinType:  string(wlt.Coin()),
-		WalletDir: wltSrv.walletDir,
+		Id:          wltName,
+		Label:       wlt.Label(),
+		Encrypted:   wlt.IsEncrypted(),
+		Type:        wlt.Type(),
+		CoinType:    string(wlt.Coin()),
+		WalletDir:   wltSrv.walletDir,
+		poolSection: wltSrv.poolSection,
 	}, nil
 }
 
@@ -996,6 +1091,24 @@ func (wltSrv *SkycoinLocalWallet) SupportedWalletTypes() []string {
 	}
 }
 
+// RemoveWallet deletes local wallet file along with its output locks
+func (wltSrv *SkycoinLocalWallet) RemoveWallet(id string) error {
+	logWallet.Info("Removing Skycoin local wallet")
+	if wltSrv.GetWallet(id) == nil {
+		return errors.ErrNotFound
+	}
+	path := filepath.Join(wltSrv.walletDir, id)
+	if err := os.Remove(path); err != nil {
+		logWallet.WithError(err).WithField("filename", path).Error("Couldn't remove wallet")
+		return err
+	}
+	if err := os.Remove(path + outputLocksExt); err != nil && !os.IsNotExist(err) {
+		logWallet.WithError(err).WithField("filename", path).Warn("Couldn't remove output locks")
+		return err
+	}
+	return nil
+}
+
 func (wltSrv *SkycoinLocalWallet) newUnicWalletFilename() string {
 	name := ""
 	for {
@@ -1096,6 +1209,7 @@ func (wltSrv *SkycoinLocalWallet) IsEncrypted(walletName string) (bool, error) {
 }
 
 type TransactionFinder struct {
+	poolSection string
 }
 
 func (tf *TransactionFinder) AddressesActivity(addresses []cipher.Address) ([]bool, error) {
@@ -1105,7 +1219,7 @@ func (tf *TransactionFinder) AddressesActivity(addresses []cipher.Address) ([]bo
 		addrs = append(addrs, addr.String())
 	}
 	answer := make([]bool, len(addrs))
-	c, err := NewSkycoinApiClient(PoolSection)
+	c, err := NewSkycoinApiClient(sectionOrDefault(tf.poolSection))
 	if err != nil {
 		logWallet.WithError(err).Error("Couldn't get API client")
 		return nil, err
@@ -1127,13 +1241,14 @@ func (tf *TransactionFinder) AddressesActivity(addresses []cipher.Address) ([]bo
 }
 
 type LocalWallet struct {
-	Id        string
-	Label     string
-	CoinType  string
-	Encrypted bool
-	Type      string
-	WalletDir string
-	balance   *util.BalanceSnapshot
+	Id          string
+	Label       string
+	CoinType    string
+	Encrypted   bool
+	Type        string
+	WalletDir   string
+	balance     *util.BalanceSnapshot
+	poolSection string
 }
 
 func (wlt *LocalWallet) Sign(txn core.Transaction, signer core.TxnSigner, pwd core.PasswordReader, index []string) (signedTxn core.Transaction, err error) {
@@ -1168,6 +1283,7 @@ func copyTransaction(txn *coin.Transaction) *coin.Transaction {
 }
 
 func (wlt *LocalWallet) signSkycoinTxn(txn core.Transaction, pwd core.PasswordReader, index []int) (core.Transaction, error) {
+	fiberCoin := LookupFiberCoin(wlt.poolSection)
 	var skyTxn *coin.Transaction
 	var err error
 	var uxouts []coin.UxOut
@@ -1219,20 +1335,20 @@ func (wlt *LocalWallet) signSkycoinTxn(txn core.Transaction, pwd core.PasswordRe
 			logWallet.WithError(err).Errorf("Error parsing transaction hash %s", cTxn.TxID)
 			return nil, err
 		}
-		tmpInt64, err := util.GetCoinValue(cTxn.Fee, params.CoinHoursTicker)
+		tmpInt64, err := util.GetCoinValue(cTxn.Fee, fiberCoin.CoinHoursTicker)
 		if err != nil {
 			logWallet.WithError(err).Errorf("Error parsing fee of TxID %s : %s", cTxn.TxID, cTxn.Fee)
 			return nil, err
 		}
 		txnFee = uint64(tmpInt64)
 		for i, cIn := range cTxn.In {
-			tmpInt64, err = util.GetCoinValue(cIn.Coins, params.SkycoinTicker)
+			tmpInt64, err = util.GetCoinValue(cIn.Coins, fiberCoin.Ticker)
 			if err != nil {
 				logWallet.WithError(err).Errorf("Error parsing coins of uxto %s : %s", cIn.UxID, cIn.Coins)
 				return nil, err
 			}
 			cInCoins := uint64(tmpInt64)
-			tmpInt64, err = util.GetCoinValue(cIn.Hours, params.CoinHoursTicker)
+			tmpInt64, err = util.GetCoinValue(cIn.Hours, fiberCoin.CoinHoursTicker)
 			if err != nil {
 				logWallet.WithError(err).Errorf("Error parsing hours of uxto %s : %s", cIn.UxID, cIn.Hours)
 				return nil, err
@@ -1268,7 +1384,7 @@ func (wlt *LocalWallet) signSkycoinTxn(txn core.Transaction, pwd core.PasswordRe
 		// Uninjected transactions
 		txnFee = unTxn.fee
 		skyTxn = copyTransaction(unTxn.txn)
-		clt, err := NewSkycoinApiClient(PoolSection)
+		clt, err := NewSkycoinApiClient(sectionOrDefault(wlt.poolSection))
 		if err != nil {
 			logWallet.WithError(err).Warn("Couldn't load skycoin wallet from local path")
 			return nil, err
@@ -1338,7 +1454,7 @@ func (wlt *LocalWallet) signSkycoinTxn(txn core.Transaction, pwd core.PasswordRe
 	if isReadableTxn {
 		vins := make([]visor.TransactionInput, 0)
 		for i, ux := range uxouts {
-			calCh, err := util.GetCoinValue(originalInputs[i].CalculatedHours, CoinHour)
+			calCh, err := util.GetCoinValue(originalInputs[i].CalculatedHours, fiberCoin.CoinHoursTicker)
 			if err != nil {
 				return nil, err
 			}
@@ -1361,12 +1477,14 @@ func (wlt *LocalWallet) signSkycoinTxn(txn core.Transaction, pwd core.PasswordRe
 			logWallet.WithError(err).Warn("Couldn't create an un SkycoinCreatedTransaction")
 			return nil, err
 		}
-		resultTxn = NewSkycoinCreatedTransaction(*crtTxn)
+		resultTxn = newSkycoinCreatedTransaction(*crtTxn, wlt.poolSection)
 	} else {
-		resultTxn, err = NewUninjectedTransaction(signedTxn, txnFee)
+		unTxn, err := NewUninjectedTransaction(signedTxn, txnFee)
 		if err != nil {
 			return nil, err
 		}
+		unTxn.poolSection = wlt.poolSection
+		resultTxn = unTxn
 	}
 	return resultTxn, nil
 
@@ -1400,38 +1518,41 @@ func (wlt *LocalWallet) SetLabel(wltName string) {
 
 }
 
-func fromTxnResponse(txnResponse *api.CreateTransactionResponse) *SkycoinCreatedTransaction {
-	return NewSkycoinCreatedTransaction(txnResponse.Transaction)
+func fromTxnResponse(txnResponse *api.CreateTransactionResponse, poolSection string) *SkycoinCreatedTransaction {
+	return newSkycoinCreatedTransaction(txnResponse.Transaction, poolSection)
 }
 
-func skyAPICreateTxn(txnReq *api.CreateTransactionRequest) (core.Transaction, error) {
-	client, err := NewSkycoinApiClient(PoolSection)
-	if err != nil {
-		logWallet.WithError(err).Warn("Couldn't load api client")
-		return nil, err
-	}
-	defer ReturnSkycoinClient(client)
-	txnR, err := client.CreateTransaction(*txnReq)
-	if err != nil {
-		logWallet.WithError(err).Warn("Couldn't create transaction")
-		return nil, err
+// skyAPICreateTxn creates transactions with nodes of connection pool section
+func skyAPICreateTxn(poolSection string) createTxn {
+	return func(txnReq *api.CreateTransactionRequest) (core.Transaction, error) {
+		client, err := NewSkycoinApiClient(sectionOrDefault(poolSection))
+		if err != nil {
+			logWallet.WithError(err).Warn("Couldn't load api client")
+			return nil, err
+		}
+		defer ReturnSkycoinClient(client)
+		txnR, err := client.CreateTransaction(*txnReq)
+		if err != nil {
+			logWallet.WithError(err).Warn("Couldn't create transaction")
+			return nil, err
+		}
+		return fromTxnResponse(txnR, poolSection), nil
 	}
-	return fromTxnResponse(txnR), nil
 }
 
 func (wlt *LocalWallet) Transfer(to core.TransactionOutput, options core.KeyValueStore) (core.Transaction, error) {
+	fiberCoin := LookupFiberCoin(wlt.poolSection)
 	logWallet.Info("Sending form local wallet")
-	quotient, err := util.AltcoinQuotient(Sky)
+	amount, err := to.GetCoins(fiberCoin.Ticker)
 	if err != nil {
-		logWallet.WithError(err).Warn("Couldn't get skycoin quotient")
+		logWallet.WithError(err).Warnf("Couldn't get ticker %s from TransactionOutput", fiberCoin.Ticker)
 		return nil, err
 	}
-	amount, err := to.GetCoins(params.SkycoinTicker)
+	strAmount, err := util.FormatAmount(core.NewAmount(fiberCoin.Ticker, amount))
 	if err != nil {
-		logWallet.WithError(err).Warnf("Couldn't get ticker %s from TransactionOutput", params.SkycoinTicker)
+		logWallet.WithError(err).Warn("Couldn't format skycoin amount")
 		return nil, err
 	}
-	strAmount := util.FormatCoins(amount, quotient)
 
 	var txnOutput SkycoinTransactionOutput
 	outAddr, err := to.GetAddress()
@@ -1451,14 +1572,25 @@ func (wlt *LocalWallet) Transfer(to core.TransactionOutput, options core.KeyValu
 		addresses = append(addresses, iterAddr.Value())
 	}
 
-	createTxnFunc := skyAPICreateTxn
-	return createTransaction(addresses, []core.TransactionOutput{&txnOutput}, nil, nil, options, createTxnFunc)
+	createTxnFunc := skyAPICreateTxn(wlt.poolSection)
+	addresses, unlocked, err := excludeLockedOutputs(wlt, addresses)
+	if err != nil {
+		return nil, err
+	}
+	txn, err := createTransaction(wlt.poolSection, addresses, []core.TransactionOutput{&txnOutput}, unlocked, nil, options, createTxnFunc)
+	if err != nil {
+		return nil, err
+	}
+	if err := reserveInputs(wlt, options, txn); err != nil {
+		return nil, err
+	}
+	return txn, nil
 }
 
 func (wlt LocalWallet) SendFromAddress(from []core.Address, to []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
 	logWallet.Info("Sending from addresses in local wallet")
 	createTxnFunc := func(txnReq *api.CreateTransactionRequest) (core.Transaction, error) {
-		client, err := NewSkycoinApiClient(PoolSection)
+		client, err := NewSkycoinApiClient(sectionOrDefault(wlt.poolSection))
 		if err != nil {
 			logWallet.WithError(err).Warn("Couldn't load api client")
 			return nil, err
@@ -1469,17 +1601,27 @@ func (wlt LocalWallet) SendFromAddress(from []core.Address, to []core.Transactio
 			logWallet.WithError(err).Warn("Couldn't create transaction")
 			return nil, err
 		}
-		return fromTxnResponse(txnR), nil
+		return fromTxnResponse(txnR, wlt.poolSection), nil
 
 	}
 
-	return createTransaction(from, to, nil, change, options, createTxnFunc)
-
+	from, unlocked, err := excludeLockedOutputs(&wlt, from)
+	if err != nil {
+		return nil, err
+	}
+	txn, err := createTransaction(wlt.poolSection, from, to, unlocked, change, options, createTxnFunc)
+	if err != nil {
+		return nil, err
+	}
+	if err := reserveInputs(&wlt, options, txn); err != nil {
+		return nil, err
+	}
+	return txn, nil
 }
 func (wlt LocalWallet) Spend(unspent, new []core.TransactionOutput, change core.Address, options core.KeyValueStore) (core.Transaction, error) {
 	logWallet.Info("Spending from local wallet")
 	createTxnFunc := func(txnReq *api.CreateTransactionRequest) (core.Transaction, error) {
-		client, err := NewSkycoinApiClient(PoolSection)
+		client, err := NewSkycoinApiClient(sectionOrDefault(wlt.poolSection))
 		if err != nil {
 			logWallet.WithError(err).Warn("Couldn't load api client")
 			return nil, err
@@ -1490,11 +1632,18 @@ func (wlt LocalWallet) Spend(unspent, new []core.TransactionOutput, change core.
 			logWallet.WithError(err).Warn("Couldn't load api client")
 			return nil, err
 		}
-		return fromTxnResponse(txnR), nil
+		return fromTxnResponse(txnR, wlt.poolSection), nil
 
 	}
 
-	return createTransaction(nil, new, unspent, change, options, createTxnFunc)
+	txn, err := createTransaction(wlt.poolSection, nil, new, unspent, change, options, createTxnFunc)
+	if err != nil {
+		return nil, err
+	}
+	if err := reserveInputs(&wlt, options, txn); err != nil {
+		return nil, err
+	}
+	return txn, nil
 }
 
 func (wlt *LocalWallet) GenAddresses(addrType core.AddressType, startIndex, count uint32, pwd core.PasswordReader) core.AddressIterator {
@@ -1620,7 +1769,7 @@ func (wlt *LocalWallet) GenAddresses(addrType core.AddressType, startIndex, coun
 	addrs := getAddrs(walletLoaded)
 	skyAddrs := make([]core.Address, 0)
 	for _, addr := range addrs {
-		newSkyAddrs, err := NewSkycoinAddress(addr.String())
+		newSkyAddrs, err := newSkycoinAddress(addr.String(), wlt.poolSection)
 		if err != nil {
 			logWallet.WithError(err).Warningf("GenAddresses: Unable to parse Skycoin address %s", addr.String())
 		} else if wlt.GetSkycoinWalletType() == wallet.WalletTypeBip44 {
@@ -1649,7 +1798,7 @@ func (wlt *LocalWallet) GetLoadedAddresses() (core.AddressIterator, error) {
 	addrs := make([]core.Address, 0)
 	addresses := walletLoaded.GetAddresses()
 	for _, addr := range addresses {
-		newSkyAddrs, err := NewSkycoinAddress(addr.String())
+		newSkyAddrs, err := newSkycoinAddress(addr.String(), wlt.poolSection)
 		if err != nil {
 			logWallet.WithError(err).Warningf("GetLoadedAddresses: Unable to parse Skycoin address %s", addr.String())
 		} else if wlt.GetSkycoinWalletType() == wallet.WalletTypeBip44 {
@@ -1742,6 +1891,7 @@ func (wlt *LocalWallet) GetSignerDescription() (string, error) {
 // Typoe assertions
 var (
 	_ core.Wallet            = &LocalWallet{}
+	_ core.WalletRemover     = &SkycoinLocalWallet{}
 	_ core.Wallet            = &RemoteWallet{}
 	_ skytypes.SkycoinWallet = &LocalWallet{}
 	_ skytypes.SkycoinWallet = &RemoteWallet{}
@@ -0,0 +1,238 @@
+package nodeclient
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+)
+
+var (
+	// ErrInsecureCredentials credentials file can be read by users other than its owner
+	ErrInsecureCredentials = errors.New("Node credentials file must be accessible only by its owner")
+	// ErrInvalidCABundle CA bundle contains no PEM certificate
+	ErrInvalidCABundle = errors.New("No certificate found in CA bundle")
+	// ErrInvalidFingerprint certificate fingerprint is not a hex encoded SHA-256 hash
+	ErrInvalidFingerprint = errors.New("Certificate fingerprint must be a hex encoded SHA-256 hash")
+	// ErrFingerprintMismatch node certificate does not match pinned fingerprint
+	ErrFingerprintMismatch = errors.New("Node certificate does not match pinned fingerprint")
+	// ErrUnsupportedProxy proxy URL is not a SOCKS5 one
+	ErrUnsupportedProxy = errors.New("Only socks5:// proxies are supported")
+	// ErrOnionWithoutProxy onion hosts can not be reached without a proxy
+	ErrOnionWithoutProxy = errors.New("Onion hosts can only be reached through a proxy")
+
+	globalProxy      *url.URL
+	globalProxyMutex sync.RWMutex
+)
+
+// DirectProxy proxy setting of nodes reached directly, ignoring global proxy
+const DirectProxy = "direct"
+
+// parseProxy validates SOCKS5 proxy URL, e.g. `socks5://127.0.0.1:9050`.
+// Host names are always resolved by the proxy, so that Tor onion hosts can be reached.
+func parseProxy(proxy string) (*url.URL, error) {
+	if proxy == "" || proxy == DirectProxy {
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		return nil, err
+	}
+	switch proxyURL.Scheme {
+	case "socks5":
+	case "socks5h":
+		proxyURL.Scheme = "socks5"
+	default:
+		return nil, ErrUnsupportedProxy
+	}
+	return proxyURL, nil
+}
+
+// SetGlobalProxy sets SOCKS5 proxy used to reach nodes without a proxy of their own
+func SetGlobalProxy(proxy string) error {
+	proxyURL, err := parseProxy(proxy)
+	if err != nil {
+		return err
+	}
+	globalProxyMutex.Lock()
+	defer globalProxyMutex.Unlock()
+	globalProxy = proxyURL
+	return nil
+}
+
+func resolveProxy(proxy string) (*url.URL, error) {
+	if proxy != "" {
+		return parseProxy(proxy)
+	}
+	globalProxyMutex.RLock()
+	defer globalProxyMutex.RUnlock()
+	return globalProxy, nil
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Credentials authenticate requests to private nodes.
+// They are kept in a file of their own instead of the plaintext configuration.
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	// Token sent as bearer token, takes precedence over username and password
+	Token string `json:"token"`
+}
+
+// LoadCredentials reads credentials from JSON file, which must not be accessible by other users
+func LoadCredentials(path string) (Credentials, error) {
+	var creds Credentials
+	info, err := os.Stat(path)
+	if err != nil {
+		return creds, err
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return creds, ErrInsecureCredentials
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return creds, err
+	}
+	err = json.Unmarshal(data, &creds)
+	return creds, err
+}
+
+// Options customize how node is reached
+type Options struct {
+	Credentials Credentials
+	// CAFile path to PEM bundle of certificate authorities trusted instead of system ones
+	CAFile string
+	// Fingerprint hex encoded SHA-256 hash of node certificate, colons allowed
+	Fingerprint string
+	// Timeout of requests, client default if zero
+	Timeout time.Duration
+	// Proxy SOCKS5 proxy URL, global proxy if empty, none if set to DirectProxy
+	Proxy string
+}
+
+// parseFingerprint decodes SHA-256 fingerprint such as `AB:CD:...` or `abcd...`
+func parseFingerprint(fingerprint string) ([]byte, error) {
+	digest, err := hex.DecodeString(strings.Replace(fingerprint, ":", "", -1))
+	if err != nil || len(digest) != sha256.Size {
+		return nil, ErrInvalidFingerprint
+	}
+	return digest, nil
+}
+
+func newTLSConfig(opts Options) (*tls.Config, error) {
+	if opts.CAFile == "" && opts.Fingerprint == "" {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{}
+	if opts.CAFile != "" {
+		pem, err := ioutil.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, ErrInvalidCABundle
+		}
+	}
+	if opts.Fingerprint != "" {
+		pin, err := parseFingerprint(opts.Fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		// Pin is checked in addition to regular chain verification
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return ErrFingerprintMismatch
+			}
+			digest := sha256.Sum256(rawCerts[0])
+			if !bytes.Equal(digest[:], pin) {
+				return ErrFingerprintMismatch
+			}
+			return nil
+		}
+	}
+	return tlsConfig, nil
+}
+
+// bearerTransport adds bearer token to requests
+type bearerTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+// RoundTrip sends request authenticated by bearer token
+func (bt *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	authReq := new(http.Request)
+	*authReq = *req
+	authReq.Header = make(http.Header, len(req.Header)+1)
+	for k, v := range req.Header {
+		authReq.Header[k] = v
+	}
+	authReq.Header.Set("Authorization", "Bearer "+bt.token)
+	return bt.base.RoundTrip(authReq)
+}
+
+// NewClient instantiates Skycoin REST API client of node at nodeAddr customized by options
+func NewClient(nodeAddr string, opts Options) (*api.Client, error) {
+	nodeURL, err := url.Parse(nodeAddr)
+	if err != nil {
+		return nil, err
+	}
+	proxyURL, err := resolveProxy(opts.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	if proxyURL == nil && strings.HasSuffix(nodeURL.Hostname(), ".onion") {
+		return nil, ErrOnionWithoutProxy
+	}
+	tlsConfig, err := newTLSConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	c := api.NewClient(nodeAddr)
+	transport, isHTTPTransport := c.HTTPClient.Transport.(*http.Transport)
+	if !isHTTPTransport {
+		transport = &http.Transport{}
+	}
+	transport.TLSClientConfig = tlsConfig
+	if proxyURL != nil {
+		// Queries to local nodes do not reveal anything
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			if isLoopback(req.URL.Hostname()) {
+				return nil, nil
+			}
+			return proxyURL, nil
+		}
+	}
+	c.HTTPClient.Transport = transport
+	if opts.Credentials.Token != "" {
+		c.HTTPClient.Transport = &bearerTransport{token: opts.Credentials.Token, base: transport}
+	} else if opts.Credentials.Username != "" || opts.Credentials.Password != "" {
+		c.SetAuth(opts.Credentials.Username, opts.Credentials.Password)
+	}
+	if opts.Timeout > 0 {
+		c.HTTPClient.Timeout = opts.Timeout
+	}
+	return c, nil
+}
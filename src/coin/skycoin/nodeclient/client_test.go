@@ -0,0 +1,267 @@
+package nodeclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/stretchr/testify/require"
+)
+
+type testNode struct {
+	*httptest.Server
+	dir    string
+	auth   string
+	delay  time.Duration
+	caFile string
+}
+
+func newTestNode(t *testing.T) *testNode {
+	dir, err := ioutil.TempDir("", "nodeclient")
+	require.NoError(t, err)
+	node := &testNode{dir: dir}
+	node.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		node.auth = r.Header.Get("Authorization")
+		time.Sleep(node.delay)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(readable.BlockchainProgress{Current: 42})
+	}))
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: node.Certificate().Raw})
+	node.caFile = filepath.Join(dir, "ca.pem")
+	require.NoError(t, ioutil.WriteFile(node.caFile, certPEM, 0644))
+	return node
+}
+
+func (node *testNode) Close() {
+	node.Server.Close()
+	os.RemoveAll(node.dir)
+}
+
+func (node *testNode) fingerprint() string {
+	digest := sha256.Sum256(node.Certificate().Raw)
+	return hex.EncodeToString(digest[:])
+}
+
+func TestNewClientCustomCA(t *testing.T) {
+	node := newTestNode(t)
+	defer node.Close()
+
+	// Self-signed node certificate is not trusted by default
+	c, err := NewClient(node.URL, Options{})
+	require.NoError(t, err)
+	_, err = c.BlockchainProgress()
+	require.Error(t, err)
+
+	c, err = NewClient(node.URL, Options{CAFile: node.caFile})
+	require.NoError(t, err)
+	progress, err := c.BlockchainProgress()
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), progress.Current)
+
+	invalidCA := filepath.Join(node.dir, "invalid.pem")
+	require.NoError(t, ioutil.WriteFile(invalidCA, []byte("not a certificate"), 0644))
+	_, err = NewClient(node.URL, Options{CAFile: invalidCA})
+	require.Equal(t, ErrInvalidCABundle, err)
+}
+
+func TestNewClientFingerprint(t *testing.T) {
+	node := newTestNode(t)
+	defer node.Close()
+
+	c, err := NewClient(node.URL, Options{CAFile: node.caFile, Fingerprint: node.fingerprint()})
+	require.NoError(t, err)
+	_, err = c.BlockchainProgress()
+	require.NoError(t, err)
+
+	otherDigest := sha256.Sum256([]byte("other certificate"))
+	c, err = NewClient(node.URL, Options{CAFile: node.caFile, Fingerprint: hex.EncodeToString(otherDigest[:])})
+	require.NoError(t, err)
+	_, err = c.BlockchainProgress()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), ErrFingerprintMismatch.Error())
+
+	_, err = NewClient(node.URL, Options{Fingerprint: "AB:CD"})
+	require.Equal(t, ErrInvalidFingerprint, err)
+}
+
+func TestNewClientAuth(t *testing.T) {
+	node := newTestNode(t)
+	defer node.Close()
+
+	c, err := NewClient(node.URL, Options{CAFile: node.caFile, Credentials: Credentials{Username: "user", Password: "pass"}})
+	require.NoError(t, err)
+	_, err = c.BlockchainProgress()
+	require.NoError(t, err)
+	require.Equal(t, "Basic dXNlcjpwYXNz", node.auth)
+
+	c, err = NewClient(node.URL, Options{CAFile: node.caFile, Credentials: Credentials{Token: "token"}})
+	require.NoError(t, err)
+	_, err = c.BlockchainProgress()
+	require.NoError(t, err)
+	require.Equal(t, "Bearer token", node.auth)
+}
+
+func TestNewClientTimeout(t *testing.T) {
+	node := newTestNode(t)
+	defer node.Close()
+	node.delay = 200 * time.Millisecond
+
+	c, err := NewClient(node.URL, Options{CAFile: node.caFile, Timeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+	_, err = c.BlockchainProgress()
+	require.Error(t, err)
+}
+
+func TestLoadCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nodeclient")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "credentials.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"username":"user","password":"pass"}`), 0600))
+
+	creds, err := LoadCredentials(path)
+	require.NoError(t, err)
+	require.Equal(t, Credentials{Username: "user", Password: "pass"}, creds)
+
+	require.NoError(t, os.Chmod(path, 0644))
+	_, err = LoadCredentials(path)
+	require.Equal(t, ErrInsecureCredentials, err)
+}
+
+// testProxy SOCKS5 proxy relaying every CONNECT request to target
+type testProxy struct {
+	net.Listener
+	target string
+	hosts  chan string
+}
+
+func newTestProxy(t *testing.T, target string) *testProxy {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	proxy := &testProxy{Listener: l, target: target, hosts: make(chan string, 16)}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go proxy.serve(conn)
+		}
+	}()
+	return proxy
+}
+
+func (proxy *testProxy) URL() string {
+	return "socks5://" + proxy.Addr().String()
+}
+
+func (proxy *testProxy) serve(conn net.Conn) {
+	defer conn.Close()
+	// Greeting, no authentication
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return
+	}
+	if _, err := io.ReadFull(conn, make([]byte, header[1])); err != nil {
+		return
+	}
+	if _, err := conn.Write([]byte{5, 0}); err != nil {
+		return
+	}
+	// CONNECT request, domain names only
+	req := make([]byte, 5)
+	if _, err := io.ReadFull(conn, req); err != nil || req[3] != 3 {
+		return
+	}
+	rest := make([]byte, int(req[4])+2)
+	if _, err := io.ReadFull(conn, rest); err != nil {
+		return
+	}
+	port := int(rest[len(rest)-2])<<8 | int(rest[len(rest)-1])
+	proxy.hosts <- net.JoinHostPort(string(rest[:req[4]]), strconv.Itoa(port))
+	upstream, err := net.Dial("tcp", proxy.target)
+	if err != nil {
+		return
+	}
+	defer upstream.Close()
+	if _, err := conn.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0}); err != nil {
+		return
+	}
+	go func() {
+		_, _ = io.Copy(upstream, conn)
+	}()
+	_, _ = io.Copy(conn, upstream)
+}
+
+// newOnionNode plain HTTP node behind proxy, as onion services usually are
+func newOnionNode(t *testing.T) (*httptest.Server, *testProxy) {
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(readable.BlockchainProgress{Current: 42})
+	}))
+	return node, newTestProxy(t, node.Listener.Addr().String())
+}
+
+const onionURL = "http://skycoinnode.onion:6420"
+
+func TestNewClientProxy(t *testing.T) {
+	node, proxy := newOnionNode(t)
+	defer node.Close()
+	defer proxy.Close()
+
+	_, err := NewClient(onionURL, Options{})
+	require.Equal(t, ErrOnionWithoutProxy, err)
+
+	// Host name is resolved by proxy
+	c, err := NewClient(onionURL, Options{Proxy: proxy.URL()})
+	require.NoError(t, err)
+	progress, err := c.BlockchainProgress()
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), progress.Current)
+	require.Equal(t, "skycoinnode.onion:6420", <-proxy.hosts)
+
+	_, err = NewClient(onionURL, Options{Proxy: "http://127.0.0.1:8080"})
+	require.Equal(t, ErrUnsupportedProxy, err)
+}
+
+func TestGlobalProxy(t *testing.T) {
+	onionNode, proxy := newOnionNode(t)
+	defer onionNode.Close()
+	defer proxy.Close()
+	node := newTestNode(t)
+	defer node.Close()
+
+	require.Equal(t, ErrUnsupportedProxy, SetGlobalProxy("http://127.0.0.1:8080"))
+	require.NoError(t, SetGlobalProxy(proxy.URL()))
+	defer func() {
+		require.NoError(t, SetGlobalProxy(""))
+	}()
+
+	c, err := NewClient(onionURL, Options{})
+	require.NoError(t, err)
+	_, err = c.BlockchainProgress()
+	require.NoError(t, err)
+	require.Equal(t, "skycoinnode.onion:6420", <-proxy.hosts)
+
+	_, err = NewClient(onionURL, Options{Proxy: DirectProxy})
+	require.Equal(t, ErrOnionWithoutProxy, err)
+
+	// Local nodes are reached directly
+	c, err = NewClient(node.URL, Options{CAFile: node.caFile})
+	require.NoError(t, err)
+	_, err = c.BlockchainProgress()
+	require.NoError(t, err)
+	require.Empty(t, proxy.hosts)
+}
@@ -0,0 +1,197 @@
+package params
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	skyparams "github.com/SkycoinProject/skycoin/src/params"
+)
+
+var (
+	// ErrInvalidFiberCoin fiber coin definition is incomplete or inconsistent
+	ErrInvalidFiberCoin = errors.New("Invalid SkyFiber coin definition")
+	// ErrUnsupportedAddressVersion address version not supported by SkyFiber cipher
+	ErrUnsupportedAddressVersion = errors.New("Unsupported SkyFiber address version")
+	// ErrDuplicateFiberCoin two fiber coins share tickers or connection pool section
+	ErrDuplicateFiberCoin = errors.New("Duplicate SkyFiber coin definition")
+)
+
+// FiberCoinDistribution coin supply and distribution addresses of a fiber coin
+type FiberCoinDistribution struct {
+	MaxCoinSupply        uint64   `json:"max_coin_supply"`
+	InitialUnlockedCount uint64   `json:"initial_unlocked_count"`
+	UnlockAddressRate    uint64   `json:"unlock_address_rate"`
+	UnlockTimeInterval   uint64   `json:"unlock_time_interval"`
+	Addresses            []string `json:"addresses"`
+}
+
+// FiberCoinConfig entry of SkyFiber coins configuration file
+type FiberCoinConfig struct {
+	Name             string                `json:"name"`
+	Description      string                `json:"description"`
+	Ticker           string                `json:"ticker"`
+	CoinHoursName    string                `json:"coin_hours_name"`
+	CoinHoursTicker  string                `json:"coin_hours_ticker"`
+	AddressVersion   byte                  `json:"address_version"`
+	Bip44CoinType    *uint32               `json:"bip44_coin_type"`
+	NodeURL          string                `json:"node_url"`
+	Node             map[string]string     `json:"node"`
+	GenesisAddress   string                `json:"genesis_address"`
+	GenesisBlockHash string                `json:"genesis_block_hash"`
+	ShareFactor      string                `json:"share_factor"`
+	BurnFactor       uint32                `json:"burn_factor"`
+	MaxTxnSize       uint32                `json:"max_transaction_size"`
+	MaxDropletPrec   *uint8                `json:"max_droplet_precision"`
+	Distribution     FiberCoinDistribution `json:"distribution"`
+	WalletDir        string                `json:"wallet_dir"`
+}
+
+// FiberCoinsFile contents of SkyFiber coins configuration file
+type FiberCoinsFile struct {
+	Coins []FiberCoinConfig `json:"coins"`
+}
+
+func invalidFiberCoin(name, reason string) error {
+	return fmt.Errorf("%v %s: %s", ErrInvalidFiberCoin, name, reason)
+}
+
+// ToParams validates fiber coin definition and converts it into coin params.
+// Empty wallet directory defaults to `.<name>/wallets` under homeDir.
+// BIP44 coin type defaults to the one registered for Skycoin.
+// Burn factor, transaction size limit and droplet precision default to those of Skycoin nodes.
+// Node settings share keys with Skycoin node settings, except for the address given by node URL.
+func (fc FiberCoinConfig) ToParams(homeDir string) (SkyFiberParams, error) {
+	if fc.Name == "" || fc.Ticker == "" || fc.CoinHoursTicker == "" {
+		return SkyFiberParams{}, invalidFiberCoin(fc.Name, "name, ticker and coin hours ticker are mandatory")
+	}
+	if fc.Ticker == fc.CoinHoursTicker {
+		return SkyFiberParams{}, invalidFiberCoin(fc.Name, "coin and coin hours tickers must differ")
+	}
+	if fc.AddressVersion != 0 {
+		return SkyFiberParams{}, ErrUnsupportedAddressVersion
+	}
+	nodeURL, err := url.Parse(fc.NodeURL)
+	if err != nil || (nodeURL.Scheme != "http" && nodeURL.Scheme != "https") || nodeURL.Host == "" {
+		return SkyFiberParams{}, invalidFiberCoin(fc.Name, "node URL must be an absolute HTTP(S) URL")
+	}
+	if _, err := cipher.DecodeBase58Address(fc.GenesisAddress); err != nil {
+		return SkyFiberParams{}, invalidFiberCoin(fc.Name, "genesis address "+err.Error())
+	}
+	if fc.GenesisBlockHash != "" {
+		if _, err := cipher.SHA256FromHex(fc.GenesisBlockHash); err != nil {
+			return SkyFiberParams{}, invalidFiberCoin(fc.Name, "genesis block hash "+err.Error())
+		}
+	}
+	shareFactor := fc.ShareFactor
+	if shareFactor == "" {
+		shareFactor = DefaultShareFactor
+	}
+	if sf, err := strconv.ParseFloat(shareFactor, 64); err != nil || sf < 0 || sf > 1 {
+		return SkyFiberParams{}, invalidFiberCoin(fc.Name, "share factor must be a decimal number in [0, 1]")
+	}
+	verifyTxn := skyparams.UserVerifyTxn
+	if fc.BurnFactor != 0 {
+		verifyTxn.BurnFactor = fc.BurnFactor
+	}
+	if fc.MaxTxnSize != 0 {
+		verifyTxn.MaxTransactionSize = fc.MaxTxnSize
+	}
+	if fc.MaxDropletPrec != nil {
+		verifyTxn.MaxDropletPrecision = *fc.MaxDropletPrec
+	}
+	if err := verifyTxn.Validate(); err != nil {
+		return SkyFiberParams{}, invalidFiberCoin(fc.Name, err.Error())
+	}
+	distribution := skyparams.Distribution{
+		MaxCoinSupply:        fc.Distribution.MaxCoinSupply,
+		InitialUnlockedCount: fc.Distribution.InitialUnlockedCount,
+		UnlockAddressRate:    fc.Distribution.UnlockAddressRate,
+		UnlockTimeInterval:   fc.Distribution.UnlockTimeInterval,
+		Addresses:            fc.Distribution.Addresses,
+	}
+	if len(distribution.Addresses) == 0 {
+		return SkyFiberParams{}, invalidFiberCoin(fc.Name, "distribution addresses are mandatory")
+	}
+	if err := distribution.Validate(); err != nil {
+		return SkyFiberParams{}, invalidFiberCoin(fc.Name, "distribution "+err.Error())
+	}
+	bip44CoinType := uint32(SkycoinBip44CoinType)
+	if fc.Bip44CoinType != nil {
+		bip44CoinType = *fc.Bip44CoinType
+	}
+	walletDir := fc.WalletDir
+	if walletDir == "" {
+		walletDir = DefaultWalletDir(homeDir, fc.Name)
+	}
+	return SkyFiberParams{
+		Name:                  fc.Name,
+		Description:           fc.Description,
+		Ticker:                fc.Ticker,
+		Accuracy:              SkycoinAccuracy,
+		CoinHoursName:         fc.CoinHoursName,
+		CoinHoursTicker:       fc.CoinHoursTicker,
+		CalculatedHoursTicker: fc.CoinHoursTicker + CalculatedHoursSuffix,
+		AddressVersion:        fc.AddressVersion,
+		Bip44CoinType:         bip44CoinType,
+		NodeAddress:           fc.NodeURL,
+		NodeSettings:          fc.Node,
+		GenesisAddress:        fc.GenesisAddress,
+		GenesisBlockHash:      fc.GenesisBlockHash,
+		ShareFactor:           shareFactor,
+		VerifyTxn:             verifyTxn,
+		Distribution:          distribution,
+		PoolSection:           SkycoinPoolSection + "." + strings.ToLower(fc.Ticker),
+		WalletDir:             walletDir,
+	}, nil
+}
+
+// DefaultWalletDir local wallets directory of fiber coin under homeDir
+func DefaultWalletDir(homeDir, name string) string {
+	dirName := "." + strings.ToLower(strings.Join(strings.Fields(name), ""))
+	return filepath.Join(homeDir, dirName, "wallets")
+}
+
+// ParseFiberCoins decodes SkyFiber coins configuration file contents
+func ParseFiberCoins(data []byte, homeDir string) ([]SkyFiberParams, error) {
+	var file FiberCoinsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{
+		SkycoinTicker:         true,
+		CoinHoursTicker:       true,
+		CalculatedHoursTicker: true,
+		SkycoinPoolSection:    true,
+	}
+	coins := make([]SkyFiberParams, 0, len(file.Coins))
+	for _, fc := range file.Coins {
+		p, err := fc.ToParams(homeDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range []string{p.Ticker, p.CoinHoursTicker, p.CalculatedHoursTicker, p.PoolSection} {
+			if seen[key] {
+				return nil, ErrDuplicateFiberCoin
+			}
+			seen[key] = true
+		}
+		coins = append(coins, p)
+	}
+	return coins, nil
+}
+
+// LoadFiberCoins reads SkyFiber coins configuration file
+func LoadFiberCoins(path, homeDir string) ([]SkyFiberParams, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFiberCoins(data, homeDir)
+}
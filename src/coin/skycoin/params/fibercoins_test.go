@@ -0,0 +1,145 @@
+package params
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	skyparams "github.com/SkycoinProject/skycoin/src/params"
+	"github.com/stretchr/testify/require"
+)
+
+const fiberCoinsJSON = `{
+	"coins": [
+		{
+			"name": "Fiber Test",
+			"ticker": "FTC",
+			"coin_hours_name": "Fiber Hours",
+			"coin_hours_ticker": "FTH",
+			"node_url": "http://127.0.0.1:6421",
+			"node": {"maxRetries": "5", "sendGating": "warn"},
+			"genesis_address": "2jBbGxZRGoQG1mqhPBnXnLTxK6oxsTf8os6",
+			"genesis_block_hash": "0551a1e5af999fe8fff529f6f2ab341e1e33db95135eef1b2be44fe6981349f3",
+			"share_factor": "0.25",
+			"burn_factor": 4,
+			"max_droplet_precision": 0,
+			"distribution": {
+				"max_coin_supply": 100000000,
+				"initial_unlocked_count": 2,
+				"unlock_address_rate": 1,
+				"unlock_time_interval": 31536000,
+				"addresses": ["R6aHqKWSQfvpdo2fGSrq4F1RYXkBWR9HHJ", "2EYM4WFHe4Dgz6kjAdUkM6Etep7ruz2ia6h"]
+			}
+		}
+	]
+}`
+
+func TestParseFiberCoins(t *testing.T) {
+	coins, err := ParseFiberCoins([]byte(fiberCoinsJSON), "/home/fiber")
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	p := coins[0]
+	require.Equal(t, "Fiber Test", p.Name)
+	require.Equal(t, "FTC", p.Ticker)
+	require.Equal(t, "FTH", p.CoinHoursTicker)
+	require.Equal(t, "FTH#ACC", p.CalculatedHoursTicker)
+	require.Equal(t, uint64(SkycoinAccuracy), p.Accuracy)
+	require.Equal(t, "http://127.0.0.1:6421", p.NodeAddress)
+	require.Equal(t, map[string]string{"maxRetries": "5", "sendGating": "warn"}, p.NodeSettings)
+	require.Equal(t, "0.25", p.ShareFactor)
+	require.Equal(t, uint32(4), p.VerifyTxn.BurnFactor)
+	require.Equal(t, skyparams.UserVerifyTxn.MaxTransactionSize, p.VerifyTxn.MaxTransactionSize)
+	require.Zero(t, p.VerifyTxn.MaxDropletPrecision)
+	require.Equal(t, uint32(SkycoinBip44CoinType), p.Bip44CoinType)
+	require.Equal(t, uint64(100000000), p.Distribution.MaxCoinSupply)
+	require.Len(t, p.Distribution.Addresses, 2)
+	require.Equal(t, "skycoin.ftc", p.PoolSection)
+	require.Equal(t, filepath.Join("/home/fiber", ".fibertest", "wallets"), p.WalletDir)
+}
+
+func TestParseFiberCoinsInvalid(t *testing.T) {
+	valid := FiberCoinConfig{
+		Name:            "Fiber Test",
+		Ticker:          "FTC",
+		CoinHoursTicker: "FTH",
+		NodeURL:         "https://fiber.example.com",
+		GenesisAddress:  "2jBbGxZRGoQG1mqhPBnXnLTxK6oxsTf8os6",
+		Distribution: FiberCoinDistribution{
+			MaxCoinSupply: 10,
+			Addresses:     []string{"R6aHqKWSQfvpdo2fGSrq4F1RYXkBWR9HHJ"},
+		},
+	}
+	p, err := valid.ToParams("/home/fiber")
+	require.NoError(t, err)
+	require.Equal(t, DefaultShareFactor, p.ShareFactor)
+	require.Equal(t, skyparams.UserVerifyTxn, p.VerifyTxn)
+	coinType := uint32(0x80000fff)
+	valid.Bip44CoinType = &coinType
+	p, err = valid.ToParams("/home/fiber")
+	require.NoError(t, err)
+	require.Equal(t, coinType, p.Bip44CoinType)
+	valid.Bip44CoinType = nil
+
+	tests := []struct {
+		name   string
+		mutate func(fc *FiberCoinConfig)
+		err    error
+	}{
+		{"missing ticker", func(fc *FiberCoinConfig) { fc.Ticker = "" }, nil},
+		{"same tickers", func(fc *FiberCoinConfig) { fc.CoinHoursTicker = fc.Ticker }, nil},
+		{"address version", func(fc *FiberCoinConfig) { fc.AddressVersion = 1 }, ErrUnsupportedAddressVersion},
+		{"relative node URL", func(fc *FiberCoinConfig) { fc.NodeURL = "fiber.example.com" }, nil},
+		{"genesis address", func(fc *FiberCoinConfig) { fc.GenesisAddress = "invalid" }, nil},
+		{"genesis hash", func(fc *FiberCoinConfig) { fc.GenesisBlockHash = "00" }, nil},
+		{"share factor", func(fc *FiberCoinConfig) { fc.ShareFactor = "2" }, nil},
+		{"burn factor", func(fc *FiberCoinConfig) { fc.BurnFactor = 1 }, nil},
+		{"transaction size", func(fc *FiberCoinConfig) { fc.MaxTxnSize = 100 }, nil},
+		{"no distribution", func(fc *FiberCoinConfig) { fc.Distribution.Addresses = nil }, nil},
+		{"uneven supply", func(fc *FiberCoinConfig) {
+			fc.Distribution.Addresses = append(fc.Distribution.Addresses, "2EYM4WFHe4Dgz6kjAdUkM6Etep7ruz2ia6h")
+			fc.Distribution.MaxCoinSupply = 11
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fc := valid
+			fc.Distribution.Addresses = append([]string(nil), valid.Distribution.Addresses...)
+			tt.mutate(&fc)
+			_, err := fc.ToParams("/home/fiber")
+			require.Error(t, err)
+			if tt.err != nil {
+				require.Equal(t, tt.err, err)
+			}
+		})
+	}
+}
+
+func TestParseFiberCoinsDuplicates(t *testing.T) {
+	_, err := ParseFiberCoins([]byte(`{"coins": [{"name": "Clone", "ticker": "SKY"}]}`), "")
+	require.Error(t, err)
+
+	fc := `{"name": "Fiber %[1]s", "ticker": "FT%[1]s", "coin_hours_ticker": "FTH", "node_url": "http://127.0.0.1:6421",
+		"genesis_address": "2jBbGxZRGoQG1mqhPBnXnLTxK6oxsTf8os6",
+		"distribution": {"max_coin_supply": 1, "addresses": ["R6aHqKWSQfvpdo2fGSrq4F1RYXkBWR9HHJ"]}}`
+	data := `{"coins": [` + fmt.Sprintf(fc, "A") + `, ` + fmt.Sprintf(fc, "B") + `]}`
+	_, err = ParseFiberCoins([]byte(data), "")
+	require.Equal(t, ErrDuplicateFiberCoin, err)
+}
+
+func TestLoadFiberCoins(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fibercoins")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fibercoins.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(fiberCoinsJSON), 0600))
+
+	coins, err := LoadFiberCoins(path, dir)
+	require.NoError(t, err)
+	require.Len(t, coins, 1)
+	require.Equal(t, filepath.Join(dir, ".fibertest", "wallets"), coins[0].WalletDir)
+
+	_, err = LoadFiberCoins(filepath.Join(dir, "missing.json"), dir)
+	require.Error(t, err)
+}
@@ -4,13 +4,63 @@ import (
 	skyparams "github.com/SkycoinProject/skycoin/src/params"
 )
 
+// SkyFiberParams describes a coin running on top of SkyFiber consensus
 type SkyFiberParams struct {
+	// Name human readable name of the coin
+	Name string
+	// Description verbose explanation of the coin
+	Description string
+	// Ticker identifies coin asset
+	Ticker string
+	// Accuracy number of decimal places of coin amounts
+	Accuracy uint64
+	// CoinHoursName human readable name of coin hours
+	CoinHoursName string
+	// CoinHoursTicker identifies coin hours asset
+	CoinHoursTicker string
+	// CalculatedHoursTicker identifies accumulated coin hours
+	CalculatedHoursTicker string
+	// AddressVersion prefix byte of coin addresses
+	AddressVersion byte
+	// Bip44CoinType coin_type segment of BIP44 derivation paths
+	Bip44CoinType uint32
+	// NodeAddress URL of the REST API of a node of the coin network
+	NodeAddress string
+	// NodeSettings resilience, authentication, TLS, mirrors and send gating settings of coin nodes
+	NodeSettings map[string]string
+	// GenesisAddress address receiving coins in genesis block
+	GenesisAddress string
+	// GenesisBlockHash hash of the genesis block
+	GenesisBlockHash string
+	// ShareFactor share of coin hours sent to destinations by default when selected automatically
+	ShareFactor string
+	// VerifyTxn burn factor, size limit and droplet precision enforced by coin nodes on transactions
+	VerifyTxn skyparams.VerifyTxn
+	// Distribution coin supply and distribution addresses
 	Distribution skyparams.Distribution
+	// PoolSection connection pool section used to talk to coin nodes
+	PoolSection string
+	// WalletDir directory storing local wallets of the coin
+	WalletDir string
 }
 
 var (
 	SkycoinMainNetParams = SkyFiberParams{
-		Distribution: skyparams.MainNetDistribution,
+		Name:                  SkycoinName,
+		Description:           SkycoinDescription,
+		Ticker:                SkycoinTicker,
+		Accuracy:              SkycoinAccuracy,
+		CoinHoursName:         CoinHoursName,
+		CoinHoursTicker:       CoinHoursTicker,
+		CalculatedHoursTicker: CalculatedHoursTicker,
+		AddressVersion:        0,
+		Bip44CoinType:         SkycoinBip44CoinType,
+		GenesisAddress:        "2jBbGxZRGoQG1mqhPBnXnLTxK6oxsTf8os6",
+		GenesisBlockHash:      "0551a1e5af999fe8fff529f6f2ab341e1e33db95135eef1b2be44fe6981349f3",
+		ShareFactor:           DefaultShareFactor,
+		VerifyTxn:             skyparams.UserVerifyTxn,
+		Distribution:          skyparams.MainNetDistribution,
+		PoolSection:           SkycoinPoolSection,
 	}
 )
 
@@ -24,6 +74,12 @@ const (
 	SkycoinFamily = "SkyFiber"
 	// SkycoinDescription verbose explanaitiion of Skycoin
 	SkycoinDescription = "Skycoin is an entire cryptocurrency ecosystem aimed at eliminating mining rewards, developing energy-efficient custom hardware, speeding up transaction confirmation times, and the advancement of a more secure and private Internet"
+	// SkycoinAccuracy number of decimal places of SkyFiber coin amounts
+	SkycoinAccuracy = 6
+	// SkycoinBip44CoinType coin_type segment of BIP44 derivation paths registered for Skycoin
+	SkycoinBip44CoinType = 8000
+	// SkycoinPoolSection connection pool section used to talk to Skycoin nodes
+	SkycoinPoolSection = "skycoin"
 	// CoinHoursTicker internal identifier to refer to Skycoin coin hours
 	CoinHoursTicker            = "SCH"
 	// CoinHoursName is the readable name for coin hours
@@ -36,4 +92,8 @@ const (
 	CalculatedHoursName = "Calculated Hours"
 	// CalculatedHoursDescription verbose explanaitiion of accumulated coin hours
 	CalculatedHoursDescription = "Calculated Hours are Coin Hours calculated considering the time since an output was created"
+	// CalculatedHoursSuffix appended to coin hours ticker to refer to accumulated coin hours
+	CalculatedHoursSuffix = "#ACC"
+	// DefaultShareFactor share of coin hours sent to destinations by default
+	DefaultShareFactor = "0.5"
 )
@@ -0,0 +1,285 @@
+package quorum
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/resilience"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logQuorum = logging.MustGetLogger("Skycoin node quorum")
+
+// MaxDisagreements number of most recent disagreements kept
+const MaxDisagreements = 100
+
+// Node client of one of the nodes reads are cross-checked against
+type Node struct {
+	URL string
+	API skytypes.SkycoinAPI
+}
+
+// Disagreement nodes gave different answers to a cross-checked read
+type Disagreement struct {
+	Time   time.Time
+	Method string
+	Arg    string
+	// Answers summary of answer given by each node indexed by node URL
+	Answers map[string]string
+	// Agreed answer given by quorum, empty if none
+	Agreed string
+}
+
+// BroadcastReport outcome of injecting transaction into every node
+type BroadcastReport struct {
+	TxID string
+	// Accepted URLs of nodes accepting transaction
+	Accepted []string
+	// Rejected errors of nodes refusing transaction or unreachable indexed by node URL
+	Rejected map[string]error
+}
+
+// Broadcaster injects transactions into several nodes
+type Broadcaster interface {
+	// Broadcast injects raw transaction into every node, failing only if none accepted it
+	Broadcast(rawTxn string) (*BroadcastReport, error)
+}
+
+// Quorum cross-checks reads that matter (balance, spent status, confirmation)
+// against several nodes and injects transactions into all of them.
+// Disagreements found by every client it wraps are kept together.
+type Quorum struct {
+	size int
+
+	mutex         sync.Mutex
+	disagreements []Disagreement
+}
+
+// New instantiates quorum of size nodes, a majority of nodes if size is zero
+func New(size int) *Quorum {
+	return &Quorum{size: size}
+}
+
+// Wrap combines clients of several nodes into one.
+// Calls not cross-checked are answered by first node.
+func (q *Quorum) Wrap(nodes []Node) skytypes.SkycoinAPI {
+	return &quorumAPI{SkycoinAPI: nodes[0].API, nodes: nodes, quorum: q}
+}
+
+// Disagreements returns most recent disagreements, oldest first
+func (q *Quorum) Disagreements() []Disagreement {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return append([]Disagreement(nil), q.disagreements...)
+}
+
+func (q *Quorum) report(d Disagreement) {
+	logQuorum.WithField("method", d.Method).WithField("arg", d.Arg).WithField("answers", d.Answers).
+		WithField("agreed", d.Agreed).Warn("Nodes disagree")
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.disagreements = append(q.disagreements, d)
+	if len(q.disagreements) > MaxDisagreements {
+		q.disagreements = q.disagreements[len(q.disagreements)-MaxDisagreements:]
+	}
+}
+
+// required number of agreeing nodes out of n
+func (q *Quorum) required(n int) int {
+	if q.size <= 0 || q.size > n {
+		return n/2 + 1
+	}
+	return q.size
+}
+
+// answer of a node to a call
+type answer struct {
+	res interface{}
+	err error
+	// key summarizes what matters in answer, equal keys mean agreement
+	key string
+}
+
+// quorumAPI decorates SkycoinAPI clients of several nodes
+type quorumAPI struct {
+	skytypes.SkycoinAPI
+	nodes  []Node
+	quorum *Quorum
+}
+
+// all calls every node concurrently
+func (qa *quorumAPI) all(call func(skytypes.SkycoinAPI) (interface{}, error)) []answer {
+	answers := make([]answer, len(qa.nodes))
+	var wg sync.WaitGroup
+	for i, node := range qa.nodes {
+		wg.Add(1)
+		go func(i int, skyApi skytypes.SkycoinAPI) {
+			defer wg.Done()
+			answers[i].res, answers[i].err = call(skyApi)
+		}(i, node.API)
+	}
+	wg.Wait()
+	return answers
+}
+
+// crossCheck returns first answer given by quorum nodes.
+// Unreachable nodes do not vote, yet they count when computing quorum size.
+func (qa *quorumAPI) crossCheck(method, arg string, call func(skytypes.SkycoinAPI) (interface{}, error), key func(interface{}) string) (interface{}, error) {
+	answers := qa.all(call)
+	votes := make(map[string]int)
+	summaries := make(map[string]string, len(answers))
+	var firstUnavailable error
+	best := ""
+	for i := range answers {
+		a := &answers[i]
+		url := qa.nodes[i].URL
+		if a.err != nil {
+			if resilience.Classify(a.err) == fce.ErrNodeUnavailable {
+				if firstUnavailable == nil {
+					firstUnavailable = a.err
+				}
+				summaries[url] = "unavailable: " + a.err.Error()
+				continue
+			}
+			a.key = "error: " + a.err.Error()
+		} else {
+			a.key = key(a.res)
+		}
+		summaries[url] = a.key
+		votes[a.key]++
+		if best == "" || votes[a.key] > votes[best] {
+			best = a.key
+		}
+	}
+	required := qa.quorum.required(len(qa.nodes))
+	agreed := votes[best] >= required
+	if len(votes) > 1 {
+		d := Disagreement{Time: time.Now(), Method: method, Arg: arg, Answers: summaries}
+		if agreed {
+			d.Agreed = best
+		}
+		qa.quorum.report(d)
+	}
+	if !agreed {
+		if len(votes) <= 1 {
+			// Not enough nodes reachable to tell
+			return nil, firstUnavailable
+		}
+		return nil, fce.ErrNodesDisagree
+	}
+	for _, a := range answers {
+		if a.key == best {
+			return a.res, a.err
+		}
+	}
+	return nil, fce.ErrNodesDisagree
+}
+
+// Balance Get balance of addresses, confirmed balance cross-checked
+func (qa *quorumAPI) Balance(addrs []string) (*api.BalanceResponse, error) {
+	res, err := qa.crossCheck("Balance", strings.Join(addrs, ","), func(skyApi skytypes.SkycoinAPI) (interface{}, error) {
+		return skyApi.Balance(addrs)
+	}, func(res interface{}) string {
+		bal := res.(*api.BalanceResponse)
+		return fmt.Sprintf("%d coins %d hours", bal.Confirmed.Coins, bal.Confirmed.Hours)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*api.BalanceResponse), nil
+}
+
+// UxOut Get uxout, spent status cross-checked
+func (qa *quorumAPI) UxOut(uxID string) (*readable.SpentOutput, error) {
+	res, err := qa.crossCheck("UxOut", uxID, func(skyApi skytypes.SkycoinAPI) (interface{}, error) {
+		return skyApi.UxOut(uxID)
+	}, func(res interface{}) string {
+		out := res.(*readable.SpentOutput)
+		if out.SpentBlockSeq == 0 {
+			return "unspent"
+		}
+		return "spent by " + out.SpentTxnID
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*readable.SpentOutput), nil
+}
+
+// Transaction Get transaction info by id, confirmation cross-checked
+func (qa *quorumAPI) Transaction(txid string) (*readable.TransactionWithStatus, error) {
+	res, err := qa.crossCheck("Transaction", txid, func(skyApi skytypes.SkycoinAPI) (interface{}, error) {
+		return skyApi.Transaction(txid)
+	}, func(res interface{}) string {
+		txn := res.(*readable.TransactionWithStatus)
+		if !txn.Status.Confirmed {
+			return "pending"
+		}
+		return fmt.Sprintf("confirmed in block %d", txn.Status.BlockSeq)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*readable.TransactionWithStatus), nil
+}
+
+// fanOut injects transaction into every node
+func (qa *quorumAPI) fanOut(inject func(skytypes.SkycoinAPI) (interface{}, error)) (*BroadcastReport, error) {
+	answers := qa.all(inject)
+	report := &BroadcastReport{Rejected: make(map[string]error)}
+	var firstErr error
+	for i, a := range answers {
+		url := qa.nodes[i].URL
+		if a.err != nil {
+			logQuorum.WithError(a.err).WithField("url", url).Warn("Node did not accept transaction")
+			report.Rejected[url] = a.err
+			if firstErr == nil {
+				firstErr = a.err
+			}
+			continue
+		}
+		report.Accepted = append(report.Accepted, url)
+		if report.TxID == "" {
+			report.TxID = a.res.(string)
+		}
+	}
+	if len(report.Accepted) == 0 {
+		return report, firstErr
+	}
+	logQuorum.WithField("txid", report.TxID).WithField("accepted", report.Accepted).Info("Transaction broadcast")
+	return report, nil
+}
+
+// Broadcast injects raw transaction into every node, failing only if none accepted it
+func (qa *quorumAPI) Broadcast(rawTxn string) (*BroadcastReport, error) {
+	return qa.fanOut(func(skyApi skytypes.SkycoinAPI) (interface{}, error) {
+		return skyApi.InjectEncodedTransaction(rawTxn)
+	})
+}
+
+// InjectTransaction Inject transaction into every node
+func (qa *quorumAPI) InjectTransaction(txn *coin.Transaction) (string, error) {
+	report, err := qa.fanOut(func(skyApi skytypes.SkycoinAPI) (interface{}, error) {
+		return skyApi.InjectTransaction(txn)
+	})
+	return report.TxID, err
+}
+
+// InjectEncodedTransaction Inject raw transaction into every node
+func (qa *quorumAPI) InjectEncodedTransaction(rawTxn string) (string, error) {
+	report, err := qa.Broadcast(rawTxn)
+	return report.TxID, err
+}
+
+// Type assertions
+var (
+	_ skytypes.SkycoinAPI = &quorumAPI{}
+	_ Broadcaster         = &quorumAPI{}
+)
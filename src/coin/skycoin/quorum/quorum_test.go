@@ -0,0 +1,125 @@
+package quorum
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/resilience"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skymocks"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func mockNodes(n int) ([]*skymocks.SkycoinAPI, []Node) {
+	mocks := make([]*skymocks.SkycoinAPI, n)
+	nodes := make([]Node, n)
+	for i := range mocks {
+		mocks[i] = new(skymocks.SkycoinAPI)
+		nodes[i] = Node{URL: string('a' + rune(i)), API: mocks[i]}
+	}
+	return mocks, nodes
+}
+
+func balance(coins uint64) *api.BalanceResponse {
+	bal := &api.BalanceResponse{}
+	bal.Confirmed.Coins = coins
+	return bal
+}
+
+func TestCrossCheckMajority(t *testing.T) {
+	mocks, nodes := mockNodes(3)
+	addrs := []string{"addr"}
+	mocks[0].On("Balance", addrs).Return(balance(5), nil)
+	mocks[1].On("Balance", addrs).Return(balance(7), nil)
+	mocks[2].On("Balance", addrs).Return(balance(7), nil)
+	q := New(0)
+
+	bal, err := q.Wrap(nodes).Balance(addrs)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), bal.Confirmed.Coins)
+	disagreements := q.Disagreements()
+	require.Len(t, disagreements, 1)
+	require.Equal(t, "Balance", disagreements[0].Method)
+	require.Equal(t, "7 coins 0 hours", disagreements[0].Agreed)
+	require.Equal(t, "5 coins 0 hours", disagreements[0].Answers["a"])
+
+	// Nodes agreeing are not reported
+	mocks[0].On("UxOut", "uxid").Return(&readable.SpentOutput{Uxid: "uxid"}, nil)
+	mocks[1].On("UxOut", "uxid").Return(&readable.SpentOutput{Uxid: "uxid"}, nil)
+	mocks[2].On("UxOut", "uxid").Return(&readable.SpentOutput{Uxid: "uxid"}, nil)
+	_, err = q.Wrap(nodes).UxOut("uxid")
+	require.NoError(t, err)
+	require.Len(t, q.Disagreements(), 1)
+}
+
+func TestCrossCheckNoQuorum(t *testing.T) {
+	mocks, nodes := mockNodes(3)
+	confirmed := &readable.TransactionWithStatus{Status: readable.TransactionStatus{Confirmed: true, BlockSeq: 4}}
+	pending := &readable.TransactionWithStatus{}
+	mocks[0].On("Transaction", "txid").Return(confirmed, nil)
+	mocks[1].On("Transaction", "txid").Return(pending, nil)
+	mocks[2].On("Transaction", "txid").Return(nil, resilience.ErrCircuitOpen)
+	q := New(0)
+
+	_, err := q.Wrap(nodes).Transaction("txid")
+	require.Equal(t, fce.ErrNodesDisagree, err)
+	disagreements := q.Disagreements()
+	require.Len(t, disagreements, 1)
+	require.Empty(t, disagreements[0].Agreed)
+	require.Equal(t, "confirmed in block 4", disagreements[0].Answers["a"])
+	require.Contains(t, disagreements[0].Answers["c"], "unavailable")
+}
+
+func TestCrossCheckUnavailable(t *testing.T) {
+	mocks, nodes := mockNodes(3)
+	addrs := []string{"addr"}
+	mocks[0].On("Balance", addrs).Return(nil, resilience.ErrCircuitOpen)
+	mocks[1].On("Balance", addrs).Return(balance(7), nil)
+	mocks[2].On("Balance", addrs).Return(balance(7), nil)
+	q := New(0)
+
+	// Unreachable nodes don't vote
+	bal, err := q.Wrap(nodes).Balance(addrs)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), bal.Confirmed.Coins)
+	require.Empty(t, q.Disagreements())
+
+	// Quorum can't be reached
+	_, err = New(3).Wrap(nodes).Balance(addrs)
+	require.Equal(t, resilience.ErrCircuitOpen, err)
+}
+
+func TestBroadcast(t *testing.T) {
+	mocks, nodes := mockNodes(3)
+	rejected := errors.New("Rejected")
+	mocks[0].On("InjectEncodedTransaction", "rawtxn").Return("txid", nil)
+	mocks[1].On("InjectEncodedTransaction", "rawtxn").Return("", rejected)
+	mocks[2].On("InjectEncodedTransaction", "rawtxn").Return("txid", nil)
+	skyApi := New(0).Wrap(nodes)
+
+	report, err := skyApi.(Broadcaster).Broadcast("rawtxn")
+	require.NoError(t, err)
+	require.Equal(t, "txid", report.TxID)
+	sort.Strings(report.Accepted)
+	require.Equal(t, []string{"a", "c"}, report.Accepted)
+	require.Equal(t, map[string]error{"b": rejected}, report.Rejected)
+
+	txid, err := skyApi.InjectEncodedTransaction("rawtxn")
+	require.NoError(t, err)
+	require.Equal(t, "txid", txid)
+}
+
+func TestBroadcastRejected(t *testing.T) {
+	mocks, nodes := mockNodes(2)
+	rejected := errors.New("Rejected")
+	mocks[0].On("InjectEncodedTransaction", "rawtxn").Return("", rejected)
+	mocks[1].On("InjectEncodedTransaction", "rawtxn").Return("", resilience.ErrCircuitOpen)
+
+	report, err := New(0).Wrap(nodes).(Broadcaster).Broadcast("rawtxn")
+	require.Error(t, err)
+	require.Empty(t, report.Accepted)
+	require.Len(t, report.Rejected, 2)
+}
@@ -0,0 +1,381 @@
+package replay
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logReplay = logging.MustGetLogger("Skycoin replay")
+
+// Redacted replaces secrets in recorded calls
+const Redacted = "REDACTED"
+
+// Entry records a single SkycoinAPI call and its outcome
+type Entry struct {
+	Seq    uint64          `json:"seq"`
+	Method string          `json:"method"`
+	Args   json.RawMessage `json:"args"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *ErrorEntry     `json:"error,omitempty"`
+}
+
+// ErrorEntry records error returned by a SkycoinAPI call
+type ErrorEntry struct {
+	Message    string `json:"message"`
+	Status     string `json:"status,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+}
+
+func newErrorEntry(err error) *ErrorEntry {
+	if err == nil {
+		return nil
+	}
+	if clientErr, isClientErr := err.(api.ClientError); isClientErr {
+		return &ErrorEntry{Message: clientErr.Message, Status: clientErr.Status, StatusCode: clientErr.StatusCode}
+	}
+	return &ErrorEntry{Message: err.Error()}
+}
+
+// Recorder writes calls made to SkycoinAPI clients as a stream of JSON entries.
+// Passwords, seeds and extended public keys are redacted before being written.
+type Recorder struct {
+	mutex  sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+	seq    uint64
+}
+
+// NewRecorder instantiates recorder writing entries to w
+func NewRecorder(w io.Writer) *Recorder {
+	rec := &Recorder{enc: json.NewEncoder(w)}
+	if closer, isCloser := w.(io.Closer); isCloser {
+		rec.closer = closer
+	}
+	return rec
+}
+
+// CreateRecorder instantiates recorder writing entries to file, truncating it if it exists
+func CreateRecorder(path string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return NewRecorder(f), nil
+}
+
+// Close closes underlying writer
+func (rec *Recorder) Close() error {
+	if rec.closer == nil {
+		return nil
+	}
+	return rec.closer.Close()
+}
+
+// Wrap decorates client so that its calls are recorded
+func (rec *Recorder) Wrap(skyApi skytypes.SkycoinAPI) skytypes.SkycoinAPI {
+	return &recordingAPI{api: skyApi, rec: rec}
+}
+
+func (rec *Recorder) record(method string, args []interface{}, result interface{}, err error) {
+	argsBytes, mErr := json.Marshal(args)
+	if mErr != nil {
+		logReplay.WithError(mErr).WithField("method", method).Error("Couldn't encode call arguments")
+		return
+	}
+	entry := Entry{Method: method, Args: argsBytes, Error: newErrorEntry(err)}
+	if err == nil && result != nil {
+		if entry.Result, mErr = json.Marshal(result); mErr != nil {
+			logReplay.WithError(mErr).WithField("method", method).Error("Couldn't encode call result")
+			return
+		}
+	}
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+	rec.seq++
+	entry.Seq = rec.seq
+	if wErr := rec.enc.Encode(entry); wErr != nil {
+		logReplay.WithError(wErr).WithField("method", method).Error("Couldn't write call record")
+	}
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return Redacted
+}
+
+func redactWallet(wr *api.WalletResponse) *api.WalletResponse {
+	if wr == nil || wr.Meta.XPub == "" {
+		return wr
+	}
+	redacted := *wr
+	redacted.Meta.XPub = Redacted
+	return &redacted
+}
+
+func redactWallets(wrs []api.WalletResponse) []api.WalletResponse {
+	redacted := make([]api.WalletResponse, len(wrs))
+	for i := range wrs {
+		redacted[i] = *redactWallet(&wrs[i])
+	}
+	return redacted
+}
+
+func createWalletArgs(o api.CreateWalletOptions) []interface{} {
+	o.Seed = redact(o.Seed)
+	o.SeedPassphrase = redact(o.SeedPassphrase)
+	o.Password = redact(o.Password)
+	o.XPub = redact(o.XPub)
+	return []interface{}{o}
+}
+
+func walletSignTransactionArgs(req api.WalletSignTransactionRequest) []interface{} {
+	req.Password = redact(req.Password)
+	return []interface{}{req}
+}
+
+func walletCreateTransactionArgs(req api.WalletCreateTransactionRequest) []interface{} {
+	req.Password = redact(req.Password)
+	return []interface{}{req}
+}
+
+func injectTransactionArgs(txn *coin.Transaction) []interface{} {
+	if txn == nil {
+		return []interface{}{nil}
+	}
+	rawTxn, err := txn.SerializeHex()
+	if err != nil {
+		return []interface{}{txn}
+	}
+	return []interface{}{rawTxn}
+}
+
+// recordingAPI decorates SkycoinAPI client recording every call
+type recordingAPI struct {
+	api skytypes.SkycoinAPI
+	rec *Recorder
+}
+
+// Transaction Get transaction info by id
+func (ra *recordingAPI) Transaction(txid string) (*readable.TransactionWithStatus, error) {
+	res, err := ra.api.Transaction(txid)
+	ra.rec.record("Transaction", []interface{}{txid}, res, err)
+	return res, err
+}
+
+// Transactions Get transactions for addresses
+func (ra *recordingAPI) Transactions(addrs []string) ([]readable.TransactionWithStatus, error) {
+	res, err := ra.api.Transactions(addrs)
+	ra.rec.record("Transactions", []interface{}{addrs}, res, err)
+	return res, err
+}
+
+// TransactionVerbose Get transaction info by id. Include spent input data
+func (ra *recordingAPI) TransactionVerbose(txid string) (*readable.TransactionWithStatusVerbose, error) {
+	res, err := ra.api.TransactionVerbose(txid)
+	ra.rec.record("TransactionVerbose", []interface{}{txid}, res, err)
+	return res, err
+}
+
+// TransactionsVerbose Get transactions for addresses. Include spent input data
+func (ra *recordingAPI) TransactionsVerbose(addrs []string) ([]readable.TransactionWithStatusVerbose, error) {
+	res, err := ra.api.TransactionsVerbose(addrs)
+	ra.rec.record("TransactionsVerbose", []interface{}{addrs}, res, err)
+	return res, err
+}
+
+// UxOut Get uxout
+func (ra *recordingAPI) UxOut(uxID string) (*readable.SpentOutput, error) {
+	res, err := ra.api.UxOut(uxID)
+	ra.rec.record("UxOut", []interface{}{uxID}, res, err)
+	return res, err
+}
+
+// PendingTransactionsVerbose Get unconfirmed transactions
+func (ra *recordingAPI) PendingTransactionsVerbose() ([]readable.UnconfirmedTransactionVerbose, error) {
+	res, err := ra.api.PendingTransactionsVerbose()
+	ra.rec.record("PendingTransactionsVerbose", []interface{}{}, res, err)
+	return res, err
+}
+
+// CoinSupply Determine coin supply
+func (ra *recordingAPI) CoinSupply() (*api.CoinSupply, error) {
+	res, err := ra.api.CoinSupply()
+	ra.rec.record("CoinSupply", []interface{}{}, res, err)
+	return res, err
+}
+
+// LastBlocks Get last N blocks
+func (ra *recordingAPI) LastBlocks(n uint64) (*readable.Blocks, error) {
+	res, err := ra.api.LastBlocks(n)
+	ra.rec.record("LastBlocks", []interface{}{n}, res, err)
+	return res, err
+}
+
+// BlockchainProgress Get blockchain progress
+func (ra *recordingAPI) BlockchainProgress() (*readable.BlockchainProgress, error) {
+	res, err := ra.api.BlockchainProgress()
+	ra.rec.record("BlockchainProgress", []interface{}{}, res, err)
+	return res, err
+}
+
+// BlockBySeq Get block by sequence number
+func (ra *recordingAPI) BlockBySeq(seq uint64) (*readable.Block, error) {
+	res, err := ra.api.BlockBySeq(seq)
+	ra.rec.record("BlockBySeq", []interface{}{seq}, res, err)
+	return res, err
+}
+
+// BlockByHash Get block by header hash
+func (ra *recordingAPI) BlockByHash(hash string) (*readable.Block, error) {
+	res, err := ra.api.BlockByHash(hash)
+	ra.rec.record("BlockByHash", []interface{}{hash}, res, err)
+	return res, err
+}
+
+// Health Get node health, version and coin identity
+func (ra *recordingAPI) Health() (*api.HealthResponse, error) {
+	res, err := ra.api.Health()
+	ra.rec.record("Health", []interface{}{}, res, err)
+	return res, err
+}
+
+// Balance Get balance of addresses
+func (ra *recordingAPI) Balance(addrs []string) (*api.BalanceResponse, error) {
+	res, err := ra.api.Balance(addrs)
+	ra.rec.record("Balance", []interface{}{addrs}, res, err)
+	return res, err
+}
+
+// OutputsForAddresses Get historical unspent outputs for an address
+func (ra *recordingAPI) OutputsForAddresses(addrs []string) (*readable.UnspentOutputsSummary, error) {
+	res, err := ra.api.OutputsForAddresses(addrs)
+	ra.rec.record("OutputsForAddresses", []interface{}{addrs}, res, err)
+	return res, err
+}
+
+// Wallet Get wallet
+func (ra *recordingAPI) Wallet(id string) (*api.WalletResponse, error) {
+	res, err := ra.api.Wallet(id)
+	ra.rec.record("Wallet", []interface{}{id}, redactWallet(res), err)
+	return res, err
+}
+
+// UpdateWallet Change wallet label
+func (ra *recordingAPI) UpdateWallet(id, label string) error {
+	err := ra.api.UpdateWallet(id, label)
+	ra.rec.record("UpdateWallet", []interface{}{id, label}, nil, err)
+	return err
+}
+
+// NewWalletAddress Generate new address in wallet
+func (ra *recordingAPI) NewWalletAddress(id string, n int, password string) ([]string, error) {
+	res, err := ra.api.NewWalletAddress(id, n, password)
+	ra.rec.record("NewWalletAddress", []interface{}{id, n, redact(password)}, res, err)
+	return res, err
+}
+
+// Wallets Get wallets
+func (ra *recordingAPI) Wallets() ([]api.WalletResponse, error) {
+	res, err := ra.api.Wallets()
+	ra.rec.record("Wallets", []interface{}{}, redactWallets(res), err)
+	return res, err
+}
+
+// CreateWallet Create wallet
+func (ra *recordingAPI) CreateWallet(o api.CreateWalletOptions) (*api.WalletResponse, error) {
+	res, err := ra.api.CreateWallet(o)
+	ra.rec.record("CreateWallet", createWalletArgs(o), redactWallet(res), err)
+	return res, err
+}
+
+// EncryptWallet Encrypt wallet
+func (ra *recordingAPI) EncryptWallet(id, password string) (*api.WalletResponse, error) {
+	res, err := ra.api.EncryptWallet(id, password)
+	ra.rec.record("EncryptWallet", []interface{}{id, redact(password)}, redactWallet(res), err)
+	return res, err
+}
+
+// DecryptWallet Decrypt wallet
+func (ra *recordingAPI) DecryptWallet(id, password string) (*api.WalletResponse, error) {
+	res, err := ra.api.DecryptWallet(id, password)
+	ra.rec.record("DecryptWallet", []interface{}{id, redact(password)}, redactWallet(res), err)
+	return res, err
+}
+
+// WalletBalance Get wallet balance
+func (ra *recordingAPI) WalletBalance(id string) (*api.BalanceResponse, error) {
+	res, err := ra.api.WalletBalance(id)
+	ra.rec.record("WalletBalance", []interface{}{id}, res, err)
+	return res, err
+}
+
+// WalletUnconfirmedTransactionsVerbose Get unconfirmed transactions of a wallet
+func (ra *recordingAPI) WalletUnconfirmedTransactionsVerbose(id string) (*api.UnconfirmedTxnsVerboseResponse, error) {
+	res, err := ra.api.WalletUnconfirmedTransactionsVerbose(id)
+	ra.rec.record("WalletUnconfirmedTransactionsVerbose", []interface{}{id}, res, err)
+	return res, err
+}
+
+// NetworkConnections Get a list of all connections
+func (ra *recordingAPI) NetworkConnections(filters *api.NetworkConnectionsFilter) (*api.Connections, error) {
+	res, err := ra.api.NetworkConnections(filters)
+	ra.rec.record("NetworkConnections", []interface{}{filters}, res, err)
+	return res, err
+}
+
+// InjectTransaction Inject transaction
+func (ra *recordingAPI) InjectTransaction(txn *coin.Transaction) (string, error) {
+	res, err := ra.api.InjectTransaction(txn)
+	ra.rec.record("InjectTransaction", injectTransactionArgs(txn), res, err)
+	return res, err
+}
+
+// InjectEncodedTransaction Inject raw transaction
+func (ra *recordingAPI) InjectEncodedTransaction(rawTxn string) (string, error) {
+	res, err := ra.api.InjectEncodedTransaction(rawTxn)
+	ra.rec.record("InjectEncodedTransaction", []interface{}{rawTxn}, res, err)
+	return res, err
+}
+
+// VerifyTransaction Decode and verify raw transaction without injecting it
+func (ra *recordingAPI) VerifyTransaction(req api.VerifyTransactionRequest) (*api.VerifyTransactionResponse, error) {
+	res, err := ra.api.VerifyTransaction(req)
+	ra.rec.record("VerifyTransaction", []interface{}{req}, res, err)
+	return res, err
+}
+
+// WalletSignTransaction Sign transaction
+func (ra *recordingAPI) WalletSignTransaction(req api.WalletSignTransactionRequest) (*api.CreateTransactionResponse, error) {
+	res, err := ra.api.WalletSignTransaction(req)
+	ra.rec.record("WalletSignTransaction", walletSignTransactionArgs(req), res, err)
+	return res, err
+}
+
+// WalletCreateTransaction Create transaction from wallet addresses
+func (ra *recordingAPI) WalletCreateTransaction(req api.WalletCreateTransactionRequest) (*api.CreateTransactionResponse, error) {
+	res, err := ra.api.WalletCreateTransaction(req)
+	ra.rec.record("WalletCreateTransaction", walletCreateTransactionArgs(req), res, err)
+	return res, err
+}
+
+// CreateTransaction Create transaction from unspent outputs or addresses
+func (ra *recordingAPI) CreateTransaction(req api.CreateTransactionRequest) (*api.CreateTransactionResponse, error) {
+	res, err := ra.api.CreateTransaction(req)
+	ra.rec.record("CreateTransaction", []interface{}{req}, res, err)
+	return res, err
+}
+
+// Type assertions
+var (
+	_ skytypes.SkycoinAPI = &recordingAPI{}
+)
@@ -0,0 +1,111 @@
+package replay
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/testutil"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/sandbox"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skymocks"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSeed     = "secret seed words"
+	testPassword = "secret password"
+)
+
+func TestRecordReplay(t *testing.T) {
+	node := sandbox.NewNode()
+	var buf bytes.Buffer
+	skyApi := NewRecorder(&buf).Wrap(node)
+
+	wltOpts := api.CreateWalletOptions{Type: "deterministic", Seed: testSeed, Password: testPassword, Encrypt: true}
+	wr, err := skyApi.CreateWallet(wltOpts)
+	require.NoError(t, err)
+	addrs := []string{wr.Entries[0].Address}
+	_, err = node.Mint(addrs[0], 100e6, 1000)
+	require.NoError(t, err)
+	bal1, err := skyApi.Balance(addrs)
+	require.NoError(t, err)
+	_, err = node.Mint(addrs[0], 1e6, 0)
+	require.NoError(t, err)
+	bal2, err := skyApi.Balance(addrs)
+	require.NoError(t, err)
+	txnReq := api.WalletCreateTransactionRequest{
+		WalletID: wr.Meta.Filename,
+		Password: testPassword,
+		CreateTransactionRequest: api.CreateTransactionRequest{
+			HoursSelection: api.HoursSelection{Type: "auto", Mode: "share", ShareFactor: "0.5"},
+			To:             []api.Receiver{{Address: testutil.MakeAddress().String(), Coins: "10"}},
+		},
+	}
+	txnR, err := skyApi.WalletCreateTransaction(txnReq)
+	require.NoError(t, err)
+	txid, err := skyApi.InjectEncodedTransaction(txnR.EncodedTransaction)
+	require.NoError(t, err)
+	_, errTxn := skyApi.Transaction(testutil.RandSHA256(t).Hex())
+	require.Error(t, errTxn)
+
+	// Secrets never reach the recording
+	require.NotContains(t, buf.String(), "secret")
+	require.Contains(t, buf.String(), Redacted)
+
+	rp, err := NewReplayer(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	replayedWr, err := rp.CreateWallet(wltOpts)
+	require.NoError(t, err)
+	require.Equal(t, wr, replayedWr)
+	replayedBal, err := rp.Balance(addrs)
+	require.NoError(t, err)
+	require.Equal(t, bal1, replayedBal)
+	// Answers to repeated calls are replayed in order, the last one is kept
+	for i := 0; i < 2; i++ {
+		replayedBal, err = rp.Balance(addrs)
+		require.NoError(t, err)
+		require.Equal(t, bal2, replayedBal)
+	}
+	replayedTxnR, err := rp.WalletCreateTransaction(txnReq)
+	require.NoError(t, err)
+	require.Equal(t, txnR, replayedTxnR)
+	replayedTxid, err := rp.InjectEncodedTransaction(txnR.EncodedTransaction)
+	require.NoError(t, err)
+	require.Equal(t, txid, replayedTxid)
+	_, err = rp.Transaction(testutil.RandSHA256(t).Hex())
+	require.Equal(t, ErrNotRecorded, err)
+	_, err = rp.CoinSupply()
+	require.Equal(t, ErrNotRecorded, err)
+}
+
+func TestReplayClientError(t *testing.T) {
+	clientErr := api.NewClientError("404 Not Found", 404, "Not Found")
+	skyMock := new(skymocks.SkycoinAPI)
+	skyMock.On("UxOut", "uxid").Return(nil, clientErr)
+	skyMock.On("UpdateWallet", "wallet", "label").Return(nil)
+
+	dir, err := ioutil.TempDir("", "replay")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "session.json")
+	rec, err := CreateRecorder(path)
+	require.NoError(t, err)
+	skyApi := rec.Wrap(skyMock)
+	_, err = skyApi.UxOut("uxid")
+	require.Equal(t, clientErr, err)
+	require.NoError(t, skyApi.UpdateWallet("wallet", "label"))
+	require.NoError(t, rec.Close())
+
+	rp, isReplay, err := LookupURL(URLScheme + path)
+	require.True(t, isReplay)
+	require.NoError(t, err)
+	_, err = rp.UxOut("uxid")
+	require.Equal(t, clientErr, err)
+	require.NoError(t, rp.UpdateWallet("wallet", "label"))
+
+	_, isReplay, _ = LookupURL("https://node.skycoin.com")
+	require.False(t, isReplay)
+}
@@ -0,0 +1,336 @@
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+)
+
+// URLScheme prefix of node addresses replaying recorded sessions, followed by path to recording
+const URLScheme = "replay://"
+
+var (
+	// ErrNotRecorded call was not made while recording session
+	ErrNotRecorded = errors.New("Call not found in recorded session")
+
+	replayers      = make(map[string]*Replayer)
+	replayersMutex sync.Mutex
+)
+
+func callKey(method string, args []byte) string {
+	return method + "\x00" + string(args)
+}
+
+// Replayer fake Skycoin node answering calls as recorded.
+// Calls are matched by method and (redacted) arguments. Answers to repeated calls
+// are replayed in recording order and the last one is kept for further calls.
+type Replayer struct {
+	mutex sync.Mutex
+	calls map[string][]Entry
+}
+
+// NewReplayer loads recorded session from stream of JSON entries
+func NewReplayer(r io.Reader) (*Replayer, error) {
+	rp := &Replayer{calls: make(map[string][]Entry)}
+	dec := json.NewDecoder(r)
+	for {
+		var entry Entry
+		if err := dec.Decode(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		key := callKey(entry.Method, entry.Args)
+		rp.calls[key] = append(rp.calls[key], entry)
+	}
+	return rp, nil
+}
+
+// OpenReplayer loads recorded session from file
+func OpenReplayer(path string) (*Replayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return NewReplayer(f)
+}
+
+// LookupURL resolves replayer serving node address, if it refers to a recorded session.
+// Sessions are loaded once and shared by every client of the same address.
+func LookupURL(url string) (*Replayer, bool, error) {
+	if !strings.HasPrefix(url, URLScheme) {
+		return nil, false, nil
+	}
+	path := strings.TrimPrefix(url, URLScheme)
+	replayersMutex.Lock()
+	defer replayersMutex.Unlock()
+	if rp, isLoaded := replayers[path]; isLoaded {
+		return rp, true, nil
+	}
+	rp, err := OpenReplayer(path)
+	if err != nil {
+		return nil, true, err
+	}
+	logReplay.WithField("path", path).Info("Replaying recorded Skycoin session")
+	replayers[path] = rp
+	return rp, true, nil
+}
+
+func (rp *Replayer) next(method string, args []interface{}) (*Entry, error) {
+	argsBytes, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	rp.mutex.Lock()
+	defer rp.mutex.Unlock()
+	key := callKey(method, argsBytes)
+	entries := rp.calls[key]
+	if len(entries) == 0 {
+		logReplay.WithField("method", method).WithField("args", string(argsBytes)).Warn("Call not recorded")
+		return nil, ErrNotRecorded
+	}
+	if len(entries) > 1 {
+		rp.calls[key] = entries[1:]
+	}
+	return &entries[0], nil
+}
+
+// replay answers call as recorded, decoding recorded result into result
+func (rp *Replayer) replay(method string, args []interface{}, result interface{}) error {
+	entry, err := rp.next(method, args)
+	if err != nil {
+		return err
+	}
+	if entry.Error != nil {
+		if entry.Error.StatusCode != 0 {
+			return api.NewClientError(entry.Error.Status, entry.Error.StatusCode, entry.Error.Message)
+		}
+		return errors.New(entry.Error.Message)
+	}
+	if result == nil || len(entry.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(entry.Result, result)
+}
+
+// Transaction Get transaction info by id
+func (rp *Replayer) Transaction(txid string) (*readable.TransactionWithStatus, error) {
+	var res *readable.TransactionWithStatus
+	err := rp.replay("Transaction", []interface{}{txid}, &res)
+	return res, err
+}
+
+// Transactions Get transactions for addresses
+func (rp *Replayer) Transactions(addrs []string) ([]readable.TransactionWithStatus, error) {
+	var res []readable.TransactionWithStatus
+	err := rp.replay("Transactions", []interface{}{addrs}, &res)
+	return res, err
+}
+
+// TransactionVerbose Get transaction info by id. Include spent input data
+func (rp *Replayer) TransactionVerbose(txid string) (*readable.TransactionWithStatusVerbose, error) {
+	var res *readable.TransactionWithStatusVerbose
+	err := rp.replay("TransactionVerbose", []interface{}{txid}, &res)
+	return res, err
+}
+
+// TransactionsVerbose Get transactions for addresses. Include spent input data
+func (rp *Replayer) TransactionsVerbose(addrs []string) ([]readable.TransactionWithStatusVerbose, error) {
+	var res []readable.TransactionWithStatusVerbose
+	err := rp.replay("TransactionsVerbose", []interface{}{addrs}, &res)
+	return res, err
+}
+
+// UxOut Get uxout
+func (rp *Replayer) UxOut(uxID string) (*readable.SpentOutput, error) {
+	var res *readable.SpentOutput
+	err := rp.replay("UxOut", []interface{}{uxID}, &res)
+	return res, err
+}
+
+// PendingTransactionsVerbose Get unconfirmed transactions
+func (rp *Replayer) PendingTransactionsVerbose() ([]readable.UnconfirmedTransactionVerbose, error) {
+	var res []readable.UnconfirmedTransactionVerbose
+	err := rp.replay("PendingTransactionsVerbose", []interface{}{}, &res)
+	return res, err
+}
+
+// CoinSupply Determine coin supply
+func (rp *Replayer) CoinSupply() (*api.CoinSupply, error) {
+	var res *api.CoinSupply
+	err := rp.replay("CoinSupply", []interface{}{}, &res)
+	return res, err
+}
+
+// LastBlocks Get last N blocks
+func (rp *Replayer) LastBlocks(n uint64) (*readable.Blocks, error) {
+	var res *readable.Blocks
+	err := rp.replay("LastBlocks", []interface{}{n}, &res)
+	return res, err
+}
+
+// BlockchainProgress Get blockchain progress
+func (rp *Replayer) BlockchainProgress() (*readable.BlockchainProgress, error) {
+	var res *readable.BlockchainProgress
+	err := rp.replay("BlockchainProgress", []interface{}{}, &res)
+	return res, err
+}
+
+// BlockBySeq Get block by sequence number
+func (rp *Replayer) BlockBySeq(seq uint64) (*readable.Block, error) {
+	var res *readable.Block
+	err := rp.replay("BlockBySeq", []interface{}{seq}, &res)
+	return res, err
+}
+
+// BlockByHash Get block by header hash
+func (rp *Replayer) BlockByHash(hash string) (*readable.Block, error) {
+	var res *readable.Block
+	err := rp.replay("BlockByHash", []interface{}{hash}, &res)
+	return res, err
+}
+
+// Health Get node health, version and coin identity
+func (rp *Replayer) Health() (*api.HealthResponse, error) {
+	var res *api.HealthResponse
+	err := rp.replay("Health", []interface{}{}, &res)
+	return res, err
+}
+
+// Balance Get balance of addresses
+func (rp *Replayer) Balance(addrs []string) (*api.BalanceResponse, error) {
+	var res *api.BalanceResponse
+	err := rp.replay("Balance", []interface{}{addrs}, &res)
+	return res, err
+}
+
+// OutputsForAddresses Get historical unspent outputs for an address
+func (rp *Replayer) OutputsForAddresses(addrs []string) (*readable.UnspentOutputsSummary, error) {
+	var res *readable.UnspentOutputsSummary
+	err := rp.replay("OutputsForAddresses", []interface{}{addrs}, &res)
+	return res, err
+}
+
+// Wallet Get wallet
+func (rp *Replayer) Wallet(id string) (*api.WalletResponse, error) {
+	var res *api.WalletResponse
+	err := rp.replay("Wallet", []interface{}{id}, &res)
+	return res, err
+}
+
+// UpdateWallet Change wallet label
+func (rp *Replayer) UpdateWallet(id, label string) error {
+	return rp.replay("UpdateWallet", []interface{}{id, label}, nil)
+}
+
+// NewWalletAddress Generate new address in wallet
+func (rp *Replayer) NewWalletAddress(id string, n int, password string) ([]string, error) {
+	var res []string
+	err := rp.replay("NewWalletAddress", []interface{}{id, n, redact(password)}, &res)
+	return res, err
+}
+
+// Wallets Get wallets
+func (rp *Replayer) Wallets() ([]api.WalletResponse, error) {
+	var res []api.WalletResponse
+	err := rp.replay("Wallets", []interface{}{}, &res)
+	return res, err
+}
+
+// CreateWallet Create wallet
+func (rp *Replayer) CreateWallet(o api.CreateWalletOptions) (*api.WalletResponse, error) {
+	var res *api.WalletResponse
+	err := rp.replay("CreateWallet", createWalletArgs(o), &res)
+	return res, err
+}
+
+// EncryptWallet Encrypt wallet
+func (rp *Replayer) EncryptWallet(id, password string) (*api.WalletResponse, error) {
+	var res *api.WalletResponse
+	err := rp.replay("EncryptWallet", []interface{}{id, redact(password)}, &res)
+	return res, err
+}
+
+// DecryptWallet Decrypt wallet
+func (rp *Replayer) DecryptWallet(id, password string) (*api.WalletResponse, error) {
+	var res *api.WalletResponse
+	err := rp.replay("DecryptWallet", []interface{}{id, redact(password)}, &res)
+	return res, err
+}
+
+// WalletBalance Get wallet balance
+func (rp *Replayer) WalletBalance(id string) (*api.BalanceResponse, error) {
+	var res *api.BalanceResponse
+	err := rp.replay("WalletBalance", []interface{}{id}, &res)
+	return res, err
+}
+
+// WalletUnconfirmedTransactionsVerbose Get unconfirmed transactions of a wallet
+func (rp *Replayer) WalletUnconfirmedTransactionsVerbose(id string) (*api.UnconfirmedTxnsVerboseResponse, error) {
+	var res *api.UnconfirmedTxnsVerboseResponse
+	err := rp.replay("WalletUnconfirmedTransactionsVerbose", []interface{}{id}, &res)
+	return res, err
+}
+
+// NetworkConnections Get a list of all connections
+func (rp *Replayer) NetworkConnections(filters *api.NetworkConnectionsFilter) (*api.Connections, error) {
+	var res *api.Connections
+	err := rp.replay("NetworkConnections", []interface{}{filters}, &res)
+	return res, err
+}
+
+// InjectTransaction Inject transaction
+func (rp *Replayer) InjectTransaction(txn *coin.Transaction) (string, error) {
+	var res string
+	err := rp.replay("InjectTransaction", injectTransactionArgs(txn), &res)
+	return res, err
+}
+
+// InjectEncodedTransaction Inject raw transaction
+func (rp *Replayer) InjectEncodedTransaction(rawTxn string) (string, error) {
+	var res string
+	err := rp.replay("InjectEncodedTransaction", []interface{}{rawTxn}, &res)
+	return res, err
+}
+
+// VerifyTransaction Decode and verify raw transaction without injecting it
+func (rp *Replayer) VerifyTransaction(req api.VerifyTransactionRequest) (*api.VerifyTransactionResponse, error) {
+	var res *api.VerifyTransactionResponse
+	err := rp.replay("VerifyTransaction", []interface{}{req}, &res)
+	return res, err
+}
+
+// WalletSignTransaction Sign transaction
+func (rp *Replayer) WalletSignTransaction(req api.WalletSignTransactionRequest) (*api.CreateTransactionResponse, error) {
+	var res *api.CreateTransactionResponse
+	err := rp.replay("WalletSignTransaction", walletSignTransactionArgs(req), &res)
+	return res, err
+}
+
+// WalletCreateTransaction Create transaction from wallet addresses
+func (rp *Replayer) WalletCreateTransaction(req api.WalletCreateTransactionRequest) (*api.CreateTransactionResponse, error) {
+	var res *api.CreateTransactionResponse
+	err := rp.replay("WalletCreateTransaction", walletCreateTransactionArgs(req), &res)
+	return res, err
+}
+
+// CreateTransaction Create transaction from unspent outputs or addresses
+func (rp *Replayer) CreateTransaction(req api.CreateTransactionRequest) (*api.CreateTransactionResponse, error) {
+	var res *api.CreateTransactionResponse
+	err := rp.replay("CreateTransaction", []interface{}{req}, &res)
+	return res, err
+}
+
+// Type assertions
+var (
+	_ skytypes.SkycoinAPI = &Replayer{}
+)
@@ -0,0 +1,346 @@
+package resilience
+
+import (
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/cenkalti/backoff"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+)
+
+// Guard applies policy to calls made to a node.
+// Rate limit and circuit state are shared by every client wrapped by the same guard.
+type Guard struct {
+	policy  Policy
+	limiter *rateLimiter
+	breaker *circuitBreaker
+}
+
+// NewGuard instantiates guard of a node
+func NewGuard(policy Policy) *Guard {
+	return &Guard{
+		policy:  policy,
+		limiter: newRateLimiter(policy.RateLimit, policy.Burst),
+		breaker: &circuitBreaker{threshold: policy.FailureThreshold, timeout: policy.OpenTimeout},
+	}
+}
+
+// Wrap decorates client so that its calls are guarded.
+// Errors returned by decorated client are classified as *NodeError whenever possible.
+func (g *Guard) Wrap(skyApi skytypes.SkycoinAPI) skytypes.SkycoinAPI {
+	return &guardedAPI{api: skyApi, guard: g}
+}
+
+func (g *Guard) newBackOff(idempotent bool) backoff.BackOff {
+	if !idempotent || g.policy.MaxRetries == 0 {
+		return &backoff.StopBackOff{}
+	}
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = g.policy.InitialInterval
+	b.MaxInterval = g.policy.MaxInterval
+	b.MaxElapsedTime = g.policy.MaxElapsedTime
+	return backoff.WithMaxTries(b, g.policy.MaxRetries)
+}
+
+// do invokes operation, retrying it after transient failures if it is idempotent
+func (g *Guard) do(method string, idempotent bool, operation func() error) error {
+	attempt := func() error {
+		if err := g.breaker.allow(); err != nil {
+			return backoff.Permanent(err)
+		}
+		g.limiter.wait()
+		err := operation()
+		isTransient := Classify(err) == fce.ErrNodeUnavailable
+		g.breaker.record(isTransient)
+		if err != nil && !isTransient {
+			return backoff.Permanent(err)
+		}
+		return err
+	}
+	notify := func(err error, next time.Duration) {
+		logResilience.WithError(err).WithField("method", method).WithField("delay", next).Warn("Node call failed, retrying")
+	}
+	return classified(backoff.RetryNotify(attempt, g.newBackOff(idempotent), notify))
+}
+
+// guardedAPI decorates SkycoinAPI client applying guard policy
+type guardedAPI struct {
+	api   skytypes.SkycoinAPI
+	guard *Guard
+}
+
+// Transaction Get transaction info by id
+func (ga *guardedAPI) Transaction(txid string) (res *readable.TransactionWithStatus, err error) {
+	err = ga.guard.do("Transaction", true, func() (err error) {
+		res, err = ga.api.Transaction(txid)
+		return
+	})
+	return
+}
+
+// Transactions Get transactions for addresses
+func (ga *guardedAPI) Transactions(addrs []string) (res []readable.TransactionWithStatus, err error) {
+	err = ga.guard.do("Transactions", true, func() (err error) {
+		res, err = ga.api.Transactions(addrs)
+		return
+	})
+	return
+}
+
+// TransactionVerbose Get transaction info by id. Include spent input data
+func (ga *guardedAPI) TransactionVerbose(txid string) (res *readable.TransactionWithStatusVerbose, err error) {
+	err = ga.guard.do("TransactionVerbose", true, func() (err error) {
+		res, err = ga.api.TransactionVerbose(txid)
+		return
+	})
+	return
+}
+
+// TransactionsVerbose Get transactions for addresses. Include spent input data
+func (ga *guardedAPI) TransactionsVerbose(addrs []string) (res []readable.TransactionWithStatusVerbose, err error) {
+	err = ga.guard.do("TransactionsVerbose", true, func() (err error) {
+		res, err = ga.api.TransactionsVerbose(addrs)
+		return
+	})
+	return
+}
+
+// UxOut Get uxout
+func (ga *guardedAPI) UxOut(uxID string) (res *readable.SpentOutput, err error) {
+	err = ga.guard.do("UxOut", true, func() (err error) {
+		res, err = ga.api.UxOut(uxID)
+		return
+	})
+	return
+}
+
+// PendingTransactionsVerbose Get unconfirmed transactions
+func (ga *guardedAPI) PendingTransactionsVerbose() (res []readable.UnconfirmedTransactionVerbose, err error) {
+	err = ga.guard.do("PendingTransactionsVerbose", true, func() (err error) {
+		res, err = ga.api.PendingTransactionsVerbose()
+		return
+	})
+	return
+}
+
+// CoinSupply Determine coin supply
+func (ga *guardedAPI) CoinSupply() (res *api.CoinSupply, err error) {
+	err = ga.guard.do("CoinSupply", true, func() (err error) {
+		res, err = ga.api.CoinSupply()
+		return
+	})
+	return
+}
+
+// LastBlocks Get last N blocks
+func (ga *guardedAPI) LastBlocks(n uint64) (res *readable.Blocks, err error) {
+	err = ga.guard.do("LastBlocks", true, func() (err error) {
+		res, err = ga.api.LastBlocks(n)
+		return
+	})
+	return
+}
+
+// BlockchainProgress Get blockchain progress
+func (ga *guardedAPI) BlockchainProgress() (res *readable.BlockchainProgress, err error) {
+	err = ga.guard.do("BlockchainProgress", true, func() (err error) {
+		res, err = ga.api.BlockchainProgress()
+		return
+	})
+	return
+}
+
+// BlockBySeq Get block by sequence number
+func (ga *guardedAPI) BlockBySeq(seq uint64) (res *readable.Block, err error) {
+	err = ga.guard.do("BlockBySeq", true, func() (err error) {
+		res, err = ga.api.BlockBySeq(seq)
+		return
+	})
+	return
+}
+
+// BlockByHash Get block by header hash
+func (ga *guardedAPI) BlockByHash(hash string) (res *readable.Block, err error) {
+	err = ga.guard.do("BlockByHash", true, func() (err error) {
+		res, err = ga.api.BlockByHash(hash)
+		return
+	})
+	return
+}
+
+// Health Get node health, version and coin identity
+func (ga *guardedAPI) Health() (res *api.HealthResponse, err error) {
+	err = ga.guard.do("Health", true, func() (err error) {
+		res, err = ga.api.Health()
+		return
+	})
+	return
+}
+
+// Balance Get balance of addresses
+func (ga *guardedAPI) Balance(addrs []string) (res *api.BalanceResponse, err error) {
+	err = ga.guard.do("Balance", true, func() (err error) {
+		res, err = ga.api.Balance(addrs)
+		return
+	})
+	return
+}
+
+// OutputsForAddresses Get historical unspent outputs for an address
+func (ga *guardedAPI) OutputsForAddresses(addrs []string) (res *readable.UnspentOutputsSummary, err error) {
+	err = ga.guard.do("OutputsForAddresses", true, func() (err error) {
+		res, err = ga.api.OutputsForAddresses(addrs)
+		return
+	})
+	return
+}
+
+// Wallet Get wallet
+func (ga *guardedAPI) Wallet(id string) (res *api.WalletResponse, err error) {
+	err = ga.guard.do("Wallet", true, func() (err error) {
+		res, err = ga.api.Wallet(id)
+		return
+	})
+	return
+}
+
+// UpdateWallet Change wallet label
+func (ga *guardedAPI) UpdateWallet(id, label string) error {
+	return ga.guard.do("UpdateWallet", true, func() error {
+		return ga.api.UpdateWallet(id, label)
+	})
+}
+
+// NewWalletAddress Generate new address in wallet
+func (ga *guardedAPI) NewWalletAddress(id string, n int, password string) (res []string, err error) {
+	err = ga.guard.do("NewWalletAddress", false, func() (err error) {
+		res, err = ga.api.NewWalletAddress(id, n, password)
+		return
+	})
+	return
+}
+
+// Wallets Get wallets
+func (ga *guardedAPI) Wallets() (res []api.WalletResponse, err error) {
+	err = ga.guard.do("Wallets", true, func() (err error) {
+		res, err = ga.api.Wallets()
+		return
+	})
+	return
+}
+
+// CreateWallet Create wallet
+func (ga *guardedAPI) CreateWallet(o api.CreateWalletOptions) (res *api.WalletResponse, err error) {
+	err = ga.guard.do("CreateWallet", false, func() (err error) {
+		res, err = ga.api.CreateWallet(o)
+		return
+	})
+	return
+}
+
+// EncryptWallet Encrypt wallet
+func (ga *guardedAPI) EncryptWallet(id, password string) (res *api.WalletResponse, err error) {
+	err = ga.guard.do("EncryptWallet", false, func() (err error) {
+		res, err = ga.api.EncryptWallet(id, password)
+		return
+	})
+	return
+}
+
+// DecryptWallet Decrypt wallet
+func (ga *guardedAPI) DecryptWallet(id, password string) (res *api.WalletResponse, err error) {
+	err = ga.guard.do("DecryptWallet", false, func() (err error) {
+		res, err = ga.api.DecryptWallet(id, password)
+		return
+	})
+	return
+}
+
+// WalletBalance Get wallet balance
+func (ga *guardedAPI) WalletBalance(id string) (res *api.BalanceResponse, err error) {
+	err = ga.guard.do("WalletBalance", true, func() (err error) {
+		res, err = ga.api.WalletBalance(id)
+		return
+	})
+	return
+}
+
+// WalletUnconfirmedTransactionsVerbose Get unconfirmed transactions of a wallet
+func (ga *guardedAPI) WalletUnconfirmedTransactionsVerbose(id string) (res *api.UnconfirmedTxnsVerboseResponse, err error) {
+	err = ga.guard.do("WalletUnconfirmedTransactionsVerbose", true, func() (err error) {
+		res, err = ga.api.WalletUnconfirmedTransactionsVerbose(id)
+		return
+	})
+	return
+}
+
+// NetworkConnections Get a list of all connections
+func (ga *guardedAPI) NetworkConnections(filters *api.NetworkConnectionsFilter) (res *api.Connections, err error) {
+	err = ga.guard.do("NetworkConnections", true, func() (err error) {
+		res, err = ga.api.NetworkConnections(filters)
+		return
+	})
+	return
+}
+
+// InjectTransaction Inject transaction. Never retried.
+func (ga *guardedAPI) InjectTransaction(txn *coin.Transaction) (res string, err error) {
+	err = ga.guard.do("InjectTransaction", false, func() (err error) {
+		res, err = ga.api.InjectTransaction(txn)
+		return
+	})
+	return
+}
+
+// InjectEncodedTransaction Inject raw transaction. Never retried.
+func (ga *guardedAPI) InjectEncodedTransaction(rawTxn string) (res string, err error) {
+	err = ga.guard.do("InjectEncodedTransaction", false, func() (err error) {
+		res, err = ga.api.InjectEncodedTransaction(rawTxn)
+		return
+	})
+	return
+}
+
+// VerifyTransaction Decode and verify raw transaction without injecting it
+func (ga *guardedAPI) VerifyTransaction(req api.VerifyTransactionRequest) (res *api.VerifyTransactionResponse, err error) {
+	err = ga.guard.do("VerifyTransaction", true, func() (err error) {
+		res, err = ga.api.VerifyTransaction(req)
+		return
+	})
+	return
+}
+
+// WalletSignTransaction Sign transaction
+func (ga *guardedAPI) WalletSignTransaction(req api.WalletSignTransactionRequest) (res *api.CreateTransactionResponse, err error) {
+	err = ga.guard.do("WalletSignTransaction", true, func() (err error) {
+		res, err = ga.api.WalletSignTransaction(req)
+		return
+	})
+	return
+}
+
+// WalletCreateTransaction Create transaction from wallet addresses
+func (ga *guardedAPI) WalletCreateTransaction(req api.WalletCreateTransactionRequest) (res *api.CreateTransactionResponse, err error) {
+	err = ga.guard.do("WalletCreateTransaction", true, func() (err error) {
+		res, err = ga.api.WalletCreateTransaction(req)
+		return
+	})
+	return
+}
+
+// CreateTransaction Create transaction from unspent outputs or addresses
+func (ga *guardedAPI) CreateTransaction(req api.CreateTransactionRequest) (res *api.CreateTransactionResponse, err error) {
+	err = ga.guard.do("CreateTransaction", true, func() (err error) {
+		res, err = ga.api.CreateTransaction(req)
+		return
+	})
+	return
+}
+
+// Type assertions
+var (
+	_ skytypes.SkycoinAPI = &guardedAPI{}
+)
@@ -0,0 +1,197 @@
+package resilience
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var (
+	logResilience = logging.MustGetLogger("Skycoin node resilience")
+
+	// ErrCircuitOpen calls are not sent to node after repeated failures until it cools down
+	ErrCircuitOpen = errors.New("Node disabled after repeated failures")
+)
+
+// Policy tunes how calls to a node are retried, throttled and cut off
+type Policy struct {
+	// MaxRetries number of times idempotent calls are retried after transient failures
+	MaxRetries uint64
+	// InitialInterval time to wait before first retry, growing exponentially afterwards
+	InitialInterval time.Duration
+	// MaxInterval upper bound of time to wait between retries
+	MaxInterval time.Duration
+	// MaxElapsedTime time after which retries stop
+	MaxElapsedTime time.Duration
+	// RateLimit maximum number of requests per second sent to node, unlimited if zero
+	RateLimit float64
+	// Burst number of requests that may be sent at once without waiting for rate limit
+	Burst int
+	// FailureThreshold consecutive transient failures opening the circuit, never opened if zero
+	FailureThreshold int
+	// OpenTimeout time the circuit stays open before a call is let through to probe node
+	OpenTimeout time.Duration
+}
+
+// DefaultPolicy policy applied to nodes unless configured otherwise
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxRetries:       3,
+		InitialInterval:  500 * time.Millisecond,
+		MaxInterval:      5 * time.Second,
+		MaxElapsedTime:   30 * time.Second,
+		RateLimit:        10,
+		Burst:            10,
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+	}
+}
+
+// NodeError error returned by node calls, labelled with its class
+type NodeError struct {
+	// Class either errors.ErrNodeUnavailable or errors.ErrNodeRequestRejected
+	Class error
+	// Err error returned by node client
+	Err error
+}
+
+// Error preserves message of error returned by node client
+func (e *NodeError) Error() string {
+	return e.Err.Error()
+}
+
+// Classify tells whether err means node is down (errors.ErrNodeUnavailable)
+// or refused an invalid request (errors.ErrNodeRequestRejected).
+// Nil is returned for errors of any other kind.
+func Classify(err error) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *NodeError:
+		return e.Class
+	case api.ClientError:
+		switch {
+		case e.StatusCode >= http.StatusInternalServerError,
+			e.StatusCode == http.StatusTooManyRequests,
+			e.StatusCode == http.StatusRequestTimeout:
+			return fce.ErrNodeUnavailable
+		default:
+			return fce.ErrNodeRequestRejected
+		}
+	case net.Error:
+		return fce.ErrNodeUnavailable
+	}
+	if err == io.EOF || err == io.ErrUnexpectedEOF || err == ErrCircuitOpen {
+		return fce.ErrNodeUnavailable
+	}
+	return nil
+}
+
+func classified(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, isNodeErr := err.(*NodeError); isNodeErr {
+		return err
+	}
+	if class := Classify(err); class != nil {
+		return &NodeError{Class: class, Err: err}
+	}
+	return err
+}
+
+// rateLimiter token bucket throttling requests
+type rateLimiter struct {
+	rate  float64
+	burst float64
+
+	mutex  sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait blocks until a request may be sent
+func (rl *rateLimiter) wait() {
+	if rl.rate <= 0 {
+		return
+	}
+	for {
+		rl.mutex.Lock()
+		now := time.Now()
+		rl.tokens += now.Sub(rl.last).Seconds() * rl.rate
+		if rl.tokens > rl.burst {
+			rl.tokens = rl.burst
+		}
+		rl.last = now
+		if rl.tokens >= 1 {
+			rl.tokens--
+			rl.mutex.Unlock()
+			return
+		}
+		delay := time.Duration((1 - rl.tokens) / rl.rate * float64(time.Second))
+		rl.mutex.Unlock()
+		time.Sleep(delay)
+	}
+}
+
+// circuitBreaker stops sending requests to a node after consecutive transient failures.
+// Once open timeout expires a single probe call is let through, closing the circuit if it succeeds.
+type circuitBreaker struct {
+	threshold int
+	timeout   time.Duration
+
+	mutex    sync.Mutex
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func (cb *circuitBreaker) allow() error {
+	if cb.threshold <= 0 {
+		return nil
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	if cb.failures < cb.threshold {
+		return nil
+	}
+	if cb.probing || time.Since(cb.openedAt) < cb.timeout {
+		return ErrCircuitOpen
+	}
+	cb.probing = true
+	return nil
+}
+
+func (cb *circuitBreaker) record(failed bool) {
+	if cb.threshold <= 0 {
+		return
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.probing = false
+	if !failed {
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.failures >= cb.threshold {
+		if cb.failures == cb.threshold {
+			logResilience.Warn("Too many node failures, opening circuit")
+		}
+		cb.openedAt = time.Now()
+	}
+}
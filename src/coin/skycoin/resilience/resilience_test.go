@@ -0,0 +1,127 @@
+package resilience
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skymocks"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/stretchr/testify/require"
+)
+
+var errTransient = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+func testPolicy() Policy {
+	return Policy{
+		MaxRetries:      3,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		MaxElapsedTime:  time.Second,
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		err   error
+		class error
+	}{
+		{nil, nil},
+		{errTransient, fce.ErrNodeUnavailable},
+		{api.NewClientError("503 Service Unavailable", 503, "Service Unavailable"), fce.ErrNodeUnavailable},
+		{api.NewClientError("429 Too Many Requests", 429, "Too Many Requests"), fce.ErrNodeUnavailable},
+		{api.NewClientError("400 Bad Request", 400, "invalid address"), fce.ErrNodeRequestRejected},
+		{api.NewClientError("404 Not Found", 404, "Not Found"), fce.ErrNodeRequestRejected},
+		{ErrCircuitOpen, fce.ErrNodeUnavailable},
+		{&NodeError{Class: fce.ErrNodeRequestRejected, Err: errTransient}, fce.ErrNodeRequestRejected},
+		{errors.New("unknown"), nil},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.class, Classify(tt.err))
+	}
+}
+
+func TestGuardRetryIdempotent(t *testing.T) {
+	skyMock := new(skymocks.SkycoinAPI)
+	addrs := []string{"addr"}
+	skyMock.On("Balance", addrs).Return(nil, errTransient).Twice()
+	skyMock.On("Balance", addrs).Return(&api.BalanceResponse{}, nil).Once()
+	skyApi := NewGuard(testPolicy()).Wrap(skyMock)
+
+	bal, err := skyApi.Balance(addrs)
+	require.NoError(t, err)
+	require.NotNil(t, bal)
+	skyMock.AssertNumberOfCalls(t, "Balance", 3)
+
+	// Retries are exhausted
+	skyMock.On("Balance", addrs).Return(nil, errTransient)
+	_, err = skyApi.Balance(addrs)
+	require.Equal(t, fce.ErrNodeUnavailable, Classify(err))
+	require.Equal(t, errTransient.Error(), err.Error())
+	skyMock.AssertNumberOfCalls(t, "Balance", 7)
+}
+
+func TestGuardNoRetry(t *testing.T) {
+	skyMock := new(skymocks.SkycoinAPI)
+	skyMock.On("InjectEncodedTransaction", "rawtxn").Return("", errTransient)
+	invalidErr := api.NewClientError("400 Bad Request", 400, "invalid txid")
+	skyMock.On("Transaction", "txid").Return(nil, invalidErr)
+	skyApi := NewGuard(testPolicy()).Wrap(skyMock)
+
+	// Injecting transactions is never retried
+	_, err := skyApi.InjectEncodedTransaction("rawtxn")
+	require.Equal(t, fce.ErrNodeUnavailable, Classify(err))
+	skyMock.AssertNumberOfCalls(t, "InjectEncodedTransaction", 1)
+
+	// Invalid requests are not retried
+	_, err = skyApi.Transaction("txid")
+	require.Equal(t, fce.ErrNodeRequestRejected, Classify(err))
+	require.Equal(t, invalidErr, err.(*NodeError).Err)
+	skyMock.AssertNumberOfCalls(t, "Transaction", 1)
+}
+
+func TestGuardCircuitBreaker(t *testing.T) {
+	skyMock := new(skymocks.SkycoinAPI)
+	skyMock.On("Wallets").Return(nil, errTransient).Twice()
+	skyMock.On("Wallets").Return([]api.WalletResponse{}, nil)
+	policy := testPolicy()
+	policy.MaxRetries = 0
+	policy.FailureThreshold = 2
+	policy.OpenTimeout = 50 * time.Millisecond
+	skyApi := NewGuard(policy).Wrap(skyMock)
+
+	for i := 0; i < 2; i++ {
+		_, err := skyApi.Wallets()
+		require.Equal(t, fce.ErrNodeUnavailable, Classify(err))
+	}
+	// Calls fail fast while circuit is open
+	_, err := skyApi.Wallets()
+	require.Equal(t, ErrCircuitOpen, err.(*NodeError).Err)
+	require.Equal(t, fce.ErrNodeUnavailable, Classify(err))
+	skyMock.AssertNumberOfCalls(t, "Wallets", 2)
+
+	// Probe call closes circuit once timeout expires
+	time.Sleep(policy.OpenTimeout)
+	for i := 0; i < 2; i++ {
+		_, err = skyApi.Wallets()
+		require.NoError(t, err)
+	}
+	skyMock.AssertNumberOfCalls(t, "Wallets", 4)
+}
+
+func TestGuardRateLimit(t *testing.T) {
+	skyMock := new(skymocks.SkycoinAPI)
+	skyMock.On("UpdateWallet", "wallet", "label").Return(nil)
+	policy := testPolicy()
+	policy.RateLimit = 50
+	policy.Burst = 1
+	skyApi := NewGuard(policy).Wrap(skyMock)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, skyApi.UpdateWallet("wallet", "label"))
+	}
+	require.True(t, time.Since(start) >= 35*time.Millisecond)
+}
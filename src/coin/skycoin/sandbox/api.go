@@ -0,0 +1,525 @@
+package sandbox
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/transaction"
+	"github.com/SkycoinProject/skycoin/src/util/droplet"
+	wh "github.com/SkycoinProject/skycoin/src/util/http"
+	"github.com/SkycoinProject/skycoin/src/visor"
+	"github.com/SkycoinProject/skycoin/src/wallet"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+)
+
+type addressSet map[cipher.Address]struct{}
+
+func newAddressSet(addrs []string) (addressSet, error) {
+	set := make(addressSet, len(addrs))
+	for _, a := range addrs {
+		addr, err := cipher.DecodeBase58Address(a)
+		if err != nil {
+			return nil, err
+		}
+		set[addr] = struct{}{}
+	}
+	return set, nil
+}
+
+func (set addressSet) has(addr cipher.Address) bool {
+	_, isMember := set[addr]
+	return isMember
+}
+
+// isRelated determines whether transaction spends from or sends to any address in set
+func (n *Node) isRelated(txn *coin.Transaction, set addressSet) bool {
+	for _, out := range txn.Out {
+		if set.has(out.Address) {
+			return true
+		}
+	}
+	for _, ux := range n.historyInputs(txn) {
+		if set.has(ux.Body.Address) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupTxn finds transaction in blockchain or mempool along with the outputs it spends
+func (n *Node) lookupTxn(txid cipher.SHA256) (*visor.Transaction, []visor.TransactionInput, error) {
+	if ctx, isConfirmed := n.confirmed[txid]; isConfirmed {
+		return n.confirmedTxn(ctx)
+	}
+	for i := range n.mempool {
+		if n.mempool[i].Transaction.Hash() == txid {
+			return n.unconfirmedTxn(&n.mempool[i])
+		}
+	}
+	return nil, nil, ErrUnknownTransaction
+}
+
+func (n *Node) confirmedTxn(ctx confirmedTxn) (*visor.Transaction, []visor.TransactionInput, error) {
+	b := n.blocks[ctx.seq]
+	inputs, err := visor.NewTransactionInputs(n.historyInputs(&ctx.txn), b.Time())
+	if err != nil {
+		return nil, nil, err
+	}
+	return &visor.Transaction{
+		Transaction: ctx.txn,
+		Status:      visor.NewConfirmedTransactionStatus(n.head().Seq()-ctx.seq+1, ctx.seq),
+		Time:        b.Time(),
+	}, inputs, nil
+}
+
+func (n *Node) unconfirmedTxn(utx *visor.UnconfirmedTransaction) (*visor.Transaction, []visor.TransactionInput, error) {
+	inputs, err := visor.NewTransactionInputs(n.historyInputs(&utx.Transaction), n.headTime())
+	if err != nil {
+		return nil, nil, err
+	}
+	return &visor.Transaction{
+		Transaction: utx.Transaction,
+		Status:      visor.NewUnconfirmedTransactionStatus(),
+		Time:        uint64(time.Unix(0, utx.Received).Unix()),
+	}, inputs, nil
+}
+
+// relatedTxns lists confirmed and then pending transactions involving addresses
+func (n *Node) relatedTxns(set addressSet) ([]visor.Transaction, [][]visor.TransactionInput, error) {
+	txns := make([]visor.Transaction, 0)
+	inputs := make([][]visor.TransactionInput, 0)
+	for _, b := range n.blocks {
+		for i := range b.Body.Transactions {
+			txn := &b.Body.Transactions[i]
+			if !n.isRelated(txn, set) {
+				continue
+			}
+			vtxn, vins, err := n.confirmedTxn(n.confirmed[txn.Hash()])
+			if err != nil {
+				return nil, nil, err
+			}
+			txns = append(txns, *vtxn)
+			inputs = append(inputs, vins)
+		}
+	}
+	for i := range n.mempool {
+		if !n.isRelated(&n.mempool[i].Transaction, set) {
+			continue
+		}
+		vtxn, vins, err := n.unconfirmedTxn(&n.mempool[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		txns = append(txns, *vtxn)
+		inputs = append(inputs, vins)
+	}
+	return txns, inputs, nil
+}
+
+// Transaction Get transaction info by id
+func (n *Node) Transaction(txid string) (*readable.TransactionWithStatus, error) {
+	h, err := cipher.SHA256FromHex(txid)
+	if err != nil {
+		return nil, err
+	}
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	vtxn, _, err := n.lookupTxn(h)
+	if err != nil {
+		return nil, err
+	}
+	return readable.NewTransactionWithStatus(vtxn)
+}
+
+// Transactions Get transactions for addresses
+func (n *Node) Transactions(addrs []string) ([]readable.TransactionWithStatus, error) {
+	set, err := newAddressSet(addrs)
+	if err != nil {
+		return nil, err
+	}
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	vtxns, _, err := n.relatedTxns(set)
+	if err != nil {
+		return nil, err
+	}
+	txns := make([]readable.TransactionWithStatus, len(vtxns))
+	for i := range vtxns {
+		txn, err := readable.NewTransactionWithStatus(&vtxns[i])
+		if err != nil {
+			return nil, err
+		}
+		txns[i] = *txn
+	}
+	return txns, nil
+}
+
+// TransactionVerbose Get transaction info by id. Include spent input data
+func (n *Node) TransactionVerbose(txid string) (*readable.TransactionWithStatusVerbose, error) {
+	h, err := cipher.SHA256FromHex(txid)
+	if err != nil {
+		return nil, err
+	}
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	vtxn, vins, err := n.lookupTxn(h)
+	if err != nil {
+		return nil, err
+	}
+	return readable.NewTransactionWithStatusVerbose(vtxn, vins)
+}
+
+// TransactionsVerbose Get transactions for addresses. Include spent input data
+func (n *Node) TransactionsVerbose(addrs []string) ([]readable.TransactionWithStatusVerbose, error) {
+	set, err := newAddressSet(addrs)
+	if err != nil {
+		return nil, err
+	}
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	vtxns, vins, err := n.relatedTxns(set)
+	if err != nil {
+		return nil, err
+	}
+	txns := make([]readable.TransactionWithStatusVerbose, len(vtxns))
+	for i := range vtxns {
+		txn, err := readable.NewTransactionWithStatusVerbose(&vtxns[i], vins[i])
+		if err != nil {
+			return nil, err
+		}
+		txns[i] = *txn
+	}
+	return txns, nil
+}
+
+// UxOut Get uxout
+func (n *Node) UxOut(uxID string) (*readable.SpentOutput, error) {
+	h, err := cipher.SHA256FromHex(uxID)
+	if err != nil {
+		return nil, err
+	}
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	ux, isKnown := n.history[h]
+	if !isKnown {
+		return nil, ErrUnknownOutput
+	}
+	out := readable.NewSpentOutput(&ux)
+	return &out, nil
+}
+
+func (n *Node) pendingTxns(set addressSet) ([]readable.UnconfirmedTransactionVerbose, error) {
+	utxns := make([]visor.UnconfirmedTransaction, 0, len(n.mempool))
+	inputs := make([][]visor.TransactionInput, 0, len(n.mempool))
+	for i := range n.mempool {
+		utx := &n.mempool[i]
+		if set != nil && !n.isRelated(&utx.Transaction, set) {
+			continue
+		}
+		vins, err := visor.NewTransactionInputs(n.historyInputs(&utx.Transaction), n.headTime())
+		if err != nil {
+			return nil, err
+		}
+		utxns = append(utxns, *utx)
+		inputs = append(inputs, vins)
+	}
+	return readable.NewUnconfirmedTransactionsVerbose(utxns, inputs)
+}
+
+// PendingTransactionsVerbose Get unconfirmed transactions
+func (n *Node) PendingTransactionsVerbose() ([]readable.UnconfirmedTransactionVerbose, error) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	return n.pendingTxns(nil)
+}
+
+// CoinSupply Determine coin supply
+func (n *Node) CoinSupply() (*api.CoinSupply, error) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	headTime := n.headTime()
+	var currentCoins, totalCoins, currentHours, totalHours uint64
+	for _, ux := range n.unspent {
+		hours, err := ux.CoinHours(headTime)
+		if err != nil {
+			return nil, err
+		}
+		totalCoins += ux.Body.Coins
+		totalHours += hours
+		if ux.Body.Address != n.faucet {
+			currentCoins += ux.Body.Coins
+			currentHours += hours
+		}
+	}
+	current, err := droplet.ToString(currentCoins)
+	if err != nil {
+		return nil, err
+	}
+	total, err := droplet.ToString(totalCoins)
+	if err != nil {
+		return nil, err
+	}
+	max, err := droplet.ToString(DefaultGenesisCoins)
+	if err != nil {
+		return nil, err
+	}
+	return &api.CoinSupply{
+		CurrentSupply:         current,
+		TotalSupply:           total,
+		MaxSupply:             max,
+		CurrentCoinHourSupply: fmt.Sprint(currentHours),
+		TotalCoinHourSupply:   fmt.Sprint(totalHours),
+		UnlockedAddresses:     []string{},
+		LockedAddresses:       []string{n.faucet.String()},
+	}, nil
+}
+
+// LastBlocks Get last N blocks
+func (n *Node) LastBlocks(num uint64) (*readable.Blocks, error) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	start := 0
+	if num < uint64(len(n.blocks)) {
+		start = len(n.blocks) - int(num)
+	}
+	return readable.NewBlocks(n.blocks[start:])
+}
+
+// BlockchainProgress Get blockchain progress
+func (n *Node) BlockchainProgress() (*readable.BlockchainProgress, error) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	return &readable.BlockchainProgress{
+		Current: n.head().Seq(),
+		Highest: n.head().Seq(),
+		Peers:   []readable.PeerBlockchainHeight{},
+	}, nil
+}
+
+// BlockBySeq Get block by sequence number
+func (n *Node) BlockBySeq(seq uint64) (*readable.Block, error) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	if seq >= uint64(len(n.blocks)) {
+		return nil, ErrUnknownBlock
+	}
+	return readable.NewBlock(n.blocks[seq].Block)
+}
+
+// BlockByHash Get block by header hash
+func (n *Node) BlockByHash(hash string) (*readable.Block, error) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	for _, b := range n.blocks {
+		if b.HashHeader().Hex() == hash {
+			return readable.NewBlock(b.Block)
+		}
+	}
+	return nil, ErrUnknownBlock
+}
+
+// Health Get node health, version and coin identity
+func (n *Node) Health() (*api.HealthResponse, error) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	return &api.HealthResponse{
+		BlockchainMetadata: api.BlockchainMetadata{
+			BlockchainMetadata: readable.BlockchainMetadata{
+				Head:        readable.NewBlockHeader(n.head().Head),
+				Unspents:    uint64(len(n.unspent)),
+				Unconfirmed: uint64(len(n.mempool)),
+			},
+			TimeSinceLastBlock: wh.FromDuration(n.now().Sub(time.Unix(int64(n.headTime()), 0))),
+		},
+		Version:          readable.BuildInfo{Version: Version},
+		CoinName:         CoinName,
+		WalletAPIEnabled: true,
+		Fiber: readable.FiberConfig{
+			Name:        CoinName,
+			DisplayName: CoinName,
+		},
+	}, nil
+}
+
+// balances computes confirmed and predicted balance of addresses
+func (n *Node) balances(set addressSet) (*api.BalanceResponse, error) {
+	headTime := n.headTime()
+	addrBalances := make(wallet.AddressBalances, len(set))
+	for addr := range set {
+		addrBalances[addr.String()] = wallet.BalancePair{}
+	}
+	add := func(b *wallet.Balance, coins, hours uint64) {
+		b.Coins += coins
+		b.Hours += hours
+	}
+	for h, ux := range n.unspent {
+		if !set.has(ux.Body.Address) {
+			continue
+		}
+		hours, err := ux.CoinHours(headTime)
+		if err != nil {
+			return nil, err
+		}
+		bp := addrBalances[ux.Body.Address.String()]
+		add(&bp.Confirmed, ux.Body.Coins, hours)
+		if _, isSpent := n.spending[h]; !isSpent {
+			add(&bp.Predicted, ux.Body.Coins, hours)
+		}
+		addrBalances[ux.Body.Address.String()] = bp
+	}
+	for _, utx := range n.mempool {
+		for _, out := range utx.Transaction.Out {
+			if !set.has(out.Address) {
+				continue
+			}
+			bp := addrBalances[out.Address.String()]
+			add(&bp.Predicted, out.Coins, out.Hours)
+			addrBalances[out.Address.String()] = bp
+		}
+	}
+	var total wallet.BalancePair
+	for _, bp := range addrBalances {
+		add(&total.Confirmed, bp.Confirmed.Coins, bp.Confirmed.Hours)
+		add(&total.Predicted, bp.Predicted.Coins, bp.Predicted.Hours)
+	}
+	return &api.BalanceResponse{
+		BalancePair: readable.NewBalancePair(total),
+		Addresses:   readable.NewAddressBalances(addrBalances),
+	}, nil
+}
+
+// Balance Get balance of addresses
+func (n *Node) Balance(addrs []string) (*api.BalanceResponse, error) {
+	set, err := newAddressSet(addrs)
+	if err != nil {
+		return nil, err
+	}
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	return n.balances(set)
+}
+
+// OutputsForAddresses Get historical unspent outputs for an address
+func (n *Node) OutputsForAddresses(addrs []string) (*readable.UnspentOutputsSummary, error) {
+	set, err := newAddressSet(addrs)
+	if err != nil {
+		return nil, err
+	}
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	headTime := n.headTime()
+	var confirmed, outgoing, incoming coin.UxArray
+	for h, ux := range n.unspent {
+		if !set.has(ux.Body.Address) {
+			continue
+		}
+		confirmed = append(confirmed, ux)
+		if _, isSpent := n.spending[h]; isSpent {
+			outgoing = append(outgoing, ux)
+		}
+	}
+	pendingHead := coin.BlockHeader{Time: headTime, BkSeq: n.head().Seq() + 1}
+	for _, utx := range n.mempool {
+		for _, ux := range coin.CreateUnspents(pendingHead, utx.Transaction) {
+			if set.has(ux.Body.Address) {
+				incoming = append(incoming, ux)
+			}
+		}
+	}
+	confirmed.Sort()
+	outgoing.Sort()
+	incoming.Sort()
+	summary := visor.UnspentOutputsSummary{HeadBlock: n.head()}
+	if summary.Confirmed, err = visor.NewUnspentOutputs(confirmed, headTime); err != nil {
+		return nil, err
+	}
+	if summary.Outgoing, err = visor.NewUnspentOutputs(outgoing, headTime); err != nil {
+		return nil, err
+	}
+	if summary.Incoming, err = visor.NewUnspentOutputs(incoming, headTime); err != nil {
+		return nil, err
+	}
+	return readable.NewUnspentOutputsSummary(&summary)
+}
+
+// NetworkConnections Get a list of all connections.
+// Sandbox nodes have no peers.
+func (n *Node) NetworkConnections(filters *api.NetworkConnectionsFilter) (*api.Connections, error) {
+	return &api.Connections{Connections: []readable.Connection{}}, nil
+}
+
+// InjectTransaction Inject transaction
+func (n *Node) InjectTransaction(txn *coin.Transaction) (string, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if err := n.inject(*txn); err != nil {
+		return "", err
+	}
+	return txn.Hash().Hex(), nil
+}
+
+// InjectEncodedTransaction Inject raw transaction
+func (n *Node) InjectEncodedTransaction(rawTxn string) (string, error) {
+	txn, err := coin.DeserializeTransactionHex(rawTxn)
+	if err != nil {
+		return "", err
+	}
+	return n.InjectTransaction(&txn)
+}
+
+// VerifyTransaction Decode and verify raw transaction without injecting it
+func (n *Node) VerifyTransaction(req api.VerifyTransactionRequest) (*api.VerifyTransactionResponse, error) {
+	txn, err := coin.DeserializeTransactionHex(req.EncodedTransaction)
+	if err != nil {
+		return nil, err
+	}
+	signed := visor.TxnSigned
+	if req.Unsigned {
+		signed = visor.TxnUnsigned
+	}
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	uxIn, err := n.verify(&txn, signed)
+	if err != nil {
+		return nil, err
+	}
+	inputs := make([]visor.TransactionInput, 0, len(uxIn))
+	for _, ux := range uxIn {
+		in, err := visor.NewTransactionInput(ux, n.headTime())
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, in)
+	}
+	created, err := api.NewCreatedTransaction(&txn, inputs)
+	if err != nil {
+		return nil, err
+	}
+	return &api.VerifyTransactionResponse{
+		Unsigned:    req.Unsigned,
+		Transaction: *created,
+	}, nil
+}
+
+// CreateTransaction Create transaction from unspent outputs or addresses
+func (n *Node) CreateTransaction(req api.CreateTransactionRequest) (*api.CreateTransactionResponse, error) {
+	p, err := skytypes.NewTxnParams(req)
+	if err != nil {
+		return nil, err
+	}
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	auxs, err := n.spendable(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	txn, uxb, err := transaction.Create(p, auxs, n.headTime())
+	if err != nil {
+		return nil, err
+	}
+	return api.NewCreateTransactionResponse(txn, visor.NewTransactionInputsFromUxBalance(uxb))
+}
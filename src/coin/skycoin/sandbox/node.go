@@ -0,0 +1,393 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	skyparams "github.com/SkycoinProject/skycoin/src/params"
+	"github.com/SkycoinProject/skycoin/src/util/droplet"
+	"github.com/SkycoinProject/skycoin/src/util/fee"
+	"github.com/SkycoinProject/skycoin/src/visor"
+	"github.com/SkycoinProject/skycoin/src/visor/historydb"
+	"github.com/SkycoinProject/skycoin/src/wallet"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logSandbox = logging.MustGetLogger("Skycoin sandbox")
+
+const (
+	// URLScheme prefix of node addresses selecting an in-process sandbox node instead of a remote node
+	URLScheme = "sandbox://"
+	// CoinName coin name reported by sandbox nodes
+	CoinName = "sandbox"
+	// Version reported by sandbox nodes
+	Version = "0.27.0"
+	// DefaultGenesisCoins droplets held by faucet address in genesis block
+	DefaultGenesisCoins = 100e6 * droplet.Multiplier
+)
+
+var (
+	// ErrNoTransactions no pending transaction to include in a new block
+	ErrNoTransactions = errors.New("No pending transactions to include in block")
+	// ErrUnknownTransaction transaction not found in blockchain nor in mempool
+	ErrUnknownTransaction = errors.New("Transaction not found")
+	// ErrUnknownBlock block sequence beyond chain tip
+	ErrUnknownBlock = errors.New("Block not found")
+	// ErrUnknownOutput output never created by any transaction
+	ErrUnknownOutput = errors.New("Output not found")
+	// ErrUnknownWallet no sandbox wallet with the given ID
+	ErrUnknownWallet = errors.New("Wallet not found")
+	// ErrDoubleSpend transaction spends outputs already spent by a pending transaction
+	ErrDoubleSpend = errors.New("Transaction spends outputs of a pending transaction")
+	// ErrSpendingUnconfirmed outputs are being spent by pending transactions
+	ErrSpendingUnconfirmed = errors.New("Outputs are being spent by pending transactions")
+	// ErrFaucetDry faucet holds less coins or hours than requested
+	ErrFaucetDry = errors.New("Faucet does not hold enough coins or hours")
+)
+
+var (
+	nodesMutex sync.Mutex
+	nodes      = make(map[string]*Node)
+)
+
+// GetNode returns sandbox node registered under name, creating it on first use
+func GetNode(name string) *Node {
+	nodesMutex.Lock()
+	defer nodesMutex.Unlock()
+	if node, isKnown := nodes[name]; isKnown {
+		return node
+	}
+	logSandbox.WithField("name", name).Info("Creating sandbox node")
+	node := NewNode()
+	nodes[name] = node
+	return node
+}
+
+// LookupURL returns sandbox node selected by node address, if any
+func LookupURL(url string) (*Node, bool) {
+	if !strings.HasPrefix(url, URLScheme) {
+		return nil, false
+	}
+	return GetNode(strings.TrimPrefix(url, URLScheme)), true
+}
+
+// confirmedTxn transaction included in a block
+type confirmedTxn struct {
+	txn coin.Transaction
+	seq uint64
+}
+
+// Node simulates a Skycoin node in process.
+// It keeps a real blockchain, unspent output set, mempool and wallets
+// and applies the same verification rules as Skycoin nodes.
+type Node struct { // Implements SkycoinAPI interface
+	mutex sync.RWMutex
+
+	verifyParams skyparams.VerifyTxn
+	cryptoType   wallet.CryptoType
+	autoMine     bool
+	timeOffset   time.Duration
+
+	faucetKey cipher.SecKey
+	faucet    cipher.Address
+
+	blocks    []coin.SignedBlock
+	unspent   map[cipher.SHA256]coin.UxOut
+	history   map[cipher.SHA256]historydb.UxOut
+	confirmed map[cipher.SHA256]confirmedTxn
+	mempool   []visor.UnconfirmedTransaction
+	spending  map[cipher.SHA256]cipher.SHA256
+
+	wallets   map[string]wallet.Wallet
+	walletSeq int
+	walletIDs []string
+}
+
+// NewNode creates a sandbox node whose genesis block assigns DefaultGenesisCoins to faucet address.
+// Injected transactions are confirmed right away until auto-mining is turned off.
+func NewNode() *Node {
+	pk, sk := cipher.MustGenerateDeterministicKeyPair([]byte("fibercryptowallet sandbox faucet"))
+	n := &Node{
+		verifyParams: skyparams.UserVerifyTxn,
+		// Keep wallet encryption fast, sandbox wallets hold no real value
+		cryptoType: wallet.CryptoTypeSha256Xor,
+		autoMine:   true,
+		faucetKey:  sk,
+		faucet:     cipher.AddressFromPubKey(pk),
+		unspent:    make(map[cipher.SHA256]coin.UxOut),
+		history:    make(map[cipher.SHA256]historydb.UxOut),
+		confirmed:  make(map[cipher.SHA256]confirmedTxn),
+		spending:   make(map[cipher.SHA256]cipher.SHA256),
+		wallets:    make(map[string]wallet.Wallet),
+	}
+	genesis, err := coin.NewGenesisBlock(n.faucet, DefaultGenesisCoins, uint64(n.now().Unix()))
+	if err != nil {
+		logSandbox.WithError(err).Panic("Couldn't create genesis block")
+	}
+	n.applyBlock(n.signBlock(*genesis))
+	return n
+}
+
+func (n *Node) now() time.Time {
+	return time.Now().Add(n.timeOffset)
+}
+
+func (n *Node) head() *coin.SignedBlock {
+	return &n.blocks[len(n.blocks)-1]
+}
+
+func (n *Node) headTime() uint64 {
+	return n.head().Time()
+}
+
+func (n *Node) signBlock(b coin.Block) coin.SignedBlock {
+	return coin.SignedBlock{
+		Block: b,
+		Sig:   cipher.MustSignHash(b.HashHeader(), n.faucetKey),
+	}
+}
+
+// applyBlock appends block to the chain and updates unspent output set
+func (n *Node) applyBlock(b coin.SignedBlock) {
+	n.blocks = append(n.blocks, b)
+	for _, txn := range b.Body.Transactions {
+		txid := txn.Hash()
+		for _, in := range txn.In {
+			spent := n.history[in]
+			spent.SpentTxnID = txid
+			spent.SpentBlockSeq = b.Seq()
+			n.history[in] = spent
+			delete(n.unspent, in)
+		}
+		for _, ux := range coin.CreateUnspents(b.Head, txn) {
+			n.unspent[ux.Hash()] = ux
+			n.history[ux.Hash()] = historydb.UxOut{Out: ux}
+		}
+		n.confirmed[txid] = confirmedTxn{txn: txn, seq: b.Seq()}
+	}
+}
+
+// uxHash summarizes unspent output set
+func (n *Node) uxHash() cipher.SHA256 {
+	var h cipher.SHA256
+	for _, ux := range n.unspent {
+		sh := ux.SnapshotHash()
+		for i := range h {
+			h[i] ^= sh[i]
+		}
+	}
+	return h
+}
+
+// inputs resolves outputs spent by transaction from unspent output set
+func (n *Node) inputs(txn *coin.Transaction) (coin.UxArray, error) {
+	uxIn := make(coin.UxArray, len(txn.In))
+	for i, in := range txn.In {
+		ux, isUnspent := n.unspent[in]
+		if !isUnspent {
+			return nil, fmt.Errorf("Unspent output %s does not exist", in.Hex())
+		}
+		uxIn[i] = ux
+	}
+	return uxIn, nil
+}
+
+// historyInputs resolves outputs spent by transaction, including already spent ones
+func (n *Node) historyInputs(txn *coin.Transaction) coin.UxArray {
+	uxIn := make(coin.UxArray, len(txn.In))
+	for i, in := range txn.In {
+		uxIn[i] = n.history[in].Out
+	}
+	return uxIn
+}
+
+// verify applies the rules of Skycoin nodes for transactions created by users
+func (n *Node) verify(txn *coin.Transaction, signed visor.TxnSignedFlag) (coin.UxArray, error) {
+	uxIn, err := n.inputs(txn)
+	if err != nil {
+		return nil, visor.NewErrTxnViolatesHardConstraint(err)
+	}
+	if err := visor.VerifySingleTxnUserConstraints(*txn); err != nil {
+		return nil, err
+	}
+	if err := visor.VerifySingleTxnSoftConstraints(*txn, n.headTime(), uxIn, skyparams.Distribution{}, n.verifyParams); err != nil {
+		return nil, err
+	}
+	if err := visor.VerifySingleTxnHardConstraints(*txn, n.head().Head, uxIn, signed); err != nil {
+		return nil, err
+	}
+	return uxIn, nil
+}
+
+// inject verifies transaction and adds it to mempool
+func (n *Node) inject(txn coin.Transaction) error {
+	txid := txn.Hash()
+	if _, isConfirmed := n.confirmed[txid]; isConfirmed {
+		return nil
+	}
+	for _, utx := range n.mempool {
+		if utx.Transaction.Hash() == txid {
+			return nil
+		}
+	}
+	if _, err := n.verify(&txn, visor.TxnSigned); err != nil {
+		return err
+	}
+	for _, in := range txn.In {
+		if _, isSpent := n.spending[in]; isSpent {
+			return ErrDoubleSpend
+		}
+	}
+	for _, in := range txn.In {
+		n.spending[in] = txid
+	}
+	n.mempool = append(n.mempool, visor.NewUnconfirmedTransaction(txn))
+	logSandbox.WithField("txid", txid.Hex()).Info("Transaction injected")
+	if n.autoMine {
+		_, err := n.mine()
+		return err
+	}
+	return nil
+}
+
+// mine confirms valid pending transactions in a new block
+func (n *Node) mine() (*coin.SignedBlock, error) {
+	txns := make(coin.Transactions, 0, len(n.mempool))
+	uxIns := make(map[cipher.SHA256]coin.UxArray)
+	for _, utx := range n.mempool {
+		txn := utx.Transaction
+		uxIn, err := n.inputs(&txn)
+		if err == nil {
+			err = visor.VerifyBlockTxnConstraints(txn, n.head().Head, uxIn)
+		}
+		if err != nil {
+			logSandbox.WithError(err).WithField("txid", txn.Hash().Hex()).Warn("Dropping invalid pending transaction")
+			continue
+		}
+		txns = append(txns, txn)
+		uxIns[txn.Hash()] = uxIn
+	}
+	n.mempool = nil
+	n.spending = make(map[cipher.SHA256]cipher.SHA256)
+	if len(txns) == 0 {
+		return nil, ErrNoTransactions
+	}
+	headTime := n.headTime()
+	feeCalc := func(txn *coin.Transaction) (uint64, error) {
+		return fee.TransactionFee(txn, headTime, uxIns[txn.Hash()])
+	}
+	blockTime := uint64(n.now().Unix())
+	if blockTime <= headTime {
+		blockTime = headTime + 1
+	}
+	b, err := coin.NewBlock(n.head().Block, blockTime, n.uxHash(), txns, feeCalc)
+	if err != nil {
+		return nil, err
+	}
+	sb := n.signBlock(*b)
+	n.applyBlock(sb)
+	logSandbox.WithField("seq", sb.Seq()).WithField("txns", len(txns)).Info("Block mined")
+	return &sb, nil
+}
+
+// MineBlock confirms pending transactions in a new block
+func (n *Node) MineBlock() (*coin.SignedBlock, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	return n.mine()
+}
+
+// SetAutoMine determines whether injected transactions are confirmed right away
+func (n *Node) SetAutoMine(autoMine bool) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.autoMine = autoMine
+}
+
+// AdvanceTime moves the sandbox clock forward so that coin hours accumulate in blocks mined later
+func (n *Node) AdvanceTime(d time.Duration) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.timeOffset += d
+}
+
+// FaucetAddress holds the coins distributed by Mint
+func (n *Node) FaucetAddress() string {
+	return n.faucet.String()
+}
+
+// Mint sends coins and hours from faucet to address in a new block, leaving pending transactions untouched.
+// Coins are expressed in droplets.
+func (n *Node) Mint(address string, coins, hours uint64) (string, error) {
+	addr, err := cipher.DecodeBase58Address(address)
+	if err != nil {
+		return "", err
+	}
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	headTime := n.headTime()
+	txn := coin.Transaction{}
+	var inCoins, inHours uint64
+	for h, ux := range n.unspent {
+		if ux.Body.Address != n.faucet {
+			continue
+		}
+		uxHours, err := ux.CoinHours(headTime)
+		if err != nil {
+			return "", err
+		}
+		inCoins += ux.Body.Coins
+		inHours += uxHours
+		if err := txn.PushInput(h); err != nil {
+			return "", err
+		}
+	}
+	remainingHours := fee.RemainingHours(inHours, n.verifyParams.BurnFactor)
+	if coins == 0 || coins >= inCoins || hours > remainingHours {
+		return "", ErrFaucetDry
+	}
+	if err := txn.PushOutput(addr, coins, hours); err != nil {
+		return "", err
+	}
+	if err := txn.PushOutput(n.faucet, inCoins-coins, remainingHours-hours); err != nil {
+		return "", err
+	}
+	keys := make([]cipher.SecKey, len(txn.In))
+	for i := range keys {
+		keys[i] = n.faucetKey
+	}
+	txn.SignInputs(keys)
+	if err := txn.UpdateHeader(); err != nil {
+		return "", err
+	}
+	uxIn, err := n.verify(&txn, visor.TxnSigned)
+	if err != nil {
+		return "", err
+	}
+	feeCalc := func(*coin.Transaction) (uint64, error) {
+		return fee.TransactionFee(&txn, headTime, uxIn)
+	}
+	blockTime := uint64(n.now().Unix())
+	if blockTime <= headTime {
+		blockTime = headTime + 1
+	}
+	b, err := coin.NewBlock(n.head().Block, blockTime, n.uxHash(), coin.Transactions{txn}, feeCalc)
+	if err != nil {
+		return "", err
+	}
+	n.applyBlock(n.signBlock(*b))
+	logSandbox.WithField("address", address).WithField("coins", coins).Info("Coins minted")
+	return txn.Hash().Hex(), nil
+}
+
+// Type assertions
+var (
+	_ skytypes.SkycoinAPI = &Node{}
+)
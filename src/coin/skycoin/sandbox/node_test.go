@@ -0,0 +1,197 @@
+package sandbox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	"github.com/SkycoinProject/skycoin/src/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const testSeed = "sandbox test seed"
+
+func createTestWallet(t *testing.T, n *Node) (string, string) {
+	wr, err := n.CreateWallet(api.CreateWalletOptions{Type: "deterministic", Seed: testSeed, Label: "Test"})
+	require.NoError(t, err)
+	require.Len(t, wr.Entries, 1)
+	return wr.Meta.Filename, wr.Entries[0].Address
+}
+
+func TestNodeMint(t *testing.T) {
+	n := NewNode()
+	_, addr := createTestWallet(t, n)
+
+	txid, err := n.Mint(addr, 100e6, 1000)
+	require.NoError(t, err)
+	txn, err := n.Transaction(txid)
+	require.NoError(t, err)
+	require.True(t, txn.Status.Confirmed)
+	require.Equal(t, uint64(1), txn.Status.BlockSeq)
+
+	bal, err := n.Balance([]string{addr})
+	require.NoError(t, err)
+	require.Equal(t, uint64(100e6), bal.Confirmed.Coins)
+	require.Equal(t, uint64(1000), bal.Confirmed.Hours)
+
+	_, err = n.Mint(addr, DefaultGenesisCoins, 0)
+	require.Equal(t, ErrFaucetDry, err)
+
+	supply, err := n.CoinSupply()
+	require.NoError(t, err)
+	require.Equal(t, "100.000000", supply.CurrentSupply)
+
+	progress, err := n.BlockchainProgress()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), progress.Current)
+}
+
+func TestNodeWalletSend(t *testing.T) {
+	n := NewNode()
+	wltID, addr := createTestWallet(t, n)
+	_, err := n.Mint(addr, 100e6, 1000)
+	require.NoError(t, err)
+	n.SetAutoMine(false)
+
+	dest := testutil.MakeAddress().String()
+	req := api.WalletCreateTransactionRequest{
+		WalletID: wltID,
+		CreateTransactionRequest: api.CreateTransactionRequest{
+			HoursSelection: api.HoursSelection{Type: "auto", Mode: "share", ShareFactor: "0.5"},
+			To:             []api.Receiver{{Address: dest, Coins: "10"}},
+		},
+	}
+	txnR, err := n.WalletCreateTransaction(req)
+	require.NoError(t, err)
+	txid, err := n.InjectEncodedTransaction(txnR.EncodedTransaction)
+	require.NoError(t, err)
+	require.Equal(t, txnR.Transaction.TxID, txid)
+
+	// Pending transaction is visible but not confirmed
+	pending, err := n.PendingTransactionsVerbose()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	bal, err := n.Balance([]string{dest})
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), bal.Confirmed.Coins)
+	require.Equal(t, uint64(10e6), bal.Predicted.Coins)
+	outs, err := n.OutputsForAddresses([]string{addr})
+	require.NoError(t, err)
+	require.Len(t, outs.OutgoingOutputs, 1)
+
+	// Outputs being spent can't be spent again
+	_, err = n.WalletCreateTransaction(req)
+	require.Equal(t, ErrSpendingUnconfirmed, err)
+
+	b, err := n.MineBlock()
+	require.NoError(t, err)
+	require.Len(t, b.Body.Transactions, 1)
+	_, err = n.MineBlock()
+	require.Equal(t, ErrNoTransactions, err)
+
+	bal, err = n.WalletBalance(wltID)
+	require.NoError(t, err)
+	require.Equal(t, uint64(90e6), bal.Confirmed.Coins)
+	txns, err := n.TransactionsVerbose([]string{dest})
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+	require.True(t, txns[0].Status.Confirmed)
+	require.Equal(t, addr, txns[0].Transaction.In[0].Address)
+}
+
+func TestNodeInjectInvalid(t *testing.T) {
+	n := NewNode()
+	wltID, addr := createTestWallet(t, n)
+	_, err := n.Mint(addr, 100e6, 1000)
+	require.NoError(t, err)
+
+	txnR, err := n.WalletCreateTransaction(api.WalletCreateTransactionRequest{
+		WalletID: wltID,
+		Unsigned: true,
+		CreateTransactionRequest: api.CreateTransactionRequest{
+			HoursSelection: api.HoursSelection{Type: "auto", Mode: "share", ShareFactor: "0.5"},
+			To:             []api.Receiver{{Address: testutil.MakeAddress().String(), Coins: "1"}},
+		},
+	})
+	require.NoError(t, err)
+	// Unsigned transactions are rejected
+	_, err = n.InjectEncodedTransaction(txnR.EncodedTransaction)
+	require.Error(t, err)
+
+	signed, err := n.WalletSignTransaction(api.WalletSignTransactionRequest{
+		WalletID:           wltID,
+		EncodedTransaction: txnR.EncodedTransaction,
+	})
+	require.NoError(t, err)
+	_, err = n.InjectEncodedTransaction(signed.EncodedTransaction)
+	require.NoError(t, err)
+
+	// Spent outputs are rejected
+	txn, err := coin.DeserializeTransactionHex(signed.EncodedTransaction)
+	require.NoError(t, err)
+	txn.Out[0].Coins += 1e6
+	require.NoError(t, txn.UpdateHeader())
+	_, err = n.InjectTransaction(&txn)
+	require.Error(t, err)
+}
+
+func TestNodeCoinHours(t *testing.T) {
+	n := NewNode()
+	_, addr := createTestWallet(t, n)
+	_, err := n.Mint(addr, 100e6, 0)
+	require.NoError(t, err)
+
+	n.AdvanceTime(24 * time.Hour)
+	_, err = n.Mint(testutil.MakeAddress().String(), 1e6, 0)
+	require.NoError(t, err)
+
+	bal, err := n.Balance([]string{addr})
+	require.NoError(t, err)
+	// Block times are truncated to seconds
+	require.InDelta(t, 2400, bal.Confirmed.Hours, 1)
+}
+
+func TestLookupURL(t *testing.T) {
+	_, isSandbox := LookupURL("https://node.skycoin.com")
+	require.False(t, isSandbox)
+	n, isSandbox := LookupURL("sandbox://lookup")
+	require.True(t, isSandbox)
+	require.Equal(t, n, GetNode("lookup"))
+}
+
+func TestNodeVerifyTransaction(t *testing.T) {
+	n := NewNode()
+	wltID, addr := createTestWallet(t, n)
+	_, err := n.Mint(addr, 100e6, 1000)
+	require.NoError(t, err)
+
+	txnR, err := n.WalletCreateTransaction(api.WalletCreateTransactionRequest{
+		WalletID: wltID,
+		Unsigned: true,
+		CreateTransactionRequest: api.CreateTransactionRequest{
+			HoursSelection: api.HoursSelection{Type: "auto", Mode: "share", ShareFactor: "0.5"},
+			To:             []api.Receiver{{Address: testutil.MakeAddress().String(), Coins: "1"}},
+		},
+	})
+	require.NoError(t, err)
+	_, err = n.VerifyTransaction(api.VerifyTransactionRequest{EncodedTransaction: txnR.EncodedTransaction})
+	require.Error(t, err)
+	verified, err := n.VerifyTransaction(api.VerifyTransactionRequest{Unsigned: true, EncodedTransaction: txnR.EncodedTransaction})
+	require.NoError(t, err)
+	require.True(t, verified.Unsigned)
+	require.Equal(t, addr, verified.Transaction.In[0].Address)
+
+	signed, err := n.WalletSignTransaction(api.WalletSignTransactionRequest{
+		WalletID:           wltID,
+		EncodedTransaction: txnR.EncodedTransaction,
+	})
+	require.NoError(t, err)
+	verified, err = n.VerifyTransaction(api.VerifyTransactionRequest{EncodedTransaction: signed.EncodedTransaction})
+	require.NoError(t, err)
+	require.False(t, verified.Unsigned)
+
+	// Verified transactions are not injected
+	_, err = n.MineBlock()
+	require.Equal(t, ErrNoTransactions, err)
+}
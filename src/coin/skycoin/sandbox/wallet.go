@@ -0,0 +1,342 @@
+package sandbox
+
+import (
+	"fmt"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	"github.com/SkycoinProject/skycoin/src/transaction"
+	"github.com/SkycoinProject/skycoin/src/visor"
+	"github.com/SkycoinProject/skycoin/src/wallet"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+)
+
+// spendable selects outputs to spend in transaction creation request.
+// Wallet addresses are used if the request does not specify any output nor address.
+func (n *Node) spendable(req api.CreateTransactionRequest, w wallet.Wallet) (coin.AddressUxOuts, error) {
+	uxs := make(coin.UxArray, 0)
+	if len(req.UxOuts) != 0 {
+		for _, uxID := range req.UxOuts {
+			h, err := cipher.SHA256FromHex(uxID)
+			if err != nil {
+				return nil, err
+			}
+			ux, isUnspent := n.unspent[h]
+			if !isUnspent {
+				return nil, ErrUnknownOutput
+			}
+			if _, isSpent := n.spending[h]; isSpent {
+				return nil, ErrSpendingUnconfirmed
+			}
+			uxs = append(uxs, ux)
+		}
+		return coin.NewAddressUxOuts(uxs), nil
+	}
+
+	addrs := req.Addresses
+	if len(addrs) == 0 && w != nil {
+		skyAddrs, err := w.GetSkycoinAddresses()
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range skyAddrs {
+			addrs = append(addrs, addr.String())
+		}
+	}
+	set, err := newAddressSet(addrs)
+	if err != nil {
+		return nil, err
+	}
+	for h, ux := range n.unspent {
+		if !set.has(ux.Body.Address) {
+			continue
+		}
+		if _, isSpent := n.spending[h]; isSpent {
+			if req.IgnoreUnconfirmed {
+				continue
+			}
+			return nil, ErrSpendingUnconfirmed
+		}
+		uxs = append(uxs, ux)
+	}
+	uxs.Sort()
+	return coin.NewAddressUxOuts(uxs), nil
+}
+
+func (n *Node) wallet(id string) (wallet.Wallet, error) {
+	w, isKnown := n.wallets[id]
+	if !isKnown {
+		return nil, ErrUnknownWallet
+	}
+	return w, nil
+}
+
+// guardUpdate runs fn against decrypted wallet, updating stored wallet with its changes
+func guardUpdate(w wallet.Wallet, password string, fn func(w wallet.Wallet) error) error {
+	if !w.IsEncrypted() {
+		return fn(w)
+	}
+	return wallet.GuardUpdate(w, []byte(password), fn)
+}
+
+// addressesActivity lets Skycoin wallets scan addresses of the sandbox chain
+type addressesActivity struct {
+	n *Node
+}
+
+// AddressesActivity determines whether addresses ever received coins
+func (aa addressesActivity) AddressesActivity(addrs []cipher.Address) ([]bool, error) {
+	active := make([]bool, len(addrs))
+	for i, addr := range addrs {
+		for _, ux := range aa.n.history {
+			if ux.Out.Body.Address == addr {
+				active[i] = true
+				break
+			}
+		}
+	}
+	return active, nil
+}
+
+// Wallet Get wallet
+func (n *Node) Wallet(id string) (*api.WalletResponse, error) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	w, err := n.wallet(id)
+	if err != nil {
+		return nil, err
+	}
+	return api.NewWalletResponse(w)
+}
+
+// UpdateWallet Change wallet label
+func (n *Node) UpdateWallet(id, label string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	w, err := n.wallet(id)
+	if err != nil {
+		return err
+	}
+	w.SetLabel(label)
+	return nil
+}
+
+// NewWalletAddress Generate new address in wallet
+func (n *Node) NewWalletAddress(id string, num int, password string) ([]string, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	w, err := n.wallet(id)
+	if err != nil {
+		return nil, err
+	}
+	var addrs []cipher.Address
+	err = guardUpdate(w, password, func(w wallet.Wallet) error {
+		var err error
+		addrs, err = w.GenerateSkycoinAddresses(uint64(num))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	strAddrs := make([]string, len(addrs))
+	for i, addr := range addrs {
+		strAddrs[i] = addr.String()
+	}
+	return strAddrs, nil
+}
+
+// Wallets Get wallets
+func (n *Node) Wallets() ([]api.WalletResponse, error) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	wallets := make([]api.WalletResponse, len(n.walletIDs))
+	for i, id := range n.walletIDs {
+		wr, err := api.NewWalletResponse(n.wallets[id])
+		if err != nil {
+			return nil, err
+		}
+		wallets[i] = *wr
+	}
+	return wallets, nil
+}
+
+// CreateWallet Create wallet
+func (n *Node) CreateWallet(o api.CreateWalletOptions) (*api.WalletResponse, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	opts := wallet.Options{
+		Type:           o.Type,
+		Coin:           wallet.CoinTypeSkycoin,
+		Label:          o.Label,
+		Seed:           o.Seed,
+		SeedPassphrase: o.SeedPassphrase,
+		Encrypt:        o.Encrypt,
+		Password:       []byte(o.Password),
+		CryptoType:     n.cryptoType,
+		XPub:           o.XPub,
+	}
+	if opts.Type == "" {
+		opts.Type = wallet.WalletTypeDeterministic
+	}
+	if opts.Type != wallet.WalletTypeCollection {
+		opts.GenerateN = 1
+		if o.ScanN > 1 {
+			opts.ScanN = uint64(o.ScanN)
+		}
+	}
+	n.walletSeq++
+	id := fmt.Sprintf("sandbox_%d.%s", n.walletSeq, wallet.WalletExt)
+	w, err := wallet.NewWalletScanAhead(id, opts, addressesActivity{n})
+	if err != nil {
+		return nil, err
+	}
+	n.wallets[id] = w
+	n.walletIDs = append(n.walletIDs, id)
+	return api.NewWalletResponse(w)
+}
+
+// EncryptWallet Encrypt wallet
+func (n *Node) EncryptWallet(id, password string) (*api.WalletResponse, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	w, err := n.wallet(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := wallet.Lock(w, []byte(password), n.cryptoType); err != nil {
+		return nil, err
+	}
+	return api.NewWalletResponse(w)
+}
+
+// DecryptWallet Decrypt wallet
+func (n *Node) DecryptWallet(id, password string) (*api.WalletResponse, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	w, err := n.wallet(id)
+	if err != nil {
+		return nil, err
+	}
+	if !w.IsEncrypted() {
+		return nil, wallet.ErrWalletNotEncrypted
+	}
+	decrypted, err := wallet.Unlock(w, []byte(password))
+	if err != nil {
+		return nil, err
+	}
+	n.wallets[id] = decrypted
+	return api.NewWalletResponse(decrypted)
+}
+
+func (n *Node) walletAddresses(id string) (addressSet, error) {
+	w, err := n.wallet(id)
+	if err != nil {
+		return nil, err
+	}
+	addrs, err := w.GetSkycoinAddresses()
+	if err != nil {
+		return nil, err
+	}
+	set := make(addressSet, len(addrs))
+	for _, addr := range addrs {
+		set[addr] = struct{}{}
+	}
+	return set, nil
+}
+
+// WalletBalance Get wallet balance
+func (n *Node) WalletBalance(id string) (*api.BalanceResponse, error) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	set, err := n.walletAddresses(id)
+	if err != nil {
+		return nil, err
+	}
+	return n.balances(set)
+}
+
+// WalletUnconfirmedTransactionsVerbose Get unconfirmed transactions of a wallet
+func (n *Node) WalletUnconfirmedTransactionsVerbose(id string) (*api.UnconfirmedTxnsVerboseResponse, error) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	set, err := n.walletAddresses(id)
+	if err != nil {
+		return nil, err
+	}
+	txns, err := n.pendingTxns(set)
+	if err != nil {
+		return nil, err
+	}
+	return &api.UnconfirmedTxnsVerboseResponse{Transactions: txns}, nil
+}
+
+// WalletSignTransaction Sign transaction
+func (n *Node) WalletSignTransaction(req api.WalletSignTransactionRequest) (*api.CreateTransactionResponse, error) {
+	txn, err := coin.DeserializeTransactionHex(req.EncodedTransaction)
+	if err != nil {
+		return nil, err
+	}
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	w, err := n.wallet(req.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	uxIn, err := n.inputs(&txn)
+	if err != nil {
+		return nil, err
+	}
+	var signed *coin.Transaction
+	err = guardUpdate(w, req.Password, func(w wallet.Wallet) error {
+		var err error
+		signed, err = wallet.SignTransaction(w, &txn, req.SignIndexes, uxIn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := visor.NewTransactionInputs(uxIn, n.headTime())
+	if err != nil {
+		return nil, err
+	}
+	return api.NewCreateTransactionResponse(signed, inputs)
+}
+
+// WalletCreateTransaction Create transaction from wallet addresses
+func (n *Node) WalletCreateTransaction(req api.WalletCreateTransactionRequest) (*api.CreateTransactionResponse, error) {
+	p, err := skytypes.NewTxnParams(req.CreateTransactionRequest)
+	if err != nil {
+		return nil, err
+	}
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	w, err := n.wallet(req.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	auxs, err := n.spendable(req.CreateTransactionRequest, w)
+	if err != nil {
+		return nil, err
+	}
+	var txn *coin.Transaction
+	var uxb []transaction.UxBalance
+	if req.Unsigned {
+		txn, uxb, err = wallet.CreateTransaction(w, p, auxs, n.headTime())
+	} else {
+		err = guardUpdate(w, req.Password, func(w wallet.Wallet) error {
+			var err error
+			txn, uxb, err = wallet.CreateTransactionSigned(w, p, auxs, n.headTime())
+			return err
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return api.NewCreateTransactionResponse(txn, visor.NewTransactionInputsFromUxBalance(uxb))
+}
+
+// Type assertions
+var (
+	_ wallet.TransactionsFinder = addressesActivity{}
+)
@@ -35,6 +35,52 @@ func (_m *SkycoinAPI) Balance(addrs []string) (*api.BalanceResponse, error) {
 	return r0, r1
 }
 
+// BlockByHash provides a mock function with given fields: hash
+func (_m *SkycoinAPI) BlockByHash(hash string) (*readable.Block, error) {
+	ret := _m.Called(hash)
+
+	var r0 *readable.Block
+	if rf, ok := ret.Get(0).(func(string) *readable.Block); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*readable.Block)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BlockBySeq provides a mock function with given fields: seq
+func (_m *SkycoinAPI) BlockBySeq(seq uint64) (*readable.Block, error) {
+	ret := _m.Called(seq)
+
+	var r0 *readable.Block
+	if rf, ok := ret.Get(0).(func(uint64) *readable.Block); ok {
+		r0 = rf(seq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*readable.Block)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(seq)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BlockchainProgress provides a mock function with given fields:
 func (_m *SkycoinAPI) BlockchainProgress() (*readable.BlockchainProgress, error) {
 	ret := _m.Called()
@@ -173,6 +219,29 @@ func (_m *SkycoinAPI) EncryptWallet(id string, password string) (*api.WalletResp
 	return r0, r1
 }
 
+// Health provides a mock function with given fields:
+func (_m *SkycoinAPI) Health() (*api.HealthResponse, error) {
+	ret := _m.Called()
+
+	var r0 *api.HealthResponse
+	if rf, ok := ret.Get(0).(func() *api.HealthResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.HealthResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InjectEncodedTransaction provides a mock function with given fields: rawTxn
 func (_m *SkycoinAPI) InjectEncodedTransaction(rawTxn string) (string, error) {
 	ret := _m.Called(rawTxn)
@@ -459,6 +528,29 @@ func (_m *SkycoinAPI) UxOut(uxID string) (*readable.SpentOutput, error) {
 	return r0, r1
 }
 
+// VerifyTransaction provides a mock function with given fields: req
+func (_m *SkycoinAPI) VerifyTransaction(req api.VerifyTransactionRequest) (*api.VerifyTransactionResponse, error) {
+	ret := _m.Called(req)
+
+	var r0 *api.VerifyTransactionResponse
+	if rf, ok := ret.Get(0).(func(api.VerifyTransactionRequest) *api.VerifyTransactionResponse); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.VerifyTransactionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(api.VerifyTransactionRequest) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Wallet provides a mock function with given fields: id
 func (_m *SkycoinAPI) Wallet(id string) (*api.WalletResponse, error) {
 	ret := _m.Called(id)
@@ -26,6 +26,12 @@ type SkycoinAPI interface {
 	LastBlocks(n uint64) (*readable.Blocks, error)
 	// BlockchainProgress Get blockchain progress
 	BlockchainProgress() (*readable.BlockchainProgress, error)
+	// BlockBySeq Get block by sequence number
+	BlockBySeq(seq uint64) (*readable.Block, error)
+	// BlockByHash Get block by header hash
+	BlockByHash(hash string) (*readable.Block, error)
+	// Health Get node health, version and coin identity
+	Health() (*api.HealthResponse, error)
 	// Balance Get balance of addresses
 	Balance(addrs []string) (*api.BalanceResponse, error)
 	// OutputsForAddresses Get historical unspent outputs for an address
@@ -54,6 +60,8 @@ type SkycoinAPI interface {
 	InjectTransaction(txn *coin.Transaction) (string, error)
 	// InjectTransaction Inject raw transaction
 	InjectEncodedTransaction(rawTxn string) (string, error)
+	// VerifyTransaction Decode and verify raw transaction without injecting it
+	VerifyTransaction(req api.VerifyTransactionRequest) (*api.VerifyTransactionResponse, error)
 	// WalletSignTransaction Sign transaction
 	WalletSignTransaction(req api.WalletSignTransactionRequest) (*api.CreateTransactionResponse, error)
 	// WalletCreateTransaction Create transaction from wallet addresses
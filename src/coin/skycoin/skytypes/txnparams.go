@@ -0,0 +1,55 @@
+package skytypes
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	"github.com/SkycoinProject/skycoin/src/transaction"
+	"github.com/SkycoinProject/skycoin/src/util/droplet"
+)
+
+// NewTxnParams decodes transaction creation request as Skycoin nodes do
+func NewTxnParams(req api.CreateTransactionRequest) (transaction.Params, error) {
+	p := transaction.Params{
+		HoursSelection: transaction.HoursSelection{
+			Type: req.HoursSelection.Type,
+			Mode: req.HoursSelection.Mode,
+		},
+		To: make([]coin.TransactionOutput, len(req.To)),
+	}
+	if req.HoursSelection.ShareFactor != "" {
+		// Decimal type is vendored by Skycoin, so decode it from JSON as Skycoin API does
+		shareFactor := []byte(strconv.Quote(req.HoursSelection.ShareFactor))
+		if err := json.Unmarshal(shareFactor, &p.HoursSelection.ShareFactor); err != nil {
+			return p, err
+		}
+	}
+	if req.ChangeAddress != nil {
+		change, err := cipher.DecodeBase58Address(*req.ChangeAddress)
+		if err != nil {
+			return p, err
+		}
+		p.ChangeAddress = &change
+	}
+	for i, to := range req.To {
+		addr, err := cipher.DecodeBase58Address(to.Address)
+		if err != nil {
+			return p, err
+		}
+		coins, err := droplet.FromString(to.Coins)
+		if err != nil {
+			return p, err
+		}
+		var hours uint64
+		if to.Hours != "" {
+			if hours, err = strconv.ParseUint(to.Hours, 10, 64); err != nil {
+				return p, err
+			}
+		}
+		p.To[i] = coin.TransactionOutput{Address: addr, Coins: coins, Hours: hours}
+	}
+	return p, p.Validate()
+}
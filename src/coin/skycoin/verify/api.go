@@ -0,0 +1,65 @@
+package verify
+
+import (
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/skytypes"
+)
+
+// Wrap decorates client so that transactions and outputs it reports are checked locally.
+// Responses are returned unchanged, inconsistencies are flagged per transaction, see Lookup.
+func Wrap(skyApi skytypes.SkycoinAPI) skytypes.SkycoinAPI {
+	return &verifyingAPI{SkycoinAPI: skyApi}
+}
+
+// verifyingAPI decorates SkycoinAPI client checking node responses.
+// Calls not returning transactions nor outputs are forwarded as is.
+type verifyingAPI struct {
+	skytypes.SkycoinAPI
+}
+
+// TransactionVerbose Get transaction info by id. Include spent input data
+func (va *verifyingAPI) TransactionVerbose(txid string) (*readable.TransactionWithStatusVerbose, error) {
+	res, err := va.SkycoinAPI.TransactionVerbose(txid)
+	if err == nil && res != nil {
+		flag(res.Transaction.Hash, CheckTransaction(res.Transaction.BlockTransactionVerbose))
+	}
+	return res, err
+}
+
+// TransactionsVerbose Get transactions for addresses. Include spent input data
+func (va *verifyingAPI) TransactionsVerbose(addrs []string) ([]readable.TransactionWithStatusVerbose, error) {
+	res, err := va.SkycoinAPI.TransactionsVerbose(addrs)
+	if err == nil {
+		for _, txn := range res {
+			flag(txn.Transaction.Hash, CheckTransaction(txn.Transaction.BlockTransactionVerbose))
+		}
+	}
+	return res, err
+}
+
+// UxOut Get uxout
+func (va *verifyingAPI) UxOut(uxID string) (*readable.SpentOutput, error) {
+	res, err := va.SkycoinAPI.UxOut(uxID)
+	if err == nil && res != nil {
+		flag(res.SrcTx, CheckSpentOutput(*res))
+	}
+	return res, err
+}
+
+// OutputsForAddresses Get historical unspent outputs for an address
+func (va *verifyingAPI) OutputsForAddresses(addrs []string) (*readable.UnspentOutputsSummary, error) {
+	res, err := va.SkycoinAPI.OutputsForAddresses(addrs)
+	if err == nil && res != nil {
+		for _, outs := range []readable.UnspentOutputs{res.HeadOutputs, res.OutgoingOutputs, res.IncomingOutputs} {
+			for _, out := range outs {
+				flag(out.SourceTransaction, CheckUnspentOutput(out, res.Head.Time))
+			}
+		}
+	}
+	return res, err
+}
+
+// Type assertions
+var (
+	_ skytypes.SkycoinAPI = &verifyingAPI{}
+)
@@ -0,0 +1,234 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/SkycoinProject/skycoin/src/coin"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/util/droplet"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logVerify = logging.MustGetLogger("Skycoin response verification")
+
+var (
+	// ErrMalformed node response can not be decoded
+	ErrMalformed = errors.New("Malformed transaction data")
+	// ErrHashMismatch transaction hash does not match its contents
+	ErrHashMismatch = errors.New("Transaction hash does not match contents")
+	// ErrInnerHashMismatch transaction inner hash does not match inputs and outputs
+	ErrInnerHashMismatch = errors.New("Transaction inner hash does not match inputs and outputs")
+	// ErrInvalidSignature input signature not made by input owner
+	ErrInvalidSignature = errors.New("Input signature not made by input owner")
+	// ErrUxIDMismatch output ID does not match its source transaction
+	ErrUxIDMismatch = errors.New("Output ID does not match source transaction")
+	// ErrHoursMismatch calculated hours inconsistent with output hours and age
+	ErrHoursMismatch = errors.New("Calculated hours inconsistent with output")
+)
+
+// Inconsistency problem found in data reported by node about a transaction
+type Inconsistency struct {
+	// Check one of the errors declared by this package
+	Check error
+	// Detail locates problem within transaction, e.g. input index
+	Detail string
+}
+
+// Error describes problem
+func (i *Inconsistency) Error() string {
+	if i.Detail == "" {
+		return i.Check.Error()
+	}
+	return fmt.Sprintf("%v: %s", i.Check, i.Detail)
+}
+
+var (
+	issuesMutex sync.RWMutex
+	// issues inconsistencies found so far indexed by transaction ID
+	issues = make(map[string][]*Inconsistency)
+)
+
+// Lookup returns inconsistencies found so far in data reported about transaction
+func Lookup(txid string) []*Inconsistency {
+	issuesMutex.RLock()
+	defer issuesMutex.RUnlock()
+	return append([]*Inconsistency(nil), issues[txid]...)
+}
+
+// flag records inconsistencies found in transaction, each one only once
+func flag(txid string, found []*Inconsistency) {
+	if len(found) == 0 {
+		return
+	}
+	issuesMutex.Lock()
+	defer issuesMutex.Unlock()
+	known := issues[txid]
+	for _, issue := range found {
+		isKnown := false
+		for _, k := range known {
+			if k.Error() == issue.Error() {
+				isKnown = true
+				break
+			}
+		}
+		if !isKnown {
+			logVerify.WithField("txid", txid).WithError(issue).Warn("Node reported inconsistent data")
+			known = append(known, issue)
+		}
+	}
+	issues[txid] = known
+}
+
+func inconsistency(check error, format string, args ...interface{}) *Inconsistency {
+	return &Inconsistency{Check: check, Detail: fmt.Sprintf(format, args...)}
+}
+
+// CheckTransaction recomputes hashes, output IDs and signatures of transaction reported by node.
+// Input owners are taken from node response.
+func CheckTransaction(rTxn readable.BlockTransactionVerbose) []*Inconsistency {
+	var found []*Inconsistency
+	txn := coin.Transaction{
+		Type: rTxn.Type,
+		In:   make([]cipher.SHA256, len(rTxn.In)),
+		Out:  make([]coin.TransactionOutput, len(rTxn.Out)),
+		Sigs: make([]cipher.Sig, len(rTxn.Sigs)),
+	}
+	owners := make([]cipher.Address, len(rTxn.In))
+	for i, in := range rTxn.In {
+		uxID, err := cipher.SHA256FromHex(in.Hash)
+		if err != nil {
+			return append(found, inconsistency(ErrMalformed, "input %d ID %s", i, in.Hash))
+		}
+		owner, err := cipher.DecodeBase58Address(in.Address)
+		if err != nil {
+			return append(found, inconsistency(ErrMalformed, "input %d owner %s", i, in.Address))
+		}
+		txn.In[i] = uxID
+		owners[i] = owner
+		if in.CalculatedHours < in.Hours {
+			found = append(found, inconsistency(ErrHoursMismatch, "input %d has %d calculated hours, less than %d hours", i, in.CalculatedHours, in.Hours))
+		}
+	}
+	for i, out := range rTxn.Out {
+		addr, err := cipher.DecodeBase58Address(out.Address)
+		if err != nil {
+			return append(found, inconsistency(ErrMalformed, "output %d address %s", i, out.Address))
+		}
+		coins, err := droplet.FromString(out.Coins)
+		if err != nil {
+			return append(found, inconsistency(ErrMalformed, "output %d coins %s", i, out.Coins))
+		}
+		txn.Out[i] = coin.TransactionOutput{Address: addr, Coins: coins, Hours: out.Hours}
+	}
+	for i, sig := range rTxn.Sigs {
+		s, err := cipher.SigFromHex(sig)
+		if err != nil {
+			return append(found, inconsistency(ErrMalformed, "signature %d", i))
+		}
+		txn.Sigs[i] = s
+	}
+
+	if isGenesis := len(txn.In) == 0; isGenesis {
+		// Genesis transaction header is never filled in, so it is hashed as reported
+		innerHash, err := cipher.SHA256FromHex(rTxn.InnerHash)
+		if err != nil {
+			return append(found, inconsistency(ErrMalformed, "inner hash %s", rTxn.InnerHash))
+		}
+		txn.InnerHash = innerHash
+		txn.Length = rTxn.Length
+	} else {
+		txn.InnerHash = txn.HashInner()
+		if !strings.EqualFold(txn.InnerHash.Hex(), rTxn.InnerHash) {
+			found = append(found, inconsistency(ErrInnerHashMismatch, "reported %s, computed %s", rTxn.InnerHash, txn.InnerHash.Hex()))
+		}
+		length, err := txn.Size()
+		if err != nil {
+			return append(found, inconsistency(ErrMalformed, "%v", err))
+		}
+		txn.Length = length
+	}
+	txid := txn.Hash()
+	if !strings.EqualFold(txid.Hex(), rTxn.Hash) {
+		found = append(found, inconsistency(ErrHashMismatch, "reported %s, computed %s", rTxn.Hash, txid.Hex()))
+	}
+	for i, out := range txn.Out {
+		uxID := out.UxID(txid)
+		// Genesis outputs have null source transaction
+		isGenesisOut := len(txn.In) == 0 && strings.EqualFold(out.UxID(cipher.SHA256{}).Hex(), rTxn.Out[i].Hash)
+		if !strings.EqualFold(uxID.Hex(), rTxn.Out[i].Hash) && !isGenesisOut {
+			found = append(found, inconsistency(ErrUxIDMismatch, "output %d reported %s, computed %s", i, rTxn.Out[i].Hash, uxID.Hex()))
+		}
+	}
+	if len(txn.Sigs) != len(txn.In) {
+		return append(found, inconsistency(ErrInvalidSignature, "%d signatures for %d inputs", len(txn.Sigs), len(txn.In)))
+	}
+	for i := range txn.In {
+		hash := cipher.AddSHA256(txn.InnerHash, txn.In[i])
+		if err := cipher.VerifyAddressSignedHash(owners[i], txn.Sigs[i], hash); err != nil {
+			found = append(found, inconsistency(ErrInvalidSignature, "input %d", i))
+		}
+	}
+	return found
+}
+
+// checkUxID compares output ID with the one computed out of source transaction and output contents
+func checkUxID(uxID string, body coin.UxBody) *Inconsistency {
+	if computed := body.Hash(); !strings.EqualFold(computed.Hex(), uxID) {
+		return inconsistency(ErrUxIDMismatch, "output reported %s, computed %s", uxID, computed.Hex())
+	}
+	return nil
+}
+
+// CheckSpentOutput checks that output ID matches source transaction and output contents
+func CheckSpentOutput(out readable.SpentOutput) []*Inconsistency {
+	src, err := cipher.SHA256FromHex(out.SrcTx)
+	if err != nil {
+		return []*Inconsistency{inconsistency(ErrMalformed, "output %s source %s", out.Uxid, out.SrcTx)}
+	}
+	owner, err := cipher.DecodeBase58Address(out.OwnerAddress)
+	if err != nil {
+		return []*Inconsistency{inconsistency(ErrMalformed, "output %s owner %s", out.Uxid, out.OwnerAddress)}
+	}
+	body := coin.UxBody{SrcTransaction: src, Address: owner, Coins: out.Coins, Hours: out.Hours}
+	if issue := checkUxID(out.Uxid, body); issue != nil {
+		return []*Inconsistency{issue}
+	}
+	return nil
+}
+
+// CheckUnspentOutput checks that output ID matches source transaction and output contents
+// and that calculated hours are those accumulated by output at headTime
+func CheckUnspentOutput(out readable.UnspentOutput, headTime uint64) []*Inconsistency {
+	src, err := cipher.SHA256FromHex(out.SourceTransaction)
+	if err != nil {
+		return []*Inconsistency{inconsistency(ErrMalformed, "output %s source %s", out.Hash, out.SourceTransaction)}
+	}
+	owner, err := cipher.DecodeBase58Address(out.Address)
+	if err != nil {
+		return []*Inconsistency{inconsistency(ErrMalformed, "output %s owner %s", out.Hash, out.Address)}
+	}
+	coins, err := droplet.FromString(out.Coins)
+	if err != nil {
+		return []*Inconsistency{inconsistency(ErrMalformed, "output %s coins %s", out.Hash, out.Coins)}
+	}
+	ux := coin.UxOut{
+		Head: coin.UxHead{Time: out.Time, BkSeq: out.BkSeq},
+		Body: coin.UxBody{SrcTransaction: src, Address: owner, Coins: coins, Hours: out.Hours},
+	}
+	var found []*Inconsistency
+	if issue := checkUxID(out.Hash, ux.Body); issue != nil {
+		found = append(found, issue)
+	}
+	hours, err := ux.CoinHours(headTime)
+	if err != nil {
+		return append(found, inconsistency(ErrMalformed, "output %s hours %v", out.Hash, err))
+	}
+	if hours != out.CalculatedHours {
+		found = append(found, inconsistency(ErrHoursMismatch, "output %s reported %d calculated hours, computed %d", out.Hash, out.CalculatedHours, hours))
+	}
+	return found
+}
@@ -0,0 +1,144 @@
+package verify
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/api"
+	"github.com/SkycoinProject/skycoin/src/readable"
+	"github.com/SkycoinProject/skycoin/src/testutil"
+	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/sandbox"
+	"github.com/stretchr/testify/require"
+)
+
+// sendTestTxn confirms a transaction spending minted coins, returns sender and receiver addresses
+func sendTestTxn(t *testing.T, node *sandbox.Node) (string, string) {
+	wr, err := node.CreateWallet(api.CreateWalletOptions{Type: "deterministic", Seed: testutil.RandSHA256(t).Hex(), Label: "Test"})
+	require.NoError(t, err)
+	addr := wr.Entries[0].Address
+	_, err = node.Mint(addr, 100e6, 1000)
+	require.NoError(t, err)
+	dest := testutil.MakeAddress().String()
+	txnR, err := node.WalletCreateTransaction(api.WalletCreateTransactionRequest{
+		WalletID: wr.Meta.Filename,
+		CreateTransactionRequest: api.CreateTransactionRequest{
+			HoursSelection: api.HoursSelection{Type: "auto", Mode: "share", ShareFactor: "0.5"},
+			To:             []api.Receiver{{Address: dest, Coins: "10"}},
+		},
+	})
+	require.NoError(t, err)
+	_, err = node.InjectEncodedTransaction(txnR.EncodedTransaction)
+	require.NoError(t, err)
+	return addr, dest
+}
+
+func checks(issues []*Inconsistency) []error {
+	found := make([]error, len(issues))
+	for i, issue := range issues {
+		found[i] = issue.Check
+	}
+	return found
+}
+
+func TestCheckTransaction(t *testing.T) {
+	node := sandbox.NewNode()
+	addr, dest := sendTestTxn(t, node)
+	txns, err := node.TransactionsVerbose([]string{dest})
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+	genuine := txns[0].Transaction.BlockTransactionVerbose
+	require.Empty(t, CheckTransaction(genuine))
+
+	genesis, err := node.BlockBySeq(0)
+	require.NoError(t, err)
+	genesisTxns, err := node.TransactionsVerbose([]string{node.FaucetAddress()})
+	require.NoError(t, err)
+	require.Equal(t, genesis.Body.Transactions[0].Hash, genesisTxns[0].Transaction.Hash)
+	require.Empty(t, CheckTransaction(genesisTxns[0].Transaction.BlockTransactionVerbose))
+
+	// Outputs tampered with
+	tampered := genuine
+	tampered.Out = append([]readable.TransactionOutput{}, genuine.Out...)
+	tampered.Out[0].Hours++
+	require.Len(t, tampered.Out, 2)
+	// IDs of both outputs depend on transaction hash
+	require.Equal(t, []error{ErrInnerHashMismatch, ErrHashMismatch, ErrUxIDMismatch, ErrUxIDMismatch, ErrInvalidSignature}, checks(CheckTransaction(tampered)))
+
+	// Input not owned by reported address
+	tampered = genuine
+	tampered.In = append([]readable.TransactionInput{}, genuine.In...)
+	tampered.In[0].Address = dest
+	require.NotEqual(t, addr, dest)
+	require.Equal(t, []error{ErrInvalidSignature}, checks(CheckTransaction(tampered)))
+
+	tampered.In[0].Address = addr
+	tampered.In[0].CalculatedHours = tampered.In[0].Hours - 1
+	require.Equal(t, []error{ErrHoursMismatch}, checks(CheckTransaction(tampered)))
+
+	tampered.In[0].CalculatedHours = tampered.In[0].Hours
+	tampered.Sigs = []string{"invalid"}
+	require.Equal(t, []error{ErrMalformed}, checks(CheckTransaction(tampered)))
+}
+
+func TestCheckOutputs(t *testing.T) {
+	node := sandbox.NewNode()
+	addr, _ := sendTestTxn(t, node)
+	node.AdvanceTime(48 * time.Hour)
+	_, err := node.Mint(testutil.MakeAddress().String(), 1e6, 0)
+	require.NoError(t, err)
+
+	outs, err := node.OutputsForAddresses([]string{addr})
+	require.NoError(t, err)
+	require.NotEmpty(t, outs.HeadOutputs)
+	out := outs.HeadOutputs[0]
+	require.Empty(t, CheckUnspentOutput(out, outs.Head.Time))
+
+	tampered := out
+	tampered.CalculatedHours++
+	require.Equal(t, []error{ErrHoursMismatch}, checks(CheckUnspentOutput(tampered, outs.Head.Time)))
+	tampered = out
+	tampered.Hours++
+	require.Equal(t, []error{ErrUxIDMismatch, ErrHoursMismatch}, checks(CheckUnspentOutput(tampered, outs.Head.Time)))
+
+	spent, err := node.UxOut(out.Hash)
+	require.NoError(t, err)
+	require.Empty(t, CheckSpentOutput(*spent))
+	spent.Coins++
+	require.Equal(t, []error{ErrUxIDMismatch}, checks(CheckSpentOutput(*spent)))
+}
+
+// tamperingNode reports outputs with inflated calculated hours
+type tamperingNode struct {
+	*sandbox.Node
+}
+
+func (tn *tamperingNode) OutputsForAddresses(addrs []string) (*readable.UnspentOutputsSummary, error) {
+	res, err := tn.Node.OutputsForAddresses(addrs)
+	if err == nil {
+		for i := range res.HeadOutputs {
+			res.HeadOutputs[i].CalculatedHours += 1000
+		}
+	}
+	return res, err
+}
+
+func TestWrap(t *testing.T) {
+	node := sandbox.NewNode()
+	_, dest := sendTestTxn(t, node)
+	skyApi := Wrap(&tamperingNode{Node: node})
+
+	txns, err := skyApi.TransactionsVerbose([]string{dest})
+	require.NoError(t, err)
+	txid := txns[0].Transaction.Hash
+	require.Empty(t, Lookup(txid))
+
+	// Inconsistent data is returned as is, yet flagged once
+	for i := 0; i < 2; i++ {
+		outs, err := skyApi.OutputsForAddresses([]string{dest})
+		require.NoError(t, err)
+		require.Len(t, outs.HeadOutputs, 1)
+	}
+	issues := Lookup(txid)
+	require.Len(t, issues, 1)
+	require.Equal(t, ErrHoursMismatch, issues[0].Check)
+}
@@ -130,6 +130,7 @@ func getInputs(wlt core.Wallet, txn coin.Transaction, indexes []int) (inputs []*
 
 func spendingOutputFromRemote(inputHash cipher.SHA256) (*readable.SpentOutput, error) {
 	logSkyWallet.Info("Getting spent outputs for transaction inputs")
+	// Pooled clients reach the node through the configured proxy, if any
 	c, err := skycoin.NewSkycoinApiClient(skycoin.PoolSection)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,220 @@
+package core
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/shopspring/decimal"
+)
+
+// Amount is an exact quantity of coins of a given asset
+type Amount struct {
+	// Coins expressed in asset base units
+	Coins uint64
+	// Ticker identifying the asset
+	Ticker string
+}
+
+// AmountLocale describes how amounts are presented to users
+type AmountLocale struct {
+	// DecimalSeparator splits whole coins from coin fractions
+	DecimalSeparator string
+	// GroupSeparator splits groups of three digits in whole coins, empty to disable grouping
+	GroupSeparator string
+}
+
+// DefaultAmountLocale formats amounts as in 1,234.5
+var DefaultAmountLocale = AmountLocale{DecimalSeparator: ".", GroupSeparator: ","}
+
+var amountLocales = map[string]AmountLocale{
+	"en": DefaultAmountLocale,
+	"zh": DefaultAmountLocale,
+	"es": AmountLocale{DecimalSeparator: ",", GroupSeparator: "."},
+	"de": AmountLocale{DecimalSeparator: ",", GroupSeparator: "."},
+	"pt": AmountLocale{DecimalSeparator: ",", GroupSeparator: "."},
+	"fr": AmountLocale{DecimalSeparator: ",", GroupSeparator: " "},
+	"ru": AmountLocale{DecimalSeparator: ",", GroupSeparator: " "},
+}
+
+// LookupAmountLocale returns amount locale for a language tag (e.g. es_ES.UTF-8),
+// falling back to DefaultAmountLocale for unknown languages
+func LookupAmountLocale(tag string) AmountLocale {
+	lang := strings.ToLower(tag)
+	if idx := strings.IndexAny(lang, "_-.@"); idx >= 0 {
+		lang = lang[:idx]
+	}
+	if locale, isKnown := amountLocales[lang]; isKnown {
+		return locale
+	}
+	return DefaultAmountLocale
+}
+
+// NewAmount instantiates an amount of coins expressed in base units of asset represented by ticker
+func NewAmount(ticker string, coins uint64) Amount {
+	return Amount{Coins: coins, Ticker: ticker}
+}
+
+// ParseAmount reads a decimal amount of coins of asset represented by ticker.
+// Values with more decimal places than allowed by accuracy are rejected.
+func ParseAmount(ticker, value string, accuracy int32) (Amount, error) {
+	return parseAmount(ticker, value, accuracy, false)
+}
+
+// ParseAmountTruncated reads a decimal amount of coins of asset represented by ticker.
+// Decimal places beyond accuracy are discarded.
+func ParseAmountTruncated(ticker, value string, accuracy int32) (Amount, error) {
+	return parseAmount(ticker, value, accuracy, true)
+}
+
+// ParseAmountLocale reads a decimal amount formatted according to locale.
+// Group separators are only accepted between groups of three digits of whole coins.
+func ParseAmountLocale(ticker, value string, accuracy int32, locale AmountLocale) (Amount, error) {
+	value = strings.TrimSpace(value)
+	whole, fraction := value, ""
+	if idx := strings.Index(value, locale.DecimalSeparator); locale.DecimalSeparator != "" && idx >= 0 {
+		whole, fraction = value[:idx], value[idx+len(locale.DecimalSeparator):]
+	}
+	if locale.GroupSeparator != "" && strings.Contains(whole, locale.GroupSeparator) {
+		groups := strings.Split(whole, locale.GroupSeparator)
+		for i, group := range groups {
+			if (i > 0 && len(group) != 3) || (i == 0 && (len(group) == 0 || len(group) > 3)) {
+				return Amount{}, errors.ErrInvalidValue
+			}
+		}
+		whole = strings.Join(groups, "")
+	}
+	if fraction != "" {
+		whole += "." + fraction
+	}
+	return ParseAmount(ticker, whole, accuracy)
+}
+
+func parseAmount(ticker, value string, accuracy int32, truncate bool) (Amount, error) {
+	if accuracy < 0 {
+		return Amount{}, errors.ErrInvalidValue
+	}
+	d, err := decimal.NewFromString(strings.TrimSpace(value))
+	if err != nil {
+		return Amount{}, err
+	}
+	if d.IsNegative() {
+		return Amount{}, errors.ErrInvalidValue
+	}
+	d = d.Shift(accuracy)
+	units := d.Truncate(0)
+	if !truncate && !units.Equal(d) {
+		return Amount{}, errors.ErrAmountPrecision
+	}
+	coins, err := strconv.ParseUint(units.String(), 10, 64)
+	if err != nil {
+		if numErr, isNumErr := err.(*strconv.NumError); isNumErr && numErr.Err == strconv.ErrRange {
+			return Amount{}, errors.ErrAmountOverflow
+		}
+		return Amount{}, err
+	}
+	return NewAmount(ticker, coins), nil
+}
+
+// Decimal returns amount as a decimal number of coins
+func (a Amount) Decimal(accuracy int32) decimal.Decimal {
+	units, _ := decimal.NewFromString(strconv.FormatUint(a.Coins, 10))
+	return units.Shift(-accuracy)
+}
+
+// Format renders amount with accuracy decimal places, trailing zeros removed, e.g. 1234.5
+func (a Amount) Format(accuracy int32) string {
+	return a.FormatLocale(accuracy, AmountLocale{DecimalSeparator: "."})
+}
+
+// FormatLocale renders amount with accuracy decimal places according to locale, trailing zeros removed
+func (a Amount) FormatLocale(accuracy int32, locale AmountLocale) string {
+	digits := strconv.FormatUint(a.Coins, 10)
+	places := int(accuracy)
+	if places < 0 {
+		places = 0
+	}
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+	whole, fraction := digits[:len(digits)-places], strings.TrimRight(digits[len(digits)-places:], "0")
+	if locale.GroupSeparator != "" {
+		whole = groupDigits(whole, locale.GroupSeparator)
+	}
+	if fraction == "" {
+		return whole
+	}
+	return whole + locale.DecimalSeparator + fraction
+}
+
+func groupDigits(digits, separator string) string {
+	if len(digits) <= 3 {
+		return digits
+	}
+	var sb strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		sb.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if i > 0 {
+			sb.WriteString(separator)
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+	return sb.String()
+}
+
+// IsZero determines whether amount holds no coins at all
+func (a Amount) IsZero() bool {
+	return a.Coins == 0
+}
+
+// Cmp compares amounts of the same asset, returning -1, 0 or 1
+// if a is lower than, equal to or greater than other
+func (a Amount) Cmp(other Amount) (int, error) {
+	if a.Ticker != other.Ticker {
+		return 0, errors.ErrAmountTickerMismatch
+	}
+	switch {
+	case a.Coins < other.Coins:
+		return -1, nil
+	case a.Coins > other.Coins:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Add sums amounts of the same asset
+func (a Amount) Add(other Amount) (Amount, error) {
+	if a.Ticker != other.Ticker {
+		return Amount{}, errors.ErrAmountTickerMismatch
+	}
+	sum, carry := bits.Add64(a.Coins, other.Coins, 0)
+	if carry != 0 {
+		return Amount{}, errors.ErrAmountOverflow
+	}
+	return NewAmount(a.Ticker, sum), nil
+}
+
+// Sub subtracts an amount of the same asset
+func (a Amount) Sub(other Amount) (Amount, error) {
+	if a.Ticker != other.Ticker {
+		return Amount{}, errors.ErrAmountTickerMismatch
+	}
+	diff, borrow := bits.Sub64(a.Coins, other.Coins, 0)
+	if borrow != 0 {
+		return Amount{}, errors.ErrAmountUnderflow
+	}
+	return NewAmount(a.Ticker, diff), nil
+}
+
+// Mul multiplies amount by a scalar factor
+func (a Amount) Mul(factor uint64) (Amount, error) {
+	hi, lo := bits.Mul64(a.Coins, factor)
+	if hi != 0 {
+		return Amount{}, errors.ErrAmountOverflow
+	}
+	return NewAmount(a.Ticker, lo), nil
+}
@@ -18,6 +18,18 @@ type BlockchainStatus interface {
 	GetLastBlock() (Block, error)
 	// GetNumberOfBlocks determine number of blocks in the blockchain
 	GetNumberOfBlocks() (uint64, error)
+	// GetBlockByHeight retrieves block preceded by height blocks in the blockchain
+	GetBlockByHeight(height uint64) (Block, error)
+	// GetBlockByHash retrieves block identified by hash
+	GetBlockByHash(hash string) (Block, error)
+	// GetBlockRange iterates over blocks with heights from start up to end, both included
+	GetBlockRange(start, end uint64) (BlockIterator, error)
+	// GetTransaction looks up any transaction by ID
+	GetTransaction(txid string) (Transaction, error)
+	// GetAddressBalance retrieves balance of any address for asset represented by ticker
+	GetAddressBalance(addr, ticker string) (uint64, error)
+	// GetAddressTransactions lists transactions of any address
+	GetAddressTransactions(addr string) (TransactionIterator, error)
 }
 
 // BlockchainAPI abstract interface for transactions management and utility functions for specific blockchain.
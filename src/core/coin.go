@@ -17,7 +17,7 @@ const (
 
 // Transaction encapsulates the contract for atomic transfers of coins
 type Transaction interface {
-	// Crypto assets involved in or supported by this transaction
+	// Crypto assets involved in or supported by this transaction, the coin transferred first
 	SupportedAssets() []string
 	// GetTimestamp at the moment of creation
 	GetTimestamp() Timestamp
@@ -62,6 +62,13 @@ type TransactionInput interface {
 	SupportedAssets() []string
 }
 
+// AccruingInput is implemented by inputs spending assets that accrue after funds were received
+type AccruingInput interface {
+	// AccruedAsset returns the ticker of amounts of asset represented by ticker
+	// accrued by the time spent output was spent, if any
+	AccruedAsset(ticker string) (string, bool)
+}
+
 // TransactionInputIterator iterates over a sequence of transaction inputs
 type TransactionInputIterator interface {
 	// Value of transaction input at iterator pointer position
@@ -112,4 +119,16 @@ type Block interface {
 	GetFee(ticker string) (uint64, error)
 	// IsGenesisBlock determines whether this block starts blockchain sequence
 	IsGenesisBlock() (bool, error)
+	// GetTransactions lists transactions included in this block
+	GetTransactions() (TransactionIterator, error)
+}
+
+// BlockIterator iterates over a sequence of blocks
+type BlockIterator interface {
+	// Value of block at iterator pointer position
+	Value() Block
+	// Next discards current value and moves iteration pointer up to next item
+	Next() bool
+	// HasNext may be used to query whether more items are to be expected in the sequence
+	HasNext() bool
 }
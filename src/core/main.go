@@ -77,3 +77,33 @@ type AltcoinManager interface {
 	// SignServicesForTxn returns an object to iterate over strategies supported to sign a given transaction on behalf of a wallet
 	SignServicesForTxn(Wallet, Transaction) TxnSignerIterator
 }
+
+// TxnOptionSpec describes a plugin-specific option accepted when creating transactions
+type TxnOptionSpec struct {
+	// Key used to set option value in transaction options
+	Key string
+	// Caption is a human-readable name of the option
+	Caption string
+	// Default value applied when option is not set
+	Default string
+	// Choices lists accepted values, empty if any value is allowed
+	Choices []string
+}
+
+// TxnOptionsProvider is implemented by plugins supporting custom transaction options
+type TxnOptionsProvider interface {
+	// ListTxnOptions enumerates options accepted when creating transactions for asset represented by ticker
+	ListTxnOptions(ticker string) []TxnOptionSpec
+}
+
+// PluginRestarter is implemented by plugins able to restart independently of other plugins
+type PluginRestarter interface {
+	// Restart restarts plugin, e.g. the process running it, and reconnects to it
+	Restart() error
+}
+
+// RawTxnDecoder is implemented by plugins able to decode transactions in wire format
+type RawTxnDecoder interface {
+	// DecodeRawTxn parses hex-encoded transaction of asset represented by ticker
+	DecodeRawTxn(ticker, rawTxn string) (Transaction, error)
+}
@@ -23,6 +23,18 @@ type PEX interface {
 	BroadcastTxn(txn Transaction) error
 }
 
+// TxnValidator is implemented by PEX objects able to check transactions before they are broadcast
+type TxnValidator interface {
+	// ValidateTxn checks transaction against network rules and returns the first violation found
+	ValidateTxn(txn Transaction) error
+}
+
+// PlanBroadcaster is implemented by PEX objects able to broadcast transactions spending outputs of previous ones
+type PlanBroadcaster interface {
+	// BroadcastPlan broadcasts transactions in order, waiting for those spent by later ones to be confirmed
+	BroadcastPlan(txns []Transaction) error
+}
+
 // PexNodeIterator scans nodes in a set
 type PexNodeIterator interface {
 	// Value of PEX node data instance at iterator pointer position
@@ -24,6 +24,12 @@ type WalletSet interface {
 	SupportedWalletTypes() []string
 }
 
+// WalletRemover is implemented by wallet sets able to delete wallets
+type WalletRemover interface {
+	// RemoveWallet deletes wallet identified by id from the set
+	RemoveWallet(id string) error
+}
+
 // WalletStorage provides access to the underlying wallets data store
 type WalletStorage interface {
 	// Encrypt protects wallet data using cryptography
@@ -71,6 +77,58 @@ type Wallet interface {
 	Sign(txn Transaction, signer TxnSigner, pwd PasswordReader, index []string) (Transaction, error)
 }
 
+// TxnPlanner is implemented by wallets able to split transfers exceeding network limits into several transactions
+type TxnPlanner interface {
+	// PlanSendFromAddress instantiates unsigned transactions to send funds from specific source addresses,
+	// in the order they have to be broadcast. Transfers fitting in a single transaction yield a single one.
+	PlanSendFromAddress(from []Address, to []TransactionOutput, change Address, options KeyValueStore) ([]Transaction, error)
+	// SignPlan signs planned transactions in order, including those spending outputs of previous ones
+	SignPlan(txns []Transaction, signer TxnSigner, pwd PasswordReader) ([]Transaction, error)
+}
+
+// UTXOManager is implemented by wallets able to restructure their unspent outputs
+type UTXOManager interface {
+	// ConsolidateOutputs instantiates unsigned transactions merging unspent outputs holding less than dust coins,
+	// every output if dust is zero, into one output sent to address per transaction
+	ConsolidateOutputs(dust uint64, to Address, options KeyValueStore) ([]Transaction, error)
+	// SplitOutput instantiates unsigned transaction spending output to send equal amounts of coins to addresses
+	SplitOutput(outID string, to []Address, options KeyValueStore) (Transaction, error)
+	// PreviewOutputs lists unspent outputs wallet would hold once transactions are confirmed
+	PreviewOutputs(txns []Transaction) ([]TransactionOutput, error)
+}
+
+// OutputLock tells whether unspent outputs are available for automatic coin selection
+type OutputLock uint32
+
+const (
+	// OutputUnlocked refers to outputs that might be spent automatically
+	OutputUnlocked OutputLock = iota
+	// OutputFrozen refers to outputs never spent automatically
+	OutputFrozen
+	// OutputReserved refers to outputs locked by a pending transaction draft
+	OutputReserved
+)
+
+// CoinController is implemented by wallets keeping locked outputs away from automatic coin selection
+type CoinController interface {
+	// SetOutputLock persists lock state of wallet output
+	SetOutputLock(outID string, lock OutputLock) error
+	// GetOutputLock retrieves lock state of wallet output
+	GetOutputLock(outID string) (OutputLock, error)
+	// ListOutputLocks maps identifiers of locked outputs to their lock state
+	ListOutputLocks() (map[string]OutputLock, error)
+}
+
+// CoinHoursPlanner is implemented by wallets able to project coin hours of their outputs
+type CoinHoursPlanner interface {
+	// ProjectOutputs lists unspent outputs of addresses, every wallet address if nil,
+	// with calculated hours accumulated at time at
+	ProjectOutputs(from []Address, at Timestamp) ([]TransactionOutput, error)
+	// PreviewTransfer instantiates transaction sending funds from addresses, every wallet address if nil,
+	// as it would be created at time at so as to review fee and coin hours received
+	PreviewTransfer(from []Address, to []TransactionOutput, change Address, at Timestamp, options KeyValueStore) (Transaction, error)
+}
+
 // WalletOutput binds transaction output to originating wallet
 type WalletOutput interface {
 	// GetWallet return wallet
@@ -74,4 +74,50 @@ var (
 	ErrHwSignTransactionCanceled = errors.New("Sign transaction with hardware wallet has been canceled")
 	// ErrNilValue object should not be null
 	ErrNilValue = errors.New("Object should not be null")
+	// ErrAmountOverflow arithmetic on amounts exceeded the range of base units
+	ErrAmountOverflow = errors.New("Amount overflow")
+	// ErrAmountUnderflow subtraction of amounts resulted in a negative value
+	ErrAmountUnderflow = errors.New("Amount underflow")
+	// ErrAmountTickerMismatch arithmetic on amounts of different assets
+	ErrAmountTickerMismatch = errors.New("Amounts of different assets")
+	// ErrAmountPrecision amount has more decimal places than supported by asset accuracy
+	ErrAmountPrecision = errors.New("Amount exceeds asset accuracy")
+	// ErrInsufficientFunds available outputs do not cover transaction amount and fees
+	ErrInsufficientFunds = errors.New("Insufficient funds")
+	// ErrNodeUnavailable node could not be reached or failed to serve request
+	ErrNodeUnavailable = errors.New("Node unavailable")
+	// ErrNodeRequestRejected node refused to serve an invalid request
+	ErrNodeRequestRejected = errors.New("Request rejected by node")
+	// ErrChainMismatch node is not on the chain of the coin it is configured for
+	ErrChainMismatch = errors.New("Node is on a different chain")
+	// ErrNodesDisagree not enough nodes gave the same answer
+	ErrNodesDisagree = errors.New("Nodes disagree")
+	// ErrNodeNotSynced node is still downloading blocks from its peers
+	ErrNodeNotSynced = errors.New("Node is not synchronized with the network")
+	// ErrNodeIncompatible node version is older than the oldest one supported
+	ErrNodeIncompatible = errors.New("Node version is not supported")
+	// ErrNodeStale no block has been seen by node for a long time
+	ErrNodeStale = errors.New("Node has not seen new blocks recently")
+	// ErrNodeNoPeers node is not connected to any peer
+	ErrNodeNoPeers = errors.New("Node has no peers")
+	// ErrTxnTooLarge transaction size exceeds the maximum accepted by nodes
+	ErrTxnTooLarge = errors.New("Transaction is too large")
+	// ErrTxnInsufficientFee transaction burns less coin hours than required by burn factor
+	ErrTxnInsufficientFee = errors.New("Transaction fee is too low")
+	// ErrTxnInsufficientHours transaction outputs hold more coin hours than its inputs
+	ErrTxnInsufficientHours = errors.New("Insufficient coin hours for transaction outputs")
+	// ErrTxnDuplicateOutput transaction creates the same output twice
+	ErrTxnDuplicateOutput = errors.New("Transaction has duplicate outputs")
+	// ErrTxnZeroCoinOutput transaction creates an output without coins
+	ErrTxnZeroCoinOutput = errors.New("Transaction has outputs without coins")
+	// ErrTxnInvalidPrecision output coins have more decimal places than allowed
+	ErrTxnInvalidPrecision = errors.New("Transaction output coins have too many decimal places")
+	// ErrTxnInputSpent transaction spends outputs spent by another transaction
+	ErrTxnInputSpent = errors.New("Transaction spends outputs already spent")
+	// ErrTxnNotFullySigned some transaction inputs are not signed
+	ErrTxnNotFullySigned = errors.New("Transaction is not fully signed")
+	// ErrTxnRejectedByNode node refused transaction on verification
+	ErrTxnRejectedByNode = errors.New("Transaction rejected by node")
+	// ErrTxnNotConfirmed transaction was not confirmed in time
+	ErrTxnNotConfirmed = errors.New("Transaction not confirmed in time")
 )
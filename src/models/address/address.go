@@ -1,6 +1,8 @@
 package address
 
 import (
+	"github.com/fibercrypto/fibercryptowallet/src/models/assets"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
 	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
 	"github.com/therecipe/qt/core"
 )
@@ -24,6 +26,16 @@ type AddressDetails struct {
 	_ string `property:"address"`
 	_ string `property:"addressSky"`
 	_ string `property:"addressCoinHours"`
+
+	_ []*assets.QAsset `property:"assets"`
+}
+
+// SetAmounts binds the amount of every asset held by this address
+func (ad *AddressDetails) SetAmounts(amounts []util.AssetAmount) {
+	qAssets := assets.NewQAssetsFromAmounts(amounts)
+	ad.SetAssets(qAssets)
+	ad.SetAddressSky(assets.AmountAt(qAssets, 0))
+	ad.SetAddressCoinHours(assets.AmountAt(qAssets, 1))
 }
 
 type AddressList struct {
@@ -1,7 +1,7 @@
 package models
 
 import (
-	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/params"
+	"github.com/fibercrypto/fibercryptowallet/src/models/assets"
 	"github.com/fibercrypto/fibercryptowallet/src/util"
 	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
 	"github.com/therecipe/qt/core"
@@ -47,6 +47,23 @@ type QAddress struct {
 	_ int    `property:"marked"`
 	_ string `property:"wallet"`
 	_ string `property:"walletId"`
+
+	_ []*assets.QAsset `property:"assets"`
+}
+
+// setAmounts binds the amount of every asset held by this address
+func (a *QAddress) setAmounts(amounts []util.AssetAmount) {
+	qAssets := assets.NewQAssetsFromAmounts(amounts)
+	a.SetAssets(qAssets)
+	a.SetAddressSky(assets.AmountAt(qAssets, 0))
+	a.SetAddressCoinHours(assets.AmountAt(qAssets, 1))
+}
+
+// setNotAvailable flags unknown amounts for assets represented by tickers
+func (a *QAddress) setNotAvailable(tickers []string) {
+	a.SetAssets(assets.NotAvailableAssets(tickers))
+	a.SetAddressSky(assets.NotAvailable)
+	a.SetAddressCoinHours(assets.NotAvailable)
 }
 
 func (m *AddressesModel) init() {
@@ -195,18 +212,23 @@ func (m *AddressesModel) removeAddress(row int) {
 func (m *AddressesModel) editAddress(row int, address string, sky, coinHours uint64, marked int) {
 	a := m.Addresses()[row]
 	a.SetAddress(address)
-	accuracy, err := util.AltcoinQuotient(params.SkycoinTicker)
-	if err != nil {
-		logAddressesModel.WithError(err).Warn("Couldn't get" + params.SkycoinTicker + " quotient")
-		return
+	// Coins are bound to primary and secondary assets already listed for this address
+	coins := []uint64{sky, coinHours}
+	tickers := make([]string, 0, len(coins))
+	balance := make(map[string]uint64, len(coins))
+	for i, qAsset := range a.Assets() {
+		if i == len(coins) {
+			break
+		}
+		tickers = append(tickers, qAsset.Ticker())
+		balance[qAsset.Ticker()] = coins[i]
 	}
-	a.SetAddressSky(util.FormatCoins(sky, accuracy))
-	accuracy, err = util.AltcoinQuotient(params.CoinHoursTicker)
+	amounts, err := util.AmountsFromBalances(tickers, balance)
 	if err != nil {
-		logAddressesModel.WithError(err).Warn("Couldn't get" + params.CoinHoursTicker + " quotient")
+		logAddressesModel.WithError(err).Warn("Couldn't format address balance")
 		return
 	}
-	a.SetAddressCoinHours(util.FormatCoins(coinHours, accuracy))
+	a.setAmounts(amounts)
 	changeMarked := true
 	if marked == a.Marked() {
 		changeMarked = false
@@ -256,6 +278,7 @@ func (m *AddressesModel) loadModel(Qaddresses []*QAddress) {
 }
 
 func updateBalanceValues(a, b *QAddress) {
+	a.SetAssets(b.Assets())
 	a.SetAddressCoinHours(b.AddressCoinHours())
 	a.SetAddressSky(b.AddressSky())
 }
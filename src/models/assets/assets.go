@@ -0,0 +1,119 @@
+package assets
+
+import (
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	qtcore "github.com/therecipe/qt/core"
+	"github.com/therecipe/qt/qml"
+)
+
+// NotAvailable is shown for amounts that could not be retrieved
+const NotAvailable = "N/A"
+
+func init() {
+	QAsset_QmlRegisterType2("AssetModels", 1, 0, "QAsset")
+	QTxnOption_QmlRegisterType2("AssetModels", 1, 0, "QTxnOption")
+	// Amounts are parsed and formatted the same way as QML does with Qt.locale()
+	util.SetUserAmountLocale(qtAmountLocale())
+}
+
+// qtAmountLocale reads separators of the default Qt locale
+func qtAmountLocale() core.AmountLocale {
+	locale := qtcore.NewQLocale()
+	return core.AmountLocale{
+		DecimalSeparator: string(rune(locale.DecimalPoint().Unicode())),
+		GroupSeparator:   string(rune(locale.GroupSeparator().Unicode())),
+	}
+}
+
+// QAsset is an amount of coins of a crypto asset
+type QAsset struct {
+	qtcore.QObject
+	_ string `property:"ticker"`
+	_ string `property:"name"`
+	_ string `property:"amount"`
+}
+
+// QTxnOption describes a transaction option declared by a plugin
+type QTxnOption struct {
+	qtcore.QObject
+	_ string   `property:"key"`
+	_ string   `property:"caption"`
+	_ string   `property:"defaultValue"`
+	_ []string `property:"choices"`
+}
+
+// NewQAssetFromAmount instantiates a QAsset for a formatted amount
+func NewQAssetFromAmount(amount util.AssetAmount) *QAsset {
+	qAsset := NewQAsset(nil)
+	qml.QQmlEngine_SetObjectOwnership(qAsset, qml.QQmlEngine__CppOwnership)
+	qAsset.SetTicker(amount.Ticker)
+	qAsset.SetName(amount.Name)
+	qAsset.SetAmount(amount.Amount)
+	return qAsset
+}
+
+// NewQAssetsFromAmounts instantiates a QAsset for each formatted amount
+func NewQAssetsFromAmounts(amounts []util.AssetAmount) []*QAsset {
+	qAssets := make([]*QAsset, 0, len(amounts))
+	for _, amount := range amounts {
+		qAssets = append(qAssets, NewQAssetFromAmount(amount))
+	}
+	return qAssets
+}
+
+// NotAvailableAssets lists assets represented by tickers with unknown amounts
+func NotAvailableAssets(tickers []string) []*QAsset {
+	qAssets := make([]*QAsset, 0, len(tickers))
+	for _, ticker := range tickers {
+		qAssets = append(qAssets, NewQAssetFromAmount(util.AssetAmount{
+			Ticker: ticker,
+			Name:   util.AltcoinCaption(ticker),
+			Amount: NotAvailable,
+		}))
+	}
+	return qAssets
+}
+
+// AmountAt returns the amount of the asset at index or N/A if missing.
+// Views still showing a primary and a secondary asset rely on it.
+func AmountAt(qAssets []*QAsset, index int) string {
+	if index < len(qAssets) {
+		return qAssets[index].Amount()
+	}
+	return NotAvailable
+}
+
+// AmountOf returns the amount of the asset represented by ticker or N/A if missing
+func AmountOf(qAssets []*QAsset, ticker string) string {
+	for _, qAsset := range qAssets {
+		if qAsset.Ticker() == ticker {
+			return qAsset.Amount()
+		}
+	}
+	return NotAvailable
+}
+
+// EqualAmounts determines whether both lists hold the same amounts of the same assets
+func EqualAmounts(a, b []*QAsset) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Ticker() != b[i].Ticker() || a[i].Amount() != b[i].Amount() {
+			return false
+		}
+	}
+	return true
+}
+
+// NewQTxnOptionFromSpec instantiates a QTxnOption for an option declared by a plugin
+func NewQTxnOptionFromSpec(spec core.TxnOptionSpec) *QTxnOption {
+	qOpt := NewQTxnOption(nil)
+	qml.QQmlEngine_SetObjectOwnership(qOpt, qml.QQmlEngine__CppOwnership)
+	qOpt.SetKey(spec.Key)
+	qOpt.SetCaption(spec.Caption)
+	qOpt.SetDefaultValue(spec.Default)
+	qOpt.SetChoices(spec.Choices)
+	return qOpt
+}
@@ -105,24 +105,25 @@ func (blockchainStatus *BlockchainStatusModel) updateInfo() error {
 	}
 
 	// block details
-	blockchainStatus.SetNumberOfBlocks(util.FormatCoins(numberOfBlocks, 1))
+	locale := util.UserAmountLocale()
+	blockchainStatus.SetNumberOfBlocks(core.NewAmount("", numberOfBlocks).FormatLocale(0, locale))
 	blockchainStatus.SetTimestampLastBlock(qtcore.NewQDateTime3(qtcore.NewQDate3(year, month, day), qtcore.NewQTime3(h, m, s, 0), qtcore.Qt__LocalTime))
 	blockchainStatus.SetHashLastBlock(string(lastBlockHash))
 	
 	// sky details
-	accuracy, err := util.AltcoinQuotient(skycoin.SkycoinTicker)
+	accuracy, err := util.AltcoinAccuracy(skycoin.SkycoinTicker)
 	if err != nil {
-		logWalletsModel.WithError(err).Warn("Couldn't get " + skycoin.SkycoinTicker + " coins quotient")
+		logWalletsModel.WithError(err).Warn("Couldn't get " + skycoin.SkycoinTicker + " coins accuracy")
 	}
-	blockchainStatus.SetCurrentSkySupply(util.FormatCoins(currentSkySupply, accuracy))
-	blockchainStatus.SetTotalSkySupply(util.FormatCoins(totalSkySupply, accuracy))
+	blockchainStatus.SetCurrentSkySupply(core.NewAmount(skycoin.SkycoinTicker, currentSkySupply).FormatLocale(accuracy, locale))
+	blockchainStatus.SetTotalSkySupply(core.NewAmount(skycoin.SkycoinTicker, totalSkySupply).FormatLocale(accuracy, locale))
 
-	accuracy, err = util.AltcoinQuotient(skycoin.CoinHoursTicker)
+	accuracy, err = util.AltcoinAccuracy(skycoin.CoinHoursTicker)
 	if err != nil {
-		logWalletsModel.WithError(err).Warn("Couldn't get " + skycoin.CoinHoursTicker + " coins quotient")
+		logWalletsModel.WithError(err).Warn("Couldn't get " + skycoin.CoinHoursTicker + " coins accuracy")
 	}
-	blockchainStatus.SetCurrentCoinHoursSupply(util.FormatCoins(currentCoinHoursSupply, accuracy))
-	blockchainStatus.SetTotalCoinHoursSupply(util.FormatCoins(totalCoinHoursSupply, accuracy))
+	blockchainStatus.SetCurrentCoinHoursSupply(core.NewAmount(skycoin.CoinHoursTicker, currentCoinHoursSupply).FormatLocale(accuracy, locale))
+	blockchainStatus.SetTotalCoinHoursSupply(core.NewAmount(skycoin.CoinHoursTicker, totalCoinHoursSupply).FormatLocale(accuracy, locale))
 	blockchainStatus.SetLoading(false)
 
 	return nil
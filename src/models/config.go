@@ -6,6 +6,7 @@ import (
 
 	local "github.com/fibercrypto/fibercryptowallet/src/main"
 
+	"github.com/fibercrypto/fibercryptowallet/src/util"
 	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
 	qtcore "github.com/therecipe/qt/core"
 	"github.com/therecipe/qt/qml"
@@ -22,6 +23,8 @@ type ConfigManager struct {
 	_             func() []string             `slot:"getSections"`
 	_             func(string) *ConfigSection `slot:"getSection"`
 	_             func(string) string         `slot:"getDefaultValue"`
+	_             func() []string             `slot:"getRestartablePlugins"`
+	_             func(string) bool           `slot:"restartPlugin"`
 }
 
 func (cm *ConfigManager) init() {
@@ -32,9 +35,20 @@ func (cm *ConfigManager) init() {
 	cm.ConnectGetSection(cm.getSection)
 	cm.ConnectGetValue(cm.getValue)
 	cm.ConnectGetDefaultValue(cm.getDefaultValue)
+	cm.ConnectGetRestartablePlugins(util.ListRestartablePlugins)
+	cm.ConnectRestartPlugin(cm.restartPlugin)
 
 }
 
+// restartPlugin restarts plugin identified by name without affecting other plugins
+func (cm *ConfigManager) restartPlugin(name string) bool {
+	if err := util.RestartPlugin(name); err != nil {
+		log.WithError(err).WithField("plugin", name).Warn("Couldn't restart plugin")
+		return false
+	}
+	return true
+}
+
 func (cm *ConfigManager) getSections() []string {
 	return cm.configManager.GetSections()
 }
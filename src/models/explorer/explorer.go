@@ -0,0 +1,320 @@
+package explorer
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+
+	skycoin "github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/models"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/models/assets"
+	"github.com/fibercrypto/fibercryptowallet/src/models/history"
+	"github.com/fibercrypto/fibercryptowallet/src/models/transactions"
+	"github.com/fibercrypto/fibercryptowallet/src/params"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+	qtcore "github.com/therecipe/qt/core"
+	"github.com/therecipe/qt/qml"
+)
+
+var logExplorer = logging.MustGetLogger("modelsExplorer")
+
+func init() {
+	QBlock_QmlRegisterType2("ExplorerModels", 1, 0, "QBlock")
+	BlockList_QmlRegisterType2("ExplorerModels", 1, 0, "QBlockList")
+	ExplorerManager_QmlRegisterType2("ExplorerModels", 1, 0, "ExplorerManager")
+}
+
+const (
+	Height = int(qtcore.Qt__UserRole) + 1<<iota
+	Hash
+	Time
+	Fee
+)
+
+// Kinds of items found by explorer search
+const (
+	ResultNone        = ""
+	ResultBlock       = "block"
+	ResultTransaction = "transaction"
+	ResultAddress     = "address"
+)
+
+// DefaultPageSize number of blocks listed per explorer page
+const DefaultPageSize = 10
+
+var hashPattern = regexp.MustCompile("^[0-9a-fA-F]{64}$")
+
+// QBlock header of a block shown by explorer
+type QBlock struct {
+	qtcore.QObject
+	_ string            `property:"height"`
+	_ string            `property:"hash"`
+	_ string            `property:"previousHash"`
+	_ *qtcore.QDateTime `property:"time"`
+	_ string            `property:"fee"`
+}
+
+// BlockList blocks listed by explorer page, most recent first
+type BlockList struct {
+	qtcore.QAbstractListModel
+
+	_ map[int]*qtcore.QByteArray `property:"roles"`
+	_ func()                     `constructor:"init"`
+	_ []*QBlock                  `property:"blocks"`
+}
+
+func (bl *BlockList) init() {
+	bl.SetRoles(map[int]*qtcore.QByteArray{
+		Height: qtcore.NewQByteArray2("height", -1),
+		Hash:   qtcore.NewQByteArray2("hash", -1),
+		Time:   qtcore.NewQByteArray2("time", -1),
+		Fee:    qtcore.NewQByteArray2("fee", -1),
+	})
+	bl.ConnectRowCount(bl.rowCount)
+	bl.ConnectData(bl.data)
+	bl.ConnectRoleNames(bl.roleNames)
+}
+
+func (bl *BlockList) rowCount(*qtcore.QModelIndex) int {
+	return len(bl.Blocks())
+}
+
+func (bl *BlockList) roleNames() map[int]*qtcore.QByteArray {
+	return bl.Roles()
+}
+
+func (bl *BlockList) data(index *qtcore.QModelIndex, role int) *qtcore.QVariant {
+	if !index.IsValid() || index.Row() >= len(bl.Blocks()) {
+		return qtcore.NewQVariant()
+	}
+	block := bl.Blocks()[index.Row()]
+	switch role {
+	case Height:
+		return qtcore.NewQVariant1(block.Height())
+	case Hash:
+		return qtcore.NewQVariant1(block.Hash())
+	case Time:
+		return qtcore.NewQVariant1(block.Time())
+	case Fee:
+		return qtcore.NewQVariant1(block.Fee())
+	}
+	return qtcore.NewQVariant()
+}
+
+func (bl *BlockList) setBlocks(blocks []*QBlock) {
+	bl.BeginResetModel()
+	bl.SetBlocks(blocks)
+	bl.EndResetModel()
+}
+
+// ExplorerManager controls explorer page, browsing blocks, transactions and addresses of any party
+type ExplorerManager struct {
+	qtcore.QObject
+	explorer core.BlockchainStatus
+
+	_ func() `constructor:"init"`
+
+	_ *BlockList                       `property:"blocks"`
+	_ *QBlock                          `property:"block"`
+	_ *transactions.TransactionDetails `property:"transaction"`
+	_ *history.TransactionList         `property:"transactions"`
+	_ string                           `property:"address"`
+	_ []*assets.QAsset                 `property:"balance"`
+	_ string                           `property:"resultKind"`
+	_ bool                             `property:"loading"`
+
+	_ func(page int)          `slot:"loadPage"`
+	_ func(query string) bool `slot:"search"`
+}
+
+func (em *ExplorerManager) init() {
+	em.SetBlocks(NewBlockList(nil))
+	em.SetTransactions(history.NewTransactionList(nil))
+	em.SetResultKindDefault(ResultNone)
+	em.ConnectLoadPage(em.loadPage)
+	em.ConnectSearch(em.search)
+	em.explorer = skycoin.NewSkycoinBlockchain(params.DataRefreshTimeout)
+}
+
+// loadPage lists blocks of page, newest blocks first
+func (em *ExplorerManager) loadPage(page int) {
+	logExplorer.Info("Loading explorer page")
+	em.SetLoading(true)
+	defer em.SetLoading(false)
+	if page < 0 {
+		page = 0
+	}
+	lastBlock, err := em.explorer.GetLastBlock()
+	if err != nil {
+		logExplorer.WithError(err).Warn("Couldn't get last block")
+		return
+	}
+	tip, err := lastBlock.GetHeight()
+	if err != nil {
+		logExplorer.WithError(err).Warn("Couldn't get last block height")
+		return
+	}
+	skipped := uint64(page * DefaultPageSize)
+	if skipped > tip {
+		em.Blocks().setBlocks(make([]*QBlock, 0))
+		return
+	}
+	end := tip - skipped
+	start := uint64(0)
+	if end >= DefaultPageSize {
+		start = end - DefaultPageSize + 1
+	}
+	blocks, err := em.explorer.GetBlockRange(start, end)
+	if err != nil {
+		logExplorer.WithError(err).Warn("Couldn't get blocks")
+		return
+	}
+	qBlocks := make([]*QBlock, 0, DefaultPageSize)
+	for blocks.Next() {
+		qBlock, err := newQBlock(blocks.Value())
+		if err != nil {
+			return
+		}
+		qBlocks = append([]*QBlock{qBlock}, qBlocks...)
+	}
+	em.Blocks().setBlocks(qBlocks)
+}
+
+// search looks up block by height or hash, transaction by ID or address
+func (em *ExplorerManager) search(query string) bool {
+	logExplorer.Info("Searching explorer")
+	em.SetLoading(true)
+	defer em.SetLoading(false)
+	em.SetResultKind(ResultNone)
+	if height, err := strconv.ParseUint(query, 10, 64); err == nil {
+		block, err := em.explorer.GetBlockByHeight(height)
+		if err != nil {
+			return false
+		}
+		return em.showBlock(block)
+	}
+	if hashPattern.MatchString(query) {
+		if txn, err := em.explorer.GetTransaction(query); err == nil {
+			return em.showTransaction(txn)
+		}
+		block, err := em.explorer.GetBlockByHash(query)
+		if err != nil {
+			return false
+		}
+		return em.showBlock(block)
+	}
+	return em.showAddress(query)
+}
+
+func (em *ExplorerManager) showBlock(block core.Block) bool {
+	qBlock, err := newQBlock(block)
+	if err != nil {
+		return false
+	}
+	txns, err := block.GetTransactions()
+	if err != nil {
+		logExplorer.WithError(err).Warn("Couldn't get block transactions")
+		return false
+	}
+	if !em.showTransactions(txns, nil) {
+		return false
+	}
+	em.SetBlock(qBlock)
+	em.SetResultKind(ResultBlock)
+	return true
+}
+
+func (em *ExplorerManager) showTransaction(txn core.Transaction) bool {
+	details, err := history.TransactionDetailsFromCoreTxn(txn, nil)
+	if err != nil {
+		logExplorer.WithError(err).Warn("Couldn't get transaction details")
+		return false
+	}
+	em.SetTransaction(details)
+	em.SetResultKind(ResultTransaction)
+	return true
+}
+
+func (em *ExplorerManager) showAddress(addr string) bool {
+	balance := make(map[string]uint64)
+	tickers := []string{skycoin.Sky, skycoin.CoinHour}
+	for _, ticker := range tickers {
+		coins, err := em.explorer.GetAddressBalance(addr, ticker)
+		if err != nil {
+			logExplorer.WithError(err).Warn("Couldn't get address balance")
+			return false
+		}
+		balance[ticker] = coins
+	}
+	amounts, err := util.AmountsFromBalances(tickers, balance)
+	if err != nil {
+		logExplorer.WithError(err).Warn("Couldn't format address balance")
+		return false
+	}
+	txns, err := em.explorer.GetAddressTransactions(addr)
+	if err != nil {
+		logExplorer.WithError(err).Warn("Couldn't get address transactions")
+		return false
+	}
+	// Amounts sent and received are shown from the point of view of the address
+	if !em.showTransactions(txns, map[string]string{addr: addr}) {
+		return false
+	}
+	em.SetAddress(addr)
+	em.SetBalance(assets.NewQAssetsFromAmounts(amounts))
+	em.SetResultKind(ResultAddress)
+	return true
+}
+
+func (em *ExplorerManager) showTransactions(txns core.TransactionIterator, addresses map[string]string) bool {
+	details := make([]*transactions.TransactionDetails, 0)
+	for txns.Next() {
+		txnDetails, err := history.TransactionDetailsFromCoreTxn(txns.Value(), addresses)
+		if err != nil {
+			logExplorer.WithError(err).Warn("Couldn't get transaction details")
+			return false
+		}
+		details = append(details, txnDetails)
+	}
+	em.Transactions().Clear()
+	em.Transactions().AddMultipleTransactions(details)
+	return true
+}
+
+func newQBlock(block core.Block) (*QBlock, error) {
+	height, err := block.GetHeight()
+	if err != nil {
+		logExplorer.WithError(err).Warn("Couldn't get block height")
+		return nil, err
+	}
+	hash, err := block.GetHash()
+	if err != nil {
+		logExplorer.WithError(err).Warn("Couldn't get block hash")
+		return nil, err
+	}
+	prevHash, err := block.GetPrevHash()
+	if err != nil {
+		logExplorer.WithError(err).Warn("Couldn't get previous block hash")
+		return nil, err
+	}
+	timestamp, err := block.GetTime()
+	if err != nil {
+		logExplorer.WithError(err).Warn("Couldn't get block time")
+		return nil, err
+	}
+	fee, err := block.GetFee(skycoin.CoinHour)
+	if err != nil {
+		logExplorer.WithError(err).Warn("Couldn't get block fee")
+		return nil, err
+	}
+	qBlock := NewQBlock(nil)
+	qml.QQmlEngine_SetObjectOwnership(qBlock, qml.QQmlEngine__CppOwnership)
+	qBlock.SetHeight(strconv.FormatUint(height, 10))
+	qBlock.SetHash(string(hash))
+	qBlock.SetPreviousHash(string(prevHash))
+	t := time.Unix(int64(timestamp), 0)
+	qBlock.SetTime(qtcore.NewQDateTime3(qtcore.NewQDate3(t.Year(), int(t.Month()), t.Day()), qtcore.NewQTime3(t.Hour(), t.Minute(), t.Second(), 0), qtcore.Qt__LocalTime))
+	qBlock.SetFee(strconv.FormatUint(fee, 10))
+	return qBlock, nil
+}
@@ -3,12 +3,10 @@ package history
 import (
 	"time"
 
-	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/params"
 	"github.com/therecipe/qt/qml"
 
 	"sync"
 
-	coin "github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/models"
 	"github.com/fibercrypto/fibercryptowallet/src/core"
 	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
 
@@ -347,11 +345,9 @@ func (hm *HistoryManager) getAddressesWithWallets() map[string]string {
 }
 
 func TransactionDetailsFromCoreTxn(txn core.Transaction, addresses map[string]string) (*transactions.TransactionDetails, error) {
-	var traspassedHoursIn, traspassedHoursOut, skyAmountIn, skyAmountOut uint64
-	traspassedHoursIn = 0
-	traspassedHoursOut = 0
-	skyAmountIn = 0
-	skyAmountOut = 0
+	tickers := txn.SupportedAssets()
+	received := make(map[string]uint64, len(tickers))
+	sentOut := make(map[string]uint64, len(tickers))
 	internally := true
 	sent := false
 	txnDetails := transactions.NewTransactionDetails(nil)
@@ -378,33 +374,15 @@ func TransactionDetailsFromCoreTxn(txn core.Transaction, addresses map[string]st
 			return nil, err
 		}
 		qIn.SetAddress(outAddr.String())
-		skyUint64, err := in.GetCoins(params.SkycoinTicker)
+		amounts, err := util.InputAmounts(in, tickers)
 		if err != nil {
-			logHistoryManager.WithError(err).Warn("Couldn't get Skycoins balance")
+			logHistoryManager.WithError(err).Warn("Couldn't get input coins")
 			return nil, err
 		}
-		accuracy, err := util.AltcoinQuotient(params.SkycoinTicker)
-		if err != nil {
-			logHistoryManager.WithError(err).Warn("Couldn't get Skycoins quotient")
-
-			return nil, err
-		}
-		qIn.SetAddressSky(util.FormatCoins(skyUint64, accuracy))
-		chUint64, err := in.GetCoins(params.CalculatedHoursTicker)
-		if err != nil {
-			logHistoryManager.WithError(err).Warn("Couldn't get Coin Hours balance")
-			return nil, err
-		}
-		accuracy, err = util.AltcoinQuotient(params.CalculatedHoursTicker)
-		if err != nil {
-			logHistoryManager.WithError(err).Warn("Couldn't get Coin Hours quotient")
-			return nil, err
-		}
-		qIn.SetAddressCoinHours(util.FormatCoins(chUint64, accuracy))
+		qIn.SetAmounts(amounts)
 		inputs.AddAddress(qIn)
 		_, ok := addresses[outAddr.String()]
 		if ok {
-			skyAmountOut += skyUint64
 			sent = true
 			_, ok := inAddresses[qIn.Address()]
 			if !ok {
@@ -415,12 +393,22 @@ func TransactionDetailsFromCoreTxn(txn core.Transaction, addresses map[string]st
 		}
 	}
 	txnDetails.SetInputs(inputs)
-	for _, out := range txn.GetOutputs() {
-		sky, err := out.GetCoins(params.SkycoinTicker)
+	var senderWallet string
+	if sent {
+		nOut, err := txnIns[0].GetSpentOutput()
+		if err != nil {
+			logHistoryManager.WithError(err).Error("Couldn't get spent output")
+			return nil, err
+		}
+		nOutAddr, err := nOut.GetAddress()
 		if err != nil {
-			logHistoryManager.WithError(err).Warn("Couldn't get Skycoins balance")
+			logHistoryManager.WithError(err).Error("Couldn't get address")
 			return nil, err
 		}
+		senderWallet = addresses[nOutAddr.String()]
+	}
+	outAmounts := make([][]util.AssetAmount, 0)
+	for _, out := range txn.GetOutputs() {
 		qOu := address.NewAddressDetails(nil)
 		qml.QQmlEngine_SetObjectOwnership(qOu, qml.QQmlEngine__CppOwnership)
 		outAddr, err := out.GetAddress()
@@ -429,58 +417,27 @@ func TransactionDetailsFromCoreTxn(txn core.Transaction, addresses map[string]st
 			return nil, err
 		}
 		qOu.SetAddress(outAddr.String())
-		accuracy, err := util.AltcoinQuotient(params.SkycoinTicker)
-		if err != nil {
-			logHistoryManager.WithError(err).Warn("Couldn't get Skycoins quotient")
-			return nil, err
-		}
-		qOu.SetAddressSky(util.FormatCoins(sky, accuracy))
-		val, err := out.GetCoins(params.CoinHoursTicker)
-		if err != nil {
-			logHistoryManager.WithError(err).Warn("Couldn't get Coin Hours balance")
-			return nil, err
-		}
-		accuracy, err = util.AltcoinQuotient(coin.CoinHour)
+		amounts, err := util.OutputAmounts(out, tickers)
 		if err != nil {
-			logHistoryManager.WithError(err).Warn("Couldn't get Coin Hours quotient")
+			logHistoryManager.WithError(err).Warn("Couldn't get output coins")
 			return nil, err
 		}
-		qOu.SetAddressCoinHours(util.FormatCoins(val, accuracy))
+		qOu.SetAmounts(amounts)
 		outputs.AddAddress(qOu)
+		outAmounts = append(outAmounts, amounts)
 		if sent {
-			nOut, err := txn.GetInputs()[0].GetSpentOutput()
-			if err != nil {
-				logHistoryManager.WithError(err).Error("Couldn't get spent output")
-				return nil, err
-			}
-			nOutAddr, err := nOut.GetAddress()
-			if err != nil {
-				logHistoryManager.WithError(err).Error("Couldn't get address")
-				return nil, err
-			}
-
-			if addresses[nOutAddr.String()] == addresses[outAddr.String()] {
-				skyAmountOut -= sky
-
-			} else {
+			if senderWallet != addresses[outAddr.String()] {
 				internally = false
-				val, err = out.GetCoins(params.CoinHoursTicker)
-				if err != nil {
-					logHistoryManager.WithError(err).Warn("Couldn't get Coin Hours send it")
-					return nil, err
+				for _, amount := range amounts {
+					sentOut[amount.Ticker] += amount.Coins
 				}
-				traspassedHoursOut += val
 			}
 		} else {
 			_, ok := addresses[outAddr.String()]
 			if ok {
-				val, err = out.GetCoins(params.CoinHoursTicker)
-				if err != nil {
-					logHistoryManager.WithError(err).Warn("Couldn't get Coin Hours balance")
-					return nil, err
+				for _, amount := range amounts {
+					received[amount.Ticker] += amount.Coins
 				}
-				traspassedHoursIn += val
-				skyAmountIn += sky
 
 				_, ok := inAddresses[qOu.Address()]
 				if !ok {
@@ -508,97 +465,38 @@ func TransactionDetailsFromCoreTxn(txn core.Transaction, addresses map[string]st
 			txnDetails.SetType(transactions.TransactionTypeInternal)
 		}
 	}
-	fee, err := txn.ComputeFee(params.CoinHoursTicker)
+	fees, err := util.TransactionFees(txn)
 	if err != nil {
 		logHistoryManager.WithError(err).Warn("Couldn't compute fee of the operation")
 		return nil, err
 	}
-	accuracy, err := util.AltcoinQuotient(coin.CoinHoursTicker)
-	if err != nil {
-		logHistoryManager.WithError(err).Warn("Couldn't get " + coin.CoinHoursTicker + " coins quotient")
-		return nil, err
-	}
-	txnDetails.SetHoursBurned(util.FormatCoins(fee, accuracy))
+	txnDetails.SetAssetFees(fees)
+	var moved map[string]uint64
 	switch txnDetails.Type() {
 	case transactions.TransactionTypeReceive:
-		{
-			accuracy, err := util.AltcoinQuotient(coin.CoinHoursTicker)
-			if err != nil {
-				logHistoryManager.WithError(err).Warn("Couldn't get " + coin.CoinHoursTicker + " coins quotient")
-				return nil, err
-			}
-			txnDetails.SetHoursTraspassed(util.FormatCoins(traspassedHoursIn, accuracy))
-			accuracy, err = util.AltcoinQuotient(params.SkycoinTicker)
-			if err != nil {
-				logHistoryManager.WithError(err).Warn("Couldn't get Skycoins quotient")
-				return nil, err
-			}
-			txnDetails.SetAmount(util.FormatCoins(skyAmountIn, accuracy))
-
-		}
+		moved = received
 	case transactions.TransactionTypeInternal:
-		{
-			var traspassedHoursMoved, skyAmountMoved uint64
-			traspassedHoursMoved = 0
-			skyAmountMoved = 0
-			ins := inputs.Addresses()
-			inFind := make(map[string]struct{}, len(ins))
-			for _, addr := range ins {
-				inFind[addr.Address()] = struct{}{}
-			}
-			for _, addr := range txn.GetOutputs() {
-				outAddr, err := addr.GetAddress()
-				if err != nil {
-					logHistoryManager.WithError(err).Error("Couldn't get address")
-					return nil, err
-				}
-				_, ok := inFind[outAddr.String()]
-				if !ok {
-					hours, err := addr.GetCoins(params.CoinHoursTicker)
-					if err != nil {
-						logHistoryManager.WithError(err).Warn("Couldn't parse Coin Hours from address")
-						return nil, err
-					}
-					traspassedHoursMoved += hours
-					sky, err := addr.GetCoins(params.SkycoinTicker)
-					if err != nil {
-						logHistoryManager.WithError(err).Error("Couldn't get Sky from address")
-						return nil, err
-					}
-					skyAmountMoved += sky
+		moved = make(map[string]uint64, len(tickers))
+		inFind := make(map[string]struct{}, len(inputs.Addresses()))
+		for _, addr := range inputs.Addresses() {
+			inFind[addr.Address()] = struct{}{}
+		}
+		for i, addr := range outputs.Addresses() {
+			if _, ok := inFind[addr.Address()]; !ok {
+				for _, amount := range outAmounts[i] {
+					moved[amount.Ticker] += amount.Coins
 				}
-
-			}
-			accuracy, err := util.AltcoinQuotient(coin.CoinHoursTicker)
-			if err != nil {
-				logHistoryManager.WithError(err).Warn("Couldn't get " + coin.CoinHoursTicker + " coins quotient")
-				return nil, err
-			}
-			txnDetails.SetHoursTraspassed(util.FormatCoins(traspassedHoursMoved, accuracy))
-			accuracy, err = util.AltcoinQuotient(params.SkycoinTicker)
-			if err != nil {
-				logHistoryManager.WithError(err).Warn("Couldn't get Skycoins quotient")
-				return nil, err
 			}
-			txnDetails.SetAmount(util.FormatCoins(skyAmountMoved, accuracy))
-
 		}
 	case transactions.TransactionTypeSend:
-		{
-			accuracy, err := util.AltcoinQuotient(coin.CoinHoursTicker)
-			if err != nil {
-				logHistoryManager.WithError(err).Warn("Couldn't get " + coin.CoinHoursTicker + " coins quotient")
-				return nil, err
-			}
-			txnDetails.SetHoursTraspassed(util.FormatCoins(traspassedHoursOut, accuracy))
-			accuracy, err = util.AltcoinQuotient(params.SkycoinTicker)
-			if err != nil {
-				logHistoryManager.WithError(err).Warn("Couldn't get Skycoins quotient")
-				return nil, err
-			}
-			txnDetails.SetAmount(util.FormatCoins(skyAmountOut, accuracy))
-		}
+		moved = sentOut
+	}
+	amounts, err := util.AmountsFromBalances(tickers, moved)
+	if err != nil {
+		logHistoryManager.WithError(err).Warn("Couldn't format transaction amounts")
+		return nil, err
 	}
+	txnDetails.SetAssetAmounts(amounts)
 	txnDetails.SetAddresses(txnAddresses)
 	txnDetails.SetTransactionID(txn.GetId())
 	return txnDetails, nil
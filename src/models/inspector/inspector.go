@@ -0,0 +1,208 @@
+package inspector
+
+import (
+	"strconv"
+
+	skycoin "github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/models"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	local "github.com/fibercrypto/fibercryptowallet/src/main"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+	qtcore "github.com/therecipe/qt/core"
+	"github.com/therecipe/qt/qml"
+)
+
+var logInspector = logging.MustGetLogger("modelsInspector")
+
+func init() {
+	QInspectedOutput_QmlRegisterType2("InspectorModels", 1, 0, "QInspectedOutput")
+	InspectedOutputList_QmlRegisterType2("InspectorModels", 1, 0, "QInspectedOutputList")
+	TransactionInspector_QmlRegisterType2("InspectorModels", 1, 0, "TransactionInspector")
+}
+
+const (
+	UxID = int(qtcore.Qt__UserRole) + 1<<iota
+	Address
+	Coins
+	Hours
+	Wallet
+	Signed
+	Spent
+)
+
+// QInspectedOutput output created or spent by inspected transaction
+type QInspectedOutput struct {
+	qtcore.QObject
+	_ string `property:"uxID"`
+	_ string `property:"address"`
+	_ string `property:"coins"`
+	_ string `property:"hours"`
+	_ string `property:"wallet"`
+	_ bool   `property:"signed"`
+	_ bool   `property:"spent"`
+}
+
+// InspectedOutputList inputs or outputs of inspected transaction
+type InspectedOutputList struct {
+	qtcore.QAbstractListModel
+
+	_ map[int]*qtcore.QByteArray `property:"roles"`
+	_ func()                     `constructor:"init"`
+	_ []*QInspectedOutput        `property:"outputs"`
+}
+
+func (ol *InspectedOutputList) init() {
+	ol.SetRoles(map[int]*qtcore.QByteArray{
+		UxID:    qtcore.NewQByteArray2("uxID", -1),
+		Address: qtcore.NewQByteArray2("address", -1),
+		Coins:   qtcore.NewQByteArray2("coins", -1),
+		Hours:   qtcore.NewQByteArray2("hours", -1),
+		Wallet:  qtcore.NewQByteArray2("wallet", -1),
+		Signed:  qtcore.NewQByteArray2("signed", -1),
+		Spent:   qtcore.NewQByteArray2("spent", -1),
+	})
+	ol.ConnectRowCount(ol.rowCount)
+	ol.ConnectData(ol.data)
+	ol.ConnectRoleNames(ol.roleNames)
+}
+
+func (ol *InspectedOutputList) rowCount(*qtcore.QModelIndex) int {
+	return len(ol.Outputs())
+}
+
+func (ol *InspectedOutputList) roleNames() map[int]*qtcore.QByteArray {
+	return ol.Roles()
+}
+
+func (ol *InspectedOutputList) data(index *qtcore.QModelIndex, role int) *qtcore.QVariant {
+	if !index.IsValid() || index.Row() >= len(ol.Outputs()) {
+		return qtcore.NewQVariant()
+	}
+	out := ol.Outputs()[index.Row()]
+	switch role {
+	case UxID:
+		return qtcore.NewQVariant1(out.UxID())
+	case Address:
+		return qtcore.NewQVariant1(out.Address())
+	case Coins:
+		return qtcore.NewQVariant1(out.Coins())
+	case Hours:
+		return qtcore.NewQVariant1(out.Hours())
+	case Wallet:
+		return qtcore.NewQVariant1(out.Wallet())
+	case Signed:
+		return qtcore.NewQVariant1(out.IsSigned())
+	case Spent:
+		return qtcore.NewQVariant1(out.IsSpent())
+	}
+	return qtcore.NewQVariant()
+}
+
+func (ol *InspectedOutputList) setOutputs(outputs []*QInspectedOutput) {
+	ol.BeginResetModel()
+	ol.SetOutputs(outputs)
+	ol.EndResetModel()
+}
+
+// TransactionInspector decodes raw transactions for review and broadcasts them
+type TransactionInspector struct {
+	qtcore.QObject
+	walletEnvs []core.WalletEnv
+	txn        core.Transaction
+
+	_ func() `constructor:"init"`
+
+	_ string               `property:"transactionID"`
+	_ *InspectedOutputList `property:"inputs"`
+	_ *InspectedOutputList `property:"outputs"`
+	_ string               `property:"hours"`
+	_ string               `property:"fee"`
+	_ bool                 `property:"fullySigned"`
+	_ string               `property:"verifyError"`
+	_ string               `property:"error"`
+
+	_ func(rawTxn string) bool `slot:"inspect"`
+	_ func() bool              `slot:"broadcast"`
+}
+
+func (ti *TransactionInspector) init() {
+	ti.SetInputs(NewInspectedOutputList(nil))
+	ti.SetOutputs(NewInspectedOutputList(nil))
+	ti.ConnectInspect(ti.inspect)
+	ti.ConnectBroadcast(ti.broadcast)
+
+	altManager := local.LoadAltcoinManager()
+	for _, plug := range altManager.ListRegisteredPlugins() {
+		ti.walletEnvs = append(ti.walletEnvs, plug.LoadWalletEnvs()...)
+	}
+}
+
+// inspect decodes raw transaction showing inputs, outputs, fee and signature status
+func (ti *TransactionInspector) inspect(rawTxn string) bool {
+	logInspector.Info("Inspecting raw transaction")
+	ti.txn = nil
+	ti.SetError("")
+	inspection, err := skycoin.InspectRawTransaction(skycoin.PoolSection, rawTxn, ti.walletEnvs...)
+	if err != nil {
+		logInspector.WithError(err).Warn("Couldn't inspect transaction")
+		ti.SetError(err.Error())
+		return false
+	}
+	accuracy, err := util.AltcoinAccuracy(skycoin.Sky)
+	if err != nil {
+		logInspector.WithError(err).Warn("Couldn't get Skycoin accuracy")
+		ti.SetError(err.Error())
+		return false
+	}
+	locale := util.UserAmountLocale()
+	inputs := make([]*QInspectedOutput, 0, len(inspection.Inputs))
+	for _, in := range inspection.Inputs {
+		qIn := newQInspectedOutput(in.InspectedOutput, accuracy, locale)
+		qIn.SetSigned(in.Signed)
+		qIn.SetSpent(in.Spent)
+		inputs = append(inputs, qIn)
+	}
+	outputs := make([]*QInspectedOutput, 0, len(inspection.Outputs))
+	for _, out := range inspection.Outputs {
+		outputs = append(outputs, newQInspectedOutput(out, accuracy, locale))
+	}
+	ti.Inputs().setOutputs(inputs)
+	ti.Outputs().setOutputs(outputs)
+	ti.SetTransactionID(inspection.Txn.GetId())
+	ti.SetHours(strconv.FormatUint(inspection.Hours, 10))
+	ti.SetFee(strconv.FormatUint(inspection.Fee, 10))
+	ti.SetFullySigned(inspection.FullySigned)
+	if inspection.VerifyErr != nil {
+		ti.SetVerifyError(inspection.VerifyErr.Error())
+	} else {
+		ti.SetVerifyError("")
+	}
+	ti.txn = inspection.Txn
+	return true
+}
+
+// broadcast injects last inspected transaction into the network
+func (ti *TransactionInspector) broadcast() bool {
+	logInspector.Info("Broadcasting inspected transaction")
+	if ti.txn == nil {
+		return false
+	}
+	if err := skycoin.NewSkycoinPEX(skycoin.PoolSection).BroadcastTxn(ti.txn); err != nil {
+		logInspector.WithError(err).Warn("Couldn't broadcast transaction")
+		ti.SetError(err.Error())
+		return false
+	}
+	ti.SetError("")
+	return true
+}
+
+func newQInspectedOutput(out skycoin.InspectedOutput, accuracy int32, locale core.AmountLocale) *QInspectedOutput {
+	qOut := NewQInspectedOutput(nil)
+	qml.QQmlEngine_SetObjectOwnership(qOut, qml.QQmlEngine__CppOwnership)
+	qOut.SetUxID(out.UxID)
+	qOut.SetAddress(out.Address)
+	qOut.SetCoins(core.NewAmount(skycoin.Sky, out.Coins).FormatLocale(accuracy, locale))
+	qOut.SetHours(strconv.FormatUint(out.Hours, 10))
+	qOut.SetWallet(out.Wallet)
+	return qOut
+}
@@ -81,7 +81,7 @@ func (m *ModelAddresses) addOutputs(mo []*ModelOutputs) {
 		find := false
 		for _, mOutSet := range m.outputs {
 			if mOut.Address() == mOutSet.Address() {
-				mOutSet.addOutputs(mOut.outputs)
+				mOutSet.addOutputs(mOut.Outputs())
 				find = true
 				break
 			}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"github.com/fibercrypto/fibercryptowallet/src/models/assets"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
 	"github.com/therecipe/qt/core"
 )
 
@@ -10,16 +12,17 @@ const (
 	AddressCoinHours
 	AddressOwner
 	WalletOwner
+	LockState
 )
 
 type ModelOutputs struct {
 	core.QAbstractListModel
-	outputs []*QOutput
 
 	_ func() `constructor:"init"`
 
 	_ map[int]*core.QByteArray `property:"roles"`
 	_ string                   `property:"address"`
+	_ []*QOutput               `property:"outputs"`
 
 	_ func([]*QOutput) `slot:"addOutputs"`
 	_ func([]*QOutput) `slot:"insertOutputs"`
@@ -37,6 +40,17 @@ type QOutput struct {
 	_ string `property:"addressCoinHours"`
 	_ string `property:"addressOwner"`
 	_ string `property:"walletOwner"`
+	_ int    `property:"lockState"`
+
+	_ []*assets.QAsset `property:"assets"`
+}
+
+// setAmounts binds the amount of every asset transferred in this output
+func (qo *QOutput) setAmounts(amounts []util.AssetAmount) {
+	qAssets := assets.NewQAssetsFromAmounts(amounts)
+	qo.SetAssets(qAssets)
+	qo.SetAddressSky(assets.AmountAt(qAssets, 0))
+	qo.SetAddressCoinHours(assets.AmountAt(qAssets, 1))
 }
 
 func (m *ModelOutputs) init() {
@@ -46,6 +60,7 @@ func (m *ModelOutputs) init() {
 		AddressCoinHours: core.NewQByteArray2("addressCoinHours", -1),
 		AddressOwner:     core.NewQByteArray2("addressOwner", -1),
 		WalletOwner:      core.NewQByteArray2("walletOwner", -1),
+		LockState:        core.NewQByteArray2("lockState", -1),
 	})
 
 	m.ConnectRowCount(m.rowCount)
@@ -60,7 +75,7 @@ func (m *ModelOutputs) init() {
 }
 
 func (m *ModelOutputs) removeOutputsFromAddress(addr string) {
-	old := m.outputs
+	old := m.Outputs()
 	new := make([]*QOutput, 0)
 	for _, out := range old {
 		if out.AddressOwner() != addr {
@@ -71,7 +86,7 @@ func (m *ModelOutputs) removeOutputsFromAddress(addr string) {
 }
 
 func (m *ModelOutputs) removeOutputsFromWallet(wltId string) {
-	old := m.outputs
+	old := m.Outputs()
 	new := make([]*QOutput, 0)
 	for _, out := range old {
 		if out.WalletOwner() != wltId {
@@ -82,7 +97,7 @@ func (m *ModelOutputs) removeOutputsFromWallet(wltId string) {
 }
 
 func (m *ModelOutputs) rowCount(*core.QModelIndex) int {
-	return len(m.outputs)
+	return len(m.Outputs())
 }
 
 func (m *ModelOutputs) roleNames() map[int]*core.QByteArray {
@@ -94,11 +109,11 @@ func (m *ModelOutputs) data(index *core.QModelIndex, role int) *core.QVariant {
 		return core.NewQVariant()
 	}
 
-	if index.Row() >= len(m.outputs) {
+	if index.Row() >= len(m.Outputs()) {
 		return core.NewQVariant()
 	}
 
-	qo := m.outputs[index.Row()]
+	qo := m.Outputs()[index.Row()]
 
 	switch role {
 	case OutputID:
@@ -121,6 +136,10 @@ func (m *ModelOutputs) data(index *core.QModelIndex, role int) *core.QVariant {
 		{
 			return core.NewQVariant1(qo.WalletOwner())
 		}
+	case LockState:
+		{
+			return core.NewQVariant1(qo.LockState())
+		}
 	default:
 		{
 			return core.NewQVariant()
@@ -137,7 +156,7 @@ func (m *ModelOutputs) insertRows(row int, count int) bool {
 func (m *ModelOutputs) addOutputs(mo []*QOutput) {
 	for row, out := range mo {
 		find := false
-		for _, outSet := range m.outputs {
+		for _, outSet := range m.Outputs() {
 			if out.OutputID() == outSet.OutputID() {
 				outSet = out
 				find = true
@@ -146,10 +165,10 @@ func (m *ModelOutputs) addOutputs(mo []*QOutput) {
 		}
 		if !find {
 			m.BeginInsertRows(core.NewQModelIndex(), row, row)
-			m.outputs = append(m.outputs, out)
+			m.SetOutputs(append(m.Outputs(), out))
 			m.EndInsertRows()
 		} else {
-			m.DataChanged(m.Index(len(m.outputs)-1, row, core.NewQModelIndex()), m.Index(len(m.outputs)-1, row+1, core.NewQModelIndex()), []int{int(core.Qt__DisplayRole)})
+			m.DataChanged(m.Index(len(m.Outputs())-1, row, core.NewQModelIndex()), m.Index(len(m.Outputs())-1, row+1, core.NewQModelIndex()), []int{int(core.Qt__DisplayRole)})
 		}
 	}
 }
@@ -166,7 +185,7 @@ func contains(outputs []*QOutput, output *QOutput) bool {
 }
 
 func (m *ModelOutputs) insertOutputs(mo []*QOutput) {
-	toInsert := m.outputs
+	toInsert := m.Outputs()
 	for _, outputToInsert := range mo {
 		if !contains(toInsert, outputToInsert) {
 			toInsert = append(toInsert, outputToInsert)
@@ -177,12 +196,12 @@ func (m *ModelOutputs) insertOutputs(mo []*QOutput) {
 
 func (m *ModelOutputs) loadModel(mo []*QOutput) {
 	m.BeginResetModel()
-	m.outputs = mo
+	m.SetOutputs(mo)
 	m.EndResetModel()
 }
 
 func (m *ModelOutputs) cleanModel() {
 	m.BeginResetModel()
-	m.outputs = make([]*QOutput, 0)
+	m.SetOutputs(make([]*QOutput, 0))
 	m.EndResetModel()
 }
@@ -5,10 +5,9 @@ import (
 	"time"
 
 	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/config"
+	sky "github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/models"
 
-	coin "github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/models"
 	"github.com/fibercrypto/fibercryptowallet/src/core"
-	local "github.com/fibercrypto/fibercryptowallet/src/main"
 	"github.com/fibercrypto/fibercryptowallet/src/util"
 	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
 	qtcore "github.com/therecipe/qt/core"
@@ -63,13 +62,11 @@ func (m *ModelWallets) init() {
 	m.ConnectRemoveWallet(m.removeWallet)
 	m.SetLoading(true)
 	m.addresses = make([]*ModelAddresses, 0)
-	altManager := local.LoadAltcoinManager()
-	walletsEnvs := make([]core.WalletEnv, 0)
-	for _, plug := range altManager.ListRegisteredPlugins() {
-		walletsEnvs = append(walletsEnvs, plug.LoadWalletEnvs()...)
+	wltEnv, err := util.LoadWalletEnv(sky.Sky)
+	if err != nil {
+		logWalletModel.WithError(err).Error("Couldn't load wallet env")
 	}
-
-	m.WalletEnv = walletsEnvs[0]
+	m.WalletEnv = wltEnv
 	go func() {
 		uptimeTicker := time.NewTicker(time.Duration(config.GetDataUpdateTime()) * time.Second)
 
@@ -150,6 +147,7 @@ func (m *ModelWallets) loadModel() {
 			ma.SetName(wlt.GetLabel())
 			ma.SetId(wlt.GetId())
 			oModels := make([]*ModelOutputs, 0)
+			locks := walletOutputLocks(wlt)
 
 			for addresses.Next() {
 				a := addresses.Value()
@@ -169,34 +167,14 @@ func (m *ModelWallets) loadModel() {
 					qo := NewQOutput(nil)
 					qml.QQmlEngine_SetObjectOwnership(qo, qml.QQmlEngine__CppOwnership)
 					qo.SetOutputID(to.GetId())
-					val, err := to.GetCoins(coin.Sky)
-					if err != nil {
-						logWalletModel.WithError(nil).Warn("Couldn't get " + coin.Sky + " coins")
-						m.SetLoading(true)
-						continue
-					}
-					accuracy, err := util.AltcoinQuotient(coin.Sky)
-					if err != nil {
-						logWalletModel.WithError(err).Warn("Couldn't get " + coin.Sky + " coins quotient")
-						m.SetLoading(true)
-						continue
-					}
-					coins := util.FormatCoins(val, accuracy)
-					qo.SetAddressSky(coins)
-					val, err = to.GetCoins(coin.CoinHoursTicker)
-					if err != nil {
-						logWalletModel.WithError(err).Warn("Couldn't get " + coin.CoinHoursTicker + " coins")
-						m.SetLoading(true)
-						continue
-					}
-					accuracy, err = util.AltcoinQuotient(coin.CoinHoursTicker)
+					qo.SetLockState(int(locks[to.GetId()]))
+					amounts, err := util.OutputAmounts(to, a.GetCryptoAccount().ListAssets())
 					if err != nil {
-						logWalletModel.WithError(err).Warn("Couldn't get " + coin.CoinHoursTicker + " coins quotient")
+						logWalletModel.WithError(err).Warn("Couldn't get output coins")
 						m.SetLoading(true)
 						continue
 					}
-					coinsH := util.FormatCoins(val, accuracy)
-					qo.SetAddressCoinHours(coinsH)
+					qo.setAmounts(amounts)
 					qOutputs = append(qOutputs, qo)
 				}
 				if len(qOutputs) != 0 {
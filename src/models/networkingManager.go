@@ -18,7 +18,7 @@ type NetworkingManager struct {
 
 func (net *NetworkingManager) init() {
 	net.ConnectGetNetworks(net.getNetworks)
-	net.Networks = skycoin.NewSkycoinRemoteNetwork("http://127.0.0.1:6420")
+	net.Networks = skycoin.NewSkycoinRemoteNetwork(skycoin.PoolSection)
 
 }
 
@@ -3,7 +3,7 @@ package pending
 import (
 	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/models" //callable as skycoin
 	"github.com/fibercrypto/fibercryptowallet/src/core"
-	local "github.com/fibercrypto/fibercryptowallet/src/main"
+	"github.com/fibercrypto/fibercryptowallet/src/models/assets"
 	"github.com/fibercrypto/fibercryptowallet/src/util"
 	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
 	qtCore "github.com/therecipe/qt/core"
@@ -34,6 +34,7 @@ type PendingTransaction struct {
 	_ *qtCore.QDateTime `property:"timeStamp"`
 	_ string            `property:"transactionID"`
 	_ int               `property:"mine"`
+	_ []*assets.QAsset  `property:"amounts"`
 }
 
 func (model *PendingTransactionList) init() {
@@ -43,13 +44,12 @@ func (model *PendingTransactionList) init() {
 	model.SetLoading(true)
 	model.ConnectCleanPendingTxns(model.cleanPendingTxns)
 
-	altManager := local.LoadAltcoinManager()
-	walletsEnvs := make([]core.WalletEnv, 0)
-	for _, plug := range altManager.ListRegisteredPlugins() {
-		walletsEnvs = append(walletsEnvs, plug.LoadWalletEnvs()...)
+	wltEnv, err := util.LoadWalletEnv(skycoin.Sky)
+	if err != nil {
+		logPendingTxn.WithError(err).Error("Couldn't load wallet env")
 	}
 	model.PEX = &skycoin.SkycoinPEX{}
-	model.WalletEnv = walletsEnvs[0]
+	model.WalletEnv = wltEnv
 
 }
 
@@ -123,51 +123,32 @@ func TransactionToPendingTransaction(stxn core.Transaction) *PendingTransaction
 	year, month, day, h, m, s := util.ParseDate(int64(stxn.GetTimestamp()))
 	pt.SetTimeStamp(qtCore.NewQDateTime3(qtCore.NewQDate3(year, month, day), qtCore.NewQTime3(h, m, s, 0), qtCore.Qt__LocalTime))
 	pt.SetTransactionID(stxn.GetId())
-	iterator := skycoin.NewSkycoinTransactionOutputIterator(stxn.GetOutputs())
-	sky, coinHours := uint64(0), uint64(0)
-	skyErr, coinHoursErr := false, false
-	for iterator.Next() {
-		output := iterator.Value()
-		val, err := output.GetCoins(skycoin.Sky)
-		if err != nil {
-			logPendingTxn.WithError(err).Warn("Couldn't get Skycoins")
-		}
-		skyErr = skyErr || (err != nil)
-		if !skyErr {
-			sky = sky + val
-		}
-		val, err = output.GetCoins(skycoin.CoinHour)
-		if err != nil {
-			logPendingTxn.WithError(err).Warn("Couldn't get Coin Hours")
-		}
-		coinHoursErr = coinHoursErr || (err != nil)
-		if !coinHoursErr {
-			coinHours = coinHours + val
+	tickers := stxn.SupportedAssets()
+	balance := make(map[string]uint64, len(tickers))
+	for _, output := range stxn.GetOutputs() {
+		for _, ticker := range tickers {
+			val, err := output.GetCoins(ticker)
+			if err != nil {
+				logPendingTxn.WithError(err).Warn("Couldn't get " + ticker + " coins")
+				pt.SetAmounts(assets.NotAvailableAssets(tickers))
+				pt.SetSky(assets.NotAvailable)
+				pt.SetCoinHours(assets.NotAvailable)
+				return pt
+			}
+			balance[ticker] += val
 		}
 	}
-	skyAccuracy, err := util.AltcoinQuotient(skycoin.Sky)
+	amounts, err := util.AmountsFromBalances(tickers, balance)
 	if err != nil {
-		logPendingTxn.WithError(err).Warn("Couldn't get Skycoins quotient")
-	}
-	skyErr = skyErr || (err != nil)
-	float_sky := ""
-	if skyErr {
-		float_sky = "NA"
-	} else {
-		float_sky = util.FormatCoins(sky, skyAccuracy)
-	}
-	pt.SetSky(float_sky)
-	skychAccuracy, err2 := util.AltcoinQuotient(skycoin.CoinHour)
-	if err != nil {
-		logPendingTxn.WithError(err).Warn("Couldn't get Coin Hours quotient")
-	}
-	coinHoursErr = coinHoursErr || (err2 != nil)
-	uint_ch := ""
-	if coinHoursErr {
-		uint_ch = "NA"
-	} else {
-		uint_ch = util.FormatCoins(coinHours, skychAccuracy)
+		logPendingTxn.WithError(err).Warn("Couldn't format transaction amounts")
+		pt.SetAmounts(assets.NotAvailableAssets(tickers))
+		pt.SetSky(assets.NotAvailable)
+		pt.SetCoinHours(assets.NotAvailable)
+		return pt
 	}
-	pt.SetCoinHours(uint_ch)
+	qAmounts := assets.NewQAssetsFromAmounts(amounts)
+	pt.SetAmounts(qAmounts)
+	pt.SetSky(assets.AmountAt(qAmounts, 0))
+	pt.SetCoinHours(assets.AmountAt(qAmounts, 1))
 	return pt
 }
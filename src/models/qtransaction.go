@@ -1,9 +1,9 @@
 package models
 
 import (
-	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/params"
 	"github.com/fibercrypto/fibercryptowallet/src/core"
 	"github.com/fibercrypto/fibercryptowallet/src/models/address"
+	"github.com/fibercrypto/fibercryptowallet/src/models/assets"
 	"github.com/fibercrypto/fibercryptowallet/src/util"
 	qtcore "github.com/therecipe/qt/core"
 )
@@ -17,6 +17,8 @@ type QTransaction struct {
 	_   string               `property:"transactionId"`
 	_   *address.AddressList `property:"inputs"`
 	_   *address.AddressList `property:"outputs"`
+	_   []*assets.QAsset     `property:"amounts"`
+	_   []*assets.QAsset     `property:"fees"`
 }
 
 func NewQTransactionFromTransaction(txn core.Transaction) (*QTransaction, error) {
@@ -25,21 +27,17 @@ func NewQTransactionFromTransaction(txn core.Transaction) (*QTransaction, error)
 	qtxn.SetTransactionId(txn.GetId())
 	inputs := address.NewAddressList(nil)
 	outputs := address.NewAddressList(nil)
-	var hoursTraspassed uint64
-	var skyTraspassed uint64
-	hoursTraspassed = 0
-	skyTraspassed = 0
+	tickers := txn.SupportedAssets()
+	traspassed := make(map[string]uint64, len(tickers))
 	inputsAddresses := make(map[string]struct{}, 0)
-	quotient, err := util.AltcoinQuotient(params.CoinHoursTicker)
+	fees, err := util.TransactionFees(txn)
 	if err != nil {
 		return nil, err
 	}
-	ch, err := txn.ComputeFee(params.CoinHoursTicker)
-	if err != nil {
-		return nil, nil
-	}
-	fee := util.FormatCoins(ch, quotient)
-	qtxn.SetHoursBurned(fee)
+	qFees := assets.NewQAssetsFromAmounts(fees)
+	qtxn.SetFees(qFees)
+	hoursTicker := accruingTicker(txn, tickers)
+	qtxn.SetHoursBurned(assets.AmountOf(qFees, hoursTicker))
 
 	//Creating inputs
 	ins := txn.GetInputs()
@@ -56,24 +54,11 @@ func NewQTransactionFromTransaction(txn core.Transaction) (*QTransaction, error)
 		addr := outAddr.String()
 		inputsAddresses[addr] = struct{}{}
 		qIn.SetAddress(addr)
-		quotient, err := util.AltcoinQuotient(params.SkycoinTicker)
-		if err != nil {
-			return nil, err
-		}
-		sky, err := in.GetCoins(params.SkycoinTicker)
-		if err != nil {
-			return nil, err
-		}
-		qIn.SetAddressSky(util.FormatCoins(sky, quotient))
-		quotient, err = util.AltcoinQuotient(params.CoinHoursTicker)
-		if err != nil {
-			return nil, err
-		}
-		ch, err := in.GetCoins(params.CalculatedHoursTicker)
+		amounts, err := util.InputAmounts(in, tickers)
 		if err != nil {
 			return nil, err
 		}
-		qIn.SetAddressCoinHours(util.FormatCoins(ch, quotient))
+		qIn.SetAmounts(amounts)
 		inputs.AddAddress(qIn)
 	}
 	qtxn.SetInputs(inputs)
@@ -88,36 +73,47 @@ func NewQTransactionFromTransaction(txn core.Transaction) (*QTransaction, error)
 		}
 		addr := outAddr.String()
 		qOu.SetAddress(addr)
-		quotient, err := util.AltcoinQuotient(params.SkycoinTicker)
-		sky, err := out.GetCoins(params.SkycoinTicker)
+		amounts, err := util.OutputAmounts(out, tickers)
 		if err != nil {
 			return nil, err
 		}
-		qOu.SetAddressSky(util.FormatCoins(sky, quotient))
-		quotient, err = util.AltcoinQuotient(params.CoinHoursTicker)
-		if err != nil {
-			return nil, err
-		}
-		ch, err := out.GetCoins(params.CoinHoursTicker)
-		if err != nil {
-			return nil, err
-		}
-		qOu.SetAddressCoinHours(util.FormatCoins(ch, quotient))
+		qOu.SetAmounts(amounts)
 		outputs.AddAddress(qOu)
 		_, ok := inputsAddresses[addr]
 		if !ok {
-			skyTraspassed += sky
-			hoursTraspassed += ch
+			for _, amount := range amounts {
+				traspassed[amount.Ticker] += amount.Coins
+			}
 		}
 	}
 	qtxn.SetOutputs(outputs)
-	qtxn.SetHoursTraspassed(util.FormatCoins(hoursTraspassed, quotient))
-	quotient, err = util.AltcoinQuotient(params.SkycoinTicker)
+	amounts, err := util.AmountsFromBalances(tickers, traspassed)
 	if err != nil {
 		return nil, err
 	}
-	strSky := util.FormatCoins(skyTraspassed, quotient)
-	qtxn.SetAmount(strSky)
+	qAmounts := assets.NewQAssetsFromAmounts(amounts)
+	qtxn.SetAmounts(qAmounts)
+	if len(tickers) > 0 {
+		qtxn.SetAmount(assets.AmountOf(qAmounts, tickers[0]))
+	}
+	qtxn.SetHoursTraspassed(assets.AmountOf(qAmounts, hoursTicker))
 
 	return qtxn, nil
 }
+
+// accruingTicker returns the ticker of the asset accruing in transaction inputs,
+// shown as hours, or empty string if none does
+func accruingTicker(txn core.Transaction, tickers []string) string {
+	for _, in := range txn.GetInputs() {
+		accruing, isAccruing := in.(core.AccruingInput)
+		if !isAccruing {
+			continue
+		}
+		for _, ticker := range tickers {
+			if _, hasAccrued := accruing.AccruedAsset(ticker); hasAccrued {
+				return ticker
+			}
+		}
+	}
+	return ""
+}
@@ -2,6 +2,8 @@ package transactions
 
 import (
 	"github.com/fibercrypto/fibercryptowallet/src/models/address"
+	"github.com/fibercrypto/fibercryptowallet/src/models/assets"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
 	qtcore "github.com/therecipe/qt/core"
 )
 
@@ -46,4 +48,21 @@ type TransactionDetails struct {
 	_ *address.AddressList `property:"addresses"`
 	_ *address.AddressList `property:"inputs"`
 	_ *address.AddressList `property:"outputs"`
+	_ []*assets.QAsset     `property:"amounts"`
+	_ []*assets.QAsset     `property:"fees"`
+}
+
+// SetAssetAmounts binds the amount of every asset moved by this transaction
+func (td *TransactionDetails) SetAssetAmounts(amounts []util.AssetAmount) {
+	qAssets := assets.NewQAssetsFromAmounts(amounts)
+	td.SetAmounts(qAssets)
+	td.SetAmount(assets.AmountAt(qAssets, 0))
+	td.SetHoursTraspassed(assets.AmountAt(qAssets, 1))
+}
+
+// SetAssetFees binds the fee paid in every asset supported by this transaction
+func (td *TransactionDetails) SetAssetFees(fees []util.AssetAmount) {
+	qAssets := assets.NewQAssetsFromAmounts(fees)
+	td.SetFees(qAssets)
+	td.SetHoursBurned(assets.AmountAt(qAssets, 1))
 }
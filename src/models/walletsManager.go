@@ -1,14 +1,15 @@
 package models
 
 import (
+	"strconv"
 	"sync"
 
 	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin"
 	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/config"
 
-	"github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/params"
-
+	"github.com/fibercrypto/fibercryptowallet/src/models/assets"
 	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/fibercrypto/fibercryptowallet/src/util/universal"
 
 	"github.com/therecipe/qt/qml"
 
@@ -16,6 +17,7 @@ import (
 
 	sky "github.com/fibercrypto/fibercryptowallet/src/coin/skycoin/models"
 	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
 	local "github.com/fibercrypto/fibercryptowallet/src/main"
 	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
 	qtCore "github.com/therecipe/qt/core"
@@ -59,35 +61,47 @@ type WalletManager struct {
 	updaterChannel            chan *updateWalletInfo
 	timerUpdate               chan time.Duration
 
-	_ func()                                                                                                                           `slot:"updateWalletEnvs"`
-	_ func(wltId, address string)                                                                                                      `slot:"updateOutputs"`
-	_ func(string)                                                                                                                     `slot:"updateAddresses"`
-	_ func()                                                                                                                           `slot:"updateWallets"`
-	_ func()                                                                                                                           `slot:"updateAll"`
-	_ func()                                                                                                                           `constructor:"init"`
-	_ func(seed string, label string, walletType string, password string, scanN int) *QWallet                                          `slot:"createEncryptedWallet"`
-	_ func(seed string, label string, walletType string, scanN int) *QWallet                                                           `slot:"createUnencryptedWallet"`
-	_ func(entropy int) string                                                                                                         `slot:"getNewSeed"`
-	_ func(seed string) int                                                                                                            `slot:"verifySeed"`
-	_ func(id string, n int, password string)                                                                                          `slot:"newWalletAddress"`
-	_ func(id string, password string) int                                                                                             `slot:"encryptWallet"`
-	_ func(id string, password string) int                                                                                             `slot:"decryptWallet"`
-	_ func() []*QWallet                                                                                                                `slot:"getWallets"`
-	_ func(id string) []*QAddress                                                                                                      `slot:"getAddresses"`
-	_ func(wltIds, addresses []string, source string, pwd interface{}, index []int, qTxn *QTransaction) *QTransaction                  `slot:"signTxn"`
-	_ func(wltId string, destinationAddress string, amount string) *QTransaction                                                       `slot:"sendTo"`
-	_ func(id, label string) *QWallet                                                                                                  `slot:"editWallet"`
-	_ func(wltId, address string) []*QOutput                                                                                           `slot:"getOutputs"`
-	_ func(txn *QTransaction) bool                                                                                                     `slot:"broadcastTxn"`
-	_ func(wltIds, from, addrTo, skyTo, coinHoursTo []string, change string, automaticCoinHours bool, burnFactor string) *QTransaction `slot:"sendFromAddresses"`
-	_ func(wltIds, outs, addrTo, skyTo, coinHoursTo []string, change string, automaticCoinHours bool, burnFactor string) *QTransaction `slot:"sendFromOutputs"`
-	_ func() []*QAddress                                                                                                               `slot:"getAllAddresses"`
-	_ func(wltId string) []*QOutput                                                                                                    `slot:"getOutputsFromWallet"`
-	_ func() string                                                                                                                    `slot:"getDefaultWalletType"`
-	_ func(wltIds, addresses []string, source string, bridgeForPassword *QBridge, index []int, qTxn *QTransaction)                     `slot:"signAndBroadcastTxnAsync"`
-	_ func() []string                                                                                                                  `slot:"getAvailableWalletTypes"`
-	_ func(address string, value int)                                                                                                  `slot:"editMarkAddress"`
-	_ func(address string) int                                                                                                         `slot:"markFieldOfAddress"`
+	_ func()                                                                                                                      `slot:"updateWalletEnvs"`
+	_ func(wltId, address string)                                                                                                 `slot:"updateOutputs"`
+	_ func(string)                                                                                                                `slot:"updateAddresses"`
+	_ func()                                                                                                                      `slot:"updateWallets"`
+	_ func()                                                                                                                      `slot:"updateAll"`
+	_ func()                                                                                                                      `constructor:"init"`
+	_ func(seed string, label string, walletType string, password string, scanN int) *QWallet                                     `slot:"createEncryptedWallet"`
+	_ func(seed string, label string, walletType string, scanN int) *QWallet                                                      `slot:"createUnencryptedWallet"`
+	_ func(seed string, label string, password string, scanN int) int                                                             `slot:"createUniversalWallet"`
+	_ func(entropy int) string                                                                                                    `slot:"getNewSeed"`
+	_ func(seed string) int                                                                                                       `slot:"verifySeed"`
+	_ func(id string, n int, password string)                                                                                     `slot:"newWalletAddress"`
+	_ func(id string, password string) int                                                                                        `slot:"encryptWallet"`
+	_ func(id string, password string) int                                                                                        `slot:"decryptWallet"`
+	_ func() []*QWallet                                                                                                           `slot:"getWallets"`
+	_ func(id string) []*QAddress                                                                                                 `slot:"getAddresses"`
+	_ func(wltIds, addresses []string, source string, pwd interface{}, index []int, qTxn *QTransaction) *QTransaction             `slot:"signTxn"`
+	_ func(wltId string, destinationAddress string, amount string) *QTransaction                                                  `slot:"sendTo"`
+	_ func(id, label string) *QWallet                                                                                             `slot:"editWallet"`
+	_ func(wltId, address string) []*QOutput                                                                                      `slot:"getOutputs"`
+	_ func(txn *QTransaction) bool                                                                                                `slot:"broadcastTxn"`
+	_ string                                                                                                                      `property:"broadcastError"`
+	_ string                                                                                                                      `property:"settingsError"`
+	_ []*QTransaction                                                                                                             `property:"transferPlan"`
+	_ func(wltId, source string, bridgeForPassword *QBridge)                                                                      `slot:"signAndBroadcastPlanAsync"`
+	_ func(wltId, dust, to string, optKeys, optValues []string) int                                                               `slot:"consolidateOutputs"`
+	_ func(wltId, outID string, to, optKeys, optValues []string) int                                                              `slot:"splitOutput"`
+	_ func(wltId string) []*QOutput                                                                                               `slot:"previewOutputs"`
+	_ func(wltId, outID string, lock int) int                                                                                     `slot:"setOutputLock"`
+	_ func(wltId, at string) []*QOutput                                                                                           `slot:"projectOutputs"`
+	_ func(wltId string, from, addrTo, tickers, amountsTo []string, change, at string, optKeys, optValues []string) *QTransaction `slot:"previewTransfer"`
+	_ func(wltIds, from, addrTo, tickers, amountsTo []string, change string, optKeys, optValues []string) *QTransaction           `slot:"sendAssetsFromAddresses"`
+	_ func(wltIds, outs, addrTo, tickers, amountsTo []string, change string, optKeys, optValues []string) *QTransaction           `slot:"sendAssetsFromOutputs"`
+	_ func(wltId string) []*assets.QTxnOption                                                                                     `slot:"getTxnOptions"`
+	_ func() []*QAddress                                                                                                          `slot:"getAllAddresses"`
+	_ func(wltId string) []*QOutput                                                                                               `slot:"getOutputsFromWallet"`
+	_ func() string                                                                                                               `slot:"getDefaultWalletType"`
+	_ func(wltIds, addresses []string, source string, bridgeForPassword *QBridge, index []int, qTxn *QTransaction)                `slot:"signAndBroadcastTxnAsync"`
+	_ func() []string                                                                                                             `slot:"getAvailableWalletTypes"`
+	_ func(address string, value int)                                                                                             `slot:"editMarkAddress"`
+	_ func(address string) int                                                                                                    `slot:"markFieldOfAddress"`
 }
 
 func (walletM *WalletManager) init() {
@@ -98,6 +112,7 @@ func (walletM *WalletManager) init() {
 		walletM.ConnectEditWallet(walletM.editWallet)
 		walletM.ConnectCreateEncryptedWallet(walletM.createEncryptedWallet)
 		walletM.ConnectCreateUnencryptedWallet(walletM.createUnencryptedWallet)
+		walletM.ConnectCreateUniversalWallet(walletM.createUniversalWallet)
 		walletM.ConnectGetNewSeed(walletM.getNewSeed)
 		walletM.ConnectVerifySeed(walletM.verifySeed)
 		walletM.ConnectNewWalletAddress(walletM.newWalletAddress)
@@ -108,8 +123,9 @@ func (walletM *WalletManager) init() {
 		walletM.ConnectSendTo(walletM.sendTo)
 		walletM.ConnectSignTxn(walletM.signTxn)
 		walletM.ConnectGetOutputs(walletM.getOutputs)
-		walletM.ConnectSendFromAddresses(walletM.sendFromAddresses)
-		walletM.ConnectSendFromOutputs(walletM.sendFromOutputs)
+		walletM.ConnectSendAssetsFromAddresses(walletM.sendAssetsFromAddresses)
+		walletM.ConnectSendAssetsFromOutputs(walletM.sendAssetsFromOutputs)
+		walletM.ConnectGetTxnOptions(walletM.getTxnOptions)
 		walletM.ConnectBroadcastTxn(walletM.broadcastTxn)
 		walletM.ConnectGetAllAddresses(walletM.getAllAddresses)
 		walletM.ConnectGetOutputsFromWallet(walletM.getOutputsFromWallet)
@@ -119,6 +135,13 @@ func (walletM *WalletManager) init() {
 		walletM.ConnectUpdateAddresses(walletM.updateAddresses)
 		walletM.ConnectUpdateOutputs(walletM.updateOutputs)
 		walletM.ConnectSignAndBroadcastTxnAsync(walletM.signAndBroadcastTxnAsync)
+		walletM.ConnectSignAndBroadcastPlanAsync(walletM.signAndBroadcastPlanAsync)
+		walletM.ConnectConsolidateOutputs(walletM.consolidateOutputs)
+		walletM.ConnectSplitOutput(walletM.splitOutput)
+		walletM.ConnectPreviewOutputs(walletM.previewOutputs)
+		walletM.ConnectSetOutputLock(walletM.setOutputLock)
+		walletM.ConnectProjectOutputs(walletM.projectOutputs)
+		walletM.ConnectPreviewTransfer(walletM.previewTransfer)
 		walletM.ConnectGetDefaultWalletType(walletM.getDefaultWalletType)
 		walletM.ConnectGetAvailableWalletTypes(walletM.getAvailableWalletTypes)
 		walletM.ConnectEditMarkAddress(walletM.editMarkAddress)
@@ -159,8 +182,7 @@ func (walletM *WalletManager) init() {
 		qWallet.SetFileName(it.Value().GetId())
 		qWallet.SetEncryptionEnabled(0)
 
-		qWallet.SetSky("N/A")
-		qWallet.SetCoinHours("N/A")
+		qWallet.setNotAvailable(it.Value().GetCryptoAccount().ListAssets())
 		qWallets = append(qWallets, qWallet)
 		walletM.utilByWallets[it.Value().GetId()] = &utilByWallet{
 			m:           sync.Mutex{},
@@ -222,7 +244,12 @@ func (walletM *WalletManager) updateAll() {
 	walletM.updateTransactionAPI()
 	walletM.updateSigner()
 	walletM.updateWalletEnvs()
-	skycoin.UpdateAltcoin()
+	if err := skycoin.UpdateAltcoin(); err != nil {
+		logWalletManager.WithError(err).Error("Couldn't apply Skycoin settings")
+		walletM.SetSettingsError(err.Error())
+	} else {
+		walletM.SetSettingsError("")
+	}
 	updateTime := config.GetDataUpdateTime()
 	logWalletManager.Debug("Update time is :=> ", time.Duration(updateTime)*time.Second)
 	walletM.timerUpdate <- time.Duration(updateTime) * time.Second
@@ -250,48 +277,37 @@ func (walletM *WalletManager) getAvailableWalletTypes() []string {
 	return walletM.WalletEnv.GetWalletSet().SupportedWalletTypes()
 }
 
+// updateSigner loads sign service of the plugin handling wallets shown in the GUI
 func (walletM *WalletManager) updateSigner() {
-
 	logWalletManager.Info("Updating Signers")
-	signers := make([]core.BlockchainSignService, 0)
-
-	for _, plug := range walletM.altManager.ListRegisteredPlugins() {
-		sing, err := plug.LoadSignService()
-		if err != nil {
-			logWalletManager.WithError(err).Errorf("Error loading signer from %s plugin", plug.GetName())
-		}
-		signers = append(signers, sing)
+	signer, err := util.LoadSignService(sky.Sky)
+	if err != nil {
+		logWalletManager.WithError(err).Errorf("Error loading signer for %s", sky.Sky)
+		return
 	}
-
-	walletM.signer = signers[0]
+	walletM.signer = signer
 }
 
+// updateTransactionAPI loads transaction API of the plugin handling wallets shown in the GUI
 func (walletM *WalletManager) updateTransactionAPI() {
 	logWalletManager.Info("Updating TransactionAPI")
-	txnAPIS := make([]core.BlockchainTransactionAPI, 0)
-
-	for _, plug := range walletM.altManager.ListRegisteredPlugins() {
-		txnAPI, err := plug.LoadTransactionAPI("MainNet")
-		if err != nil {
-			logWalletManager.WithError(err).Errorf("Error loading transaction API from %s plugin", plug.GetName())
-		}
-		txnAPIS = append(txnAPIS, txnAPI)
+	txnAPI, err := util.LoadTransactionAPI(sky.Sky, "MainNet")
+	if err != nil {
+		logWalletManager.WithError(err).Errorf("Error loading transaction API for %s", sky.Sky)
+		return
 	}
-
-	walletM.transactionAPI = txnAPIS[0]
+	walletM.transactionAPI = txnAPI
 }
+
+// updateWalletEnvs loads wallet environment of the plugin handling wallets shown in the GUI
 func (walletM *WalletManager) updateWalletEnvs() {
 	logWalletManager.Info("Updating WalletEnvs")
-	walletsEnvs := make([]core.WalletEnv, 0)
-
-	for _, plug := range walletM.altManager.ListRegisteredPlugins() {
-		walletsEnvs = append(walletsEnvs, plug.LoadWalletEnvs()...)
-	}
-	if len(walletsEnvs) == 0 {
-		logWalletManager.Error("Error loading wallet envs")
+	wltEnv, err := util.LoadWalletEnv(sky.Sky)
+	if err != nil {
+		logWalletManager.WithError(err).Error("Error loading wallet envs")
 		return
 	}
-	walletM.WalletEnv = walletsEnvs[0]
+	walletM.WalletEnv = wltEnv
 }
 
 func (walletM *WalletManager) initWalletAddresses(wltId string) {
@@ -312,8 +328,7 @@ func (walletM *WalletManager) initWalletAddresses(wltId string) {
 		qAddress.SetMarked(0)
 		qAddress.SetWallet(wlt.GetLabel())
 		qAddress.SetWalletId(wlt.GetId())
-		qAddress.SetAddressSky("N/A")
-		qAddress.SetAddressCoinHours("N/A")
+		qAddress.setNotAvailable(addr.GetCryptoAccount().ListAssets())
 		qml.QQmlEngine_SetObjectOwnership(qAddress, qml.QQmlEngine__CppOwnership)
 
 		qAddresses2 = append(qAddresses2, qAddress)
@@ -356,34 +371,13 @@ func (walletM *WalletManager) updateAddresses(wltId string) {
 		qAddress.SetMarked(0)
 		qAddress.SetWallet(wlt.GetLabel())
 		qAddress.SetWalletId(wlt.GetId())
-		skyFl, err := addr.GetCryptoAccount().GetBalance(params.SkycoinTicker)
-		if err != nil {
-			qAddress.SetAddressSky("N/A")
-			qAddress.SetAddressCoinHours("N/A")
-			logWalletManager.WithError(err).Warn("Couldn't load " + params.SkycoinTicker + " Balance")
-			continue
-		}
-		accuracy, err := util.AltcoinQuotient(params.SkycoinTicker)
-		if err != nil {
-			qAddress.SetAddressSky("N/A")
-			qAddress.SetAddressCoinHours("N/A")
-			logWalletManager.WithError(err).Warn("Couldn't load " + params.SkycoinTicker + " quotient")
-			continue
-		}
-		qAddress.SetAddressSky(util.FormatCoins(skyFl, accuracy))
-		coinH, err := addr.GetCryptoAccount().GetBalance(params.CoinHoursTicker)
-		if err != nil {
-			qAddress.SetAddressCoinHours("N/A")
-			logWalletManager.WithError(err).Warn("Couldn't load " + params.CoinHoursTicker + " Balance")
-			continue
-		}
-		accuracy, err = util.AltcoinQuotient(params.CoinHoursTicker)
+		balances, err := util.AccountBalances(addr.GetCryptoAccount())
 		if err != nil {
-			qAddress.SetAddressCoinHours("N/A")
-			logWalletManager.WithError(err).Warn("Couldn't load " + params.CoinHoursTicker + " quotient")
+			qAddress.setNotAvailable(addr.GetCryptoAccount().ListAssets())
+			logWalletManager.WithError(err).Warn("Couldn't load address balance")
 			continue
 		}
-		qAddress.SetAddressCoinHours(util.FormatCoins(coinH, accuracy))
+		qAddress.setAmounts(balances)
 		qml.QQmlEngine_SetObjectOwnership(qAddress, qml.QQmlEngine__CppOwnership)
 
 		info := new(updateAddressInfo)
@@ -395,16 +389,8 @@ func (walletM *WalletManager) updateAddresses(wltId string) {
 			sendChan <- info
 
 		} else {
-			changed := false
-			if qAddress.AddressSky() != "N/A" && qAddress.AddressSky() != oldAddr.AddressSky() {
-				changed = true
-				oldAddr.SetAddressSky(qAddress.AddressSky())
-			}
-			if qAddress.AddressCoinHours() != "N/A" && qAddress.AddressCoinHours() != oldAddr.AddressCoinHours() {
-				changed = true
-				oldAddr.SetAddressCoinHours(qAddress.AddressCoinHours())
-			}
-			if changed {
+			if !assets.EqualAmounts(qAddress.Assets(), oldAddr.Assets()) {
+				updateBalanceValues(oldAddr, qAddress)
 				info.isNew = false
 				info.address = oldAddr
 				sendChan <- info
@@ -447,38 +433,18 @@ func (walletM *WalletManager) updateOutputs(wltId, address string) {
 		walletM.outputsByAddressMutex.Unlock()
 		return
 	}
+	locks := walletOutputLocks(walletM.WalletEnv.GetWalletSet().GetWallet(wltId))
 	for outsIter.Next() {
 		qout := NewQOutput(nil)
 		qml.QQmlEngine_SetObjectOwnership(qout, qml.QQmlEngine__CppOwnership)
 		qout.SetOutputID(outsIter.Value().GetId())
-		skyV, err := outsIter.Value().GetCoins(sky.Sky)
-		if err != nil {
-			qout.SetAddressSky("N/A")
-			logWalletManager.WithError(err).Warn("Couldn't get " + sky.Sky + " coins")
-			continue
-		}
-		quotient, err := util.AltcoinQuotient(sky.Sky)
-		if err != nil {
-			qout.SetAddressSky("N/A")
-			logWalletManager.WithError(err).Warn("Couldn't get " + sky.Sky + " quotient")
-			continue
-		}
-		sSky := util.FormatCoins(skyV, quotient)
-		qout.SetAddressSky(sSky)
-		ch, err := outsIter.Value().GetCoins(sky.CoinHour)
+		qout.SetLockState(int(locks[outsIter.Value().GetId()]))
+		amounts, err := util.OutputAmounts(outsIter.Value(), addr.GetCryptoAccount().ListAssets())
 		if err != nil {
-			qout.SetAddressCoinHours("N/A")
-			logWalletManager.WithError(err).Warn("Couldn't get " + sky.CoinHour + " coins")
+			logWalletManager.WithError(err).Warn("Couldn't get output coins")
 			continue
 		}
-		quotient, err = util.AltcoinQuotient(sky.CoinHour)
-		if err != nil {
-			qout.SetAddressCoinHours("N/A")
-			logWalletManager.WithError(err).Warn("Couldn't get " + sky.Sky + " quotient")
-			continue
-		}
-		sCh := util.FormatCoins(ch, quotient)
-		qout.SetAddressCoinHours(sCh)
+		qout.setAmounts(amounts)
 		qout.SetAddressOwner(addr.String())
 		qout.SetWalletOwner(wltId)
 		outs = append(outs, qout)
@@ -511,7 +477,7 @@ func (walletM *WalletManager) updateWallets() {
 			if walletM.wallets[i].FileName() == qw.FileName() {
 				row = i
 				founded = true
-				if !isEqual(walletM.wallets[i], qw) && (qw.Sky() != "N/A" || qw.CoinHours() != "N/A") {
+				if !isEqual(walletM.wallets[i], qw) && hasBalances(qw) {
 					qw.SetExpand(walletM.wallets[i].IsExpand())
 					walletM.wallets[i] = qw
 					changed = true
@@ -554,13 +520,20 @@ func (walletM *WalletManager) getAllAddresses() []*QAddress {
 }
 
 func isEqual(a, b *QWallet) bool {
-	return a.Name() == b.Name() && a.Sky() == b.Sky() && a.CoinHours() == b.CoinHours() && a.EncryptionEnabled() == b.EncryptionEnabled()
+	return a.Name() == b.Name() && assets.EqualAmounts(a.Balances(), b.Balances()) && a.EncryptionEnabled() == b.EncryptionEnabled()
+}
+
+func hasBalances(qw *QWallet) bool {
+	for _, qAsset := range qw.Balances() {
+		if qAsset.Amount() != assets.NotAvailable {
+			return true
+		}
+	}
+	return false
 }
 func (walletM *WalletManager) broadcastTxn(txn *QTransaction) bool {
 	logWalletManager.Info("Broadcasting transaction")
-	altManager := local.LoadAltcoinManager()
-	plug, _ := altManager.LookupAltcoinPlugin(params.SkycoinTicker)
-	pex, err := plug.LoadPEX("MainNet")
+	pex, err := loadTxnPEX(txn.txn)
 	if err != nil {
 		logWalletManager.WithError(err).Warn("Error loading PEX")
 		return false
@@ -572,19 +545,42 @@ func (walletM *WalletManager) broadcastTxn(txn *QTransaction) bool {
 	}
 	if !isSigned {
 		logWalletManager.Warn("Transaction is not fully signed")
+		walletM.SetBroadcastError(errors.ErrTxnNotFullySigned.Error())
 		return false
 	}
+	if validator, isValidator := pex.(core.TxnValidator); isValidator {
+		if err := validator.ValidateTxn(txn.txn); err != nil {
+			logWalletManager.WithError(err).Warn("Transaction is not valid")
+			walletM.SetBroadcastError(err.Error())
+			return false
+		}
+	}
 	err = pex.BroadcastTxn(txn.txn)
 	if err != nil {
 		logWalletManager.WithError(err).Warn("Error broadcasting transaction")
+		walletM.SetBroadcastError(err.Error())
 		return false
 	}
 	logWalletManager.Info("Transaction Injected")
+	walletM.SetBroadcastError("")
 	return true
 }
 
-func (walletM *WalletManager) sendFromOutputs(wltIds []string, from, addrTo, skyTo, coinHoursTo []string, change string, automaticCoinHours bool, burnFactor string) *QTransaction {
-	logWalletManager.Info("Creating transaction")
+// loadTxnPEX loads PEX of plugin handling first asset of transaction
+func loadTxnPEX(txn core.Transaction) (core.PEX, error) {
+	tickers := txn.SupportedAssets()
+	if len(tickers) == 0 {
+		return nil, errors.ErrInvalidTxn
+	}
+	altManager := local.LoadAltcoinManager()
+	plug, isRegistered := altManager.LookupAltcoinPlugin(tickers[0])
+	if !isRegistered {
+		return nil, errors.ErrInvalidAltcoinTicker
+	}
+	return plug.LoadPEX("MainNet")
+}
+
+func (walletM *WalletManager) lookupWallets(wltIds []string) ([]core.Wallet, int) {
 	wltCache := make(map[string]core.Wallet, 0)
 	wlts := make([]core.Wallet, 0)
 	for _, wltId := range wltIds {
@@ -594,51 +590,67 @@ func (walletM *WalletManager) sendFromOutputs(wltIds []string, from, addrTo, sky
 			wlt = walletM.WalletEnv.GetWalletSet().GetWallet(wltId)
 			if wlt == nil {
 				logWalletManager.Warn("Couldn't load wallet to create transaction")
-				return nil
+				return nil, 0
 			}
 			wltCache[wltId] = wlt
 		}
 		wlts = append(wlts, wlt)
 	}
+	return wlts, len(wltCache)
+}
+
+func newTxnOptionsForWallet(wlt core.Wallet, optKeys, optValues []string) core.KeyValueStore {
+	values := make(map[string]string, len(optKeys))
+	for i, key := range optKeys {
+		if i < len(optValues) {
+			values[key] = optValues[i]
+		}
+	}
+	tickers := wlt.GetCryptoAccount().ListAssets()
+	if len(tickers) == 0 {
+		return util.NewTxnOptions("", values)
+	}
+	return util.NewTxnOptions(tickers[0], values)
+}
+
+func (walletM *WalletManager) getTxnOptions(wltId string) []*assets.QTxnOption {
+	wlt := walletM.WalletEnv.GetWalletSet().GetWallet(wltId)
+	if wlt == nil {
+		logWalletManager.Warn("Couldn't load wallet to list transaction options")
+		return nil
+	}
+	qOpts := make([]*assets.QTxnOption, 0)
+	tickers := wlt.GetCryptoAccount().ListAssets()
+	if len(tickers) == 0 {
+		return qOpts
+	}
+	for _, spec := range util.ListTxnOptions(tickers[0]) {
+		qOpts = append(qOpts, assets.NewQTxnOptionFromSpec(spec))
+	}
+	return qOpts
+}
+
+func (walletM *WalletManager) sendAssetsFromOutputs(wltIds []string, from, addrTo, tickers, amountsTo []string, change string, optKeys, optValues []string) *QTransaction {
+	logWalletManager.Info("Creating transaction")
+	wlts, wltCount := walletM.lookupWallets(wltIds)
+	if len(wlts) == 0 {
+		return nil
+	}
 
 	outputsFrom := make([]core.TransactionOutput, 0)
 	for _, outAddr := range from {
 		out := util.NewGenericOutput(nil, outAddr)
 		outputsFrom = append(outputsFrom, &out)
 	}
-	outputsTo := make([]core.TransactionOutput, 0)
-	for i := 0; i < len(addrTo); i++ {
-		ch := ""
-		if !automaticCoinHours {
-			ch = coinHoursTo[i]
-		}
-		addr := util.NewGenericAddress(addrTo[i])
-		out := util.NewGenericOutput(&addr, "")
-		// FIXME: Remove explicit reference to Skycoin
-		err := out.PushCoins(sky.Sky, skyTo[i])
-		if err != nil {
-			logWalletManager.WithError(err).Warn("Error parsing value for %s", sky.Sky)
-			return nil
-		}
-		// FIXME: Remove explicit reference to Skycoin
-		err = out.PushCoins(sky.CoinHour, ch)
-		if err != nil {
-			logWalletManager.WithError(err).Warn("Error parsing value for %s", sky.Sky)
-			return nil
-		}
-		outputsTo = append(outputsTo, &out)
+	outputsTo, err := util.NewDestinationOutputs(addrTo, tickers, amountsTo)
+	if err != nil {
+		logWalletManager.WithError(err).Warn("Error parsing destination amounts")
+		return nil
 	}
 	changeAddr := util.NewGenericAddress(change)
-	opt := util.NewKeyValueMap()
-	opt.SetValue("BurnFactor", burnFactor)
-	if automaticCoinHours {
-		opt.SetValue("CoinHoursSelectionType", "auto")
-	} else {
-		opt.SetValue("CoinHoursSelectionType", "manual")
-	}
+	opt := newTxnOptionsForWallet(wlts[0], optKeys, optValues)
 	var txn core.Transaction
-	var err error
-	if len(wltCache) > 1 {
+	if wltCount > 1 {
 		walletsOutputs := make([]core.WalletOutput, 0)
 		for i, wlt := range wlts {
 			walletsOutputs = append(walletsOutputs, &util.SimpleWalletOutput{
@@ -663,21 +675,11 @@ func (walletM *WalletManager) sendFromOutputs(wltIds []string, from, addrTo, sky
 	}
 	return qTransaction
 }
-func (walletM *WalletManager) sendFromAddresses(wltIds []string, from, addrTo, skyTo, coinHoursTo []string, change string, automaticCoinHours bool, burnFactor string) *QTransaction {
-	wltCache := make(map[string]core.Wallet, 0)
-	wlts := make([]core.Wallet, 0)
-	for _, wltId := range wltIds {
-		var wlt core.Wallet
-		wlt, exist := wltCache[wltId]
-		if !exist {
-			wlt = walletM.WalletEnv.GetWalletSet().GetWallet(wltId)
-			if wlt == nil {
-				logWalletManager.Warn("Couldn't load wallet to create transaction")
-				return nil
-			}
-			wltCache[wltId] = wlt
-		}
-		wlts = append(wlts, wlt)
+
+func (walletM *WalletManager) sendAssetsFromAddresses(wltIds []string, from, addrTo, tickers, amountsTo []string, change string, optKeys, optValues []string) *QTransaction {
+	wlts, wltCount := walletM.lookupWallets(wltIds)
+	if len(wlts) == 0 {
+		return nil
 	}
 
 	addrsFrom := make([]core.Address, 0)
@@ -685,40 +687,29 @@ func (walletM *WalletManager) sendFromAddresses(wltIds []string, from, addrTo, s
 
 		addrsFrom = append(addrsFrom, &util.GenericAddress{addr})
 	}
-	outputsTo := make([]core.TransactionOutput, 0)
-	for i := 0; i < len(addrTo); i++ {
-		ch := ""
-		if !automaticCoinHours {
-			ch = coinHoursTo[i]
-		}
-		addr := util.NewGenericAddress(addrTo[i])
-		out := util.NewGenericOutput(&addr, "")
-		// FIXME: Remove explicit reference to Skycoin
-		err := out.PushCoins(sky.Sky, skyTo[i])
-		if err != nil {
-			logWalletManager.WithError(err).Warnf("Error parsing value for %s", sky.Sky)
-			return nil
-		}
-		// FIXME: Remove explicit reference to Skycoin
-		err = out.PushCoins(sky.CoinHour, ch)
-		if err != nil {
-			logWalletManager.WithError(err).Warnf("Error parsing value for %s", sky.Sky)
-			return nil
-		}
-		outputsTo = append(outputsTo, &out)
+	outputsTo, err := util.NewDestinationOutputs(addrTo, tickers, amountsTo)
+	if err != nil {
+		logWalletManager.WithError(err).Warn("Error parsing destination amounts")
+		return nil
 	}
 	changeAddr := &util.GenericAddress{change}
 
-	opt := util.NewKeyValueMap()
-	opt.SetValue("BurnFactor", burnFactor)
-	if automaticCoinHours {
-		opt.SetValue("CoinHoursSelectionType", "auto")
-	} else {
-		opt.SetValue("CoinHoursSelectionType", "manual")
-	}
+	opt := newTxnOptionsForWallet(wlts[0], optKeys, optValues)
+	walletM.SetTransferPlan(nil)
 	var txn core.Transaction
-	var err error
-	if len(wltCache) > 1 {
+	if planner, isPlanner := wlts[0].(core.TxnPlanner); isPlanner && wltCount == 1 {
+		var plan []core.Transaction
+		plan, err = planner.PlanSendFromAddress(addrsFrom, outputsTo, changeAddr, opt)
+		if err == nil && len(plan) > 1 {
+			// Transfer exceeds transaction limits, plan is reviewed and signed as a batch
+			logWalletManager.Infof("Transfer split in %d transactions", len(plan))
+			walletM.setTransferPlan(plan)
+			return nil
+		}
+		if err == nil {
+			txn = plan[0]
+		}
+	} else if wltCount > 1 {
 		walletsAddresses := make([]core.WalletAddress, 0)
 		for i, wlt := range wlts {
 			walletsAddresses = append(walletsAddresses, &util.SimpleWalletAddress{
@@ -772,19 +763,21 @@ func (walletM *WalletManager) getOutputsFromWallet(wltId string) []*QOutput {
 func (walletM *WalletManager) sendTo(wltId, destinationAddress, amount string) *QTransaction {
 	logWalletManager.Info("Creating Transaction")
 	wlt := walletM.WalletEnv.GetWalletSet().GetWallet(wltId)
-	addr := util.NewGenericAddress(destinationAddress)
-	opt := util.NewKeyValueMap()
-	opt.SetValue("BurnFactor", "0.5")
-	opt.SetValue("CoinHoursSelectionType", "auto")
 	if wlt == nil {
 		logWalletManager.Warn("Couldn't load wallet to create transaction")
 		return nil
 	}
+	tickers := wlt.GetCryptoAccount().ListAssets()
+	if len(tickers) == 0 {
+		logWalletManager.Warn("Wallet does not hold any asset")
+		return nil
+	}
+	addr := util.NewGenericAddress(destinationAddress)
+	opt := newTxnOptionsForWallet(wlt, nil, nil)
 	txOut := util.NewGenericOutput(&addr, "")
-	// FIXME: Remove explicit reference to Skycoin
-	err := txOut.PushCoins(sky.Sky, amount)
+	err := txOut.PushCoins(tickers[0], amount)
 	if err != nil {
-		logWalletManager.WithError(err).Warn("Error parsing value for %s", sky.Sky)
+		logWalletManager.WithError(err).Warnf("Error parsing value for %s", tickers[0])
 		return nil
 	}
 	txn, err := wlt.Transfer(&txOut, opt)
@@ -888,31 +881,34 @@ func (walletM *WalletManager) signTxn(wltIds, address []string, source string, t
 
 }
 
-func (walletM *WalletManager) signAndBroadcastTxnAsync(wltIds, addresses []string, source string, bridgeForPassword *QBridge, index []int, qTxn *QTransaction) {
-	channel := make(chan *QTransaction)
-	go func() {
-		var pwd core.PasswordReader = func(message string, ctx core.KeyValueStore) (string, error) {
-			bridgeForPassword.BeginUse()
-			defer bridgeForPassword.EndUse()
-			bridgeForPassword.lock()
-			suffix := ""
-			v := ctx.GetValue(core.StrWalletLabel)
-			if v == nil {
-				v = ctx.GetValue(core.StrWalletName)
-			}
-			if v != nil {
-				if str, isStr := v.(string); isStr {
-					suffix = " for " + str
-				}
+// bridgePasswordReader asks for passwords through QML bridge
+func bridgePasswordReader(bridgeForPassword *QBridge) core.PasswordReader {
+	return func(message string, ctx core.KeyValueStore) (string, error) {
+		bridgeForPassword.BeginUse()
+		defer bridgeForPassword.EndUse()
+		bridgeForPassword.lock()
+		suffix := ""
+		v := ctx.GetValue(core.StrWalletLabel)
+		if v == nil {
+			v = ctx.GetValue(core.StrWalletName)
+		}
+		if v != nil {
+			if str, isStr := v.(string); isStr {
+				suffix = " for " + str
 			}
-			bridgeForPassword.GetPassword(message + suffix)
-			bridgeForPassword.lock()
-			pass := bridgeForPassword.getResult()
-			bridgeForPassword.unlock()
-			return pass, nil
 		}
+		bridgeForPassword.GetPassword(message + suffix)
+		bridgeForPassword.lock()
+		pass := bridgeForPassword.getResult()
+		bridgeForPassword.unlock()
+		return pass, nil
+	}
+}
 
-		channel <- walletM.signTxn(wltIds, addresses, source, pwd, index, qTxn)
+func (walletM *WalletManager) signAndBroadcastTxnAsync(wltIds, addresses []string, source string, bridgeForPassword *QBridge, index []int, qTxn *QTransaction) {
+	channel := make(chan *QTransaction)
+	go func() {
+		channel <- walletM.signTxn(wltIds, addresses, source, bridgePasswordReader(bridgeForPassword), index, qTxn)
 	}()
 
 	go func() {
@@ -923,6 +919,298 @@ func (walletM *WalletManager) signAndBroadcastTxnAsync(wltIds, addresses []strin
 	}()
 }
 
+// setTransferPlan exposes transactions of a split transfer for review
+func (walletM *WalletManager) setTransferPlan(plan []core.Transaction) {
+	qPlan := make([]*QTransaction, 0, len(plan))
+	for _, txn := range plan {
+		qTxn, err := NewQTransactionFromTransaction(txn)
+		if err != nil {
+			logWalletManager.WithError(err).Warn("Error converting transaction")
+			return
+		}
+		qPlan = append(qPlan, qTxn)
+	}
+	walletM.SetTransferPlan(qPlan)
+}
+
+// signAndBroadcastPlanAsync signs transactions of split transfer as a batch and broadcasts them in order
+func (walletM *WalletManager) signAndBroadcastPlanAsync(wltId, source string, bridgeForPassword *QBridge) {
+	qPlan := walletM.TransferPlan()
+	if len(qPlan) == 0 {
+		logWalletManager.Warn("No transfer plan to sign")
+		return
+	}
+	plan := make([]core.Transaction, 0, len(qPlan))
+	for _, qTxn := range qPlan {
+		plan = append(plan, qTxn.txn)
+	}
+	wlts, _ := walletM.lookupWallets([]string{wltId})
+	if len(wlts) == 0 {
+		return
+	}
+	planner, isPlanner := wlts[0].(core.TxnPlanner)
+	if !isPlanner {
+		logWalletManager.Warn("Wallet can not sign transfer plans")
+		return
+	}
+	go func() {
+		signer, err := util.LookupSignServiceForWallet(wlts[0], core.UID(source))
+		if err != nil {
+			logWalletManager.WithError(err).Warnf("No signer %s for wallet %v", source, wlts[0])
+			return
+		}
+		if signerUid, err := signer.GetSignerUID(); err == nil && wlts[0].GetId() == string(signerUid) {
+			// NOTE the signer is the wallet it self
+			signer = nil
+		}
+		signed, err := planner.SignPlan(plan, signer, bridgePasswordReader(bridgeForPassword))
+		if err != nil {
+			logWalletManager.WithError(err).Warn("Error signing transfer plan")
+			walletM.SetBroadcastError(err.Error())
+			return
+		}
+		pex, err := loadTxnPEX(signed[0])
+		if err != nil {
+			logWalletManager.WithError(err).Warn("Error loading PEX")
+			walletM.SetBroadcastError(err.Error())
+			return
+		}
+		broadcaster, isBroadcaster := pex.(core.PlanBroadcaster)
+		if !isBroadcaster {
+			logWalletManager.Warn("PEX can not broadcast transfer plans")
+			return
+		}
+		if err := broadcaster.BroadcastPlan(signed); err != nil {
+			logWalletManager.WithError(err).Warn("Error broadcasting transfer plan")
+			walletM.SetBroadcastError(err.Error())
+			return
+		}
+		logWalletManager.Info("Transfer plan broadcast")
+		walletM.SetBroadcastError("")
+		walletM.SetTransferPlan(nil)
+	}()
+}
+
+// consolidateOutputs exposes transactions merging wallet outputs holding less than dust coins for review
+func (walletM *WalletManager) consolidateOutputs(wltId, dust, to string, optKeys, optValues []string) int {
+	walletM.SetTransferPlan(nil)
+	wlts, _ := walletM.lookupWallets([]string{wltId})
+	if len(wlts) == 0 {
+		return 0
+	}
+	manager, isManager := wlts[0].(core.UTXOManager)
+	if !isManager {
+		logWalletManager.Warn("Wallet can not consolidate outputs")
+		return 0
+	}
+	var dustCoins uint64
+	if tickers := wlts[0].GetCryptoAccount().ListAssets(); dust != "" && len(tickers) > 0 {
+		dustOut := util.NewGenericOutput(nil, "")
+		if err := dustOut.PushCoins(tickers[0], dust); err != nil {
+			logWalletManager.WithError(err).Warn("Error parsing dust amount")
+			return 0
+		}
+		dustCoins, _ = dustOut.GetCoins(tickers[0])
+	}
+	plan, err := manager.ConsolidateOutputs(dustCoins, &util.GenericAddress{to}, newTxnOptionsForWallet(wlts[0], optKeys, optValues))
+	if err != nil {
+		logWalletManager.WithError(err).Warn("Error consolidating outputs")
+		return 0
+	}
+	walletM.setTransferPlan(plan)
+	return len(plan)
+}
+
+// splitOutput exposes transaction sending equal amounts of output coins to addresses for review
+func (walletM *WalletManager) splitOutput(wltId, outID string, to, optKeys, optValues []string) int {
+	walletM.SetTransferPlan(nil)
+	wlts, _ := walletM.lookupWallets([]string{wltId})
+	if len(wlts) == 0 {
+		return 0
+	}
+	manager, isManager := wlts[0].(core.UTXOManager)
+	if !isManager {
+		logWalletManager.Warn("Wallet can not split outputs")
+		return 0
+	}
+	addrs := make([]core.Address, 0, len(to))
+	for _, addr := range to {
+		addrs = append(addrs, &util.GenericAddress{addr})
+	}
+	txn, err := manager.SplitOutput(outID, addrs, newTxnOptionsForWallet(wlts[0], optKeys, optValues))
+	if err != nil {
+		logWalletManager.WithError(err).Warn("Error splitting output")
+		return 0
+	}
+	walletM.setTransferPlan([]core.Transaction{txn})
+	return 1
+}
+
+// previewOutputs lists wallet outputs once transactions of transfer plan are confirmed
+func (walletM *WalletManager) previewOutputs(wltId string) []*QOutput {
+	wlts, _ := walletM.lookupWallets([]string{wltId})
+	if len(wlts) == 0 {
+		return nil
+	}
+	manager, isManager := wlts[0].(core.UTXOManager)
+	if !isManager {
+		logWalletManager.Warn("Wallet can not preview outputs")
+		return nil
+	}
+	plan := make([]core.Transaction, 0)
+	for _, qTxn := range walletM.TransferPlan() {
+		plan = append(plan, qTxn.txn)
+	}
+	preview, err := manager.PreviewOutputs(plan)
+	if err != nil {
+		logWalletManager.WithError(err).Warn("Error previewing outputs")
+		return nil
+	}
+	return newQOutputsForWallet(wltId, wlts[0], preview)
+}
+
+// newQOutputsForWallet wraps outputs of wallet for views
+func newQOutputsForWallet(wltId string, wlt core.Wallet, walletOuts []core.TransactionOutput) []*QOutput {
+	tickers := wlt.GetCryptoAccount().ListAssets()
+	locks := walletOutputLocks(wlt)
+	outs := make([]*QOutput, 0, len(walletOuts))
+	for _, out := range walletOuts {
+		qout := NewQOutput(nil)
+		qml.QQmlEngine_SetObjectOwnership(qout, qml.QQmlEngine__CppOwnership)
+		qout.SetOutputID(out.GetId())
+		qout.SetLockState(int(locks[out.GetId()]))
+		amounts, err := util.OutputAmounts(out, tickers)
+		if err != nil {
+			logWalletManager.WithError(err).Warn("Couldn't get output coins")
+			continue
+		}
+		qout.setAmounts(amounts)
+		if addr, err := out.GetAddress(); err == nil {
+			qout.SetAddressOwner(addr.String())
+		}
+		qout.SetWalletOwner(wltId)
+		outs = append(outs, qout)
+	}
+	return outs
+}
+
+// projectOutputs lists wallet outputs with coin hours accumulated at Unix time at, head block time if empty
+func (walletM *WalletManager) projectOutputs(wltId, at string) []*QOutput {
+	wlts, _ := walletM.lookupWallets([]string{wltId})
+	if len(wlts) == 0 {
+		return nil
+	}
+	planner, isPlanner := wlts[0].(core.CoinHoursPlanner)
+	if !isPlanner {
+		logWalletManager.Warn("Wallet can not project coin hours")
+		return nil
+	}
+	timestamp, err := parseTimestamp(at)
+	if err != nil {
+		logWalletManager.WithError(err).Warn("Invalid projection time")
+		return nil
+	}
+	projected, err := planner.ProjectOutputs(nil, timestamp)
+	if err != nil {
+		logWalletManager.WithError(err).Warn("Error projecting outputs")
+		return nil
+	}
+	return newQOutputsForWallet(wltId, wlts[0], projected)
+}
+
+// previewTransfer instantiates transaction as it would be created at Unix time at, head block time if empty,
+// so that fee and coin hours received are reviewed before creating it
+func (walletM *WalletManager) previewTransfer(wltId string, from, addrTo, tickers, amountsTo []string, change, at string, optKeys, optValues []string) *QTransaction {
+	wlts, _ := walletM.lookupWallets([]string{wltId})
+	if len(wlts) == 0 {
+		return nil
+	}
+	planner, isPlanner := wlts[0].(core.CoinHoursPlanner)
+	if !isPlanner {
+		logWalletManager.Warn("Wallet can not preview transfers")
+		return nil
+	}
+	timestamp, err := parseTimestamp(at)
+	if err != nil {
+		logWalletManager.WithError(err).Warn("Invalid preview time")
+		return nil
+	}
+	var addrsFrom []core.Address
+	for _, addr := range from {
+		addrsFrom = append(addrsFrom, &util.GenericAddress{addr})
+	}
+	outputsTo, err := util.NewDestinationOutputs(addrTo, tickers, amountsTo)
+	if err != nil {
+		logWalletManager.WithError(err).Warn("Error parsing destination amounts")
+		return nil
+	}
+	var changeAddr core.Address
+	if change != "" {
+		changeAddr = &util.GenericAddress{change}
+	}
+	txn, err := planner.PreviewTransfer(addrsFrom, outputsTo, changeAddr, timestamp, newTxnOptionsForWallet(wlts[0], optKeys, optValues))
+	if err != nil {
+		logWalletManager.WithError(err).Warn("Error previewing transfer")
+		return nil
+	}
+	qTxn, err := NewQTransactionFromTransaction(txn)
+	if err != nil {
+		logWalletManager.WithError(err).Warn("Error converting transaction")
+		return nil
+	}
+	return qTxn
+}
+
+// parseTimestamp reads Unix time, zero if empty
+func parseTimestamp(at string) (core.Timestamp, error) {
+	if at == "" {
+		return 0, nil
+	}
+	t, err := strconv.ParseUint(at, 10, 64)
+	return core.Timestamp(t), err
+}
+
+// walletOutputLocks maps locked outputs of wallets supporting coin control to their lock state
+func walletOutputLocks(wlt core.Wallet) map[string]core.OutputLock {
+	controller, isController := wlt.(core.CoinController)
+	if !isController {
+		return nil
+	}
+	locks, err := controller.ListOutputLocks()
+	if err != nil {
+		logWalletManager.WithError(err).Warn("Couldn't load output locks")
+		return nil
+	}
+	return locks
+}
+
+// setOutputLock freezes, reserves or unlocks wallet output, cached outputs are updated accordingly
+func (walletM *WalletManager) setOutputLock(wltId, outID string, lock int) int {
+	wlts, _ := walletM.lookupWallets([]string{wltId})
+	if len(wlts) == 0 {
+		return 0
+	}
+	controller, isController := wlts[0].(core.CoinController)
+	if !isController {
+		logWalletManager.Warn("Wallet does not support coin control")
+		return 0
+	}
+	if err := controller.SetOutputLock(outID, core.OutputLock(lock)); err != nil {
+		logWalletManager.WithError(err).Warn("Couldn't set output lock")
+		return 0
+	}
+	walletM.outputsByAddressMutex.Lock()
+	defer walletM.outputsByAddressMutex.Unlock()
+	for _, outs := range walletM.outputsByAddress {
+		for _, qout := range outs {
+			if qout.OutputID() == outID {
+				qout.SetLockState(lock)
+			}
+		}
+	}
+	return 1
+}
+
 func (walletM *WalletManager) createEncryptedWallet(seed, label, wltType, password string, scanN int) *QWallet {
 	logWalletManager.Info("Creating encrypted wallet")
 	pwd := util.ConstantPassword(password)
@@ -976,6 +1264,28 @@ func (walletM *WalletManager) createUnencryptedWallet(seed, label, wltType strin
 
 }
 
+// createUniversalWallet links wallets of every BIP44 coin to a single seed and returns how many were linked
+func (walletM *WalletManager) createUniversalWallet(seed, label, password string, scanN int) int {
+	logWalletManager.Info("Creating universal wallet")
+	pwd := util.ConstantPassword(password)
+	// NOTE: No easy way to get plain passwords in memory
+	password = ""
+	wltDir := universal.NewDirectory(universal.DefaultWalletDir(), walletM.altManager)
+	wlt, err := wltDir.CreateWallet(label, seed, pwd, scanN)
+	if err != nil {
+		logWalletManager.WithError(err).Error("Couldn't create universal wallet")
+		return 0
+	}
+	links, err := wlt.Links()
+	if err != nil {
+		logWalletManager.WithError(err).Error("Couldn't list linked wallets")
+		return 0
+	}
+	logWalletManager.Info("Created universal wallet")
+	walletM.updateWallets()
+	return len(links)
+}
+
 func (walletM *WalletManager) getNewSeed(entropy int) string {
 	logWalletManager.Info("Getting new seed")
 	seed, err := walletM.SeedGenerator.GenerateMnemonic(entropy)
@@ -1104,43 +1414,17 @@ func fromWalletToQWallet(wlt core.Wallet, isEncrypted, withoutBalance bool) *QWa
 	}
 
 	if withoutBalance {
-		qWallet.SetSky("N/A")
-		qWallet.SetCoinHours("N/A")
+		qWallet.setNotAvailable(wlt.GetCryptoAccount().ListAssets())
 		logWalletManager.Info("Passing over default wallet, without balance")
 		return qWallet
 	}
 
-	bl, err := wlt.GetCryptoAccount().GetBalance(sky.Sky)
-	if err != nil {
-		qWallet.SetSky("N/A")
-		qWallet.SetCoinHours("N/A")
-		logWalletManager.WithError(err).Error("Couldn't get Skycoin balance")
-		return qWallet
-	}
-
-	accuracy, err := util.AltcoinQuotient(params.SkycoinTicker)
-	if err != nil {
-		qWallet.SetSky("N/A")
-		qWallet.SetCoinHours("N/A")
-		logWalletManager.WithError(err).Error("Couldn't get Skycoin Altcoin quotient")
-		return qWallet
-	}
-
-	qWallet.SetSky(util.FormatCoins(bl, accuracy))
-
-	bl, err = wlt.GetCryptoAccount().GetBalance(sky.CoinHoursTicker)
-	if err != nil {
-		qWallet.SetCoinHours("N/A")
-		logWalletManager.WithError(err).Error("Couldn't get Coin Hours balance")
-		return qWallet
-	}
-	accuracy, err = util.AltcoinQuotient(params.CoinHoursTicker)
+	balances, err := util.AccountBalances(wlt.GetCryptoAccount())
 	if err != nil {
-		qWallet.SetCoinHours("N/A")
-		logWalletManager.WithError(err).Error("Couldn't get Coin Hours Altcoin quotient")
-		panic(nil)
+		qWallet.setNotAvailable(wlt.GetCryptoAccount().ListAssets())
+		logWalletManager.WithError(err).Error("Couldn't get wallet balance")
 		return qWallet
 	}
-	qWallet.SetCoinHours(util.FormatCoins(bl, accuracy))
+	qWallet.setBalances(balances)
 	return qWallet
 }
@@ -4,6 +4,8 @@ import (
 	hardware "github.com/fibercrypto/fibercryptowallet/src/contrib/skywallet"
 	fccore "github.com/fibercrypto/fibercryptowallet/src/core"
 	wlcore "github.com/fibercrypto/fibercryptowallet/src/main"
+	"github.com/fibercrypto/fibercryptowallet/src/models/assets"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
 	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
 	"github.com/fibercrypto/skywallet-go/src/integration/proxy"
 	skyWallet "github.com/fibercrypto/skywallet-go/src/skywallet"
@@ -56,6 +58,23 @@ type QWallet struct {
 	_ string `property:"fileName"`
 	_ bool   `property:"expand"`
 	_ bool   `property:"hasHardwareWallet"`
+
+	_ []*assets.QAsset `property:"balances"`
+}
+
+// setBalances binds the balance of every asset held in this wallet
+func (w *QWallet) setBalances(balances []util.AssetAmount) {
+	qAssets := assets.NewQAssetsFromAmounts(balances)
+	w.SetBalances(qAssets)
+	w.SetSky(assets.AmountAt(qAssets, 0))
+	w.SetCoinHours(assets.AmountAt(qAssets, 1))
+}
+
+// setNotAvailable flags unknown balances for assets represented by tickers
+func (w *QWallet) setNotAvailable(tickers []string) {
+	w.SetBalances(assets.NotAvailableAssets(tickers))
+	w.SetSky(assets.NotAvailable)
+	w.SetCoinHours(assets.NotAvailable)
 }
 
 func (walletModel *WalletModel) init() {
@@ -97,6 +116,7 @@ func (walletModel *WalletModel) init() {
 				if wi.wallet.EncryptionEnabled() == 1 {
 					encrypted = true
 				}
+				walletModel.Wallets()[wi.row].SetBalances(wi.wallet.Balances())
 				walletModel.editWallet(wi.row, wi.wallet.Name(), encrypted, wi.wallet.Sky(), wi.wallet.CoinHours())
 				walletModel.ConnectSniffHw(walletModel.sniffHw)
 			}
@@ -328,6 +348,7 @@ func (walletModel *WalletModel) removeWallet(row int) {
 
 func (walletModel *WalletModel) updateModel(wallets []*QWallet) {
 	for i, wlt := range wallets {
+		walletModel.Wallets()[i].SetBalances(wlt.Balances())
 		walletModel.editWallet(i, wlt.Name(), wlt.EncryptionEnabled() == 1, wlt.Sky(), wlt.CoinHours())
 	}
 }
@@ -93,37 +93,24 @@ Item {
 
         } // RowLayout
 
-        RowLayout {
-            TextField {
-                id: textFieldDestinationAmount
-                onTextChanged: sky = text
-                text: sky
-                selectByMouse: true
-                implicitWidth: 60
-                validator: DoubleValidator {
-                    notation: DoubleValidator.StandardNotation
+        Repeater {
+            model: amounts
+
+            RowLayout {
+                TextField {
+                    id: textFieldDestinationAmount
+                    onTextChanged: amount = text
+                    text: amount
+                    selectByMouse: true
+                    implicitWidth: 60
+                    validator: DoubleValidator {
+                        locale: Qt.locale().name
+                        notation: DoubleValidator.StandardNotation
+                    }
                 }
+                Label { text: subPageSendAdvanced.tickers[index] }
             }
-            Label { text: qsTr("SKY") }
-        }
-
-        RowLayout {
-            visible: !checkBoxAutomaticCoinHoursAllocation.checked
-
-            TextField {
-                id: textFieldCoinHoursAmount
-                onTextChanged: coinHours = text
-                text: coinHours
-                selectByMouse: true
-                implicitWidth: 60
-                validator: DoubleValidator {
-                    notation: DoubleValidator.StandardNotation
-                }
-            }
-            Label {
-                text: qsTr("Coin hours")
-            }
-        } // RowLayout
+        } // Repeater (amounts)
 
         ToolButton {
             id: toolButtonAddRemoveDestination
@@ -138,7 +125,7 @@ Item {
 
             onClicked: {
                 if (index === 0) {
-                    listModelDestinations.append( { "address": "", "sky": "0.0", "coinHours": "0.0" } )
+                    listModelDestinations.append( { "address": "", "amounts": subPageSendAdvanced.newDestinationAmounts() } )
                 } else {
                     listModelDestinations.remove(index)
                 }
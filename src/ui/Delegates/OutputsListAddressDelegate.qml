@@ -82,5 +82,5 @@ Item {
         }
     } // ListView
 
-    // Roles: outputID, addressSky, addressCoinHours
+    // Roles: outputID, addressSky, addressCoinHours, lockState
 }
@@ -3,10 +3,17 @@ import QtQuick.Controls 2.12
 import QtQuick.Controls.Material 2.12
 import QtQuick.Layouts 1.12
 
+// Resource imports
+// import "qrc:/ui/src/ui/Utils/amounts.js"
+import "../Utils/amounts.js" as Amounts // For quick UI development, switch back to resources when making a release
+
 Item {
     id: outputsListAddressOutputDelegate
 
-    implicitHeight: Math.max(textOutputID.height, (toolButtonCopy.height - toolButtonCopy.topPadding*2), labelAddressSky.height, labelAddressCoins.height)
+    // Lock state is read from the output itself so that it is refreshed as soon as it changes
+    readonly property var qoutput: ListView.view.model.outputs[index]
+
+    implicitHeight: Math.max(textOutputID.height, (toolButtonCopy.height - toolButtonCopy.topPadding*2), labelAmounts.height)
 
     RowLayout {
         id: rowLayoutRoot
@@ -66,19 +73,44 @@ Item {
             } // ToolButton
         } // RowLayout (output ID)
 
+        ToolButton {
+            id: toolButtonSplit
+            text: qsTr("Split")
+            Material.foreground: Material.accent
+
+            onClicked: {
+                splitOutput(walletOwner, outputID)
+            }
+        }
+
         Label {
-            id: labelAddressSky
-            text: addressSky // a role of the model
-            color: Material.accent
-            horizontalAlignment: Text.AlignRight
-            Layout.preferredWidth: internalLabelsWidth/2
+            id: labelLockState
+            visible: qoutput.lockState !== 0
+            text: qoutput.lockState === 1 ? qsTr("Frozen") : qsTr("Reserved")
+            color: Material.hintTextColor
+        }
+
+        ToolButton {
+            id: toolButtonLock
+            icon.source: "qrc:/images/resources/images/icons/lock" + (qoutput.lockState !== 0 ? "On" : "Off") + ".svg"
+            ToolTip.text: qoutput.lockState !== 0 ? qsTr("Unlock output") : qsTr("Freeze output, it will not be spent automatically")
+            ToolTip.visible: hovered // TODO: pressed when mobile?
+            ToolTip.delay: Qt.styleHints.mousePressAndHoldInterval
+
+            onClicked: {
+                var lock = qoutput.lockState !== 0 ? 0 : 1
+                if (walletManager.setOutputLock(walletOwner, outputID, lock)) {
+                    qoutput.lockState = lock
+                }
+            }
         }
 
         Label {
-            id: labelAddressCoins
-            text: addressCoinHours // a role of the model
+            id: labelAmounts
+            text: Amounts.summary(qoutput.assets)
+            color: Material.accent
             horizontalAlignment: Text.AlignRight
-            Layout.preferredWidth: internalLabelsWidth
+            Layout.preferredWidth: amountsLabelWidth
         }
     } // RowLayout (addresses)
 }
@@ -33,6 +33,32 @@ Item {
             animateDisplacement = true
             expanded = !expanded
         }
+
+        Row {
+            anchors.verticalCenter: parent.verticalCenter
+            anchors.right: parent.right
+            anchors.rightMargin: 10
+
+            ToolButton {
+                id: toolButtonProjectHours
+                text: qsTr("Project hours")
+                Material.foreground: Material.accent
+
+                onClicked: {
+                    projectOutputs(qaddresses.id)
+                }
+            }
+            ToolButton {
+                id: toolButtonConsolidate
+                text: qsTr("Consolidate")
+                icon.source: "qrc:/images/resources/images/icons/send.svg"
+                Material.foreground: Material.accent
+
+                onClicked: {
+                    consolidateOutputs(qaddresses.id)
+                }
+            }
+        }
     } // ItemDelegate
 
     ListView {
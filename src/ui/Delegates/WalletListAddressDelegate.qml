@@ -7,6 +7,7 @@ import WalletsManager 1.0
 // Resource imports
 // import "qrc:/ui/src/ui/"
 import "../" // For quick UI development, switch back to resources when making a release
+import "../Utils/amounts.js" as Amounts // For quick UI development, switch back to resources when making a release
 
 Item {
     id: root
@@ -185,13 +186,13 @@ Item {
         }
 
         Label {
-            id: labelAddressSky
+            id: labelAddressBalances
             visible:  !showOnlyAddresses
             color: Material.accent
             horizontalAlignment: Text.AlignRight
-            Layout.preferredWidth: internalLabelsWidth
+            Layout.preferredWidth: balancesLabelWidth
 
-            text: addressSky === qsTr("N/A") ? "" : addressSky // a role of the model
+            text: addressSky === qsTr("N/A") ? "" : Amounts.summary(root.ListView.view.model.addresses[index].assets)
 
             BusyIndicator {
                 anchors.verticalCenter: parent.verticalCenter
@@ -202,13 +203,5 @@ Item {
                 implicitHeight: parent.height + 10
             }
         }
-
-        Label {
-            id: labelAddressCoins
-            visible:  !showOnlyAddresses
-            text: addressCoinHours // a role of the model
-            horizontalAlignment: Text.AlignRight
-            Layout.preferredWidth: internalLabelsWidth
-        }
     } // RowLayout (addresses)
 }
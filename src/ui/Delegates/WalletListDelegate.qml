@@ -8,6 +8,7 @@ import WalletsManager 1.0
 // Resource imports
 // import "qrc:/ui/src/ui/Dialogs"
 import "../Dialogs/" // For quick UI development, switch back to resources when making a release
+import "../Utils/amounts.js" as Amounts // For quick UI development, switch back to resources when making a release
 
 Item {
     id: root
@@ -80,11 +81,11 @@ Item {
                 }
 
                 Label {
-                    id: labelSky
-                    text: sky === qsTr("N/A") ? "" : sky // a role of the model
+                    id: labelBalances
+                    text: sky === qsTr("N/A") ? "" : Amounts.summary(root.ListView.view.model.wallets[index].balances)
                     color: Material.accent
                     horizontalAlignment: Text.AlignRight
-                    Layout.preferredWidth: internalLabelsWidth
+                    Layout.preferredWidth: balancesLabelWidth
                     BusyIndicator {
                         anchors.verticalCenter: parent.verticalCenter
                         anchors.right: parent.right
@@ -94,13 +95,6 @@ Item {
                         implicitHeight: parent.height + 10
                     }
                 }
-
-                Label {
-                    id: labelCoins
-                    text: coinHours // a role of the model
-                    horizontalAlignment: Text.AlignRight
-                    Layout.preferredWidth: internalLabelsWidth
-                }
             } // RowLayout
 
             onClicked: {
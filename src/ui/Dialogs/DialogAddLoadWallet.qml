@@ -17,6 +17,10 @@ Dialog {
     property alias name: createLoadWallet.name
     property alias seed: createLoadWallet.seed
     property alias encryptionEnabled: checkBoxEncryptWallet.checked
+    property alias universalWallet: checkBoxUniversalWallet.checked
+
+    // Emitted with the number of coin wallets linked to the seed, zero on error
+    signal universalWalletCreated(int linkedCount)
 
     Component.onCompleted: {
         standardButton(Dialog.Ok).text = mode === CreateLoadWallet.Create ? qsTr("Create") : qsTr("Load")
@@ -27,6 +31,14 @@ Dialog {
     }
     onAccepted:{
         var scanA = 0
+        if (universalWallet) {
+            if (mode === CreateLoadWallet.Load){
+                scanA = 10
+            }
+            universalWalletCreated(walletManager.createUniversalWallet(seed, name, textFieldPassword.text, scanA))
+            textFieldPassword.text = ""
+            return
+        }
         if(encryptionEnabled){
             if (mode === CreateLoadWallet.Load){
                 scanA = 10
@@ -142,7 +154,21 @@ Dialog {
                 }
             } // ColumnLayoutSeedWarning
 
+            CheckBox {
+                id: checkBoxUniversalWallet
+                text: qsTr("Universal wallet (one seed for every supported coin)")
+
+                onCheckedChanged: {
+                    // Universal wallets always keep their seed encrypted
+                    if (checked) {
+                        checkBoxEncryptWallet.checked = true
+                    }
+                    updateAcceptButtonStatus()
+                }
+            }
+
             RowLayout{
+                enabled: !checkBoxUniversalWallet.checked
                 Label{
                     text:qsTr("Wallet Type: ")
                 }
@@ -172,6 +198,7 @@ Dialog {
                 id: checkBoxEncryptWallet
                 text: qsTr("Encrypt wallet")
                 checked: true
+                enabled: !checkBoxUniversalWallet.checked
 
                 onCheckedChanged: {
                     updateAcceptButtonStatus()
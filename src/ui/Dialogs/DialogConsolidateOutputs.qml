@@ -0,0 +1,52 @@
+import QtQuick 2.12
+import QtQuick.Controls 2.12
+import QtQuick.Controls.Material 2.12
+import QtQuick.Layouts 1.12
+
+Dialog {
+    id: dialogConsolidateOutputs
+
+    property alias dust: textFieldDust.text
+    property alias destinationAddress: textFieldDestinationAddress.text
+
+    title: qsTr("Consolidate outputs")
+    standardButtons: Dialog.Ok | Dialog.Cancel
+
+    onAboutToShow: {
+        textFieldDust.forceActiveFocus()
+    }
+
+    ColumnLayout {
+        id: columnLayoutRoot
+        anchors.fill: parent
+
+        Label {
+            text: qsTr("Dust threshold")
+            font.bold: true
+            Layout.fillWidth: true
+        }
+        TextField {
+            id: textFieldDust
+            placeholderText: qsTr("Merge outputs holding less coins, every output if empty")
+            selectByMouse: true
+            Layout.fillWidth: true
+            validator: DoubleValidator {
+                locale: Qt.locale().name
+                notation: DoubleValidator.StandardNotation
+            }
+        }
+
+        Label {
+            text: qsTr("Destination address")
+            font.bold: true
+            Layout.fillWidth: true
+        }
+        TextField {
+            id: textFieldDestinationAddress
+            placeholderText: qsTr("Address receiving the merged coins")
+            selectByMouse: true
+            font.family: "Code New Roman"
+            Layout.fillWidth: true
+        }
+    } // ColumnLayout (root)
+}
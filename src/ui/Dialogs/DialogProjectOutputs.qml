@@ -0,0 +1,72 @@
+import QtQuick 2.12
+import QtQuick.Controls 2.12
+import QtQuick.Controls.Material 2.12
+import QtQuick.Layouts 1.12
+
+// Resource imports
+// import "qrc:/ui/src/ui/Utils/amounts.js"
+import "../Utils/amounts.js" as Amounts // For quick UI development, switch back to resources when making a release
+
+// Outputs of a wallet with the coin hours they would hold some hours from now
+Dialog {
+    id: dialogProjectOutputs
+
+    property string walletId
+
+    function updateProjection() {
+        var at = spinBoxHours.value > 0 ? String(Math.floor(Date.now() / 1000) + spinBoxHours.value * 3600) : ""
+        listViewProjectedOutputs.model = walletManager.projectOutputs(walletId, at)
+    }
+
+    title: qsTr("Projected coin hours")
+    standardButtons: Dialog.Close
+
+    onAboutToShow: {
+        updateProjection()
+    }
+
+    ColumnLayout {
+        id: columnLayoutRoot
+        anchors.fill: parent
+
+        RowLayout {
+            Label { text: qsTr("Hours from now") }
+            SpinBox {
+                id: spinBoxHours
+                from: 0
+                to: 8760
+                editable: true
+
+                onValueModified: {
+                    updateProjection()
+                }
+            }
+        }
+
+        ListView {
+            id: listViewProjectedOutputs
+
+            Layout.fillWidth: true
+            Layout.fillHeight: true
+            clip: true
+
+            delegate: RowLayout {
+                width: listViewProjectedOutputs.width
+
+                Label {
+                    text: modelData.outputID
+                    font.family: "Code New Roman"
+                    elide: Text.ElideMiddle
+                    Layout.fillWidth: true
+                }
+                Label {
+                    text: Amounts.summary(modelData.assets)
+                    color: Material.accent
+                    horizontalAlignment: Text.AlignRight
+                }
+            }
+
+            ScrollIndicator.vertical: ScrollIndicator {}
+        } // ListView
+    } // ColumnLayout (root)
+}
@@ -16,9 +16,8 @@ Dialog {
 
     property alias previewDate: transactionDetails.date                    
     property alias previewType: transactionDetails.type                  
-    property alias previewAmount: transactionDetails.amount              
-    property alias previewHoursReceived: transactionDetails.hoursReceived
-    property alias previewHoursBurned: transactionDetails.hoursBurned    
+    property alias previewAmounts: transactionDetails.amounts
+    property alias previewFees: transactionDetails.fees
     property alias previewtransactionID: transactionDetails.transactionID
     property alias inputs : transactionDetails.modelInputs
     property alias outputs : transactionDetails.modelOutputs
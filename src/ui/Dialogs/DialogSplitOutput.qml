@@ -0,0 +1,58 @@
+import QtQuick 2.12
+import QtQuick.Controls 2.12
+import QtQuick.Controls.Material 2.12
+import QtQuick.Layouts 1.12
+
+Dialog {
+    id: dialogSplitOutput
+
+    property string outputID
+
+    // Non empty lines of the addresses text area
+    function getDestinationAddresses() {
+        var addresses = []
+        var lines = textAreaAddresses.text.split("\n")
+        for (var i = 0; i < lines.length; i++) {
+            var address = lines[i].trim()
+            if (address !== "") {
+                addresses.push(address)
+            }
+        }
+        return addresses
+    }
+
+    title: qsTr("Split output")
+    standardButtons: Dialog.Ok | Dialog.Cancel
+
+    onAboutToShow: {
+        textAreaAddresses.clear()
+        textAreaAddresses.forceActiveFocus()
+    }
+
+    ColumnLayout {
+        id: columnLayoutRoot
+        anchors.fill: parent
+
+        Label {
+            text: qsTr("Coins of output %1 are sent in equal amounts to each address").arg(outputID)
+            wrapMode: Text.WrapAnywhere
+            Layout.fillWidth: true
+        }
+
+        Label {
+            text: qsTr("Destination addresses (one per line)")
+            font.bold: true
+            Layout.fillWidth: true
+        }
+        ScrollView {
+            Layout.fillWidth: true
+            Layout.fillHeight: true
+
+            TextArea {
+                id: textAreaAddresses
+                selectByMouse: true
+                font.family: "Code New Roman"
+            }
+        }
+    } // ColumnLayout (root)
+}
@@ -0,0 +1,106 @@
+import QtQuick 2.12
+import QtQuick.Controls 2.12
+import QtQuick.Controls.Material 2.12
+import QtQuick.Layouts 1.12
+
+// Resource imports
+// import "qrc:/ui/src/ui/Utils/amounts.js"
+import "../Utils/amounts.js" as Amounts // For quick UI development, switch back to resources when making a release
+
+// Review of a transfer split in several transactions (e.g. walletManager.transferPlan)
+Dialog {
+    id: dialogTransferPlan
+
+    property string walletId
+    property var plan: []
+    // Outputs the wallet would hold once the plan is confirmed (e.g. walletManager.previewOutputs)
+    property var resultingOutputs: []
+    property alias headerMessage: labelHeaderMessage.text
+
+    title: qsTr("Confirm transactions")
+    standardButtons: Dialog.Ok | Dialog.Cancel
+
+    ColumnLayout {
+        id: columnLayoutRoot
+        anchors.fill: parent
+        spacing: 10
+
+        Label {
+            id: labelHeaderMessage
+            text: qsTr("The transfer exceeds the limits of a single transaction. The following %1 transactions will be signed together and broadcast in order.").arg(plan.length)
+            wrapMode: Text.WordWrap
+            Layout.fillWidth: true
+        }
+
+        ListView {
+            id: listViewPlan
+
+            Layout.fillWidth: true
+            Layout.fillHeight: true
+            clip: true
+            model: plan
+
+            delegate: ItemDelegate {
+                width: listViewPlan.width
+
+                contentItem: ColumnLayout {
+                    Label {
+                        text: (index + 1) + ". " + Amounts.summary(modelData.amounts)
+                        font.bold: true
+                        Layout.fillWidth: true
+                    }
+                    Label {
+                        text: qsTr("Fee:") + ' ' + Amounts.summary(modelData.fees)
+                        color: Material.hintTextColor
+                        Layout.fillWidth: true
+                    }
+                    Label {
+                        text: modelData.transactionId
+                        font.family: "Code New Roman"
+                        font.pointSize: Qt.application.font.pointSize * 0.9
+                        color: Material.hintTextColor
+                        elide: Text.ElideMiddle
+                        Layout.fillWidth: true
+                    }
+                }
+            }
+
+            ScrollIndicator.vertical: ScrollIndicator {}
+        } // ListView
+
+        Label {
+            visible: resultingOutputs.length > 0
+            text: qsTr("Outputs once confirmed")
+            font.bold: true
+            Layout.fillWidth: true
+        }
+
+        ListView {
+            id: listViewResultingOutputs
+
+            visible: resultingOutputs.length > 0
+            Layout.fillWidth: true
+            Layout.fillHeight: true
+            clip: true
+            model: resultingOutputs
+
+            delegate: RowLayout {
+                width: listViewResultingOutputs.width
+
+                Label {
+                    text: modelData.outputID
+                    font.family: "Code New Roman"
+                    elide: Text.ElideMiddle
+                    Layout.fillWidth: true
+                }
+                Label {
+                    text: Amounts.summary(modelData.assets)
+                    color: Material.accent
+                    horizontalAlignment: Text.AlignRight
+                }
+            }
+
+            ScrollIndicator.vertical: ScrollIndicator {}
+        } // ListView (resulting outputs)
+    } // ColumnLayout (root)
+}
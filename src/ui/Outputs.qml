@@ -3,10 +3,13 @@ import QtQuick.Controls 2.12
 import QtQuick.Controls.Material 2.12
 import QtQuick.Layouts 1.12
 import OutputsModels 1.0
+import Utils 1.0
 
 // Resource imports
 // import "qrc:/ui/src/ui/Delegates"
+// import "qrc:/ui/src/ui/Dialogs"
 import "Delegates/" // For quick UI development, switch back to resources when making a release
+import "Dialogs/" // For quick UI development, switch back to resources when making a release
 
 Page {
     id: outputs
@@ -15,6 +18,37 @@ Page {
     readonly property real listOutputsRightMargin: 50
     readonly property real listOutputsSpacing: 20
     readonly property real internalLabelsWidth: 60
+    readonly property real amountsLabelWidth: 3*internalLabelsWidth
+    property string coinControlWalletId
+
+    function projectOutputs(walletId) {
+        dialogProjectOutputs.walletId = walletId
+        dialogProjectOutputs.open()
+    }
+
+    function consolidateOutputs(walletId) {
+        coinControlWalletId = walletId
+        dialogConsolidateOutputs.open()
+    }
+
+    function splitOutput(walletId, outputID) {
+        coinControlWalletId = walletId
+        dialogSplitOutput.outputID = outputID
+        dialogSplitOutput.open()
+    }
+
+    // Review transactions built by the wallet along with the outputs they would leave
+    function reviewTransferPlan(count) {
+        if (count === 0) {
+            msgDialogCoinControlError.open()
+            return
+        }
+        dialogTransferPlan.walletId = coinControlWalletId
+        dialogTransferPlan.plan = walletManager.transferPlan
+        dialogTransferPlan.resultingOutputs = walletManager.previewOutputs(coinControlWalletId)
+        dialogTransferPlan.headerMessage = qsTr("The following %1 transactions will be signed together and broadcast in order.").arg(count)
+        dialogTransferPlan.open()
+    }
 
     Frame {
         id: frame
@@ -40,17 +74,11 @@ Page {
                         Layout.fillWidth: true
                     }
                     Label {
-                        text: qsTr("Sky")
-                        font.pointSize: 9
-                        horizontalAlignment: Text.AlignRight
-                        Layout.preferredWidth: internalLabelsWidth/2
-                    }
-                    Label {
-                        text: qsTr("Coin hours")
+                        text: qsTr("Amounts")
                         font.pointSize: 9
                         horizontalAlignment: Text.AlignRight
                         Layout.rightMargin: listOutputsRightMargin
-                        Layout.preferredWidth: internalLabelsWidth
+                        Layout.preferredWidth: amountsLabelWidth
                     }
                 }
 
@@ -88,6 +116,92 @@ Page {
         id: modelWallets
     }
 
+    DialogProjectOutputs {
+        id: dialogProjectOutputs
+        anchors.centerIn: Overlay.overlay
+        width: applicationWindow.width > 640 - 40 ? 640 - 40 : applicationWindow.width - 40
+        height: applicationWindow.height > 540 - 40 ? 540 - 40 : applicationWindow.height - 40
+
+        modal: true
+        focus: true
+    }
+
+    DialogConsolidateOutputs {
+        id: dialogConsolidateOutputs
+        anchors.centerIn: Overlay.overlay
+        width: applicationWindow.width > 540 ? 540 - 40 : applicationWindow.width - 40
+
+        modal: true
+        focus: true
+        onAccepted: {
+            reviewTransferPlan(walletManager.consolidateOutputs(coinControlWalletId, dust, destinationAddress, [], []))
+        }
+    }
+
+    DialogSplitOutput {
+        id: dialogSplitOutput
+        anchors.centerIn: Overlay.overlay
+        width: applicationWindow.width > 540 ? 540 - 40 : applicationWindow.width - 40
+        height: applicationWindow.height > 400 ? 400 - 40 : applicationWindow.height - 40
+
+        modal: true
+        focus: true
+        onAccepted: {
+            reviewTransferPlan(walletManager.splitOutput(coinControlWalletId, outputID, getDestinationAddresses(), [], []))
+        }
+    }
+
+    DialogTransferPlan {
+        id: dialogTransferPlan
+        anchors.centerIn: Overlay.overlay
+        width: applicationWindow.width > 640 - 40 ? 640 - 40 : applicationWindow.width - 40
+        height: applicationWindow.height > 540 - 40 ? 540 - 40 : applicationWindow.height - 40
+
+        modal: true
+        focus: true
+        onAccepted: {
+            walletManager.signAndBroadcastPlanAsync(walletId, "", bridgeForPassword)
+        }
+    }
+
+    MsgDialog {
+        id: msgDialogCoinControlError
+        anchors.centerIn: Overlay.overlay
+        width: applicationWindow.width > 440 ? 440 - 40 : applicationWindow.width - 40
+        height: applicationWindow.height > 280 ? 280 - 40 : applicationWindow.height - 40
+
+        title: qsTr("Transactions not created")
+        text: qsTr("The wallet could not build transactions for the selected outputs.")
+        imagePath: "qrc:/images/resources/images/icons/warning.svg"
+
+        modal: true
+        focus: visible
+    }
+
+    DialogGetPassword {
+        id: getPasswordDialog
+        anchors.centerIn: Overlay.overlay
+        width: applicationWindow.width > 540 ? 540 - 120 : applicationWindow.width - 40
+        height: applicationWindow.height > 570 ? 570 - 180 : applicationWindow.height - 40
+
+        focus: true
+        modal: true
+        onClosed: {
+            bridgeForPassword.setResult(getPasswordDialog.password)
+            bridgeForPassword.unlock()
+        }
+    }
+
+    QBridge {
+        id: bridgeForPassword
+
+        onGetPassword: {
+            getPasswordDialog.title = message
+            getPasswordDialog.clear()
+            getPasswordDialog.open()
+        }
+    }
+
     BusyIndicator {
         id: busyIndicator
 
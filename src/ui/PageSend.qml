@@ -44,15 +44,14 @@ Page {
                     //walletSelecteds = stackView.currentItem.advancedPage.getSelectedWallet()
                     var destinationSummary = stackView.currentItem.advancedPage.getDestinationsSummary()
                     var changeAddress = stackView.currentItem.advancedPage.getChangeAddress()
-                    var automaticCoinHours = stackView.currentItem.advancedPage.getAutomaticCoinHours()
-                    var burnFactor = stackView.currentItem.advancedPage.getBurnFactor()
+                    var txnOptions = stackView.currentItem.advancedPage.getTxnOptions()
                     if (outs[0].length > 0){
-                        txn = walletManager.sendFromOutputs(outs[1], outs[0], destinationSummary[0], destinationSummary[1], destinationSummary[2], changeAddress, automaticCoinHours, burnFactor)
+                        txn = walletManager.sendAssetsFromOutputs(outs[1], outs[0], destinationSummary[0], destinationSummary[1], destinationSummary[2], changeAddress, txnOptions[0], txnOptions[1])
                     } else {
                         if (addrs[0].length == 0){
                             addrs = stackView.currentItem.advancedPage.getAllAddressesWithWallets()                            
                         }
-                        txn = walletManager.sendFromAddresses(addrs[1], addrs[0], destinationSummary[0], destinationSummary[1], destinationSummary[2], changeAddress, automaticCoinHours, burnFactor)
+                        txn = walletManager.sendAssetsFromAddresses(addrs[1], addrs[0], destinationSummary[0], destinationSummary[1], destinationSummary[2], changeAddress, txnOptions[0], txnOptions[1])
                     } 
                     
                     isEncrypted = stackView.currentItem.advancedPage.walletIsEncrypted()
@@ -66,12 +65,22 @@ Page {
                     addrs[1].push(walletSelected)
                     txn = walletManager.sendTo(walletSelected, stackView.currentItem.simplePage.getDestinationAddress(), stackView.currentItem.simplePage.getAmount())
                 }
+                if (!txn) {
+                    if (advancedMode && walletManager.transferPlan.length > 0) {
+                        // Transfer was split in several transactions, review them as a batch
+                        dialogTransferPlan.walletId = addrs[1][0]
+                        dialogTransferPlan.plan = walletManager.transferPlan
+                        dialogTransferPlan.open()
+                    } else {
+                        msgDialogTxnError.open()
+                    }
+                    return
+                }
                 dialogSendTransaction.showPasswordField =  false//isEncrypted// get if the current wallet is encrypted
                 //dialogSendTransaction.previewDate = "2019-02-26 15:27"               
                 dialogSendTransaction.previewType = TransactionDetails.Type.Send
-                dialogSendTransaction.previewAmount = txn.amount
-                dialogSendTransaction.previewHoursReceived = txn.hoursTraspassed
-                dialogSendTransaction.previewHoursBurned = txn.hoursBurned
+                dialogSendTransaction.previewAmounts = txn.amounts
+                dialogSendTransaction.previewFees = txn.fees
                 dialogSendTransaction.previewtransactionID = txn.transactionId
                 dialogSendTransaction.inputs = txn.inputs
                 dialogSendTransaction.outputs = txn.outputs
@@ -182,11 +191,38 @@ Page {
         modal: true
         focus: true
 		onAccepted: {
-            signerSelected = stackView.currentItem.simplePage.getSignerSelected()
+            signerSelected = advancedMode ? "" : stackView.currentItem.simplePage.getSignerSelected()
             walletManager.signAndBroadcastTxnAsync(walletsAddresses[1], walletsAddresses[0],signerSelected, bridgeForPassword, [], txn)
         }
     }
 
+    DialogTransferPlan {
+        id: dialogTransferPlan
+        anchors.centerIn: Overlay.overlay
+        width: applicationWindow.width > 640 - 40 ? 640 - 40 : applicationWindow.width - 40
+        height: applicationWindow.height > 480 - 40 ? 480 - 40 : applicationWindow.height - 40
+
+        modal: true
+        focus: true
+        onAccepted: {
+            walletManager.signAndBroadcastPlanAsync(walletId, "", bridgeForPassword)
+        }
+    }
+
+    MsgDialog {
+        id: msgDialogTxnError
+        anchors.centerIn: Overlay.overlay
+        width: applicationWindow.width > 440 ? 440 - 40 : applicationWindow.width - 40
+        height: applicationWindow.height > 280 ? 280 - 40 : applicationWindow.height - 40
+
+        title: qsTr("Transaction not created")
+        text: qsTr("The transaction could not be created. Check the selected sources, destinations and amounts.")
+        imagePath: "qrc:/images/resources/images/icons/warning.svg"
+
+        modal: true
+        focus: visible
+    }
+
     DialogGetPassword{
         id: getPasswordDialog
         anchors.centerIn: Overlay.overlay
@@ -18,6 +18,7 @@ Page {
     readonly property real listWalletRightMargin: 50
     readonly property real listWalletSpacing: 20
     readonly property real internalLabelsWidth: 70
+    readonly property real balancesLabelWidth: 3*internalLabelsWidth
 
     header: ColumnLayout {
 
@@ -32,17 +33,11 @@ Page {
                 Layout.fillWidth: true
             }
             Label {
-                text: qsTr("SKY")
-                font.pointSize: 9
-                horizontalAlignment: Text.AlignRight
-                Layout.preferredWidth: internalLabelsWidth
-            }
-            Label {
-                text: qsTr("Coin hours")
+                text: qsTr("Balance")
                 font.pointSize: 9
                 horizontalAlignment: Text.AlignRight
                 Layout.rightMargin: listWalletRightMargin
-                Layout.preferredWidth: internalLabelsWidth
+                Layout.preferredWidth: balancesLabelWidth
             }
         } // RowLayout
 
@@ -154,6 +149,28 @@ Page {
 
         width: applicationWindow.width > 540 ? 540 - 40 : applicationWindow.width - 40
         height: applicationWindow.height > 640 ? 640 - 40 : applicationWindow.height - 40
+
+        onUniversalWalletCreated: {
+            if (linkedCount > 0) {
+                walletModel.loadModel(walletManager.getWallets())
+                msgDialogUniversalWallet.text = qsTr("The seed was linked to %1 coin wallets.").arg(linkedCount)
+            } else {
+                msgDialogUniversalWallet.text = qsTr("The universal wallet could not be created.")
+            }
+            msgDialogUniversalWallet.open()
+        }
+    }
+
+    MsgDialog {
+        id: msgDialogUniversalWallet
+        anchors.centerIn: Overlay.overlay
+        width: applicationWindow.width > 440 ? 440 - 40 : applicationWindow.width - 40
+        height: applicationWindow.height > 280 ? 280 - 40 : applicationWindow.height - 40
+
+        title: qsTr("Universal wallet")
+
+        modal: true
+        focus: visible
     }
 
 
@@ -201,15 +201,28 @@ Page {
 
                 title: qsTr("Network settings")
 
-                TextField {
-                    id: textFieldNodeUrl
-
+                ColumnLayout {
                     anchors.fill: parent
-                    selectByMouse: true
-                    placeholderText: qsTr("Node URL")
 
-                    onTextChanged: {
-                        updateFooterButtonsStatus()
+                    TextField {
+                        id: textFieldNodeUrl
+
+                        Layout.fillWidth: true
+                        selectByMouse: true
+                        placeholderText: qsTr("Node URL")
+
+                        onTextChanged: {
+                            updateFooterButtonsStatus()
+                        }
+                    }
+
+                    // Node settings that could not be applied, such as unreadable credentials
+                    Label {
+                        Layout.fillWidth: true
+                        visible: text !== ""
+                        text: walletManager.settingsError
+                        color: Material.color(Material.Red)
+                        wrapMode: Text.Wrap
                     }
                 }
             } // GroupBox (network settings)
@@ -12,39 +12,84 @@ import OutputsModels 1.0
 import "Delegates" // For quick UI development, switch back to resources when making a release
 import "Dialogs" // For quick UI development, switch back to resources when making a release
 import "Controls" // For quick UI development, switch back to resources when making a release
+import "Utils/amounts.js" as Amounts // For quick UI development, switch back to resources when making a release
 
 Page {
     id: subPageSendAdvanced
 
-    property int upperCoinBound: 0
-    property int upperAltCointBound: 0
-    property int minFeeAmount: 0
+    // Assets and transaction options of the first selected wallet
+    property var tickers: []
+    property var txnOptions: []
+    // Amount of each asset that can be sent with the current selection
+    property var availableAmounts: []
 
     function updateInfo() {
 		subPageSendAdvanced.updateOutputs()
-	   	upperCoinBound = 0;
-	   	upperAltCointBound = 0;
-	   	minFeeAmount = 0;
-		var valCH = 0;
+		var selected = []
 		if (comboBoxWalletsUnspentOutputsSendFrom.enabled) {
        		for (var i = 0; i < comboBoxWalletsUnspentOutputsSendFrom.checkedElements.length; i++) {
-       			upperCoinBound += parseFloat(comboBoxWalletsUnspentOutputsSendFrom.model.outputs[comboBoxWalletsUnspentOutputsSendFrom.checkedElements[i]].addressSky, 10)
-				var s = comboBoxWalletsUnspentOutputsSendFrom.model.outputs[comboBoxWalletsUnspentOutputsSendFrom.checkedElements[i]].addressCoinHours
-       		    valCH += parseInt(comboBoxWalletsUnspentOutputsSendFrom.model.outputs[comboBoxWalletsUnspentOutputsSendFrom.checkedElements[i]].addressCoinHours.replace('\,',''), 10)
+       			selected.push(comboBoxWalletsUnspentOutputsSendFrom.model.outputs[comboBoxWalletsUnspentOutputsSendFrom.checkedElements[i]].assets)
        		}
 		} else if (comboBoxWalletsAddressesSendFrom.enabled) {
     	   		for (var i = 0; i < comboBoxWalletsAddressesSendFrom.checkedElements.length; i++) {
-    	   			upperCoinBound += parseFloat(comboBoxWalletsAddressesSendFrom.model.addresses[comboBoxWalletsAddressesSendFrom.checkedElements[i]].addressSky, 10)
-    	   		    valCH += parseInt(comboBoxWalletsAddressesSendFrom.model.addresses[comboBoxWalletsAddressesSendFrom.checkedElements[i]].addressCoinHours.replace('\,',''), 10)
+    	   			selected.push(comboBoxWalletsAddressesSendFrom.model.addresses[comboBoxWalletsAddressesSendFrom.checkedElements[i]].assets)
     	   		}
         } else {
             for(var i = 0; i < comboBoxWalletsAddressesSendFrom.model.addresses.length; i++) {
-                upperCoinBound += parseFloat(comboBoxWalletsAddressesSendFrom.model.addresses[i].addressSky, 10)
-                valCH += parseInt(comboBoxWalletsAddressesSendFrom.model.addresses[i].addressCoinHours.replace('\,',''), 10)
+                selected.push(comboBoxWalletsAddressesSendFrom.model.addresses[i].assets)
             } 
         }
-		upperAltCointBound = valCH * 9/10
-		minFeeAmount = valCH/10
+		var totals = []
+		for (var i = 0; i < tickers.length; i++) {
+			totals.push(0)
+		}
+		for (var i = 0; i < selected.length; i++) {
+			for (var j = 0; j < selected[i].length; j++) {
+				var pos = tickers.indexOf(selected[i][j].ticker)
+				if (pos >= 0) {
+					totals[pos] += Amounts.parse(selected[i][j].amount)
+				}
+			}
+		}
+		availableAmounts = totals
+    }
+
+    // Load assets and transaction options declared by the plugin of the selected wallets
+    function updateWalletSelection() {
+        var indexs = comboBoxWalletsSendFrom.getCheckedDelegates()
+        if (indexs.length === 0) {
+            tickers = []
+            txnOptions = []
+        } else {
+            var wallet = comboBoxWalletsSendFrom.model.wallets[indexs[0]]
+            tickers = Amounts.tickers(wallet.balances)
+            txnOptions = walletManager.getTxnOptions(wallet.fileName)
+        }
+        for (var i = 0; i < listModelDestinations.count; i++) {
+            var amounts = listModelDestinations.get(i).amounts
+            while (amounts.count < tickers.length) {
+                amounts.append({ "amount": "0.0" })
+            }
+            while (amounts.count > tickers.length) {
+                amounts.remove(amounts.count - 1)
+            }
+        }
+    }
+
+    function newDestinationAmounts() {
+        var amounts = []
+        for (var i = 0; i < tickers.length; i++) {
+            amounts.push({ "amount": "0.0" })
+        }
+        return amounts
+    }
+
+    function getAvailableAmountsText() {
+        var parts = []
+        for (var i = 0; i < tickers.length; i++) {
+            parts.push("<b>" + Amounts.format(availableAmounts.length > i ? availableAmounts[i] : 0, 6) + " " + tickers[i] + "</b>")
+        }
+        return parts.join(", ")
     }
 
     function getSelectedAddressesWithWallets() {
@@ -98,25 +143,45 @@ Page {
 
     function getDestinationsSummary() {
         var addrs = []
-        var skyAmounts = []
-        var coinHoursAmount = []
+        var amountsTo = []
         for (var i = 0; i < listModelDestinations.count; i++) {
-            addrs.push(listModelDestinations.get(i).address)
-            skyAmounts.push(listModelDestinations.get(i).sky)
-            coinHoursAmount.push(listModelDestinations.get(i).coinHours)
+            var destination = listModelDestinations.get(i)
+            addrs.push(destination.address)
+            for (var j = 0; j < tickers.length; j++) {
+                amountsTo.push(j < destination.amounts.count ? destination.amounts.get(j).amount : "")
+            }
         }
-        return [addrs, skyAmounts, coinHoursAmount]
+        return [addrs, tickers, amountsTo]
     }
 
     function getChangeAddress() {
         return textFieldCustomChangeAddress.text
     }
 
-    function getAutomaticCoinHours() {
-        return checkBoxAutomaticCoinHoursAllocation.checked
+    function getTxnOptions() {
+        var keys = []
+        var values = []
+        for (var i = 0; i < repeaterTxnOptions.count; i++) {
+            keys.push(repeaterTxnOptions.itemAt(i).key)
+            values.push(repeaterTxnOptions.itemAt(i).value)
+        }
+        return [keys, values]
     }
-    function getBurnFactor() {
-        return sliderCoinHoursShareFactor.value
+
+    // Fee and amounts of the transfer as it would be created some hours from now
+    function previewTransfer() {
+        var addrs = getSelectedAddressesWithWallets()
+        if (addrs[0].length === 0) {
+            addrs = getAllAddressesWithWallets()
+        }
+        var txn = null
+        if (addrs[1].length > 0) {
+            var destinations = getDestinationsSummary()
+            var options = getTxnOptions()
+            var at = spinBoxPreviewHours.value > 0 ? String(Math.floor(Date.now() / 1000) + spinBoxPreviewHours.value * 3600) : ""
+            txn = walletManager.previewTransfer(addrs[1][0], addrs[0], destinations[0], destinations[1], destinations[2], getChangeAddress(), at, options[0], options[1])
+        }
+        labelPreviewTransfer.text = txn ? qsTr("Send %1, fee %2").arg(Amounts.summary(txn.amounts)).arg(Amounts.summary(txn.fees)) : qsTr("The transfer can not be previewed with the current selection")
     }
 
     function getAllAddressesWithWallets() {
@@ -238,12 +303,13 @@ Page {
                                 listAddresses.removeAddressesFromWallet(comboBoxWalletsSendFrom.model.wallets[index].fileName)
                                 listOutputs.removeOutputsFromWallet(comboBoxWalletsSendFrom.model.wallets[index].fileName)
                             }
+                            subPageSendAdvanced.updateWalletSelection()
 							subPageSendAdvanced.updateInfo();
                             comboBoxWalletsSendFrom.numberOfCheckedElements = comboBoxWalletsSendFrom.checkedElements.length
                         }
 
                         width: parent.width
-                        text: comboBoxWalletsSendFrom.textRole ? (Array.isArray(comboBoxWalletsSendFrom.model) ? modelData[comboBoxWalletsSendFrom.textRole] + " - " + modelData["sky"] + " SKY (" + modelData["coinHours"] + " CoinHours)"  : model[comboBoxWalletsSendFrom.textRole] + " - " + model["sky"] + " SKY (" + model["coinHours"] + " CoinHours)") : " --- " + modelData
+                        text: comboBoxWalletsSendFrom.textRole ? (Array.isArray(comboBoxWalletsSendFrom.model) ? modelData[comboBoxWalletsSendFrom.textRole] + " - " + Amounts.summary(modelData.balances) : model[comboBoxWalletsSendFrom.textRole] + " - " + Amounts.summary(comboBoxWalletsSendFrom.model.wallets[index].balances)) : " --- " + modelData
                         // Load the saved state when the delegate is recicled:
                         checked: comboBoxWalletsSendFrom.checkedElements.indexOf(index) >= 0
                         hoverEnabled: comboBoxWalletsSendFrom.hoverEnabled
@@ -332,7 +398,7 @@ Page {
                         id: checkDelegate
 
                         width: parent.width
-                        text: comboBoxWalletsAddressesSendFrom.textRole ? (Array.isArray(comboBoxWalletsAddressesSendFrom.model) ? modelData["addressSky"] + " --- " +  modelData[comboBoxWalletsAddressesSendFrom.textRole]  + " - " + modelData["addressSky"] + " SKY (" + modelData["addressCoinHours"] + " CoinHours)" : model[comboBoxWalletsAddressesSendFrom.textRole] + " - " + model["addressSky"] + " SKY (" + model["addressCoinHours"] + " CoinHours)") : modelData
+                        text: comboBoxWalletsAddressesSendFrom.textRole ? (Array.isArray(comboBoxWalletsAddressesSendFrom.model) ? modelData[comboBoxWalletsAddressesSendFrom.textRole]  + " - " + Amounts.summary(modelData.assets) : model[comboBoxWalletsAddressesSendFrom.textRole] + " - " + Amounts.summary(comboBoxWalletsAddressesSendFrom.model.addresses[index].assets)) : modelData
                         font.family: "Code New Roman"
 
                         LayoutMirroring.enabled: true
@@ -466,7 +532,7 @@ Page {
                         }
 
                         width: parent.width
-                        text: comboBoxWalletsUnspentOutputsSendFrom.textRole ? (Array.isArray(comboBoxWalletsUnspentOutputsSendFrom.model) ? modelData[comboBoxWalletsUnspentOutputsSendFrom.textRole] + " - " + modelData["addressSky"] + " SKY (" + modelData["addressCoinHours"] + " CoinHours)" :model[comboBoxWalletsUnspentOutputsSendFrom.textRole] + " - " + model["addressSky"] + " SKY (" + model["addressCoinHours"] + " CoinHours)") : modelData
+                        text: comboBoxWalletsUnspentOutputsSendFrom.textRole ? (Array.isArray(comboBoxWalletsUnspentOutputsSendFrom.model) ? modelData[comboBoxWalletsUnspentOutputsSendFrom.textRole] + " - " + Amounts.summary(modelData.assets) : model[comboBoxWalletsUnspentOutputsSendFrom.textRole] + " - " + Amounts.summary(comboBoxWalletsUnspentOutputsSendFrom.model.outputs[index].assets)) : modelData
                         font.family: "Code New Roman"
                         // Load the saved state when the delegate is recicled:
                         checked: comboBoxWalletsUnspentOutputsSendFrom.checkedElements.indexOf(index) >= 0
@@ -493,7 +559,8 @@ Page {
         Label {
             Layout.fillWidth: true
             Layout.preferredHeight: 30
-            text: "With your current selection you can send up to <b>" + subPageSendAdvanced.upperCoinBound + " SKY</b> and <b>" + subPageSendAdvanced.upperAltCointBound + " Coin Hours</b> (at least <b>" + subPageSendAdvanced.minFeeAmount + " Coin Hours</b> must be used for the transaction fee)"
+            visible: subPageSendAdvanced.tickers.length > 0
+            text: qsTr("With your current selection you can send up to") + " " + subPageSendAdvanced.getAvailableAmountsText()
             wrapMode: Text.WordWrap
             horizontalAlignment: Text.AlignHCenter
         }
@@ -573,38 +640,80 @@ Page {
         } // ColumnLayout (custom change address)
 
         ColumnLayout {
-            id: columnLayoutAutomaticCoinHoursAllocation
+            id: columnLayoutTxnOptions
 
             Layout.fillWidth: true
-
             Layout.alignment: Qt.AlignTop
+            visible: repeaterTxnOptions.count > 0
+
+            Label { text: qsTr("Transaction options") }
+
+            // Options declared by the plugin of the selected wallets
+            Repeater {
+                id: repeaterTxnOptions
+                model: subPageSendAdvanced.txnOptions
 
-            CheckBox {
-                id: checkBoxAutomaticCoinHoursAllocation
-                text: qsTr("Automatic coin hours allocation")
-                checked: true
+                RowLayout {
+                    readonly property string key: modelData.key
+                    readonly property string value: modelData.choices.length > 0 ? comboBoxTxnOption.currentText : textFieldTxnOption.text
+
+                    Layout.fillWidth: true
+
+                    Label {
+                        text: modelData.caption
+                        Layout.preferredWidth: 200
+                    }
+                    ComboBox {
+                        id: comboBoxTxnOption
+                        visible: modelData.choices.length > 0
+                        model: modelData.choices
+                        currentIndex: Math.max(0, modelData.choices.indexOf(modelData.defaultValue))
+                        Layout.fillWidth: true
+                    }
+                    TextField {
+                        id: textFieldTxnOption
+                        visible: modelData.choices.length === 0
+                        text: modelData.defaultValue
+                        selectByMouse: true
+                        Layout.fillWidth: true
+                    }
+                }
             }
+        } // ColumnLayout (transaction options)
 
-            Slider {
-                id: sliderCoinHoursShareFactor
+        ColumnLayout {
+            id: columnLayoutPreviewTransfer
 
-                Layout.preferredWidth: parent.width < 500 ? 500 : parent.width
+            Layout.fillWidth: true
+            Layout.alignment: Qt.AlignTop
+            visible: subPageSendAdvanced.tickers.length > 0
 
-                opacity: checkBoxAutomaticCoinHoursAllocation.checked ? 1.0 : 0.0
-                Behavior on opacity { NumberAnimation {} }
-                from: 0.01
-                to: 1.00
-                value: 0.50
-                stepSize: 0.01
+            RowLayout {
+                Label { text: qsTr("Preview in") }
+                SpinBox {
+                    id: spinBoxPreviewHours
+                    from: 0
+                    to: 8760
+                    editable: true
+                }
+                Label { text: qsTr("hours") }
+                Button {
+                    text: qsTr("Preview")
+                    flat: true
+                    highlighted: true
 
-                ToolTip {
-                    parent: sliderCoinHoursShareFactor.handle
-                    visible: sliderCoinHoursShareFactor.pressed
-                    text: sliderCoinHoursShareFactor.value.toFixed(2)
-                    font.pointSize: Qt.application.font.pointSize * 1.2
+                    onClicked: {
+                        subPageSendAdvanced.previewTransfer()
+                    }
                 }
             }
-        } // ColumnLayout (automatic coin hours allocation)
+
+            Label {
+                id: labelPreviewTransfer
+                Layout.fillWidth: true
+                wrapMode: Text.WordWrap
+            }
+        } // ColumnLayout (preview transfer)
     } // ColumnLayout (root)
 
     DialogSelectAddressByWallet {
@@ -628,8 +737,11 @@ Page {
         id: modelAddressesByWallet
     }
 
+    // Roles: address, amounts (one `amount` for each ticker)
     ListModel {
         id: listModelDestinations
-        ListElement { address: ""; sky: "0.0"; coinHours: "0.0" }
+        Component.onCompleted: {
+            append({ "address": "", "amounts": newDestinationAmounts() })
+        }
     }
 }
@@ -10,6 +10,7 @@ import AddrsBookManager 1.0
 // import "qrc:/ui/src/ui/Controls"
 import "Dialogs" // For quick UI development, switch back to resources when making a release
 import "Controls" // For quick UI development, switch back to resources when making a release
+import "Utils/amounts.js" as Amounts // For quick UI development, switch back to resources when making a release
 
 Page {
     id: root
@@ -113,7 +114,7 @@ getAddressList()
                     Layout.fillWidth: true
                     id: comboBoxWalletsSendFrom
                     textRole: "name"
-                    displayText: comboBoxWalletsSendFrom.model.wallets[comboBoxWalletsSendFrom.currentIndex] && comboBoxWalletsSendFrom.model.wallets[comboBoxWalletsSendFrom.currentIndex].sky ? comboBoxWalletsSendFrom.model.wallets[comboBoxWalletsSendFrom.currentIndex].name + " - " + comboBoxWalletsSendFrom.model.wallets[comboBoxWalletsSendFrom.currentIndex].sky + " SKY (" + comboBoxWalletsSendFrom.model.wallets[comboBoxWalletsSendFrom.currentIndex].coinHours + " CoinHours)" : "Select a wallet"
+                    displayText: comboBoxWalletsSendFrom.model.wallets[comboBoxWalletsSendFrom.currentIndex] && comboBoxWalletsSendFrom.model.wallets[comboBoxWalletsSendFrom.currentIndex].sky ? comboBoxWalletsSendFrom.model.wallets[comboBoxWalletsSendFrom.currentIndex].name + " - " + Amounts.summary(comboBoxWalletsSendFrom.model.wallets[comboBoxWalletsSendFrom.currentIndex].balances) : "Select a wallet"
                     model: WalletModel {
                         Component.onCompleted: {
                             loadModel(walletManager.getWallets())
@@ -123,7 +124,7 @@ getAddressList()
                     // Taken from Qt 5.13.0 source code:
                     delegate: MenuItem {
                         width: parent.width
-                        text: comboBoxWalletsSendFrom.textRole ? (Array.isArray(comboBoxWalletsSendFrom.model) ? modelData[comboBoxWalletsSendFrom.textRole] : model[comboBoxWalletsSendFrom.textRole] + " - "+model["sky"] + " SKY (" + model["coinHours"] + " CoinHours)") : " --- " + modelData
+                        text: comboBoxWalletsSendFrom.textRole ? (Array.isArray(comboBoxWalletsSendFrom.model) ? modelData[comboBoxWalletsSendFrom.textRole] : model[comboBoxWalletsSendFrom.textRole] + " - " + Amounts.summary(comboBoxWalletsSendFrom.model.wallets[index].balances)) : " --- " + modelData
                         Material.foreground: comboBoxWalletsSendFrom.currentIndex === index ? parent.Material.accent : parent.Material.foreground
                         highlighted: comboBoxWalletsSendFrom.highlightedIndex === index
                         hoverEnabled: comboBoxWalletsSendFrom.hoverEnabled
@@ -245,6 +246,7 @@ getAddressList()
             Layout.fillWidth: true
             Layout.topMargin: -10
             validator: DoubleValidator {
+                locale: Qt.locale().name
                 notation: DoubleValidator.StandardNotation
             }
             onTextChanged:{
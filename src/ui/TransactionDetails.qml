@@ -7,6 +7,7 @@ import HistoryModels 1.0
 // Resource imports
 // import "qrc:/ui/src/ui/Delegates"
 import "Delegates/" // For quick UI development, switch back to resources when making a release
+import "Utils/amounts.js" as Amounts // For quick UI development, switch back to resources when making a release
 
 // Backend imports
 import HistoryModels 1.0
@@ -21,6 +22,9 @@ Item {
     property real amount: 0
     property string hoursReceived
     property string hoursBurned
+    // Per asset amounts and fees (lists of QAsset), when known they replace amount and hours
+    property var amounts: []
+    property var fees: []
     property string transactionID
     property QAddressList modelInputs
     property QAddressList modelOutputs
@@ -95,12 +99,12 @@ Item {
                     }
 
                     Label {
-                        text: qsTr("Hours:")
+                        text: root.amounts.length > 0 ? qsTr("Fee:") : qsTr("Hours:")
                         font.pointSize: Qt.application.font.pointSize * 0.9
                         font.bold: true
                     }
                     Label {
-                        text: root.hoursReceived + ' ' + qsTr("received") + ' | ' + hoursBurned + ' ' + qsTr("burned")
+                        text: root.amounts.length > 0 ? Amounts.summary(root.fees) : root.hoursReceived + ' ' + qsTr("received") + ' | ' + hoursBurned + ' ' + qsTr("burned")
                         font.pointSize: Qt.application.font.pointSize * 0.9
                     }
 
@@ -129,7 +133,7 @@ Item {
                     Layout.fillWidth: true
                 }
                 Label {
-                    text: (type === TransactionDetails.Type.Receive ? qsTr("Receive") : qsTr("Send")) + ' ' + amount + ' ' + qsTr("SKY")
+                    text: (type === TransactionDetails.Type.Receive ? qsTr("Receive") : qsTr("Send")) + ' ' + (root.amounts.length > 0 ? Amounts.summary(root.amounts) : amount + ' ' + qsTr("SKY"))
                     font.bold: true
                     font.pointSize: Qt.application.font.pointSize * 1.15
                     horizontalAlignment: Label.AlignHCenter
@@ -0,0 +1,44 @@
+.pragma library
+
+// Models parse and format amounts according to the default Qt locale, as Qt.locale() does
+
+// Number represented by amount text, 0 if not a number (e.g. N/A)
+function parse(text) {
+    try {
+        var value = Number.fromLocaleString(Qt.locale(), text)
+        return isNaN(value) ? 0 : value
+    } catch (e) {
+        return 0
+    }
+}
+
+// Amount text for value with at most decimals places, trailing zeros removed
+function format(value, decimals) {
+    var text = Number(value).toLocaleString(Qt.locale(), 'f', decimals)
+    var point = Qt.locale().decimalPoint
+    if (decimals > 0 && text.indexOf(point) >= 0) {
+        text = text.replace(/0+$/, "")
+        if (text.slice(-point.length) === point) {
+            text = text.slice(0, -point.length)
+        }
+    }
+    return text
+}
+
+// Tickers of a list of assets (e.g. wallet balances)
+function tickers(assets) {
+    var result = []
+    for (var i = 0; assets && i < assets.length; i++) {
+        result.push(assets[i].ticker)
+    }
+    return result
+}
+
+// Human readable list of asset amounts, e.g. "1.5 SKY, 20 SCH"
+function summary(assets) {
+    var parts = []
+    for (var i = 0; assets && i < assets.length; i++) {
+        parts.push(assets[i].amount + ' ' + assets[i].ticker)
+    }
+    return parts.join(', ')
+}
@@ -0,0 +1,162 @@
+package util
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/mocks"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAmount(t *testing.T) {
+	registerAssetsPlugin(new(mocks.AltcoinPlugin),
+		core.AltcoinMetadata{Name: "Amount coin", Ticker: "AMOUNTCOIN", Accuracy: 6},
+		core.AltcoinMetadata{Name: "Amount token", Ticker: "AMOUNTTOKEN", Accuracy: 18},
+	)
+
+	tests := []struct {
+		name   string
+		value  string
+		ticker string
+		err    error
+		want   uint64
+	}{
+		{name: "whole", value: "10", ticker: "AMOUNTCOIN", want: 10000000},
+		{name: "fraction", value: "0.000001", ticker: "AMOUNTCOIN", want: 1},
+		{name: "large", value: "18446744073709.551615", ticker: "AMOUNTCOIN", want: math.MaxUint64},
+		{name: "noFloatRounding", value: "9007199254.740993", ticker: "AMOUNTCOIN", want: 9007199254740993},
+		{name: "highAccuracy", value: "1.000000000000000001", ticker: "AMOUNTTOKEN", want: 1000000000000000001},
+		{name: "precision", value: "0.0000001", ticker: "AMOUNTCOIN", err: errors.ErrAmountPrecision},
+		{name: "overflow", value: "18446744073709.551616", ticker: "AMOUNTCOIN", err: errors.ErrAmountOverflow},
+		{name: "negative", value: "-1", ticker: "AMOUNTCOIN", err: errors.ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := ParseAmount(tt.value, tt.ticker)
+			if tt.err != nil {
+				require.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, core.NewAmount(tt.ticker, tt.want), amount)
+			formatted, err := FormatAmountLocale(amount, core.AmountLocale{DecimalSeparator: "."})
+			require.NoError(t, err)
+			back, err := ParseAmount(formatted, tt.ticker)
+			require.NoError(t, err)
+			require.Equal(t, amount, back)
+		})
+	}
+	_, err := ParseAmount("1", "AMOUNTUNK")
+	require.Error(t, err)
+	_, err = ParseAmount("coin", "AMOUNTCOIN")
+	require.Error(t, err)
+}
+
+func TestFormatAmountLocale(t *testing.T) {
+	registerAssetsPlugin(new(mocks.AltcoinPlugin),
+		core.AltcoinMetadata{Name: "Locale coin", Ticker: "LOCALECOIN", Accuracy: 3},
+	)
+	amount := core.NewAmount("LOCALECOIN", 1234567890)
+
+	formatted, err := FormatAmount(amount)
+	require.NoError(t, err)
+	require.Equal(t, "1234567.89", formatted)
+	formatted, err = FormatAmountLocale(amount, core.DefaultAmountLocale)
+	require.NoError(t, err)
+	require.Equal(t, "1,234,567.89", formatted)
+	formatted, err = FormatAmountLocale(amount, core.LookupAmountLocale("es_ES.UTF-8"))
+	require.NoError(t, err)
+	require.Equal(t, "1.234.567,89", formatted)
+	formatted, err = FormatAmountLocale(amount, core.LookupAmountLocale("fr-FR"))
+	require.NoError(t, err)
+	require.Equal(t, "1 234 567,89", formatted)
+	require.Equal(t, core.DefaultAmountLocale, core.LookupAmountLocale("xx"))
+	require.Equal(t, "1234567.89", amount.Format(3))
+
+	parsed, err := ParseAmountLocale("1.234.567,89", "LOCALECOIN", core.LookupAmountLocale("de"))
+	require.NoError(t, err)
+	require.Equal(t, amount, parsed)
+	parsed, err = ParseAmountLocale("1234567.89", "LOCALECOIN", core.DefaultAmountLocale)
+	require.NoError(t, err)
+	require.Equal(t, amount, parsed)
+	// Misplaced group separators are not taken for decimal separators
+	_, err = ParseAmountLocale("1.5", "LOCALECOIN", core.LookupAmountLocale("es"))
+	require.Equal(t, errors.ErrInvalidValue, err)
+	_, err = ParseAmountLocale("1,5", "LOCALECOIN", core.DefaultAmountLocale)
+	require.Equal(t, errors.ErrInvalidValue, err)
+	_, err = ParseAmountLocale("1,234.5678", "LOCALECOIN", core.DefaultAmountLocale)
+	require.Equal(t, errors.ErrAmountPrecision, err)
+}
+
+func TestUserAmountLocale(t *testing.T) {
+	registerAssetsPlugin(new(mocks.AltcoinPlugin),
+		core.AltcoinMetadata{Name: "User coin", Ticker: "USERCOIN", Accuracy: 2},
+	)
+	for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		defer os.Setenv(name, os.Getenv(name))
+		os.Unsetenv(name)
+	}
+	require.Equal(t, core.DefaultAmountLocale, UserAmountLocale())
+	os.Setenv("LANG", "de_DE.UTF-8")
+	require.Equal(t, core.LookupAmountLocale("de"), UserAmountLocale())
+	os.Setenv("LC_ALL", "en_US.UTF-8")
+	require.Equal(t, core.DefaultAmountLocale, UserAmountLocale())
+	os.Unsetenv("LC_ALL")
+
+	amount, err := NewAssetAmount("USERCOIN", 123456)
+	require.NoError(t, err)
+	require.Equal(t, "1.234,56", amount.Amount)
+	out := NewGenericOutput(nil, "")
+	require.NoError(t, out.PushCoins("USERCOIN", "1.234,56"))
+	coins, err := out.GetCoins("USERCOIN")
+	require.NoError(t, err)
+	require.Equal(t, uint64(123456), coins)
+	require.Error(t, out.PushCoins("USERCOIN", "1.5"))
+
+	// Locale set by user interface takes precedence over environment
+	defer func() { userAmountLocale = nil }()
+	SetUserAmountLocale(core.AmountLocale{DecimalSeparator: ".", GroupSeparator: "\u202f"})
+	amount, err = NewAssetAmount("USERCOIN", 123456)
+	require.NoError(t, err)
+	require.Equal(t, "1\u202f234.56", amount.Amount)
+	require.NoError(t, out.PushCoins("USERCOIN", "1\u202f234.5"))
+	coins, err = out.GetCoins("USERCOIN")
+	require.NoError(t, err)
+	require.Equal(t, uint64(123450), coins)
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	a := core.NewAmount("ARITHCOIN", 1500)
+	b := core.NewAmount("ARITHCOIN", 500)
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2000), sum.Coins)
+	diff, err := a.Sub(b)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), diff.Coins)
+	prod, err := b.Mul(3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1500), prod.Coins)
+	cmp, err := a.Cmp(prod)
+	require.NoError(t, err)
+	require.Equal(t, 0, cmp)
+	cmp, err = b.Cmp(a)
+	require.NoError(t, err)
+	require.Equal(t, -1, cmp)
+	require.True(t, core.NewAmount("ARITHCOIN", 0).IsZero())
+
+	_, err = b.Sub(a)
+	require.Equal(t, errors.ErrAmountUnderflow, err)
+	_, err = core.NewAmount("ARITHCOIN", math.MaxUint64).Add(b)
+	require.Equal(t, errors.ErrAmountOverflow, err)
+	_, err = core.NewAmount("ARITHCOIN", math.MaxUint64).Mul(2)
+	require.Equal(t, errors.ErrAmountOverflow, err)
+	_, err = a.Add(core.NewAmount("OTHERCOIN", 1))
+	require.Equal(t, errors.ErrAmountTickerMismatch, err)
+	_, err = a.Cmp(core.NewAmount("OTHERCOIN", 1))
+	require.Equal(t, errors.ErrAmountTickerMismatch, err)
+}
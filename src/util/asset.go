@@ -0,0 +1,143 @@
+package util
+
+import (
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	local "github.com/fibercrypto/fibercryptowallet/src/main"
+)
+
+// AssetAmount is a human-readable amount of coins of a given asset
+type AssetAmount struct {
+	// Ticker identifying the asset
+	Ticker string
+	// Name of the asset
+	Name string
+	// Coins expressed in asset base units
+	Coins uint64
+	// Amount formatted according to asset accuracy and user locale
+	Amount string
+}
+
+// NewAssetAmount formats coins of asset represented by ticker for users
+func NewAssetAmount(ticker string, coins uint64) (AssetAmount, error) {
+	formatted, err := FormatAmountLocale(core.NewAmount(ticker, coins), UserAmountLocale())
+	if err != nil {
+		return AssetAmount{}, err
+	}
+	return AssetAmount{
+		Ticker: ticker,
+		Name:   AltcoinCaption(ticker),
+		Coins:  coins,
+		Amount: formatted,
+	}, nil
+}
+
+// AmountsFromBalances formats a balance for each ticker, preserving tickers order
+func AmountsFromBalances(tickers []string, balance map[string]uint64) ([]AssetAmount, error) {
+	amounts := make([]AssetAmount, 0, len(tickers))
+	for _, ticker := range tickers {
+		amount, err := NewAssetAmount(ticker, balance[ticker])
+		if err != nil {
+			return nil, err
+		}
+		amounts = append(amounts, amount)
+	}
+	return amounts, nil
+}
+
+// AccountBalances retrieves formatted balance of every asset listed by account
+func AccountBalances(account core.CryptoAccount) ([]AssetAmount, error) {
+	tickers := account.ListAssets()
+	balance := make(map[string]uint64, len(tickers))
+	for _, ticker := range tickers {
+		coins, err := account.GetBalance(ticker)
+		if err != nil {
+			return nil, err
+		}
+		balance[ticker] = coins
+	}
+	return AmountsFromBalances(tickers, balance)
+}
+
+// OutputAmounts formats coins transferred in output for each ticker
+func OutputAmounts(out core.TransactionOutput, tickers []string) ([]AssetAmount, error) {
+	balance := make(map[string]uint64, len(tickers))
+	for _, ticker := range tickers {
+		coins, err := out.GetCoins(ticker)
+		if err != nil {
+			return nil, err
+		}
+		balance[ticker] = coins
+	}
+	return AmountsFromBalances(tickers, balance)
+}
+
+// InputAmounts formats coins spent by input for each ticker.
+// Amounts of assets accruing over time are those accrued by the time input was spent.
+func InputAmounts(in core.TransactionInput, tickers []string) ([]AssetAmount, error) {
+	accruing, isAccruing := in.(core.AccruingInput)
+	balance := make(map[string]uint64, len(tickers))
+	for _, ticker := range tickers {
+		spentTicker := ticker
+		if isAccruing {
+			if accrued, hasAccrued := accruing.AccruedAsset(ticker); hasAccrued {
+				spentTicker = accrued
+			}
+		}
+		coins, err := in.GetCoins(spentTicker)
+		if err != nil {
+			return nil, err
+		}
+		balance[ticker] = coins
+	}
+	return AmountsFromBalances(tickers, balance)
+}
+
+// TransactionFees formats the fee paid in every asset supported by transaction
+func TransactionFees(txn core.Transaction) ([]AssetAmount, error) {
+	tickers := txn.SupportedAssets()
+	fees := make(map[string]uint64, len(tickers))
+	for _, ticker := range tickers {
+		fee, err := txn.ComputeFee(ticker)
+		if err != nil {
+			return nil, err
+		}
+		fees[ticker] = fee
+	}
+	return AmountsFromBalances(tickers, fees)
+}
+
+// LookupAmount returns the amount of asset represented by ticker, if found
+func LookupAmount(amounts []AssetAmount, ticker string) (AssetAmount, bool) {
+	for _, amount := range amounts {
+		if amount.Ticker == ticker {
+			return amount, true
+		}
+	}
+	return AssetAmount{}, false
+}
+
+// ListTxnOptions enumerates transaction options supported by plugin handling asset represented by ticker
+func ListTxnOptions(ticker string) []core.TxnOptionSpec {
+	plugin, isRegistered := local.LoadAltcoinManager().LookupAltcoinPlugin(ticker)
+	if !isRegistered {
+		return nil
+	}
+	if provider, isProvider := plugin.(core.TxnOptionsProvider); isProvider {
+		return provider.ListTxnOptions(ticker)
+	}
+	return nil
+}
+
+// NewTxnOptions initializes transaction options with defaults declared by plugin
+// handling asset represented by ticker, overridden by values
+func NewTxnOptions(ticker string, values map[string]string) core.KeyValueStore {
+	defaults := NewKeyValueMap()
+	for _, opt := range ListTxnOptions(ticker) {
+		defaults.SetValue(opt.Key, opt.Default)
+	}
+	opts := NewKeyValueMap()
+	for k, v := range values {
+		opts.SetValue(k, v)
+	}
+	return NewKeyValuesWithDefaults(opts, defaults)
+}
@@ -0,0 +1,128 @@
+package util
+
+import (
+	"os"
+	"testing"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/mocks"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type pluginWithTxnOptions struct {
+	mocks.AltcoinPlugin
+	opts []core.TxnOptionSpec
+}
+
+func (p *pluginWithTxnOptions) ListTxnOptions(ticker string) []core.TxnOptionSpec {
+	return p.opts
+}
+
+func registerAssetsPlugin(plugin *mocks.AltcoinPlugin, metas ...core.AltcoinMetadata) {
+	plugin.On("RegisterTo", mock.Anything).Return().Run(func(args mock.Arguments) {
+		manager := args.Get(0).(core.AltcoinManager)
+		for _, meta := range metas {
+			manager.RegisterAltcoin(meta, plugin)
+		}
+	})
+	plugin.On("GetName").Return(metas[0].Name)
+	RegisterAltcoin(plugin)
+}
+
+func TestAssetAmounts(t *testing.T) {
+	registerAssetsPlugin(new(mocks.AltcoinPlugin),
+		core.AltcoinMetadata{Name: "Asset coin", Ticker: "ASSETCOIN", Accuracy: 3},
+		core.AltcoinMetadata{Name: "Asset hours", Ticker: "ASSETHOUR", Accuracy: 0},
+	)
+	tickers := []string{"ASSETCOIN", "ASSETHOUR"}
+	os.Setenv("LC_ALL", "en_US.UTF-8")
+	defer os.Unsetenv("LC_ALL")
+
+	amount, err := NewAssetAmount("ASSETCOIN", 1234567)
+	require.NoError(t, err)
+	require.Equal(t, AssetAmount{Ticker: "ASSETCOIN", Name: "Asset coin", Coins: 1234567, Amount: "1,234.567"}, amount)
+	_, err = NewAssetAmount("ASSETUNK", 1)
+	require.Error(t, err)
+
+	account := new(mocks.CryptoAccount)
+	account.On("ListAssets").Return(tickers)
+	account.On("GetBalance", "ASSETCOIN").Return(uint64(2000), nil)
+	account.On("GetBalance", "ASSETHOUR").Return(uint64(15), nil)
+	balances, err := AccountBalances(account)
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	require.Equal(t, "2", balances[0].Amount)
+	require.Equal(t, "15", balances[1].Amount)
+	hours, found := LookupAmount(balances, "ASSETHOUR")
+	require.True(t, found)
+	require.Equal(t, uint64(15), hours.Coins)
+	_, found = LookupAmount(balances, "ASSETUNK")
+	require.False(t, found)
+
+	out := new(mocks.TransactionOutput)
+	out.On("GetCoins", "ASSETCOIN").Return(uint64(500), nil)
+	out.On("GetCoins", "ASSETHOUR").Return(uint64(7), nil)
+	outAmounts, err := OutputAmounts(out, tickers)
+	require.NoError(t, err)
+	require.Equal(t, "0.5", outAmounts[0].Amount)
+	require.Equal(t, "7", outAmounts[1].Amount)
+
+	in := new(mocks.TransactionInput)
+	in.On("GetCoins", "ASSETCOIN").Return(uint64(1000), nil)
+	in.On("GetCoins", "ASSETHOUR").Return(uint64(0), nil)
+	inAmounts, err := InputAmounts(in, tickers)
+	require.NoError(t, err)
+	require.Equal(t, "1", inAmounts[0].Amount)
+	require.Equal(t, "0", inAmounts[1].Amount)
+
+	// Inputs show hours accrued by the time they were spent
+	accruing := &accruingInput{in, "ASSETHOUR", "ASSETHOUR#ACC"}
+	in.On("GetCoins", "ASSETHOUR#ACC").Return(uint64(12), nil)
+	inAmounts, err = InputAmounts(accruing, tickers)
+	require.NoError(t, err)
+	require.Equal(t, "1", inAmounts[0].Amount)
+	require.Equal(t, "ASSETHOUR", inAmounts[1].Ticker)
+	require.Equal(t, "12", inAmounts[1].Amount)
+
+	txn := new(mocks.Transaction)
+	txn.On("SupportedAssets").Return(tickers)
+	txn.On("ComputeFee", "ASSETCOIN").Return(uint64(0), nil)
+	txn.On("ComputeFee", "ASSETHOUR").Return(uint64(3), nil)
+	fees, err := TransactionFees(txn)
+	require.NoError(t, err)
+	require.Equal(t, "0", fees[0].Amount)
+	require.Equal(t, "3", fees[1].Amount)
+}
+
+// accruingInput reports amounts of asset accrued as a different asset
+type accruingInput struct {
+	*mocks.TransactionInput
+	ticker, accrued string
+}
+
+func (in *accruingInput) AccruedAsset(ticker string) (string, bool) {
+	return in.accrued, ticker == in.ticker
+}
+
+func TestTxnOptions(t *testing.T) {
+	plugin := &pluginWithTxnOptions{
+		opts: []core.TxnOptionSpec{
+			core.TxnOptionSpec{Key: "Mode", Default: "auto", Choices: []string{"auto", "manual"}},
+			core.TxnOptionSpec{Key: "Factor", Default: "0.5"},
+		},
+	}
+	plugin.On("RegisterTo", mock.Anything).Return().Run(func(args mock.Arguments) {
+		args.Get(0).(core.AltcoinManager).RegisterAltcoin(core.AltcoinMetadata{Name: "Options coin", Ticker: "OPTCOIN"}, plugin)
+	})
+	plugin.On("GetName").Return("Options coin")
+	RegisterAltcoin(plugin)
+
+	require.Equal(t, plugin.opts, ListTxnOptions("OPTCOIN"))
+	require.Nil(t, ListTxnOptions("OPTUNK"))
+
+	opts := NewTxnOptions("OPTCOIN", map[string]string{"Mode": "manual"})
+	require.Equal(t, "manual", opts.GetValue("Mode"))
+	require.Equal(t, "0.5", opts.GetValue("Factor"))
+	require.Nil(t, opts.GetValue("Other"))
+}
@@ -40,13 +40,13 @@ func (gOut *GenericOutput) SetCoins(ticker string, coins uint64) {
 	gOut.Balance[ticker] = coins
 }
 
-// PushCoins parses coins string and allocates them for an asset given its ticker
+// PushCoins parses coins string entered by user and allocates them for an asset given its ticker
 func (gOut *GenericOutput) PushCoins(ticker string, coinStr string) error {
-	coins, err := GetCoinValue(coinStr, ticker)
+	amount, err := ParseAmountLocale(coinStr, ticker, UserAmountLocale())
 	if err != nil {
 		return err
 	}
-	gOut.Balance[ticker] = coins
+	gOut.Balance[ticker] = amount.Coins
 	return nil
 }
 
@@ -66,6 +66,32 @@ func (gOut *GenericOutput) SupportedAssets() (tickers []string) {
 	return
 }
 
+// NewDestinationOutputs creates an output for each destination address.
+// Amounts are laid out one row per address and one column per ticker.
+// An empty amount allocates no coins of the corresponding asset.
+func NewDestinationOutputs(addrTo, tickers, amountsTo []string) ([]core.TransactionOutput, error) {
+	if len(amountsTo) != len(addrTo)*len(tickers) {
+		return nil, errors.ErrInvalidValue
+	}
+	outputs := make([]core.TransactionOutput, 0, len(addrTo))
+	for i, addrStr := range addrTo {
+		addr := NewGenericAddress(addrStr)
+		out := NewGenericOutput(&addr, "")
+		for j, ticker := range tickers {
+			amount := amountsTo[i*len(tickers)+j]
+			if amount == "" {
+				out.SetCoins(ticker, 0)
+				continue
+			}
+			if err := out.PushCoins(ticker, amount); err != nil {
+				return nil, err
+			}
+		}
+		outputs = append(outputs, &out)
+	}
+	return outputs, nil
+}
+
 // Type assertions
 var (
 	_ core.TransactionOutput = &GenericOutput{}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"os"
 	"sort"
 	"testing"
 
@@ -65,3 +66,37 @@ func TestNewGenericOutput(t *testing.T) {
 	_, err2 := output.GetCoins("other_coin")
 	require.Error(t, err2)
 }
+
+func TestNewDestinationOutputs(t *testing.T) {
+	mockPlugin := new(mocks.AltcoinPlugin)
+	mockPlugin.On("RegisterTo", mock.Anything).Return().Run(func(args mock.Arguments) {
+		manager := args.Get(0).(core.AltcoinManager)
+		manager.RegisterAltcoin(core.AltcoinMetadata{Name: "Dest coin", Ticker: "DESTCOIN", Accuracy: 2}, mockPlugin)
+		manager.RegisterAltcoin(core.AltcoinMetadata{Name: "Dest hours", Ticker: "DESTHOUR", Accuracy: 0}, mockPlugin)
+	})
+	mockPlugin.On("GetName").Return("Dest coin")
+	RegisterAltcoin(mockPlugin)
+	tickers := []string{"DESTCOIN", "DESTHOUR"}
+	os.Setenv("LC_ALL", "en_US.UTF-8")
+	defer os.Unsetenv("LC_ALL")
+
+	outs, err := NewDestinationOutputs([]string{"addr1", "addr2"}, tickers, []string{"1.5", "10", "2", ""})
+	require.NoError(t, err)
+	require.Len(t, outs, 2)
+	expected := [][]uint64{{150, 10}, {200, 0}}
+	for i, out := range outs {
+		addr, err := out.GetAddress()
+		require.NoError(t, err)
+		require.Equal(t, []string{"addr1", "addr2"}[i], addr.String())
+		for j, ticker := range tickers {
+			coins, err := out.GetCoins(ticker)
+			require.NoError(t, err)
+			require.Equal(t, expected[i][j], coins)
+		}
+	}
+
+	_, err = NewDestinationOutputs([]string{"addr1"}, tickers, []string{"1"})
+	require.Error(t, err)
+	_, err = NewDestinationOutputs([]string{"addr1"}, tickers, []string{"x", "1"})
+	require.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/fibercrypto/fibercryptowallet/src/coin/mocks"
 	"github.com/fibercrypto/fibercryptowallet/src/core"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -37,6 +38,69 @@ func TestRegisteredPlugin(t *testing.T) {
 	require.Equal(t, uint64(fakeQuotient), q)
 }
 
+// restartablePlugin plugin able to restart independently
+type restartablePlugin struct {
+	*mocks.AltcoinPlugin
+}
+
+func (p restartablePlugin) Restart() error {
+	return p.Called().Error(0)
+}
+
+func TestRestartPlugin(t *testing.T) {
+	meta := core.AltcoinMetadata{Name: "Restartable coin", Ticker: "RSTCOIN"}
+	plugin := restartablePlugin{new(mocks.AltcoinPlugin)}
+	plugin.On("RegisterTo", mock.Anything).Return().Run(func(args mock.Arguments) {
+		args.Get(0).(core.AltcoinManager).RegisterAltcoin(meta, plugin)
+	})
+	plugin.On("GetName").Return("Restartable")
+	plugin.On("Restart").Return(nil)
+	RegisterAltcoin(plugin)
+
+	require.Contains(t, ListRestartablePlugins(), "Restartable")
+	require.NoError(t, RestartPlugin("Restartable"))
+	plugin.AssertCalled(t, "Restart")
+	require.Equal(t, fce.ErrNotFound, RestartPlugin("Unregistered"))
+}
+
+func TestLookupPluginServices(t *testing.T) {
+	other := new(mocks.AltcoinPlugin)
+	other.On("RegisterTo", mock.Anything).Return().Run(func(args mock.Arguments) {
+		args.Get(0).(core.AltcoinManager).RegisterAltcoin(core.AltcoinMetadata{Name: "Other coin", Ticker: "SVCOTHER"}, other)
+	})
+	other.On("GetName").Return("Other")
+	RegisterAltcoin(other)
+	env, signer, txnAPI := new(mocks.WalletEnv), new(mocks.BlockchainSignService), new(mocks.BlockchainTransactionAPI)
+	plugin := new(mocks.AltcoinPlugin)
+	plugin.On("RegisterTo", mock.Anything).Return().Run(func(args mock.Arguments) {
+		args.Get(0).(core.AltcoinManager).RegisterAltcoin(core.AltcoinMetadata{Name: "Service coin", Ticker: "SVCCOIN"}, plugin)
+	})
+	plugin.On("GetName").Return("Service")
+	plugin.On("LoadWalletEnvs").Return([]core.WalletEnv{env, new(mocks.WalletEnv)})
+	plugin.On("LoadSignService").Return(signer, nil)
+	plugin.On("LoadTransactionAPI", "MainNet").Return(txnAPI, nil)
+	RegisterAltcoin(plugin)
+
+	// Services are resolved by ticker regardless of registration order
+	foundEnv, err := LoadWalletEnv("SVCCOIN")
+	require.NoError(t, err)
+	require.True(t, foundEnv == env)
+	foundSigner, err := LoadSignService("SVCCOIN")
+	require.NoError(t, err)
+	require.True(t, foundSigner == signer)
+	foundAPI, err := LoadTransactionAPI("SVCCOIN", "MainNet")
+	require.NoError(t, err)
+	require.True(t, foundAPI == txnAPI)
+	other.AssertNotCalled(t, "LoadWalletEnvs")
+
+	_, err = LoadWalletEnv("SVCUNK")
+	require.Equal(t, fce.ErrInvalidAltcoinTicker, err)
+	_, err = LoadSignService("SVCUNK")
+	require.Equal(t, fce.ErrInvalidAltcoinTicker, err)
+	_, err = LoadTransactionAPI("SVCUNK", "MainNet")
+	require.Equal(t, fce.ErrInvalidAltcoinTicker, err)
+}
+
 func TestUnknownPlugin(t *testing.T) {
 	fakeTicker := "MOCKSCOIN_UNK"
 	require.Equal(t, "MOCKSCOIN_UNK <Unregistered>", AltcoinCaption(fakeTicker))
@@ -5,6 +5,7 @@ import (
 	"math"
 
 	"github.com/fibercrypto/fibercryptowallet/src/core"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
 	local "github.com/fibercrypto/fibercryptowallet/src/main"
 )
 
@@ -16,16 +17,85 @@ func AltcoinCaption(ticker string) string {
 }
 
 func AltcoinQuotient(ticker string) (uint64, error) {
+	accuracy, err := AltcoinAccuracy(ticker)
+	if err != nil {
+		return uint64(0), err
+	}
+	quotient := uint64(1)
+	for i := int32(0); i < accuracy; i++ {
+		if quotient > math.MaxUint64/10 {
+			return uint64(0), fce.ErrAmountOverflow
+		}
+		quotient *= 10
+	}
+	return quotient, nil
+}
+
+// AltcoinAccuracy returns decimal places seen in fractions of asset represented by ticker
+func AltcoinAccuracy(ticker string) (int32, error) {
 	if info, isRegistered := local.LoadAltcoinManager().DescribeAltcoin(ticker); isRegistered {
-		return uint64(math.Pow(float64(10), float64(info.Accuracy))), nil
+		return info.Accuracy, nil
 	}
-	return uint64(0), errors.New(ticker + " <Unregistered>")
+	return 0, errors.New(ticker + " <Unregistered>")
 }
 
 func RegisterAltcoin(p core.AltcoinPlugin) {
 	local.LoadAltcoinManager().RegisterPlugin(p)
 }
 
+// LoadWalletEnv loads the default wallet environment of plugin handling asset represented by ticker
+func LoadWalletEnv(ticker string) (core.WalletEnv, error) {
+	plugin, isRegistered := local.LoadAltcoinManager().LookupAltcoinPlugin(ticker)
+	if !isRegistered {
+		return nil, fce.ErrInvalidAltcoinTicker
+	}
+	envs := plugin.LoadWalletEnvs()
+	if len(envs) == 0 || envs[0] == nil {
+		return nil, fce.ErrNotFound
+	}
+	return envs[0], nil
+}
+
+// LoadSignService loads sign service of plugin handling asset represented by ticker
+func LoadSignService(ticker string) (core.BlockchainSignService, error) {
+	plugin, isRegistered := local.LoadAltcoinManager().LookupAltcoinPlugin(ticker)
+	if !isRegistered {
+		return nil, fce.ErrInvalidAltcoinTicker
+	}
+	return plugin.LoadSignService()
+}
+
+// LoadTransactionAPI loads transaction API on netType network of plugin handling asset represented by ticker
+func LoadTransactionAPI(ticker, netType string) (core.BlockchainTransactionAPI, error) {
+	plugin, isRegistered := local.LoadAltcoinManager().LookupAltcoinPlugin(ticker)
+	if !isRegistered {
+		return nil, fce.ErrInvalidAltcoinTicker
+	}
+	return plugin.LoadTransactionAPI(netType)
+}
+
+// ListRestartablePlugins enumerates names of registered plugins able to restart independently
+func ListRestartablePlugins() []string {
+	names := make([]string, 0)
+	for _, plugin := range local.LoadAltcoinManager().ListRegisteredPlugins() {
+		if _, isRestarter := plugin.(core.PluginRestarter); isRestarter {
+			names = append(names, plugin.GetName())
+		}
+	}
+	return names
+}
+
+// RestartPlugin restarts registered plugin identified by name, leaving other plugins untouched
+func RestartPlugin(name string) error {
+	for _, plugin := range local.LoadAltcoinManager().ListRegisteredPlugins() {
+		restarter, isRestarter := plugin.(core.PluginRestarter)
+		if isRestarter && plugin.GetName() == name {
+			return restarter.Restart()
+		}
+	}
+	return fce.ErrNotFound
+}
+
 // LookupSignerByUID search for signer matching given ID
 func LookupSignerByUID(wlt core.Wallet, id core.UID) core.TxnSigner {
 	wltSigner, isSigner := wlt.(core.TxnSigner)
@@ -0,0 +1,470 @@
+package universal
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SkycoinProject/skycoin/src/cipher"
+	"github.com/SkycoinProject/skycoin/src/cipher/bip32"
+	"github.com/SkycoinProject/skycoin/src/cipher/bip39"
+	"github.com/SkycoinProject/skycoin/src/cipher/encrypt"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	fce "github.com/fibercrypto/fibercryptowallet/src/errors"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+	"github.com/fibercrypto/fibercryptowallet/src/util/logging"
+)
+
+var logUniversal = logging.MustGetLogger("Universal wallet")
+
+var (
+	// ErrNoLinkedCoins no registered coin could derive wallets from seed
+	ErrNoLinkedCoins = errors.New("No registered coin supports BIP44 wallets")
+	// ErrWalletNotFound universal wallet file does not exist
+	ErrWalletNotFound = errors.New("Universal wallet not found")
+)
+
+const (
+	// WalletTypeBip44 type of plugin wallets deriving accounts along m/44'/coin_type'/account' paths
+	WalletTypeBip44 = "bip44"
+	// WalletTimestampFormat wallet file name timestamp format
+	WalletTimestampFormat = "2006_01_02"
+
+	walletExt     = ".uwlt"
+	walletVersion = "1"
+)
+
+// walletCryptor protects universal seeds at rest
+var walletCryptor = encrypt.DefaultScryptChacha20poly1305
+
+// Link binds a universal wallet to the wallet derived from its seed by a coin plugin
+type Link struct {
+	// Plugin name of the plugin owning linked wallet
+	Plugin string `json:"plugin"`
+	// Ticker identifies the coin of linked wallet
+	Ticker string `json:"ticker"`
+	// CoinType coin_type segment of BIP44 derivation path
+	CoinType uint32 `json:"coin_type"`
+	// WalletID identifies linked wallet in plugin wallet set
+	WalletID string `json:"wallet_id"`
+	// AccountKey extended public key of account m/44'/coin_type'/0'
+	AccountKey string `json:"account_key"`
+}
+
+// walletFile is the on-disk representation of universal wallets
+type walletFile struct {
+	Version string `json:"version"`
+	Label   string `json:"label"`
+	// Seed base64 encoded ciphertext of BIP39 mnemonic
+	Seed  string `json:"seed"`
+	Links []Link `json:"links"`
+}
+
+func loadWalletFile(path string) (*walletFile, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var wf walletFile
+	if err := json.Unmarshal(b, &wf); err != nil {
+		return nil, err
+	}
+	return &wf, nil
+}
+
+func saveWalletFile(path string, wf *walletFile) error {
+	b, err := json.MarshalIndent(wf, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0600)
+}
+
+func encryptSeed(mnemonic, password string) (string, error) {
+	b, err := walletCryptor.Encrypt([]byte(mnemonic), []byte(password))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func decryptSeed(seed, password string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(seed)
+	if err != nil {
+		return "", err
+	}
+	plain, err := walletCryptor.Decrypt(b, []byte(password))
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// AccountPublicKey derives extended public key of account m/44'/coinType'/0' for BIP39 mnemonic
+func AccountPublicKey(mnemonic string, coinType uint32) (string, error) {
+	seed, err := bip39.NewSeed(mnemonic, "")
+	if err != nil {
+		return "", err
+	}
+	accKey, err := bip32.NewPrivateKeyFromPath(seed, fmt.Sprintf("m/44'/%d'/0'", coinType))
+	if err != nil {
+		return "", err
+	}
+	return accKey.PublicKey().String(), nil
+}
+
+// bip44Coin returns metadata of the coin whose accounts are derived by plugin wallets
+func bip44Coin(plugin core.AltcoinPlugin) (core.AltcoinMetadata, bool) {
+	for _, info := range plugin.ListSupportedAltcoins() {
+		if info.HasBip44 {
+			return info, true
+		}
+	}
+	return core.AltcoinMetadata{}, false
+}
+
+// bip44WalletSet returns the first plugin wallet set able to create BIP44 wallets
+func bip44WalletSet(plugin core.AltcoinPlugin) core.WalletSet {
+	for _, env := range plugin.LoadWalletEnvs() {
+		wltSet := env.GetWalletSet()
+		for _, wltType := range wltSet.SupportedWalletTypes() {
+			if wltType == WalletTypeBip44 {
+				return wltSet
+			}
+		}
+	}
+	return nil
+}
+
+// DefaultWalletDir directory storing universal wallets of current user
+func DefaultWalletDir() string {
+	usr, err := user.Current()
+	if err != nil {
+		logUniversal.WithError(err).Error()
+		return ""
+	}
+	return filepath.Join(usr.HomeDir, ".fibercryptowallet", "universal")
+}
+
+// Directory stores universal wallets in a local folder
+type Directory struct {
+	WalletDir string
+	manager   core.AltcoinManager
+	mutex     *sync.Mutex
+}
+
+// NewDirectory instantiates universal wallets storage backed by folder at dirPath.
+// Linked wallets are created by plugins registered against manager.
+func NewDirectory(dirPath string, manager core.AltcoinManager) *Directory {
+	return &Directory{
+		WalletDir: dirPath,
+		manager:   manager,
+		mutex:     new(sync.Mutex),
+	}
+}
+
+func (dir *Directory) path(id string) string {
+	return filepath.Join(dir.WalletDir, id)
+}
+
+// ListWallets returns universal wallets found in directory
+func (dir *Directory) ListWallets() []*Wallet {
+	logUniversal.Info("Listing universal wallets")
+	wallets := make([]*Wallet, 0)
+	entries, err := ioutil.ReadDir(dir.WalletDir)
+	if err != nil {
+		logUniversal.WithError(err).WithField("dirname", dir.WalletDir).Debug("Couldn't read wallet directory")
+		return wallets
+	}
+	for _, e := range entries {
+		if !e.Mode().IsRegular() || !strings.HasSuffix(e.Name(), walletExt) {
+			continue
+		}
+		if w := dir.GetWallet(e.Name()); w != nil {
+			wallets = append(wallets, w)
+		}
+	}
+	return wallets
+}
+
+// GetWallet to lookup universal wallet by ID
+func (dir *Directory) GetWallet(id string) *Wallet {
+	if _, err := loadWalletFile(dir.path(id)); err != nil {
+		logUniversal.WithError(err).WithField("filename", dir.path(id)).Debug("Couldn't load wallet")
+		return nil
+	}
+	return &Wallet{Id: id, dir: dir}
+}
+
+// LookupLink finds universal wallet linked to wallet identified by walletID in wallet set of coin ticker
+func (dir *Directory) LookupLink(ticker, walletID string) (*Wallet, error) {
+	for _, w := range dir.ListWallets() {
+		links, err := w.Links()
+		if err != nil {
+			continue
+		}
+		for _, link := range links {
+			if link.Ticker == ticker && link.WalletID == walletID {
+				return w, nil
+			}
+		}
+	}
+	return nil, ErrWalletNotFound
+}
+
+// CreateWallet stores BIP39 mnemonic encrypted with password and links a wallet
+// of every registered coin supporting BIP44 to it.
+// Restoring a seed this way brings back wallets of all those coins,
+// scanAddressesN is used to discover addresses with previous history.
+func (dir *Directory) CreateWallet(label, mnemonic string, pwd core.PasswordReader, scanAddressesN int) (*Wallet, error) {
+	logUniversal.Info("Creating universal wallet")
+	if err := bip39.ValidateMnemonic(mnemonic); err != nil {
+		return nil, fce.ErrInvalidWalletEntropy
+	}
+	pwdCtx := util.NewKeyValueMap()
+	pwdCtx.SetValue(core.StrTypeName, core.TypeNameWalletSet)
+	pwdCtx.SetValue(core.StrMethodName, "CreateWallet")
+	pwdCtx.SetValue(core.StrWalletLabel, label)
+	password, err := pwd("Enter password", pwdCtx)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := encryptSeed(mnemonic, password)
+	if err != nil {
+		return nil, err
+	}
+	wf := &walletFile{
+		Version: walletVersion,
+		Label:   label,
+		Seed:    seed,
+		Links:   make([]Link, 0),
+	}
+	if err := os.MkdirAll(dir.WalletDir, 0700); err != nil {
+		return nil, err
+	}
+	dir.mutex.Lock()
+	defer dir.mutex.Unlock()
+	// Universal wallet is saved before linked wallets are created, so that they never outlive it
+	id := dir.newUniqueWalletFilename()
+	if err := saveWalletFile(dir.path(id), wf); err != nil {
+		logUniversal.WithError(err).WithField("dir", dir.WalletDir).Error("Couldn't save wallet")
+		return nil, err
+	}
+	created := dir.linkCoins(wf, mnemonic, password, scanAddressesN)
+	if len(created) == 0 {
+		dir.removeWalletFile(id)
+		return nil, ErrNoLinkedCoins
+	}
+	if err := saveWalletFile(dir.path(id), wf); err != nil {
+		logUniversal.WithError(err).WithField("dir", dir.WalletDir).Error("Couldn't save linked wallets")
+		removeLinkedWallets(created)
+		dir.removeWalletFile(id)
+		return nil, err
+	}
+	return &Wallet{Id: id, dir: dir}, nil
+}
+
+// removeWalletFile deletes universal wallet file left without linked wallets
+func (dir *Directory) removeWalletFile(id string) {
+	if err := os.Remove(dir.path(id)); err != nil {
+		logUniversal.WithError(err).WithField("filename", dir.path(id)).Error("Couldn't remove wallet")
+	}
+}
+
+// linkedWallet wallet created by plugin wallet set for a new link
+type linkedWallet struct {
+	wltSet core.WalletSet
+	id     string
+}
+
+// removeLinkedWallets deletes wallets created for links which could not be saved
+func removeLinkedWallets(created []linkedWallet) {
+	for _, lw := range created {
+		remover, isRemover := lw.wltSet.(core.WalletRemover)
+		if !isRemover {
+			logUniversal.WithField("wallet", lw.id).Warn("Linked wallet set can not remove wallets")
+			continue
+		}
+		if err := remover.RemoveWallet(lw.id); err != nil {
+			logUniversal.WithError(err).WithField("wallet", lw.id).Error("Couldn't remove linked wallet")
+		}
+	}
+}
+
+// linkCoins creates wallets derived from mnemonic for registered coins not linked yet
+// and returns the wallets created for links added
+func (dir *Directory) linkCoins(wf *walletFile, mnemonic, password string, scanAddressesN int) []linkedWallet {
+	linked := make(map[string]bool, len(wf.Links))
+	for _, link := range wf.Links {
+		linked[link.Ticker] = true
+	}
+	created := make([]linkedWallet, 0)
+	for _, plugin := range dir.manager.ListRegisteredPlugins() {
+		info, hasBip44 := bip44Coin(plugin)
+		if !hasBip44 || linked[info.Ticker] {
+			continue
+		}
+		wltSet := bip44WalletSet(plugin)
+		if wltSet == nil {
+			logUniversal.WithField("plugin", plugin.GetName()).Warn("Plugin can not create BIP44 wallets")
+			continue
+		}
+		coinType := uint32(info.Bip44CoinType)
+		accKey, err := AccountPublicKey(mnemonic, coinType)
+		if err != nil {
+			logUniversal.WithError(err).WithField("ticker", info.Ticker).Error("Couldn't derive account key")
+			continue
+		}
+		wlt, err := wltSet.CreateWallet(wf.Label, mnemonic, WalletTypeBip44, true, util.ConstantPassword(password), scanAddressesN)
+		if err != nil {
+			logUniversal.WithError(err).WithField("ticker", info.Ticker).Error("Couldn't create linked wallet")
+			continue
+		}
+		wf.Links = append(wf.Links, Link{
+			Plugin:     plugin.GetName(),
+			Ticker:     info.Ticker,
+			CoinType:   coinType,
+			WalletID:   wlt.GetId(),
+			AccountKey: accKey,
+		})
+		linked[info.Ticker] = true
+		created = append(created, linkedWallet{wltSet: wltSet, id: wlt.GetId()})
+	}
+	return created
+}
+
+func (dir *Directory) newUniqueWalletFilename() string {
+	for {
+		timestamp := time.Now().Format(WalletTimestampFormat)
+		padding := hex.EncodeToString(cipher.RandByte(2))
+		name := fmt.Sprintf("%s_%s%s", timestamp, padding, walletExt)
+		if _, err := os.Stat(dir.path(name)); os.IsNotExist(err) {
+			return name
+		}
+	}
+}
+
+// Wallet is a BIP39 seed shared by linked wallets of every coin supporting BIP44
+type Wallet struct {
+	Id  string
+	dir *Directory
+}
+
+func (wlt *Wallet) load() (*walletFile, error) {
+	wf, err := loadWalletFile(wlt.dir.path(wlt.Id))
+	if err != nil {
+		logUniversal.WithError(err).WithField("filename", wlt.dir.path(wlt.Id)).Error("Couldn't load wallet file")
+		return nil, err
+	}
+	return wf, nil
+}
+
+// GetId returns wallet local identifier
+func (wlt *Wallet) GetId() string {
+	return wlt.Id
+}
+
+// GetLabel provides a human-readable name for this wallet
+func (wlt *Wallet) GetLabel() string {
+	wf, err := wlt.load()
+	if err != nil {
+		return ""
+	}
+	return wf.Label
+}
+
+// Links lists wallets derived from universal seed
+func (wlt *Wallet) Links() ([]Link, error) {
+	wf, err := wlt.load()
+	if err != nil {
+		return nil, err
+	}
+	return wf.Links, nil
+}
+
+// LinkedWallets looks up wallets derived from universal seed in wallet sets of their plugins
+func (wlt *Wallet) LinkedWallets() []core.Wallet {
+	links, err := wlt.Links()
+	if err != nil {
+		return nil
+	}
+	wallets := make([]core.Wallet, 0, len(links))
+	for _, link := range links {
+		plugin, isRegistered := wlt.dir.manager.LookupAltcoinPlugin(link.Ticker)
+		if !isRegistered {
+			continue
+		}
+		for _, env := range plugin.LoadWalletEnvs() {
+			if w := env.GetWalletSet().GetWallet(link.WalletID); w != nil {
+				wallets = append(wallets, w)
+				break
+			}
+		}
+	}
+	return wallets
+}
+
+// Mnemonic decrypts universal seed
+func (wlt *Wallet) Mnemonic(pwd core.PasswordReader) (string, error) {
+	wf, err := wlt.load()
+	if err != nil {
+		return "", err
+	}
+	password, err := wlt.readPassword(wf, "Mnemonic", pwd)
+	if err != nil {
+		return "", err
+	}
+	return decryptSeed(wf.Seed, password)
+}
+
+// LinkCoins derives wallets for coins registered after universal wallet was created
+// and returns links added this way
+func (wlt *Wallet) LinkCoins(pwd core.PasswordReader, scanAddressesN int) ([]Link, error) {
+	logUniversal.Info("Linking coins to universal wallet")
+	wlt.dir.mutex.Lock()
+	defer wlt.dir.mutex.Unlock()
+	wf, err := wlt.load()
+	if err != nil {
+		return nil, err
+	}
+	password, err := wlt.readPassword(wf, "LinkCoins", pwd)
+	if err != nil {
+		return nil, err
+	}
+	mnemonic, err := decryptSeed(wf.Seed, password)
+	if err != nil {
+		return nil, err
+	}
+	count := len(wf.Links)
+	created := wlt.dir.linkCoins(wf, mnemonic, password, scanAddressesN)
+	if len(created) == 0 {
+		return []Link{}, nil
+	}
+	if err := saveWalletFile(wlt.dir.path(wlt.Id), wf); err != nil {
+		logUniversal.WithError(err).Error("Couldn't save linked wallets")
+		removeLinkedWallets(created)
+		return nil, err
+	}
+	return wf.Links[count:], nil
+}
+
+func (wlt *Wallet) readPassword(wf *walletFile, method string, pwd core.PasswordReader) (string, error) {
+	if pwd == nil {
+		return "", fce.ErrWalletCantSign
+	}
+	pwdCtx := util.NewKeyValueMap()
+	pwdCtx.SetValue(core.StrTypeName, core.TypeNameWallet)
+	pwdCtx.SetValue(core.StrMethodName, method)
+	pwdCtx.SetValue(core.StrWalletName, wlt.Id)
+	pwdCtx.SetValue(core.StrWalletLabel, wf.Label)
+	return pwd("Enter password", pwdCtx)
+}
@@ -0,0 +1,206 @@
+package universal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fibercrypto/fibercryptowallet/src/coin/mocks"
+	"github.com/fibercrypto/fibercryptowallet/src/core"
+	"github.com/fibercrypto/fibercryptowallet/src/util"
+)
+
+const (
+	testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	testPassword = "secret"
+)
+
+// mockBip44Plugin instantiates plugin whose single wallet set creates wallet wltID
+func mockBip44Plugin(name, ticker string, hasBip44 bool, coinType int32, wltID string) (*mocks.AltcoinPlugin, *mocks.WalletSet) {
+	plugin := new(mocks.AltcoinPlugin)
+	plugin.On("GetName").Return(name)
+	plugin.On("ListSupportedAltcoins").Return([]core.AltcoinMetadata{
+		{Name: name, Ticker: ticker, HasBip44: hasBip44, Bip44CoinType: coinType},
+	})
+	wlt := new(mocks.Wallet)
+	wlt.On("GetId").Return(wltID)
+	wltSet := new(mocks.WalletSet)
+	wltSet.On("SupportedWalletTypes").Return([]string{"deterministic", WalletTypeBip44})
+	wltSet.On("CreateWallet", "universe", testMnemonic, WalletTypeBip44, true, mock.Anything, 0).Return(wlt, nil)
+	wltSet.On("GetWallet", wltID).Return(wlt)
+	env := new(mocks.WalletEnv)
+	env.On("GetWalletSet").Return(wltSet)
+	plugin.On("LoadWalletEnvs").Return([]core.WalletEnv{env})
+	return plugin, wltSet
+}
+
+func mockManager(plugins ...*mocks.AltcoinPlugin) *mocks.AltcoinManager {
+	manager := new(mocks.AltcoinManager)
+	registered := make([]core.AltcoinPlugin, len(plugins))
+	for i, plugin := range plugins {
+		registered[i] = plugin
+		ticker := plugin.ListSupportedAltcoins()[0].Ticker
+		manager.On("LookupAltcoinPlugin", ticker).Return(plugin, true)
+	}
+	manager.On("ListRegisteredPlugins").Return(registered)
+	return manager
+}
+
+func TestCreateWallet(t *testing.T) {
+	walletDir, err := ioutil.TempDir("", "universal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walletDir)
+
+	sky, skySet := mockBip44Plugin("SkyFiber", "SKY", true, 8000, "sky.wlt")
+	noBip44, noBip44Set := mockBip44Plugin("Legacy", "LGC", false, 0, "lgc.wlt")
+	dir := NewDirectory(walletDir, mockManager(sky, noBip44))
+
+	_, err = dir.CreateWallet("universe", "not a mnemonic", util.ConstantPassword(testPassword), 0)
+	require.Error(t, err)
+
+	wlt, err := dir.CreateWallet("universe", testMnemonic, util.ConstantPassword(testPassword), 0)
+	require.NoError(t, err)
+	skySet.AssertCalled(t, "CreateWallet", "universe", testMnemonic, WalletTypeBip44, true, mock.Anything, 0)
+	noBip44Set.AssertNotCalled(t, "CreateWallet", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	require.Equal(t, "universe", wlt.GetLabel())
+	links, err := wlt.Links()
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	require.Equal(t, "SkyFiber", links[0].Plugin)
+	require.Equal(t, "SKY", links[0].Ticker)
+	require.Equal(t, uint32(8000), links[0].CoinType)
+	require.Equal(t, "sky.wlt", links[0].WalletID)
+	accKey, err := AccountPublicKey(testMnemonic, 8000)
+	require.NoError(t, err)
+	require.Equal(t, accKey, links[0].AccountKey)
+
+	linked := wlt.LinkedWallets()
+	require.Len(t, linked, 1)
+	require.Equal(t, "sky.wlt", linked[0].GetId())
+
+	found, err := dir.LookupLink("SKY", "sky.wlt")
+	require.NoError(t, err)
+	require.Equal(t, wlt.GetId(), found.GetId())
+	_, err = dir.LookupLink("SKY", "other.wlt")
+	require.Equal(t, ErrWalletNotFound, err)
+
+	wallets := dir.ListWallets()
+	require.Len(t, wallets, 1)
+	require.Equal(t, wlt.GetId(), wallets[0].GetId())
+
+	mnemonic, err := wlt.Mnemonic(util.ConstantPassword(testPassword))
+	require.NoError(t, err)
+	require.Equal(t, testMnemonic, mnemonic)
+	_, err = wlt.Mnemonic(util.ConstantPassword("wrong"))
+	require.Error(t, err)
+}
+
+func TestCreateWalletNoLinkedCoins(t *testing.T) {
+	walletDir, err := ioutil.TempDir("", "universal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walletDir)
+
+	noBip44, _ := mockBip44Plugin("Legacy", "LGC", false, 0, "lgc.wlt")
+	dir := NewDirectory(walletDir, mockManager(noBip44))
+	_, err = dir.CreateWallet("universe", testMnemonic, util.ConstantPassword(testPassword), 0)
+	require.Equal(t, ErrNoLinkedCoins, err)
+	require.Empty(t, dir.ListWallets())
+}
+
+// removableWalletSet wallet set able to delete wallets
+type removableWalletSet struct {
+	*mocks.WalletSet
+}
+
+func (wltSet removableWalletSet) RemoveWallet(id string) error {
+	return wltSet.Called(id).Error(0)
+}
+
+func TestCreateWalletRollback(t *testing.T) {
+	walletDir, err := ioutil.TempDir("", "universal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walletDir)
+
+	// Linked wallets are not created if universal wallet can not be saved
+	sky, skySet := mockBip44Plugin("SkyFiber", "SKY", true, 8000, "sky.wlt")
+	notDir := filepath.Join(walletDir, "file")
+	require.NoError(t, ioutil.WriteFile(notDir, nil, 0600))
+	_, err = NewDirectory(notDir, mockManager(sky)).CreateWallet("universe", testMnemonic, util.ConstantPassword(testPassword), 0)
+	require.Error(t, err)
+	skySet.AssertNotCalled(t, "CreateWallet", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	// Linked wallets are removed if links can not be saved
+	plugin := new(mocks.AltcoinPlugin)
+	plugin.On("GetName").Return("SkyFiber")
+	plugin.On("ListSupportedAltcoins").Return([]core.AltcoinMetadata{
+		{Name: "SkyFiber", Ticker: "SKY", HasBip44: true, Bip44CoinType: 8000},
+	})
+	wlt := new(mocks.Wallet)
+	wlt.On("GetId").Return("sky.wlt")
+	wltSet := removableWalletSet{new(mocks.WalletSet)}
+	wltSet.On("SupportedWalletTypes").Return([]string{WalletTypeBip44})
+	wltSet.On("CreateWallet", "universe", testMnemonic, WalletTypeBip44, true, mock.Anything, 0).Return(wlt, nil).Run(func(mock.Arguments) {
+		// Universal wallet file is replaced by a folder, which can not be written
+		entries, err := ioutil.ReadDir(walletDir)
+		require.NoError(t, err)
+		for _, e := range entries {
+			if strings.HasSuffix(e.Name(), walletExt) {
+				path := filepath.Join(walletDir, e.Name())
+				require.NoError(t, os.Remove(path))
+				require.NoError(t, os.Mkdir(path, 0700))
+			}
+		}
+	})
+	wltSet.On("RemoveWallet", "sky.wlt").Return(nil)
+	env := new(mocks.WalletEnv)
+	env.On("GetWalletSet").Return(wltSet)
+	plugin.On("LoadWalletEnvs").Return([]core.WalletEnv{env})
+	dir := NewDirectory(walletDir, mockManager(plugin))
+	_, err = dir.CreateWallet("universe", testMnemonic, util.ConstantPassword(testPassword), 0)
+	require.Error(t, err)
+	wltSet.AssertCalled(t, "RemoveWallet", "sky.wlt")
+	require.Empty(t, dir.ListWallets())
+	entries, err := ioutil.ReadDir(walletDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestLinkCoins(t *testing.T) {
+	walletDir, err := ioutil.TempDir("", "universal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walletDir)
+
+	sky, _ := mockBip44Plugin("SkyFiber", "SKY", true, 8000, "sky.wlt")
+	wlt, err := NewDirectory(walletDir, mockManager(sky)).CreateWallet("universe", testMnemonic, util.ConstantPassword(testPassword), 0)
+	require.NoError(t, err)
+
+	eth, ethSet := mockBip44Plugin("Ethereum", "ETH", true, 60, "eth.wlt")
+	dir := NewDirectory(walletDir, mockManager(sky, eth))
+	wlt = dir.GetWallet(wlt.GetId())
+	require.NotNil(t, wlt)
+
+	_, err = wlt.LinkCoins(util.ConstantPassword("wrong"), 0)
+	require.Error(t, err)
+	ethSet.AssertNotCalled(t, "CreateWallet", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	added, err := wlt.LinkCoins(util.ConstantPassword(testPassword), 0)
+	require.NoError(t, err)
+	require.Len(t, added, 1)
+	require.Equal(t, "ETH", added[0].Ticker)
+	require.Equal(t, uint32(60), added[0].CoinType)
+
+	links, err := wlt.Links()
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	require.Len(t, wlt.LinkedWallets(), 2)
+
+	added, err = wlt.LinkCoins(util.ConstantPassword(testPassword), 0)
+	require.NoError(t, err)
+	require.Empty(t, added)
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"errors"
+	"os"
 	"strconv"
 
 	"github.com/fibercrypto/fibercryptowallet/src/core"
@@ -18,16 +19,72 @@ func Min(a, b int) int {
 	return b
 }
 
+// GetCoinValue reads an exact decimal amount of asset represented by ticker in base units.
+// Values with more decimal places than asset accuracy are rejected.
 func GetCoinValue(value string, ticker string) (uint64, error) {
-	accuracy, err := AltcoinQuotient(ticker)
+	amount, err := ParseAmount(value, ticker)
 	if err != nil {
 		return uint64(0), err
 	}
-	coin, err2 := strconv.ParseFloat(value, 64)
-	if err2 != nil {
-		return uint64(0), err2
+	return amount.Coins, nil
+}
+
+// ParseAmount reads an exact decimal amount of asset represented by ticker
+func ParseAmount(value string, ticker string) (core.Amount, error) {
+	accuracy, err := AltcoinAccuracy(ticker)
+	if err != nil {
+		return core.Amount{}, err
+	}
+	return core.ParseAmount(ticker, value, accuracy)
+}
+
+// ParseAmountLocale reads an exact decimal amount of asset represented by ticker formatted according to locale
+func ParseAmountLocale(value string, ticker string, locale core.AmountLocale) (core.Amount, error) {
+	accuracy, err := AltcoinAccuracy(ticker)
+	if err != nil {
+		return core.Amount{}, err
 	}
-	return uint64(coin * float64(accuracy)), nil
+	return core.ParseAmountLocale(ticker, value, accuracy, locale)
+}
+
+// FormatAmount renders amount according to the accuracy of its asset as read by ParseAmount, e.g. 1234.5
+func FormatAmount(amount core.Amount) (string, error) {
+	accuracy, err := AltcoinAccuracy(amount.Ticker)
+	if err != nil {
+		return "", err
+	}
+	return amount.Format(accuracy), nil
+}
+
+// FormatAmountLocale renders amount according to the accuracy of its asset and locale
+func FormatAmountLocale(amount core.Amount, locale core.AmountLocale) (string, error) {
+	accuracy, err := AltcoinAccuracy(amount.Ticker)
+	if err != nil {
+		return "", err
+	}
+	return amount.FormatLocale(accuracy, locale), nil
+}
+
+var userAmountLocale *core.AmountLocale
+
+// SetUserAmountLocale presents amounts to users according to locale,
+// so that user interfaces parsing and formatting numbers on their own agree with models
+func SetUserAmountLocale(locale core.AmountLocale) {
+	userAmountLocale = &locale
+}
+
+// UserAmountLocale presents amounts to users according to the locale set by SetUserAmountLocale,
+// or else the language set in LC_ALL, LC_NUMERIC or LANG environment variables
+func UserAmountLocale() core.AmountLocale {
+	if userAmountLocale != nil {
+		return *userAmountLocale
+	}
+	for _, name := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		if tag := os.Getenv(name); tag != "" {
+			return core.LookupAmountLocale(tag)
+		}
+	}
+	return core.DefaultAmountLocale
 }
 
 func FormatUint64(n uint64) string {
@@ -47,22 +104,8 @@ func FormatUint64(n uint64) string {
 }
 
 func FormatCoins(n uint64, quotient uint64) string {
-	if n == uint64(0) {
-		return "0"
-	}
-
-	number := strconv.FormatUint(n, 10)
-	lenQ := len(strconv.FormatUint(quotient, 10)) - 1
-	nFormatted := FormatUint64(n / quotient)
-	if lenQ > len(number) {
-		return strconv.FormatFloat(float64(n)/float64(quotient), 'f', -1, 64)
-	}
-	reminder := number[len(number)-lenQ:]
-	reminder = RemoveZeros(reminder)
-	if len(reminder) == 0 {
-		return nFormatted
-	}
-	return nFormatted + "." + reminder
+	accuracy := int32(len(strconv.FormatUint(quotient, 10)) - 1)
+	return core.NewAmount("", n).FormatLocale(accuracy, core.DefaultAmountLocale)
 }
 
 func RemoveZeros(s string) string {
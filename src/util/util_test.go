@@ -144,7 +144,8 @@ func TestGetCoinValue(t *testing.T) {
 		{name: "invalidGetCoinValue1", value: "10", ticker: "MYCOIN", valid: false, want: uint64(0)},
 		{name: "invalidGetCoinValue2", value: "coin", ticker: fakeTicker, valid: false, want: uint64(0)},
 		{name: "validGetCoinValue1", value: "10", ticker: fakeTicker, valid: true, want: uint64(10000)},
-		{name: "validGetCoinValue2", value: "12.123456", ticker: fakeTicker, valid: true, want: uint64(12123)},
+		{name: "validGetCoinValue2", value: "12.123", ticker: fakeTicker, valid: true, want: uint64(12123)},
+		{name: "invalidGetCoinValue3", value: "12.123456", ticker: fakeTicker, valid: false, want: uint64(0)},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {